@@ -0,0 +1,296 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// inputTail is the part of a stream's input a converter still reads: the
+// frames it keeps as history before its read position, the frame under it and
+// the frames it buffered past it, with the position and the state of the
+// stream that a converter taking over must carry on.
+type inputTail struct {
+	frames   []float32 // Interleaved; a view of the converter's buffer where possible
+	channels int
+	current  int     // Frame under the read position; len(frames)/channels when none is buffered
+	position float64 // Read position past frame current, see srcState.lastPosition
+	ratio    float64 // Ratio of the last output frame, 0 before the first
+
+	filtered  bool  // See srcState.filtered; false when nothing was buffered
+	fadePos   int64 // Output frames so far, for Options.FadeFrames
+	trimLeft  int64 // Output frames still to drop for Options.TrimLeadingTransient
+	trimKnown bool
+	rational  rationalRatio
+}
+
+// AdoptTail makes the converter carry on the stream of from: it copies the
+// input history its own filter needs before from's read position (the last
+// halfFilterChanLen samples for the sinc converters, one frame for Linear and
+// ZeroOrderHold), the input from buffered past it, the position and the ratio.
+func (state *srcState) AdoptTail(from Converter) error {
+	if state == nil {
+		return mapError(ErrBadState)
+	}
+	return adoptTail(state, from)
+}
+
+// AdoptTail makes every group carry on the matching channels of from's stream.
+func (g *channelGroups) AdoptTail(from Converter) error {
+	if err := adoptTail(g, from); err != nil {
+		return g.fail(err)
+	}
+	return nil
+}
+
+// AdoptTail makes the inner converter carry on the stream of from, or of from's
+// inner converter when from is a channel mapping too.
+func (m *channelMapper) AdoptTail(from Converter) error {
+	if err := adoptTail(m, from); err != nil {
+		return m.fail(err)
+	}
+	return nil
+}
+
+// adoptTail takes the tail of from and installs it in c. On error c is left as
+// it was.
+func adoptTail(c, from Converter) error {
+	if from == nil || from == c {
+		return mapError(ErrBadState)
+	}
+	t, errCode := tailOf(from)
+	if errCode == ErrNoError {
+		errCode = installTail(c, &t, false)
+	}
+	if errCode == ErrNoError {
+		errCode = installTail(c, &t, true)
+	}
+	return mapError(errCode)
+}
+
+// tailOf returns the input tail of c.
+func tailOf(c Converter) (inputTail, ErrorCode) {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.tail()
+	case *channelGroups:
+		return conv.tail()
+	case *channelMapper:
+		t, errCode := tailOf(conv.inner)
+		t.fadePos = conv.post.fadePos
+		return t, errCode
+	}
+	return inputTail{}, ErrBadConverter
+}
+
+// installTail checks that c can take over t or, when apply is set, resets c
+// and installs t, which it has checked before.
+func installTail(c Converter, t *inputTail, apply bool) ErrorCode {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.installTail(t, apply)
+	case *channelGroups:
+		if t.channels != conv.channels {
+			return ErrBadChannelCount
+		}
+		if apply {
+			if err := conv.Reset(); err != nil {
+				return ErrBadState
+			}
+			conv.post.fadePos = t.fadePos
+		}
+		first := 0
+		for i, state := range conv.groups {
+			part := t.channelRange(first, conv.widths[i])
+			if errCode := state.installTail(&part, apply); errCode != ErrNoError {
+				return errCode
+			}
+			first += conv.widths[i]
+		}
+		return ErrNoError
+	case *channelMapper:
+		if errCode := installTail(conv.inner, t, apply); errCode != ErrNoError || !apply {
+			return errCode
+		}
+		conv.clock = clockEstimator{}
+		conv.post.reset()
+		conv.post.fadePos = t.fadePos
+		conv.lastErr = nil
+		return ErrNoError
+	}
+	return ErrBadConverter
+}
+
+// tail returns the input tail of the converter. The frames are a view of its
+// buffer, valid until it processes again.
+func (state *srcState) tail() (inputTail, ErrorCode) {
+	if state.vt == nil {
+		return inputTail{}, ErrBadState // Closed
+	}
+	if state.mode == ModeCallback {
+		return inputTail{}, ErrBadMode
+	}
+	if state.flushing {
+		return inputTail{}, ErrBadState // The stream ended, there is nothing to carry on
+	}
+	t := inputTail{
+		channels:  state.channels,
+		position:  state.lastPosition,
+		ratio:     state.lastRatio,
+		filtered:  state.filtered,
+		fadePos:   state.post.fadePos,
+		trimLeft:  state.trimLeft,
+		trimKnown: state.trimKnown,
+		rational:  state.rational,
+	}
+	switch filter := state.privateData.(type) {
+	case *sincFilter:
+		if filter.bCurrent == 0 {
+			break // Nothing loaded since the reset
+		}
+		// The samples before bCurrent are all input, or the silence before
+		// the first frame: prepareData keeps the buffer in order
+		if filter.bEnd < filter.bCurrent || filter.bEnd > int64(len(filter.buffer)) {
+			return inputTail{}, ErrBadInternalState
+		}
+		t.frames = filter.buffer[:filter.bEnd]
+		t.current = int(filter.bCurrent) / state.channels
+	case *linearFilter:
+		if filter.dirty {
+			t.frames = filter.lastValue
+		}
+	case *zohFilter:
+		if filter.dirty {
+			t.frames = filter.lastValue
+		}
+	default:
+		return inputTail{}, ErrBadConverter
+	}
+	return t, ErrNoError
+}
+
+// installTail checks that the converter can take over t or, when apply is set,
+// resets it and installs t.
+func (state *srcState) installTail(t *inputTail, apply bool) ErrorCode {
+	if state.vt == nil {
+		return ErrBadState
+	}
+	if state.mode == ModeCallback {
+		return ErrBadMode
+	}
+	if t.channels != state.channels {
+		return ErrBadChannelCount
+	}
+	ch := state.channels
+	ahead := len(t.frames)/ch - t.current // Frames from the read position on
+	switch filter := state.privateData.(type) {
+	case *sincFilter:
+		ratio := t.ratio
+		if isBadSrcRatio(ratio) {
+			ratio = 1.0
+		}
+		half := filter.halfChanLen(ch, ratio)
+		// The first Process call moves the read position by the whole frames
+		// of position, which must be buffered
+		if len(t.frames) > 0 && (int(t.position) > ahead || half+int64(ahead*ch) > filter.bLen) {
+			return ErrBadState
+		}
+		if !apply {
+			return ErrNoError
+		}
+		if err := state.Reset(); err != nil {
+			return ErrBadState
+		}
+		if len(t.frames) > 0 {
+			history := minInt(t.current, int(half)/ch)
+			lookback := filter.buffer[:half]
+			for i := range lookback {
+				lookback[i] = 0.0 // History from no longer holds is silence, as at the start
+			}
+			copy(lookback[half-int64(history*ch):], t.frames[(t.current-history)*ch:t.current*ch])
+			n := copy(filter.buffer[half:filter.bLen], t.frames[t.current*ch:])
+			filter.bCurrent, filter.bEnd = half, half+int64(n)
+		}
+	case *linearFilter, *zohFilter:
+		// These keep no input past the frame under the read position, so from
+		// must not have buffered any
+		if len(t.frames) > 0 && ahead != 1 {
+			return ErrBadState
+		}
+		if !apply {
+			return ErrNoError
+		}
+		if err := state.Reset(); err != nil {
+			return ErrBadState
+		}
+		if len(t.frames) > 0 {
+			last := t.frames[t.current*ch : (t.current+1)*ch]
+			if linear, ok := filter.(*linearFilter); ok {
+				copy(linear.lastValue, last)
+				linear.dirty = true
+			} else {
+				zoh := filter.(*zohFilter)
+				copy(zoh.lastValue, last)
+				zoh.dirty = true
+			}
+		}
+	default:
+		return ErrBadConverter
+	}
+	state.lastPosition, state.lastRatio = t.position, t.ratio
+	state.filtered = t.filtered
+	state.post.fadePos = t.fadePos
+	state.trimLeft, state.trimKnown = t.trimLeft, t.trimKnown
+	state.rational = t.rational
+	return ErrNoError
+}
+
+// tail merges the tails of the groups, which run in step, into one.
+func (g *channelGroups) tail() (inputTail, ErrorCode) {
+	if g.feeder != nil {
+		return inputTail{}, ErrBadMode
+	}
+	parts := make([]inputTail, len(g.groups))
+	for i, state := range g.groups {
+		part, errCode := state.tail()
+		if errCode != ErrNoError {
+			return inputTail{}, errCode
+		}
+		parts[i] = part
+	}
+	t := parts[0]
+	t.channels, t.fadePos = g.channels, g.post.fadePos
+	history, ahead := t.current, len(t.frames)/g.widths[0]-t.current
+	for i, part := range parts {
+		if len(part.frames)/g.widths[i]-part.current != ahead {
+			return inputTail{}, ErrBadInternalState
+		}
+		history = minInt(history, part.current)
+	}
+	t.current = history
+	t.frames = make([]float32, (history+ahead)*g.channels)
+	first := 0
+	for i, part := range parts {
+		width := g.widths[i]
+		src := part.frames[(part.current-history)*width:]
+		for f := 0; f < history+ahead; f++ {
+			copy(t.frames[f*g.channels+first:f*g.channels+first+width], src[f*width:(f+1)*width])
+		}
+		first += width
+	}
+	return t, ErrNoError
+}
+
+// channelRange returns the tail of width channels from first on.
+func (t *inputTail) channelRange(first, width int) inputTail {
+	part := *t
+	part.channels = width
+	frames := len(t.frames) / t.channels
+	part.frames = make([]float32, frames*width)
+	for f := 0; f < frames; f++ {
+		copy(part.frames[f*width:(f+1)*width], t.frames[f*t.channels+first:f*t.channels+first+width])
+	}
+	return part
+}
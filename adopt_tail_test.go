@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// convertWithHandoff converts in by blocks of 100 frames with from, hands the
+// stream over to to after handoff blocks and converts the rest with it. It
+// also returns the samples from gave.
+func convertWithHandoff(t *testing.T, from, to Converter, in []float32, channels, outChannels int, ratio float64, handoff int) (got []float32, before int) {
+	t.Helper()
+	before = -1
+	out := make([]float32, 1024*outChannels)
+	conv := from
+	frames := len(in) / channels
+	for pos, block := 0, 0; ; block++ {
+		if block == handoff {
+			if err := to.(TailAdopter).AdoptTail(from); err != nil {
+				t.Fatalf("AdoptTail failed: %v", err)
+			}
+			conv, before = to, len(got)
+		}
+		n := minInt(100, frames-pos)
+		data := SrcData{DataIn: in[pos*channels : (pos+n)*channels], InputFrames: int64(n), DataOut: out, OutputFrames: 1024, SrcRatio: ratio, EndOfInput: pos+n == frames}
+		if err := conv.Process(&data); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		got = append(got, out[:data.OutputFramesGen*int64(outChannels)]...)
+		pos += int(data.InputFramesUsed)
+		if data.EndOfInput && data.OutputFramesGen == 0 {
+			return got, before
+		}
+	}
+}
+
+// TestAdoptTail checks that a converter taking over a stream mid-flight gives
+// the output of one converter of its type running the whole stream.
+func TestAdoptTail(t *testing.T) {
+	type testCase struct {
+		from, to ConverterType
+		channels int
+		opts     Options
+	}
+	var cases []testCase
+	for _, ct := range []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, ZeroOrderHold, Linear} {
+		for _, channels := range []int{1, 2} {
+			cases = append(cases, testCase{from: ct, to: ct, channels: channels})
+		}
+	}
+	cases = append(cases,
+		// The better filter keeps more history than the faster one needs
+		testCase{from: SincBestQuality, to: SincFastest, channels: 1},
+		testCase{from: SincMediumQuality, to: SincFastest, channels: 2},
+		testCase{from: Linear, to: ZeroOrderHold, channels: 1},
+		testCase{from: SincFastest, to: SincFastest, channels: 2 * maxChannels},
+		testCase{from: SincFastest, to: SincFastest, channels: 2, opts: Options{OutputChannels: 1}},
+	)
+	// Not 0.37: the last frame of the drain would land on the end of the input,
+	// where the end test, on absolute buffer positions in floating point, may
+	// go either way
+	for _, ratio := range []float64{0.4137, 1.5} {
+		for _, tc := range cases {
+			name := fmt.Sprintf("%s_to_%s/%dch/%+v/ratio=%g", tc.from, tc.to, tc.channels, tc.opts, ratio)
+			outChannels := tc.channels
+			if tc.opts.OutputChannels > 0 {
+				outChannels = tc.opts.OutputChannels
+			}
+			in := multichannelTones(3000, tc.channels)
+			newConv := func(ct ConverterType) Converter {
+				c, err := NewWithOptions(ct, tc.channels, tc.opts)
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				return c
+			}
+			from, to, ref := newConv(tc.from), newConv(tc.to), newConv(tc.to)
+			want, _ := convertWithHandoff(t, ref, nil, in, tc.channels, outChannels, ratio, -1)
+			got, before := convertWithHandoff(t, from, to, in, tc.channels, outChannels, ratio, 12)
+			if tc.from != tc.to {
+				// The output before the handoff comes from the other type
+				got, want = got[before:], want[minInt(before, len(want)):]
+			}
+			checkSameSamples(t, name, got, want)
+			for _, c := range []Converter{from, to, ref} {
+				c.Close()
+			}
+		}
+	}
+}
+
+// TestAdoptTailErrors checks the handoffs AdoptTail refuses leave the converter
+// as it was.
+func TestAdoptTailErrors(t *testing.T) {
+	in := genSine(1000, 440, 16000, 0.5)
+	from, _ := New(SincFastest, 1)
+	defer from.Close()
+	data := SrcData{DataIn: in, InputFrames: 1000, DataOut: make([]float32, 2000), OutputFrames: 2000, SrcRatio: 1.5}
+	if err := from.Process(&data); err != nil {
+		t.Fatal(err)
+	}
+
+	linear, _ := New(Linear, 1)
+	defer linear.Close()
+	if err := linear.(TailAdopter).AdoptTail(from); !errors.Is(err, ErrBadState) {
+		t.Errorf("Linear taking over from a sinc converter: got %v, want %v", err, ErrBadState)
+	}
+	stereo, _ := New(SincFastest, 2)
+	defer stereo.Close()
+	if err := stereo.(TailAdopter).AdoptTail(from); !errors.Is(err, ErrBadChannelCount) {
+		t.Errorf("channel count mismatch: got %v, want %v", err, ErrBadChannelCount)
+	}
+	if err := from.(TailAdopter).AdoptTail(from); err == nil {
+		t.Error("expected error taking over from itself")
+	}
+	// A Converter implemented outside the package has no tail to hand over
+	outside := struct{ Converter }{from}
+	if err := stereo.(TailAdopter).AdoptTail(outside); !errors.Is(err, ErrBadConverter) {
+		t.Errorf("taking over from an outside converter: got %v, want %v", err, ErrBadConverter)
+	}
+	// Refused, the converter still converts from the start of a stream
+	want, _ := convertWithHandoff(t, linear, nil, in, 1, 1, 1.5, -1)
+	fresh, _ := New(Linear, 1)
+	defer fresh.Close()
+	got, _ := convertWithHandoff(t, fresh, nil, in, 1, 1, 1.5, -1)
+	checkSameSamples(t, "after refused handoff", got, want)
+
+	data = SrcData{DataOut: make([]float32, 2000), OutputFrames: 2000, SrcRatio: 1.5, EndOfInput: true}
+	if err := from.Process(&data); err != nil {
+		t.Fatal(err)
+	}
+	to, _ := New(SincFastest, 1)
+	defer to.Close()
+	if err := to.(TailAdopter).AdoptTail(from); err == nil {
+		t.Error("expected error taking over a draining stream")
+	}
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// Arena keeps scratch memory between requests (the float buffers and the
+// converter of a mix.Mixer created with MixerConfig.Arena and of the
+// conversions taking PCMOptions, including their flush loops), so a goroutine
+// serving many requests stops allocating them once it has seen its largest
+// request. Give each goroutine its own Arena and call Reset between requests.
+//
+// Buffers handed out by Floats are valid until the next Reset. The results
+// returned by the helpers are never taken from the arena and stay valid. A nil
+// *Arena is valid and allocates everything afresh.
+//
+// NOTE: An Arena is NOT goroutine-safe.
+type Arena struct {
+	floats     []float32 // Slab handed out by Floats
+	floatsUsed int
+	floatsWant int // Floats asked for since Reset; the slab grows to it
+	converters []arenaConverter
+}
+
+// arenaConverter is a converter kept by an Arena for reuse.
+type arenaConverter struct {
+	conv          Converter
+	converterType ConverterType
+	channels      int
+	inUse         bool
+}
+
+// NewArena creates an empty arena; it grows to fit the first requests.
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// Floats returns n zeroed float32 samples, valid until the next Reset.
+func (a *Arena) Floats(n int) []float32 {
+	if a == nil || n <= 0 {
+		return make([]float32, maxInt(n, 0))
+	}
+	a.floatsWant += n
+	if a.floatsUsed+n > len(a.floats) {
+		return make([]float32, n) // The slab grows at Reset
+	}
+	buf := a.floats[a.floatsUsed : a.floatsUsed+n : a.floatsUsed+n]
+	a.floatsUsed += n
+	for i := range buf {
+		buf[i] = 0
+	}
+	return buf
+}
+
+// Reset makes the memory handed out since the last Reset available again, so
+// the buffers returned by Floats must no longer be used. The slab grows here
+// to what the last request needed.
+func (a *Arena) Reset() {
+	if a == nil {
+		return
+	}
+	if a.floatsWant > len(a.floats) {
+		a.floats = make([]float32, a.floatsWant)
+	}
+	a.floatsUsed, a.floatsWant = 0, 0
+	for i := range a.converters {
+		a.converters[i].inUse = false
+	}
+}
+
+// Close releases the memory and closes the converters kept by the arena. The
+// arena can still be used afterwards and starts empty.
+func (a *Arena) Close() error {
+	if a == nil {
+		return nil
+	}
+	var firstErr error
+	for _, c := range a.converters {
+		if err := c.conv.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	*a = Arena{}
+	return firstErr
+}
+
+// Converter returns a reset converter of the given type and channel count,
+// reusing a free one kept by the arena. Hand it back with Release; it is free
+// again after Reset too. A nil arena creates a new converter.
+func (a *Arena) Converter(converterType ConverterType, channels int) (Converter, error) {
+	if a == nil {
+		return New(converterType, channels)
+	}
+	for i := range a.converters {
+		c := &a.converters[i]
+		if c.inUse || c.converterType != converterType || c.channels != channels {
+			continue
+		}
+		if err := c.conv.Reset(); err != nil {
+			return nil, err
+		}
+		c.inUse = true
+		return c.conv, nil
+	}
+	conv, err := New(converterType, channels)
+	if err != nil {
+		return nil, err
+	}
+	a.converters = append(a.converters, arenaConverter{conv: conv, converterType: converterType, channels: channels, inUse: true})
+	return conv, nil
+}
+
+// Release hands back a converter obtained from Converter. A converter the arena
+// does not keep, e.g. from a nil arena, is closed.
+func (a *Arena) Release(conv Converter) error {
+	if a != nil {
+		for i := range a.converters {
+			if a.converters[i].conv == conv {
+				a.converters[i].inUse = false
+				return nil
+			}
+		}
+	}
+	return conv.Close()
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+)
+
+func TestArenaFloats(t *testing.T) {
+	a := NewArena()
+	first := a.Floats(100)
+	second := a.Floats(50)
+	if len(first) != 100 || len(second) != 50 {
+		t.Fatalf("got %d and %d samples, want 100 and 50", len(first), len(second))
+	}
+	first[0], second[0] = 1, 2
+	a.Reset()
+	if len(a.floats) != 150 {
+		t.Errorf("slab of %d samples after a request for 150", len(a.floats))
+	}
+
+	reused := a.Floats(100)
+	for i, v := range reused {
+		if v != 0 {
+			t.Fatalf("reused sample %d = %g, want 0", i, v)
+		}
+	}
+	// Appending past a buffer must not overwrite the next one
+	next := a.Floats(50)
+	reused = append(reused, 9)
+	if next[0] != 0 {
+		t.Errorf("append to one buffer wrote %g into the next", next[0])
+	}
+
+	var none *Arena
+	if buf := none.Floats(8); len(buf) != 8 {
+		t.Errorf("nil arena gave %d samples, want 8", len(buf))
+	}
+	if buf := a.Floats(-1); len(buf) != 0 {
+		t.Errorf("negative size gave %d samples", len(buf))
+	}
+}
+
+func TestArenaConverters(t *testing.T) {
+	a := NewArena()
+	defer a.Close()
+	c1, err := a.Converter(SincFastest, 1)
+	if err != nil {
+		t.Fatalf("Converter failed: %v", err)
+	}
+	c2, _ := a.Converter(SincFastest, 1)
+	if c1 == c2 {
+		t.Fatal("converter in use handed out twice")
+	}
+	a.Release(c1)
+	if c3, _ := a.Converter(SincFastest, 1); c3 != c1 {
+		t.Error("released converter not reused")
+	}
+	if c4, _ := a.Converter(Linear, 1); c4 == c1 || c4 == c2 {
+		t.Error("converter of another type reused")
+	}
+	a.Reset()
+	if c5, _ := a.Converter(SincFastest, 1); c5 != c1 && c5 != c2 {
+		t.Error("converters not free after Reset")
+	}
+	if err := a.Close(); err != nil || len(a.converters) != 0 {
+		t.Errorf("Close left %d converters, err %v", len(a.converters), err)
+	}
+}
+
+// Output with an arena must match the plain calls, request after request.
+func TestArenaMatchesPlain(t *testing.T) {
+	a := NewArena()
+	defer a.Close()
+	for i, frames := range []int{160, 800, 320, 800} {
+		ulaw := encodeUlawTone(frames, 440*float64(i+1), 0.5)
+		want, err := ConvertUlawToPCM(ulaw, SincMediumQuality)
+		if err != nil {
+			t.Fatalf("ConvertUlawToPCM failed: %v", err)
+		}
+		got, err := ConvertUlawToPCMWithOptions(ulaw, SincMediumQuality, PCMOptions{Arena: a})
+		if err != nil {
+			t.Fatalf("ConvertUlawToPCMWithOptions failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("request %d: u-law conversion differs with the arena", i)
+		}
+
+		pcm := s16leTone(3*frames, 300, 24000, 0.4)
+		want, _ = Resample24kHzTo16kHz(pcm)
+		if got, err = Resample24kHzTo16kHzWithOptions(pcm, PCMOptions{Arena: a}); err != nil {
+			t.Fatalf("Resample24kHzTo16kHzWithOptions failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("request %d: resampling differs with the arena", i)
+		}
+		a.Reset()
+	}
+}
+
+func TestArenaReducesAllocations(t *testing.T) {
+	ulaw := encodeUlawTone(160, 440, 0.5)
+	request := func(a *Arena) {
+		if _, err := ConvertUlawToPCMWithOptions(ulaw, SincBestQuality, PCMOptions{Arena: a}); err != nil {
+			t.Fatal(err)
+		}
+		a.Reset()
+	}
+	allocated := func(a *Arena) uint64 {
+		request(a) // Warm up
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		for i := 0; i < 20; i++ {
+			request(a)
+		}
+		runtime.ReadMemStats(&after)
+		return after.TotalAlloc - before.TotalAlloc
+	}
+	a := NewArena()
+	defer a.Close()
+	plain, arena := allocated(nil), allocated(a)
+	if arena*10 > plain {
+		t.Errorf("allocated %d bytes with the arena, %d without; want a tenth at most", arena, plain)
+	}
+}
+
+func BenchmarkConvertUlawToPCMArena(b *testing.B) {
+	ulaw := encodeUlawTone(160, 440, 0.5)
+	a := NewArena()
+	defer a.Close()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ConvertUlawToPCMWithOptions(ulaw, SincBestQuality, PCMOptions{Arena: a}); err != nil {
+			b.Fatal(err)
+		}
+		a.Reset()
+	}
+}
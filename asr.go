@@ -0,0 +1,154 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// ASROptions holds the optional processing of PrepareASRAudioWithOptions and
+// ASRStream. The zero value only decodes and resamples.
+type ASROptions struct {
+	// HighPassHz, if positive, removes rumble and mains hum below it with a
+	// second-order Butterworth high-pass, e.g. 100. It must be below 4000.
+	HighPassHz float64
+	// GainDB scales the audio, e.g. +6 for quiet callers; the output is clipped
+	// at full scale.
+	GainDB float64
+}
+
+// ASRStream converts a call's 8kHz u-law audio to 16kHz S16LE PCM for a speech
+// recognizer chunk by chunk, e.g. one 20ms media frame at a time, the streaming
+// counterpart of PrepareASRAudio: the filter and resampler state is kept across
+// chunks, so they are joined without clicks and the concatenated output equals
+// that of PrepareASRAudioWithOptions on the whole stream.
+//
+// NOTE: An ASRStream is NOT goroutine-safe.
+type ASRStream struct {
+	conv     Converter
+	highPass *BiquadFilter // nil without ASROptions.HighPassHz
+	gain     float32
+	in       []float32
+	out      []float32
+}
+
+// NewASRStream creates an ASRStream with the given options.
+func NewASRStream(opts ASROptions) (*ASRStream, error) {
+	if math.IsNaN(opts.GainDB) || math.IsInf(opts.GainDB, 0) {
+		return nil, fmt.Errorf("gain must be finite, got %f", opts.GainDB)
+	}
+	s := &ASRStream{gain: float32(math.Pow(10.0, opts.GainDB/20.0))}
+	if opts.HighPassHz > 0 {
+		hp, err := NewHighPass(inputSampleRateUlaw, opts.HighPassHz, butterworthQ)
+		if err != nil {
+			return nil, fmt.Errorf("high-pass: %w", err)
+		}
+		s.highPass = hp
+	}
+	conv, err := New(SincMediumQuality, channelsUlaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize libsamplerate: %w", err)
+	}
+	s.conv = conv
+	return s, nil
+}
+
+// Write converts a chunk of u-law bytes and returns the 16kHz S16LE PCM ready
+// so far, which the caller owns. Because of the filter delay the first chunks
+// return slightly less than twice their length; Flush returns the remainder.
+func (s *ASRStream) Write(ulaw []byte) ([]byte, error) {
+	return s.process(ulaw, false)
+}
+
+// Flush returns the audio still held in the resampler, at the end of the
+// utterance or call, and resets the stream for a new one.
+func (s *ASRStream) Flush() ([]byte, error) {
+	pcm, err := s.process(nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return pcm, s.Reset()
+}
+
+// Reset discards the buffered audio and filter state, for a new stream.
+func (s *ASRStream) Reset() error {
+	if s.highPass != nil {
+		s.highPass.Reset()
+	}
+	return s.conv.Reset()
+}
+
+// Close releases the resampler.
+func (s *ASRStream) Close() error {
+	return s.conv.Close()
+}
+
+func (s *ASRStream) process(ulaw []byte, endOfInput bool) ([]byte, error) {
+	s.in = growFloats(s.in, len(ulaw))
+	UlawToFloatArray(ulaw, s.in)
+	if s.highPass != nil {
+		s.highPass.Apply(s.in, channelsUlaw)
+	}
+	if s.gain != 1 {
+		for i := range s.in {
+			s.in[i] *= s.gain
+		}
+	}
+	data := SrcData{
+		DataIn:      s.in,
+		InputFrames: int64(len(s.in)),
+		SrcRatio:    outputSampleRatePCM / inputSampleRateUlaw,
+		EndOfInput:  endOfInput,
+	}
+	s.out = s.out[:0]
+	if err := ProcessAppend(s.conv, &data, &s.out); err != nil {
+		return nil, fmt.Errorf("ASR resampling failed: %w", err)
+	}
+	return appendPCMFloatToS16LEBytes(make([]byte, 0, len(s.out)*bytesPerOutputFrame), s.out), nil
+}
+
+// PrepareASRAudio converts 8kHz u-law from a phone call to 16kHz S16LE PCM, the
+// format most speech recognizers expect; the mirror of PreparePSTNAudio. Use
+// PrepareASRAudioWithOptions for a high-pass or gain, and ASRStream to convert
+// a live call chunk by chunk.
+//
+// Args:
+//
+//	ulaw: Slice of bytes containing u-law encoded audio data (8kHz).
+//
+// Returns:
+//
+//	A slice of bytes containing 16-bit little-endian PCM audio data (16kHz),
+//	or nil and an error if conversion fails.
+func PrepareASRAudio(ulaw []byte) ([]byte, error) {
+	return PrepareASRAudioWithOptions(ulaw, ASROptions{})
+}
+
+// PrepareASRAudioWithOptions is PrepareASRAudio with the optional high-pass and
+// gain of opts, applied before resampling.
+func PrepareASRAudioWithOptions(ulaw []byte, opts ASROptions) ([]byte, error) {
+	s, err := NewASRStream(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	if len(ulaw) == 0 {
+		return []byte{}, nil
+	}
+	pcm, err := s.Write(ulaw)
+	if err != nil {
+		return nil, err
+	}
+	rest, err := s.Flush()
+	if err != nil {
+		return nil, err
+	}
+	return append(pcm, rest...), nil
+}
@@ -0,0 +1,101 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// s16leLevelDB returns the RMS level in dBFS of S16LE PCM, skipping the first
+// skip samples.
+func s16leLevelDB(pcm []byte, skip int) float64 {
+	samples := make([]float32, len(pcm)/2)
+	DecodePCM(FormatS16LE, pcm, samples)
+	return 20 * math.Log10(rmsGo(samples[skip:]))
+}
+
+func TestPrepareASRAudio(t *testing.T) {
+	const frames = 8000
+	ulaw := encodeUlawTone(frames, 1000, 0.25)
+	want := ulawLevelDB(ulaw, 0)
+
+	pcm, err := PrepareASRAudio(ulaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(pcm) / 2; n < 2*frames-1 || n > 2*frames+1 {
+		t.Errorf("%d output samples, want %d within one", n, 2*frames)
+	}
+	if level := s16leLevelDB(pcm, 320); math.Abs(level-want) > 0.2 {
+		t.Errorf("level %.2fdBFS, want the input's %.2f", level, want)
+	}
+
+	pcm, err = PrepareASRAudioWithOptions(ulaw, ASROptions{GainDB: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level := s16leLevelDB(pcm, 320); math.Abs(level-want-6) > 0.2 {
+		t.Errorf("with +6dB gain: level %.2fdBFS, want %.2f", level, want+6)
+	}
+
+	hum := encodeUlawTone(frames, 50, 0.25)
+	pcm, err = PrepareASRAudioWithOptions(hum, ASROptions{HighPassHz: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level := s16leLevelDB(pcm, 1600); level > ulawLevelDB(hum, 0)-20 {
+		t.Errorf("50Hz hum through a 200Hz high-pass at %.2fdBFS, want 20dB down", level)
+	}
+
+	if pcm, err := PrepareASRAudio(nil); err != nil || len(pcm) != 0 {
+		t.Errorf("empty input: %d bytes, %v", len(pcm), err)
+	}
+	if _, err := NewASRStream(ASROptions{HighPassHz: 5000}); err == nil {
+		t.Error("high-pass above Nyquist: no error")
+	}
+	if _, err := NewASRStream(ASROptions{GainDB: math.Inf(1)}); err == nil {
+		t.Error("infinite gain: no error")
+	}
+}
+
+// TestASRStreamChunks checks that 20ms chunks give the same PCM as one call,
+// and that Flush starts a new stream.
+func TestASRStreamChunks(t *testing.T) {
+	opts := ASROptions{HighPassHz: 100, GainDB: 3}
+	ulaw := encodeUlawTone(8000, 440, 0.3)
+	want, err := PrepareASRAudioWithOptions(ulaw, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewASRStream(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for stream := 0; stream < 2; stream++ {
+		var got []byte
+		for start := 0; start < len(ulaw); start += 160 {
+			pcm, err := s.Write(ulaw[start:minInt(start+160, len(ulaw))])
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, pcm...)
+		}
+		rest, err := s.Flush()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got = append(got, rest...); !bytes.Equal(got, want) {
+			t.Errorf("stream %d: chunked output (%d bytes) differs from one call (%d bytes)", stream, len(got), len(want))
+		}
+	}
+}
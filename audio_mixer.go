@@ -19,21 +19,26 @@ const (
 	mixInput24kHzSampleRate  = 24000.0
 	mixInput16kHzSampleRate  = 16000.0
 	mixOutputMuLawSampleRate = 8000.0
-	mixChannels              = 1   // Assuming Mono input/output
-	mixBytesPerInputFrame    = 2   // S16LE
-	mixBytesPerOutputFrame   = 1   // u-Law
-	mixFactorDefault         = 0.6 // Default mix factor
+	mixChannels              = 1           // Assuming Mono input/output
+	mixInputFormat           = FormatS16LE // Mixer input samples
+	mixBytesPerInputFrame    = 2           // S16LE mono, see BytesPerFrame
+	mixBytesPerOutputFrame   = 1           // u-Law
+	mixFactorDefault         = 0.6         // Default mix factor
 )
 
 // --- Helper: S16LE Bytes to int16 ---
 func bytesToS16LEGo(buffer []byte, byteIndex int) (int16, error) {
+	return bytesToS16Go(buffer, byteIndex, binary.LittleEndian)
+}
+
+// --- Helper: S16 Bytes in the given byte order to int16 ---
+func bytesToS16Go(buffer []byte, byteIndex int, order binary.ByteOrder) (int16, error) {
 	// Bounds check
 	if byteIndex < 0 || byteIndex+1 >= len(buffer) {
 		// Return error instead of printing warning like C++ helper
-		return 0, fmt.Errorf("bytesToS16LEGo read out of bounds: index %d for buffer size %d", byteIndex, len(buffer))
+		return 0, fmt.Errorf("bytesToS16Go read out of bounds: index %d for buffer size %d", byteIndex, len(buffer))
 	}
-	// Read 2 bytes using LittleEndian encoding
-	return int16(binary.LittleEndian.Uint16(buffer[byteIndex:])), nil
+	return int16(order.Uint16(buffer[byteIndex:])), nil
 }
 
 // --- Helper: int16 to float32 [-1.0, 1.0) ---
@@ -55,10 +60,10 @@ func linearToUlawGo(pcmVal int16) byte {
 	var pcmMag int // Use int for intermediate magnitude calculations
 
 	if pcmVal < 0 {
-		sign = 0
-		pcmMag = int(-pcmVal)
+		sign = 0x80           // Cleared by the inversion below: G.711 codes negatives as 0x00-0x7F
+		pcmMag = -int(pcmVal) // Negate as int: -(-32768) overflows int16
 	} else {
-		sign = 0x80
+		sign = 0
 		pcmMag = int(pcmVal)
 	}
 
@@ -112,6 +117,8 @@ func ulawToLinearGo(ulawVal byte) int16 {
 // MixResampleUlaw24to8 mixes two S16LE 24kHz PCM audio streams, resamples to 8kHz,
 // and converts to u-Law. It updates lastSample2MixedPos with the index of the
 // last sample used from pcmStream2 + 1 (wrapping if necessary).
+// The 24 in the name is the 24kHz input rate; the samples are 16-bit (see
+// ResampleS24LE for 24-bit PCM).
 //
 // Args:
 //
@@ -124,6 +131,8 @@ func ulawToLinearGo(ulawVal byte) int16 {
 // Returns:
 //
 //	A byte slice containing the resulting 8kHz u-Law audio data, or nil and an error.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixResampleUlaw24to8(
 	pcmStream1, pcmStream2 []byte,
 	lastSample2MixedPos *int, // Pointer to track position
@@ -135,6 +144,8 @@ func MixResampleUlaw24to8(
 // MixResampleUlaw16to8 mixes two S16LE 16kHz PCM audio streams, resamples to 8kHz,
 // and converts to u-Law. It updates lastSample2MixedPos with the index of the
 // last sample used from pcmStream2 + 1 (wrapping if necessary).
+// The 16 in the name is the 16kHz input rate; the samples are 16-bit (see
+// ResampleS24LE for 24-bit PCM).
 //
 // Args:
 //
@@ -147,6 +158,8 @@ func MixResampleUlaw24to8(
 // Returns:
 //
 //	A byte slice containing the resulting 8kHz u-Law audio data, or nil and an error.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixResampleUlaw16to8(
 	pcmStream1, pcmStream2 []byte,
 	lastSample2MixedPos *int, // Pointer to track position
@@ -171,6 +184,8 @@ func MixResampleUlaw16to8(
 // Returns:
 //
 //	A byte slice containing the resulting mixed 8kHz mu-Law audio data, or an error.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixUlaw8kHz(stream1, stream2 []byte, lastPosStream2 *int, mixFactor float32) ([]byte, error) {
 	if mixFactor < 0.0 || mixFactor > 1.0 {
 		return nil, fmt.Errorf("mixFactor must be between 0.0 and 1.0, got %f", mixFactor)
@@ -178,67 +193,36 @@ func MixUlaw8kHz(stream1, stream2 []byte, lastPosStream2 *int, mixFactor float32
 	if lastPosStream2 == nil {
 		return nil, fmt.Errorf("lastPosStream2 pointer must not be nil")
 	}
-
-	len1 := len(stream1)
-	len2 := len(stream2)
-
-	if len1 == 0 {
+	if len(stream1) == 0 {
 		return []byte{}, nil // Nothing to process
 	}
+	result := make([]byte, len(stream1))
+	*lastPosStream2 = mixUlawLoop(result, stream1, stream2, *lastPosStream2, mixFactor)
+	return result, nil
+}
 
-	// Validate and adjust starting position for stream 2
-	startPos2 := *lastPosStream2 + 1
-	if len2 > 0 { // Only wrap if stream 2 has frames
-		if startPos2 < 0 || startPos2 >= len2 {
-			startPos2 = 0 // Wrap around
-		}
-	} else {
-		startPos2 = 0 // If stream 2 is empty, always start at 0 conceptually
-	}
-
-	result := make([]byte, len1)
-	i2 := startPos2 // Current index for stream 2
-
-	for i1 := 0; i1 < len1; i1++ {
-		var pcm1, pcm2 int16
-
-		// Decode sample from stream 1
-		pcm1 = ulawToLinearGo(stream1[i1])
-
-		// Decode sample from stream 2, or use silence (0) if stream is empty
-		if len2 > 0 {
-			pcm2 = ulawToLinearGo(stream2[i2])
-		}
-
+// mixUlawBlock mixes the u-Law stream1 with background (decoded samples in the int16
+// range, one per stream1 sample) and encodes the result back to u-Law.
+func mixUlawBlock(stream1 []byte, background []float32, mixFactor float32) []byte {
+	result := make([]byte, len(stream1))
+	for i1, b := range stream1 {
 		// Mix the samples as float32 to apply the factor accurately
-		mixedPcmFloat := float32(pcm1)*mixFactor + float32(pcm2)*mixFactor
+		mixed := float32(ulawDecodeTable[b])*mixFactor + background[i1]*mixFactor
 
 		// Clip the mixed sample to the int16 range to prevent overflow
-		if mixedPcmFloat > 32767.0 {
-			mixedPcmFloat = 32767.0
-		} else if mixedPcmFloat < -32768.0 {
-			mixedPcmFloat = -32768.0
-		}
-
-		// Convert back to int16 and encode the final sample back to mu-Law
-		result[i1] = linearToUlawGo(int16(mixedPcmFloat))
-
-		// Advance and wrap stream 2 index
-		if len2 > 0 {
-			i2++
-			if i2 >= len2 {
-				i2 = 0 // Wrap around
-			}
+		if mixed > 32767.0 {
+			mixed = 32767.0
+		} else if mixed < -32768.0 {
+			mixed = -32768.0
 		}
+		result[i1] = linearToUlawGo(int16(mixed))
 	}
-
-	// Update the position pointer with the *next* index to be used from stream 2
-	*lastPosStream2 = i2
-
-	return result, nil
+	return result
 }
 
 // MixUlaw8kHzDefaultFactor is a wrapper for MixUlaw8kHz using the default mix factor.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixUlaw8kHzDefaultFactor(stream1, stream2 []byte, lastPosStream2 *int) ([]byte, error) {
 	return MixUlaw8kHz(stream1, stream2, lastPosStream2, mixFactorDefault)
 }
@@ -260,49 +244,142 @@ func MixUlaw8kHzDefaultFactor(stream1, stream2 []byte, lastPosStream2 *int) ([]b
 // Returns:
 //
 //	A byte slice containing the resulting 8kHz u-Law audio data, or nil and an error.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixResampleUlawWithRatio(
 	pcmStream1, pcmStream2 []byte,
 	lastSample2MixedPos *int, // Pointer to track position
 	srcRatio float64,
 	mixFactor float32,
 ) ([]byte, error) {
+	resultFloat, err := mixResampleFloat(pcmStream1, pcmStream2, lastSample2MixedPos, srcRatio, mixFactor)
+	if err != nil {
+		return nil, err
+	}
+	return appendPCMFloatToUlawBytes(make([]byte, 0, len(resultFloat)), resultFloat), nil
+}
+
+// MixResampleFloat32 mixes two S16LE PCM streams like MixResampleUlawWithRatio but
+// returns the resampled mix as float32 samples, without quantizing to u-law or int16.
+// The samples are not clipped, so they may exceed [-1.0, 1.0] if the mix is hot.
+//
+// Args:
+//
+//	pcmStream1: Byte slice containing the first S16LE PCM stream (the output is as long as this stream).
+//	pcmStream2: Byte slice containing the second S16LE PCM stream, looped as needed.
+//	lastSample2MixedPos: Pointer to the read position in pcmStream2, updated on success.
+//	srcRatio: The resampling ratio (output rate / input rate), e.g. 16000.0/24000.0.
+//	mixFactor: The scaling factor applied to each stream before adding (0.0 to 1.0).
+//
+// Returns:
+//
+//	The resampled mono float32 samples, or nil and an error.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
+func MixResampleFloat32(
+	pcmStream1, pcmStream2 []byte,
+	lastSample2MixedPos *int,
+	srcRatio float64,
+	mixFactor float32,
+) ([]float32, error) {
+	return mixResampleFloat(pcmStream1, pcmStream2, lastSample2MixedPos, srcRatio, mixFactor)
+}
+
+// MixResampleF32LE is MixResampleFloat32 returning 32-bit little-endian float bytes,
+// ready to write as RAW or as the data chunk of a float WAV file.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
+func MixResampleF32LE(
+	pcmStream1, pcmStream2 []byte,
+	lastSample2MixedPos *int,
+	srcRatio float64,
+	mixFactor float32,
+) ([]byte, error) {
+	resultFloat, err := MixResampleFloat32(pcmStream1, pcmStream2, lastSample2MixedPos, srcRatio, mixFactor)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(resultFloat)*FormatF32LE.BytesPerSample())
+	if _, err := EncodePCM(FormatF32LE, resultFloat, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// mixResampleFloat mixes stream 1 with (looped) stream 2 via resampleMix, updating
+// the stream 2 position.
+func mixResampleFloat(
+	pcmStream1, pcmStream2 []byte,
+	lastSample2MixedPos *int,
+	srcRatio float64,
+	mixFactor float32,
+) ([]float32, error) {
 	// --- Input Validation ---
-	if len(pcmStream1)%mixBytesPerInputFrame != 0 {
-		return nil, fmt.Errorf("input stream 1 size (%d) not multiple of frame size (%d)", len(pcmStream1), mixBytesPerInputFrame)
+	frames1, err := BytesToFrames(mixInputFormat, mixChannels, len(pcmStream1))
+	if err != nil {
+		return nil, fmt.Errorf("input stream 1: %w", err)
 	}
-	if len(pcmStream2)%mixBytesPerInputFrame != 0 {
-		return nil, fmt.Errorf("input stream 2 size (%d) not multiple of frame size (%d)", len(pcmStream2), mixBytesPerInputFrame)
+	frames2, err := BytesToFrames(mixInputFormat, mixChannels, len(pcmStream2))
+	if err != nil {
+		return nil, fmt.Errorf("input stream 2: %w", err)
 	}
 	if lastSample2MixedPos == nil {
 		return nil, fmt.Errorf("lastSample2MixedPos pointer must not be nil")
 	}
 
-	frames1 := len(pcmStream1) / mixBytesPerInputFrame
-	frames2 := len(pcmStream2) / mixBytesPerInputFrame
 	totalInputFrames := frames1 // Process for the duration of stream 1
 
 	if totalInputFrames == 0 {
-		fmt.Println("MixResampleUlaw24to8: Warning: Input stream 1 is empty. Returning empty output.")
 		// Do not update lastSample2MixedPos if no processing happens
-		return []byte{}, nil
-	}
-	if frames2 == 0 {
-		fmt.Println("MixResampleUlaw24to8: Warning: Input stream 2 is empty. Mixing only stream 1.")
-		// Allow proceeding, but stream 2 samples will be 0.0
+		return []float32{}, nil
 	}
+	// An empty stream 2 is allowed, stream 1 is then mixed with silence
 
 	// Validate and adjust starting position for stream 2
 	startPos2 := *lastSample2MixedPos + 1
 	if frames2 > 0 { // Only wrap if stream 2 has frames
 		if startPos2 < 0 || startPos2 >= frames2 {
-			fmt.Printf("MixResampleUlaw24to8: Info: Wrapping stream 2 index (%d -> 0), frames2=%d\n", startPos2, frames2)
-			startPos2 = 0
+			startPos2 = 0 // Wrap around
 		}
 	} else {
 		startPos2 = 0 // If stream 2 is empty, always start at 0 conceptually
 	}
 	// fmt.Printf("MixResampleUlaw24to8: DEBUG: Mixing %d frames. Stream 2 starts at index %d (frames2=%d).\n", totalInputFrames, startPos2, frames2)
 
+	// Decode stream 2, looped, or use silence (0.0) if stream is empty
+	background := make([]float32, totalInputFrames)
+	i2 := startPos2 // Current index for stream 2
+	if frames2 > 0 {
+		for i1 := range background {
+			byteIndex2 := FramesToBytes(mixInputFormat, mixChannels, i2)
+			s16_2, err2 := bytesToS16LEGo(pcmStream2, byteIndex2)
+			if err2 != nil {
+				return nil, fmt.Errorf("error reading stream 2 at index %d: %w", byteIndex2, err2)
+			} // Should not happen
+			background[i1] = s16ToFloatGo(s16_2)
+
+			// Advance and wrap stream 2 index
+			i2++
+			if i2 >= frames2 {
+				i2 = 0 // Wrap around
+			}
+		}
+	}
+	// Update the position pointer with the *next* index to be used from stream 2
+	*lastSample2MixedPos = i2
+	// fmt.Printf("MixResampleUlaw24to8: DEBUG: Mixing complete. Next stream 2 index: %d\n", *lastSample2MixedPos)
+
+	return resampleMix(pcmStream1, background, srcRatio, mixFactor)
+}
+
+// resampleMix mixes the S16LE stream 1 with background (samples in [-1.0, 1.0),
+// one per stream 1 frame) and resamples the mix with SincBestQuality.
+func resampleMix(pcmStream1 []byte, background []float32, srcRatio float64, mixFactor float32) ([]float32, error) {
+	totalInputFrames := len(pcmStream1) / BytesPerFrame(mixInputFormat, mixChannels)
+	if totalInputFrames == 0 {
+		return []float32{}, nil
+	}
+
 	// --- libsamplerate Setup ---
 	//const srcRatio = mixOutputMuLawSampleRate / mixInput24kHzSampleRate // 1.0 / 3.0
 	var state Converter
@@ -319,48 +396,23 @@ func MixResampleUlawWithRatio(
 	mixedFloatBuffer := make([]float32, totalInputFrames*mixChannels)
 	estimatedOutputFrames := int64(math.Ceil(float64(totalInputFrames)*srcRatio)) + 20
 	outputFloatBuffer := make([]float32, estimatedOutputFrames*int64(mixChannels))
-	resultUlawVector := make([]byte, 0, estimatedOutputFrames*int64(mixChannels)) // Capacity only
+	resultFloat := make([]float32, 0, estimatedOutputFrames*int64(mixChannels)) // Capacity only
 
 	// --- Mixing ---
-	i2 := startPos2 // Current index for stream 2
 	for i1 := 0; i1 < totalInputFrames; i1++ {
-		byteIndex1 := i1 * mixBytesPerInputFrame
-		byteIndex2 := i2 * mixBytesPerInputFrame
-
-		var s16_1, s16_2 int16
-		var err1, err2 error
-		var sample1F, sample2F float32
+		byteIndex1 := FramesToBytes(mixInputFormat, mixChannels, i1)
 
 		// Stream 1 sample (always exists within loop bounds)
-		s16_1, err1 = bytesToS16LEGo(pcmStream1, byteIndex1)
+		s16_1, err1 := bytesToS16LEGo(pcmStream1, byteIndex1)
 		if err1 != nil {
 			return nil, fmt.Errorf("error reading stream 1 at index %d: %w", byteIndex1, err1)
 		} // Should not happen
-		sample1F = s16ToFloatGo(s16_1)
-
-		// Stream 2 sample (only if stream 2 has frames and index is valid)
-		if frames2 > 0 {
-			s16_2, err2 = bytesToS16LEGo(pcmStream2, byteIndex2)
-			if err2 != nil {
-				return nil, fmt.Errorf("error reading stream 2 at index %d: %w", byteIndex2, err2)
-			} // Should not happen
-			sample2F = s16ToFloatGo(s16_2)
-		} // else sample2F remains 0.0
+		sample1F := s16ToFloatGo(s16_1)
+		sample2F := background[i1]
 
 		// Mix and store (already scaled)
 		mixedFloatBuffer[i1] = sample1F*mixFactor + sample2F*mixFactor
-
-		// Advance and wrap stream 2 index
-		if frames2 > 0 { // Only advance if stream 2 has content
-			i2++
-			if i2 >= frames2 {
-				i2 = 0 // Wrap around
-			}
-		}
 	}
-	// Update the position pointer with the *next* index to be used from stream 2
-	*lastSample2MixedPos = i2
-	// fmt.Printf("MixResampleUlaw24to8: DEBUG: Mixing complete. Next stream 2 index: %d\n", *lastSample2MixedPos)
 
 	// --- Resampling ---
 	srcData := SrcData{
@@ -380,9 +432,9 @@ func MixResampleUlawWithRatio(
 	framesGenerated := srcData.OutputFramesGen
 	// fmt.Printf("MixResampleUlaw24to8: DEBUG: Resampling generated %d frames.\n", framesGenerated)
 
-	// --- Convert and Store Output (First Pass) ---
+	// --- Store Output (First Pass) ---
 	if framesGenerated > 0 {
-		resultUlawVector = appendPCMFloatToUlawBytes(resultUlawVector, outputFloatBuffer[:framesGenerated*int64(mixChannels)])
+		resultFloat = append(resultFloat, outputFloatBuffer[:framesGenerated*int64(mixChannels)]...)
 	}
 
 	// --- Flush Resampler ---
@@ -408,18 +460,17 @@ func MixResampleUlawWithRatio(
 			break // No more output from flush
 		}
 
-		resultUlawVector = appendPCMFloatToUlawBytes(resultUlawVector, outputFloatBuffer[:framesGenerated*int64(mixChannels)])
+		resultFloat = append(resultFloat, outputFloatBuffer[:framesGenerated*int64(mixChannels)]...)
 
 	}
 	// fmt.Printf("MixResampleUlaw24to8: DEBUG: Flushing generated additional %d frames.\n", totalFlushedFrames)
 
-	// fmt.Printf("MixResampleUlaw24to8: DEBUG: Processing complete. Total output u-Law bytes: %d\n", len(resultUlawVector))
-	return resultUlawVector, nil
+	return resultFloat, nil
 }
 
 // appendPCMFloatToUlawBytes converts float32 samples (already resampled)
 // to u-Law bytes and appends them to the destination slice.
-// Uses clamping and scaling by 32767 before u-Law encoding.
+// Encodes as FloatToUlawArray does.
 func appendPCMFloatToUlawBytes(dest []byte, src []float32) []byte {
 	// Ensure capacity if possible (though append handles reallocation)
 	if cap(dest)-len(dest) < len(src) {
@@ -430,22 +481,7 @@ func appendPCMFloatToUlawBytes(dest []byte, src []float32) []byte {
 	}
 
 	for _, sampleF := range src {
-		// Clamp float32 sample to [-1.0, 1.0]
-		if sampleF > 1.0 {
-			sampleF = 1.0
-		}
-		if sampleF < -1.0 {
-			sampleF = -1.0
-		}
-
-		// Scale to int16 range using 32767 and cast
-		sampleS16 := int16(sampleF * 32767.0)
-
-		// Convert int16 to u-Law byte
-		ulawByte := linearToUlawGo(sampleS16)
-
-		// Append the u-Law byte
-		dest = append(dest, ulawByte)
+		dest = append(dest, floatToUlawGo(sampleF))
 	}
 	return dest
 }
@@ -454,23 +490,34 @@ func appendPCMFloatToUlawBytes(dest []byte, src []float32) []byte {
 // and appends them to the destination slice.
 // Uses clamping and scaling by 32767 before encoding.
 func appendPCMFloatToS16LEBytes(dest []byte, src []float32) []byte {
+	dest, _ = appendPCMFloatToS16LEBytesCounted(dest, src)
+	return dest
+}
+
+// appendPCMFloatToS16LEBytesCounted is appendPCMFloatToS16LEBytes also returning
+// the number of samples clamped.
+func appendPCMFloatToS16LEBytesCounted(dest []byte, src []float32) ([]byte, int) {
 	// Pre-allocate a temporary 2-byte buffer to avoid allocation in the loop.
 	var buf [2]byte
 
 	// Grow destination slice once to avoid multiple reallocations.
-	if cap(dest)-len(dest) < len(src)*mixBytesPerInputFrame {
-		newDest := make([]byte, len(dest), len(dest)+len(src)*mixBytesPerInputFrame)
+	size := len(src) * FormatS16LE.BytesPerSample() // src counts samples, not frames
+	if cap(dest)-len(dest) < size {
+		newDest := make([]byte, len(dest), len(dest)+size)
 		copy(newDest, dest)
 		dest = newDest
 	}
 
+	clipped := 0
 	for _, sampleF := range src {
 		// Clamp float32 sample to [-1.0, 1.0]
 		clampedSample := sampleF
 		if clampedSample > 1.0 {
 			clampedSample = 1.0
+			clipped++
 		} else if clampedSample < -1.0 {
 			clampedSample = -1.0
+			clipped++
 		}
 
 		// Scale to int16 range using 32767 and cast
@@ -482,7 +529,7 @@ func appendPCMFloatToS16LEBytes(dest []byte, src []float32) []byte {
 		// Append the bytes
 		dest = append(dest, buf[:]...)
 	}
-	return dest
+	return dest, clipped
 }
 
 // Resample24kHzTo16kHz resamples a S16LE 24kHz PCM audio stream to 16kHz S16LE PCM.
@@ -495,40 +542,48 @@ func appendPCMFloatToS16LEBytes(dest []byte, src []float32) []byte {
 //
 //	A byte slice containing the resulting 16kHz S16LE PCM audio data, or nil and an error.
 func Resample24kHzTo16kHz(pcmStream24kHz []byte) ([]byte, error) {
+	return Resample24kHzTo16kHzWithOptions(pcmStream24kHz, PCMOptions{})
+}
+
+// Resample24kHzTo16kHzWithOptions is Resample24kHzTo16kHz with the byte order
+// (of both input and output), arena and clip reporting of opts.
+func Resample24kHzTo16kHzWithOptions(pcmStream24kHz []byte, opts PCMOptions) ([]byte, error) {
 	// --- Input Validation ---
-	if len(pcmStream24kHz)%mixBytesPerInputFrame != 0 {
-		return nil, fmt.Errorf("input stream size (%d) not multiple of frame size (%d)", len(pcmStream24kHz), mixBytesPerInputFrame)
+	totalInputFrames, err := BytesToFrames(mixInputFormat, mixChannels, len(pcmStream24kHz))
+	if err != nil {
+		return nil, fmt.Errorf("input stream: %w", err)
 	}
-
-	totalInputFrames := len(pcmStream24kHz) / mixBytesPerInputFrame
 	if totalInputFrames == 0 {
 		return []byte{}, nil // Return empty slice for empty input
 	}
 
 	// --- Convert input bytes to float32 buffer ---
-	inputFloatBuffer := make([]float32, totalInputFrames*mixChannels)
-	for i := 0; i < totalInputFrames; i++ {
-		byteIndex := i * mixBytesPerInputFrame
-		s16, err := bytesToS16LEGo(pcmStream24kHz, byteIndex)
-		if err != nil {
-			// This should be unreachable due to the length check above, but good practice.
-			return nil, fmt.Errorf("error reading input stream at index %d: %w", byteIndex, err)
-		}
-		inputFloatBuffer[i] = s16ToFloatGo(s16)
+	order := opts.order()
+	inputFloatBuffer := opts.Arena.Floats(totalInputFrames * mixChannels)
+	for i := range inputFloatBuffer {
+		byteIndex := FramesToBytes(mixInputFormat, mixChannels, i)
+		inputFloatBuffer[i] = s16ToFloatGo(int16(order.Uint16(pcmStream24kHz[byteIndex:])))
 	}
 
 	// --- libsamplerate Setup ---
 	const srcRatio = mixInput16kHzSampleRate / mixInput24kHzSampleRate // 16000.0 / 24000.0 = 2.0 / 3.0
-	state, err := New(SincBestQuality, mixChannels)
+	state, err := opts.Arena.Converter(SincBestQuality, mixChannels)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resampler: %w", err)
 	}
-	defer state.Close()
+	defer opts.Arena.Release(state)
 
-	return resampleStream(state, inputFloatBuffer, srcRatio)
+	out, clipped, err := resampleStream(state, inputFloatBuffer, srcRatio, order, opts.Arena)
+	if err != nil {
+		return nil, err
+	}
+	opts.reportClip(clipped, len(out)/FormatS16LE.BytesPerSample())
+	return out, nil
 }
 
 // MixResampleUlaw24to8DefaultFactor is an optional wrapper with default mix factor, but for 24kHz to 8kHz
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixResampleUlaw24to8DefaultFactor(
 	pcmStream1, pcmStream2 []byte,
 	lastSample2MixedPos *int,
@@ -537,6 +592,8 @@ func MixResampleUlaw24to8DefaultFactor(
 }
 
 // MixResampleUlaw16to8DefaultFactor is an optional wrapper with default mix factor, but for 16kHz to 8kHz
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix).
 func MixResampleUlaw16to8DefaultFactor(
 	pcmStream1, pcmStream2 []byte,
 	lastSample2MixedPos *int,
@@ -545,14 +602,15 @@ func MixResampleUlaw16to8DefaultFactor(
 }
 
 // resampleStream is a private helper to perform the core resampling and flushing logic.
-func resampleStream(state Converter, inputFloatBuffer []float32, srcRatio float64) ([]byte, error) {
+// It also returns the number of samples clamped.
+func resampleStream(state Converter, inputFloatBuffer []float32, srcRatio float64, order binary.ByteOrder, arena *Arena) ([]byte, int, error) {
 	totalInputFrames := len(inputFloatBuffer)
 
 	// --- Buffers ---
 	estimatedOutputFrames := int64(math.Ceil(float64(totalInputFrames)*srcRatio)) + 20
-	outputFloatBuffer := make([]float32, estimatedOutputFrames*int64(mixChannels))
+	outputFloatBuffer := arena.Floats(int(estimatedOutputFrames) * mixChannels)
 	// Estimate final byte slice capacity
-	resultBytes := make([]byte, 0, estimatedOutputFrames*int64(mixChannels)*mixBytesPerInputFrame)
+	resultBytes := make([]byte, 0, FramesToBytes(FormatS16LE, mixChannels, int(estimatedOutputFrames)))
 
 	// --- Resampling ---
 	srcData := SrcData{
@@ -565,14 +623,17 @@ func resampleStream(state Converter, inputFloatBuffer []float32, srcRatio float6
 	}
 
 	if err := state.Process(&srcData); err != nil {
-		return nil, fmt.Errorf("resampling process failed: %w", err)
+		return nil, 0, fmt.Errorf("resampling process failed: %w", err)
 	}
 
 	framesGenerated := srcData.OutputFramesGen
+	clipped, n := 0, 0
+	var byteBuf [2]byte
 
 	// --- Convert and Store Output (First Pass) ---
 	if framesGenerated > 0 {
-		resultBytes = appendPCMFloatToS16LEBytes(resultBytes, outputFloatBuffer[:framesGenerated*int64(mixChannels)])
+		resultBytes, n = appendFloatToBytesPCM16Counted(resultBytes, outputFloatBuffer[:framesGenerated*int64(mixChannels)], byteBuf[:], order)
+		clipped += n
 	}
 
 	// --- Flush Resampler ---
@@ -585,7 +646,7 @@ func resampleStream(state Converter, inputFloatBuffer []float32, srcRatio float6
 		srcData.OutputFramesGen = 0                          // Reset before call
 
 		if err := state.Process(&srcData); err != nil {
-			return nil, fmt.Errorf("resampling flush failed: %w", err)
+			return nil, 0, fmt.Errorf("resampling flush failed: %w", err)
 		}
 
 		framesGenerated = srcData.OutputFramesGen
@@ -593,8 +654,9 @@ func resampleStream(state Converter, inputFloatBuffer []float32, srcRatio float6
 			break // No more output from flush
 		}
 
-		resultBytes = appendPCMFloatToS16LEBytes(resultBytes, outputFloatBuffer[:framesGenerated*int64(mixChannels)])
+		resultBytes, n = appendFloatToBytesPCM16Counted(resultBytes, outputFloatBuffer[:framesGenerated*int64(mixChannels)], byteBuf[:], order)
+		clipped += n
 	}
 
-	return resultBytes, nil
+	return resultBytes, clipped, nil
 }
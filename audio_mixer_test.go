@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+)
+
+// TestMixResampleFloat32MatchesUlaw checks the float output is the u-law output
+// before quantization, and that the F32LE bytes encode the same samples.
+func TestMixResampleFloat32MatchesUlaw(t *testing.T) {
+	speech := corpus.MustReadFile(corpus.Speech24kS16LE)
+	typing := corpus.MustReadFile(corpus.Typing24kS16LE)
+	const ratio = 16000.0 / 24000.0
+
+	posUlaw, posFloat, posBytes := 100, 100, 100
+	ulaw, err := MixResampleUlawWithRatio(speech, typing, &posUlaw, ratio, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixResampleUlawWithRatio failed: %v", err)
+	}
+	floats, err := MixResampleFloat32(speech, typing, &posFloat, ratio, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixResampleFloat32 failed: %v", err)
+	}
+	f32le, err := MixResampleF32LE(speech, typing, &posBytes, ratio, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixResampleF32LE failed: %v", err)
+	}
+
+	if posFloat != posUlaw || posBytes != posUlaw {
+		t.Errorf("stream 2 positions differ: ulaw %d, float %d, f32le %d", posUlaw, posFloat, posBytes)
+	}
+	if want := int(math.Ceil(float64(len(speech)/2) * ratio)); math.Abs(float64(len(floats)-want)) > 2 {
+		t.Errorf("got %d float samples, want about %d", len(floats), want)
+	}
+	if requantized := appendPCMFloatToUlawBytes(nil, floats); !bytes.Equal(requantized, ulaw) {
+		t.Error("float output does not quantize to the u-law output")
+	}
+
+	if len(f32le) != len(floats)*4 {
+		t.Fatalf("got %d f32le bytes, want %d", len(f32le), len(floats)*4)
+	}
+	decoded := make([]float32, len(floats))
+	if _, err := DecodePCM(FormatF32LE, f32le, decoded); err != nil {
+		t.Fatalf("DecodePCM failed: %v", err)
+	}
+	for i := range floats {
+		if decoded[i] != floats[i] {
+			t.Fatalf("sample %d: f32le %g, float %g", i, decoded[i], floats[i])
+		}
+	}
+
+	// Sub-LSB detail survives in the float output
+	fractional := 0
+	for _, v := range floats {
+		if s := float64(v) * 32768; s != math.Trunc(s) {
+			fractional++
+		}
+	}
+	if fractional < len(floats)/2 {
+		t.Errorf("only %d of %d samples carry sub-int16 precision", fractional, len(floats))
+	}
+}
+
+func TestMixResampleFloat32Errors(t *testing.T) {
+	pos := 0
+	if _, err := MixResampleFloat32(make([]byte, 3), nil, &pos, 0.5, 0.5); err == nil {
+		t.Error("expected error for odd stream 1 size")
+	}
+	if _, err := MixResampleF32LE(make([]byte, 4), nil, nil, 0.5, 0.5); err == nil {
+		t.Error("expected error for nil position")
+	}
+}
+
+// TestLinearToUlawFullScale checks the most negative int16, which the mixers
+// clip to, encodes as full scale rather than overflowing.
+func TestLinearToUlawFullScale(t *testing.T) {
+	for _, v := range []int16{-32768, -32767, 32767} {
+		if got := ulawToLinearInt16Go(linearToUlawGo(v)); math.Abs(float64(got)) < 32000 || (got < 0) != (v < 0) {
+			t.Errorf("%d encodes to a u-law code decoding to %d", v, got)
+		}
+	}
+}
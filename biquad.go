@@ -0,0 +1,31 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// biquad is a second-order IIR section (Direct Form I), normalized so a0 == 1.
+type biquad struct {
+	b0, b1, b2 float64
+	a1, a2     float64
+}
+
+// biquadState holds the delay line of a biquad for one channel.
+type biquadState struct {
+	x1, x2 float64
+	y1, y2 float64
+}
+
+// process filters a single sample through the section, updating the delay line.
+func (bq *biquad) process(st *biquadState, x float64) float64 {
+	y := bq.b0*x + bq.b1*st.x1 + bq.b2*st.x2 - bq.a1*st.y1 - bq.a2*st.y2
+	st.x2 = st.x1
+	st.x1 = x
+	st.y2 = st.y1
+	st.y1 = y
+	return y
+}
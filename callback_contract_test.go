@@ -0,0 +1,268 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+// eofSource returns its data in chunks and io.EOF together with the last chunk.
+type eofSource struct {
+	data       []float32
+	pos        int
+	chunkLen   int
+	callsAfter int // Calls made after io.EOF was returned
+	eofSent    bool
+}
+
+func eofSourceCallback(userData interface{}) ([]float32, int64, error) {
+	s := userData.(*eofSource)
+	if s.eofSent {
+		s.callsAfter++
+		return nil, 0, io.EOF
+	}
+	end := minInt(s.pos+s.chunkLen, len(s.data))
+	chunk := s.data[s.pos:end]
+	s.pos = end
+	if s.pos >= len(s.data) {
+		s.eofSent = true
+		return chunk, int64(len(chunk)), io.EOF
+	}
+	return chunk, int64(len(chunk)), nil
+}
+
+// TestCallbackReadEOF checks io.EOF is end of input, not an error, and that the
+// data returned with it is used.
+func TestCallbackReadEOF(t *testing.T) {
+	for _, ct := range []ConverterType{SincFastest, Linear, ZeroOrderHold} {
+		t.Run(GetName(ct), func(t *testing.T) {
+			input := make([]float32, 5000)
+			genWindowedSinesGo(1, []float64{0.05}, 0.9, input)
+			src := &eofSource{data: input, chunkLen: 1000}
+
+			c, err := CallbackNew(eofSourceCallback, ct, 1, src)
+			if err != nil {
+				t.Fatalf("CallbackNew failed: %v", err)
+			}
+			const ratio = 2.0
+			out := readAllCallback(t, c, ratio, 256)
+
+			if src.callsAfter != 0 {
+				t.Errorf("callback called %d times after io.EOF", src.callsAfter)
+			}
+			expected := float64(len(input)) * ratio
+			if math.Abs(float64(len(out))-expected) > expected*0.01+10 {
+				t.Errorf("output frames %d, want about %.0f (last chunk lost?)", len(out), expected)
+			}
+
+			// Still drained on later reads, without calling the callback again
+			n, err := CallbackRead(c, ratio, 10, make([]float32, 10))
+			if n != 0 || err != nil {
+				t.Errorf("read after drain: n=%d err=%v, want 0, nil", n, err)
+			}
+
+			// Reset re-arms the callback
+			if err := c.Reset(); err != nil {
+				t.Fatalf("Reset failed: %v", err)
+			}
+			src.pos, src.eofSent = 0, false
+			again := readAllCallback(t, c, ratio, 256)
+			if len(again) != len(out) {
+				t.Errorf("after Reset got %d frames, want %d", len(again), len(out))
+			}
+		})
+	}
+}
+
+// TestCallbackReadErrorPropagation checks callback errors are wrapped and recorded.
+func TestCallbackReadErrorPropagation(t *testing.T) {
+	errBroken := errors.New("device unplugged")
+	calls := 0
+	cb := func(userData interface{}) ([]float32, int64, error) {
+		calls++
+		if calls > 2 {
+			return nil, 0, errBroken
+		}
+		return make([]float32, 100), 100, nil
+	}
+	c, err := CallbackNew(cb, Linear, 1, nil)
+	if err != nil {
+		t.Fatalf("CallbackNew failed: %v", err)
+	}
+	_, err = CallbackRead(c, 1.0, 1000, make([]float32, 1000))
+	if err == nil {
+		t.Fatal("expected callback error")
+	}
+	if !errors.Is(err, errBroken) {
+		t.Errorf("error %v does not wrap the callback error", err)
+	}
+	if errors.Is(err, io.EOF) {
+		t.Errorf("callback failure must not look like EOF")
+	}
+	if mapGoErrorToCode(c.LastError()) != ErrBadCallback {
+		t.Errorf("LastError() = %v, want ErrBadCallback", c.LastError())
+	}
+}
+
+// TestCallbackReadRatioEnvelope changes the ratio on every read and checks the
+// effective ratio (measured on a ramp input) moves smoothly, reaching each target
+// by the end of the read, whatever the callback chunk size.
+func TestCallbackReadRatioEnvelope(t *testing.T) {
+	const blockLen = 200
+	const slope = 1e-5 // Input is a ramp, so output steps equal slope / ratio
+	input := make([]float32, 60000)
+	for i := range input {
+		input[i] = float32(float64(i) * slope)
+	}
+	ratios := []float64{0.5, 0.8, 0.6, 1.2, 0.9, 0.7}
+
+	for _, chunkLen := range []int{37, 1000} {
+		c, err := CallbackNew(cloneableSourceCallback, Linear, 1, &cloneableSource{data: input, chunkLen: chunkLen})
+		if err != nil {
+			t.Fatalf("CallbackNew failed: %v", err)
+		}
+		buf := make([]float32, blockLen)
+		var out []float32
+		for _, r := range ratios {
+			n, err := CallbackRead(c, r, blockLen, buf)
+			if err != nil || n != blockLen {
+				t.Fatalf("CallbackRead: n=%d err=%v", n, err)
+			}
+			out = append(out, buf[:n]...)
+
+			// The last step of the read must be at (close to) the requested ratio
+			step := float64(out[len(out)-1] - out[len(out)-2])
+			if got := slope / step; math.Abs(got-r) > 0.02 {
+				t.Errorf("chunk %d: ratio at end of read %.4f, want %.4f", chunkLen, got, r)
+			}
+		}
+
+		// Step sizes must change gradually: the largest per-frame change of 1/ratio
+		// allowed is the steepest envelope, |1/0.6 - 1/1.2| spread over a read, with slack
+		// for float32 resolution of the ramp.
+		maxDelta := math.Abs(1/0.6-1/1.2) / blockLen * slope * 4
+		for i := 10; i < len(out); i++ { // Skip the start-up transient of the interpolator
+			d := math.Abs(float64((out[i] - out[i-1]) - (out[i-1] - out[i-2])))
+			if d > maxDelta+2e-7 {
+				t.Fatalf("chunk %d: discontinuity at frame %d: step change %g > %g", chunkLen, i, d, maxDelta)
+			}
+		}
+	}
+}
+
+// TestCallbackReadAppend checks appending reads give the same stream as
+// CallbackRead into a caller-sized buffer, keeping what dst already holds.
+func TestCallbackReadAppend(t *testing.T) {
+	input := make([]float32, 2*3000)
+	genWindowedSinesGo(2, []float64{0.05, 0.11}, 0.9, input)
+	newConv := func() Converter {
+		c, err := CallbackNew(eofSourceCallback, SincFastest, 2, &eofSource{data: input, chunkLen: 2 * 700})
+		if err != nil {
+			t.Fatalf("CallbackNew failed: %v", err)
+		}
+		return c
+	}
+
+	ref := newConv()
+	defer ref.Close()
+	var want []float32
+	buf := make([]float32, 2*333)
+	for {
+		n, err := CallbackRead(ref, 1.5, 333, buf)
+		if err != nil {
+			t.Fatalf("CallbackRead failed: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+		want = append(want, buf[:2*n]...)
+	}
+
+	c := newConv()
+	defer c.Close()
+	got := []float32{42, 43} // Existing content is kept
+	for {
+		var n int64
+		var err error
+		got, n, err = CallbackReadAppend(c, 1.5, 333, got)
+		if err != nil {
+			t.Fatalf("CallbackReadAppend failed: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+	}
+	if got[0] != 42 || got[1] != 43 {
+		t.Errorf("existing samples overwritten: %v", got[:2])
+	}
+	got = got[2:]
+	if len(got) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sample %d is %g, want %g", i, got[i], want[i])
+		}
+	}
+
+	if out, n, err := CallbackReadAppend(c, 1.5, 0, got); n != 0 || err != nil || len(out) != len(got) {
+		t.Errorf("zero frames: got %d frames, %d samples, %v", n, len(out), err)
+	}
+	if _, _, err := CallbackReadAppend(nil, 1.5, 10, nil); err == nil {
+		t.Error("expected error for a nil converter")
+	}
+}
+
+// TestCallbackReadShortOutput checks a buffer too small for the request fails
+// with ErrShortOutput without reading, while a short read with no error only
+// happens at the end of the stream.
+func TestCallbackReadShortOutput(t *testing.T) {
+	for _, channels := range []int{1, 2 * maxChannels} {
+		input := make([]float32, 3000*channels)
+		src := &eofSource{data: input, chunkLen: 500 * channels}
+		c, err := CallbackNew(eofSourceCallback, SincFastest, channels, src)
+		if err != nil {
+			t.Fatalf("CallbackNew failed: %v", err)
+		}
+
+		n, err := CallbackRead(c, 1.5, 256, make([]float32, 255*channels))
+		if !errors.Is(err, ErrShortOutput) || n != 0 {
+			t.Fatalf("%d channels: short buffer gave %d frames, err %v; want ErrShortOutput", channels, n, err)
+		}
+		if mapGoErrorToCode(err) != ErrShortOutput || errors.Is(err, ErrBadSrcRatio) {
+			t.Errorf("%d channels: error %v maps to code %d", channels, err, mapGoErrorToCode(err))
+		}
+		if src.pos != 0 {
+			t.Errorf("%d channels: %d samples read for a failed request", channels, src.pos)
+		}
+
+		out := make([]float32, 256*channels)
+		var total int64
+		for {
+			n, err := CallbackRead(c, 1.5, 256, out)
+			if err != nil {
+				t.Fatalf("%d channels: CallbackRead failed: %v", channels, err)
+			}
+			total += n
+			if n < 256 {
+				if !src.eofSent {
+					t.Errorf("%d channels: short read of %d frames before the end of the stream", channels, n)
+				}
+				break
+			}
+		}
+		if total < 4400 {
+			t.Errorf("%d channels: read %d frames of about 4500", channels, total)
+		}
+		c.Close()
+	}
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// ChainStage describes one step of a ChainConverter.
+type ChainStage struct {
+	Rate          float64       // Output sample rate of this step in Hz
+	ConverterType ConverterType // Converter used for this step
+}
+
+// ChainConverter pipes one stream through several converters in sequence, e.g.
+// 44.1 kHz to 48 kHz for a mixing bus and then to 8 kHz for SIP. The output of
+// each step is fed to the next within the same call, and on the last call every
+// step is flushed before its output reaches the next, so the result has no gaps
+// and equals running the steps one after the other over the whole stream.
+//
+// NOTE: A ChainConverter is NOT goroutine-safe.
+type ChainConverter struct {
+	inputRate float64
+	channels  int
+	stages    []ChainStage
+	steps     []*streamStage
+}
+
+// NewChainConverter creates a ChainConverter for interleaved input at inputRate
+// with the given channel count, converting through the rates in stages in order.
+func NewChainConverter(inputRate float64, channels int, stages []ChainStage) (*ChainConverter, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if inputRate <= 0 || math.IsNaN(inputRate) || math.IsInf(inputRate, 0) {
+		return nil, fmt.Errorf("input rate must be positive, got %f", inputRate)
+	}
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("chain needs at least one stage")
+	}
+
+	c := &ChainConverter{
+		inputRate: inputRate,
+		channels:  channels,
+		stages:    append([]ChainStage(nil), stages...),
+	}
+	rate := inputRate
+	for i, s := range stages {
+		ratio := s.Rate / rate
+		if isBadSrcRatio(ratio) {
+			c.Close()
+			return nil, fmt.Errorf("stage %d: %f Hz to %f Hz gives invalid ratio %f: %w", i, rate, s.Rate, ratio, mapError(ErrBadSrcRatio))
+		}
+		conv, err := New(s.ConverterType, channels)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("stage %d: failed to create converter: %w", i, err)
+		}
+		c.steps = append(c.steps, &streamStage{
+			conv:    conv,
+			ratio:   ratio,
+			scratch: make([]float32, fanoutScratchFrames*channels),
+		})
+		rate = s.Rate
+	}
+	return c, nil
+}
+
+// Stages returns the stage descriptions.
+func (c *ChainConverter) Stages() []ChainStage {
+	return append([]ChainStage(nil), c.stages...)
+}
+
+// OutputRate returns the sample rate of the last stage.
+func (c *ChainConverter) OutputRate() float64 {
+	return c.stages[len(c.stages)-1].Rate
+}
+
+// Process feeds interleaved input through every stage and returns the
+// interleaved output of the last one. Set endOfInput on the last call to flush
+// the stages in order; call Reset before reusing the chain for a new stream.
+//
+// The returned slice is reused by the next call; copy it to keep it.
+func (c *ChainConverter) Process(in []float32, endOfInput bool) ([]float32, error) {
+	if len(in)%c.channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), c.channels)
+	}
+	for i, step := range c.steps {
+		// With endOfInput, step i-1 was drained completely before this point, so
+		// step i sees the whole remaining stream before it flushes.
+		if err := step.process(in, c.channels, endOfInput); err != nil {
+			return nil, fmt.Errorf("stage %d: %w", i, err)
+		}
+		in = step.out
+	}
+	return in, nil
+}
+
+// Reset resets every stage converter, e.g. to start a new stream.
+func (c *ChainConverter) Reset() error {
+	for i, step := range c.steps {
+		if err := step.conv.Reset(); err != nil {
+			return fmt.Errorf("stage %d: %w", i, err)
+		}
+		step.out = step.out[:0]
+	}
+	return nil
+}
+
+// Close releases all stage converters.
+func (c *ChainConverter) Close() error {
+	var firstErr error
+	for _, step := range c.steps {
+		if err := step.conv.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,123 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// TestChainConverterMatchesSequentialRuns checks block-wise chained output equals
+// running each stage over the whole output of the previous one.
+func TestChainConverterMatchesSequentialRuns(t *testing.T) {
+	const channels = 2
+	stages := []ChainStage{
+		{Rate: 48000, ConverterType: SincFastest},
+		{Rate: 8000, ConverterType: SincMediumQuality},
+	}
+	mono := genSine(22050, 440, 44100, 0.5)
+	in := make([]float32, len(mono)*channels)
+	for i, v := range mono {
+		in[i*channels], in[i*channels+1] = v, -v
+	}
+
+	chain, err := NewChainConverter(44100, channels, stages)
+	if err != nil {
+		t.Fatalf("NewChainConverter failed: %v", err)
+	}
+	defer chain.Close()
+	if chain.OutputRate() != 8000 {
+		t.Errorf("OutputRate() = %f, want 8000", chain.OutputRate())
+	}
+
+	run := func() []float32 {
+		var got []float32
+		const blockFrames = 441 // 10 ms blocks
+		for pos := 0; pos < len(in); pos += blockFrames * channels {
+			end := minInt(pos+blockFrames*channels, len(in))
+			out, err := chain.Process(in[pos:end], end == len(in))
+			if err != nil {
+				t.Fatalf("Process failed: %v", err)
+			}
+			got = append(got, out...)
+		}
+		return got
+	}
+	got := run()
+
+	want := in
+	rate := 44100.0
+	for _, s := range stages {
+		conv, _ := New(s.ConverterType, channels)
+		want, err = processAll(conv, want, channels, s.Rate/rate)
+		if err != nil {
+			t.Fatalf("processAll failed: %v", err)
+		}
+		conv.Close()
+		rate = s.Rate
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(float64(got[i]-want[i])) > 1e-6 {
+			t.Fatalf("sample %d is %g, want %g", i, got[i], want[i])
+		}
+	}
+	if frames := len(got) / channels; math.Abs(float64(frames-4000)) > 4 {
+		t.Errorf("got %d frames, want about 4000", frames)
+	}
+
+	if err := chain.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	again := run()
+	if len(again) != len(got) {
+		t.Fatalf("after Reset got %d samples, want %d", len(again), len(got))
+	}
+}
+
+func TestChainConverterErrors(t *testing.T) {
+	if _, err := NewChainConverter(44100, 1, nil); err == nil {
+		t.Error("expected error for empty chain")
+	}
+	if _, err := NewChainConverter(44100, 0, []ChainStage{{Rate: 8000}}); err == nil {
+		t.Error("expected error for zero channels")
+	}
+	if _, err := NewChainConverter(8000, 1, []ChainStage{{Rate: 16000}, {Rate: 8}}); err == nil {
+		t.Error("expected error for out of range stage ratio")
+	}
+	chain, _ := NewChainConverter(8000, 2, []ChainStage{{Rate: 16000, ConverterType: Linear}})
+	defer chain.Close()
+	if _, err := chain.Process(make([]float32, 3), false); err == nil {
+		t.Error("expected error for partial frame")
+	}
+}
+
+// TestChainConverterWideStream checks a chain takes more channels than one sinc
+// state holds, as New does.
+func TestChainConverterWideStream(t *testing.T) {
+	const channels = 2 * maxChannels
+	stages := []ChainStage{
+		{Rate: 16000, ConverterType: SincFastest},
+		{Rate: 8000, ConverterType: Linear},
+	}
+	in, _ := wideTestSignal(channels, 480)
+	chain, err := NewChainConverter(48000, channels, stages)
+	if err != nil {
+		t.Fatalf("NewChainConverter failed: %v", err)
+	}
+	defer chain.Close()
+	got, err := chain.Process(in, true)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	want := resampleWhole(t, Linear, resampleWhole(t, SincFastest, in, channels, 16000.0/48000), channels, 0.5)
+	checkSameSamples(t, "chain", got, want)
+}
@@ -0,0 +1,433 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"time"
+)
+
+// channelGroups runs a sinc converter with more than maxChannels channels (e.g.
+// high-order ambisonics) as several sinc states of at most maxChannels channels
+// each. All groups get the same calls with the same ratio, so they consume and
+// produce identical frame counts and share one ratio/position history; only the
+// interleaving is done here.
+type channelGroups struct {
+	converterType ConverterType
+	channels      int
+	groups        []*srcState
+	widths        []int // Channels in each group
+	inBufs        [][]float32
+	outBufs       [][]float32
+	groupData     SrcData      // Block of the group being run; a field so Process does not allocate it
+	feeder        *groupFeeder // Callback mode only
+	clock         clockEstimator
+	options       Options
+	nanBuf        []float32 // Sanitized copy of the input, for NaNZero
+	post          postChain // Run on the interleaved output, for all groups
+	corruptions   int64     // Recoveries done with Options.ResetOnCorruption
+	name          string
+	lastErr       error
+}
+
+// Compile-time check to ensure channelGroups implements Converter and the optional
+// interfaces
+var _ converter = (*channelGroups)(nil)
+
+// needsChannelGroups reports whether a converter must be split into channel groups.
+func needsChannelGroups(converterType ConverterType, channels int) bool {
+	switch converterType {
+	case SincBestQuality, SincMediumQuality, SincFastest:
+		return channels > maxChannels
+	}
+	return false // Linear and ZOH have no channel limit
+}
+
+func newChannelGroups(converterType ConverterType, channels int) (*channelGroups, error) {
+	g := &channelGroups{converterType: converterType, channels: channels}
+	for first := 0; first < channels; first += maxChannels {
+		width := minInt(maxChannels, channels-first)
+		state, errCode := psrcSetConverter(converterType, width)
+		if errCode != ErrNoError {
+			g.Close()
+			return nil, mapError(errCode)
+		}
+		g.groups = append(g.groups, state)
+		g.widths = append(g.widths, width)
+	}
+	g.inBufs = make([][]float32, len(g.groups))
+	g.outBufs = make([][]float32, len(g.groups))
+	g.post = newPostChain(channels, Options{})
+	reportAs(g, g)
+	return g, nil
+}
+
+// Process deinterleaves the input into the groups, runs them and interleaves the result.
+func (g *channelGroups) Process(data *SrcData) error {
+	collector := currentMetricsCollector()
+	if collector == nil {
+		return g.process(data)
+	}
+	start := time.Now()
+	err := g.process(data)
+	observeProcess(collector, g, data, time.Since(start), err)
+	return err
+}
+
+func (g *channelGroups) process(data *SrcData) error {
+	if data == nil {
+		return g.fail(mapError(ErrBadData))
+	}
+	inFrames := maxInt64(data.InputFrames, 0)
+	outFrames := maxInt64(data.OutputFrames, 0)
+	if int64(len(data.DataIn)) < inFrames*int64(g.channels) || int64(len(data.DataOut)) < outFrames*int64(g.channels) {
+		return g.fail(mapError(ErrBadDataPtr))
+	}
+
+	in := data.DataIn
+	if g.options.NaNPolicy != NaNPass && inFrames > 0 {
+		clean, errCode := sanitizeInput(g.options.NaNPolicy, in, int(inFrames)*g.channels, &g.nanBuf)
+		if errCode != ErrNoError {
+			return g.fail(mapError(errCode)) // Before any group consumes input
+		}
+		in = clean
+	}
+
+	data.InputFramesUsed, data.OutputFramesGen = 0, 0
+	first := 0
+	for i, state := range g.groups {
+		width := g.widths[i]
+		g.inBufs[i] = growFloats(g.inBufs[i], int(inFrames)*width)
+		g.outBufs[i] = growFloats(g.outBufs[i], int(outFrames)*width)
+		extractChannels(g.inBufs[i], in, g.channels, first, width, int(inFrames))
+
+		g.groupData = SrcData{
+			DataIn:       g.inBufs[i],
+			InputFrames:  inFrames,
+			DataOut:      g.outBufs[i],
+			OutputFrames: outFrames,
+			SrcRatio:     data.SrcRatio,
+			EndOfInput:   data.EndOfInput,
+		}
+		if inFrames == 0 {
+			g.groupData.DataIn = nil
+		}
+		if err := state.process(&g.groupData); err != nil {
+			return g.fail(err)
+		}
+		if i == 0 {
+			data.InputFramesUsed, data.OutputFramesGen = g.groupData.InputFramesUsed, g.groupData.OutputFramesGen
+		} else if g.groupData.InputFramesUsed != data.InputFramesUsed || g.groupData.OutputFramesGen != data.OutputFramesGen {
+			return g.fail(mapError(ErrBadInternalState)) // Groups out of lockstep
+		}
+		insertChannels(data.DataOut, g.outBufs[i], g.channels, first, width, int(g.groupData.OutputFramesGen))
+		first += width
+	}
+	g.checkCorruption(data.DataOut, data.OutputFramesGen)
+	g.post.run(data.DataOut, data.InputFramesUsed, data.OutputFramesGen)
+	if g.post.errorOnFull && outputFull(g, data) {
+		return g.fail(mapError(ErrOutputFull))
+	}
+	g.lastErr = nil
+	return nil
+}
+
+// callbackRead runs CallbackRead on every group and interleaves the results.
+func (g *channelGroups) callbackRead(ratio float64, framesToRead int64, outData []float32) (int64, error) {
+	if g.feeder == nil {
+		return 0, g.fail(mapError(ErrBadMode))
+	}
+	if int64(len(outData)) < framesToRead*int64(g.channels) {
+		return 0, fmt.Errorf("%w (need %d samples, got %d)", mapError(ErrShortOutput), framesToRead*int64(g.channels), len(outData))
+	}
+	var framesRead int64
+	inBefore := g.groups[0].stats.InputFrames
+	first := 0
+	for i, state := range g.groups {
+		width := g.widths[i]
+		g.outBufs[i] = growFloats(g.outBufs[i], int(framesToRead)*width)
+		n, err := CallbackRead(state, ratio, framesToRead, g.outBufs[i])
+		if err != nil {
+			return 0, g.fail(err)
+		}
+		if i == 0 {
+			framesRead = n
+		} else if n != framesRead {
+			return 0, g.fail(mapError(ErrBadInternalState))
+		}
+		insertChannels(outData, g.outBufs[i], g.channels, first, width, int(n))
+		first += width
+	}
+	g.checkCorruption(outData, framesRead)
+	g.post.run(outData, g.groups[0].stats.InputFrames-inBefore, framesRead)
+	g.lastErr = nil
+	return framesRead, nil
+}
+
+// Reset resets every group (and the callback queues).
+func (g *channelGroups) Reset() error {
+	for _, state := range g.groups {
+		if err := state.Reset(); err != nil {
+			return g.fail(err)
+		}
+	}
+	if g.feeder != nil {
+		g.feeder.reset()
+	}
+	g.post.reset()
+	g.clock = clockEstimator{}
+	g.lastErr = nil
+	return nil
+}
+
+// SetRatio sets the ratio on every group.
+func (g *channelGroups) SetRatio(newRatio float64) error {
+	for _, state := range g.groups {
+		if err := state.SetRatio(newRatio); err != nil {
+			return g.fail(err)
+		}
+	}
+	return nil
+}
+
+// SupportsVariableRatio reports whether the groups, which share a type, support
+// variable ratios.
+func (g *channelGroups) SupportsVariableRatio() bool {
+	return g.groups[0].SupportsVariableRatio()
+}
+
+// GetChannels returns the total channel count.
+func (g *channelGroups) GetChannels() int {
+	return g.channels
+}
+
+// Close closes every group.
+func (g *channelGroups) Close() error {
+	for _, state := range g.groups {
+		state.Close()
+	}
+	g.lastErr = mapError(ErrBadState)
+	return nil
+}
+
+// LastError returns the last error encountered.
+func (g *channelGroups) LastError() error {
+	return g.lastErr
+}
+
+// Stats returns the counters of the first group, which match every other group's,
+// with the buffer occupancy in frames.
+func (g *channelGroups) Stats() Stats {
+	s := g.groups[0].Stats()
+	s.CorruptionResets = g.corruptions
+	return s
+}
+
+// Clone clones every group. In callback mode the clones share a new feeder with
+// copies of the queued input, and the user data is cloned if it implements
+// UserDataCloner.
+func (g *channelGroups) Clone() (Converter, error) {
+	c := &channelGroups{
+		converterType: g.converterType,
+		channels:      g.channels,
+		clock:         g.clock,
+		options:       g.options,
+		post:          g.post.clone(),
+		corruptions:   g.corruptions,
+		name:          g.name,
+		widths:        append([]int(nil), g.widths...),
+		inBufs:        make([][]float32, len(g.groups)),
+		outBufs:       make([][]float32, len(g.groups)),
+	}
+	if g.feeder != nil {
+		c.feeder = g.feeder.clone()
+	}
+	for i, state := range g.groups {
+		clone, err := state.Clone()
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		cs := clone.(*srcState)
+		if c.feeder != nil {
+			cs.userCallbackData = &groupSlot{feeder: c.feeder, index: i}
+		}
+		c.groups = append(c.groups, cs)
+	}
+	reportAs(c, c)
+	return c, nil
+}
+
+// checkCorruption implements Options.ResetOnCorruption, resetting all groups together
+// so they stay in lockstep.
+func (g *channelGroups) checkCorruption(out []float32, frames int64) {
+	if !g.options.ResetOnCorruption || frames <= 0 {
+		return
+	}
+	out = out[:frames*int64(g.channels)]
+	if firstNonFinite(out) < 0 {
+		return
+	}
+	for _, state := range g.groups {
+		state.resetFilter()
+	}
+	zeroNonFinite(out)
+	g.corruptions++
+}
+
+func (g *channelGroups) fail(err error) error {
+	g.lastErr = err
+	return err
+}
+
+// --- Callback Mode ---
+
+// groupFeeder splits each chunk from the user callback between the groups. Group 0
+// pulls from the user callback; the other groups take the matching chunk from their
+// queue. Groups consume input in lockstep, so they request chunks in the same order.
+type groupFeeder struct {
+	cbFunc    CallbackFunc
+	userData  interface{}
+	channels  int
+	widths    []int
+	queues    [][]groupChunk // Pending chunks for groups 1..n-1
+	nanPolicy NaNPolicy      // Options.NaNPolicy, applied to the chunks before they are split
+	nanBuf    []float32
+}
+
+type groupChunk struct {
+	data   []float32
+	frames int64
+	err    error
+}
+
+// groupSlot is the callback user data of one group.
+type groupSlot struct {
+	feeder *groupFeeder
+	index  int
+}
+
+func groupSlotCallback(userData interface{}) ([]float32, int64, error) {
+	slot := userData.(*groupSlot)
+	f := slot.feeder
+	if slot.index > 0 {
+		q := f.queues[slot.index]
+		if len(q) == 0 {
+			return nil, 0, mapError(ErrBadInternalState) // Groups out of lockstep
+		}
+		chunk := q[0]
+		f.queues[slot.index] = q[1:]
+		return chunk.data, chunk.frames, chunk.err
+	}
+
+	data, frames, err := f.cbFunc(f.userData)
+	if frames*int64(f.channels) > int64(len(data)) {
+		frames = int64(len(data) / f.channels)
+	}
+	if frames < 0 {
+		frames = 0
+	}
+	if f.nanPolicy != NaNPass && frames > 0 {
+		clean, errCode := sanitizeInput(f.nanPolicy, data, int(frames)*f.channels, &f.nanBuf)
+		if errCode != ErrNoError {
+			clean, frames, err = nil, 0, mapError(errCode)
+		}
+		data = clean
+	}
+	var first0 []float32
+	first := 0
+	for i, width := range f.widths {
+		part := make([]float32, int(frames)*width)
+		extractChannels(part, data, f.channels, first, width, int(frames))
+		if i == 0 {
+			first0 = part
+		} else {
+			f.queues[i] = append(f.queues[i], groupChunk{data: part, frames: frames, err: err})
+		}
+		first += width
+	}
+	return first0, frames, err
+}
+
+func (f *groupFeeder) reset() {
+	for i := range f.queues {
+		f.queues[i] = nil
+	}
+}
+
+func (f *groupFeeder) clone() *groupFeeder {
+	c := &groupFeeder{
+		cbFunc:    f.cbFunc,
+		userData:  f.userData,
+		channels:  f.channels,
+		widths:    f.widths,
+		queues:    make([][]groupChunk, len(f.queues)),
+		nanPolicy: f.nanPolicy,
+	}
+	if cloner, ok := f.userData.(UserDataCloner); ok {
+		c.userData = cloner.CloneUserData()
+	}
+	for i, q := range f.queues {
+		for _, chunk := range q {
+			chunk.data = append([]float32(nil), chunk.data...)
+			c.queues[i] = append(c.queues[i], chunk)
+		}
+	}
+	return c
+}
+
+// newCallbackChannelGroups creates a grouped converter in callback mode.
+func newCallbackChannelGroups(cbFunc CallbackFunc, converterType ConverterType, channels int, userData interface{}) (*channelGroups, error) {
+	g, err := newChannelGroups(converterType, channels)
+	if err != nil {
+		return nil, err
+	}
+	g.feeder = &groupFeeder{
+		cbFunc:   cbFunc,
+		userData: userData,
+		channels: channels,
+		widths:   g.widths,
+		queues:   make([][]groupChunk, len(g.groups)),
+	}
+	for i, state := range g.groups {
+		if err := state.Reset(); err != nil {
+			g.Close()
+			return nil, err
+		}
+		state.mode = ModeCallback
+		state.callbackFunc = groupSlotCallback
+		state.userCallbackData = &groupSlot{feeder: g.feeder, index: i}
+	}
+	return g, nil
+}
+
+// --- Helpers ---
+
+// growFloats returns buf resized to n samples, reallocating only if needed.
+func growFloats(buf []float32, n int) []float32 {
+	if cap(buf) < n {
+		return make([]float32, n)
+	}
+	return buf[:n]
+}
+
+// extractChannels copies channels [first, first+width) of frames interleaved frames
+// of src (with stride channels) into dst, interleaved with stride width.
+func extractChannels(dst, src []float32, channels, first, width, frames int) {
+	for fr := 0; fr < frames; fr++ {
+		copy(dst[fr*width:(fr+1)*width], src[fr*channels+first:fr*channels+first+width])
+	}
+}
+
+// insertChannels is the inverse of extractChannels.
+func insertChannels(dst, src []float32, channels, first, width, frames int) {
+	for fr := 0; fr < frames; fr++ {
+		copy(dst[fr*channels+first:fr*channels+first+width], src[fr*width:(fr+1)*width])
+	}
+}
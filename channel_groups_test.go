@@ -0,0 +1,166 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// wideTestSignal returns frames interleaved frames of channels channels, each a
+// sine at its own frequency, and the same data per channel.
+func wideTestSignal(channels, frames int) ([]float32, [][]float32) {
+	interleaved := make([]float32, channels*frames)
+	planar := make([][]float32, channels)
+	for ch := range planar {
+		planar[ch] = make([]float32, frames)
+		freq := 0.01 + 0.3*float64(ch)/float64(channels)
+		for fr := 0; fr < frames; fr++ {
+			v := float32(0.8 * math.Sin(2*math.Pi*freq*float64(fr)+float64(ch)))
+			planar[ch][fr] = v
+			interleaved[fr*channels+ch] = v
+		}
+	}
+	return interleaved, planar
+}
+
+// checkWideOutput compares every channel of a wide output with the same channel
+// converted on its own.
+func checkWideOutput(t *testing.T, got []float32, planar [][]float32, ratio float64) {
+	t.Helper()
+	channels := len(planar)
+	for _, ch := range []int{0, 1, maxChannels - 1, maxChannels, maxChannels + 1, channels - 1} {
+		mono, err := New(SincFastest, 1)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		want, err := processAll(mono, planar[ch], 1, ratio)
+		mono.Close()
+		if err != nil {
+			t.Fatalf("processAll failed: %v", err)
+		}
+		frames := minInt(len(want), len(got)/channels)
+		if frames < len(want)-2 {
+			t.Fatalf("channel %d: got %d frames, want %d", ch, len(got)/channels, len(want))
+		}
+		for fr := 0; fr < frames; fr++ {
+			if d := math.Abs(float64(got[fr*channels+ch] - want[fr])); d > 1e-5 {
+				t.Fatalf("channel %d frame %d: got %g, want %g", ch, fr, got[fr*channels+ch], want[fr])
+			}
+		}
+	}
+}
+
+func TestChannelGroupsProcess(t *testing.T) {
+	const channels = 300 // Three groups: 128 + 128 + 44
+	const ratio = 0.75
+	in, planar := wideTestSignal(channels, 2000)
+
+	conv, err := New(SincFastest, channels)
+	if err != nil {
+		t.Fatalf("New(%d channels) failed: %v", channels, err)
+	}
+	defer conv.Close()
+	if conv.GetChannels() != channels {
+		t.Errorf("GetChannels() = %d, want %d", conv.GetChannels(), channels)
+	}
+
+	// Feed in uneven blocks, keeping a clone half way through
+	var got, cloneGot []float32
+	var clone Converter
+	out := make([]float32, 1000*channels)
+	for pos, block := 0, 0; ; block++ {
+		n := minInt(137+block*50, len(in)/channels-pos)
+		data := SrcData{
+			DataIn: in[pos*channels : (pos+n)*channels], InputFrames: int64(n),
+			DataOut: out, OutputFrames: 1000, SrcRatio: ratio, EndOfInput: pos+n == len(in)/channels,
+		}
+		if err := conv.Process(&data); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		got = append(got, out[:data.OutputFramesGen*channels]...)
+		if clone != nil {
+			cloneData := data
+			cloneData.DataOut = make([]float32, len(out))
+			if err := clone.Process(&cloneData); err != nil {
+				t.Fatalf("clone Process failed: %v", err)
+			}
+			cloneGot = append(cloneGot, cloneData.DataOut[:cloneData.OutputFramesGen*channels]...)
+		}
+		pos += int(data.InputFramesUsed)
+		if data.EndOfInput && data.OutputFramesGen == 0 {
+			break
+		}
+		if block == 3 {
+			if clone, err = conv.Clone(); err != nil {
+				t.Fatalf("Clone failed: %v", err)
+			}
+			defer clone.Close()
+			cloneGot = append(cloneGot, got...)
+		}
+	}
+	checkWideOutput(t, got, planar, ratio)
+
+	if len(cloneGot) != len(got) {
+		t.Fatalf("clone produced %d samples, original %d", len(cloneGot), len(got))
+	}
+	for i := range got {
+		if cloneGot[i] != got[i] {
+			t.Fatalf("clone differs at sample %d", i)
+		}
+	}
+	if s := conv.(StatsReporter).Stats(); s.OutputFrames != int64(len(got)/channels) {
+		t.Errorf("Stats().OutputFrames = %d, want %d", s.OutputFrames, len(got)/channels)
+	}
+}
+
+func TestChannelGroupsCallback(t *testing.T) {
+	const channels = 200
+	const ratio = 1.5
+	in, planar := wideTestSignal(channels, 1500)
+
+	pos := 0
+	cb := func(userData interface{}) ([]float32, int64, error) {
+		end := minInt(pos+333*channels, len(in))
+		chunk := in[pos:end]
+		pos = end
+		return chunk, int64(len(chunk) / channels), nil
+	}
+	c, err := CallbackNew(cb, SincFastest, channels, nil)
+	if err != nil {
+		t.Fatalf("CallbackNew failed: %v", err)
+	}
+	defer c.Close()
+
+	var got []float32
+	buf := make([]float32, 256*channels)
+	for {
+		n, err := CallbackRead(c, ratio, 256, buf)
+		if err != nil {
+			t.Fatalf("CallbackRead failed: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+		got = append(got, buf[:n*channels]...)
+	}
+	checkWideOutput(t, got, planar, ratio)
+}
+
+func TestChannelGroupsLinearUnlimited(t *testing.T) {
+	// Linear and ZOH have no per-state channel limit and are not split
+	conv, err := New(Linear, 1000)
+	if err != nil {
+		t.Fatalf("New(Linear, 1000) failed: %v", err)
+	}
+	defer conv.Close()
+	if _, ok := conv.(*srcState); !ok {
+		t.Errorf("Linear with 1000 channels was wrapped in %T", conv)
+	}
+}
@@ -0,0 +1,326 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"time"
+)
+
+// channelMix is a channel up/downmix matrix: output channel j of a frame is the
+// sum of matrix[j][i] times input channel i.
+type channelMix struct {
+	in, out int
+	matrix  [][]float32
+}
+
+// newChannelMix returns the mix requested by opts for a converter with the given
+// input channels, or nil when the channel layout is unchanged.
+func newChannelMix(channels int, opts Options) (*channelMix, error) {
+	if opts.OutputChannels < 0 || opts.OutputChannels > maxChannels {
+		return nil, fmt.Errorf("OutputChannels must be between 0 and %d, got %d: %w", maxChannels, opts.OutputChannels, mapError(ErrBadChannelCount))
+	}
+	out := opts.OutputChannels
+	if out == 0 {
+		out = channels
+	}
+	if opts.ChannelMatrix == nil {
+		if out == channels {
+			return nil, nil
+		}
+		return &channelMix{in: channels, out: out, matrix: defaultChannelMatrix(channels, out)}, nil
+	}
+	if len(opts.ChannelMatrix) != out {
+		return nil, fmt.Errorf("ChannelMatrix has %d rows, want one per output channel (%d)", len(opts.ChannelMatrix), out)
+	}
+	matrix := make([][]float32, out)
+	for j, row := range opts.ChannelMatrix {
+		if len(row) != channels {
+			return nil, fmt.Errorf("ChannelMatrix row %d has %d coefficients, want one per input channel (%d)", j, len(row), channels)
+		}
+		matrix[j] = append([]float32(nil), row...)
+	}
+	return &channelMix{in: channels, out: out, matrix: matrix}, nil
+}
+
+// defaultChannelMatrix averages input channels i with i%out == j into output j when
+// downmixing (stereo to mono is (L+R)/2), and copies input j%in to output j when
+// upmixing (mono to stereo duplicates the channel).
+func defaultChannelMatrix(in, out int) [][]float32 {
+	matrix := make([][]float32, out)
+	for j := range matrix {
+		matrix[j] = make([]float32, in)
+	}
+	if out < in {
+		for j := 0; j < out; j++ {
+			count := 0
+			for i := j; i < in; i += out {
+				count++
+			}
+			for i := j; i < in; i += out {
+				matrix[j][i] = 1.0 / float32(count)
+			}
+		}
+		return matrix
+	}
+	for j := 0; j < out; j++ {
+		matrix[j][j%in] = 1.0
+	}
+	return matrix
+}
+
+// pre reports whether the mix is applied before resampling. Downmixing first and
+// upmixing last keeps the converter at the smaller channel count; a linear mix
+// commutes with resampling, so the result is the same either way.
+func (m *channelMix) pre() bool {
+	return m.out <= m.in
+}
+
+// innerChannels is the channel count the converter runs at.
+func (m *channelMix) innerChannels() int {
+	return minInt(m.in, m.out)
+}
+
+// apply mixes frames frames of src (m.in channels) into dst (m.out channels).
+func (m *channelMix) apply(dst, src []float32, frames int) {
+	for fr := 0; fr < frames; fr++ {
+		in := src[fr*m.in : (fr+1)*m.in]
+		out := dst[fr*m.out : (fr+1)*m.out]
+		for j, row := range m.matrix {
+			var sum float32
+			for i, coef := range row {
+				sum += float32(coef * in[i]) // No FMA, see Options.Deterministic
+			}
+			out[j] = sum
+		}
+	}
+}
+
+// channelMapper is a converter with Options.OutputChannels or ChannelMatrix set:
+// it takes input with mix.in channels and produces output with mix.out channels,
+// mixing on the side of the inner converter with fewer channels.
+type channelMapper struct {
+	inner     converter // Created with innerOptions
+	mix       *channelMix
+	inBuf     []float32
+	outBuf    []float32
+	innerData SrcData // Block of the inner converter; a field so Process does not allocate it
+	clock     clockEstimator
+	options   Options
+	nanBuf    []float32 // Sanitized copy of the input, for NaNZero
+	post      postChain // Run on the output layout
+	lastErr   error
+}
+
+// Compile-time check to ensure channelMapper implements Converter and the optional
+// interfaces
+var _ converter = (*channelMapper)(nil)
+
+// newChannelMapper wraps inner, created with opts.innerOptions(), in a
+// channelMapper doing mix.
+func newChannelMapper(inner converter, mix *channelMix, opts Options) *channelMapper {
+	m := &channelMapper{inner: inner, mix: mix, options: opts, post: newPostChain(mix.out, opts)}
+	reportAs(inner, m)
+	return m
+}
+
+// Process mixes and resamples one block. DataIn holds input-layout frames and
+// DataOut output-layout frames.
+func (m *channelMapper) Process(data *SrcData) error {
+	collector := currentMetricsCollector()
+	if collector == nil {
+		return m.process(data)
+	}
+	start := time.Now()
+	err := m.process(data)
+	observeProcess(collector, m, data, time.Since(start), err)
+	return err
+}
+
+func (m *channelMapper) process(data *SrcData) error {
+	if data == nil {
+		return m.fail(mapError(ErrBadData))
+	}
+	inFrames := maxInt64(data.InputFrames, 0)
+	outFrames := maxInt64(data.OutputFrames, 0)
+	if int64(len(data.DataIn)) < inFrames*int64(m.mix.in) || int64(len(data.DataOut)) < outFrames*int64(m.mix.out) {
+		return m.fail(mapError(ErrBadDataPtr))
+	}
+	in := data.DataIn
+	if m.options.NaNPolicy != NaNPass && inFrames > 0 {
+		clean, errCode := sanitizeInput(m.options.NaNPolicy, in, int(inFrames)*m.mix.in, &m.nanBuf)
+		if errCode != ErrNoError {
+			return m.fail(mapError(errCode))
+		}
+		in = clean
+	}
+
+	m.innerData = *data
+	inner := &m.innerData
+	inner.DataIn = in
+	if m.mix.pre() {
+		m.inBuf = growFloats(m.inBuf, int(inFrames)*m.mix.out)
+		m.mix.apply(m.inBuf, in, int(inFrames))
+		inner.DataIn = m.inBuf
+	} else {
+		m.outBuf = growFloats(m.outBuf, int(outFrames)*m.mix.in)
+		inner.DataOut = m.outBuf
+	}
+	if inFrames == 0 {
+		inner.DataIn = nil
+	}
+	if err := processInner(m.inner, inner); err != nil {
+		return m.fail(err)
+	}
+	if !m.mix.pre() {
+		m.mix.apply(data.DataOut, m.outBuf, int(inner.OutputFramesGen))
+	}
+	data.InputFramesUsed, data.OutputFramesGen = inner.InputFramesUsed, inner.OutputFramesGen
+	m.post.run(data.DataOut, data.InputFramesUsed, data.OutputFramesGen)
+	if m.post.errorOnFull && outputFull(m, data) {
+		return m.fail(mapError(ErrOutputFull))
+	}
+	m.lastErr = nil
+	return nil
+}
+
+// processInner runs the converter inside a wrapper, whose own Process reports
+// the call to the metrics.
+func processInner(c Converter, data *SrcData) error {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.process(data)
+	case *channelGroups:
+		return conv.process(data)
+	}
+	return c.Process(data)
+}
+
+// callbackRead reads output-layout frames from the inner callback converter.
+func (m *channelMapper) callbackRead(ratio float64, framesToRead int64, outData []float32) (int64, error) {
+	if framesToRead <= 0 {
+		return 0, nil
+	}
+	if int64(len(outData)) < framesToRead*int64(m.mix.out) {
+		return 0, fmt.Errorf("%w (need %d samples, got %d)", mapError(ErrShortOutput), framesToRead*int64(m.mix.out), len(outData))
+	}
+	var n int64
+	var err error
+	inBefore := m.inner.Stats().InputFrames
+	if m.mix.pre() {
+		n, err = CallbackRead(m.inner, ratio, framesToRead, outData)
+	} else {
+		m.outBuf = growFloats(m.outBuf, int(framesToRead)*m.mix.in)
+		n, err = CallbackRead(m.inner, ratio, framesToRead, m.outBuf)
+		m.mix.apply(outData, m.outBuf, int(n))
+	}
+	m.post.run(outData, m.inner.Stats().InputFrames-inBefore, n)
+	return n, err
+}
+
+// Reset resets the inner converter.
+func (m *channelMapper) Reset() error {
+	m.clock = clockEstimator{}
+	m.post.reset()
+	return m.inner.Reset()
+}
+
+// SetRatio sets the ratio of the inner converter.
+func (m *channelMapper) SetRatio(newRatio float64) error {
+	return m.inner.SetRatio(newRatio)
+}
+
+// SupportsVariableRatio reports whether the inner converter supports variable
+// ratios.
+func (m *channelMapper) SupportsVariableRatio() bool {
+	return m.inner.SupportsVariableRatio()
+}
+
+// GetChannels returns the input channel count; output frames have
+// Options.OutputChannels channels.
+func (m *channelMapper) GetChannels() int {
+	return m.mix.in
+}
+
+// Close closes the inner converter.
+func (m *channelMapper) Close() error {
+	return m.inner.Close()
+}
+
+// LastError returns the last error encountered.
+func (m *channelMapper) LastError() error {
+	if m.lastErr != nil {
+		return m.lastErr
+	}
+	return m.inner.LastError()
+}
+
+// Stats returns the inner converter's counters.
+func (m *channelMapper) Stats() Stats {
+	return m.inner.Stats()
+}
+
+// Clone clones the inner converter; the mix matrix is shared, as it never changes.
+func (m *channelMapper) Clone() (Converter, error) {
+	inner, err := m.inner.Clone()
+	if err != nil {
+		return nil, err
+	}
+	c := &channelMapper{inner: inner.(converter), mix: m.mix, clock: m.clock, options: m.options, post: m.post.clone()}
+	reportAs(c.inner, c)
+	return c, nil
+}
+
+func (m *channelMapper) fail(err error) error {
+	m.lastErr = err
+	return err
+}
+
+// --- Callback Mode ---
+
+// mapperFeed is the callback user data of the inner converter in callback mode:
+// it calls the user's callback and downmixes its chunks when mixing pre-resampling.
+type mapperFeed struct {
+	cbFunc    CallbackFunc
+	userData  interface{}
+	mix       *channelMix
+	buf       []float32
+	nanPolicy NaNPolicy // Options.NaNPolicy, applied to the chunks before they are mixed
+	nanBuf    []float32
+}
+
+func mapperCallback(userData interface{}) ([]float32, int64, error) {
+	f := userData.(*mapperFeed)
+	data, frames, err := f.cbFunc(f.userData)
+	if f.nanPolicy != NaNPass && frames > 0 {
+		frames = minInt64(frames, int64(len(data)/f.mix.in))
+		clean, errCode := sanitizeInput(f.nanPolicy, data, int(frames)*f.mix.in, &f.nanBuf)
+		if errCode != ErrNoError {
+			return nil, 0, mapError(errCode)
+		}
+		data = clean
+	}
+	if !f.mix.pre() {
+		return data, frames, err
+	}
+	frames = minInt64(maxInt64(frames, 0), int64(len(data)/f.mix.in))
+	f.buf = growFloats(f.buf, int(frames)*f.mix.out)
+	f.mix.apply(f.buf, data, int(frames))
+	return f.buf, frames, err
+}
+
+// CloneUserData gives each converter clone its own buffer and, if supported, its
+// own copy of the user data.
+func (f *mapperFeed) CloneUserData() interface{} {
+	c := &mapperFeed{cbFunc: f.cbFunc, userData: f.userData, mix: f.mix, nanPolicy: f.nanPolicy}
+	if cloner, ok := f.userData.(UserDataCloner); ok {
+		c.userData = cloner.CloneUserData()
+	}
+	return c
+}
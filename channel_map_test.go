@@ -0,0 +1,173 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"testing"
+)
+
+// stereoTestSignal returns different tones on the left and right channels.
+func stereoTestSignal(frames int) []float32 {
+	left := genSine(frames, 440, 16000, 0.5)
+	right := genSine(frames, 1000, 16000, 0.3)
+	in := make([]float32, 2*frames)
+	for i := range left {
+		in[2*i], in[2*i+1] = left[i], right[i]
+	}
+	return in
+}
+
+func TestOutputChannelsDownmix(t *testing.T) {
+	in := stereoTestSignal(4000)
+	mono := make([]float32, len(in)/2)
+	for i := range mono {
+		var sum float32
+		sum += 0.5 * in[2*i]
+		sum += 0.5 * in[2*i+1]
+		mono[i] = sum
+	}
+
+	conv, err := NewWithOptions(SincMediumQuality, 2, Options{OutputChannels: 1})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	if conv.GetChannels() != 2 {
+		t.Errorf("GetChannels() = %d, want the input count 2", conv.GetChannels())
+	}
+	got := drain(t, conv, in, 2, 1, 0.5, 512)
+
+	ref, _ := New(SincMediumQuality, 1)
+	defer ref.Close()
+	want := drain(t, ref, mono, 1, 1, 0.5, 512)
+	if len(got) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sample %d is %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestOutputChannelsUpmixAndMatrix(t *testing.T) {
+	mono := genSine(3000, 300, 16000, 0.5)
+	ref, _ := New(Linear, 1)
+	want := drain(t, ref, mono, 1, 1, 1.5, 512)
+
+	up, err := NewWithOptions(Linear, 1, Options{OutputChannels: 2})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	got := drain(t, up, mono, 1, 2, 1.5, 512)
+	if len(got) != 2*len(want) {
+		t.Fatalf("got %d samples, want %d", len(got), 2*len(want))
+	}
+	for i, v := range want {
+		if got[2*i] != v || got[2*i+1] != v {
+			t.Fatalf("frame %d is (%g, %g), want %g on both channels", i, got[2*i], got[2*i+1], v)
+		}
+	}
+	// Effects run on the upmixed output
+	if err := SetEffects(up, Chain{NewGain(-6), NewFadeIn(10)}); err != nil {
+		t.Fatalf("SetEffects failed: %v", err)
+	}
+	up.Reset()
+	stereo := make([]float32, 2*len(want))
+	for i, v := range want {
+		stereo[2*i], stereo[2*i+1] = v, v
+	}
+	Chain{NewGain(-6), NewFadeIn(10)}.Apply(stereo, 2)
+	checkSameSamples(t, "effects after an upmix", drain(t, up, mono, 1, 2, 1.5, 512), stereo)
+
+	// Swapping channels through a custom matrix
+	in := stereoTestSignal(2000)
+	swap, err := NewWithOptions(ZeroOrderHold, 2, Options{ChannelMatrix: [][]float32{{0, 1}, {1, 0}}})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	swapped := drain(t, swap, in, 2, 2, 1.25, 512)
+	plain, _ := New(ZeroOrderHold, 2)
+	unswapped := drain(t, plain, in, 2, 2, 1.25, 512)
+	if len(swapped) != len(unswapped) {
+		t.Fatalf("got %d samples, want %d", len(swapped), len(unswapped))
+	}
+	for i := 0; i < len(swapped); i += 2 {
+		if swapped[i] != unswapped[i+1] || swapped[i+1] != unswapped[i] {
+			t.Fatalf("frame %d not swapped", i/2)
+		}
+	}
+
+	bad := []Options{
+		{OutputChannels: -1},
+		{OutputChannels: 1, ChannelMatrix: [][]float32{{1}}},
+		{OutputChannels: 2, ChannelMatrix: [][]float32{{1, 0}}},
+	}
+	for _, opts := range bad {
+		if _, err := NewWithOptions(Linear, 2, opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestOutputChannelsCallback(t *testing.T) {
+	in := stereoTestSignal(3000)
+	read := func(c Converter, outChannels int) []float32 {
+		var got []float32
+		for {
+			var n int64
+			var err error
+			got, n, err = CallbackReadAppend(c, 0.5, 100, got)
+			if err != nil {
+				t.Fatalf("CallbackReadAppend failed: %v", err)
+			}
+			if n == 0 {
+				if len(got)%outChannels != 0 {
+					t.Fatalf("read %d samples, not whole %d channel frames", len(got), outChannels)
+				}
+				return got
+			}
+		}
+	}
+	chunks := func(data []float32, channels int) CallbackFunc {
+		pos := 0
+		return func(interface{}) ([]float32, int64, error) {
+			end := minInt(pos+256*channels, len(data))
+			chunk := data[pos:end]
+			pos = end
+			return chunk, int64(len(chunk) / channels), nil
+		}
+	}
+
+	conv, err := CallbackNewWithOptions(chunks(in, 2), SincFastest, 2, nil, Options{OutputChannels: 1})
+	if err != nil {
+		t.Fatalf("CallbackNewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	got := read(conv, 1)
+
+	mono := make([]float32, len(in)/2)
+	for i := range mono {
+		var sum float32
+		sum += 0.5 * in[2*i]
+		sum += 0.5 * in[2*i+1]
+		mono[i] = sum
+	}
+	ref, _ := CallbackNew(chunks(mono, 1), SincFastest, 1, nil)
+	defer ref.Close()
+	want := read(ref, 1)
+	if len(got) != len(want) || len(want) == 0 {
+		t.Fatalf("got %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sample %d is %g, want %g", i, got[i], want[i])
+		}
+	}
+}
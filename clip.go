@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+const softClipKnee = 0.7 // SoftClip leaves samples up to ~-3 dBFS untouched
+
+// ClipStrategy selects how the mixers bring a loud mix back into [-1.0, 1.0]
+// before encoding it to integer samples.
+type ClipStrategy int
+
+const (
+	// HardClip clamps samples to [-1.0, 1.0] (the default, and the original
+	// behavior). Transparent below full scale, harsh when the mix is hot.
+	HardClip ClipStrategy = iota
+	// SoftClip passes samples up to 0.7 unchanged and bends larger ones along a
+	// tanh curve towards full scale, which saturates audibly more gently.
+	SoftClip
+	// Normalize scales a block down as a whole when its peak exceeds full scale,
+	// so nothing is distorted; the level may then change from block to block.
+	Normalize
+)
+
+// String returns the name of the strategy.
+func (c ClipStrategy) String() string {
+	switch c {
+	case HardClip:
+		return "hard clip"
+	case SoftClip:
+		return "soft clip"
+	case Normalize:
+		return "normalize"
+	default:
+		return fmt.Sprintf("ClipStrategy(%d)", int(c))
+	}
+}
+
+// IsValid reports whether c is one of the defined strategies.
+func (c ClipStrategy) IsValid() bool {
+	return c >= HardClip && c <= Normalize
+}
+
+// Apply limits samples to [-1.0, 1.0] in place. Unknown strategies hard clip.
+func (c ClipStrategy) Apply(samples []float32) {
+	switch c {
+	case SoftClip:
+		for i, v := range samples {
+			samples[i] = softClip(v)
+		}
+	case Normalize:
+		peak := 0.0
+		for _, v := range samples {
+			peak = maxFloat64(peak, math.Abs(float64(v)))
+		}
+		if peak <= 1.0 {
+			return
+		}
+		scale := float32(1.0 / peak)
+		for i := range samples {
+			samples[i] *= scale
+		}
+	default:
+		for i, v := range samples {
+			if v > 1.0 {
+				samples[i] = 1.0
+			} else if v < -1.0 {
+				samples[i] = -1.0
+			}
+		}
+	}
+}
+
+// CountClipped returns the number of samples beyond full scale, outside
+// [-1.0, 1.0]: the samples HardClip and the integer encoders clamp. A count
+// above zero means the gain staging leaves no headroom.
+func CountClipped(samples []float32) int {
+	n := 0
+	for _, v := range samples {
+		if v > 1.0 || v < -1.0 {
+			n++
+		}
+	}
+	return n
+}
+
+// softClip is the identity up to softClipKnee and follows tanh above it, with
+// a continuous slope and full scale as the asymptote.
+func softClip(x float32) float32 {
+	mag := math.Abs(float64(x))
+	if mag <= softClipKnee {
+		return x
+	}
+	y := softClipKnee + (1.0-softClipKnee)*math.Tanh((mag-softClipKnee)/(1.0-softClipKnee))
+	return float32(math.Copysign(y, float64(x)))
+}
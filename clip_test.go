@@ -0,0 +1,97 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestClipStrategyApply(t *testing.T) {
+	in := []float32{0.2, -0.5, 0.7, 0.9, -1.5, 3.0}
+
+	hard := append([]float32(nil), in...)
+	HardClip.Apply(hard)
+	for i, want := range []float32{0.2, -0.5, 0.7, 0.9, -1.0, 1.0} {
+		if hard[i] != want {
+			t.Errorf("HardClip: sample %d is %g, want %g", i, hard[i], want)
+		}
+	}
+
+	soft := append([]float32(nil), in...)
+	SoftClip.Apply(soft)
+	for i := 0; i < 3; i++ {
+		if soft[i] != in[i] {
+			t.Errorf("SoftClip changed sample %d below the knee: %g -> %g", i, in[i], soft[i])
+		}
+	}
+	if !(soft[3] > 0.7 && soft[3] < 0.9) || !(soft[4] < -0.7 && soft[4] > -1.0) || !(soft[5] > soft[3] && soft[5] < 1.0) {
+		t.Errorf("SoftClip did not saturate monotonically below full scale: %v", soft)
+	}
+	// Slope 1 at the knee: no kink
+	if d := softClip(softClipKnee+1e-4) - softClip(softClipKnee); math.Abs(float64(d)-1e-4) > 1e-6 {
+		t.Errorf("SoftClip slope at the knee is %g, want 1", d/1e-4)
+	}
+
+	norm := append([]float32(nil), in...)
+	Normalize.Apply(norm)
+	for i, v := range in {
+		if want := v / 3.0; math.Abs(float64(norm[i]-want)) > 1e-7 {
+			t.Errorf("Normalize: sample %d is %g, want %g", i, norm[i], want)
+		}
+	}
+	quiet := []float32{0.5, -0.25}
+	Normalize.Apply(quiet)
+	if quiet[0] != 0.5 || quiet[1] != -0.25 {
+		t.Errorf("Normalize changed a block below full scale: %v", quiet)
+	}
+}
+
+func TestCountClipped(t *testing.T) {
+	if n := CountClipped([]float32{0.2, 1.0, -1.0, 1.01, -3, 0}); n != 2 {
+		t.Errorf("CountClipped = %d, want 2", n)
+	}
+	if _, n := appendPCMFloatToS16LEBytesCounted(nil, []float32{1.5, -0.5, -2}); n != 2 {
+		t.Errorf("S16LE encoder clamped %d samples, want 2", n)
+	}
+}
+
+func TestConvertersReportClipping(t *testing.T) {
+	var n int
+	count := PCMOptions{OnClip: func(c, _ int) { n = c }}
+	hot := s16leTone(2400, 1000, 24000, 1.0)
+	if _, err := Resample24kHzTo16kHzWithOptions(hot, count); err != nil || n == 0 {
+		t.Errorf("Resample24kHzTo16kHzWithOptions: %d clipped, err %v; want overshoot clipped", n, err)
+	}
+	quiet := s16leTone(2400, 1000, 24000, 0.5)
+	out, err := Resample24kHzTo16kHzWithOptions(quiet, count)
+	if err != nil || n != 0 {
+		t.Errorf("Resample24kHzTo16kHzWithOptions: %d clipped at half scale, err %v", n, err)
+	}
+	if plain, _ := Resample24kHzTo16kHz(quiet); !bytes.Equal(plain, out) {
+		t.Error("Resample24kHzTo16kHzWithOptions output differs from Resample24kHzTo16kHz")
+	}
+
+	calls := 0
+	opts := UlawOptions{OnClip: func(c, samples int) {
+		calls++
+		if c == 0 || samples == 0 {
+			t.Errorf("ConvertPCMToUlaw: OnClip got %d of %d samples clipped for a full-scale tone", c, samples)
+		}
+	}}
+	if _, err := ConvertPCMToUlaw(hot, 24000, SincMediumQuality, opts); err != nil || calls != 1 {
+		t.Errorf("ConvertPCMToUlaw: OnClip called %d times, err %v", calls, err)
+	}
+
+	n = -1
+	if _, err := ConvertUlawToPCMWithOptions(encodeUlawTone(800, 440, 0.5), SincMediumQuality, count); err != nil || n != 0 {
+		t.Errorf("ConvertUlawToPCMWithOptions: %d clipped at half scale, err %v", n, err)
+	}
+}
@@ -0,0 +1,163 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"testing"
+)
+
+// cloneableSource is callback user data that supports UserDataCloner.
+type cloneableSource struct {
+	data     []float32
+	pos      int
+	chunkLen int
+}
+
+func (s *cloneableSource) CloneUserData() interface{} {
+	c := *s // data is read only, sharing it is fine
+	return &c
+}
+
+func cloneableSourceCallback(userData interface{}) ([]float32, int64, error) {
+	s := userData.(*cloneableSource)
+	if s.pos >= len(s.data) {
+		return nil, 0, nil
+	}
+	end := minInt(s.pos+s.chunkLen, len(s.data))
+	chunk := s.data[s.pos:end]
+	s.pos = end
+	return chunk, int64(len(chunk)), nil
+}
+
+// readAllCallback reads until the converter stops producing output.
+func readAllCallback(t *testing.T, c Converter, ratio float64, blockLen int64) []float32 {
+	t.Helper()
+	var out []float32
+	for {
+		var n int64
+		var err error
+		out, n, err = CallbackReadAppend(c, ratio, blockLen, out)
+		if err != nil {
+			t.Fatalf("CallbackReadAppend failed: %v", err)
+		}
+		if n == 0 {
+			return out
+		}
+	}
+}
+
+// TestCloneCallbackContinuesIdentically checks that a clone taken mid-stream produces
+// exactly the same remaining output as the original and as an uninterrupted run.
+func TestCloneCallbackContinuesIdentically(t *testing.T) {
+	input := make([]float32, 20000)
+	genWindowedSinesGo(1, []float64{0.07}, 0.9, input)
+	const ratio = 0.73
+	const blockLen = 97 // Odd size so the callback input is left partially consumed
+
+	converters := []ConverterType{SincFastest, SincMediumQuality, ZeroOrderHold, Linear}
+	for _, ct := range converters {
+		t.Run(GetName(ct), func(t *testing.T) {
+			// Reference: one uninterrupted run
+			refConv, err := CallbackNew(cloneableSourceCallback, ct, 1, &cloneableSource{data: input, chunkLen: 300})
+			if err != nil {
+				t.Fatalf("CallbackNew failed: %v", err)
+			}
+			reference := readAllCallback(t, refConv, ratio, blockLen)
+
+			orig, err := CallbackNew(cloneableSourceCallback, ct, 1, &cloneableSource{data: input, chunkLen: 300})
+			if err != nil {
+				t.Fatalf("CallbackNew failed: %v", err)
+			}
+			head := make([]float32, blockLen*10)
+			var headLen int64
+			for i := 0; i < 10; i++ {
+				n, err := CallbackRead(orig, ratio, blockLen, head[headLen:])
+				if err != nil {
+					t.Fatalf("CallbackRead failed: %v", err)
+				}
+				headLen += n
+			}
+			if orig.(*srcState).savedFrames == 0 {
+				t.Fatalf("test setup: expected buffered callback input before cloning")
+			}
+
+			clone, err := orig.Clone()
+			if err != nil {
+				t.Fatalf("Clone failed: %v", err)
+			}
+
+			// Interleave reads on both so any shared buffer would be corrupted
+			var tailOrig, tailClone []float32
+			bufA := make([]float32, blockLen)
+			bufB := make([]float32, blockLen)
+			for {
+				nA, errA := CallbackRead(orig, ratio, blockLen, bufA)
+				nB, errB := CallbackRead(clone, ratio, blockLen, bufB)
+				if errA != nil || errB != nil {
+					t.Fatalf("CallbackRead failed: %v / %v", errA, errB)
+				}
+				tailOrig = append(tailOrig, bufA[:nA]...)
+				tailClone = append(tailClone, bufB[:nB]...)
+				if nA == 0 && nB == 0 {
+					break
+				}
+			}
+
+			if len(tailOrig) != len(tailClone) {
+				t.Fatalf("original produced %d frames after clone, clone produced %d", len(tailOrig), len(tailClone))
+			}
+			for i := range tailOrig {
+				if tailOrig[i] != tailClone[i] {
+					t.Fatalf("frame %d differs: original %g, clone %g", i, tailOrig[i], tailClone[i])
+				}
+			}
+
+			full := append(head[:headLen:headLen], tailClone...)
+			if len(full) != len(reference) {
+				t.Fatalf("clone stream length %d, reference %d", len(full), len(reference))
+			}
+			for i := range full {
+				if full[i] != reference[i] {
+					t.Fatalf("frame %d differs from uninterrupted run: %g vs %g", i, full[i], reference[i])
+				}
+			}
+		})
+	}
+}
+
+// TestCloneCallbackSharedUserData checks user data without UserDataCloner is shared.
+func TestCloneCallbackSharedUserData(t *testing.T) {
+	type plainSource struct{ calls int }
+	src := &plainSource{}
+	cb := func(userData interface{}) ([]float32, int64, error) {
+		userData.(*plainSource).calls++
+		return nil, 0, nil
+	}
+	orig, err := CallbackNew(cb, Linear, 1, src)
+	if err != nil {
+		t.Fatalf("CallbackNew failed: %v", err)
+	}
+	clone, err := orig.Clone()
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if clone.(*srcState).userCallbackData != interface{}(src) {
+		t.Errorf("expected user data to be shared")
+	}
+	if clone.(*srcState).mode != ModeCallback || clone.(*srcState).callbackFunc == nil {
+		t.Errorf("clone lost callback mode or callback function")
+	}
+	buf := make([]float32, 8)
+	if _, err := CallbackRead(clone, 1.0, 8, buf); err != nil {
+		t.Fatalf("CallbackRead on clone failed: %v", err)
+	}
+	if src.calls == 0 {
+		t.Errorf("clone did not call the shared callback")
+	}
+}
@@ -0,0 +1,204 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+// src-bench compares the converters on synthetic signals, to help choose a
+// quality level with real numbers:
+//
+//	go run ./cmd/src-bench -ratios 0.1667,0.5,2 -png /tmp/spectrograms
+//
+// For each enabled converter and ratio it prints:
+//
+//	Mframes/s  input frames converted per second, on a logarithmic sweep
+//	realtime   how many times faster than realtime that is at -rate
+//	SNR        signal to noise and distortion of a resampled sine, in dB
+//	bandwidth  -3 dB point, in % of the lower of the input and output Nyquist
+//
+// With -png, a spectrogram of each resampled sweep is written as
+// <converter>-<ratio>.png; aliasing shows up as lines folding back from the top.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// config holds the benchmark settings.
+type config struct {
+	converters []libsamplerate.ConverterType // Empty means every enabled converter
+	ratios     []float64
+	rate       float64       // Input rate of the sweep, for the realtime factor
+	duration   time.Duration // Length of the sweep
+	pngDir     string        // Empty means no spectrograms
+}
+
+// result is one row of the table.
+type result struct {
+	converter libsamplerate.ConverterInfo
+	ratio     float64
+	mfps      float64 // Input Mframes per second
+	realtime  float64
+	snr       float64
+	bandwidth float64 // -1 if the -3 dB point could not be bracketed
+}
+
+// run benchmarks every converter at every ratio, writes the table to w and
+// returns the paths of the spectrograms written.
+func run(cfg config, w io.Writer) ([]string, error) {
+	infos, err := selectConverters(cfg.converters)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range cfg.ratios {
+		if !libsamplerate.IsValidRatio(r) {
+			return nil, fmt.Errorf("invalid ratio %g", r)
+		}
+	}
+	frames := int(cfg.rate * cfg.duration.Seconds())
+	if frames <= 0 {
+		return nil, fmt.Errorf("sweep of %v at %g Hz is empty", cfg.duration, cfg.rate)
+	}
+	sweep := logSweep(frames)
+
+	var written []string
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "converter\tratio\tMframes/s\trealtime\tSNR dB\tbandwidth %\t")
+	for _, info := range infos {
+		for _, ratio := range cfg.ratios {
+			res := result{converter: info, ratio: ratio}
+			out, elapsed, err := convert(info.Type, sweep, ratio)
+			if err != nil {
+				return nil, fmt.Errorf("%s at %g: %w", info.Name, ratio, err)
+			}
+			res.mfps = float64(frames) / elapsed.Seconds() / 1e6
+			res.realtime = cfg.duration.Seconds() / elapsed.Seconds()
+			if res.snr, err = measureSNR(info.Type, ratio); err != nil {
+				return nil, fmt.Errorf("%s at %g: %w", info.Name, ratio, err)
+			}
+			if res.bandwidth, err = measureBandwidth(info.Type, ratio); err != nil {
+				return nil, fmt.Errorf("%s at %g: %w", info.Name, ratio, err)
+			}
+			printResult(tw, res)
+
+			if cfg.pngDir != "" {
+				path := filepath.Join(cfg.pngDir, fmt.Sprintf("%s-%g.png", shortName(info.Type), ratio))
+				if err := writeSpectrogram(path, out); err != nil {
+					return nil, err
+				}
+				written = append(written, path)
+			}
+		}
+	}
+	return written, tw.Flush()
+}
+
+func printResult(w io.Writer, r result) {
+	bandwidth := "-"
+	if r.bandwidth >= 0 {
+		bandwidth = fmt.Sprintf("%.1f", r.bandwidth)
+	}
+	fmt.Fprintf(w, "%s\t%g\t%.2f\t%.0fx\t%.1f\t%s\t\n", r.converter.Name, r.ratio, r.mfps, r.realtime, r.snr, bandwidth)
+}
+
+// selectConverters returns the infos of the requested converters, or of every
+// enabled one when types is empty.
+func selectConverters(types []libsamplerate.ConverterType) ([]libsamplerate.ConverterInfo, error) {
+	var infos []libsamplerate.ConverterInfo
+	for _, info := range libsamplerate.ListConverters() {
+		if len(types) == 0 && info.Enabled {
+			infos = append(infos, info)
+		}
+	}
+	for _, ct := range types {
+		found := false
+		for _, info := range libsamplerate.ListConverters() {
+			if info.Type == ct {
+				if !info.Enabled {
+					return nil, fmt.Errorf("converter %s is not compiled in", info.Name)
+				}
+				infos, found = append(infos, info), true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown converter %d", ct)
+		}
+	}
+	return infos, nil
+}
+
+// shortName names a converter in file names.
+func shortName(ct libsamplerate.ConverterType) string {
+	switch ct {
+	case libsamplerate.SincBestQuality:
+		return "sinc-best"
+	case libsamplerate.SincMediumQuality:
+		return "sinc-medium"
+	case libsamplerate.SincFastest:
+		return "sinc-fastest"
+	case libsamplerate.ZeroOrderHold:
+		return "zoh"
+	case libsamplerate.Linear:
+		return "linear"
+	default:
+		return strconv.Itoa(int(ct))
+	}
+}
+
+// splitList splits a comma separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func main() {
+	var cfg config
+	var converters, ratios string
+	flag.StringVar(&converters, "converters", "", "converter types to compare, 0 (best sinc) to 4 (linear), comma separated (default: all enabled)")
+	flag.StringVar(&ratios, "ratios", "0.1667,0.5,0.9,1.5,2", "output/input ratios, comma separated")
+	flag.Float64Var(&cfg.rate, "rate", 48000, "input sample rate in Hz, for the realtime factor")
+	flag.DurationVar(&cfg.duration, "duration", 2*time.Second, "length of the throughput sweep")
+	flag.StringVar(&cfg.pngDir, "png", "", "directory to write spectrogram PNGs to (default: none)")
+	flag.Parse()
+
+	for _, item := range splitList(converters) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			log.Fatalf("-converters: %v", err)
+		}
+		cfg.converters = append(cfg.converters, libsamplerate.ConverterType(n))
+	}
+	for _, item := range splitList(ratios) {
+		r, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			log.Fatalf("-ratios: %v", err)
+		}
+		cfg.ratios = append(cfg.ratios, r)
+	}
+
+	written, err := run(cfg, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, path := range written {
+		log.Println("wrote", path)
+	}
+}
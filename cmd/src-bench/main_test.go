@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// TestRun benchmarks the fast converters briefly and checks the table and the
+// spectrograms.
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	cfg := config{
+		converters: []libsamplerate.ConverterType{libsamplerate.SincFastest, libsamplerate.Linear},
+		ratios:     []float64{0.5, 2},
+		rate:       48000,
+		duration:   100 * time.Millisecond,
+		pngDir:     t.TempDir(),
+	}
+	written, err := run(cfg, &out)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want a header and 4 rows:\n%s", len(lines), out.String())
+	}
+	if fields := strings.Fields(lines[2]); fields[len(fields)-1] != "80.2" { // Fastest Sinc at ratio 2
+		t.Errorf("unexpected bandwidth in %q", lines[2])
+	}
+	if len(written) != 4 {
+		t.Fatalf("wrote %d spectrograms, want 4", len(written))
+	}
+	f, err := os.Open(written[0])
+	if err != nil {
+		t.Fatalf("missing spectrogram: %v", err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("spectrogram is not a PNG: %v", err)
+	}
+	if h := img.Bounds().Dy(); h != specWindow/2 {
+		t.Errorf("spectrogram is %d pixels high, want %d", h, specWindow/2)
+	}
+}
+
+func TestMeasureSNR(t *testing.T) {
+	best, err := measureSNR(libsamplerate.SincFastest, 0.9)
+	if err != nil {
+		t.Fatalf("measureSNR failed: %v", err)
+	}
+	linear, _ := measureSNR(libsamplerate.Linear, 0.9)
+	if best < 90 || linear > 40 {
+		t.Errorf("SNR %.1f dB for sinc and %.1f dB for linear, want > 90 and < 40", best, linear)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, cfg := range []config{
+		{converters: []libsamplerate.ConverterType{9}, ratios: []float64{2}, rate: 48000, duration: time.Second},
+		{ratios: []float64{1000}, rate: 48000, duration: time.Second},
+		{ratios: []float64{2}, rate: 48000},
+	} {
+		if _, err := run(cfg, &bytes.Buffer{}); err == nil {
+			t.Errorf("%+v: expected error", cfg)
+		}
+	}
+}
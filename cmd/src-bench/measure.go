@@ -0,0 +1,207 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+	"gonum.org/v1/gonum/dsp/fourier"
+)
+
+const (
+	blockFrames  = 4096  // Input frames per Process call when timing
+	snrFFTLen    = 16384 // Output samples analyzed for the SNR
+	snrPeakBins  = 6     // Bins each side of the peak counted as signal (Blackman-Harris main lobe)
+	snrDCBins    = 4     // Bins near DC left out of the noise
+	toneAmp      = 0.5
+	bandwidthLo  = 0.3   // Search range of the -3 dB point, as a fraction of the lower Nyquist
+	bandwidthHi  = 0.999 //
+	bandwidthIts = 16
+)
+
+// logSweep returns a logarithmic sine sweep from 20 Hz to 0.45 of the sample
+// rate, at 48kHz, over frames samples.
+func logSweep(frames int) []float32 {
+	f0, f1 := 20.0/48000.0, 0.45
+	k := math.Log(f1 / f0)
+	out := make([]float32, frames)
+	for i := range out {
+		t := float64(i) / float64(frames)
+		phase := 2 * math.Pi * f0 * float64(frames) / k * (math.Exp(t*k) - 1)
+		out[i] = float32(toneAmp * math.Sin(phase))
+	}
+	return out
+}
+
+// sine returns frames samples of a sine at freq cycles per sample.
+func sine(frames int, freq float64) []float32 {
+	out := make([]float32, frames)
+	for i := range out {
+		out[i] = float32(toneAmp * math.Sin(2*math.Pi*freq*float64(i)))
+	}
+	return out
+}
+
+// convert resamples in by ratio in blocks, as a streaming user would, and
+// returns the output and the time spent in Process.
+func convert(ct libsamplerate.ConverterType, in []float32, ratio float64) ([]float32, time.Duration, error) {
+	conv, err := libsamplerate.New(ct, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conv.Close()
+
+	outBuf := make([]float32, int(math.Ceil(blockFrames*ratio))+64)
+	out := make([]float32, 0, int(float64(len(in))*ratio)+64)
+	var elapsed time.Duration
+	for pos := 0; ; {
+		end := minInt(pos+blockFrames, len(in))
+		data := libsamplerate.SrcData{
+			DataIn:       in[pos:end],
+			InputFrames:  int64(end - pos),
+			DataOut:      outBuf,
+			OutputFrames: int64(len(outBuf)),
+			SrcRatio:     ratio,
+			EndOfInput:   end == len(in),
+		}
+		start := time.Now()
+		err := conv.Process(&data)
+		elapsed += time.Since(start)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, outBuf[:data.OutputFramesGen]...)
+		pos += int(data.InputFramesUsed)
+		if end == len(in) && data.InputFramesUsed == data.InputFrames && data.OutputFramesGen == 0 {
+			return out, elapsed, nil
+		}
+	}
+}
+
+// measureSNR resamples a sine in the pass band and returns the ratio of its
+// power to everything else in the output spectrum (noise and distortion), in dB.
+func measureSNR(ct libsamplerate.ConverterType, ratio float64) (float64, error) {
+	// Centre the tone on an FFT bin so only the window main lobe holds signal
+	bin := math.Round(0.2 * math.Min(1, ratio) / ratio * snrFFTLen)
+	inFrames := int(math.Ceil(2*snrFFTLen/ratio)) + 4096
+	out, _, err := convert(ct, sine(inFrames, bin/snrFFTLen*ratio), ratio)
+	if err != nil {
+		return 0, err
+	}
+	if len(out) < snrFFTLen {
+		return 0, fmt.Errorf("only %d output samples for the SNR", len(out))
+	}
+	start := (len(out) - snrFFTLen) / 2 // Steady state, away from the edges
+	seg := make([]float64, snrFFTLen)
+	for i := range seg {
+		seg[i] = float64(out[start+i]) * blackmanHarris(i, snrFFTLen)
+	}
+	coeffs := fourier.NewFFT(snrFFTLen).Coefficients(nil, seg)
+
+	power := make([]float64, len(coeffs))
+	peak := snrDCBins
+	for k, c := range coeffs {
+		power[k] = real(c)*real(c) + imag(c)*imag(c)
+		if k >= snrDCBins && power[k] > power[peak] {
+			peak = k
+		}
+	}
+	var signal, noise float64
+	for k := snrDCBins; k < len(power); k++ {
+		if k >= peak-snrPeakBins && k <= peak+snrPeakBins {
+			signal += power[k]
+		} else {
+			noise += power[k]
+		}
+	}
+	if noise == 0 {
+		return math.Inf(1), nil
+	}
+	return 10 * math.Log10(signal/noise), nil
+}
+
+// measureBandwidth returns the -3 dB frequency of the converter at ratio, in %
+// of the lower of the input and output Nyquist frequencies, or -1 if the
+// response does not cross -3 dB in the searched range.
+func measureBandwidth(ct libsamplerate.ConverterType, ratio float64) (float64, error) {
+	lo, hi := bandwidthLo, bandwidthHi
+	aLo, err := attenuation(ct, ratio, lo)
+	if err != nil {
+		return 0, err
+	}
+	aHi, err := attenuation(ct, ratio, hi)
+	if err != nil {
+		return 0, err
+	}
+	if aLo > 3 || aHi < 3 {
+		return -1, nil
+	}
+	for i := 0; i < bandwidthIts && aHi-aLo > 0.1; i++ {
+		mid := (lo + hi) / 2
+		a, err := attenuation(ct, ratio, mid)
+		if err != nil {
+			return 0, err
+		}
+		if a < 3 {
+			lo, aLo = mid, a
+		} else {
+			hi, aHi = mid, a
+		}
+	}
+	return 100 * (lo + (3-aLo)*(hi-lo)/(aHi-aLo)), nil
+}
+
+// attenuation returns how much the converter attenuates a sine at fraction of
+// the lower Nyquist frequency, in dB.
+func attenuation(ct libsamplerate.ConverterType, ratio, fraction float64) (float64, error) {
+	freq := fraction * 0.5 * math.Min(1, ratio) // Cycles per input sample
+	inFrames := int(math.Ceil(8192/ratio)) + 4096
+	out, _, err := convert(ct, sine(inFrames, freq), ratio)
+	if err != nil {
+		return 0, err
+	}
+	n := len(out) / 2
+	seg := out[(len(out)-n)/2:][:n]
+	amp := toneAmplitude(seg, freq/ratio)
+	if amp <= 0 {
+		return math.Inf(1), nil
+	}
+	return -20 * math.Log10(amp/toneAmp), nil
+}
+
+// toneAmplitude estimates the amplitude of the sine at freq cycles per sample
+// in x, with a Hann window.
+func toneAmplitude(x []float32, freq float64) float64 {
+	var sum complex128
+	var wsum float64
+	for i, v := range x {
+		w := 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(len(x)-1))
+		sum += complex(float64(v)*w, 0) * cmplx.Exp(complex(0, -2*math.Pi*freq*float64(i)))
+		wsum += w
+	}
+	return 2 * cmplx.Abs(sum) / wsum
+}
+
+// blackmanHarris returns sample i of an n point periodic 4-term Blackman-Harris
+// window (-92 dB sidelobes).
+func blackmanHarris(i, n int) float64 {
+	x := 2 * math.Pi * float64(i) / float64(n)
+	return 0.35875 - 0.48829*math.Cos(x) + 0.14128*math.Cos(2*x) - 0.01168*math.Cos(3*x)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+
+	"gonum.org/v1/gonum/dsp/fourier"
+)
+
+const (
+	specWindow = 512 // FFT length, the image is specWindow/2 pixels high
+	specHop    = 256
+	specMaxCol = 1600   // Wider outputs are hopped further to keep the image this wide
+	specFloor  = -140.0 // dB shown as black
+)
+
+// writeSpectrogram writes a PNG spectrogram of x: time left to right, frequency
+// (0 to Nyquist) bottom to top, level in dB from black (specFloor) to white
+// (full scale).
+func writeSpectrogram(path string, x []float32) error {
+	hop := specHop
+	if cols := (len(x) - specWindow) / hop; cols > specMaxCol {
+		hop = (len(x) - specWindow) / specMaxCol
+	}
+	cols := 1
+	if len(x) > specWindow {
+		cols = (len(x)-specWindow)/hop + 1
+	}
+	rows := specWindow / 2
+	img := image.NewGray(image.Rect(0, 0, cols, rows))
+
+	fft := fourier.NewFFT(specWindow)
+	frame := make([]float64, specWindow)
+	var coeffs []complex128
+	var wsum float64
+	for i := range frame {
+		wsum += blackmanHarris(i, specWindow)
+	}
+	for c := 0; c < cols; c++ {
+		for i := range frame {
+			frame[i] = 0
+			if j := c*hop + i; j < len(x) {
+				frame[i] = float64(x[j]) * blackmanHarris(i, specWindow)
+			}
+		}
+		coeffs = fft.Coefficients(coeffs, frame)
+		for r := 0; r < rows; r++ {
+			mag := 2 * math.Hypot(real(coeffs[r]), imag(coeffs[r])) / wsum
+			db := specFloor
+			if mag > 0 {
+				db = math.Max(20*math.Log10(mag), specFloor)
+			}
+			level := 255 * (1 - math.Min(db, 0)/specFloor)
+			img.SetGray(c, rows-1-r, color.Gray{Y: uint8(level)})
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,4 +1,3 @@
-
 //
 // Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
 // All rights reserved.
@@ -23,7 +22,9 @@ type SrcData struct {
 	// DataOut is the output buffer.
 	DataOut []float32 // float *data_out
 
-	// InputFrames is the number of frames available in DataIn.
+	// InputFrames is the number of frames available in DataIn. It may be 0 with
+	// EndOfInput false, e.g. when upstream has no data yet: DataIn is then ignored
+	// and the call only returns output the converter still holds, if any.
 	InputFrames int64 // long input_frames
 
 	// OutputFrames is the maximum number of frames that can be written to DataOut.
@@ -38,7 +39,12 @@ type SrcData struct {
 	OutputFramesGen int64 // long output_frames_gen
 
 	// EndOfInput should be set to true if this is the last block
-	// of input data, otherwise false.
+	// of input data, otherwise false. Once it is set and the converter is
+	// drained, a stream of n frames at a fixed ratio has given round(n*ratio)
+	// frames within one, with every converter type: the sinc converters flush
+	// their filter tail, Linear and ZeroOrderHold stop at the last input frame.
+	// The ratio may change during the drain (with SetRatio or SrcRatio) and
+	// applies to the input not yet converted.
 	EndOfInput bool // int end_of_input (using bool)
 
 	// SrcRatio is the desired conversion ratio (output_sample_rate / input_sample_rate).
@@ -52,6 +58,14 @@ type SrcData struct {
 // This signature provides a more Go-idiomatic way for the callback to provide data.
 type CallbackFunc func(userData interface{}) (data []float32, framesRead int64, err error)
 
+// UserDataCloner can be implemented by callback user data that holds per-stream
+// state (e.g. a read position). Clone() on a callback-mode converter calls
+// CloneUserData so the clone pulls input independently of the original.
+// User data that does not implement it is shared between the clones.
+type UserDataCloner interface {
+	CloneUserData() interface{}
+}
+
 // srcState holds the internal state for a converter instance.
 // Corresponds to SRC_STATE_tag in common.h
 type srcState struct {
@@ -60,8 +74,9 @@ type srcState struct {
 	lastRatio    float64 // Previously used ratio
 	lastPosition float64 // Position across buffer boundaries (0.0 to < 1.0)
 
-	errCode  ErrorCode // Last error encountered (internal)
-	channels int       // Number of channels
+	errCode       ErrorCode     // Last error encountered (internal)
+	channels      int           // Number of channels
+	converterType ConverterType // Converter type the state was created with
 
 	mode Mode // Current operating mode (Process or Callback)
 
@@ -70,6 +85,44 @@ type srcState struct {
 	userCallbackData interface{}  // User data passed to the callback function
 	savedFrames      int64        // Frames remaining from the last callback read
 	savedData        []float32    // Slice pointing to remaining data from last callback
+	callbackEOF      bool         // Callback signalled end of input (zero frames or io.EOF)
+
+	// --- Pre-Filter ---
+	preFilter      PreFilter // Optional per-channel hook run on the input, see SetPreFilter
+	preBuf         []float32 // Filtered copy of the input handed to the converter
+	preChannel     []float32 // One channel of the input, passed to preFilter
+	preCarry       []float32 // Filtered frames the converter did not consume
+	preCarryFrames int64
+
+	// --- Timed Input ---
+	clock clockEstimator // Input rate measured by ProcessTimed
+
+	// --- S16LE Input ---
+	s16In  []byte    // Input of ProcessFromS16LE in place of DataIn, during the call
+	s16Buf []float32 // s16In decoded, for the paths that cannot read it directly
+
+	// --- Options ---
+	options Options   // See NewWithOptions
+	nanBuf  []float32 // Sanitized copy of the input, for NaNZero
+	post    postChain // Output processing of Options and SetEffects
+
+	// --- Leading Transient ---
+	trimLeft  int64 // Output frames still to drop for Options.TrimLeadingTransient
+	trimKnown bool  // trimLeft was worked out since the last reset
+	trimLast  int64 // Frames dropped by the last Process call
+
+	// --- Passthrough ---
+	filtered bool // The filter has run since the last reset, so ratio 1.0 can no longer be copied through
+
+	// --- Exact Ratio ---
+	rational rationalRatio // See SetRatioRational
+
+	// --- Monitoring ---
+	stats    Stats     // Cumulative counters, see Stats()
+	flushing bool      // Last Process call had EndOfInput set
+	name     string    // Label for DumpState, see SetName
+	outer    Converter // Wrapper the metrics report this state's Process calls as, see reportAs
+	quiet    bool      // Another group of the wrapper reports the calls
 
 	// --- Converter Specific Data ---
 	// Use interface{} to hold the specific filter state (e.g., *sincFilter)
@@ -134,6 +187,9 @@ const (
 	ErrNoVariableRatio       // Specific converter limitation
 	ErrSincPrepareDataBadLen // Internal Sinc error
 	ErrBadInternalState      // Catch-all internal
+	ErrNonFiniteInput        // NaN or Inf input rejected by NaNError
+	ErrOutputFull            // Output buffer filled before the input was consumed, see Options.ErrorOnOutputFull
+	ErrShortOutput           // Output buffer too small for the frames requested from CallbackRead
 
 	// ErrMaxError // Placeholder for the end
 )
@@ -147,11 +203,9 @@ const (
 
 // --- Internal Helper Functions (from common.h) ---
 
-// // psfLrint rounds a float64 to the nearest integer (like C lrint).
+// psfLrint rounds a float64 to the nearest integer. math.Round rounds half away
+// from zero; C lrint rounds half to even by default (see RoundingMode).
 func psfLrint(x float64) int {
-	// Go's math.Round rounds half to even, C's lrint typically rounds half away from zero.
-	// Depending on requirements, math.Round might be sufficient, or a custom impl needed.
-	// Let's use math.Round for now. Add 0.0 to handle -0.0 case if needed.
 	return int(math.Round(x + 0.0))
 }
 
@@ -219,6 +273,13 @@ func maxInt(a, b int) int {
 	return b
 }
 
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func minFloat64(a, b float64) float64 {
 	if a < b {
 		return a
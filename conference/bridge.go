@@ -0,0 +1,330 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+// Package conference mixes the audio of several call participants, each at its
+// own sample rate and encoding (e.g. 8kHz u-law PSTN legs with 48kHz WebRTC
+// legs): every participant's audio is resampled to a common mixing rate, each
+// participant gets the mix of everybody else (N-1, without their own voice), and
+// that mix is resampled and encoded back to the participant's format. It uses
+// libsamplerate only through its public API.
+package conference
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// --- Defaults ---
+const (
+	DefaultMixRate       = 16000.0               // Wideband, used when BridgeConfig.MixRate is 0
+	DefaultFrameDuration = 20 * time.Millisecond // One RTP packet of audio
+	DefaultMaxBuffered   = 10                    // Frames queued per participant before the oldest audio is dropped
+)
+
+// BridgeConfig holds the settings of a Bridge.
+type BridgeConfig struct {
+	// MixRate is the rate the audio is mixed at, in Hz; 0 means DefaultMixRate.
+	// Participants at a higher rate are band-limited to MixRate/2.
+	MixRate float64
+	// FrameDuration is the audio produced per participant by each Mix call; 0
+	// means DefaultFrameDuration.
+	FrameDuration time.Duration
+	// ConverterType used by every resampler; the zero value is SincBestQuality.
+	// SincFastest keeps the CPU cost of large bridges down.
+	ConverterType libsamplerate.ConverterType
+	// Gain scales every N-1 mix, e.g. 0.7 to leave headroom when several people
+	// talk at once; 0 means 1.0.
+	Gain float32
+	// Clip selects how mixes louder than full scale are limited before encoding;
+	// the zero value is HardClip.
+	Clip libsamplerate.ClipStrategy
+	// MaxBuffered is the number of frames of audio queued per participant before
+	// the oldest is dropped (ParticipantStats.Dropped); 0 means DefaultMaxBuffered.
+	MaxBuffered int
+	// Workers limits the goroutines rendering the mixes of one Mix call; 0 means
+	// GOMAXPROCS.
+	Workers int
+}
+
+func (cfg *BridgeConfig) validate() error {
+	if cfg.MixRate == 0 {
+		cfg.MixRate = DefaultMixRate
+	}
+	if cfg.FrameDuration == 0 {
+		cfg.FrameDuration = DefaultFrameDuration
+	}
+	if cfg.Gain == 0 {
+		cfg.Gain = 1
+	}
+	if cfg.MaxBuffered == 0 {
+		cfg.MaxBuffered = DefaultMaxBuffered
+	}
+	if cfg.Workers == 0 {
+		cfg.Workers = runtime.GOMAXPROCS(0)
+	}
+	if cfg.MixRate < 0 {
+		return fmt.Errorf("mix rate must be positive, got %g", cfg.MixRate)
+	}
+	if cfg.FrameDuration < 0 || cfg.frameSamples() == 0 {
+		return fmt.Errorf("frame duration %v holds no sample at %g Hz", cfg.FrameDuration, cfg.MixRate)
+	}
+	if cfg.Gain < 0 {
+		return fmt.Errorf("gain must be positive, got %g", cfg.Gain)
+	}
+	if !cfg.Clip.IsValid() {
+		return fmt.Errorf("unknown clip strategy %v", cfg.Clip)
+	}
+	if cfg.MaxBuffered < 0 {
+		return fmt.Errorf("MaxBuffered must not be negative, got %d", cfg.MaxBuffered)
+	}
+	if cfg.Workers < 0 {
+		return fmt.Errorf("Workers must not be negative, got %d", cfg.Workers)
+	}
+	return nil
+}
+
+// frameSamples returns the number of mixing-rate samples in one frame.
+func (cfg *BridgeConfig) frameSamples() int {
+	return int(cfg.MixRate * cfg.FrameDuration.Seconds())
+}
+
+// Bridge is a conference bridge. Participants Join and Leave at any time, Write
+// their audio as it arrives, and a clock (e.g. a 20ms ticker) calls Mix to get
+// one frame of output per participant.
+//
+// Write may be called from one goroutine per participant, concurrently with each
+// other and with Mix, Join and Leave. The per-participant work of Mix (resampling
+// and encoding) runs on up to Workers goroutines.
+type Bridge struct {
+	cfg          BridgeConfig
+	frameSamples int
+
+	mu           sync.RWMutex // Guards participants
+	participants map[string]*participant
+
+	mixMu sync.Mutex // Serializes Mix calls
+	total []float32  // Sum of all participants' frames
+}
+
+// NewBridge creates an empty bridge.
+func NewBridge(cfg BridgeConfig) (*Bridge, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	n := cfg.frameSamples()
+	return &Bridge{
+		cfg:          cfg,
+		frameSamples: n,
+		participants: make(map[string]*participant),
+		total:        make([]float32, n),
+	}, nil
+}
+
+// Config returns the configuration of the bridge, with defaults filled in.
+func (b *Bridge) Config() BridgeConfig {
+	return b.cfg
+}
+
+// Join adds a participant. It hears the others from the next Mix call.
+func (b *Bridge) Join(cfg ParticipantConfig) error {
+	if cfg.ID == "" {
+		return fmt.Errorf("participant needs an ID")
+	}
+	if cfg.Encoding.bytesPerSample() == 0 {
+		return fmt.Errorf("participant %q: unknown encoding %v", cfg.ID, cfg.Encoding)
+	}
+	if !libsamplerate.IsValidRatio(b.cfg.MixRate / cfg.Rate) {
+		return fmt.Errorf("participant %q: unsupported rate %g Hz for mixing at %g Hz", cfg.ID, cfg.Rate, b.cfg.MixRate)
+	}
+	p, err := newParticipant(cfg, b.cfg.MixRate, b.cfg.ConverterType, b.frameSamples, b.cfg.MaxBuffered*b.frameSamples)
+	if err != nil {
+		return fmt.Errorf("participant %q: %w", cfg.ID, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.participants[cfg.ID]; ok {
+		p.close()
+		return fmt.Errorf("participant %q already joined", cfg.ID)
+	}
+	b.participants[cfg.ID] = p
+	return nil
+}
+
+// Leave removes a participant and releases its resamplers.
+func (b *Bridge) Leave(id string) error {
+	b.mu.Lock()
+	p, ok := b.participants[id]
+	delete(b.participants, id)
+	b.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown participant %q", id)
+	}
+	return p.close()
+}
+
+// Participants returns the IDs of the participants, sorted.
+func (b *Bridge) Participants() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	ids := make([]string, 0, len(b.participants))
+	for id := range b.participants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Stats returns the counters of a participant.
+func (b *Bridge) Stats(id string) (ParticipantStats, error) {
+	b.mu.RLock()
+	p, ok := b.participants[id]
+	b.mu.RUnlock()
+	if !ok {
+		return ParticipantStats{}, fmt.Errorf("unknown participant %q", id)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats, nil
+}
+
+// Write queues audio from a participant, in its encoding at its rate. Any block
+// size works; Mix takes one frame of it per call.
+func (b *Bridge) Write(id string, audio []byte) error {
+	b.mu.RLock()
+	p, ok := b.participants[id]
+	b.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown participant %q", id)
+	}
+	if err := p.write(audio); err != nil {
+		return fmt.Errorf("participant %q: %w", id, err)
+	}
+	return nil
+}
+
+// Mix produces the next frame: for every participant, the mix of all the others
+// in its rate and encoding, keyed by participant ID. A participant that sent too
+// little audio is mixed with silence for the missing part. Output lengths vary by
+// a sample from frame to frame at rates that do not divide evenly, and the first
+// frames are a little shorter because of the resampler delay.
+func (b *Bridge) Mix() (map[string][]byte, error) {
+	b.mixMu.Lock()
+	defer b.mixMu.Unlock()
+	b.mu.RLock() // Join and Leave wait for the frame, Write does not
+	defer b.mu.RUnlock()
+	ps := make([]*participant, 0, len(b.participants))
+	for _, p := range b.participants {
+		ps = append(ps, p)
+	}
+
+	for _, p := range ps {
+		p.take()
+	}
+	b.sumFrames(ps)
+
+	outs := make([][]byte, len(ps))
+	err := b.parallel(len(ps), func(i int) error {
+		out, err := ps[i].render(b.total, b.cfg.Gain, b.cfg.Clip)
+		if err != nil {
+			return fmt.Errorf("participant %q: %w", ps[i].cfg.ID, err)
+		}
+		outs[i] = out
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]byte, len(ps))
+	for i, p := range ps {
+		result[p.cfg.ID] = outs[i]
+	}
+	return result, nil
+}
+
+// sumFrames adds the frames of all participants into b.total, splitting the frame
+// between the workers.
+func (b *Bridge) sumFrames(ps []*participant) {
+	chunks := minInt(b.cfg.Workers, (b.frameSamples+255)/256) // Not worth a goroutine below 256 samples
+	size := (b.frameSamples + chunks - 1) / chunks
+	b.parallel(chunks, func(c int) error {
+		start, end := c*size, minInt((c+1)*size, b.frameSamples)
+		total := b.total[start:end]
+		for i := range total {
+			total[i] = 0
+		}
+		for _, p := range ps {
+			for i, v := range p.frame[start:end] {
+				total[i] += v
+			}
+		}
+		return nil
+	})
+}
+
+// parallel runs fn(0) to fn(n-1) on up to cfg.Workers goroutines and returns the
+// first error.
+func (b *Bridge) parallel(n int, fn func(i int) error) error {
+	if n <= 1 || b.cfg.Workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := fn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	errs := make([]error, n)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < minInt(b.cfg.Workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close removes every participant and releases their resamplers.
+func (b *Bridge) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var firstErr error
+	for id, p := range b.participants {
+		if err := p.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(b.participants, id)
+	}
+	return firstErr
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
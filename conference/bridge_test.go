@@ -0,0 +1,334 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package conference
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// tone returns frames samples of a sine at freq Hz.
+func tone(frames int, freq, rate float64) []float32 {
+	out := make([]float32, frames)
+	for i := range out {
+		out[i] = float32(0.3 * math.Sin(2*math.Pi*freq*float64(i)/rate))
+	}
+	return out
+}
+
+// level returns the amplitude of the freq Hz component of in.
+func level(in []float32, freq, rate float64) float64 {
+	var re, im float64
+	for i, v := range in {
+		w := 2 * math.Pi * freq * float64(i) / rate
+		re += float64(v) * math.Cos(w)
+		im -= float64(v) * math.Sin(w)
+	}
+	return 2 * math.Hypot(re, im) / float64(len(in))
+}
+
+type leg struct {
+	cfg  ParticipantConfig
+	freq float64
+}
+
+// runBridge joins the legs, feeds each its tone one frame per Mix for frames
+// frames and returns the decoded output of each leg, without the first skip
+// frames.
+func runBridge(t *testing.T, b *Bridge, legs []leg, frames, skip int) map[string][]float32 {
+	t.Helper()
+	tones := make(map[string][]float32)
+	for _, l := range legs {
+		if err := b.Join(l.cfg); err != nil {
+			t.Fatalf("Join %q failed: %v", l.cfg.ID, err)
+		}
+		tones[l.cfg.ID] = tone(frames*int(l.cfg.Rate/50), l.freq, l.cfg.Rate)
+	}
+	got := make(map[string][]float32)
+	for f := 0; f < frames; f++ {
+		for _, l := range legs {
+			n := int(l.cfg.Rate / 50)
+			audio, err := l.cfg.Encoding.encode(tones[l.cfg.ID][f*n : (f+1)*n])
+			if err != nil {
+				t.Fatalf("encode failed: %v", err)
+			}
+			if err := b.Write(l.cfg.ID, audio); err != nil {
+				t.Fatalf("Write %q failed: %v", l.cfg.ID, err)
+			}
+		}
+		outs, err := b.Mix()
+		if err != nil {
+			t.Fatalf("Mix failed: %v", err)
+		}
+		for _, l := range legs {
+			out, ok := outs[l.cfg.ID]
+			if !ok {
+				t.Fatalf("frame %d: no output for %q", f, l.cfg.ID)
+			}
+			samples, err := l.cfg.Encoding.decode(out, nil)
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if f >= skip {
+				got[l.cfg.ID] = append(got[l.cfg.ID], samples...)
+			}
+		}
+	}
+	return got
+}
+
+func TestBridgeMinusOne(t *testing.T) {
+	legs := []leg{
+		{ParticipantConfig{ID: "pstn", Rate: 8000, Encoding: ULaw}, 500},
+		{ParticipantConfig{ID: "sip", Rate: 16000, Encoding: S16LE}, 1100},
+		{ParticipantConfig{ID: "rtp", Rate: 22050, Encoding: S16BE}, 1700},
+		{ParticipantConfig{ID: "webrtc", Rate: 48000, Encoding: F32LE}, 2300},
+	}
+	b, err := NewBridge(BridgeConfig{ConverterType: libsamplerate.SincFastest, Workers: 3})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	defer b.Close()
+	const frames = 50
+	got := runBridge(t, b, legs, frames, 10)
+
+	for _, l := range legs {
+		out := got[l.cfg.ID]
+		// A frame holds Rate/50 samples, give or take the rounding of the resampler
+		want := (frames - 10) * int(l.cfg.Rate/50)
+		if d := len(out) - want; d < -2 || d > 2 {
+			t.Errorf("%s: got %d samples over %d frames, want about %d", l.cfg.ID, len(out), frames-10, want)
+		}
+		for _, other := range legs {
+			lv := level(out, other.freq, l.cfg.Rate)
+			if other.cfg.ID == l.cfg.ID {
+				if lv > 0.01 {
+					t.Errorf("%s hears itself at %g", l.cfg.ID, lv)
+				}
+			} else if lv < 0.25 || lv > 0.35 {
+				t.Errorf("%s hears %s at %g, want 0.3", l.cfg.ID, other.cfg.ID, lv)
+			}
+		}
+	}
+}
+
+func TestBridgeAlone(t *testing.T) {
+	b, err := NewBridge(BridgeConfig{})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	defer b.Close()
+	got := runBridge(t, b, []leg{{ParticipantConfig{ID: "a", Rate: 8000, Encoding: S16LE}, 440}}, 5, 0)
+	for i, v := range got["a"] {
+		if v != 0 {
+			t.Fatalf("lone participant: sample %d = %g, want silence", i, v)
+		}
+	}
+}
+
+func TestBridgeGainAndClip(t *testing.T) {
+	b, err := NewBridge(BridgeConfig{MixRate: 8000, Gain: 4, Clip: libsamplerate.HardClip})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	defer b.Close()
+	legs := []leg{
+		{ParticipantConfig{ID: "a", Rate: 8000, Encoding: F32LE}, 400},
+		{ParticipantConfig{ID: "b", Rate: 8000, Encoding: F32LE}, 600},
+	}
+	got := runBridge(t, b, legs, 5, 0)
+	var peak float32
+	for _, v := range got["a"] {
+		if v > peak {
+			peak = v
+		}
+	}
+	if peak != 1 {
+		t.Errorf("peak of a 1.2 mix = %g, want clipped to 1", peak)
+	}
+}
+
+func TestBridgeStats(t *testing.T) {
+	b, err := NewBridge(BridgeConfig{MixRate: 8000, MaxBuffered: 2})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	defer b.Close()
+	if err := b.Join(ParticipantConfig{ID: "a", Rate: 8000, Encoding: ULaw}); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+
+	// Half a frame, then nothing: two underruns
+	if err := b.Write("a", make([]byte, 80)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := b.Mix(); err != nil {
+			t.Fatalf("Mix failed: %v", err)
+		}
+	}
+	// Five frames with room for two: three dropped
+	if err := b.Write("a", make([]byte, 5*160)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	st, err := b.Stats("a")
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if want := (ParticipantStats{Frames: 2, Underruns: 2, Dropped: 3 * 160}); st != want {
+		t.Errorf("Stats = %+v, want %+v", st, want)
+	}
+}
+
+func TestBridgeJoinLeave(t *testing.T) {
+	b, err := NewBridge(BridgeConfig{})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	defer b.Close()
+	for _, id := range []string{"b", "a"} {
+		if err := b.Join(ParticipantConfig{ID: id, Rate: 8000, Encoding: ULaw}); err != nil {
+			t.Fatalf("Join %q failed: %v", id, err)
+		}
+	}
+	if ids := b.Participants(); len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("Participants = %v, want [a b]", ids)
+	}
+
+	bad := []ParticipantConfig{
+		{ID: "", Rate: 8000},
+		{ID: "a", Rate: 8000},
+		{ID: "c", Rate: 8000, Encoding: Encoding(9)},
+		{ID: "d", Rate: 0},
+		{ID: "e", Rate: 1},
+	}
+	for _, cfg := range bad {
+		if err := b.Join(cfg); err == nil {
+			t.Errorf("Join(%+v) accepted", cfg)
+		}
+	}
+
+	if err := b.Leave("a"); err != nil {
+		t.Fatalf("Leave failed: %v", err)
+	}
+	if err := b.Leave("a"); err == nil {
+		t.Error("second Leave accepted")
+	}
+	if err := b.Write("a", make([]byte, 160)); err == nil {
+		t.Error("Write after Leave accepted")
+	}
+	if err := b.Write("b", make([]byte, 3)); err != nil {
+		t.Errorf("odd u-law Write failed: %v", err)
+	}
+	outs, err := b.Mix()
+	if err != nil {
+		t.Fatalf("Mix failed: %v", err)
+	}
+	if _, ok := outs["a"]; ok || len(outs) != 1 {
+		t.Errorf("Mix after Leave returned %d outputs", len(outs))
+	}
+}
+
+func TestBridgeConfigErrors(t *testing.T) {
+	bad := []BridgeConfig{
+		{MixRate: -8000},
+		{FrameDuration: -1},
+		{MixRate: 8000, FrameDuration: 100000}, // 0.1ms, less than a sample
+		{Gain: -1},
+		{Clip: libsamplerate.ClipStrategy(99)},
+		{MaxBuffered: -1},
+		{Workers: -2},
+	}
+	for _, cfg := range bad {
+		if _, err := NewBridge(cfg); err == nil {
+			t.Errorf("NewBridge(%+v) accepted", cfg)
+		}
+	}
+	b, err := NewBridge(BridgeConfig{})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	if cfg := b.Config(); cfg.MixRate != DefaultMixRate || cfg.FrameDuration != DefaultFrameDuration || cfg.Gain != 1 {
+		t.Errorf("defaults not filled in: %+v", cfg)
+	}
+	defer b.Close()
+	if err := b.Join(ParticipantConfig{ID: "x", Rate: 16000, Encoding: S16LE}); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+	if err := b.Write("x", []byte{1, 2, 3}); err == nil {
+		t.Error("odd S16LE Write accepted")
+	}
+}
+
+// Participants write from their own goroutines while a clock mixes.
+func TestBridgeConcurrent(t *testing.T) {
+	b, err := NewBridge(BridgeConfig{ConverterType: libsamplerate.SincFastest})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	defer b.Close()
+	rates := []float64{8000, 16000, 32000, 44100, 48000, 11025}
+	ids := []string{"a", "b", "c", "d", "e", "f"}
+	for i, id := range ids {
+		if err := b.Join(ParticipantConfig{ID: id, Rate: rates[i], Encoding: S16LE}); err != nil {
+			t.Fatalf("Join failed: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(id string, rate float64) {
+			defer wg.Done()
+			audio, _ := S16LE.encode(tone(int(rate/50), 300, rate))
+			for f := 0; f < 40; f++ {
+				if err := b.Write(id, audio); err != nil {
+					t.Errorf("Write %q failed: %v", id, err)
+					return
+				}
+			}
+		}(id, rates[i])
+	}
+	for f := 0; f < 40; f++ {
+		outs, err := b.Mix()
+		if err != nil {
+			t.Fatalf("Mix failed: %v", err)
+		}
+		if len(outs) != len(ids) {
+			t.Fatalf("Mix returned %d outputs, want %d", len(outs), len(ids))
+		}
+	}
+	wg.Wait()
+
+	// A participant joining and leaving during mixing
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			if err := b.Join(ParticipantConfig{ID: "g", Rate: 24000, Encoding: F32LE}); err != nil {
+				t.Errorf("Join failed: %v", err)
+				return
+			}
+			b.Write("g", make([]byte, 4*480))
+			if err := b.Leave("g"); err != nil {
+				t.Errorf("Leave failed: %v", err)
+				return
+			}
+		}
+	}()
+	for f := 0; f < 20; f++ {
+		if _, err := b.Mix(); err != nil {
+			t.Fatalf("Mix failed: %v", err)
+		}
+	}
+	<-done
+}
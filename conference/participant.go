@@ -0,0 +1,237 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package conference
+
+import (
+	"fmt"
+	"sync"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// Encoding is the sample layout of a participant's audio, mono.
+type Encoding int
+
+const (
+	S16LE Encoding = iota // Signed 16-bit little-endian PCM
+	ULaw                  // G.711 u-law, one byte per sample
+	S16BE                 // Signed 16-bit big-endian PCM (L16 RTP payloads)
+	F32LE                 // 32-bit float little-endian, e.g. from a WebRTC stack
+)
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case S16LE:
+		return "s16le"
+	case ULaw:
+		return "u-law"
+	case S16BE:
+		return "s16be"
+	case F32LE:
+		return "f32le"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int(e))
+	}
+}
+
+// bytesPerSample returns the size of one sample, or 0 for an unknown encoding.
+func (e Encoding) bytesPerSample() int {
+	switch e {
+	case ULaw:
+		return 1
+	case S16LE, S16BE:
+		return 2
+	case F32LE:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// pcmFormat returns the libsamplerate format of a PCM encoding.
+func (e Encoding) pcmFormat() libsamplerate.SampleFormat {
+	switch e {
+	case S16BE:
+		return libsamplerate.FormatS16BE
+	case F32LE:
+		return libsamplerate.FormatF32LE
+	default:
+		return libsamplerate.FormatS16LE
+	}
+}
+
+// decode converts bytes in encoding e to float32 samples, reusing buf.
+func (e Encoding) decode(in []byte, buf []float32) ([]float32, error) {
+	size := e.bytesPerSample()
+	if len(in)%size != 0 {
+		return nil, fmt.Errorf("size %d is not a multiple of the %v sample size %d", len(in), e, size)
+	}
+	n := len(in) / size
+	if cap(buf) < n {
+		buf = make([]float32, n)
+	}
+	buf = buf[:n]
+	if e == ULaw {
+		libsamplerate.UlawToFloatArray(in, buf)
+		return buf, nil
+	}
+	if _, err := libsamplerate.DecodePCM(e.pcmFormat(), in, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// encode converts samples to encoding e, in a new slice.
+func (e Encoding) encode(in []float32) ([]byte, error) {
+	out := make([]byte, len(in)*e.bytesPerSample())
+	if e == ULaw {
+		libsamplerate.FloatToUlawArray(in, out)
+		return out, nil
+	}
+	if _, err := libsamplerate.EncodePCM(e.pcmFormat(), in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParticipantConfig describes the audio of one participant. The bridge sends
+// each participant its mix in the same rate and encoding.
+type ParticipantConfig struct {
+	ID       string
+	Rate     float64 // Sample rate in Hz, e.g. 8000 for a PSTN leg or 48000 for WebRTC
+	Encoding Encoding
+}
+
+// ParticipantStats counts the conditions the bridge handled for a participant.
+type ParticipantStats struct {
+	Frames    int64 // Mix frames produced for the participant
+	Underruns int64 // Frames for which the participant had sent too little audio, padded with silence
+	Dropped   int64 // Mix-rate samples dropped because the participant sent audio faster than it was mixed
+}
+
+// participant is the state of one leg: the uplink converts its audio to the
+// mixing rate into a queue, the downlink converts its N-1 mix back.
+type participant struct {
+	cfg ParticipantConfig
+
+	mu       sync.Mutex              // Guards uplink, queue and stats
+	uplink   libsamplerate.Converter // nil when Rate is the mixing rate
+	upRatio  float64
+	decodeIn []float32
+	queue    []float32 // Audio at the mixing rate, waiting to be mixed
+	maxQueue int
+	stats    ParticipantStats
+	closed   bool // Left the bridge; a Write racing with Leave fails
+
+	// Used by Mix only
+	downlink  libsamplerate.Converter // nil when Rate is the mixing rate
+	downRatio float64
+	frame     []float32 // This participant's audio in the current mix frame
+	mixBuf    []float32 // Its N-1 mix, at the mixing rate
+	outBuf    []float32 // Its N-1 mix, at its own rate
+}
+
+func newParticipant(cfg ParticipantConfig, mixRate float64, converterType libsamplerate.ConverterType, frameSamples, maxQueue int) (*participant, error) {
+	p := &participant{
+		cfg:       cfg,
+		upRatio:   mixRate / cfg.Rate,
+		downRatio: cfg.Rate / mixRate,
+		maxQueue:  maxQueue,
+		frame:     make([]float32, frameSamples),
+		mixBuf:    make([]float32, frameSamples),
+	}
+	if cfg.Rate != mixRate {
+		var err error
+		if p.uplink, err = libsamplerate.New(converterType, 1); err != nil {
+			return nil, fmt.Errorf("failed to create uplink resampler: %w", err)
+		}
+		if p.downlink, err = libsamplerate.New(converterType, 1); err != nil {
+			p.uplink.Close()
+			return nil, fmt.Errorf("failed to create downlink resampler: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// write decodes audio and queues it at the mixing rate.
+func (p *participant) write(audio []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("left the bridge")
+	}
+	var err error
+	if p.decodeIn, err = p.cfg.Encoding.decode(audio, p.decodeIn); err != nil {
+		return err
+	}
+	if p.uplink == nil {
+		p.queue = append(p.queue, p.decodeIn...)
+	} else {
+		data := libsamplerate.SrcData{DataIn: p.decodeIn, InputFrames: int64(len(p.decodeIn)), SrcRatio: p.upRatio}
+		if err := libsamplerate.ProcessAppend(p.uplink, &data, &p.queue); err != nil {
+			return fmt.Errorf("uplink resampling failed: %w", err)
+		}
+	}
+	if over := len(p.queue) - p.maxQueue; over > 0 {
+		p.queue = p.queue[:copy(p.queue, p.queue[over:])] // Keep the latest audio
+		p.stats.Dropped += int64(over)
+	}
+	return nil
+}
+
+// take moves the next frame of queued audio to p.frame, padding with silence.
+func (p *participant) take() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := copy(p.frame, p.queue)
+	for i := n; i < len(p.frame); i++ {
+		p.frame[i] = 0
+	}
+	p.queue = p.queue[:copy(p.queue, p.queue[n:])]
+	if n < len(p.frame) {
+		p.stats.Underruns++
+	}
+	p.stats.Frames++
+}
+
+// render builds the N-1 mix of the participant from the sum of all frames and
+// returns it in its rate and encoding.
+func (p *participant) render(total []float32, gain float32, clip libsamplerate.ClipStrategy) ([]byte, error) {
+	for i, v := range total {
+		p.mixBuf[i] = (v - p.frame[i]) * gain
+	}
+	out := p.mixBuf
+	if p.downlink != nil {
+		p.outBuf = p.outBuf[:0]
+		data := libsamplerate.SrcData{DataIn: p.mixBuf, InputFrames: int64(len(p.mixBuf)), SrcRatio: p.downRatio}
+		if err := libsamplerate.ProcessAppend(p.downlink, &data, &p.outBuf); err != nil {
+			return nil, fmt.Errorf("downlink resampling failed: %w", err)
+		}
+		out = p.outBuf
+	}
+	clip.Apply(out)
+	return p.cfg.Encoding.encode(out)
+}
+
+func (p *participant) close() error {
+	p.mu.Lock() // Wait for a Write in progress
+	defer p.mu.Unlock()
+	p.closed = true
+	var err error
+	if p.uplink != nil {
+		err = p.uplink.Close()
+	}
+	if p.downlink != nil {
+		if cerr := p.downlink.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+)
+
+// ConstantOutput wraps a converter so that every call produces exactly the same
+// number of output frames (e.g. 160 samples every 20ms for 8kHz telephony),
+// instead of an OutputFramesGen that varies by +/-1 frame from call to call.
+//
+// Surplus output is kept in an internal FIFO for the next call. When there is a
+// deficit (at the start, because of the filter delay, or if the caller feeds too
+// little input) silence is inserted before the queued frames, which adds that
+// much latency once; the stream itself is never cut or reordered.
+//
+// NOTE: A ConstantOutput is NOT goroutine-safe.
+type ConstantOutput struct {
+	stage       *streamStage
+	channels    int
+	blockFrames int
+	fifo        []float32 // Queued interleaved output frames
+	underruns   int64     // Silent frames inserted by Process
+	draining    bool
+}
+
+// NewConstantOutput creates a converter of the given type that always returns
+// blockFrames frames per Process call, resampling by ratio (output rate / input rate).
+func NewConstantOutput(converterType ConverterType, channels int, ratio float64, blockFrames int) (*ConstantOutput, error) {
+	if blockFrames <= 0 {
+		return nil, fmt.Errorf("blockFrames must be positive, got %d", blockFrames)
+	}
+	if isBadSrcRatio(ratio) {
+		return nil, mapError(ErrBadSrcRatio)
+	}
+	conv, err := New(converterType, channels)
+	if err != nil {
+		return nil, err
+	}
+	return &ConstantOutput{
+		stage: &streamStage{
+			conv:    conv,
+			ratio:   ratio,
+			scratch: make([]float32, fanoutScratchFrames*channels),
+		},
+		channels:    channels,
+		blockFrames: blockFrames,
+	}, nil
+}
+
+// BlockFrames returns the number of frames produced per call.
+func (c *ConstantOutput) BlockFrames() int {
+	return c.blockFrames
+}
+
+// Process converts the interleaved input and writes exactly BlockFrames frames to
+// out, which must hold exactly BlockFrames*channels samples.
+func (c *ConstantOutput) Process(in, out []float32) error {
+	if c.draining {
+		return fmt.Errorf("Process called after Flush; call Reset first")
+	}
+	if err := c.checkOut(out); err != nil {
+		return err
+	}
+	if len(in)%c.channels != 0 {
+		return fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), c.channels)
+	}
+	if err := c.stage.process(in, c.channels, false); err != nil {
+		return err
+	}
+	c.fifo = append(c.fifo, c.stage.out...)
+
+	// On a deficit, pad the front of the block so queued frames stay contiguous
+	missing := len(out) - len(c.fifo)
+	if missing < 0 {
+		missing = 0
+	}
+	for i := 0; i < missing; i++ {
+		out[i] = 0
+	}
+	c.underruns += int64(missing / c.channels)
+	c.pop(out[missing:])
+	return nil
+}
+
+// Flush drains the converter at the end of the stream. Each call writes one block
+// to out (zero-padded at the end) and returns the number of real frames in it,
+// or 0 once everything has been returned.
+func (c *ConstantOutput) Flush(out []float32) (int, error) {
+	if err := c.checkOut(out); err != nil {
+		return 0, err
+	}
+	if !c.draining {
+		if err := c.stage.process(nil, c.channels, true); err != nil {
+			return 0, err
+		}
+		c.fifo = append(c.fifo, c.stage.out...)
+		c.draining = true
+	}
+	n := minInt(len(out), len(c.fifo))
+	c.pop(out[:n])
+	for i := n; i < len(out); i++ {
+		out[i] = 0
+	}
+	return n / c.channels, nil
+}
+
+// Buffered returns the number of output frames queued for the next calls.
+func (c *ConstantOutput) Buffered() int {
+	return len(c.fifo) / c.channels
+}
+
+// Underruns returns the total number of silent frames Process has inserted.
+func (c *ConstantOutput) Underruns() int64 {
+	return c.underruns
+}
+
+// Reset clears the queue and the converter state for a new stream.
+func (c *ConstantOutput) Reset() error {
+	c.fifo = c.fifo[:0]
+	c.underruns = 0
+	c.draining = false
+	return c.stage.conv.Reset()
+}
+
+// Close releases the underlying converter.
+func (c *ConstantOutput) Close() error {
+	return c.stage.conv.Close()
+}
+
+func (c *ConstantOutput) checkOut(out []float32) error {
+	if len(out) != c.blockFrames*c.channels {
+		return fmt.Errorf("output size (%d) must be exactly %d frames x %d channels", len(out), c.blockFrames, c.channels)
+	}
+	return nil
+}
+
+// pop moves the oldest len(dst) queued samples into dst.
+func (c *ConstantOutput) pop(dst []float32) {
+	n := copy(dst, c.fifo)
+	c.fifo = c.fifo[:copy(c.fifo, c.fifo[n:])]
+}
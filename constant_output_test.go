@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestConstantOutputContiguous checks every block is full size and that the
+// concatenated blocks are the plain converter output delayed by the underruns.
+func TestConstantOutputContiguous(t *testing.T) {
+	tests := []struct {
+		inRate, outRate float64
+		inBlock         int
+		ct              ConverterType
+	}{
+		{24000, 8000, 480, SincFastest},
+		{48000, 44100, 480, SincMediumQuality},
+		{8000, 16000, 160, Linear},
+		{44100, 16000, 441, ZeroOrderHold},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%.0f_to_%.0f", GetName(tt.ct), tt.inRate, tt.outRate), func(t *testing.T) {
+			const channels = 2
+			const blocks = 100
+			ratio := tt.outRate / tt.inRate
+			outBlock := int(float64(tt.inBlock)*ratio + 0.5)
+
+			mono := make([]float32, tt.inBlock*blocks)
+			genWindowedSinesGo(2, []float64{0.013, 0.07}, 0.9, mono)
+			in := make([]float32, len(mono)*channels)
+			for i, v := range mono {
+				in[i*channels] = v
+				in[i*channels+1] = -v
+			}
+
+			c, err := NewConstantOutput(tt.ct, channels, ratio, outBlock)
+			if err != nil {
+				t.Fatalf("NewConstantOutput failed: %v", err)
+			}
+			defer c.Close()
+
+			var got []float32
+			out := make([]float32, outBlock*channels)
+			maxBuffered := 0
+			for b := 0; b < blocks; b++ {
+				block := in[b*tt.inBlock*channels : (b+1)*tt.inBlock*channels]
+				if err := c.Process(block, out); err != nil {
+					t.Fatalf("Process failed: %v", err)
+				}
+				got = append(got, out...)
+				if b > 10 && c.Buffered() > maxBuffered {
+					maxBuffered = c.Buffered()
+				}
+			}
+			if maxBuffered > outBlock {
+				t.Errorf("queue grew to %d frames, want at most one block", maxBuffered)
+			}
+			for {
+				n, err := c.Flush(out)
+				if err != nil {
+					t.Fatalf("Flush failed: %v", err)
+				}
+				if n == 0 {
+					break
+				}
+				got = append(got, out[:n*channels]...)
+			}
+
+			conv, err := New(tt.ct, channels)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			want, err := processAll(conv, in, channels, ratio)
+			conv.Close()
+			if err != nil {
+				t.Fatalf("processAll failed: %v", err)
+			}
+
+			lead := int(c.Underruns()) * channels
+			for i := 0; i < lead; i++ {
+				if got[i] != 0 {
+					t.Fatalf("sample %d in the underrun lead-in is %g, want 0", i, got[i])
+				}
+			}
+			if len(got)-lead != len(want) {
+				t.Fatalf("got %d samples after %d lead-in, want %d", len(got)-lead, lead, len(want))
+			}
+			for i := range want {
+				if got[lead+i] != want[i] {
+					t.Fatalf("sample %d is %g, want %g", i, got[lead+i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConstantOutputErrors(t *testing.T) {
+	if _, err := NewConstantOutput(Linear, 1, 0.5, 0); err == nil {
+		t.Error("expected error for zero block size")
+	}
+	if _, err := NewConstantOutput(Linear, 1, 1000, 160); err == nil {
+		t.Error("expected error for bad ratio")
+	}
+	c, err := NewConstantOutput(Linear, 2, 0.5, 160)
+	if err != nil {
+		t.Fatalf("NewConstantOutput failed: %v", err)
+	}
+	defer c.Close()
+	if err := c.Process(make([]float32, 640), make([]float32, 100)); err == nil {
+		t.Error("expected error for wrong output size")
+	}
+	if err := c.Process(make([]float32, 3), make([]float32, 320)); err == nil {
+		t.Error("expected error for partial input frame")
+	}
+	if _, err := c.Flush(make([]float32, 320)); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if err := c.Process(make([]float32, 640), make([]float32, 320)); err == nil {
+		t.Error("expected error for Process after Flush")
+	}
+	if err := c.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if err := c.Process(make([]float32, 640), make([]float32, 320)); err != nil {
+		t.Errorf("Process after Reset failed: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// ConverterInfo describes a converter type, see ListConverters.
+type ConverterInfo struct {
+	Type        ConverterType
+	Name        string // As returned by GetName
+	Description string // As returned by GetDescription
+	// Enabled reports whether the converter was compiled in; New fails with
+	// ErrBadConverter for disabled ones.
+	Enabled bool
+	// SupportsVariableRatio reports whether SrcData.SrcRatio may change from one
+	// Process call to the next, the converter gliding to the new ratio over the
+	// block (SetRatio makes a step change instead).
+	SupportsVariableRatio bool
+}
+
+// ListConverters returns every converter type from best to lowest quality, e.g.
+// to populate a quality selection without trial New calls. Disabled converters
+// are listed too, with Enabled false.
+func ListConverters() []ConverterInfo {
+	types := []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, Linear, ZeroOrderHold}
+	infos := make([]ConverterInfo, 0, len(types))
+	for _, ct := range types {
+		infos = append(infos, ConverterInfo{
+			Type:                  ct,
+			Name:                  GetName(ct),
+			Description:           GetDescription(ct),
+			Enabled:               converterEnabled(ct),
+			SupportsVariableRatio: true, // All of them, as in the C library
+		})
+	}
+	return infos
+}
+
+// converterEnabled reports whether a converter type was compiled in (see config.go).
+func converterEnabled(converterType ConverterType) bool {
+	switch converterType {
+	case SincBestQuality:
+		return enableSincBestConverter
+	case SincMediumQuality:
+		return enableSincMediumConverter
+	case SincFastest:
+		return enableSincFastConverter
+	case ZeroOrderHold, Linear:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListConverters(t *testing.T) {
+	infos := ListConverters()
+	if len(infos) != 5 {
+		t.Fatalf("got %d converters, want 5", len(infos))
+	}
+	if infos[0].Type != SincBestQuality {
+		t.Errorf("first converter is %v, want SincBestQuality", infos[0].Type)
+	}
+	seen := make(map[ConverterType]bool)
+	for _, info := range infos {
+		if seen[info.Type] {
+			t.Errorf("converter %d listed twice", info.Type)
+		}
+		seen[info.Type] = true
+		if info.Name == "" || info.Name != GetName(info.Type) || info.Description != GetDescription(info.Type) {
+			t.Errorf("converter %d: name %q, description %q", info.Type, info.Name, info.Description)
+		}
+		if !info.SupportsVariableRatio {
+			t.Errorf("%s: expected variable ratio support", info.Name)
+		}
+
+		conv, err := New(info.Type, 1)
+		if info.Enabled != (err == nil) {
+			t.Errorf("%s: Enabled is %v but New returned %v", info.Name, info.Enabled, err)
+		}
+		if err == nil {
+			if conv.(VariableRatioReporter).SupportsVariableRatio() != info.SupportsVariableRatio {
+				t.Errorf("%s: SupportsVariableRatio() disagrees with ListConverters", info.Name)
+			}
+			conv.Close()
+		}
+	}
+}
+
+// constantRatioOnly strips the variable ratio process function of a converter,
+// as a converter type without one would have.
+func constantRatioOnly(t *testing.T, c Converter) {
+	t.Helper()
+	state := c.(*srcState)
+	vt := *state.vt
+	vt.variProcess = nil
+	state.vt = &vt
+}
+
+func TestSupportsVariableRatio(t *testing.T) {
+	for _, channels := range []int{1, 130} { // 130 runs as channel groups
+		conv, _ := New(SincFastest, channels)
+		mapped, _ := NewWithOptions(SincFastest, channels, Options{OutputChannels: 1})
+		if !conv.(VariableRatioReporter).SupportsVariableRatio() || !mapped.(VariableRatioReporter).SupportsVariableRatio() {
+			t.Errorf("%d channels: sinc converter without variable ratio support", channels)
+		}
+		conv.Close()
+		mapped.Close()
+	}
+
+	conv, _ := New(Linear, 1)
+	defer conv.Close()
+	constantRatioOnly(t, conv)
+	if conv.(VariableRatioReporter).SupportsVariableRatio() {
+		t.Fatal("SupportsVariableRatio() true without a variable ratio process function")
+	}
+	if err := conv.SetRatio(2); err != nil {
+		t.Fatalf("setting the first ratio failed: %v", err)
+	}
+	if err := conv.SetRatio(2); err != nil {
+		t.Errorf("setting the same ratio again failed: %v", err)
+	}
+	if err := conv.SetRatio(1.5); !errors.Is(err, ErrNoVariableRatio) {
+		t.Errorf("SetRatio to a new ratio: got %v, want ErrNoVariableRatio", err)
+	}
+
+	in := genSine(400, 440, 8000, 0.5)
+	out := make([]float32, 1000)
+	data := SrcData{DataIn: in, InputFrames: 400, DataOut: out, OutputFrames: 1000, SrcRatio: 2}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process at the set ratio failed: %v", err)
+	}
+	data = SrcData{DataIn: in, InputFrames: 400, DataOut: out, OutputFrames: 1000, SrcRatio: 1.5}
+	if err := conv.Process(&data); !errors.Is(err, ErrNoVariableRatio) {
+		t.Errorf("Process at another ratio: got %v, want ErrNoVariableRatio", err)
+	}
+
+	if err := conv.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if err := conv.SetRatio(1.5); err != nil {
+		t.Errorf("SetRatio after Reset failed: %v", err)
+	}
+}
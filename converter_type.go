@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Short names of the converter types, as String returns and configuration
+// files and flags use them (e.g. quality: "sinc_medium").
+var converterTypeNames = map[ConverterType]string{
+	SincBestQuality:   "sinc_best",
+	SincMediumQuality: "sinc_medium",
+	SincFastest:       "sinc_fastest",
+	ZeroOrderHold:     "zero_order_hold",
+	Linear:            "linear",
+}
+
+// converterTypeAliases maps the other accepted spellings of the converter types,
+// normalized by normalizeConverterName, to them: the Go constant names, the C
+// library constant names (SRC_SINC_BEST_QUALITY...) and "zoh".
+var converterTypeAliases = map[string]ConverterType{
+	"sincbestquality":      SincBestQuality,
+	"srcsincbestquality":   SincBestQuality,
+	"sincmediumquality":    SincMediumQuality,
+	"srcsincmediumquality": SincMediumQuality,
+	"srcsincfastest":       SincFastest,
+	"srczeroorderhold":     ZeroOrderHold,
+	"zoh":                  ZeroOrderHold,
+	"srclinear":            Linear,
+}
+
+// String returns the short name of the converter type, e.g. "sinc_medium".
+func (t ConverterType) String() string {
+	if name, ok := converterTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ConverterType(%d)", int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler, writing the short name, so a
+// ConverterType field reads as "sinc_medium" in JSON, YAML and the like.
+func (t ConverterType) MarshalText() ([]byte, error) {
+	name, ok := converterTypeNames[t]
+	if !ok {
+		return nil, fmt.Errorf("converter type %d: %w", int(t), mapError(ErrBadConverter))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting what
+// ParseConverterType does.
+func (t *ConverterType) UnmarshalText(text []byte) error {
+	parsed, err := ParseConverterType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// ParseConverterType returns the converter type named s: its short name as
+// String returns it ("sinc_best", "sinc_medium", "sinc_fastest",
+// "zero_order_hold", "linear"), its Go constant name ("SincMediumQuality") or
+// its C library one ("SRC_SINC_MEDIUM_QUALITY"), or "zoh". Case, and '_', '-'
+// and spaces, do not matter. The error of an unknown name wraps
+// ErrBadConverter.
+func ParseConverterType(s string) (ConverterType, error) {
+	key := normalizeConverterName(s)
+	for t, name := range converterTypeNames {
+		if normalizeConverterName(name) == key {
+			return t, nil
+		}
+	}
+	if t, ok := converterTypeAliases[key]; ok {
+		return t, nil
+	}
+	return 0, fmt.Errorf("unknown converter type %q (want sinc_best, sinc_medium, sinc_fastest, zero_order_hold or linear): %w", s, mapError(ErrBadConverter))
+}
+
+// normalizeConverterName lowercases s and drops the separators ParseConverterType
+// ignores.
+func normalizeConverterName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// ConverterName is GetName returning an error wrapping ErrBadConverter, instead
+// of an empty string, for an unknown converter type.
+func ConverterName(converterType ConverterType) (string, error) {
+	if name := GetName(converterType); name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("converter type %d: %w", int(converterType), mapError(ErrBadConverter))
+}
+
+// ConverterDescription is GetDescription returning an error wrapping
+// ErrBadConverter, instead of an empty string, for an unknown converter type.
+func ConverterDescription(converterType ConverterType) (string, error) {
+	if desc := GetDescription(converterType); desc != "" {
+		return desc, nil
+	}
+	return "", fmt.Errorf("converter type %d: %w", int(converterType), mapError(ErrBadConverter))
+}
@@ -0,0 +1,88 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestConverterTypeNames checks that every converter type round-trips through
+// String and ParseConverterType, and the other spellings Parse accepts.
+func TestConverterTypeNames(t *testing.T) {
+	for _, info := range ListConverters() {
+		got, err := ParseConverterType(info.Type.String())
+		if err != nil || got != info.Type {
+			t.Errorf("ParseConverterType(%q) = %d, %v; want %d", info.Type.String(), got, err, info.Type)
+		}
+	}
+	for s, want := range map[string]ConverterType{
+		"sinc_medium":             SincMediumQuality,
+		" Sinc-Fastest ":          SincFastest,
+		"SincBestQuality":         SincBestQuality,
+		"SRC_SINC_MEDIUM_QUALITY": SincMediumQuality,
+		"SRC_ZERO_ORDER_HOLD":     ZeroOrderHold,
+		"zoh":                     ZeroOrderHold,
+		"LINEAR":                  Linear,
+	} {
+		if got, err := ParseConverterType(s); err != nil || got != want {
+			t.Errorf("ParseConverterType(%q) = %d, %v; want %d", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "sinc", "cubic", "2"} {
+		if _, err := ParseConverterType(s); !errors.Is(err, ErrBadConverter) {
+			t.Errorf("ParseConverterType(%q) error = %v, want ErrBadConverter", s, err)
+		}
+	}
+	if s := ConverterType(42).String(); s != "ConverterType(42)" {
+		t.Errorf("String of an unknown type = %q", s)
+	}
+}
+
+// TestConverterTypeText checks the JSON encoding of a ConverterType field.
+func TestConverterTypeText(t *testing.T) {
+	type config struct {
+		Quality ConverterType `json:"quality"`
+	}
+	b, err := json.Marshal(config{Quality: SincMediumQuality})
+	if err != nil || string(b) != `{"quality":"sinc_medium"}` {
+		t.Fatalf("Marshal = %s, %v", b, err)
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"quality":"SRC_SINC_FASTEST"}`), &c); err != nil || c.Quality != SincFastest {
+		t.Fatalf("Unmarshal = %d, %v; want SincFastest", c.Quality, err)
+	}
+	if err := json.Unmarshal([]byte(`{"quality":"best"}`), &c); !errors.Is(err, ErrBadConverter) {
+		t.Errorf("Unmarshal of an unknown name: error = %v, want ErrBadConverter", err)
+	}
+	if _, err := json.Marshal(config{Quality: 42}); !errors.Is(err, ErrBadConverter) {
+		t.Errorf("Marshal of an unknown type: error = %v, want ErrBadConverter", err)
+	}
+}
+
+// TestConverterNameErrors checks the error-returning GetName and GetDescription.
+func TestConverterNameErrors(t *testing.T) {
+	for _, info := range ListConverters() {
+		name, err := ConverterName(info.Type)
+		if err != nil || name != info.Name {
+			t.Errorf("ConverterName(%s) = %q, %v; want %q", info.Type, name, err, info.Name)
+		}
+		desc, err := ConverterDescription(info.Type)
+		if err != nil || desc != info.Description {
+			t.Errorf("ConverterDescription(%s) = %q, %v; want %q", info.Type, desc, err, info.Description)
+		}
+	}
+	if _, err := ConverterName(-1); !errors.Is(err, ErrBadConverter) {
+		t.Errorf("ConverterName(-1) error = %v, want ErrBadConverter", err)
+	}
+	if _, err := ConverterDescription(5); !errors.Is(err, ErrBadConverter) {
+		t.Errorf("ConverterDescription(5) error = %v, want ErrBadConverter", err)
+	}
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"io/fs"
+	"math"
+	"testing"
+
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+)
+
+// TestCorpusConversions runs every file of the embedded corpus through the
+// converter and mixer paths that accept its format and checks output lengths.
+func TestCorpusConversions(t *testing.T) {
+	corpusFS := corpus.FS()
+	names, err := fs.Glob(corpusFS, "*")
+	if err != nil || len(names) == 0 {
+		t.Fatalf("corpus is empty: %v", err)
+	}
+
+	for _, name := range names {
+		data, err := fs.ReadFile(corpusFS, name)
+		if err != nil {
+			t.Fatalf("reading %s: %v", name, err)
+		}
+		rate := corpus.SampleRate(name)
+		if rate == 0 {
+			t.Fatalf("%s: cannot infer sample rate from name", name)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			if corpus.IsUlaw(name) {
+				pcm, err := ConvertUlawToPCM(data, SincMediumQuality)
+				if err != nil {
+					t.Fatalf("ConvertUlawToPCM failed: %v", err)
+				}
+				checkLength(t, "ConvertUlawToPCM", len(pcm)/2, len(data)*2)
+
+				pos := 0
+				mixed, err := MixUlaw8kHz(data, corpus.MustReadFile(corpus.Typing8kUlaw), &pos, 0.5)
+				if err != nil {
+					t.Fatalf("MixUlaw8kHz failed: %v", err)
+				}
+				checkLength(t, "MixUlaw8kHz", len(mixed), len(data))
+				return
+			}
+
+			frames := len(data) / 2
+			pos := 0
+			ulaw, err := MixResampleUlawWithRatio(data, data, &pos, 8000.0/float64(rate), 0.5)
+			if err != nil {
+				t.Fatalf("MixResampleUlawWithRatio failed: %v", err)
+			}
+			checkLength(t, "MixResampleUlawWithRatio", len(ulaw), frames*8000/rate)
+
+			f32, err := ResampleFormat(data, FormatS16LE, FormatF32LE, 1, 16000.0/float64(rate), SincFastest)
+			if err != nil {
+				t.Fatalf("ResampleFormat failed: %v", err)
+			}
+			checkLength(t, "ResampleFormat", len(f32)/4, frames*16000/rate)
+		})
+	}
+}
+
+// checkLength allows for the few frames of rounding at the stream edges.
+func checkLength(t *testing.T, what string, got, want int) {
+	t.Helper()
+	if math.Abs(float64(got-want)) > 4 {
+		t.Errorf("%s: got %d frames, want about %d", what, got, want)
+	}
+}
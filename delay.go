@@ -0,0 +1,80 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/dsp/fourier"
+)
+
+// EstimateDelay returns the offset, in samples, of b relative to a: the lag d
+// at which b[i+d] best matches a[i], positive when b comes later. It is the
+// peak of their cross-correlation, computed with FFTs in O(n log n), so long
+// recordings can be compared, e.g. a converter's output against its input at
+// ratio 1 to measure its latency, or two streams before mixing them.
+//
+// The peak is searched over lags in [-maxLag, maxLag]; maxLag <= 0 searches
+// every lag at which the two overlap. Narrowing it around the expected offset
+// avoids matching a later repeat of periodic audio. For multichannel audio,
+// pass one channel, or a mono mix of both streams.
+//
+// Returns:
+//
+//	The lag of the strongest correlation, 0 when either input is empty or silent.
+func EstimateDelay(a, b []float32, maxLag int) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	lo, hi := -(len(a) - 1), len(b)-1
+	if maxLag > 0 {
+		lo, hi = maxInt(lo, -maxLag), minInt(hi, maxLag)
+	}
+	if lo > hi {
+		return 0
+	}
+
+	// Zero padded to a power of two at least len(a)+len(b)-1, the circular
+	// correlation has lag d at index d and -d at n-d without wrapping into
+	// each other
+	n := 1
+	for n < len(a)+len(b)-1 {
+		n <<= 1
+	}
+	fft := fourier.NewFFT(n)
+	seq := make([]float64, n)
+	for i, v := range a {
+		seq[i] = float64(v)
+	}
+	fa := fft.Coefficients(nil, seq)
+	for i := range seq {
+		seq[i] = 0
+	}
+	for i, v := range b {
+		seq[i] = float64(v)
+	}
+	fb := fft.Coefficients(nil, seq)
+	for i := range fb {
+		fb[i] *= cmplx.Conj(fa[i])
+	}
+	corr := fft.Sequence(seq, fb)
+
+	best, bestCorr := 0, 0.0
+	for d := lo; d <= hi; d++ {
+		idx := d
+		if d < 0 {
+			idx += n
+		}
+		// Ties, e.g. on silence, keep the lag closest to 0
+		if c := corr[idx]; c > bestCorr || (c == bestCorr && d*d < best*best) {
+			best, bestCorr = d, c
+		}
+	}
+	return best
+}
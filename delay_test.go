@@ -0,0 +1,68 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateDelay(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	noise := make([]float32, 5000)
+	for i := range noise {
+		noise[i] = float32(rng.Float64() - 0.5)
+	}
+	// shifted returns noise delayed by d samples, with a little noise of its own
+	shifted := func(d, n int) []float32 {
+		out := make([]float32, n)
+		for i := range out {
+			if j := i - d; j >= 0 && j < len(noise) {
+				out[i] = noise[j]
+			}
+			out[i] += float32(0.1 * (rng.Float64() - 0.5))
+		}
+		return out
+	}
+	for _, d := range []int{0, 1, 37, -250, 1999} {
+		if got := EstimateDelay(noise, shifted(d, 4000), 0); got != d {
+			t.Errorf("delay %d: got %d", d, got)
+		}
+		if got := EstimateDelay(noise[:3000], shifted(d, 6000), 2000); got != d {
+			t.Errorf("delay %d within 2000: got %d", d, got)
+		}
+	}
+	if got := EstimateDelay(noise, shifted(300, 4000), 100); got == 300 {
+		t.Error("found a delay outside maxLag")
+	}
+
+	// A converter at ratio 1 is delayed by its filter latency: none for sinc,
+	// one frame for Linear
+	for _, tt := range []struct {
+		converterType ConverterType
+		delay         int
+	}{{SincFastest, 0}, {Linear, 1}} {
+		conv, err := New(tt.converterType, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := processAll(conv, noise, 1, 1.0001)
+		conv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := EstimateDelay(noise[:4000], out[:4000], 20); got != tt.delay {
+			t.Errorf("%s: delay %d, want %d", tt.converterType, got, tt.delay)
+		}
+	}
+
+	if EstimateDelay(nil, noise, 0) != 0 || EstimateDelay(make([]float32, 100), make([]float32, 100), 0) != 0 {
+		t.Error("expected 0 for empty or silent input")
+	}
+}
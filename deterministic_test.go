@@ -0,0 +1,111 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+// deterministicInput returns frames*channels samples of noise from an integer
+// generator, so the input itself does not depend on the platform's math library.
+func deterministicInput(frames, channels int) []float32 {
+	in := make([]float32, frames*channels)
+	x := uint32(12345)
+	for i := range in {
+		x = x*1664525 + 1013904223
+		in[i] = float32(int32(x)) / (1 << 31) * 0.5
+	}
+	return in
+}
+
+// outputDigest returns the first 8 bytes of the SHA-256 of the output bits.
+func outputDigest(out []float32) string {
+	h := sha256.New()
+	var b [4]byte
+	for _, v := range out {
+		binary.LittleEndian.PutUint32(b[:], math.Float32bits(v))
+		h.Write(b[:])
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// TestDeterministicGolden pins the output bits of deterministic converters. A
+// failure here means the output changed, which Options.Deterministic does not
+// allow in any release: fix the change, do not update the golden values.
+func TestDeterministicGolden(t *testing.T) {
+	cases := []struct {
+		name     string
+		ct       ConverterType
+		channels int
+		ratio    float64
+		opts     Options
+		want     string
+	}{
+		{"best stereo", SincBestQuality, 2, 48000.0 / 44100.0, Options{}, "84ad0a484e420d7c"},
+		{"medium mono", SincMediumQuality, 1, 0.5, Options{}, "8af083fa5c447588"},
+		{"fastest hex", SincFastest, 6, 2.0, Options{}, "76cfb5936659113c"},
+		{"fastest groups", SincFastest, 2 * maxChannels, 0.75, Options{}, "6bcec974cb333cdd"},
+		{"fastest ratio 1", SincFastest, 1, 1.0, Options{}, "4f76fa71da5638c1"},
+		{"linear stereo", Linear, 2, 48000.0 / 44100.0, Options{}, "772d2b02d809245b"},
+		{"zoh stereo", ZeroOrderHold, 2, 0.3, Options{}, "6d40f81f5c071a3c"}, // One frame shorter since ZOH ends like Linear
+		{"low latency mono", SincBestQuality, 1, 8000.0 / 16000.0, Options{LowLatency: true}, "81b9ac3ea6880373"},
+		{"downmix", SincFastest, 2, 0.5, Options{OutputChannels: 1}, "a09b563557f5f05d"},
+	}
+	for _, c := range cases {
+		c.opts.Deterministic = true
+		conv, err := NewWithOptions(c.ct, c.channels, c.opts)
+		if err != nil {
+			t.Fatalf("%s: NewWithOptions failed: %v", c.name, err)
+		}
+		outChannels := c.channels
+		if c.opts.OutputChannels != 0 {
+			outChannels = c.opts.OutputChannels
+		}
+		in := deterministicInput(2000, c.channels)
+		out := drain(t, conv, in, c.channels, outChannels, c.ratio, 512)
+		conv.Close()
+		if got := outputDigest(out); got != c.want {
+			t.Errorf("%s: output digest %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+// TestDeterministicGoldenVectors pins output samples of deterministic converters
+// bit for bit, next to the digests of TestDeterministicGolden, so a drift shows
+// the samples it moved.
+func TestDeterministicGoldenVectors(t *testing.T) {
+	cases := []struct {
+		name     string
+		ct       ConverterType
+		channels int
+		ratio    float64
+		want     []uint32 // Output samples 600 to 607
+	}{
+		{"medium mono", SincMediumQuality, 1, 0.5, []uint32{0xbe8d5a96, 0xbbc059cd, 0xbd2dcee0, 0xbebdf2cf, 0x3e2240a6, 0x3e9face8, 0x3e0768d8, 0x3c6cdfd4}},
+		{"best stereo", SincBestQuality, 2, 48000.0 / 44100.0, []uint32{0xbdc7d089, 0x3de62f53, 0xbedc2950, 0x3e1d4395, 0xbded7cbc, 0xbeb51c35, 0x3ea1ca05, 0xbcc59c6a}},
+		{"linear stereo", Linear, 2, 48000.0 / 44100.0, []uint32{0x3e296d29, 0xbdf611a5, 0xbdc04ba8, 0x3dde7230, 0xbe9f0578, 0x3d5c09ab, 0xbe1f5e5b, 0xbe24cb3a}},
+	}
+	for _, c := range cases {
+		conv, err := NewWithOptions(c.ct, c.channels, Options{Deterministic: true})
+		if err != nil {
+			t.Fatalf("%s: NewWithOptions failed: %v", c.name, err)
+		}
+		out := drain(t, conv, deterministicInput(2000, c.channels), c.channels, c.channels, c.ratio, 512)
+		conv.Close()
+		for i, want := range c.want {
+			if got := math.Float32bits(out[600+i]); got != want {
+				t.Errorf("%s: sample %d is %#08x (%g), want %#08x (%g)", c.name, 600+i, got, out[600+i], want, math.Float32frombits(want))
+			}
+		}
+	}
+}
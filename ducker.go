@@ -0,0 +1,142 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// --- Ducker Constants ---
+const (
+	duckerDetectMs = 10.0  // Time constant of the voice energy follower
+	duckerHoldMs   = 200.0 // Keep ducking this long after speech stops, bridges pauses between words
+)
+
+// Ducker lowers (ducks) or mutes the background stream of a mix while the voice
+// stream contains speech, so typing noise or music on hold does not compete with
+// the speaker. Speech is detected by energy: the mean square of the voice,
+// smoothed over about 10ms, above the threshold. The background gain moves to
+// the ducked level with the attack time and back to full with the release time,
+// after a short hold that bridges the pauses between words.
+//
+// Set it in mix.MixerConfig.Ducker, or call Duck per sample. A Ducker keeps state
+// across calls so it can be used on a chunked stream.
+// NOTE: A Ducker is NOT goroutine-safe; use one instance per stream.
+type Ducker struct {
+	thresholdDBov float64
+	duckDB        float64
+
+	threshold   float64 // Linear threshold, squared (compared with the mean square)
+	duckGain    float64 // Background gain while ducked
+	detectCoef  float64 // Per-frame smoothing of the energy follower
+	attackCoef  float64 // Per-frame gain smoothing towards duckGain
+	releaseCoef float64 // Per-frame gain smoothing back to 1.0
+	holdFrames  int
+
+	energy   float64 // Smoothed mean square of the voice
+	holdLeft int     // Frames left before the release starts
+	gain     float64 // Current background gain
+}
+
+// NewDucker creates a ducker for streams at the given sample rate.
+//
+// Args:
+//
+//	sampleRate: Sample rate (Hz) of the streams (the input rate of the mixer, e.g.
+//	            8000 for 8kHz u-law).
+//	thresholdDBov: Voice RMS level (dBov, <= 0) above which the voice counts as speech,
+//	               e.g. -40.
+//	duckDB: Background gain (dB, <= 0) while speech is detected, e.g. -15. Use
+//	        math.Inf(-1) to mute the background.
+//	attack: Time for the background to fade down once speech starts (0 is immediate).
+//	release: Time for the background to fade back up after the hold (0 is immediate).
+//
+// Returns:
+//
+//	A new *Ducker, or nil and an error if the parameters are invalid.
+func NewDucker(sampleRate, thresholdDBov, duckDB float64, attack, release time.Duration) (*Ducker, error) {
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return nil, fmt.Errorf("sampleRate must be positive, got %f", sampleRate)
+	}
+	if thresholdDBov > 0 || math.IsNaN(thresholdDBov) {
+		return nil, fmt.Errorf("thresholdDBov must be <= 0, got %f", thresholdDBov)
+	}
+	if duckDB > 0 || math.IsNaN(duckDB) {
+		return nil, fmt.Errorf("duckDB must be <= 0, got %f", duckDB)
+	}
+	if attack < 0 || release < 0 {
+		return nil, fmt.Errorf("attack and release must not be negative, got %v and %v", attack, release)
+	}
+	threshold := dbovToLinear(thresholdDBov)
+	d := &Ducker{
+		thresholdDBov: thresholdDBov,
+		duckDB:        duckDB,
+		threshold:     threshold * threshold,
+		duckGain:      dbovToLinear(duckDB), // 0 for -Inf
+		detectCoef:    smoothingCoef(sampleRate, duckerDetectMs/1000.0),
+		attackCoef:    smoothingCoef(sampleRate, attack.Seconds()),
+		releaseCoef:   smoothingCoef(sampleRate, release.Seconds()),
+		holdFrames:    int(math.Round(sampleRate * duckerHoldMs / 1000.0)),
+	}
+	d.Reset()
+	return d, nil
+}
+
+// smoothingCoef returns the per-frame coefficient of a one-pole smoother with
+// the given time constant in seconds; 0 seconds gives 0 (no smoothing).
+func smoothingCoef(sampleRate, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	return math.Exp(-1.0 / (sampleRate * seconds))
+}
+
+// Reset clears the detector and restores the full background gain.
+func (d *Ducker) Reset() {
+	if d == nil {
+		return
+	}
+	d.energy = 0
+	d.holdLeft = 0
+	d.gain = 1.0
+}
+
+// ThresholdDBov returns the configured speech threshold in dBov.
+func (d *Ducker) ThresholdDBov() float64 { return d.thresholdDBov }
+
+// DuckDB returns the configured background gain while ducked, in dB.
+func (d *Ducker) DuckDB() float64 { return d.duckDB }
+
+// Gain returns the background gain applied to the last sample (1.0 is full level).
+func (d *Ducker) Gain() float64 { return d.gain }
+
+// Speech reports whether speech was detected at the last sample, including the
+// hold time after it.
+func (d *Ducker) Speech() bool { return d.holdLeft > 0 }
+
+// Duck updates the detector with one voice sample (in [-1.0, 1.0)) and returns
+// the background sample scaled by the current gain. The background may be at
+// any scale.
+func (d *Ducker) Duck(voice, background float32) float32 {
+	v := float64(voice)
+	d.energy = d.detectCoef*d.energy + (1.0-d.detectCoef)*v*v
+
+	target, coef := 1.0, d.releaseCoef
+	if d.energy >= d.threshold {
+		d.holdLeft = d.holdFrames + 1 // Counted down below, still ducking this frame
+	}
+	if d.holdLeft > 0 {
+		d.holdLeft--
+		target, coef = d.duckGain, d.attackCoef
+	}
+	d.gain = target + (d.gain-target)*coef
+	return float32(float64(background) * d.gain)
+}
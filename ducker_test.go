@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestDuckerTimings feeds 1s of silence, 1s of speech-level tone and 1s of
+// silence and checks the background gain follows with the attack and release.
+func TestDuckerTimings(t *testing.T) {
+	const rate = 8000
+	d, err := NewDucker(rate, -40, -20, 20*time.Millisecond, 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewDucker failed: %v", err)
+	}
+	tone := genSine(rate, 440, rate, 0.3)
+	gains := make([]float64, 0, 3*rate)
+	for part := 0; part < 3; part++ {
+		for i := 0; i < rate; i++ {
+			var v float32
+			if part == 1 {
+				v = tone[i]
+			}
+			if got := d.Duck(v, 0.5); math.Abs(float64(got)-0.5*d.Gain()) > 1e-6 {
+				t.Fatalf("Duck returned %g with gain %g", got, d.Gain())
+			}
+			gains = append(gains, d.Gain())
+		}
+	}
+	at := func(ms int) float64 { return gains[ms*rate/1000] }
+
+	if at(900) != 1.0 {
+		t.Errorf("gain %g before speech, want 1", at(900))
+	}
+	if g := at(1100); math.Abs(g-0.1) > 0.01 { // -20 dB, 100ms after speech starts
+		t.Errorf("gain %g during speech, want 0.1", g)
+	}
+	if g := at(2150); math.Abs(g-0.1) > 0.01 {
+		t.Errorf("gain %g within the hold after speech, want 0.1", g)
+	}
+	if g := at(2300); g < 0.15 || g > 0.9 {
+		t.Errorf("gain %g during the release, want between the levels", g)
+	}
+	if g := at(2990); g < 0.9 {
+		t.Errorf("gain %g at the end, want close to 1", g)
+	}
+	if d.Speech() {
+		t.Error("speech still detected after 1s of silence")
+	}
+	d.Reset()
+	if d.Gain() != 1.0 {
+		t.Errorf("gain %g after Reset, want 1", d.Gain())
+	}
+}
+
+func TestDuckerMute(t *testing.T) {
+	d, err := NewDucker(8000, -40, math.Inf(-1), 0, 0)
+	if err != nil {
+		t.Fatalf("NewDucker failed: %v", err)
+	}
+	var out float32
+	for i := 0; i < 100; i++ {
+		out = d.Duck(0.5, 0.5)
+	}
+	if out != 0 {
+		t.Errorf("background %g during speech, want muted", out)
+	}
+
+	for _, bad := range []struct {
+		rate, threshold, duck float64
+		attack, release       time.Duration
+	}{
+		{0, -40, -20, 0, 0},
+		{8000, 3, -20, 0, 0},
+		{8000, -40, 6, 0, 0},
+		{8000, -40, -20, -time.Millisecond, 0},
+	} {
+		if _, err := NewDucker(bad.rate, bad.threshold, bad.duck, bad.attack, bad.release); err == nil {
+			t.Errorf("%+v: expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"io"
+)
+
+// The DumpState output looks like this, with nested converters (channel groups,
+// the inner converter of a channel mapping) indented below their parent:
+//
+//	converter name="call-42" type="Fastest Sinc Interpolator" channels=2 mode=process
+//	  ratio last=0.5 position=0.25 filtered=true
+//	  buffer len=1234 current=600 end=700 realEnd=-1 frames=50
+//	  stats input=48000 output=24000 calls=100 flushes=0 underruns=0 corruptionResets=0 buffered=50
+
+// SetName labels the converter for DumpState.
+func (state *srcState) SetName(name string) {
+	if state != nil {
+		state.name = name
+	}
+}
+
+// Name returns the label set with SetName.
+func (state *srcState) Name() string {
+	if state == nil {
+		return ""
+	}
+	return state.name
+}
+
+// DumpState writes the converter state to w.
+func (state *srcState) DumpState(w io.Writer) error {
+	if state == nil {
+		return mapError(ErrBadState)
+	}
+	return state.dump(w, "")
+}
+
+func (state *srcState) dump(w io.Writer, indent string) error {
+	d := &dumper{w: w, indent: indent}
+	mode := "process"
+	if state.mode == ModeCallback {
+		mode = "callback"
+	}
+	d.line("converter name=%q type=%q channels=%d mode=%s", state.name, GetName(state.converterType), state.channels, mode)
+	d.indent += "  "
+	d.line("ratio last=%g position=%g filtered=%t", state.lastRatio, state.lastPosition, state.filtered)
+	switch filter := state.privateData.(type) {
+	case *sincFilter:
+		frames := int64(0)
+		if state.channels > 0 && filter.bEnd > filter.bCurrent {
+			frames = (filter.bEnd - filter.bCurrent) / int64(state.channels)
+		}
+		d.line("buffer len=%d current=%d end=%d realEnd=%d frames=%d", filter.bLen, filter.bCurrent, filter.bEnd, filter.bRealEnd, frames)
+	case *linearFilter:
+		d.line("history primed=%t", filter.dirty)
+	case *zohFilter:
+		d.line("history primed=%t", filter.dirty)
+	}
+	if state.mode == ModeCallback {
+		d.line("callback savedFrames=%d eof=%t", state.savedFrames, state.callbackEOF)
+	}
+	d.stats(state.Stats())
+	return d.err
+}
+
+// SetName labels the converter for DumpState.
+func (g *channelGroups) SetName(name string) {
+	g.name = name
+}
+
+// Name returns the label set with SetName.
+func (g *channelGroups) Name() string {
+	return g.name
+}
+
+// DumpState writes the state of the groups to w, each group indented below.
+func (g *channelGroups) DumpState(w io.Writer) error {
+	return g.dump(w, "")
+}
+
+func (g *channelGroups) dump(w io.Writer, indent string) error {
+	d := &dumper{w: w, indent: indent}
+	d.line("converter name=%q type=%q channels=%d groups=%d", g.name, GetName(g.converterType), g.channels, len(g.groups))
+	d.indent += "  "
+	d.stats(g.Stats())
+	if d.err != nil {
+		return d.err
+	}
+	first := 0
+	for i, state := range g.groups {
+		d.line("group index=%d firstChannel=%d", i, first)
+		if err := state.dump(w, d.indent+"  "); err != nil {
+			return err
+		}
+		first += g.widths[i]
+	}
+	return d.err
+}
+
+// SetName labels the converter for DumpState.
+func (m *channelMapper) SetName(name string) {
+	m.inner.SetName(name)
+}
+
+// Name returns the label set with SetName.
+func (m *channelMapper) Name() string {
+	return m.inner.Name()
+}
+
+// DumpState writes the channel mapping and, indented below, the inner converter.
+func (m *channelMapper) DumpState(w io.Writer) error {
+	d := &dumper{w: w}
+	d.line("mapper inChannels=%d outChannels=%d", m.mix.in, m.mix.out)
+	if d.err != nil {
+		return d.err
+	}
+	switch inner := m.inner.(type) {
+	case *srcState:
+		return inner.dump(w, "  ")
+	case *channelGroups:
+		return inner.dump(w, "  ")
+	default:
+		return m.inner.DumpState(w)
+	}
+}
+
+// dumper writes indented lines, keeping the first write error.
+type dumper struct {
+	w      io.Writer
+	indent string
+	err    error
+}
+
+func (d *dumper) line(format string, args ...interface{}) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, d.indent+format+"\n", args...)
+}
+
+func (d *dumper) stats(s Stats) {
+	d.line("stats input=%d output=%d calls=%d flushes=%d underruns=%d corruptionResets=%d buffered=%d",
+		s.InputFrames, s.OutputFrames, s.ProcessCalls, s.Flushes, s.Underruns, s.CorruptionResets, s.BufferedFrames)
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDumpState(t *testing.T) {
+	conv, err := New(SincFastest, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	conv.(Namer).SetName("call-42")
+	if _, err := processBlock(t, conv, make([]float32, 2*1000), 2); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	var sb strings.Builder
+	if err := conv.(StateDumper).DumpState(&sb); err != nil {
+		t.Fatalf("DumpState failed: %v", err)
+	}
+	dump := sb.String()
+	for _, want := range []string{
+		`converter name="call-42" type="Fastest Sinc Interpolator" channels=2 mode=process`,
+		"\n  ratio last=1.5 ",
+		"\n  buffer len=",
+		" realEnd=-1 ",
+		"\n  stats input=1000 ",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump does not contain %q:\n%s", want, dump)
+		}
+	}
+
+	clone, err := conv.Clone()
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	defer clone.Close()
+	if clone.(Namer).Name() != "call-42" {
+		t.Errorf("clone name %q, want call-42", clone.(Namer).Name())
+	}
+}
+
+func TestDumpStateNested(t *testing.T) {
+	groups, _ := New(SincFastest, 2*maxChannels)
+	defer groups.Close()
+	groups.(Namer).SetName("wide")
+	var sb strings.Builder
+	if err := groups.(StateDumper).DumpState(&sb); err != nil {
+		t.Fatalf("DumpState failed: %v", err)
+	}
+	if n := strings.Count(sb.String(), "\n    converter "); n != 2 {
+		t.Errorf("found %d indented group converters, want 2:\n%s", n, sb.String())
+	}
+
+	mapper, err := NewWithOptions(Linear, 2, Options{OutputChannels: 1})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer mapper.Close()
+	mapper.(Namer).SetName("downmix")
+	sb.Reset()
+	if err := mapper.(StateDumper).DumpState(&sb); err != nil {
+		t.Fatalf("DumpState failed: %v", err)
+	}
+	if !strings.HasPrefix(sb.String(), "mapper inChannels=2 outChannels=1\n  converter name=\"downmix\"") {
+		t.Errorf("unexpected mapper dump:\n%s", sb.String())
+	}
+	if mapper.(Namer).Name() != "downmix" {
+		t.Errorf("mapper name %q, want downmix", mapper.(Namer).Name())
+	}
+
+	if err := mapper.(StateDumper).DumpState(failingWriter{}); err == nil {
+		t.Error("expected the write error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
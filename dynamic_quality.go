@@ -0,0 +1,284 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// qualityLadder lists the converter types DynamicQuality steps through, best
+// first.
+var qualityLadder = []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, Linear}
+
+// Input frames DynamicQuality keeps as history for a better filter taking
+// over, enough for the SincBestQuality filter down to a ratio of about 1/8
+const dqHistoryFrames = 2048
+
+// DynamicQualityOptions tunes DynamicQuality. The zero value starts at
+// SincBestQuality, steps down above half of real time and back up below a
+// tenth, judged over one second of audio.
+type DynamicQualityOptions struct {
+	// Top is the quality used first and the highest stepped back up to: one of
+	// SincBestQuality (the zero value), SincMediumQuality, SincFastest or Linear.
+	Top ConverterType
+	// HighLoad is the processing time, as a fraction of the audio's real-time
+	// duration, above which the quality steps down; 0 means 0.5. On a server
+	// running many streams, lower it to the share of a core one stream may use.
+	HighLoad float64
+	// LowLoad is the load below which the quality steps back up; 0 means 0.1.
+	// Keep it well below HighLoad divided by how much costlier the next
+	// quality is (several times, see BenchmarkConverters), or it flaps.
+	LowLoad float64
+	// Window is how much audio the load is measured over before each decision;
+	// 0 means one second.
+	Window time.Duration
+	// OnChange, if set, is called after each step with the old and new quality
+	// and the load that caused it, e.g. to log it.
+	OnChange func(from, to ConverterType, load float64)
+}
+
+// DynamicQuality resamples a stream in process mode like a Converter, but
+// measures how long each block takes against its real-time duration and steps
+// the quality down, SincBestQuality to SincMediumQuality to SincFastest to
+// Linear, when the machine cannot keep up, and back up when the headroom
+// returns, so a soft real-time server degrades the audio instead of falling
+// behind.
+//
+// A step replaces the converter between two Process calls. The new one takes
+// the stream over as with AdoptTail, with the last input frames as history, so
+// the stream goes on without a gap, repeat or phase jump; as the converters
+// look ahead by different amounts, the block after a step gives a few frames
+// more or fewer than usual. A step the new converter cannot take over yet, as
+// when Linear's read position is more than a frame past its input, is tried
+// again after the next block.
+//
+// NOTE: A DynamicQuality is NOT goroutine-safe.
+type DynamicQuality struct {
+	conv     Converter
+	level    int // Index of the quality in qualityLadder
+	top      int
+	channels int
+	inRate   float64
+	opts     DynamicQualityOptions
+	clock    func() time.Time // time.Now, replaced in tests
+
+	history []float32 // Last input frames, the history of a converter taking over
+	want    int       // Level of a step still to take, level when none
+	out     []float32 // Output of the input fed to a new converter, reused
+	pending []float32 // Frames the new converter gave while fed, still to return
+
+	elapsed time.Duration // Processing time in the current window
+	audio   time.Duration // Audio processed in the current window
+	load    float64       // Load of the last complete window
+	stepErr error         // Error of the last step, see LastError
+}
+
+// NewDynamicQuality creates a DynamicQuality for channels interleaved channels
+// of input at inRate Hz.
+func NewDynamicQuality(channels int, inRate float64, opts DynamicQualityOptions) (*DynamicQuality, error) {
+	top := -1
+	for i, q := range qualityLadder {
+		if q == opts.Top {
+			top = i
+		}
+	}
+	if top < 0 {
+		return nil, fmt.Errorf("top quality %s is not on the quality ladder: %w", opts.Top, mapError(ErrBadConverter))
+	}
+	if !(inRate > 0) || math.IsInf(inRate, 0) {
+		return nil, fmt.Errorf("input rate must be positive, got %f", inRate)
+	}
+	if opts.HighLoad == 0 {
+		opts.HighLoad = 0.5
+	}
+	if opts.LowLoad == 0 {
+		opts.LowLoad = 0.1
+	}
+	if opts.Window == 0 {
+		opts.Window = time.Second
+	}
+	if !(opts.HighLoad > 0) || !(opts.LowLoad > 0) || opts.LowLoad >= opts.HighLoad || opts.Window < 0 {
+		return nil, fmt.Errorf("need 0 < LowLoad (%f) < HighLoad (%f) and a non-negative Window", opts.LowLoad, opts.HighLoad)
+	}
+	conv, err := New(qualityLadder[top], channels)
+	if err != nil {
+		return nil, err
+	}
+	return &DynamicQuality{
+		conv:     conv,
+		level:    top,
+		top:      top,
+		want:     top,
+		channels: channels,
+		inRate:   inRate,
+		opts:     opts,
+		clock:    time.Now,
+	}, nil
+}
+
+// Quality returns the converter type in use.
+func (dq *DynamicQuality) Quality() ConverterType {
+	return qualityLadder[dq.level]
+}
+
+// Load returns the processing time over the real-time duration of the audio in
+// the last complete measuring window, 0 before the first.
+func (dq *DynamicQuality) Load() float64 {
+	return dq.load
+}
+
+// Process converts data as Converter.Process does, then steps the quality if
+// the measuring window is complete. A step that fails does not fail the block,
+// whose output is valid: the quality stays, the step is tried again after the
+// next block, and LastError reports the failure.
+func (dq *DynamicQuality) Process(data *SrcData) error {
+	if data == nil {
+		return mapError(ErrBadData)
+	}
+	start := dq.clock()
+	err := dq.process(data)
+	elapsed := dq.clock().Sub(start)
+	if err != nil {
+		return err
+	}
+	dq.remember(data)
+	if data.InputFramesUsed == 0 || data.EndOfInput {
+		return nil // Drains and empty blocks say nothing about the load
+	}
+	dq.elapsed += elapsed
+	dq.audio += time.Duration(float64(data.InputFramesUsed) / dq.inRate * float64(time.Second))
+	if dq.audio >= dq.opts.Window {
+		dq.load = float64(dq.elapsed) / float64(dq.audio)
+		dq.elapsed, dq.audio = 0, 0
+		switch {
+		case dq.load > dq.opts.HighLoad && dq.level < len(qualityLadder)-1:
+			dq.want = dq.level + 1
+		case dq.load < dq.opts.LowLoad && dq.level > dq.top:
+			dq.want = dq.level - 1
+		}
+	}
+	if dq.want != dq.level {
+		dq.stepErr = dq.step(dq.want)
+	}
+	return nil
+}
+
+// LastError returns the error of the last step of the quality that failed, or
+// nil once a step succeeds.
+func (dq *DynamicQuality) LastError() error {
+	return dq.stepErr
+}
+
+// process returns the pending frames, then runs the converter.
+func (dq *DynamicQuality) process(data *SrcData) error {
+	work := *data
+	var gen int64
+	if len(dq.pending) > 0 {
+		ch := int64(dq.channels)
+		n := int64(copy(work.DataOut[:minInt64(maxInt64(work.OutputFrames, 0)*ch, int64(len(work.DataOut)))], dq.pending)) / ch
+		dq.pending = dq.pending[:copy(dq.pending, dq.pending[n*ch:])]
+		gen = n
+		work.DataOut = work.DataOut[n*ch:]
+		work.OutputFrames -= n
+		if work.OutputFrames <= 0 {
+			data.InputFramesUsed, data.OutputFramesGen = 0, gen
+			return nil
+		}
+	}
+	if err := dq.conv.Process(&work); err != nil {
+		return err
+	}
+	data.InputFramesUsed, data.OutputFramesGen = work.InputFramesUsed, gen+work.OutputFramesGen
+	return nil
+}
+
+// remember keeps the last input frames.
+func (dq *DynamicQuality) remember(data *SrcData) {
+	ch := dq.channels
+	dq.history = append(dq.history, data.DataIn[:data.InputFramesUsed*int64(ch)]...)
+	if len(dq.history) > 2*dqHistoryFrames*ch {
+		dq.history = dq.history[:copy(dq.history, dq.history[len(dq.history)-dqHistoryFrames*ch:])]
+	}
+}
+
+// step replaces the converter by one of quality qualityLadder[level], which
+// takes the stream over. It leaves the converter as it was when the new one
+// cannot take over yet.
+func (dq *DynamicQuality) step(level int) error {
+	t, errCode := tailOf(dq.conv)
+	if errCode != ErrNoError {
+		return mapError(errCode)
+	}
+	// The tail ends at the last input frame consumed, as the history does,
+	// which goes back further
+	ch := dq.channels
+	if extra := (len(dq.history) - len(t.frames)) / ch; extra > 0 && len(t.frames) > 0 {
+		t.frames, t.current = dq.history, t.current+extra
+	}
+	// Linear keeps only the frame under the read position: the new converter
+	// is fed the frames past it, and what they give is pending
+	var feed []float32
+	if qualityLadder[level] == Linear && len(t.frames) > (t.current+1)*ch {
+		t.frames, feed = t.frames[:(t.current+1)*ch], t.frames[(t.current+1)*ch:]
+	}
+	conv, err := New(qualityLadder[level], ch)
+	if err != nil {
+		return err
+	}
+	if errCode := installTail(conv, &t, false); errCode != ErrNoError {
+		conv.Close()
+		if errCode == ErrBadState {
+			return nil // Not yet, see the type
+		}
+		return mapError(errCode)
+	}
+	if errCode := installTail(conv, &t, true); errCode != ErrNoError {
+		conv.Close()
+		return mapError(errCode)
+	}
+
+	dq.out = growFloats(dq.out, ch*(int(float64(len(feed)/ch)*maxFloat64(t.ratio, 1))+16))
+	data := SrcData{DataIn: feed, InputFrames: int64(len(feed) / ch), SrcRatio: t.ratio}
+	for data.InputFrames > 0 {
+		data.DataOut, data.OutputFrames = dq.out, int64(len(dq.out)/ch)
+		if err := conv.Process(&data); err != nil {
+			conv.Close()
+			return err
+		}
+		dq.pending = append(dq.pending, dq.out[:data.OutputFramesGen*int64(ch)]...)
+		data.DataIn = data.DataIn[data.InputFramesUsed*int64(ch):]
+		data.InputFrames -= data.InputFramesUsed
+		if data.InputFramesUsed == 0 && data.OutputFramesGen == 0 {
+			break
+		}
+	}
+	dq.conv.Close()
+	dq.conv = conv
+	from := qualityLadder[dq.level]
+	dq.level, dq.want = level, level
+	if dq.opts.OnChange != nil {
+		dq.opts.OnChange(from, qualityLadder[level], dq.load)
+	}
+	return nil
+}
+
+// Reset discards the buffered audio for a new stream; the quality stays.
+func (dq *DynamicQuality) Reset() error {
+	dq.history, dq.pending = dq.history[:0], dq.pending[:0]
+	dq.want, dq.stepErr = dq.level, nil
+	dq.elapsed, dq.audio = 0, 0
+	return dq.conv.Reset()
+}
+
+// Close releases the converter.
+func (dq *DynamicQuality) Close() error {
+	return dq.conv.Close()
+}
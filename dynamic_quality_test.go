@@ -0,0 +1,201 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestDynamicQualitySteps streams a sine in blocks of about 20ms with a fake clock that
+// makes the blocks expensive, then cheap, and checks the quality steps down to
+// Linear and back up to the top, while the output stays the sine: a step that
+// lost or repeated a frame would show as an error of a tenth of the amplitude.
+func TestDynamicQualitySteps(t *testing.T) {
+	const (
+		rate   = 8000.0
+		freq   = 300.0
+		amp    = 0.5
+		block  = 157 // About 20ms; not whole periods of the ratios, see below
+		blocks = 300
+	)
+	for _, channels := range []int{1, 2} {
+		for _, ratio := range []float64{2, 0.5, 0.21} {
+			var changes []ConverterType
+			dq, err := NewDynamicQuality(channels, rate, DynamicQualityOptions{
+				Window:   100 * time.Millisecond,
+				OnChange: func(from, to ConverterType, load float64) { changes = append(changes, to) },
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var now time.Time
+			cost := 18 * time.Millisecond // 0.9 of real time
+			dq.clock = func() time.Time {
+				now = now.Add(cost) // Between the two reads of each Process
+				return now
+			}
+
+			mono := genSine(block*blocks, freq, rate, amp)
+			in := make([]float32, len(mono)*channels)
+			for i, v := range mono {
+				for c := 0; c < channels; c++ {
+					in[i*channels+c] = v
+				}
+			}
+			out := make([]float32, 4*block*channels)
+			var got []float32
+			for b := 0; ; b++ {
+				if b == 100 {
+					cost = 100 * time.Microsecond // 0.005 of real time
+				}
+				start := minInt(b*block, blocks*block) * channels
+				end := minInt((b+1)*block, blocks*block) * channels
+				data := SrcData{
+					DataIn:       in[start:end],
+					InputFrames:  int64((end - start) / channels),
+					DataOut:      out,
+					OutputFrames: int64(len(out) / channels),
+					SrcRatio:     ratio,
+					EndOfInput:   end == len(in),
+				}
+				if err := dq.Process(&data); err != nil {
+					t.Fatal(err)
+				}
+				if data.InputFramesUsed != data.InputFrames {
+					t.Fatalf("block %d: used %d of %d frames", b, data.InputFramesUsed, data.InputFrames)
+				}
+				got = append(got, out[:data.OutputFramesGen*int64(channels)]...)
+				if data.EndOfInput && data.OutputFramesGen == 0 {
+					break
+				}
+			}
+			dq.Close()
+
+			want := []ConverterType{SincMediumQuality, SincFastest, Linear, SincFastest, SincMediumQuality, SincBestQuality}
+			if len(changes) != len(want) {
+				t.Fatalf("%dch ratio %g: steps %v, want %v", channels, ratio, changes, want)
+			}
+			for i := range want {
+				if changes[i] != want[i] {
+					t.Fatalf("%dch ratio %g: steps %v, want %v", channels, ratio, changes, want)
+				}
+			}
+			if dq.Quality() != SincBestQuality || dq.Load() > 0.1 {
+				t.Errorf("%dch ratio %g: ends at %s with load %.3f", channels, ratio, dq.Quality(), dq.Load())
+			}
+
+			frames := len(got) / channels
+			if wantFrames := int(math.Round(float64(len(mono)) * ratio)); frames < wantFrames-2 || frames > wantFrames+2 {
+				t.Errorf("%dch ratio %g: %d frames, want %d", channels, ratio, frames, wantFrames)
+			}
+			// The ends have the filter transients; downsampling a 300Hz tone to
+			// 1680Hz leaves it in band, so the error bound holds for all ratios.
+			// At 0.21, Linear's read position often ends two frames or more past
+			// its input, so steps up from it wait for a later block
+			worst := 0.0
+			for k := 200; k < frames-200; k++ {
+				ideal := amp * math.Sin(2*math.Pi*freq*float64(k)/ratio/rate)
+				for c := 0; c < channels; c++ {
+					worst = maxFloat64(worst, math.Abs(float64(got[k*channels+c])-ideal))
+				}
+			}
+			if worst > 0.03 {
+				t.Errorf("%dch ratio %g: output deviates from the sine by %.4f", channels, ratio, worst)
+			}
+		}
+	}
+}
+
+func TestDynamicQualityOptions(t *testing.T) {
+	if _, err := NewDynamicQuality(1, 8000, DynamicQualityOptions{Top: ZeroOrderHold}); err == nil {
+		t.Error("expected error for a top quality off the ladder")
+	}
+	if _, err := NewDynamicQuality(1, 0, DynamicQualityOptions{}); err == nil {
+		t.Error("expected error for a zero input rate")
+	}
+	if _, err := NewDynamicQuality(1, 8000, DynamicQualityOptions{HighLoad: 0.2, LowLoad: 0.3}); err == nil {
+		t.Error("expected error for LowLoad above HighLoad")
+	}
+	if _, err := NewDynamicQuality(0, 8000, DynamicQualityOptions{}); err == nil {
+		t.Error("expected error for zero channels")
+	}
+
+	dq, err := NewDynamicQuality(1, 8000, DynamicQualityOptions{Top: SincFastest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dq.Close()
+	if dq.Quality() != SincFastest {
+		t.Errorf("starts at %s, want sinc_fastest", dq.Quality())
+	}
+	in := genSine(800, 440, 8000, 0.5)
+	out := make([]float32, 2000)
+	data := SrcData{DataIn: in, InputFrames: 800, DataOut: out, OutputFrames: 2000, SrcRatio: 2}
+	if err := dq.Process(&data); err != nil {
+		t.Fatal(err)
+	}
+	first := append([]float32(nil), out[:data.OutputFramesGen]...)
+	if err := dq.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	data = SrcData{DataIn: in, InputFrames: 800, DataOut: out, OutputFrames: 2000, SrcRatio: 2}
+	if err := dq.Process(&data); err != nil {
+		t.Fatal(err)
+	}
+	checkSameSamples(t, "after Reset", out[:data.OutputFramesGen], first)
+	if err := dq.Process(nil); err == nil {
+		t.Error("expected error for nil data")
+	}
+}
+
+// opaqueConverter hides the converter it wraps from tailOf, so a step from it
+// fails.
+type opaqueConverter struct {
+	Converter
+}
+
+// TestDynamicQualityStepError checks a failed step keeps the block's output and
+// the quality, and shows in LastError until a step succeeds.
+func TestDynamicQualityStepError(t *testing.T) {
+	dq, err := NewDynamicQuality(1, 8000, DynamicQualityOptions{Window: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dq.Close()
+	var now time.Time
+	dq.clock = func() time.Time {
+		now = now.Add(90 * time.Millisecond) // 0.9 of real time per block
+		return now
+	}
+	dq.conv = opaqueConverter{dq.conv}
+
+	in := genSine(800, 440, 8000, 0.5)
+	out := make([]float32, 2000)
+	data := SrcData{DataIn: in, InputFrames: 800, DataOut: out, OutputFrames: 2000, SrcRatio: 2}
+	if err := dq.Process(&data); err != nil {
+		t.Fatalf("Process failed with a failed step: %v", err)
+	}
+	if data.InputFramesUsed != 800 || data.OutputFramesGen == 0 {
+		t.Errorf("used %d frames and gave %d, want the block converted", data.InputFramesUsed, data.OutputFramesGen)
+	}
+	if dq.LastError() == nil || dq.Quality() != SincBestQuality {
+		t.Fatalf("LastError %v at %s, want the step error at sinc_best_quality", dq.LastError(), dq.Quality())
+	}
+
+	dq.conv = dq.conv.(opaqueConverter).Converter
+	data = SrcData{DataIn: in, InputFrames: 800, DataOut: out, OutputFrames: 2000, SrcRatio: 2}
+	if err := dq.Process(&data); err != nil {
+		t.Fatal(err)
+	}
+	if dq.LastError() != nil || dq.Quality() != SincMediumQuality {
+		t.Errorf("LastError %v at %s after the retry, want nil at sinc_medium_quality", dq.LastError(), dq.Quality())
+	}
+}
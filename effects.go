@@ -0,0 +1,281 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// --- Effects Constants ---
+const (
+	telephoneBandLowHz  = 300.0  // Lower edge of the PSTN voice band
+	telephoneBandHighHz = 3400.0 // Upper edge of the PSTN voice band
+	butterworthQ        = 0.7071067811865476
+)
+
+// butterworth4Q holds the Q of the two biquad sections of a fourth-order
+// Butterworth filter.
+var butterworth4Q = [2]float64{0.5411961001461970, 1.3065629648763766}
+
+// Effect processes interleaved float frames in place. Effects keep per-channel
+// state across calls, so one instance must only be used for one stream.
+type Effect interface {
+	// Apply processes len(samples)/channels interleaved frames in place.
+	Apply(samples []float32, channels int)
+	// Reset clears the effect's state, e.g. for a new stream.
+	Reset()
+}
+
+// Chain is a list of effects applied in order. It is itself an Effect, so it can
+// be attached to a converter (SetEffects) or to the mixer (mix.MixerConfig.Effects).
+type Chain []Effect
+
+// Apply runs every effect of the chain over the samples, in order.
+func (c Chain) Apply(samples []float32, channels int) {
+	for _, e := range c {
+		e.Apply(samples, channels)
+	}
+}
+
+// Reset resets every effect of the chain.
+func (c Chain) Reset() {
+	for _, e := range c {
+		e.Reset()
+	}
+}
+
+// SetEffects attaches an effect (typically a Chain) to a converter: it runs on the
+// frames generated by every Process call, in place in DataOut, before the caller
+// packs them into bytes. nil removes it. Reset on the converter also resets it;
+// clones start without effects, since effect state belongs to one stream.
+func SetEffects(c Converter, e Effect) error {
+	switch conv := c.(type) {
+	case *srcState:
+		if conv == nil {
+			return mapError(ErrBadState)
+		}
+		conv.post.effects = e
+		return nil
+	case *channelGroups:
+		conv.post.effects = e // Applied to the interleaved output of all groups
+		return nil
+	case *channelMapper:
+		conv.post.effects = e // Applied to the output layout
+		return nil
+	default:
+		return fmt.Errorf("effects not supported by %T", c)
+	}
+}
+
+// --- Gain ---
+
+// Gain scales every sample by a fixed factor.
+type Gain struct {
+	factor float32
+}
+
+// NewGain returns a gain of the given number of decibels (negative attenuates).
+func NewGain(db float64) *Gain {
+	g := &Gain{}
+	g.SetDB(db)
+	return g
+}
+
+// SetDB changes the gain.
+func (g *Gain) SetDB(db float64) {
+	g.factor = float32(math.Pow(10.0, db/20.0))
+}
+
+// DB returns the gain in decibels.
+func (g *Gain) DB() float64 {
+	return 20.0 * math.Log10(float64(g.factor))
+}
+
+// Apply scales the samples in place.
+func (g *Gain) Apply(samples []float32, channels int) {
+	for i := range samples {
+		samples[i] *= g.factor
+	}
+}
+
+// Reset does nothing; Gain has no state.
+func (g *Gain) Reset() {}
+
+// --- Fade ---
+
+// Fade ramps the gain linearly from a start to an end gain over a number of frames,
+// then holds the end gain. Use NewFadeIn/NewFadeOut for the common cases.
+type Fade struct {
+	startGain, endGain float64
+	frames             int64
+	pos                int64
+}
+
+// NewFade returns a linear fade from startGain to endGain (linear factors) over frames.
+func NewFade(startGain, endGain float64, frames int64) *Fade {
+	if frames < 0 {
+		frames = 0
+	}
+	return &Fade{startGain: startGain, endGain: endGain, frames: frames}
+}
+
+// NewFadeIn returns a fade from silence to full level over frames.
+func NewFadeIn(frames int64) *Fade {
+	return NewFade(0, 1, frames)
+}
+
+// NewFadeOut returns a fade from full level to silence over frames.
+func NewFadeOut(frames int64) *Fade {
+	return NewFade(1, 0, frames)
+}
+
+// Done reports whether the ramp has finished.
+func (f *Fade) Done() bool {
+	return f.pos >= f.frames
+}
+
+// Apply multiplies each frame by the current fade gain.
+func (f *Fade) Apply(samples []float32, channels int) {
+	if channels <= 0 {
+		return
+	}
+	frames := len(samples) / channels
+	for fr := 0; fr < frames; fr++ {
+		gain := f.endGain
+		if f.pos < f.frames {
+			gain = f.startGain + (f.endGain-f.startGain)*float64(f.pos)/float64(f.frames)
+			f.pos++
+		}
+		g := float32(gain)
+		for ch := 0; ch < channels; ch++ {
+			samples[fr*channels+ch] *= g
+		}
+	}
+}
+
+// Reset restarts the fade.
+func (f *Fade) Reset() {
+	f.pos = 0
+}
+
+// --- Biquad EQ ---
+
+// BiquadFilter is a second-order IIR filter (RBJ audio EQ cookbook designs), with
+// separate state per channel.
+type BiquadFilter struct {
+	bq     biquad
+	states []biquadState
+}
+
+// NewLowPass returns a low-pass filter with the given cutoff (Hz) and Q
+// (0.7071 for Butterworth).
+func NewLowPass(sampleRate, cutoffHz, q float64) (*BiquadFilter, error) {
+	w0, alpha, err := biquadParams(sampleRate, cutoffHz, q)
+	if err != nil {
+		return nil, err
+	}
+	cosW0 := math.Cos(w0)
+	return newBiquadFilter((1-cosW0)/2, 1-cosW0, (1-cosW0)/2, 1+alpha, -2*cosW0, 1-alpha), nil
+}
+
+// NewHighPass returns a high-pass filter with the given cutoff (Hz) and Q.
+func NewHighPass(sampleRate, cutoffHz, q float64) (*BiquadFilter, error) {
+	w0, alpha, err := biquadParams(sampleRate, cutoffHz, q)
+	if err != nil {
+		return nil, err
+	}
+	cosW0 := math.Cos(w0)
+	return newBiquadFilter((1+cosW0)/2, -(1 + cosW0), (1+cosW0)/2, 1+alpha, -2*cosW0, 1-alpha), nil
+}
+
+// NewPeakingEQ returns a peaking (bell) EQ boosting or cutting gainDB around centerHz.
+func NewPeakingEQ(sampleRate, centerHz, q, gainDB float64) (*BiquadFilter, error) {
+	w0, alpha, err := biquadParams(sampleRate, centerHz, q)
+	if err != nil {
+		return nil, err
+	}
+	a := math.Pow(10.0, gainDB/40.0)
+	cosW0 := math.Cos(w0)
+	return newBiquadFilter(1+alpha*a, -2*cosW0, 1-alpha*a, 1+alpha/a, -2*cosW0, 1-alpha/a), nil
+}
+
+// TelephoneBandLimit returns a chain limiting audio to the 300-3400Hz PSTN voice
+// band (second-order Butterworth high-pass and low-pass).
+func TelephoneBandLimit(sampleRate float64) (Chain, error) {
+	hp, err := NewHighPass(sampleRate, telephoneBandLowHz, butterworthQ)
+	if err != nil {
+		return nil, err
+	}
+	lp, err := NewLowPass(sampleRate, telephoneBandHighHz, butterworthQ)
+	if err != nil {
+		return nil, err
+	}
+	return Chain{hp, lp}, nil
+}
+
+// NewTelephonyBandpass returns the 300-3400Hz band-pass of the TelephonyBandpass
+// options: fourth-order Butterworth high-pass and low-pass edges, each a cascade
+// of two biquads, rolling off twice as steeply as TelephoneBandLimit. The sample
+// rate must be above 6800Hz.
+func NewTelephonyBandpass(sampleRate float64) (Chain, error) {
+	var c Chain
+	for _, q := range butterworth4Q {
+		hp, err := NewHighPass(sampleRate, telephoneBandLowHz, q)
+		if err != nil {
+			return nil, err
+		}
+		lp, err := NewLowPass(sampleRate, telephoneBandHighHz, q)
+		if err != nil {
+			return nil, err
+		}
+		c = append(c, hp, lp)
+	}
+	return c, nil
+}
+
+// Apply filters each channel in place.
+func (f *BiquadFilter) Apply(samples []float32, channels int) {
+	if channels <= 0 {
+		return
+	}
+	if len(f.states) < channels {
+		f.states = append(f.states, make([]biquadState, channels-len(f.states))...)
+	}
+	for i, x := range samples {
+		samples[i] = float32(f.bq.process(&f.states[i%channels], float64(x)))
+	}
+}
+
+// Reset clears the filter history.
+func (f *BiquadFilter) Reset() {
+	for i := range f.states {
+		f.states[i] = biquadState{}
+	}
+}
+
+// biquadParams validates the design parameters and returns w0 and alpha.
+func biquadParams(sampleRate, freq, q float64) (w0, alpha float64, err error) {
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return 0, 0, fmt.Errorf("sampleRate must be positive, got %f", sampleRate)
+	}
+	if !(freq > 0 && freq < sampleRate/2) {
+		return 0, 0, fmt.Errorf("frequency %f Hz must be between 0 and Nyquist (%f Hz)", freq, sampleRate/2)
+	}
+	if !(q > 0) || math.IsInf(q, 0) {
+		return 0, 0, fmt.Errorf("q must be positive, got %f", q)
+	}
+	w0 = 2 * math.Pi * freq / sampleRate
+	return w0, math.Sin(w0) / (2 * q), nil
+}
+
+// newBiquadFilter normalizes the coefficients by a0.
+func newBiquadFilter(b0, b1, b2, a0, a1, a2 float64) *BiquadFilter {
+	return &BiquadFilter{bq: biquad{b0: b0 / a0, b1: b1 / a0, b2: b2 / a0, a1: a1 / a0, a2: a2 / a0}}
+}
@@ -0,0 +1,153 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// rmsGo returns the RMS of the samples.
+func rmsGo(samples []float32) float64 {
+	sum := 0.0
+	for _, v := range samples {
+		sum += float64(v) * float64(v)
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+func TestGainAndFade(t *testing.T) {
+	g := NewGain(-6.0206)
+	samples := []float32{1, -1, 0.5}
+	g.Apply(samples, 1)
+	if math.Abs(float64(samples[0])-0.5) > 1e-4 || math.Abs(float64(samples[2])-0.25) > 1e-4 {
+		t.Errorf("-6 dB gain gave %v", samples)
+	}
+	if math.Abs(g.DB()+6.0206) > 1e-4 {
+		t.Errorf("DB() = %f, want -6.0206", g.DB())
+	}
+
+	// Stereo fade-in over 4 frames, applied in two blocks
+	f := NewFadeIn(4)
+	block := []float32{1, 1, 1, 1, 1, 1}
+	f.Apply(block, 2)
+	f.Apply(block[:0], 2)
+	rest := []float32{1, 1, 1, 1, 1, 1}
+	f.Apply(rest, 2)
+	want := []float32{0, 0, 0.25, 0.25, 0.5, 0.5, 0.75, 0.75, 1, 1, 1, 1}
+	got := append(block, rest...)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("fade-in gave %v, want %v", got, want)
+		}
+	}
+	if !f.Done() {
+		t.Error("fade should be done")
+	}
+	f.Reset()
+	if f.Done() {
+		t.Error("Reset should restart the fade")
+	}
+}
+
+func TestTelephoneBandLimit(t *testing.T) {
+	const rate = 8000.0
+	tests := []struct {
+		freq         float64
+		minDB, maxDB float64
+	}{
+		{100, -30, -15},
+		{1000, -0.5, 0.5},
+		{3000, -3, 0.5},
+		{3900, -100, -20},
+	}
+	for _, tt := range tests {
+		chain, err := TelephoneBandLimit(rate)
+		if err != nil {
+			t.Fatalf("TelephoneBandLimit failed: %v", err)
+		}
+		in := genSine(int(rate), tt.freq, rate, 0.5)
+		out := append([]float32(nil), in...)
+		chain.Apply(out, 1)
+		settled := len(out) / 2
+		db := 20.0 * math.Log10(rmsGo(out[settled:])/rmsGo(in[settled:]))
+		if db < tt.minDB || db > tt.maxDB {
+			t.Errorf("%.0f Hz: response %.2f dB, want in [%.1f, %.1f]", tt.freq, db, tt.minDB, tt.maxDB)
+		}
+	}
+
+	if _, err := TelephoneBandLimit(6000); err == nil {
+		t.Error("expected error when 3400 Hz is above Nyquist")
+	}
+	if _, err := NewPeakingEQ(8000, 1000, 0, 6); err == nil {
+		t.Error("expected error for zero Q")
+	}
+}
+
+// TestSetEffectsMatchesOffline checks a chain attached to a converter gives the
+// same result as running it over the converter output afterwards, with filter
+// state carried across Process calls and cleared by Reset.
+func TestSetEffectsMatchesOffline(t *testing.T) {
+	const channels = 2
+	mono := genSine(8000, 440, 16000, 0.5)
+	in := make([]float32, len(mono)*channels)
+	for i, v := range mono {
+		in[i*channels] = v
+		in[i*channels+1] = -v
+	}
+	newChain := func() Chain {
+		band, err := TelephoneBandLimit(8000)
+		if err != nil {
+			t.Fatalf("TelephoneBandLimit failed: %v", err)
+		}
+		return append(Chain{NewGain(-3), NewFadeIn(100)}, band...)
+	}
+
+	ref, err := New(SincFastest, channels)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer ref.Close()
+	want, err := processAll(ref, in, channels, 0.5)
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+	newChain().Apply(want, channels)
+
+	conv, err := New(SincFastest, channels)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	if err := SetEffects(conv, newChain()); err != nil {
+		t.Fatalf("SetEffects failed: %v", err)
+	}
+	for pass := 0; pass < 2; pass++ {
+		stage := &streamStage{conv: conv, ratio: 0.5, scratch: make([]float32, 256*channels)}
+		var got []float32
+		for off := 0; off < len(in); off += 320 * channels {
+			end := minInt(off+320*channels, len(in))
+			if err := stage.process(in[off:end], channels, end == len(in)); err != nil {
+				t.Fatalf("process failed: %v", err)
+			}
+			got = append(got, stage.out...)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("pass %d: got %d samples, want %d", pass, len(got), len(want))
+		}
+		for i := range want {
+			if math.Abs(float64(got[i]-want[i])) > 1e-5 {
+				t.Fatalf("pass %d: sample %d is %g, want %g", pass, i, got[i], want[i])
+			}
+		}
+		if err := conv.Reset(); err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+	}
+}
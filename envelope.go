@@ -0,0 +1,33 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// Envelope returns the gains of the first (voice) stream, g1, and of the second
+// (background) stream, g2, at an input frame of a mix. It replaces the constant
+// mix factor, so the background can be faded over time within and across mix
+// calls (see mix.MixerConfig.Envelope).
+type Envelope func(frame int64) (g1, g2 float32)
+
+// FadeBackground returns an Envelope keeping the voice at voiceGain and moving
+// the background gain from `from` to `to` over frames input frames starting at
+// frame start, along a raised cosine; before start it is `from`, after the fade
+// `to`. E.g. FadeBackground(0.5, 0.5, 0.1, t, 24000) ducks hold music over one
+// second of 24kHz input when an agent connects at frame t.
+func FadeBackground(voiceGain, from, to float32, start, frames int64) Envelope {
+	return func(frame int64) (float32, float32) {
+		switch {
+		case frame < start:
+			return voiceGain, from
+		case frame >= start+frames:
+			return voiceGain, to
+		}
+		t := fadeGain(frame-start, frames)
+		return voiceGain, from + (to-from)*t
+	}
+}
@@ -0,0 +1,38 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFadeBackground(t *testing.T) {
+	env := FadeBackground(0.5, 0.8, 0.2, 100, 50)
+	for _, tt := range []struct {
+		frame  int64
+		g2     float32
+		within float32
+	}{
+		{0, 0.8, 0}, {99, 0.8, 0}, {100, 0.8, 0}, {125, 0.5, 1e-6}, {150, 0.2, 0}, {1000, 0.2, 0},
+	} {
+		g1, g2 := env(tt.frame)
+		if g1 != 0.5 || float32(math.Abs(float64(g2-tt.g2))) > tt.within {
+			t.Errorf("frame %d: gains %g, %g; want 0.5, %g", tt.frame, g1, g2, tt.g2)
+		}
+	}
+	prev := float32(1)
+	for f := int64(100); f <= 150; f++ {
+		_, g2 := env(f)
+		if g2 > prev {
+			t.Fatalf("fade not monotonic at frame %d: %g after %g", f, g2, prev)
+		}
+		prev = g2
+	}
+}
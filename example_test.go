@@ -0,0 +1,117 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate_test
+
+import (
+	"fmt"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+)
+
+// Convert an 8kHz u-law leg to 16kHz S16LE PCM, e.g. to feed a speech recognizer.
+func ExampleConvertUlawToPCM() {
+	ulaw := corpus.MustReadFile(corpus.Speech8kUlaw) // 1 second of 8kHz u-law
+
+	pcm, err := libsamplerate.ConvertUlawToPCM(ulaw, libsamplerate.SincMediumQuality)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(len(ulaw), "u-law bytes ->", len(pcm)/2, "PCM frames")
+	// Output: 8000 u-law bytes -> 15999 PCM frames
+}
+
+// Mix a prompt with a looping background track, both 8kHz u-law. The position in
+// the background track is kept across calls so the loop continues seamlessly.
+func ExampleMixUlaw8kHz() {
+	prompt := corpus.MustReadFile(corpus.Speech8kUlaw)
+	background := corpus.MustReadFile(corpus.Typing8kUlaw)
+
+	pos := -1
+	for chunk := 0; chunk < 3; chunk++ {
+		frame := prompt[chunk*160 : (chunk+1)*160] // 20ms frames
+		mixed, err := libsamplerate.MixUlaw8kHz(frame, background, &pos, 0.5)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(len(mixed), "mixed bytes")
+	}
+	// Output:
+	// 160 mixed bytes
+	// 160 mixed bytes
+	// 160 mixed bytes
+}
+
+// packetCounter stands in for a real codec such as Opus; see the Encoder
+// documentation for an adapter wrapping gopkg.in/hraban/opus.
+type packetCounter struct{ packets int }
+
+func (c *packetCounter) EncodeFrame(frame []float32) ([]byte, error) {
+	c.packets++
+	return make([]byte, len(frame)/8), nil
+}
+
+// Resample an 8kHz u-law leg to 16kHz and encode it in 20ms frames, as for an
+// Opus wideband stream.
+func ExamplePipeline() {
+	ulaw := corpus.MustReadFile(corpus.Speech8kUlaw) // 1 second of 8kHz u-law
+	samples := make([]float32, len(ulaw))
+	libsamplerate.UlawToFloatArray(ulaw, samples)
+
+	enc := &packetCounter{}
+	p, err := libsamplerate.NewPipeline(enc, libsamplerate.SincMediumQuality, 1, 8000, 16000, 320)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer p.Close()
+	for pos := 0; pos < len(samples); pos += 160 { // 20ms blocks from the network
+		packets, err := p.Process(samples[pos:pos+160], pos+160 == len(samples))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		_ = packets // Write the packets to the Ogg stream or RTP session
+	}
+	fmt.Println(enc.packets, "packets of", p.FrameFrames(), "frames")
+	// Output: 50 packets of 320 frames
+}
+
+// Upsample an 8kHz leg to 16kHz in 20ms blocks without doing the frame math by
+// hand.
+func ExampleNewSrcDataFromDuration() {
+	ulaw := corpus.MustReadFile(corpus.Speech8kUlaw) // 1 second of 8kHz u-law
+
+	data, err := libsamplerate.NewSrcDataFromDuration(8000, 16000, 1, 20*time.Millisecond)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	conv, err := libsamplerate.New(libsamplerate.SincMediumQuality, 1)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer conv.Close()
+	var out int64
+	for pos := 0; pos < len(ulaw); pos += int(data.InputFrames) {
+		libsamplerate.UlawToFloatArray(ulaw[pos:pos+int(data.InputFrames)], data.DataIn)
+		if err := conv.Process(data); err != nil {
+			fmt.Println(err)
+			return
+		}
+		out += data.OutputFramesGen // data.DataOut[:data.OutputFramesGen] go to the recognizer
+	}
+	// The rest of the 16000 frames stay in the filter until EndOfInput is set
+	fmt.Println(data.InputFrames, "frames in per block,", out, "frames out in total")
+	// Output: 160 frames in per block, 15906 frames out in total
+}
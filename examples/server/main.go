@@ -0,0 +1,261 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+// server is an HTTP transcoding server built on the streaming (callback) API.
+//
+// POST raw audio to /transcode and the resampled audio is streamed back in the
+// chunked response while the request body is still being uploaded:
+//
+//	curl -T speech.raw -H 'Transfer-Encoding: chunked' \
+//	  'http://localhost:8080/transcode?in_rate=24000&out_rate=8000&out_format=ulaw' > out.ulaw
+//
+// Query parameters:
+//
+//	in_rate, out_rate     sample rates in Hz (required)
+//	in_format, out_format s16le (default), s16be, s24le, s24be, s32le, s32be,
+//	                      f32le, f32be, f64le, f64be, u8 or ulaw
+//	channels              interleaved channel count (default 1)
+//	quality               converter type, 0 (best sinc) to 4 (linear), default 2
+//
+// Back-pressure: the converter pulls input from the request body only when it
+// needs it to produce output, and output is written synchronously, so a slow
+// client stalls the upload instead of growing server memory. When the body ends,
+// the converter is flushed and the remaining audio is sent before the response
+// closes.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+const (
+	readChunkFrames  = 1024 // Input frames read from the body per callback
+	writeChunkFrames = 1024 // Output frames produced per response write
+)
+
+// codec is the raw audio format of one side of the transcode.
+type codec struct {
+	name   string
+	format libsamplerate.SampleFormat
+	ulaw   bool
+}
+
+func (c codec) bytesPerSample() int {
+	if c.ulaw {
+		return 1
+	}
+	return c.format.BytesPerSample()
+}
+
+func (c codec) decode(in []byte, out []float32) error {
+	if c.ulaw {
+		libsamplerate.UlawToFloatArray(in, out)
+		return nil
+	}
+	_, err := libsamplerate.DecodePCM(c.format, in, out)
+	return err
+}
+
+func (c codec) encode(in []float32, out []byte) error {
+	if c.ulaw {
+		libsamplerate.FloatToUlawArray(in, out)
+		return nil
+	}
+	_, err := libsamplerate.EncodePCM(c.format, in, out)
+	return err
+}
+
+// parseCodec maps a query parameter value to a codec; empty means s16le.
+func parseCodec(name string) (codec, error) {
+	if name == "" {
+		name = libsamplerate.FormatS16LE.String()
+	}
+	if name == "ulaw" {
+		return codec{name: name, ulaw: true}, nil
+	}
+	for f := libsamplerate.FormatU8; f.IsValid(); f++ {
+		if f.String() == name {
+			return codec{name: name, format: f}, nil
+		}
+	}
+	return codec{}, fmt.Errorf("unknown format %q", name)
+}
+
+// transcodeParams holds the parsed query of a /transcode request.
+type transcodeParams struct {
+	in, out         codec
+	inRate, outRate float64
+	channels        int
+	quality         libsamplerate.ConverterType
+}
+
+func parseParams(r *http.Request) (transcodeParams, error) {
+	q := r.URL.Query()
+	var p transcodeParams
+	var err error
+	if p.in, err = parseCodec(q.Get("in_format")); err != nil {
+		return p, err
+	}
+	if p.out, err = parseCodec(q.Get("out_format")); err != nil {
+		return p, err
+	}
+	if p.inRate, err = strconv.ParseFloat(q.Get("in_rate"), 64); err != nil || p.inRate <= 0 {
+		return p, fmt.Errorf("invalid in_rate %q", q.Get("in_rate"))
+	}
+	if p.outRate, err = strconv.ParseFloat(q.Get("out_rate"), 64); err != nil || p.outRate <= 0 {
+		return p, fmt.Errorf("invalid out_rate %q", q.Get("out_rate"))
+	}
+	if !libsamplerate.IsValidRatio(p.outRate / p.inRate) {
+		return p, fmt.Errorf("unsupported conversion %g -> %g Hz", p.inRate, p.outRate)
+	}
+	p.channels = 1
+	if s := q.Get("channels"); s != "" {
+		if p.channels, err = strconv.Atoi(s); err != nil || p.channels <= 0 {
+			return p, fmt.Errorf("invalid channels %q", s)
+		}
+	}
+	p.quality = libsamplerate.SincFastest
+	if s := q.Get("quality"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || libsamplerate.GetName(libsamplerate.ConverterType(n)) == "" {
+			return p, fmt.Errorf("invalid quality %q", s)
+		}
+		p.quality = libsamplerate.ConverterType(n)
+	}
+	return p, nil
+}
+
+// bodySource feeds the converter from the request body, one chunk per callback.
+type bodySource struct {
+	body     io.Reader
+	in       codec
+	channels int
+	raw      []byte
+	pcm      []float32
+	err      error // First read/decode error, reported by the handler
+}
+
+func bodySourceCallback(userData interface{}) ([]float32, int64, error) {
+	s := userData.(*bodySource)
+	n, err := io.ReadFull(s.body, s.raw)
+	frameBytes := s.in.bytesPerSample() * s.channels
+	frames := n / frameBytes
+	if err == io.ErrUnexpectedEOF {
+		if n%frameBytes != 0 {
+			s.err = fmt.Errorf("input ends with a partial frame (%d bytes)", n%frameBytes)
+			return nil, 0, s.err
+		}
+		err = io.EOF // Short last chunk
+	}
+	if err != nil && err != io.EOF {
+		s.err = err
+		return nil, 0, err
+	}
+	samples := frames * s.channels
+	if decErr := s.in.decode(s.raw[:frames*frameBytes], s.pcm[:samples]); decErr != nil {
+		s.err = decErr
+		return nil, 0, decErr
+	}
+	return s.pcm[:samples], int64(frames), err
+}
+
+func transcodeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "use POST or PUT", http.StatusMethodNotAllowed)
+		return
+	}
+	p, err := parseParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	src := &bodySource{
+		body:     r.Body,
+		in:       p.in,
+		channels: p.channels,
+		raw:      make([]byte, readChunkFrames*p.channels*p.in.bytesPerSample()),
+		pcm:      make([]float32, readChunkFrames*p.channels),
+	}
+	conv, err := libsamplerate.CallbackNew(bodySourceCallback, p.quality, p.channels, src)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer conv.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("X-Sample-Rate", strconv.FormatFloat(p.outRate, 'f', -1, 64))
+	w.Header().Set("X-Sample-Format", p.out.name)
+	w.Header().Set("X-Channels", strconv.Itoa(p.channels))
+	// HTTP/1 closes the request body once the response starts unless full duplex
+	// is enabled; HTTP/2 is always full duplex.
+	rc := http.NewResponseController(w)
+	if err := rc.EnableFullDuplex(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("transcode: %v", err)
+	}
+
+	ratio := p.outRate / p.inRate
+	out := make([]float32, writeChunkFrames*p.channels)
+	encoded := make([]byte, len(out)*p.out.bytesPerSample())
+	for {
+		if err := r.Context().Err(); err != nil {
+			return // Client went away
+		}
+		n, err := libsamplerate.CallbackRead(conv, ratio, writeChunkFrames, out)
+		if err != nil {
+			if src.err != nil {
+				err = src.err
+			}
+			// Headers are usually sent already, so all we can do is cut the stream short
+			log.Printf("transcode: %v", err)
+			return
+		}
+		if n == 0 {
+			return // Input ended and the converter is drained
+		}
+		samples := int(n) * p.channels
+		if err := p.out.encode(out[:samples], encoded); err != nil {
+			log.Printf("transcode: %v", err)
+			return
+		}
+		if _, err := w.Write(encoded[:samples*p.out.bytesPerSample()]); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return
+		}
+	}
+}
+
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/transcode", transcodeHandler)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok", libsamplerate.Version())
+	})
+	return mux
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	flag.Parse()
+
+	log.Println("listening on", *addr)
+	if err := http.ListenAndServe(*addr, newMux()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
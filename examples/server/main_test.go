@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+)
+
+func TestTranscodeCorpus(t *testing.T) {
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		file      string
+		query     string
+		wantBytes int
+	}{
+		{"24k s16le to 8k ulaw", corpus.Speech24kS16LE, "in_rate=24000&out_rate=8000&out_format=ulaw", 8000},
+		{"8k ulaw to 16k s16le", corpus.Speech8kUlaw, "in_rate=8000&in_format=ulaw&out_rate=16000", 32000},
+		{"16k s16le to 48k f32le", corpus.Typing16kS16LE, "in_rate=16000&out_rate=48000&out_format=f32le&quality=4", 115200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(srv.URL+"/transcode?"+tt.query, "application/octet-stream",
+				bytes.NewReader(corpus.MustReadFile(tt.file)))
+			if err != nil {
+				t.Fatalf("POST failed: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status %s", resp.Status)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("reading response: %v", err)
+			}
+			if math.Abs(float64(len(body)-tt.wantBytes)) > float64(tt.wantBytes)/100 {
+				t.Errorf("got %d bytes, want about %d", len(body), tt.wantBytes)
+			}
+		})
+	}
+}
+
+// TestTranscodeStreams checks output arrives while the upload is still open.
+func TestTranscodeStreams(t *testing.T) {
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	pr, pw := io.Pipe()
+	respc := make(chan *http.Response, 1)
+	errc := make(chan error, 1)
+	go func() {
+		resp, err := http.Post(srv.URL+"/transcode?in_rate=16000&out_rate=8000", "application/octet-stream", pr)
+		if err != nil {
+			errc <- err
+			return
+		}
+		respc <- resp
+	}()
+
+	chunk := corpus.MustReadFile(corpus.Speech16kS16LE) // 1 s
+	if _, err := pw.Write(chunk); err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+
+	var resp *http.Response
+	select {
+	case resp = <-respc:
+	case err := <-errc:
+		t.Fatalf("POST failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no response while upload open")
+	}
+	defer resp.Body.Close()
+
+	// Most of the first second must come back before the upload is closed
+	got := make([]byte, 12000)
+	if _, err := io.ReadFull(resp.Body, got); err != nil {
+		t.Fatalf("reading streamed output: %v", err)
+	}
+
+	pw.Close() // End of input: the server flushes and closes the response
+	rest, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading tail: %v", err)
+	}
+	if total := len(got) + len(rest); math.Abs(float64(total-16000)) > 40 {
+		t.Errorf("got %d bytes in total, want about 16000", total)
+	}
+}
+
+func TestTranscodeBadRequests(t *testing.T) {
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	for _, query := range []string{
+		"out_rate=8000",
+		"in_rate=8000&out_rate=abc",
+		"in_rate=8000&out_rate=16000&in_format=mp3",
+		"in_rate=8000&out_rate=16000&channels=0",
+		"in_rate=8000&out_rate=16000&quality=9",
+		"in_rate=1&out_rate=48000",
+	} {
+		resp, err := http.Post(srv.URL+"/transcode?"+query, "application/octet-stream", bytes.NewReader(nil))
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", query, resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Get(srv.URL + "/transcode?in_rate=8000&out_rate=16000")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status %d, want 405", resp.StatusCode)
+	}
+}
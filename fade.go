@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "math"
+
+// fadeGain returns the raised-cosine gain of frame n of a length-frame fade-in,
+// rising from 0 at n == 0 towards 1. A fade-out uses it backwards.
+func fadeGain(n, length int64) float32 {
+	return float32(0.5 - 0.5*math.Cos(math.Pi*float64(n)/float64(length)))
+}
+
+// fadeIn applies Options.FadeFrames to frames interleaved output frames. pos
+// counts the output frames since the last reset, across calls.
+func fadeIn(out []float32, channels int, frames int64, pos *int64, length int) {
+	if length <= 0 || *pos >= int64(length) || frames <= 0 {
+		return
+	}
+	n := minInt64(frames, int64(length)-*pos)
+	for fr := int64(0); fr < n; fr++ {
+		g := fadeGain(*pos+fr, int64(length))
+		frame := out[fr*int64(channels):][:channels]
+		for ch := range frame {
+			frame[ch] *= g
+		}
+	}
+	*pos += frames
+}
+
+// fadeOut fades the last length frames of interleaved out to silence, or all of
+// it when it is shorter.
+func fadeOut(out []float32, channels, length int) {
+	frames := int64(len(out) / channels)
+	n := minInt64(frames, int64(length))
+	for k := int64(0); k < n; k++ {
+		g := fadeGain(n-1-k, n) // Reaches 0 on the last frame
+		frame := out[(frames-n+k)*int64(channels):][:channels]
+		for ch := range frame {
+			frame[ch] *= g
+		}
+	}
+}
+
+// Drain ends the stream of c: it processes the end of input (data with no input
+// and EndOfInput set, at ratio) and appends all the output the converter still
+// holds to *out, as ProcessAppend does. With Options.FadeFrames set, the last
+// FadeFrames frames of *out are then faded out to silence, so pass the slice
+// holding the end of the segment: the tail of a sinc converter is short, and
+// Linear and ZeroOrderHold have none. Reset the converter to start the next
+// segment, which fades in.
+func Drain(c Converter, ratio float64, out *[]float32) error {
+	if c == nil || out == nil {
+		return mapError(ErrBadData)
+	}
+	data := SrcData{SrcRatio: ratio, EndOfInput: true}
+	if err := ProcessAppend(c, &data, out); err != nil {
+		return err
+	}
+	if length := optionsOf(c).FadeFrames; length > 0 {
+		fadeOut(*out, outputChannelsOf(c), length)
+	}
+	return nil
+}
+
+// optionsOf returns the options c was created with.
+func optionsOf(c Converter) Options {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.options
+	case *channelGroups:
+		return conv.options
+	case *channelMapper:
+		return conv.options
+	}
+	return Options{}
+}
@@ -0,0 +1,160 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// checkFadeIn checks that out (DC at level v, passed through at ratio 1) fades in
+// over length frames.
+func checkFadeIn(t *testing.T, name string, out []float32, channels, length int, v float32) {
+	t.Helper()
+	for i, got := range out {
+		want := v
+		if fr := i / channels; fr < length {
+			want = v * fadeGain(int64(fr), int64(length))
+		}
+		if math.Abs(float64(got-want)) > 1e-6 {
+			t.Fatalf("%s: sample %d = %g, want %g", name, i, got, want)
+		}
+	}
+}
+
+func TestFadeGain(t *testing.T) {
+	if g := fadeGain(0, 32); g != 0 {
+		t.Errorf("fadeGain(0) = %g, want 0", g)
+	}
+	if g := fadeGain(16, 32); math.Abs(float64(g)-0.5) > 1e-7 {
+		t.Errorf("fadeGain(16 of 32) = %g, want 0.5", g)
+	}
+	for n := int64(1); n < 32; n++ {
+		if fadeGain(n, 32) <= fadeGain(n-1, 32) {
+			t.Fatalf("fadeGain not rising at %d", n)
+		}
+	}
+}
+
+func TestFadeInAfterReset(t *testing.T) {
+	const length = 50
+	conv, err := NewWithOptions(SincFastest, 2, Options{FadeFrames: length})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	in := constFrames(200, 2, 0.5)
+
+	// Ratio 1.0 is passed through, so the output is exactly the faded input; the
+	// fade spans several blocks
+	checkFadeIn(t, "new", stream(t, conv, in, 2, 2, 1, 17, 1024, false), 2, length, 0.5)
+	if err := conv.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	checkFadeIn(t, "after Reset", stream(t, conv, in, 2, 2, 1, 64, 1024, false), 2, length, 0.5)
+}
+
+func TestFadeGroupsAndMapper(t *testing.T) {
+	const length = 30
+	cases := []struct {
+		name                  string
+		channels, outChannels int
+		opts                  Options
+	}{
+		{"groups", 2 * maxChannels, 2 * maxChannels, Options{FadeFrames: length}},
+		{"upmix", 1, 2, Options{FadeFrames: length, OutputChannels: 2}},
+		{"downmix", 2, 1, Options{FadeFrames: length, OutputChannels: 1}},
+	}
+	for _, tc := range cases {
+		conv, err := NewWithOptions(SincFastest, tc.channels, tc.opts)
+		if err != nil {
+			t.Fatalf("%s: NewWithOptions failed: %v", tc.name, err)
+		}
+		in := constFrames(100, tc.channels, 0.25)
+		checkFadeIn(t, tc.name, stream(t, conv, in, tc.channels, tc.outChannels, 1, 13, 1024, false), tc.outChannels, length, 0.25)
+		if err := conv.Reset(); err != nil {
+			t.Fatalf("%s: Reset failed: %v", tc.name, err)
+		}
+		checkFadeIn(t, tc.name+" after Reset", stream(t, conv, in, tc.channels, tc.outChannels, 1, 40, 1024, false), tc.outChannels, length, 0.25)
+		conv.Close()
+	}
+}
+
+func TestDrainFadeOut(t *testing.T) {
+	const length = 40
+	for _, ct := range []ConverterType{SincMediumQuality, Linear, ZeroOrderHold} {
+		conv, err := NewWithOptions(ct, 1, Options{FadeFrames: length})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		out := stream(t, conv, constFrames(1000, 1, 0.5), 1, 1, 1.5, 100, 1024, false)
+		before := len(out)
+		if err := Drain(conv, 1.5, &out); err != nil {
+			t.Fatalf("%v: Drain failed: %v", ct, err)
+		}
+		conv.Close()
+
+		if ct == SincMediumQuality && len(out) <= before {
+			t.Errorf("%v: Drain appended nothing", ct)
+		}
+		if out[len(out)-1] != 0 {
+			t.Errorf("%v: last sample %g, want silence", ct, out[len(out)-1])
+		}
+		for i := len(out) - length + 1; i < len(out); i++ {
+			if math.Abs(float64(out[i])) > math.Abs(float64(out[i-1]))+1e-6 {
+				t.Fatalf("%v: fade-out rising at sample %d (%g after %g)", ct, i, out[i], out[i-1])
+			}
+		}
+		if v := out[len(out)-length-1]; math.Abs(float64(v)-0.5) > 0.01 {
+			t.Errorf("%v: sample before the fade-out = %g, want 0.5 untouched", ct, v)
+		}
+	}
+}
+
+// Segments spliced with Reset step from and to silence without a fade; with one
+// the largest step between neighbouring samples stays small.
+func TestFadeSplice(t *testing.T) {
+	maxStep := func(fade int) float64 {
+		conv, err := NewWithOptions(SincBestQuality, 1, Options{FadeFrames: fade})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		defer conv.Close()
+		var out []float32
+		for seg := 0; seg < 3; seg++ {
+			out = append(out, stream(t, conv, constFrames(2000, 1, 0.8), 1, 1, 0.5, 256, 1024, false)...)
+			if err := Drain(conv, 0.5, &out); err != nil {
+				t.Fatalf("Drain failed: %v", err)
+			}
+			if err := conv.Reset(); err != nil {
+				t.Fatalf("Reset failed: %v", err)
+			}
+		}
+		var step float64
+		for i := 1; i < len(out); i++ {
+			step = math.Max(step, math.Abs(float64(out[i]-out[i-1])))
+		}
+		return step
+	}
+	if step := maxStep(0); step < 0.1 {
+		t.Fatalf("unfaded splice steps at most %g, the test signal does not click", step)
+	}
+	if step := maxStep(80); step > 0.02 {
+		t.Errorf("faded splice steps by %g", step)
+	}
+}
+
+func TestFadeFramesValidation(t *testing.T) {
+	if _, err := NewWithOptions(Linear, 1, Options{FadeFrames: -1}); err == nil {
+		t.Error("negative FadeFrames accepted")
+	}
+	if err := Drain(nil, 1, new([]float32)); err == nil {
+		t.Error("Drain of a nil converter accepted")
+	}
+}
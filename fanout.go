@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+const fanoutScratchFrames = 4096 // Per-branch output scratch size, in frames
+
+// FanoutOutput describes one output branch of a Fanout.
+type FanoutOutput struct {
+	Rate          float64       // Output sample rate in Hz
+	ConverterType ConverterType // Converter used for this branch
+}
+
+// streamStage is a converter run block by block at a fixed ratio, with a reusable
+// scratch buffer and the output of the last call. Each Fanout branch is one.
+type streamStage struct {
+	conv        Converter
+	ratio       float64
+	outChannels int // Output channels when conv remaps them, else 0
+	scratch     []float32
+	out         []float32
+}
+
+// Fanout resamples one input stream to several output rates at once, e.g. a 48 kHz
+// master to 16 kHz for ASR and 8 kHz for SIP. The input is decoded once per call
+// and handed to every branch; each branch keeps its own converter state, so the
+// outputs are identical to running independent converters over the same data.
+//
+// NOTE: A Fanout is NOT goroutine-safe.
+type Fanout struct {
+	inputRate float64
+	channels  int
+	outputs   []FanoutOutput
+	branches  []*streamStage
+	decodeBuf []float32
+}
+
+// NewFanout creates a Fanout for interleaved input at inputRate with the given
+// channel count, producing one output per entry in outputs.
+func NewFanout(inputRate float64, channels int, outputs []FanoutOutput) (*Fanout, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if inputRate <= 0 || math.IsNaN(inputRate) || math.IsInf(inputRate, 0) {
+		return nil, fmt.Errorf("input rate must be positive, got %f", inputRate)
+	}
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("fanout needs at least one output")
+	}
+
+	f := &Fanout{
+		inputRate: inputRate,
+		channels:  channels,
+		outputs:   append([]FanoutOutput(nil), outputs...),
+	}
+	for i, o := range outputs {
+		ratio := o.Rate / inputRate
+		if isBadSrcRatio(ratio) {
+			f.Close()
+			return nil, fmt.Errorf("output %d: rate %f gives invalid ratio %f: %w", i, o.Rate, ratio, mapError(ErrBadSrcRatio))
+		}
+		conv, err := New(o.ConverterType, channels)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("output %d: failed to create converter: %w", i, err)
+		}
+		f.branches = append(f.branches, &streamStage{
+			conv:    conv,
+			ratio:   ratio,
+			scratch: make([]float32, fanoutScratchFrames*channels),
+		})
+	}
+	return f, nil
+}
+
+// Outputs returns the output branch descriptions, in the order results are returned.
+func (f *Fanout) Outputs() []FanoutOutput {
+	return append([]FanoutOutput(nil), f.outputs...)
+}
+
+// Process feeds interleaved input to every branch and returns one interleaved
+// output slice per branch, in the order given to NewFanout. Set endOfInput on the
+// last call to flush the converters.
+//
+// The returned slices are reused by the next call; copy them to keep them.
+func (f *Fanout) Process(in []float32, endOfInput bool) ([][]float32, error) {
+	if len(in)%f.channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), f.channels)
+	}
+	results := make([][]float32, len(f.branches))
+	for i, b := range f.branches {
+		if err := b.process(in, f.channels, endOfInput); err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		results[i] = b.out
+	}
+	return results, nil
+}
+
+// ProcessPCM is like Process but takes raw PCM in the given format. The input is
+// decoded once and shared by all branches.
+func (f *Fanout) ProcessPCM(format SampleFormat, in []byte, endOfInput bool) ([][]float32, error) {
+	bps := format.BytesPerSample()
+	if bps == 0 {
+		return nil, fmt.Errorf("unknown sample format %d", format)
+	}
+	samples := len(in) / bps
+	if cap(f.decodeBuf) < samples {
+		f.decodeBuf = make([]float32, samples)
+	}
+	f.decodeBuf = f.decodeBuf[:samples]
+	if _, err := DecodePCM(format, in, f.decodeBuf); err != nil {
+		return nil, err
+	}
+	return f.Process(f.decodeBuf, endOfInput)
+}
+
+// Reset resets every branch converter, e.g. to start a new stream.
+func (f *Fanout) Reset() error {
+	for i, b := range f.branches {
+		if err := b.conv.Reset(); err != nil {
+			return fmt.Errorf("output %d: %w", i, err)
+		}
+		b.out = b.out[:0]
+	}
+	return nil
+}
+
+// Close releases all branch converters.
+func (f *Fanout) Close() error {
+	var firstErr error
+	for _, b := range f.branches {
+		if err := b.conv.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// process runs one block through the converter, collecting all output in
+// b.out. The scratch buffer is reused, so no allocation happens once b.out has grown
+// to the steady-state block size.
+func (b *streamStage) process(in []float32, channels int, endOfInput bool) error {
+	b.out = b.out[:0]
+	outChannels := channels
+	if b.outChannels > 0 {
+		outChannels = b.outChannels
+	}
+	srcData := SrcData{
+		DataIn:      in,
+		InputFrames: int64(len(in) / channels),
+		SrcRatio:    b.ratio,
+		EndOfInput:  endOfInput,
+	}
+	scratchFrames := int64(len(b.scratch) / outChannels)
+
+	for {
+		srcData.DataOut = b.scratch
+		srcData.OutputFrames = scratchFrames
+		srcData.OutputFramesGen = 0
+		if err := b.conv.Process(&srcData); err != nil {
+			return fmt.Errorf("resampling process failed: %w", err)
+		}
+		b.out = append(b.out, b.scratch[:srcData.OutputFramesGen*int64(outChannels)]...)
+
+		srcData.DataIn = srcData.DataIn[srcData.InputFramesUsed*int64(channels):]
+		srcData.InputFrames -= srcData.InputFramesUsed
+		if srcData.OutputFramesGen == 0 && srcData.InputFramesUsed == 0 {
+			break // Drained, or waiting for more input
+		}
+		if !endOfInput && srcData.InputFrames == 0 && srcData.OutputFramesGen < scratchFrames {
+			break // All input consumed and the converter had room to spare
+		}
+		if len(srcData.DataIn) == 0 {
+			srcData.DataIn = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"testing"
+)
+
+// TestFanoutMatchesIndependentConverters checks each branch output equals a
+// stand-alone converter run over the whole input.
+func TestFanoutMatchesIndependentConverters(t *testing.T) {
+	const channels = 2
+	const inRate = 48000.0
+	outputs := []FanoutOutput{
+		{Rate: 16000, ConverterType: SincFastest},
+		{Rate: 8000, ConverterType: Linear},
+		{Rate: 44100, ConverterType: SincMediumQuality},
+	}
+
+	mono := make([]float32, 24000)
+	genWindowedSinesGo(3, []float64{0.01, 0.037, 0.08}, 0.9, mono)
+	in := make([]float32, len(mono)*channels)
+	for i, v := range mono {
+		in[i*channels] = v
+		in[i*channels+1] = v * 0.25
+	}
+
+	f, err := NewFanout(inRate, channels, outputs)
+	if err != nil {
+		t.Fatalf("NewFanout failed: %v", err)
+	}
+	defer f.Close()
+
+	got := make([][]float32, len(outputs))
+	const blockFrames = 480 // 10 ms blocks
+	for pos := 0; pos < len(in); pos += blockFrames * channels {
+		end := minInt(pos+blockFrames*channels, len(in))
+		res, err := f.Process(in[pos:end], end == len(in))
+		if err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		if len(res) != len(outputs) {
+			t.Fatalf("got %d outputs, want %d", len(res), len(outputs))
+		}
+		for i := range res {
+			got[i] = append(got[i], res[i]...)
+		}
+	}
+
+	for i, o := range outputs {
+		conv, err := New(o.ConverterType, channels)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		want, err := processAll(conv, in, channels, o.Rate/inRate)
+		conv.Close()
+		if err != nil {
+			t.Fatalf("processAll failed: %v", err)
+		}
+		if len(got[i]) != len(want) {
+			t.Errorf("output %d (%.0f Hz): %d samples, want %d", i, o.Rate, len(got[i]), len(want))
+			continue
+		}
+		for j := range want {
+			if got[i][j] != want[j] {
+				t.Errorf("output %d (%.0f Hz): sample %d is %g, want %g", i, o.Rate, j, got[i][j], want[j])
+				break
+			}
+		}
+	}
+}
+
+func TestFanoutProcessPCMAndErrors(t *testing.T) {
+	if _, err := NewFanout(48000, 1, nil); err == nil {
+		t.Error("expected error for no outputs")
+	}
+	if _, err := NewFanout(48000, 0, []FanoutOutput{{Rate: 8000}}); err == nil {
+		t.Error("expected error for zero channels")
+	}
+	if _, err := NewFanout(48000, 1, []FanoutOutput{{Rate: 48000 * 1000}}); err == nil {
+		t.Error("expected error for out of range ratio")
+	}
+
+	f, err := NewFanout(16000, 1, []FanoutOutput{{Rate: 8000, ConverterType: Linear}, {Rate: 32000, ConverterType: ZeroOrderHold}})
+	if err != nil {
+		t.Fatalf("NewFanout failed: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.ProcessPCM(FormatS16LE, make([]byte, 3), false); err == nil {
+		t.Error("expected error for odd byte count")
+	}
+	res, err := f.ProcessPCM(FormatS16LE, make([]byte, 3200), true)
+	if err != nil {
+		t.Fatalf("ProcessPCM failed: %v", err)
+	}
+	if n := len(res[0]); n < 790 || n > 810 {
+		t.Errorf("8 kHz branch produced %d frames, want about 800", n)
+	}
+	if n := len(res[1]); n < 3190 || n > 3210 {
+		t.Errorf("32 kHz branch produced %d frames, want about 3200", n)
+	}
+	if err := f.Reset(); err != nil {
+		t.Errorf("Reset failed: %v", err)
+	}
+}
+
+// TestFanoutWideStream checks a fanout takes more channels than one sinc state
+// holds, as New does.
+func TestFanoutWideStream(t *testing.T) {
+	const channels = 2 * maxChannels
+	in, _ := wideTestSignal(channels, 480)
+	f, err := NewFanout(48000, channels, []FanoutOutput{
+		{Rate: 16000, ConverterType: SincFastest},
+		{Rate: 8000, ConverterType: Linear},
+	})
+	if err != nil {
+		t.Fatalf("NewFanout failed: %v", err)
+	}
+	defer f.Close()
+	outs, err := f.Process(in, true)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	checkSameSamples(t, "16 kHz", outs[0], resampleWhole(t, SincFastest, in, channels, 16000.0/48000))
+	checkSameSamples(t, "8 kHz", outs[1], resampleWhole(t, Linear, in, channels, 8000.0/48000))
+}
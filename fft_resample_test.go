@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResampleFFTSine(t *testing.T) {
+	cases := []struct {
+		inRate, outRate int
+		freq            float64
+	}{
+		{44100, 48000, 1000},
+		{48000, 44100, 5000},
+		{8000, 44100, 3000},
+		{48000, 8000, 700},
+		{16000, 16000 * 3, 7000},
+	}
+	for _, tc := range cases {
+		const frames = 30000
+		in := genSine(frames, tc.freq, float64(tc.inRate), 0.5)
+		out, err := ResampleFFT(in, 1, tc.inRate, tc.outRate)
+		if err != nil {
+			t.Fatalf("%d to %d: ResampleFFT failed: %v", tc.inRate, tc.outRate, err)
+		}
+		if want := ExactOutputFrames(frames, tc.inRate, tc.outRate); int64(len(out)) != want {
+			t.Fatalf("%d to %d: got %d frames, want %d", tc.inRate, tc.outRate, len(out), want)
+		}
+
+		// Away from the edges the output is the sine sampled at the new rate
+		want := genSine(len(out), tc.freq, float64(tc.outRate), 0.5)
+		margin := len(out) / 10
+		var maxErr float64
+		for i := margin; i < len(out)-margin; i++ {
+			maxErr = math.Max(maxErr, math.Abs(float64(out[i]-want[i])))
+		}
+		if maxErr > 1e-4 {
+			t.Errorf("%d to %d: error %g against the ideal sine", tc.inRate, tc.outRate, maxErr)
+		}
+	}
+}
+
+func TestResampleFFTBandLimit(t *testing.T) {
+	// 6 kHz is above the 4 kHz Nyquist frequency of the output
+	in := genSine(48000, 6000, 48000, 0.5)
+	out, err := ResampleFFT(in, 1, 48000, 8000)
+	if err != nil {
+		t.Fatalf("ResampleFFT failed: %v", err)
+	}
+	if rms := rmsGo(out[800 : len(out)-800]); rms > 1e-3 {
+		t.Errorf("tone above the output band leaks through at RMS %g", rms)
+	}
+}
+
+func TestResampleFFTChannels(t *testing.T) {
+	left := genSine(10000, 440, 22050, 0.5)
+	right := genSine(10000, 2500, 22050, 0.25)
+	stereo := make([]float32, 0, 20000)
+	for i := range left {
+		stereo = append(stereo, left[i], right[i])
+	}
+	out, err := ResampleFFT(stereo, 2, 22050, 32000)
+	if err != nil {
+		t.Fatalf("ResampleFFT failed: %v", err)
+	}
+	wantL, _ := ResampleFFT(left, 1, 22050, 32000)
+	wantR, _ := ResampleFFT(right, 1, 22050, 32000)
+	if len(out) != 2*len(wantL) {
+		t.Fatalf("got %d samples, want %d", len(out), 2*len(wantL))
+	}
+	for i := range wantL {
+		if out[2*i] != wantL[i] || out[2*i+1] != wantR[i] {
+			t.Fatalf("frame %d: channels mixed up", i)
+		}
+	}
+}
+
+func TestResampleFFTEdgeCases(t *testing.T) {
+	if out, err := ResampleFFT(nil, 2, 44100, 48000); err != nil || len(out) != 0 {
+		t.Errorf("empty input: got %d samples, err %v", len(out), err)
+	}
+	short, err := ResampleFFT([]float32{0.5, 0.5, 0.5}, 1, 8000, 16000)
+	if err != nil || len(short) != 6 {
+		t.Errorf("3 frames: got %d samples, err %v", len(short), err)
+	}
+	in := []float32{0.1, 0.2, 0.3, 0.4}
+	same, err := ResampleFFT(in, 2, 48000, 48000)
+	if err != nil || len(same) != 4 || same[3] != 0.4 {
+		t.Errorf("equal rates: got %v, err %v", same, err)
+	}
+	same[0] = 9
+	if in[0] != 0.1 {
+		t.Error("equal rates returned the input slice")
+	}
+
+	bad := []struct {
+		channels, inRate, outRate int
+		in                        []float32
+	}{
+		{0, 8000, 16000, nil},
+		{2, 8000, 16000, make([]float32, 3)},
+		{1, 0, 16000, nil},
+		{1, 8000, 8000 * 300, nil},
+		{1, 8000, 8191, nil}, // 8191 is prime
+	}
+	for _, tc := range bad {
+		if _, err := ResampleFFT(tc.in, tc.channels, tc.inRate, tc.outRate); err == nil {
+			t.Errorf("ResampleFFT(%d channels, %d to %d) accepted", tc.channels, tc.inRate, tc.outRate)
+		}
+	}
+}
+
+func TestLargestPrimeFactor(t *testing.T) {
+	for n, want := range map[int]int{1: 1, 2: 2, 147: 7, 160: 5, 8191: 8191, 1001: 13} {
+		if got := largestPrimeFactor(n); got != want {
+			t.Errorf("largestPrimeFactor(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func BenchmarkResampleFFT(b *testing.B) {
+	in := genSine(10*44100, 1000, 44100, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ResampleFFT(in, 1, 44100, 48000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkResampleRateSincBest(b *testing.B) {
+	in := genSine(10*44100, 1000, 44100, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ResampleRate(in, 44100, 48000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
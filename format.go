@@ -0,0 +1,371 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// SampleFormat identifies a PCM sample layout in a byte stream.
+type SampleFormat int
+
+const (
+	FormatU8    SampleFormat = 0  // Unsigned 8-bit, 128 is silence
+	FormatS16LE SampleFormat = 1  // Signed 16-bit little-endian
+	FormatS16BE SampleFormat = 2  // Signed 16-bit big-endian
+	FormatS24LE SampleFormat = 3  // Signed 24-bit little-endian, packed (3 bytes per sample)
+	FormatS24BE SampleFormat = 4  // Signed 24-bit big-endian, packed (3 bytes per sample)
+	FormatS32LE SampleFormat = 5  // Signed 32-bit little-endian
+	FormatS32BE SampleFormat = 6  // Signed 32-bit big-endian
+	FormatF32LE SampleFormat = 7  // IEEE 754 32-bit float little-endian
+	FormatF32BE SampleFormat = 8  // IEEE 754 32-bit float big-endian
+	FormatF64LE SampleFormat = 9  // IEEE 754 64-bit float little-endian
+	FormatF64BE SampleFormat = 10 // IEEE 754 64-bit float big-endian
+)
+
+// Full scale values used to normalize integer formats to [-1.0, 1.0).
+const (
+	fullScaleS8  = 128.0
+	fullScaleS16 = 32768.0
+	fullScaleS24 = 8388608.0
+	fullScaleS32 = 2147483648.0
+)
+
+// BytesPerSample returns the size of one sample in bytes, or 0 for an unknown format.
+func (f SampleFormat) BytesPerSample() int {
+	switch f {
+	case FormatU8:
+		return 1
+	case FormatS16LE, FormatS16BE:
+		return 2
+	case FormatS24LE, FormatS24BE:
+		return 3
+	case FormatS32LE, FormatS32BE, FormatF32LE, FormatF32BE:
+		return 4
+	case FormatF64LE, FormatF64BE:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// String returns the conventional short name of the format (e.g. "s16le").
+func (f SampleFormat) String() string {
+	switch f {
+	case FormatU8:
+		return "u8"
+	case FormatS16LE:
+		return "s16le"
+	case FormatS16BE:
+		return "s16be"
+	case FormatS24LE:
+		return "s24le"
+	case FormatS24BE:
+		return "s24be"
+	case FormatS32LE:
+		return "s32le"
+	case FormatS32BE:
+		return "s32be"
+	case FormatF32LE:
+		return "f32le"
+	case FormatF32BE:
+		return "f32be"
+	case FormatF64LE:
+		return "f64le"
+	case FormatF64BE:
+		return "f64be"
+	default:
+		return fmt.Sprintf("SampleFormat(%d)", int(f))
+	}
+}
+
+// IsValid reports whether f is a known sample format.
+func (f SampleFormat) IsValid() bool {
+	return f.BytesPerSample() > 0
+}
+
+// IsBigEndian reports whether f stores multi-byte samples most significant
+// byte first, as AIFF files and network byte order RTP payloads do.
+func (f SampleFormat) IsBigEndian() bool {
+	return f.byteOrder() == binary.BigEndian
+}
+
+// SwappedByteOrder returns the format with the same sample type in the other
+// byte order (e.g. FormatS16BE for FormatS16LE). FormatU8 and unknown formats
+// are returned unchanged.
+func (f SampleFormat) SwappedByteOrder() SampleFormat {
+	switch f {
+	case FormatS16LE, FormatS24LE, FormatS32LE, FormatF32LE, FormatF64LE:
+		return f + 1
+	case FormatS16BE, FormatS24BE, FormatS32BE, FormatF32BE, FormatF64BE:
+		return f - 1
+	default:
+		return f
+	}
+}
+
+// SwapByteOrder reverses the bytes of every sample of data in place, turning a
+// stream in format into one in format.SwappedByteOrder(), e.g. S16BE RTP
+// payloads into S16LE for the mixers.
+func SwapByteOrder(format SampleFormat, data []byte) error {
+	bps := format.BytesPerSample()
+	if bps == 0 {
+		return fmt.Errorf("unknown sample format %d", format)
+	}
+	if len(data)%bps != 0 {
+		return fmt.Errorf("input size (%d) not multiple of sample size (%d) for %s", len(data), bps, format)
+	}
+	for i := 0; i < len(data); i += bps {
+		for a, b := i, i+bps-1; a < b; a, b = a+1, b-1 {
+			data[a], data[b] = data[b], data[a]
+		}
+	}
+	return nil
+}
+
+// byteOrder returns the byte order of a multi-byte format.
+func (f SampleFormat) byteOrder() binary.ByteOrder {
+	switch f {
+	case FormatS16BE, FormatS24BE, FormatS32BE, FormatF32BE, FormatF64BE:
+		return binary.BigEndian
+	default:
+		return binary.LittleEndian
+	}
+}
+
+// DecodePCM converts raw PCM bytes in the given format to float32 samples in [-1.0, 1.0).
+// It decodes min(len(in)/BytesPerSample, len(out)) samples and returns that count.
+// An error is returned for unknown formats or if len(in) is not a multiple of the sample size.
+func DecodePCM(format SampleFormat, in []byte, out []float32) (int, error) {
+	bps := format.BytesPerSample()
+	if bps == 0 {
+		return 0, fmt.Errorf("unknown sample format %d", format)
+	}
+	if len(in)%bps != 0 {
+		return 0, fmt.Errorf("input size (%d) not multiple of sample size (%d) for %s", len(in), bps, format)
+	}
+	count := minInt(len(in)/bps, len(out))
+	order := format.byteOrder()
+
+	switch format {
+	case FormatU8:
+		for i := 0; i < count; i++ {
+			out[i] = float32((float64(in[i]) - fullScaleS8) / fullScaleS8)
+		}
+	case FormatS16LE, FormatS16BE:
+		for i := 0; i < count; i++ {
+			out[i] = float32(float64(int16(order.Uint16(in[i*2:]))) / fullScaleS16)
+		}
+	case FormatS24LE:
+		for i := 0; i < count; i++ {
+			b := in[i*3:]
+			out[i] = float32(float64(signExtend24(uint32(b[0])|uint32(b[1])<<8|uint32(b[2])<<16)) / fullScaleS24)
+		}
+	case FormatS24BE:
+		for i := 0; i < count; i++ {
+			b := in[i*3:]
+			out[i] = float32(float64(signExtend24(uint32(b[2])|uint32(b[1])<<8|uint32(b[0])<<16)) / fullScaleS24)
+		}
+	case FormatS32LE, FormatS32BE:
+		for i := 0; i < count; i++ {
+			out[i] = float32(float64(int32(order.Uint32(in[i*4:]))) / fullScaleS32)
+		}
+	case FormatF32LE, FormatF32BE:
+		for i := 0; i < count; i++ {
+			out[i] = math.Float32frombits(order.Uint32(in[i*4:]))
+		}
+	case FormatF64LE, FormatF64BE:
+		for i := 0; i < count; i++ {
+			out[i] = float32(math.Float64frombits(order.Uint64(in[i*8:])))
+		}
+	}
+	return count, nil
+}
+
+// EncodePCM converts float32 samples to raw PCM bytes in the given format.
+// Integer formats are rounded and clipped to their range; float formats are written as is.
+// It encodes min(len(in), len(out)/BytesPerSample) samples and returns that count.
+func EncodePCM(format SampleFormat, in []float32, out []byte) (int, error) {
+	return EncodePCMWithRounding(format, in, out, RoundHalfAwayFromZero)
+}
+
+// EncodePCMWithRounding is EncodePCM rounding integer formats with the given mode.
+func EncodePCMWithRounding(format SampleFormat, in []float32, out []byte, mode RoundingMode) (int, error) {
+	bps := format.BytesPerSample()
+	if bps == 0 {
+		return 0, fmt.Errorf("unknown sample format %d", format)
+	}
+	count := minInt(len(in), len(out)/bps)
+	order := format.byteOrder()
+
+	switch format {
+	case FormatU8:
+		for i := 0; i < count; i++ {
+			out[i] = byte(quantize(in[i], fullScaleS8, mode) + 128)
+		}
+	case FormatS16LE, FormatS16BE:
+		for i := 0; i < count; i++ {
+			order.PutUint16(out[i*2:], uint16(int16(quantize(in[i], fullScaleS16, mode))))
+		}
+	case FormatS24LE:
+		for i := 0; i < count; i++ {
+			v := uint32(quantize(in[i], fullScaleS24, mode))
+			out[i*3], out[i*3+1], out[i*3+2] = byte(v), byte(v>>8), byte(v>>16)
+		}
+	case FormatS24BE:
+		for i := 0; i < count; i++ {
+			v := uint32(quantize(in[i], fullScaleS24, mode))
+			out[i*3], out[i*3+1], out[i*3+2] = byte(v>>16), byte(v>>8), byte(v)
+		}
+	case FormatS32LE, FormatS32BE:
+		for i := 0; i < count; i++ {
+			order.PutUint32(out[i*4:], uint32(int32(quantize(in[i], fullScaleS32, mode))))
+		}
+	case FormatF32LE, FormatF32BE:
+		for i := 0; i < count; i++ {
+			order.PutUint32(out[i*4:], math.Float32bits(in[i]))
+		}
+	case FormatF64LE, FormatF64BE:
+		for i := 0; i < count; i++ {
+			order.PutUint64(out[i*8:], math.Float64bits(float64(in[i])))
+		}
+	}
+	return count, nil
+}
+
+// ResampleFormat resamples an interleaved PCM byte stream, decoding it from inFormat
+// and encoding the result to outFormat (which may differ, e.g. s24le in, s16le out).
+// All input is processed at once and the converter is flushed at the end.
+//
+// Args:
+//
+//	in: Interleaved PCM bytes in inFormat.
+//	inFormat, outFormat: Sample layouts of the input and output.
+//	channels: Number of interleaved channels.
+//	srcRatio: Output rate / input rate (e.g. 16000.0/24000.0).
+//	converterType: Converter to use (e.g. SincBestQuality).
+//
+// Returns:
+//
+//	The resampled PCM bytes in outFormat, or nil and an error.
+func ResampleFormat(in []byte, inFormat, outFormat SampleFormat, channels int, srcRatio float64, converterType ConverterType) ([]byte, error) {
+	if !inFormat.IsValid() {
+		return nil, fmt.Errorf("unknown input sample format %d", inFormat)
+	}
+	if !outFormat.IsValid() {
+		return nil, fmt.Errorf("unknown output sample format %d", outFormat)
+	}
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	frameSize := BytesPerFrame(inFormat, channels)
+	if len(in)%frameSize != 0 {
+		return nil, fmt.Errorf("input stream size (%d) not multiple of frame size (%d)", len(in), frameSize)
+	}
+	if len(in) == 0 {
+		return []byte{}, nil
+	}
+
+	inputFloatBuffer := make([]float32, len(in)/inFormat.BytesPerSample())
+	if _, err := DecodePCM(inFormat, in, inputFloatBuffer); err != nil {
+		return nil, err
+	}
+
+	state, err := New(converterType, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	defer state.Close()
+
+	outputFloatBuffer, err := processAll(state, inputFloatBuffer, channels, srcRatio)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(outputFloatBuffer)*outFormat.BytesPerSample())
+	if _, err := EncodePCM(outFormat, outputFloatBuffer, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// processAll runs a whole interleaved buffer through the converter with EndOfInput
+// set and keeps calling Process until the converter is drained.
+// It returns the complete interleaved output.
+func processAll(state Converter, in []float32, channels int, srcRatio float64) ([]float32, error) {
+	return processAllWith(state, in, channels, srcRatio, currentProgressFunc())
+}
+
+// processAllWith is processAll reporting to progress, which may be nil.
+func processAllWith(state Converter, in []float32, channels int, srcRatio float64, progress ProgressFunc) ([]float32, error) {
+	inputFrames := int64(len(in) / channels)
+	estimatedOutputFrames := int64(math.Ceil(float64(inputFrames)*srcRatio)) + 20
+	outputFloatBuffer := make([]float32, estimatedOutputFrames*int64(channels))
+	result := make([]float32, 0, len(outputFloatBuffer))
+
+	srcData := SrcData{
+		DataIn:       in,
+		InputFrames:  inputFrames,
+		DataOut:      outputFloatBuffer,
+		OutputFrames: estimatedOutputFrames,
+		SrcRatio:     srcRatio,
+		EndOfInput:   true, // Process all input at once
+	}
+
+	// With a progress hook the input is fed in blocks, see SetProgressFunc
+	remaining := inputFrames
+	for {
+		srcData.InputFrames = remaining
+		if progress != nil && remaining > progressChunkFrames {
+			srcData.InputFrames, srcData.EndOfInput = progressChunkFrames, false
+		} else {
+			srcData.EndOfInput = true
+		}
+		if err := state.Process(&srcData); err != nil {
+			return nil, fmt.Errorf("resampling process failed: %w", err)
+		}
+		result = append(result, outputFloatBuffer[:srcData.OutputFramesGen*int64(channels)]...)
+
+		// Advance past consumed input, keep flushing until nothing more comes out
+		srcData.DataIn = srcData.DataIn[srcData.InputFramesUsed*int64(channels):]
+		remaining -= srcData.InputFramesUsed
+		if progress != nil && srcData.InputFramesUsed > 0 {
+			progress(inputFrames-remaining, inputFrames)
+		}
+		if srcData.OutputFramesGen == 0 && srcData.InputFramesUsed == 0 {
+			break // Drained (or stalled, which would otherwise loop forever)
+		}
+		if len(srcData.DataIn) == 0 {
+			srcData.DataIn = nil
+		}
+	}
+	return result, nil
+}
+
+// quantize scales a float sample to an integer range, rounds it with mode and clips it.
+func quantize(x float32, fullScale float64, mode RoundingMode) int64 {
+	v := float64(x) * fullScale
+	if v >= fullScale-1 {
+		return int64(fullScale - 1)
+	}
+	if v <= -fullScale {
+		return int64(-fullScale)
+	}
+	if math.IsNaN(v) {
+		return 0
+	}
+	return int64(mode.Round(v))
+}
+
+// signExtend24 sign extends a 24-bit two's complement value stored in the low bits of v.
+func signExtend24(v uint32) int32 {
+	return int32(v<<8) >> 8
+}
@@ -0,0 +1,222 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+var allSampleFormats = []SampleFormat{
+	FormatU8, FormatS16LE, FormatS16BE, FormatS24LE, FormatS24BE,
+	FormatS32LE, FormatS32BE, FormatF32LE, FormatF32BE, FormatF64LE, FormatF64BE,
+}
+
+// TestFormatRoundTrip checks encode->decode for every format is within one quantization step.
+func TestFormatRoundTrip(t *testing.T) {
+	in := []float32{0, 0.5, -0.5, 0.25, -0.999, 0.123456, -0.654321, 0.9999}
+	for _, f := range allSampleFormats {
+		t.Run(f.String(), func(t *testing.T) {
+			raw := make([]byte, len(in)*f.BytesPerSample())
+			n, err := EncodePCM(f, in, raw)
+			if err != nil || n != len(in) {
+				t.Fatalf("EncodePCM: n=%d err=%v", n, err)
+			}
+			out := make([]float32, len(in))
+			n, err = DecodePCM(f, raw, out)
+			if err != nil || n != len(in) {
+				t.Fatalf("DecodePCM: n=%d err=%v", n, err)
+			}
+
+			tolerance := 1e-7
+			switch f {
+			case FormatU8:
+				tolerance = 1.0 / fullScaleS8
+			case FormatS16LE, FormatS16BE:
+				tolerance = 1.0 / fullScaleS16
+			case FormatS24LE, FormatS24BE:
+				tolerance = 1.0 / fullScaleS24
+			}
+			for i := range in {
+				if math.Abs(float64(in[i]-out[i])) > tolerance {
+					t.Errorf("sample %d: in %.7f out %.7f (tolerance %g)", i, in[i], out[i], tolerance)
+				}
+			}
+		})
+	}
+}
+
+// TestFormatLayouts checks the exact byte layout of a few known values.
+func TestFormatLayouts(t *testing.T) {
+	tests := []struct {
+		format SampleFormat
+		value  float32
+		want   []byte
+	}{
+		{FormatU8, 0, []byte{0x80}},
+		{FormatU8, -1, []byte{0x00}},
+		{FormatU8, 1, []byte{0xFF}},
+		{FormatS16LE, 0.5, []byte{0x00, 0x40}},
+		{FormatS16BE, 0.5, []byte{0x40, 0x00}},
+		{FormatS16LE, -1, []byte{0x00, 0x80}},
+		{FormatS16LE, 2, []byte{0xFF, 0x7F}}, // Clipped
+		{FormatS24LE, 0.5, []byte{0x00, 0x00, 0x40}},
+		{FormatS24BE, 0.5, []byte{0x40, 0x00, 0x00}},
+		{FormatS24LE, -1.0 / fullScaleS24, []byte{0xFF, 0xFF, 0xFF}},
+		{FormatS32LE, -1, []byte{0x00, 0x00, 0x00, 0x80}},
+		{FormatS32BE, 0.5, []byte{0x40, 0x00, 0x00, 0x00}},
+		{FormatF32BE, 1, []byte{0x3F, 0x80, 0x00, 0x00}},
+		{FormatF64LE, 1, []byte{0, 0, 0, 0, 0, 0, 0xF0, 0x3F}},
+	}
+	for _, tt := range tests {
+		got := make([]byte, tt.format.BytesPerSample())
+		if _, err := EncodePCM(tt.format, []float32{tt.value}, got); err != nil {
+			t.Fatalf("%s: EncodePCM failed: %v", tt.format, err)
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("%s(%g): got % X, want % X", tt.format, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	bad := SampleFormat(99)
+	if bad.IsValid() || bad.BytesPerSample() != 0 {
+		t.Errorf("format 99 reported as valid")
+	}
+	if bad.String() != "SampleFormat(99)" {
+		t.Errorf("unexpected String(): %s", bad.String())
+	}
+	if _, err := DecodePCM(bad, []byte{0}, make([]float32, 1)); err == nil {
+		t.Error("expected error decoding unknown format")
+	}
+	if _, err := EncodePCM(bad, []float32{0}, make([]byte, 1)); err == nil {
+		t.Error("expected error encoding unknown format")
+	}
+	if _, err := DecodePCM(FormatS24LE, []byte{0, 0, 0, 0}, make([]float32, 2)); err == nil {
+		t.Error("expected error for partial s24le sample")
+	}
+	if _, err := ResampleFormat([]byte{0, 0, 0}, FormatS16LE, FormatS16LE, 1, 0.5, Linear); err == nil {
+		t.Error("expected error for partial frame")
+	}
+	if _, err := ResampleFormat(nil, FormatS16LE, bad, 1, 0.5, Linear); err == nil {
+		t.Error("expected error for unknown output format")
+	}
+}
+
+func TestSwapByteOrder(t *testing.T) {
+	samples := []float32{0.5, -0.25, 0.125, -1}
+	for _, f := range []SampleFormat{FormatS16LE, FormatS24BE, FormatS32LE, FormatF32BE, FormatF64LE} {
+		swapped := f.SwappedByteOrder()
+		if swapped.IsBigEndian() == f.IsBigEndian() || swapped.SwappedByteOrder() != f {
+			t.Errorf("%v: swapped format %v", f, swapped)
+		}
+		data := make([]byte, len(samples)*f.BytesPerSample())
+		EncodePCM(f, samples, data)
+		if err := SwapByteOrder(f, data); err != nil {
+			t.Fatalf("%v: SwapByteOrder failed: %v", f, err)
+		}
+		got := make([]float32, len(samples))
+		DecodePCM(swapped, data, got)
+		for i := range samples {
+			if got[i] != samples[i] {
+				t.Errorf("%v: sample %d is %g as %v, want %g", f, i, got[i], swapped, samples[i])
+			}
+		}
+	}
+	if FormatU8.SwappedByteOrder() != FormatU8 || FormatU8.IsBigEndian() {
+		t.Error("u8 has no byte order")
+	}
+	if err := SwapByteOrder(FormatS16BE, []byte{1, 2, 3}); err == nil {
+		t.Error("expected error for a partial sample")
+	}
+	if err := SwapByteOrder(SampleFormat(99), nil); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+}
+
+// TestBigEndianHelpers checks the S16BE variants of the u-law converters and the
+// looping source match the little-endian ones on byte-swapped data.
+func TestBigEndianHelpers(t *testing.T) {
+	le := s16leTone(2400, 440, 24000, 0.5)
+	be := append([]byte(nil), le...)
+	SwapByteOrder(FormatS16LE, be)
+
+	want, _ := ConvertPCMToUlaw(le, 24000, SincFastest, UlawOptions{})
+	got, err := ConvertPCMToUlaw(be, 24000, SincFastest, UlawOptions{BigEndian: true})
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("ConvertPCMToUlaw of S16BE differs from S16LE (err %v)", err)
+	}
+
+	pcmLE, _ := ConvertUlawToPCM(want, SincFastest)
+	pcmBE, err := ConvertUlawToPCMWithOptions(want, SincFastest, PCMOptions{BigEndian: true})
+	if err != nil {
+		t.Fatalf("ConvertUlawToPCMWithOptions failed: %v", err)
+	}
+	SwapByteOrder(FormatS16BE, pcmBE)
+	if !bytes.Equal(pcmBE, pcmLE) {
+		t.Error("ConvertUlawToPCMWithOptions(BigEndian) is not the byte-swapped S16LE output")
+	}
+
+	resampledLE, _ := Resample24kHzTo16kHz(le)
+	resampledBE, err := Resample24kHzTo16kHzWithOptions(be, PCMOptions{BigEndian: true})
+	if err != nil {
+		t.Fatalf("Resample24kHzTo16kHzWithOptions failed: %v", err)
+	}
+	SwapByteOrder(FormatS16BE, resampledBE)
+	if !bytes.Equal(resampledBE, resampledLE) {
+		t.Error("Resample24kHzTo16kHzWithOptions(BigEndian) is not the byte-swapped S16LE output")
+	}
+
+	srcLE, _ := NewLoopingSourceS16LE(le)
+	srcBE, err := NewLoopingSourceS16BE(be)
+	if err != nil {
+		t.Fatalf("NewLoopingSourceS16BE failed: %v", err)
+	}
+	a, b := make([]float32, 100), make([]float32, 100)
+	srcLE.read(a, 1)
+	srcBE.read(b, 1)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("S16BE source sample %d is %g, want %g", i, b[i], a[i])
+		}
+	}
+}
+
+// TestResampleFormat24Bit resamples a 24-bit 24kHz sine to 16-bit 16kHz and checks
+// it matches resampling the same float data directly.
+func TestResampleFormat24Bit(t *testing.T) {
+	const frames = 4800
+	sine := genSine(frames, 440, 24000, 0.5)
+	raw := make([]byte, frames*3)
+	if _, err := EncodePCM(FormatS24LE, sine, raw); err != nil {
+		t.Fatalf("EncodePCM failed: %v", err)
+	}
+
+	out, err := ResampleFormat(raw, FormatS24LE, FormatS16LE, 1, 16000.0/24000.0, SincFastest)
+	if err != nil {
+		t.Fatalf("ResampleFormat failed: %v", err)
+	}
+	gotFrames := len(out) / 2
+	if math.Abs(float64(gotFrames)-frames*2.0/3.0) > 2 {
+		t.Errorf("output frames %d, want about %d", gotFrames, frames*2/3)
+	}
+
+	// Peak of the resampled sine should still be around 0.5
+	peak := 0.0
+	for i := 0; i < gotFrames; i++ {
+		v := math.Abs(float64(int16(binary.LittleEndian.Uint16(out[i*2:]))) / fullScaleS16)
+		peak = math.Max(peak, v)
+	}
+	if math.Abs(peak-0.5) > 0.01 {
+		t.Errorf("peak %.4f, want about 0.5", peak)
+	}
+}
@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// A frame is one sample per channel, so an interleaved PCM byte stream holds
+// BytesPerFrame bytes per frame and a float32 buffer channels samples per frame.
+// Converter calls (SrcData.InputFrames, OutputFrames) count frames, while slices
+// count bytes or samples; these helpers convert between them.
+
+// BytesPerFrame returns the size in bytes of one interleaved frame of format
+// with the given channel count, e.g. 4 for stereo FormatS16LE. It returns 0 for
+// an unknown format or a channel count below 1.
+func BytesPerFrame(format SampleFormat, channels int) int {
+	if channels <= 0 {
+		return 0
+	}
+	return format.BytesPerSample() * channels
+}
+
+// FramesToBytes returns the size in bytes of frames interleaved frames of format.
+func FramesToBytes(format SampleFormat, channels, frames int) int {
+	return frames * BytesPerFrame(format, channels)
+}
+
+// BytesToFrames returns the number of interleaved frames of format in n bytes.
+// It fails if n is not a whole number of frames, or the format or channel count
+// is invalid.
+func BytesToFrames(format SampleFormat, channels, n int) (int, error) {
+	if !format.IsValid() {
+		return 0, fmt.Errorf("unknown sample format %d", format)
+	}
+	if channels <= 0 {
+		return 0, mapError(ErrBadChannelCount)
+	}
+	frameBytes := BytesPerFrame(format, channels)
+	if n < 0 || n%frameBytes != 0 {
+		return 0, fmt.Errorf("size (%d) not multiple of frame size (%d)", n, frameBytes)
+	}
+	return n / frameBytes, nil
+}
+
+// DurationToFrames returns the number of frames d lasts at rate Hz, rounded to
+// the nearest frame, e.g. 160 for 20ms at 8kHz. Whole seconds are counted
+// exactly for integer rates, so long durations do not drift. Negative
+// durations and invalid rates give 0.
+func DurationToFrames(rate float64, d time.Duration) int64 {
+	if d <= 0 || !(rate > 0) || math.IsInf(rate, 1) {
+		return 0
+	}
+	secs := int64(d / time.Second)
+	frac := float64(d%time.Second) / float64(time.Second)
+	return int64(math.Round(float64(secs)*rate + frac*rate))
+}
+
+// FramesToDuration returns how long frames frames last at rate Hz, rounded to
+// the nearest nanosecond. Invalid rates give 0.
+func FramesToDuration(rate float64, frames int64) time.Duration {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return 0
+	}
+	secs := float64(frames) / rate
+	whole := math.Trunc(secs)
+	return time.Duration(whole)*time.Second + time.Duration(math.Round((secs-whole)*float64(time.Second)))
+}
+
+// srcDataOutputSlack is the output frames NewSrcDataFromDuration adds to a
+// block's nominal output, for the fraction of a frame the converter carries
+// from block to block and for a ratio changed by a little, e.g. to follow a
+// drifting clock.
+const srcDataOutputSlack = 8
+
+// NewSrcDataFromDuration returns an SrcData for converting blocks lasting d
+// from inRate to outRate Hz, e.g. 20ms telephony frames: SrcRatio is
+// outRate/inRate, DataIn holds InputFrames = DurationToFrames(inRate, d)
+// frames and DataOut OutputFrames, enough for one block's output with some
+// slack. Fill DataIn and call Process for each block; when a block is not full,
+// lower InputFrames.
+//
+// Args:
+//
+//	inRate: Input sample rate in Hz.
+//	outRate: Output sample rate in Hz.
+//	channels: Number of interleaved channels, sizing DataIn and DataOut.
+//	d: Duration of one input block.
+//
+// Returns:
+//
+//	The SrcData, or nil and an error for invalid rates, channels or a block
+//	shorter than one input frame.
+func NewSrcDataFromDuration(inRate, outRate int, channels int, d time.Duration) (*SrcData, error) {
+	ratio, err := RateRatio(inRate, outRate)
+	if err != nil {
+		return nil, err
+	}
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	in := DurationToFrames(float64(inRate), d)
+	if in <= 0 {
+		return nil, fmt.Errorf("block of %v is shorter than one frame at %d Hz", d, inRate)
+	}
+	out := ExactOutputFrames(in, inRate, outRate) + srcDataOutputSlack
+	return &SrcData{
+		DataIn:       make([]float32, in*int64(channels)),
+		DataOut:      make([]float32, out*int64(channels)),
+		InputFrames:  in,
+		OutputFrames: out,
+		SrcRatio:     ratio,
+	}, nil
+}
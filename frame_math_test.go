@@ -0,0 +1,158 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBytesPerFrame(t *testing.T) {
+	tests := []struct {
+		format   SampleFormat
+		channels int
+		want     int
+	}{
+		{FormatS16LE, 1, 2},
+		{FormatS16LE, 2, 4},
+		{FormatS24BE, 2, 6},
+		{FormatF32LE, 6, 24},
+		{FormatU8, 3, 3},
+		{FormatS16LE, 0, 0},
+		{SampleFormat(42), 2, 0},
+	}
+	for _, tt := range tests {
+		if got := BytesPerFrame(tt.format, tt.channels); got != tt.want {
+			t.Errorf("BytesPerFrame(%v, %d) = %d, want %d", tt.format, tt.channels, got, tt.want)
+		}
+	}
+	if got := FramesToBytes(FormatS16LE, 2, 160); got != 640 {
+		t.Errorf("FramesToBytes(s16le, 2, 160) = %d, want 640", got)
+	}
+}
+
+func TestBytesToFrames(t *testing.T) {
+	if n, err := BytesToFrames(FormatS16LE, 2, 640); err != nil || n != 160 {
+		t.Errorf("BytesToFrames(s16le, 2, 640) = %d, %v, want 160", n, err)
+	}
+	if n, err := BytesToFrames(FormatS24LE, 1, 0); err != nil || n != 0 {
+		t.Errorf("BytesToFrames(s24le, 1, 0) = %d, %v, want 0", n, err)
+	}
+	// 6 bytes are 3 mono but 1.5 stereo S16 frames
+	if _, err := BytesToFrames(FormatS16LE, 2, 6); err == nil {
+		t.Error("partial stereo frame accepted")
+	}
+	if _, err := BytesToFrames(FormatS16LE, 0, 4); err == nil {
+		t.Error("0 channels accepted")
+	}
+	if _, err := BytesToFrames(SampleFormat(42), 1, 4); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
+
+func TestDurationToFrames(t *testing.T) {
+	tests := []struct {
+		rate float64
+		d    time.Duration
+		want int64
+	}{
+		{8000, 20 * time.Millisecond, 160},
+		{44100, 10 * time.Millisecond, 441},
+		{48000, time.Millisecond / 3, 16},
+		{11025, 1000 * time.Hour, 11025 * 3600 * 1000},
+		{8000, -time.Second, 0},
+		{0, time.Second, 0},
+	}
+	for _, tt := range tests {
+		if got := DurationToFrames(tt.rate, tt.d); got != tt.want {
+			t.Errorf("DurationToFrames(%g, %v) = %d, want %d", tt.rate, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFramesToDuration(t *testing.T) {
+	tests := []struct {
+		rate   float64
+		frames int64
+		want   time.Duration
+	}{
+		{8000, 160, 20 * time.Millisecond},
+		{44100, 441, 10 * time.Millisecond},
+		{48000, 1, 20833 * time.Nanosecond},
+		{8000, 8000 * 3600 * 1000, 1000 * time.Hour},
+		{0, 100, 0},
+	}
+	for _, tt := range tests {
+		if got := FramesToDuration(tt.rate, tt.frames); got != tt.want {
+			t.Errorf("FramesToDuration(%g, %d) = %v, want %v", tt.rate, tt.frames, got, tt.want)
+		}
+	}
+	for _, frames := range []int64{1, 159, 44099, 1 << 30} {
+		if back := DurationToFrames(44100, FramesToDuration(44100, frames)); back != frames {
+			t.Errorf("%d frames round trip to %d", frames, back)
+		}
+	}
+}
+
+// TestNewSrcDataFromDuration streams blocks through the SrcData it returns and
+// checks every block's output fits.
+func TestNewSrcDataFromDuration(t *testing.T) {
+	tests := []struct {
+		inRate, outRate, channels int
+		d                         time.Duration
+		inFrames, outFrames       int64
+		ratio                     float64
+	}{
+		{8000, 16000, 1, 20 * time.Millisecond, 160, 328, 2},
+		{44100, 48000, 2, 10 * time.Millisecond, 441, 488, 160.0 / 147.0},
+		{48000, 8000, 1, 20 * time.Millisecond, 960, 168, 1.0 / 6.0},
+	}
+	for _, tt := range tests {
+		data, err := NewSrcDataFromDuration(tt.inRate, tt.outRate, tt.channels, tt.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.InputFrames != tt.inFrames || data.OutputFrames != tt.outFrames || data.SrcRatio != tt.ratio ||
+			len(data.DataIn) != int(tt.inFrames)*tt.channels || len(data.DataOut) != int(tt.outFrames)*tt.channels {
+			t.Errorf("%d to %d Hz, %v: got %d/%d frames (%d/%d samples) at ratio %g", tt.inRate, tt.outRate, tt.d,
+				data.InputFrames, data.OutputFrames, len(data.DataIn), len(data.DataOut), data.SrcRatio)
+		}
+
+		conv, err := New(SincBestQuality, tt.channels)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for b := 0; b < 100; b++ {
+			for i := range data.DataIn {
+				data.DataIn[i] = float32(math.Sin(float64(b*len(data.DataIn)+i) * 0.01))
+			}
+			if err := conv.Process(data); err != nil {
+				t.Fatal(err)
+			}
+			if data.InputFramesUsed != data.InputFrames {
+				t.Fatalf("%d to %d Hz: block %d used %d of %d frames", tt.inRate, tt.outRate, b, data.InputFramesUsed, data.InputFrames)
+			}
+		}
+		conv.Close()
+	}
+
+	for _, bad := range []struct {
+		inRate, outRate, channels int
+		d                         time.Duration
+	}{
+		{0, 8000, 1, time.Millisecond},
+		{8000, 16000, 0, time.Millisecond},
+		{8000, 16000, 1, 10 * time.Microsecond},
+	} {
+		if _, err := NewSrcDataFromDuration(bad.inRate, bad.outRate, bad.channels, bad.d); err == nil {
+			t.Errorf("%d to %d Hz, %d channels, %v: expected error", bad.inRate, bad.outRate, bad.channels, bad.d)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"io"
+)
+
+// Empty blocks in a row after which ReadFrames gives up, as io.ReadFull does
+const maxEmptyBlocks = 100
+
+// SourceFunc supplies the input of a FrameReader: it returns the next block of
+// interleaved whole frames, and io.EOF (with or without a last block) at the end
+// of the stream. The block is read until the next call, so it must not change
+// before then.
+type SourceFunc func() ([]float32, error)
+
+// FrameReader pulls converted frames from a Converter fed by a SourceFunc, so a
+// consumer asks for output instead of pushing input through Process: ReadFrames
+// fills the caller's buffer with whole frames, fetching input as needed, and
+// drains the converter at the end of the source. It replaces the sample and
+// frame count bookkeeping of CallbackRead.
+//
+// NOTE: A FrameReader is NOT goroutine-safe.
+type FrameReader struct {
+	conv        Converter
+	src         SourceFunc
+	ratio       float64
+	inChannels  int
+	outChannels int
+	pending     []float32 // Input not yet consumed by the converter
+	eof         bool      // src returned io.EOF
+	done        bool      // The converter is drained
+}
+
+// NewFrameReader creates a FrameReader converting the blocks of src with conv,
+// created with New or NewWithOptions, at the given ratio (output rate / input
+// rate). The reader takes over conv: close it with Close.
+func NewFrameReader(conv Converter, ratio float64, src SourceFunc) (*FrameReader, error) {
+	if conv == nil || src == nil {
+		return nil, mapError(ErrBadData)
+	}
+	if isBadSrcRatio(ratio) {
+		return nil, fmt.Errorf("ratio %f: %w", ratio, mapError(ErrBadSrcRatio))
+	}
+	return &FrameReader{
+		conv:        conv,
+		src:         src,
+		ratio:       ratio,
+		inChannels:  conv.GetChannels(),
+		outChannels: outputChannelsOf(conv),
+	}, nil
+}
+
+// Channels returns the number of channels of the frames ReadFrames returns.
+func (r *FrameReader) Channels() int {
+	return r.outChannels
+}
+
+// SetRatio changes the ratio from the next ReadFrames call on; the converter
+// glides to it as with Process.
+func (r *FrameReader) SetRatio(ratio float64) error {
+	if isBadSrcRatio(ratio) {
+		return fmt.Errorf("ratio %f: %w", ratio, mapError(ErrBadSrcRatio))
+	}
+	r.ratio = ratio
+	return nil
+}
+
+// ReadFrames fills dst with as many whole interleaved frames as fit in it and
+// returns how many it wrote; samples beyond the last whole frame are left
+// untouched. It returns fewer frames only at the end of the stream or with an
+// error, and 0 and io.EOF once the converter is drained. An error from the
+// source other than io.EOF is returned with the frames read before it; the next
+// call asks the source again.
+func (r *FrameReader) ReadFrames(dst []float32) (int, error) {
+	frames := len(dst) / r.outChannels
+	if frames == 0 {
+		return 0, fmt.Errorf("%w (need %d samples for a frame, got %d)", mapError(ErrShortOutput), r.outChannels, len(dst))
+	}
+	if r.done {
+		return 0, io.EOF
+	}
+	written, empty := 0, 0
+	for written < frames {
+		if len(r.pending) == 0 && !r.eof {
+			block, err := r.src()
+			if err != nil && err != io.EOF {
+				return written, err
+			}
+			if len(block)%r.inChannels != 0 {
+				return written, fmt.Errorf("source block of %d samples not multiple of channel count (%d)", len(block), r.inChannels)
+			}
+			r.pending, r.eof = block, err == io.EOF
+			if len(block) == 0 && !r.eof {
+				if empty++; empty >= maxEmptyBlocks {
+					return written, io.ErrNoProgress
+				}
+				continue
+			}
+			empty = 0
+		}
+
+		data := SrcData{
+			DataIn:       r.pending,
+			InputFrames:  int64(len(r.pending) / r.inChannels),
+			DataOut:      dst[written*r.outChannels:],
+			OutputFrames: int64(frames - written),
+			SrcRatio:     r.ratio,
+			EndOfInput:   r.eof,
+		}
+		if len(r.pending) == 0 {
+			data.DataIn = nil
+		}
+		if err := r.conv.Process(&data); err != nil {
+			return written, err
+		}
+		r.pending = r.pending[data.InputFramesUsed*int64(r.inChannels):]
+		written += int(data.OutputFramesGen)
+		if data.InputFramesUsed == 0 && data.OutputFramesGen == 0 {
+			if r.eof && len(r.pending) == 0 {
+				r.done = true
+				break
+			}
+			if empty++; empty >= maxEmptyBlocks {
+				return written, io.ErrNoProgress
+			}
+		}
+	}
+	if written == 0 && r.done {
+		return 0, io.EOF
+	}
+	return written, nil
+}
+
+// Reset resets the converter and forgets the source position, so the next
+// ReadFrames starts a new stream from src.
+func (r *FrameReader) Reset() error {
+	r.pending, r.eof, r.done = nil, false, false
+	return r.conv.Reset()
+}
+
+// Close closes the converter.
+func (r *FrameReader) Close() error {
+	return r.conv.Close()
+}
@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// blockSource returns a SourceFunc handing out in in blocks of the given sizes
+// in samples, cycling through them, then io.EOF.
+func blockSource(in []float32, sizes ...int) SourceFunc {
+	pos, k := 0, 0
+	return func() ([]float32, error) {
+		if pos >= len(in) {
+			return nil, io.EOF
+		}
+		n := minInt(sizes[k%len(sizes)], len(in)-pos)
+		k++
+		pos += n
+		return in[pos-n : pos], nil
+	}
+}
+
+func TestFrameReader(t *testing.T) {
+	const channels = 2
+	in := genSine(3000*channels, 440, 16000, 0.5)
+	ref, _ := New(SincFastest, channels)
+	want, err := processAll(ref, in, channels, 0.75)
+	ref.Close()
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+
+	conv, _ := New(SincFastest, channels)
+	r, err := NewFrameReader(conv, 0.75, blockSource(in, 0, 250*channels, 3*channels, 1000*channels))
+	if err != nil {
+		t.Fatalf("NewFrameReader failed: %v", err)
+	}
+	defer r.Close()
+	var got []float32
+	buf := make([]float32, 2*37+1) // Odd: the last sample never holds a frame
+	for {
+		n, err := r.ReadFrames(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadFrames failed: %v", err)
+		}
+		if n != 37 && len(got)+n*channels != len(want) {
+			t.Fatalf("short read of %d frames before the end", n)
+		}
+		got = append(got, buf[:n*channels]...)
+	}
+	checkSameSamples(t, "FrameReader", got, want)
+	if n, err := r.ReadFrames(buf); n != 0 || err != io.EOF {
+		t.Errorf("read after the end: %d frames, %v; want 0 and io.EOF", n, err)
+	}
+}
+
+func TestFrameReaderReset(t *testing.T) {
+	in := genSine(1000, 440, 8000, 0.5)
+	var source SourceFunc
+	conv, _ := New(Linear, 1)
+	r, _ := NewFrameReader(conv, 2, func() ([]float32, error) { return source() })
+	defer r.Close()
+	readAll := func() int {
+		source = blockSource(in, 100)
+		total := 0
+		buf := make([]float32, 512)
+		for {
+			n, err := r.ReadFrames(buf)
+			if err == io.EOF {
+				return total
+			}
+			if err != nil {
+				t.Fatalf("ReadFrames failed: %v", err)
+			}
+			total += n
+		}
+	}
+	first := readAll()
+	if err := r.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if second := readAll(); second != first || first != 2000 {
+		t.Errorf("read %d frames, then %d after Reset; want 2000 twice", first, second)
+	}
+}
+
+func TestFrameReaderErrors(t *testing.T) {
+	conv, _ := New(Linear, 2)
+	defer conv.Close()
+	if _, err := NewFrameReader(conv, 0, blockSource(nil, 1)); err == nil {
+		t.Error("expected error for a zero ratio")
+	}
+	if _, err := NewFrameReader(conv, 1, nil); err == nil {
+		t.Error("expected error for a nil source")
+	}
+
+	failing := errors.New("socket closed")
+	calls := 0
+	r, _ := NewFrameReader(conv, 1, func() ([]float32, error) {
+		if calls++; calls == 1 {
+			return make([]float32, 20), nil
+		}
+		return nil, failing
+	})
+	buf := make([]float32, 100)
+	if n, err := r.ReadFrames(buf); !errors.Is(err, failing) || n > 10 {
+		t.Errorf("got %d frames and %v, want the source error", n, err)
+	}
+	if _, err := r.ReadFrames(buf[:1]); !errors.Is(err, ErrShortOutput) {
+		t.Errorf("got %v for a buffer smaller than a frame, want ErrShortOutput", err)
+	}
+
+	conv.Reset()
+	r, _ = NewFrameReader(conv, 1, func() ([]float32, error) { return make([]float32, 3), nil })
+	if _, err := r.ReadFrames(buf); err == nil {
+		t.Error("expected error for a block of partial frames")
+	}
+	conv.Reset()
+	r, _ = NewFrameReader(conv, 1, func() ([]float32, error) { return nil, nil })
+	if _, err := r.ReadFrames(buf); err != io.ErrNoProgress {
+		t.Errorf("got %v for a source returning nothing, want io.ErrNoProgress", err)
+	}
+}
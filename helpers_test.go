@@ -0,0 +1,190 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// Helpers shared by the tests of the package: signal generators, converter
+// drivers and comparisons.
+
+// genSine generates a mono sine of the given frequency and linear amplitude.
+func genSine(frames int, freq, sampleRate, amplitude float64) []float32 {
+	out := make([]float32, frames)
+	for i := range out {
+		out[i] = float32(amplitude * math.Sin(2.0*math.Pi*freq*float64(i)/sampleRate))
+	}
+	return out
+}
+
+// s16leTone returns frames S16LE samples of a sine at freq Hz.
+func s16leTone(frames int, freq, rate, amp float64) []byte {
+	out := make([]byte, 2*frames)
+	EncodePCM(FormatS16LE, genSine(frames, freq, rate, amp), out)
+	return out
+}
+
+// encodeUlawTone generates a u-law encoded 8kHz sine at the given linear
+// amplitude.
+func encodeUlawTone(frames int, freq, amplitude float64) []byte {
+	out := make([]byte, frames)
+	for i, v := range genSine(frames, freq, 8000, amplitude) {
+		out[i] = linearToUlawGo(int16(float64(v) * 32767.0))
+	}
+	return out
+}
+
+// harmonicTone returns frames of a tone at freq Hz whose harmonics 2 to 5 are
+// louder than the fundamental, which fools a plain autocorrelation peak.
+func harmonicTone(frames int, freq, rate float64) []float32 {
+	out := genSine(frames, freq, rate, 0.1)
+	for i := range out {
+		phase := 2 * math.Pi * freq * float64(i) / rate
+		v := float64(out[i])
+		for h := 2; h <= 5; h++ {
+			v += 0.2 * math.Sin(float64(h)*phase+float64(h))
+		}
+		out[i] = float32(v)
+	}
+	return out
+}
+
+// multichannelTones returns frames of a different tone per channel.
+func multichannelTones(frames, channels int) []float32 {
+	in := make([]float32, frames*channels)
+	for c := 0; c < channels; c++ {
+		for i, v := range genSine(frames, 200+310*float64(c), 48000, 0.5) {
+			in[i*channels+c] = v
+		}
+	}
+	return in
+}
+
+// constFrames returns frames interleaved frames of value v.
+func constFrames(frames, channels int, v float32) []float32 {
+	out := make([]float32, frames*channels)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+// processBlock runs one Process call at ratio 1.5 and returns the generated
+// output.
+func processBlock(t *testing.T, c Converter, in []float32, channels int) ([]float32, error) {
+	t.Helper()
+	out := make([]float32, 2*len(in)+64*channels)
+	data := SrcData{
+		DataIn:       in,
+		InputFrames:  int64(len(in) / channels),
+		DataOut:      out,
+		OutputFrames: int64(len(out) / channels),
+		SrcRatio:     1.5,
+	}
+	err := c.Process(&data)
+	return out[:data.OutputFramesGen*int64(channels)], err
+}
+
+// stream converts in, interleaved with inChannels channels, through c at ratio
+// as a streaming caller does: block input frames per Process call, with room
+// for outFrames output frames, advancing the input by InputFramesUsed. With end
+// the last block carries EndOfInput and c is drained; otherwise stream returns
+// once the input is used and the output taken. It returns the output, with
+// outChannels channels.
+//
+// The input handed over runs past InputFrames into NaN, so reading beyond it
+// shows up in the output, and the frame counts c reports are checked.
+func stream(t *testing.T, c Converter, in []float32, inChannels, outChannels int, ratio float64, block, outFrames int, end bool) []float32 {
+	t.Helper()
+	frames := len(in) / inChannels
+	padded := append(append([]float32(nil), in...), make([]float32, 64*inChannels)...)
+	for i := len(in); i < len(padded); i++ {
+		padded[i] = float32(math.NaN())
+	}
+	var result []float32
+	out := make([]float32, outFrames*outChannels)
+	pos, idle := 0, 0
+	for {
+		n := minInt(block, frames-pos)
+		data := SrcData{
+			DataIn:       padded[pos*inChannels:],
+			InputFrames:  int64(n),
+			DataOut:      out,
+			OutputFrames: int64(outFrames),
+			SrcRatio:     ratio,
+			EndOfInput:   end && pos+n == frames,
+		}
+		if err := c.Process(&data); err != nil {
+			t.Fatalf("Process at input frame %d: %v", pos, err)
+		}
+		if data.InputFramesUsed < 0 || data.InputFramesUsed > data.InputFrames {
+			t.Fatalf("InputFramesUsed %d of %d at input frame %d", data.InputFramesUsed, data.InputFrames, pos)
+		}
+		if data.OutputFramesGen < 0 || data.OutputFramesGen > data.OutputFrames {
+			t.Fatalf("OutputFramesGen %d of %d at input frame %d", data.OutputFramesGen, data.OutputFrames, pos)
+		}
+		pos += int(data.InputFramesUsed)
+		result = append(result, out[:data.OutputFramesGen*int64(outChannels)]...)
+		if data.EndOfInput && data.OutputFramesGen == 0 {
+			if pos != frames {
+				t.Fatalf("stream ended with %d of %d input frames used", pos, frames)
+			}
+			return result
+		}
+		if !end && pos == frames && data.OutputFramesGen < data.OutputFrames {
+			return result
+		}
+		if data.InputFramesUsed == 0 && data.OutputFramesGen == 0 {
+			if idle++; idle > 2 {
+				t.Fatalf("no progress at input frame %d of %d", pos, frames)
+			}
+		} else {
+			idle = 0
+		}
+	}
+}
+
+// drain converts all of in through c at ratio, handing over the rest of the
+// input with EndOfInput on every call, and outFrames of room for the output,
+// until c is drained.
+func drain(t *testing.T, c Converter, in []float32, inChannels, outChannels int, ratio float64, outFrames int) []float32 {
+	t.Helper()
+	return stream(t, c, in, inChannels, outChannels, ratio, len(in)/inChannels, outFrames, true)
+}
+
+// resampleWhole converts in with a new converter of type ct and one Process
+// call with an output buffer large enough for all of it.
+func resampleWhole(t *testing.T, ct ConverterType, in []float32, channels int, ratio float64) []float32 {
+	t.Helper()
+	conv, err := New(ct, channels)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	out, err := processAll(conv, in, channels, ratio)
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+	return out
+}
+
+// checkSameSamples fails the test unless got and want hold the same samples.
+func checkSameSamples(t *testing.T, name string, got, want []float32) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d samples, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s: sample %d = %g, want %g", name, i, got[i], want[i])
+		}
+	}
+}
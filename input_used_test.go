@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestInputFramesUsedStreaming checks InputFramesUsed of every converter and
+// sinc channel variant (mono, stereo, quad, hex and the generic one) when the
+// input comes in tiny blocks and the output buffer is smaller still, at ratios
+// up to the limits: a consumer advancing its input by InputFramesUsed must get
+// exactly the output of one call over the whole input.
+func TestInputFramesUsedStreaming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("slow")
+	}
+	ratios := []float64{1.0 / srcMaxRatio, 0.1, 0.999, 1.001, 3.0, srcMaxRatio}
+	for _, converterType := range []ConverterType{SincFastest, SincBestQuality, Linear, ZeroOrderHold} {
+		for _, channels := range []int{1, 2, 4, 6, 5} {
+			for _, ratio := range ratios {
+				frames := int(math.Min(1500, 3000/ratio))
+				in := genSine(frames*channels, 440, 8000, 0.5)
+				oneShot, _ := New(converterType, channels)
+				want, err := processAll(oneShot, in, channels, ratio)
+				oneShot.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, block := range []int{1, 3, 64} {
+					for _, eofWithLast := range []bool{true, false} {
+						name := fmt.Sprintf("%s/%dch/ratio %g/block %d/eof with last %t", converterType, channels, ratio, block, eofWithLast)
+						conv, _ := New(converterType, channels)
+						got := stream(t, conv, in, channels, channels, ratio, block, 5, eofWithLast)
+						if !eofWithLast {
+							// The drain is a separate call without input
+							got = append(got, drain(t, conv, nil, channels, channels, ratio, 5)...)
+						}
+						conv.Close()
+						checkSameSamples(t, name, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestInputFramesUsedAfterEOF checks that a drained converter consumes and
+// produces nothing more.
+func TestInputFramesUsedAfterEOF(t *testing.T) {
+	conv, _ := New(SincFastest, 2)
+	defer conv.Close()
+	in := genSine(200, 440, 8000, 0.5)
+	stream(t, conv, in, 2, 2, 0.5, 10, 7, true)
+	out := make([]float32, 20)
+	data := SrcData{DataIn: in, InputFrames: 100, DataOut: out, OutputFrames: 10, SrcRatio: 0.5, EndOfInput: true}
+	if err := conv.Process(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.InputFramesUsed != 0 || data.OutputFramesGen != 0 {
+		t.Errorf("after the drain: used %d, generated %d; want 0 and 0", data.InputFramesUsed, data.OutputFramesGen)
+	}
+}
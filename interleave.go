@@ -0,0 +1,127 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "fmt"
+
+// interleaveBlockFrames is the number of frames copied per channel before moving
+// to the next channel. Small enough for the touched part of dst and every src
+// channel to stay in L1, large enough to amortize the loop overhead.
+const interleaveBlockFrames = 256
+
+// Interleave writes planar channel buffers into a single interleaved buffer,
+// as expected by Process (frame 0 ch 0, frame 0 ch 1, ..., frame 1 ch 0, ...).
+//
+// All channels must have the same length; that length is the number of frames
+// written. dst must hold at least frames*len(src) samples.
+// It returns the number of frames written.
+func Interleave(dst []float32, src [][]float32) (int, error) {
+	channels := len(src)
+	if channels == 0 {
+		return 0, mapError(ErrBadChannelCount)
+	}
+	frames := len(src[0])
+	for ch := 1; ch < channels; ch++ {
+		if len(src[ch]) != frames {
+			return 0, fmt.Errorf("channel %d length (%d) differs from channel 0 length (%d)", ch, len(src[ch]), frames)
+		}
+	}
+	if len(dst) < frames*channels {
+		return 0, fmt.Errorf("output buffer too small: need %d, got %d", frames*channels, len(dst))
+	}
+
+	switch channels {
+	case 1:
+		copy(dst, src[0])
+	case 2:
+		left, right := src[0], src[1]
+		out := dst[:frames*2]
+		for fr := range left {
+			out[2*fr] = left[fr]
+			out[2*fr+1] = right[fr]
+		}
+	default:
+		for start := 0; start < frames; start += interleaveBlockFrames {
+			end := minInt(start+interleaveBlockFrames, frames)
+			for ch, in := range src {
+				block := in[start:end]
+				outIdx := start*channels + ch
+				for _, v := range block {
+					dst[outIdx] = v
+					outIdx += channels
+				}
+			}
+		}
+	}
+	return frames, nil
+}
+
+// Deinterleave splits an interleaved buffer into planar channel buffers.
+// The channel count is len(dst); the number of frames is len(src)/len(dst).
+// Each dst channel must hold at least that many frames.
+// It returns the number of frames written to each channel.
+func Deinterleave(dst [][]float32, src []float32) (int, error) {
+	channels := len(dst)
+	if channels == 0 {
+		return 0, mapError(ErrBadChannelCount)
+	}
+	if len(src)%channels != 0 {
+		return 0, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(src), channels)
+	}
+	frames := len(src) / channels
+	for ch := range dst {
+		if len(dst[ch]) < frames {
+			return 0, fmt.Errorf("output channel %d too small: need %d, got %d", ch, frames, len(dst[ch]))
+		}
+	}
+
+	switch channels {
+	case 1:
+		copy(dst[0], src)
+	case 2:
+		left, right := dst[0][:frames], dst[1][:frames]
+		for fr := range left {
+			left[fr] = src[2*fr]
+			right[fr] = src[2*fr+1]
+		}
+	default:
+		for start := 0; start < frames; start += interleaveBlockFrames {
+			end := minInt(start+interleaveBlockFrames, frames)
+			for ch, out := range dst {
+				block := out[start:end]
+				inIdx := start*channels + ch
+				for i := range block {
+					block[i] = src[inIdx]
+					inIdx += channels
+				}
+			}
+		}
+	}
+	return frames, nil
+}
+
+// DeinterleaveNew is like Deinterleave but allocates the planar buffers.
+func DeinterleaveNew(src []float32, channels int) ([][]float32, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if len(src)%channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(src), channels)
+	}
+	frames := len(src) / channels
+	backing := make([]float32, len(src)) // One allocation for all channels
+	dst := make([][]float32, channels)
+	for ch := range dst {
+		dst[ch] = backing[ch*frames : (ch+1)*frames : (ch+1)*frames]
+	}
+	if _, err := Deinterleave(dst, src); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
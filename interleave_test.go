@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// makePlanar builds planar test data where every sample encodes its channel and frame.
+func makePlanar(channels, frames int) [][]float32 {
+	planar := make([][]float32, channels)
+	for ch := range planar {
+		planar[ch] = make([]float32, frames)
+		for fr := range planar[ch] {
+			planar[ch][fr] = float32(ch*100000 + fr)
+		}
+	}
+	return planar
+}
+
+func TestInterleaveDeinterleave(t *testing.T) {
+	for _, channels := range []int{1, 2, 3, 6, 17} {
+		for _, frames := range []int{0, 1, 255, 256, 1000} {
+			t.Run(fmt.Sprintf("Ch%d_Frames%d", channels, frames), func(t *testing.T) {
+				planar := makePlanar(channels, frames)
+
+				interleaved := make([]float32, channels*frames)
+				n, err := Interleave(interleaved, planar)
+				if err != nil || n != frames {
+					t.Fatalf("Interleave: n=%d err=%v", n, err)
+				}
+
+				for i, v := range interleaved {
+					if want := planar[i%channels][i/channels]; v != want {
+						t.Fatalf("interleaved[%d]=%g, want %g", i, v, want)
+					}
+				}
+
+				back, err := DeinterleaveNew(interleaved, channels)
+				if err != nil {
+					t.Fatalf("DeinterleaveNew failed: %v", err)
+				}
+				for ch := range planar {
+					for fr := range planar[ch] {
+						if back[ch][fr] != planar[ch][fr] {
+							t.Fatalf("ch %d frame %d: got %g, want %g", ch, fr, back[ch][fr], planar[ch][fr])
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestInterleaveErrors(t *testing.T) {
+	if _, err := Interleave(make([]float32, 4), nil); err == nil {
+		t.Error("expected error for zero channels")
+	}
+	if _, err := Interleave(make([]float32, 4), [][]float32{{1, 2}, {1}}); err == nil {
+		t.Error("expected error for mismatched channel lengths")
+	}
+	if _, err := Interleave(make([]float32, 3), [][]float32{{1, 2}, {1, 2}}); err == nil {
+		t.Error("expected error for short output")
+	}
+	if _, err := Deinterleave([][]float32{make([]float32, 2), make([]float32, 2)}, make([]float32, 5)); err == nil {
+		t.Error("expected error for partial frame")
+	}
+	if _, err := Deinterleave([][]float32{make([]float32, 2), make([]float32, 1)}, make([]float32, 4)); err == nil {
+		t.Error("expected error for short channel")
+	}
+	if _, err := DeinterleaveNew(make([]float32, 4), 0); err == nil {
+		t.Error("expected error for zero channels")
+	}
+}
+
+func BenchmarkInterleave(b *testing.B) {
+	for _, channels := range []int{2, 8} {
+		planar := makePlanar(channels, 4096)
+		out := make([]float32, channels*4096)
+		b.Run(fmt.Sprintf("Ch%d", channels), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = Interleave(out, planar)
+			}
+		})
+	}
+}
+
+func BenchmarkDeinterleave(b *testing.B) {
+	for _, channels := range []int{2, 8} {
+		interleaved := make([]float32, channels*4096)
+		planar := makePlanar(channels, 4096)
+		b.Run(fmt.Sprintf("Ch%d", channels), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = Deinterleave(planar, interleaved)
+			}
+		})
+	}
+}
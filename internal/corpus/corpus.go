@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+// Package corpus embeds a short synthetic audio corpus (speech-like and
+// keyboard-typing snippets as S16LE PCM and 8kHz u-law) so the examples and
+// tests run anywhere without external files. Regenerate with "go run gen.go".
+package corpus
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed data
+var files embed.FS
+
+// File names in the corpus.
+const (
+	Speech24kS16LE = "speech_24k_s16le.raw" // 1.0 s, 24kHz, mono S16LE
+	Typing24kS16LE = "typing_24k_s16le.raw" // 0.6 s, 24kHz, mono S16LE
+	Speech16kS16LE = "speech_16k_s16le.raw" // 1.0 s, 16kHz, mono S16LE
+	Typing16kS16LE = "typing_16k_s16le.raw" // 0.6 s, 16kHz, mono S16LE
+	Speech8kUlaw   = "speech_8k.ulaw"       // 1.0 s, 8kHz, mono G.711 u-law
+	Typing8kUlaw   = "typing_8k.ulaw"       // 0.6 s, 8kHz, mono G.711 u-law
+)
+
+// FS returns the corpus as a read-only file system rooted at the data directory.
+func FS() fs.FS {
+	sub, err := fs.Sub(files, "data")
+	if err != nil {
+		panic(err) // The directory is embedded at build time, this cannot fail
+	}
+	return sub
+}
+
+// ReadFile returns the contents of a corpus file.
+func ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(FS(), name)
+}
+
+// MustReadFile is like ReadFile but panics on error; meant for examples and tests.
+func MustReadFile(name string) []byte {
+	data, err := ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// SampleRate returns the sample rate encoded in a corpus file name (e.g. 24000 for
+// "speech_24k_s16le.raw"), or 0 if the name does not follow the corpus convention.
+func SampleRate(name string) int {
+	for _, part := range strings.Split(strings.TrimSuffix(name, path.Ext(name)), "_") {
+		switch part {
+		case "8k":
+			return 8000
+		case "16k":
+			return 16000
+		case "24k":
+			return 24000
+		}
+	}
+	return 0
+}
+
+// IsUlaw reports whether a corpus file holds u-law (rather than S16LE) data.
+func IsUlaw(name string) bool {
+	return path.Ext(name) == ".ulaw"
+}
@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+//go:build ignore
+
+// gen.go regenerates the embedded test corpus in ./data.
+// Run with: go run gen.go
+package main
+
+import (
+	"encoding/binary"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// lcg is a tiny deterministic noise source so the corpus is reproducible.
+type lcg uint32
+
+func (l *lcg) next() float64 {
+	*l = *l*1664525 + 1013904223
+	return float64(*l>>8)/float64(1<<24)*2.0 - 1.0
+}
+
+// speechLike produces a voiced-sounding signal: a harmonic series with a slowly
+// moving pitch, amplitude-modulated by syllable-rate bursts separated by pauses.
+func speechLike(rate float64, seconds float64, seed lcg) []float64 {
+	n := int(rate * seconds)
+	out := make([]float64, n)
+	phase := 0.0
+	for i := range out {
+		t := float64(i) / rate
+		pitch := 140.0 + 30.0*math.Sin(2.0*math.Pi*0.7*t)
+		phase += 2.0 * math.Pi * pitch / rate
+		v := 0.0
+		for h := 1; h <= 12; h++ {
+			if pitch*float64(h) >= rate/2.0 {
+				break
+			}
+			v += math.Sin(float64(h)*phase) / float64(h)
+		}
+		syllable := math.Max(0, math.Sin(2.0*math.Pi*3.0*t)) // ~6 syllables per second with gaps
+		out[i] = 0.25*v*syllable + 0.002*seed.next()
+	}
+	return out
+}
+
+// typing produces short decaying noise clicks over a low hiss, like keyboard noise.
+func typing(rate float64, seconds float64, seed lcg) []float64 {
+	n := int(rate * seconds)
+	out := make([]float64, n)
+	env := 0.0
+	nextClick := 0
+	for i := range out {
+		if i >= nextClick {
+			env = 0.5
+			nextClick = i + int(rate*(0.08+0.1*(seed.next()+1.0)))
+		}
+		env *= math.Exp(-1.0 / (rate * 0.004))
+		out[i] = env*seed.next() + 0.003*seed.next()
+	}
+	return out
+}
+
+func s16le(samples []float64) []byte {
+	out := make([]byte, 0, len(samples)*2)
+	for _, v := range samples {
+		out = binary.LittleEndian.AppendUint16(out, uint16(int16(math.Max(-1, math.Min(1, v))*32767.0)))
+	}
+	return out
+}
+
+// ulaw encodes with the G.711 algorithm used by the library.
+func ulaw(samples []float64) []byte {
+	out := make([]byte, len(samples))
+	for i, v := range samples {
+		pcm := int(math.Max(-1, math.Min(1, v)) * 32767.0)
+		sign := 0x80
+		if pcm < 0 {
+			sign = 0
+			pcm = -pcm
+		}
+		if pcm > 32635 {
+			pcm = 32635
+		}
+		pcm += 0x84
+		exponent := 7
+		for mask := 0x4000; pcm&mask == 0 && exponent > 0; exponent-- {
+			mask >>= 1
+		}
+		mantissa := (pcm >> (exponent + 3)) & 0x0F
+		out[i] = ^byte(sign | exponent<<4 | mantissa)
+	}
+	return out
+}
+
+func main() {
+	files := map[string][]byte{
+		"speech_24k_s16le.raw": s16le(speechLike(24000, 1.0, 1)),
+		"typing_24k_s16le.raw": s16le(typing(24000, 0.6, 2)),
+		"speech_16k_s16le.raw": s16le(speechLike(16000, 1.0, 3)),
+		"typing_16k_s16le.raw": s16le(typing(16000, 0.6, 4)),
+		"speech_8k.ulaw":       ulaw(speechLike(8000, 1.0, 5)),
+		"typing_8k.ulaw":       ulaw(typing(8000, 0.6, 6)),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join("data", name), data, 0644); err != nil {
+			log.Fatal(err)
+		}
+		log.Println("wrote", name, len(data), "bytes")
+	}
+}
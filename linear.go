@@ -232,10 +232,10 @@ func linearVariProcess(state *srcState, data *SrcData) ErrorCode {
 		for ch := 0; ch < channels; ch++ {
 			lastVal := float64(filter.lastValue[ch])
 			firstVal := float64(inputData[ch])
-			data.DataOut[outPos+ch] = float32(lastVal + inputIndex*(firstVal-lastVal))
+			data.DataOut[outPos+ch] = float32(lastVal + float64(inputIndex*(firstVal-lastVal))) // No FMA, see Options.Deterministic
 		}
 		outGenSamples += int64(channels)
-		inputIndex += 1.0 / srcRatio
+		inputIndex = state.advanceInput(inputIndex, srcRatio)
 	}
 
 	// --- Main Processing Loop ---
@@ -295,12 +295,12 @@ func linearVariProcess(state *srcState, data *SrcData) ErrorCode {
 		for ch := 0; ch < channels; ch++ {
 			y0 := float64(inputData[y0BaseIndex+int64(ch)])
 			y1 := float64(inputData[y1BaseIndex+int64(ch)])
-			data.DataOut[outPos+ch] = float32(y0 + inputIndex*(y1-y0))
+			data.DataOut[outPos+ch] = float32(y0 + float64(inputIndex*(y1-y0))) // No FMA, see Options.Deterministic
 		}
 		outGenSamples += int64(channels)
 
 		// Figure out the next index.
-		inputIndex += 1.0 / srcRatio
+		inputIndex = state.advanceInput(inputIndex, srcRatio)
 		intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
 		inUsedSamples += int64(intInputAdvance) * int64(channels) // Advance input usage marker (use int64 conversion)
 		inputIndex = fmodOne(inputIndex)                          // Keep fractional part
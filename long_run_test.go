@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// A stream running for days passes 2^31 and 2^32 frames; the counters are
+// advanced to there instead of processing that much audio.
+func TestLongRunCounters(t *testing.T) {
+	const rate = 48000
+	const days = 3
+	start := int64(days * 86400 * rate) // Past 2^32 frames
+	if start <= math.MaxUint32 {
+		t.Fatalf("start %d does not simulate a wrap", start)
+	}
+
+	conv, err := New(Linear, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	state := conv.(*srcState)
+	state.stats.InputFrames, state.stats.OutputFrames = start, start
+	state.clock = clockEstimator{started: true, frame: start}
+
+	in := make([]float32, 2*960)
+	out := make([]float32, 2*2000)
+	ts := time.Duration(days) * 24 * time.Hour
+	for block := 0; block < 50; block++ {
+		data := SrcData{DataIn: in, InputFrames: 960, DataOut: out, OutputFrames: 2000, SrcRatio: 1}
+		if err := ProcessTimed(conv, &data, ts, rate); err != nil {
+			t.Fatalf("block %d: ProcessTimed failed: %v", block, err)
+		}
+		if math.Abs(data.SrcRatio-1) > 1e-9 {
+			t.Fatalf("block %d: measured ratio %.12f after %d days, want 1", block, data.SrcRatio, days)
+		}
+		ts += 20 * time.Millisecond
+	}
+
+	st := conv.(StatsReporter).Stats()
+	if want := start + 50*960; st.InputFrames != want || state.clock.frame != want {
+		t.Errorf("input frames %d, clock frame %d, want %d", st.InputFrames, state.clock.frame, want)
+	}
+	if st.OutputFrames <= start {
+		t.Errorf("output frames %d wrapped below %d", st.OutputFrames, start)
+	}
+}
+
+// Streaming in many uneven blocks wraps the sinc ring buffer thousands of times;
+// the indices must stay inside it and the output match a one-shot conversion.
+func TestLongRunSincRingBuffer(t *testing.T) {
+	const frames = 400000
+	in := genSine(frames, 1000, 44100, 0.5)
+	for _, ratio := range []float64{0.73, 1.6} {
+		want := resampleWhole(t, SincFastest, in, 1, ratio)
+
+		conv, err := New(SincFastest, 1)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		filter := conv.(*srcState).privateData.(*sincFilter)
+		var out []float32
+		block := 1
+		for pos := 0; pos < frames; {
+			end := minInt(pos+block, frames)
+			data := SrcData{DataIn: in[pos:end], InputFrames: int64(end - pos), SrcRatio: ratio, EndOfInput: end == frames}
+			if err := ProcessAppend(conv, &data, &out); err != nil {
+				t.Fatalf("at %g: ProcessAppend at frame %d failed: %v", ratio, pos, err)
+			}
+			if filter.bCurrent < 0 || filter.bCurrent >= filter.bLen || filter.bEnd > filter.bLen {
+				t.Fatalf("at %g: indices current=%d end=%d outside the buffer of %d", ratio, filter.bCurrent, filter.bEnd, filter.bLen)
+			}
+			pos = end
+			block = block*7%997 + 1
+		}
+		conv.Close()
+
+		if len(out) != len(want) {
+			t.Fatalf("at %g: streamed %d samples, want %d", ratio, len(out), len(want))
+		}
+		for i := range want {
+			if out[i] != want[i] {
+				t.Fatalf("at %g: sample %d = %g, want %g", ratio, i, out[i], want[i])
+			}
+		}
+	}
+}
+
+// Hours of streaming in 20ms blocks must not drift: the output count stays
+// within the filter delay of ratio*input, and the interpolation phase left in
+// lastPosition matches the exact rational position of the next output frame.
+func TestLongRunNoDrift(t *testing.T) {
+	cases := []struct {
+		ct              ConverterType
+		inRate, outRate int
+		seconds         int
+	}{
+		{Linear, 8000, 11025, 2 * 3600},
+		{ZeroOrderHold, 11025, 8000, 3600},
+		{SincFastest, 44100, 48000, 300},
+		{SincFastest, 8000, 11025, 1200},
+	}
+	for _, tc := range cases {
+		if testing.Short() {
+			tc.seconds /= 20
+		}
+		ratio, _ := RateRatio(tc.inRate, tc.outRate)
+		g := int64(gcd(tc.inRate, tc.outRate))
+		num, den := int64(tc.outRate)/g, int64(tc.inRate)/g
+		conv, err := New(tc.ct, 1)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		delay := float64(filterReachFrames(conv, ratio))*ratio + 2
+
+		src := genSine(tc.inRate+1000, 997, float64(tc.inRate), 0.5)
+		block := tc.inRate / 50
+		out := make([]float32, 2*int(float64(block)*ratio)+16)
+		total := int64(tc.seconds) * int64(tc.inRate)
+		var inFrames, outFrames int64
+		baseline := math.NaN()
+		for pos := 0; inFrames < total; pos = (pos + block) % 1000 {
+			data := SrcData{DataIn: src[pos : pos+block], InputFrames: int64(block), DataOut: out, OutputFrames: int64(len(out)), SrcRatio: ratio}
+			for data.InputFrames > 0 {
+				if err := conv.Process(&data); err != nil {
+					t.Fatalf("%v %d to %d: Process failed after %d frames: %v", tc.ct, tc.inRate, tc.outRate, inFrames, err)
+				}
+				inFrames += data.InputFramesUsed
+				outFrames += data.OutputFramesGen
+				data.DataIn = data.DataIn[data.InputFramesUsed:]
+				data.InputFrames -= data.InputFramesUsed
+			}
+			lag := float64(inFrames)*ratio - float64(outFrames)
+			if math.IsNaN(baseline) && inFrames >= 10*int64(tc.inRate) {
+				baseline = lag
+			}
+			if lag < -1 || lag > delay || (!math.IsNaN(baseline) && math.Abs(lag-baseline) > 1) {
+				t.Fatalf("%v %d to %d: output lags ratio*input by %.3f frames after %d input frames (%.3f after 10s, delay %.1f)",
+					tc.ct, tc.inRate, tc.outRate, lag, inFrames, baseline, delay)
+			}
+		}
+
+		// The next output frame falls outFrames*den/num input frames in
+		phase := float64(outFrames*den%num) / float64(num)
+		got := fmodOne(conv.(*srcState).lastPosition)
+		if d := math.Abs(got - phase); math.Min(d, 1-d) > 1e-6 {
+			t.Errorf("%v %d to %d: phase %.9f after %d output frames, want %.9f", tc.ct, tc.inRate, tc.outRate, got, outFrames, phase)
+		}
+		conv.Close()
+	}
+}
+
+func TestCallbackReadLargeRequest(t *testing.T) {
+	conv, err := CallbackNew(func(interface{}) ([]float32, int64, error) { return nil, 0, nil }, Linear, 4, nil)
+	if err != nil {
+		t.Fatalf("CallbackNew failed: %v", err)
+	}
+	defer conv.Close()
+	// frames*channels overflows a 32-bit int; the request must be rejected, not
+	// wrap to a small size that passes the check
+	if _, err := CallbackRead(conv, 1, 1<<30, make([]float32, 16)); err == nil {
+		t.Error("request larger than the output buffer accepted")
+	}
+}
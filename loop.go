@@ -0,0 +1,130 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+)
+
+const loopPlayerChunkFrames = 1024 // Max frames handed to the converter per callback
+
+// LoopPlayer plays an in-memory interleaved buffer through a resampler, with
+// optional sample-accurate loop points (e.g. for game audio).
+//
+// Looping is done on the input side: when playback reaches the loop end, the
+// frames from the loop start are fed to the converter right after it, so the
+// filter history spans the boundary exactly as if the loop had been rendered
+// out in full. There is no click and no reset at the loop point.
+//
+// NOTE: A LoopPlayer is NOT goroutine-safe.
+type LoopPlayer struct {
+	conv     Converter
+	data     []float32
+	channels int
+	frames   int64
+
+	pos       int64 // Next input frame handed to the converter
+	loopStart int64
+	loopEnd   int64
+	looping   bool
+}
+
+// NewLoopPlayer creates a player for interleaved data with the given channel count.
+// The data slice is not copied and must not be modified while playing.
+func NewLoopPlayer(data []float32, channels int, converterType ConverterType) (*LoopPlayer, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if len(data)%channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(data), channels)
+	}
+	p := &LoopPlayer{
+		data:     data,
+		channels: channels,
+		frames:   int64(len(data) / channels),
+	}
+	conv, err := CallbackNew(loopPlayerCallback, converterType, channels, p)
+	if err != nil {
+		return nil, err
+	}
+	p.conv = conv
+	return p, nil
+}
+
+// loopPlayerCallback feeds the converter, wrapping to the loop start at the loop end.
+func loopPlayerCallback(userData interface{}) ([]float32, int64, error) {
+	p := userData.(*LoopPlayer)
+
+	if p.looping && p.pos == p.loopEnd {
+		p.pos = p.loopStart
+	}
+	end := p.frames
+	if p.looping && p.pos < p.loopEnd {
+		end = p.loopEnd // Never hand out data past the loop end
+	}
+	if p.pos >= end {
+		return nil, 0, nil // End of data
+	}
+	if end-p.pos > loopPlayerChunkFrames {
+		end = p.pos + loopPlayerChunkFrames
+	}
+
+	chunk := p.data[p.pos*int64(p.channels) : end*int64(p.channels)]
+	frames := end - p.pos
+	p.pos = end
+	return chunk, frames, nil
+}
+
+// SetLoop loops playback between startFrame (inclusive) and endFrame (exclusive).
+// The loop takes effect when playback reaches endFrame; if the input position is
+// already past endFrame, playback continues to the end of the data.
+func (p *LoopPlayer) SetLoop(startFrame, endFrame int64) error {
+	if startFrame < 0 || endFrame > p.frames || startFrame >= endFrame {
+		return fmt.Errorf("invalid loop [%d, %d) for %d frames", startFrame, endFrame, p.frames)
+	}
+	p.loopStart = startFrame
+	p.loopEnd = endFrame
+	p.looping = true
+	return nil
+}
+
+// ClearLoop disables looping; playback continues to the end of the data.
+func (p *LoopPlayer) ClearLoop() {
+	p.looping = false
+}
+
+// SeekFrame moves playback to the given input frame and resets the converter.
+func (p *LoopPlayer) SeekFrame(frame int64) error {
+	if frame < 0 || frame > p.frames {
+		return fmt.Errorf("seek position %d out of range [0, %d]", frame, p.frames)
+	}
+	if err := p.conv.Reset(); err != nil {
+		return err
+	}
+	p.pos = frame
+	return nil
+}
+
+// InputPosition returns the next input frame that will be handed to the converter.
+// Because of the filter delay this is ahead of what is currently audible.
+func (p *LoopPlayer) InputPosition() int64 {
+	return p.pos
+}
+
+// Read renders up to len(out)/channels output frames at the given ratio
+// (output rate / input rate). It returns the number of frames written, which is
+// 0 once the data has been played out (never while looping).
+func (p *LoopPlayer) Read(ratio float64, out []float32) (int64, error) {
+	return CallbackRead(p.conv, ratio, int64(len(out)/p.channels), out)
+}
+
+// Close releases the underlying converter.
+func (p *LoopPlayer) Close() error {
+	return p.conv.Close()
+}
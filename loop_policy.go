@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "fmt"
+
+// LoopMode selects what the mixers play once the background (stream 2) ends.
+type LoopMode int
+
+const (
+	// LoopForever wraps around to the start of the background (the default, and
+	// the original behavior).
+	LoopForever LoopMode = iota
+	// LoopOnce plays the background once and then mixes the voice with silence.
+	LoopOnce
+	// LoopCount plays the background LoopPolicy.Count times, then silence.
+	LoopCount
+	// HoldLast plays the background once and then repeats its last sample, e.g. a
+	// level that should stay once a fade-in has played.
+	HoldLast
+)
+
+// String returns the name of the mode.
+func (m LoopMode) String() string {
+	switch m {
+	case LoopForever:
+		return "loop forever"
+	case LoopOnce:
+		return "loop once"
+	case LoopCount:
+		return "loop count"
+	case HoldLast:
+		return "hold last"
+	default:
+		return fmt.Sprintf("LoopMode(%d)", int(m))
+	}
+}
+
+// LoopPolicy tells the mixers how to repeat the background. The zero value loops
+// forever.
+//
+// Positions: with LoopForever the background position wraps, as it always did.
+// With the other modes it counts samples across the repeats, from 0 to Length,
+// and stays at Length once the background has ended, so a saved position says
+// both where in the audio and in which repeat the mix is.
+type LoopPolicy struct {
+	Mode  LoopMode
+	Count int // Number of plays for LoopCount, >= 1
+}
+
+// LoopN returns the policy playing the background n times.
+func LoopN(n int) LoopPolicy {
+	return LoopPolicy{Mode: LoopCount, Count: n}
+}
+
+// String describes the policy.
+func (p LoopPolicy) String() string {
+	if p.Mode == LoopCount {
+		return fmt.Sprintf("loop %d times", p.Count)
+	}
+	return p.Mode.String()
+}
+
+// Validate reports an unknown mode or a LoopCount policy without plays.
+func (p LoopPolicy) Validate() error {
+	switch p.Mode {
+	case LoopForever, LoopOnce, HoldLast:
+		return nil
+	case LoopCount:
+		if p.Count < 1 {
+			return fmt.Errorf("loop count must be at least 1, got %d", p.Count)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown loop mode %d", p.Mode)
+	}
+}
+
+// Length returns the number of samples the policy plays from a frames-long
+// background before it is silent (or holds the last sample), or -1 for
+// LoopForever.
+func (p LoopPolicy) Length(frames int) int {
+	switch p.Mode {
+	case LoopForever:
+		return -1
+	case LoopCount:
+		return frames * p.Count
+	default:
+		return frames
+	}
+}
+
+// Index returns the sample of a frames-long background to play at position pos,
+// counted across repeats, or -1 where the policy plays silence.
+func (p LoopPolicy) Index(pos, frames int) int {
+	if frames <= 0 || pos < 0 {
+		return -1
+	}
+	switch p.Mode {
+	case LoopForever:
+		return pos % frames
+	case HoldLast:
+		return minInt(pos, frames-1)
+	default:
+		if pos >= p.Length(frames) {
+			return -1
+		}
+		return pos % frames
+	}
+}
@@ -0,0 +1,90 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLoopPolicyIndex(t *testing.T) {
+	tests := []struct {
+		policy LoopPolicy
+		length int
+		want   []int // Index of positions 0..9 with 3 frames
+	}{
+		{LoopPolicy{}, -1, []int{0, 1, 2, 0, 1, 2, 0, 1, 2, 0}},
+		{LoopPolicy{Mode: LoopOnce}, 3, []int{0, 1, 2, -1, -1, -1, -1, -1, -1, -1}},
+		{LoopN(2), 6, []int{0, 1, 2, 0, 1, 2, -1, -1, -1, -1}},
+		{LoopPolicy{Mode: HoldLast}, 3, []int{0, 1, 2, 2, 2, 2, 2, 2, 2, 2}},
+	}
+	for _, tt := range tests {
+		if err := tt.policy.Validate(); err != nil {
+			t.Errorf("%v: Validate failed: %v", tt.policy, err)
+		}
+		if n := tt.policy.Length(3); n != tt.length {
+			t.Errorf("%v: Length(3) = %d, want %d", tt.policy, n, tt.length)
+		}
+		for pos, want := range tt.want {
+			if got := tt.policy.Index(pos, 3); got != want {
+				t.Errorf("%v: Index(%d, 3) = %d, want %d", tt.policy, pos, got, want)
+			}
+		}
+		if got := tt.policy.Index(0, 0); got != -1 {
+			t.Errorf("%v: Index on an empty stream = %d, want -1", tt.policy, got)
+		}
+	}
+	for _, bad := range []LoopPolicy{LoopN(0), {Mode: LoopMode(9)}} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("%v: expected error", bad)
+		}
+	}
+	if s := LoopN(3).String(); s != "loop 3 times" {
+		t.Errorf("LoopN(3) is %q", s)
+	}
+}
+
+func TestLoopingSourceLoopN(t *testing.T) {
+	src := NewLoopingSourceUlaw(constUlaw(3, 1000))
+	if err := src.SetLoopPolicy(LoopN(2)); err != nil {
+		t.Fatalf("SetLoopPolicy failed: %v", err)
+	}
+	buf := make([]float32, 4)
+	src.read(buf, 1.0)
+	if src.Done() || src.Position() != 1 {
+		t.Errorf("after 4 of 6 samples: done %v position %d", src.Done(), src.Position())
+	}
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	restored := NewLoopingSourceUlaw(constUlaw(3, 1000))
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	restored.read(buf, 1.0)
+	if buf[1] == 0 || buf[2] != 0 || !restored.Done() || restored.LoopPolicy() != LoopN(2) {
+		t.Errorf("restored source should finish its second play, got %v (policy %v)", buf, restored.LoopPolicy())
+	}
+
+	// A state saved before loop policies restores SetLoop(false) as LoopOnce
+	if err := json.Unmarshal([]byte(`{"position":1,"frames":3,"loop":false,"gain":1}`), restored); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if restored.LoopPolicy().Mode != LoopOnce || restored.Loop() {
+		t.Errorf("old state restored as %v", restored.LoopPolicy())
+	}
+	if err := json.Unmarshal([]byte(`{"position":1,"frames":3,"loopMode":2,"loopCount":2,"play":2}`), restored); err == nil {
+		t.Error("expected error for a play past the count")
+	}
+	if err := src.SetLoopPolicy(LoopN(-1)); err == nil {
+		t.Error("expected error for LoopN(-1)")
+	}
+}
@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestLoopPlayerMatchesUnrolled checks that looped playback is sample-identical to
+// resampling the loop written out in full, i.e. there is no seam at the loop point.
+func TestLoopPlayerMatchesUnrolled(t *testing.T) {
+	const channels = 2
+	const frames = 3000
+	const loopStart, loopEnd = 500, 2100
+	const loops = 4
+
+	data := make([]float32, frames*channels)
+	mono := make([]float32, frames)
+	genWindowedSinesGo(2, []float64{0.011, 0.043}, 0.9, mono)
+	for i, v := range mono {
+		data[i*channels] = v
+		data[i*channels+1] = -v * 0.5
+	}
+
+	// Intro, then the loop body repeated
+	unrolled := append([]float32{}, data[:loopEnd*channels]...)
+	for i := 1; i < loops; i++ {
+		unrolled = append(unrolled, data[loopStart*channels:loopEnd*channels]...)
+	}
+
+	for _, ct := range []ConverterType{SincFastest, Linear} {
+		for _, ratio := range []float64{1.0, 0.75, 1.6} {
+			t.Run(fmt.Sprintf("%s_%.2f", GetName(ct), ratio), func(t *testing.T) {
+				wantLen := int(float64(len(unrolled)/channels)*ratio) - 200 // Stay clear of the flush tail
+				conv, err := New(ct, channels)
+				if err != nil {
+					t.Fatalf("New failed: %v", err)
+				}
+				want, err := processAll(conv, unrolled, channels, ratio)
+				if err != nil {
+					t.Fatalf("processAll failed: %v", err)
+				}
+
+				p, err := NewLoopPlayer(data, channels, ct)
+				if err != nil {
+					t.Fatalf("NewLoopPlayer failed: %v", err)
+				}
+				defer p.Close()
+				if err := p.SetLoop(loopStart, loopEnd); err != nil {
+					t.Fatalf("SetLoop failed: %v", err)
+				}
+
+				got := make([]float32, 0, wantLen*channels)
+				buf := make([]float32, 113*channels) // Odd read size
+				for len(got) < wantLen*channels {
+					n, err := p.Read(ratio, buf)
+					if err != nil {
+						t.Fatalf("Read failed: %v", err)
+					}
+					if n == 0 {
+						t.Fatalf("looping player ran out of data after %d frames", len(got)/channels)
+					}
+					got = append(got, buf[:n*channels]...)
+				}
+
+				for i := 0; i < wantLen*channels; i++ {
+					if got[i] != want[i] {
+						t.Fatalf("sample %d (frame %d) differs: looped %g, unrolled %g", i, i/channels, got[i], want[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestLoopPlayerClearAndSeek checks playback ends after ClearLoop and restarts after SeekFrame.
+func TestLoopPlayerClearAndSeek(t *testing.T) {
+	data := make([]float32, 4000)
+	genWindowedSinesGo(1, []float64{0.05}, 0.9, data)
+
+	p, err := NewLoopPlayer(data, 1, Linear)
+	if err != nil {
+		t.Fatalf("NewLoopPlayer failed: %v", err)
+	}
+	if err := p.SetLoop(100, 50); err == nil {
+		t.Error("expected error for inverted loop")
+	}
+	if err := p.SetLoop(0, 5000); err == nil {
+		t.Error("expected error for loop past the end")
+	}
+	if err := p.SetLoop(1000, 2000); err != nil {
+		t.Fatalf("SetLoop failed: %v", err)
+	}
+
+	buf := make([]float32, 256)
+	for i := 0; i < 40; i++ { // Well past the data length: must keep looping
+		if n, err := p.Read(1.0, buf); err != nil || n == 0 {
+			t.Fatalf("looping Read: n=%d err=%v", n, err)
+		}
+	}
+
+	p.ClearLoop()
+	total := int64(0)
+	for i := 0; i < 100; i++ {
+		n, err := p.Read(1.0, buf)
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+		total += n
+	}
+	if total == 0 || total > 4000 {
+		t.Errorf("after ClearLoop played %d more frames, want (0, 4000]", total)
+	}
+
+	if err := p.SeekFrame(3900); err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	if p.InputPosition() != 3900 {
+		t.Errorf("InputPosition %d, want 3900", p.InputPosition())
+	}
+	n, err := p.Read(1.0, buf)
+	if err != nil || n == 0 {
+		t.Errorf("Read after SeekFrame: n=%d err=%v", n, err)
+	}
+	if err := p.SeekFrame(-1); err == nil {
+		t.Error("expected error seeking before the start")
+	}
+}
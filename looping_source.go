@@ -0,0 +1,296 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// LoopingSource is a background stream for the mixer (music on hold, ambience)
+// that owns its audio, read position, loop policy and gain. It replaces the
+// lastPosStream2/lastSample2MixedPos pointers of the Mix functions: the position
+// is simply the next sample to play, and it can be persisted across restarts with
+// encoding/json or encoding/gob.
+//
+// Only the playback state is serialized, not the audio. To restore, create the
+// source again from the same audio and unmarshal the saved state into it.
+//
+// NOTE: A LoopingSource is NOT goroutine-safe.
+type LoopingSource struct {
+	samples []int16
+	pos     int // Next sample to play
+	play    int // Current play, counted from 0, for LoopCount
+	policy  LoopPolicy
+	gain    float32
+}
+
+// loopingSourceState is the serialized form of a LoopingSource.
+type loopingSourceState struct {
+	Position int      `json:"position"`
+	Frames   int      `json:"frames"` // Length of the audio, checked on restore
+	Loop     bool     `json:"loop"`   // LoopMode is LoopForever; kept for states saved before LoopMode
+	Gain     float32  `json:"gain"`
+	LoopMode LoopMode `json:"loopMode,omitempty"`
+	Count    int      `json:"loopCount,omitempty"`
+	Play     int      `json:"play,omitempty"`
+}
+
+// NewLoopingSourceUlaw creates a looping source from 8-bit u-Law audio, as used
+// by MixUlaw8kHz.
+func NewLoopingSourceUlaw(data []byte) *LoopingSource {
+	samples := make([]int16, len(data))
+	for i, b := range data {
+		samples[i] = ulawToLinearGo(b)
+	}
+	return &LoopingSource{samples: samples, gain: 1.0}
+}
+
+// NewLoopingSourceS16LE creates a looping source from S16LE PCM audio, as used by
+// MixResampleUlawWithRatio. It must be at the input rate of the mix.
+func NewLoopingSourceS16LE(data []byte) (*LoopingSource, error) {
+	return newLoopingSourceS16(data, binary.LittleEndian)
+}
+
+// NewLoopingSourceS16BE is NewLoopingSourceS16LE for big-endian PCM audio, e.g.
+// hold music read from an AIFF file.
+func NewLoopingSourceS16BE(data []byte) (*LoopingSource, error) {
+	return newLoopingSourceS16(data, binary.BigEndian)
+}
+
+func newLoopingSourceS16(data []byte, order binary.ByteOrder) (*LoopingSource, error) {
+	if len(data)%mixBytesPerInputFrame != 0 {
+		return nil, fmt.Errorf("input stream size (%d) not multiple of frame size (%d)", len(data), mixBytesPerInputFrame)
+	}
+	samples := make([]int16, len(data)/mixBytesPerInputFrame)
+	for i := range samples {
+		samples[i] = int16(order.Uint16(data[i*mixBytesPerInputFrame:]))
+	}
+	return &LoopingSource{samples: samples, gain: 1.0}, nil
+}
+
+// Frames returns the length of the audio in samples.
+func (s *LoopingSource) Frames() int {
+	return len(s.samples)
+}
+
+// Position returns the next sample to play.
+func (s *LoopingSource) Position() int {
+	return s.pos
+}
+
+// Seek sets the next sample to play, in [0, Frames()], within the current play.
+func (s *LoopingSource) Seek(pos int) error {
+	if pos < 0 || pos > len(s.samples) {
+		return fmt.Errorf("position %d out of range [0, %d]", pos, len(s.samples))
+	}
+	s.pos = pos
+	return nil
+}
+
+// SetLoop enables or disables looping: it sets LoopForever or LoopOnce. A source
+// that does not loop plays once and then outputs silence.
+func (s *LoopingSource) SetLoop(loop bool) {
+	if loop {
+		s.policy = LoopPolicy{}
+	} else {
+		s.policy = LoopPolicy{Mode: LoopOnce}
+	}
+}
+
+// Loop reports whether the source loops forever.
+func (s *LoopingSource) Loop() bool {
+	return s.policy.Mode == LoopForever
+}
+
+// SetLoopPolicy sets how the source repeats, e.g. LoopN(3). The position and the
+// current play are kept.
+func (s *LoopingSource) SetLoopPolicy(p LoopPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	s.policy = p
+	return nil
+}
+
+// LoopPolicy returns how the source repeats.
+func (s *LoopingSource) LoopPolicy() LoopPolicy {
+	return s.policy
+}
+
+// SetGain sets the linear gain applied to the source before mixing.
+func (s *LoopingSource) SetGain(gain float32) {
+	s.gain = gain
+}
+
+// Gain returns the linear gain of the source.
+func (s *LoopingSource) Gain() float32 {
+	return s.gain
+}
+
+// Done reports whether a source that does not loop forever has played to the
+// end (a HoldLast source then repeats its last sample).
+func (s *LoopingSource) Done() bool {
+	return s.pos >= len(s.samples) && !s.repeats()
+}
+
+// repeats reports whether the policy starts another play at the end.
+func (s *LoopingSource) repeats() bool {
+	switch s.policy.Mode {
+	case LoopForever:
+		return true
+	case LoopCount:
+		return s.play+1 < s.policy.Count
+	default:
+		return false
+	}
+}
+
+// rewind starts the next play, if the policy has one.
+func (s *LoopingSource) rewind() bool {
+	if !s.repeats() {
+		return false
+	}
+	if s.policy.Mode == LoopCount {
+		s.play++
+	}
+	s.pos = 0
+	return true
+}
+
+// next returns the next sample in the int16 range, with the gain applied, and
+// advances the position. An empty or finished source is silence, or its last
+// sample for HoldLast.
+func (s *LoopingSource) next() float32 {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	if s.pos >= len(s.samples) && !s.rewind() {
+		if s.policy.Mode == HoldLast {
+			return float32(s.samples[len(s.samples)-1]) * s.gain
+		}
+		return 0
+	}
+	v := float32(s.samples[s.pos]) * s.gain
+	s.pos++
+	if s.pos >= len(s.samples) {
+		s.rewind()
+	}
+	return v
+}
+
+// read fills dst with the next samples, multiplied by scale.
+func (s *LoopingSource) read(dst []float32, scale float32) {
+	for i := range dst {
+		dst[i] = s.next() * scale
+	}
+}
+
+// MarshalJSON saves the playback state (not the audio).
+func (s *LoopingSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.state())
+}
+
+// UnmarshalJSON restores a state saved by MarshalJSON into a source created from
+// the same audio.
+func (s *LoopingSource) UnmarshalJSON(data []byte) error {
+	var st loopingSourceState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	return s.restore(st)
+}
+
+// GobEncode saves the playback state (not the audio).
+func (s *LoopingSource) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.state()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode restores a state saved by GobEncode into a source created from the
+// same audio.
+func (s *LoopingSource) GobDecode(data []byte) error {
+	var st loopingSourceState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&st); err != nil {
+		return err
+	}
+	return s.restore(st)
+}
+
+func (s *LoopingSource) state() loopingSourceState {
+	return loopingSourceState{
+		Position: s.pos,
+		Frames:   len(s.samples),
+		Loop:     s.policy.Mode == LoopForever,
+		Gain:     s.gain,
+		LoopMode: s.policy.Mode,
+		Count:    s.policy.Count,
+		Play:     s.play,
+	}
+}
+
+func (s *LoopingSource) restore(st loopingSourceState) error {
+	if st.Frames != len(s.samples) {
+		return fmt.Errorf("saved state is for %d frames of audio, source has %d", st.Frames, len(s.samples))
+	}
+	if st.Position < 0 || st.Position > st.Frames {
+		return fmt.Errorf("saved position %d out of range [0, %d]", st.Position, st.Frames)
+	}
+	policy := LoopPolicy{Mode: st.LoopMode, Count: st.Count}
+	if policy.Mode == LoopForever && !st.Loop {
+		policy.Mode = LoopOnce // Saved before LoopMode, by SetLoop(false)
+	}
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("saved state: %w", err)
+	}
+	if st.Play < 0 || (st.Play > 0 && st.Play >= policy.Count) {
+		return fmt.Errorf("saved play %d out of range for %v", st.Play, policy)
+	}
+	s.pos, s.play, s.policy, s.gain = st.Position, st.Play, policy, st.Gain
+	return nil
+}
+
+// MixUlaw8kHzSource is MixUlaw8kHz reading the second stream from a u-Law
+// LoopingSource, which keeps track of its own position.
+func MixUlaw8kHzSource(stream1 []byte, source *LoopingSource, mixFactor float32) ([]byte, error) {
+	if mixFactor < 0.0 || mixFactor > 1.0 {
+		return nil, fmt.Errorf("mixFactor must be between 0.0 and 1.0, got %f", mixFactor)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("source must not be nil")
+	}
+	background := make([]float32, len(stream1))
+	source.read(background, 1.0)
+	return mixUlawBlock(stream1, background, mixFactor), nil
+}
+
+// MixResampleUlawSource is MixResampleUlawWithRatio reading the second stream
+// from an S16LE LoopingSource at the input rate, which keeps track of its own
+// position.
+func MixResampleUlawSource(pcmStream1 []byte, source *LoopingSource, srcRatio float64, mixFactor float32) ([]byte, error) {
+	if len(pcmStream1)%mixBytesPerInputFrame != 0 {
+		return nil, fmt.Errorf("input stream 1 size (%d) not multiple of frame size (%d)", len(pcmStream1), mixBytesPerInputFrame)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("source must not be nil")
+	}
+	background := make([]float32, len(pcmStream1)/mixBytesPerInputFrame)
+	source.read(background, 1.0/32768.0)
+	resultFloat, err := resampleMix(pcmStream1, background, srcRatio, mixFactor)
+	if err != nil {
+		return nil, err
+	}
+	return appendPCMFloatToUlawBytes(make([]byte, 0, len(resultFloat)), resultFloat), nil
+}
@@ -0,0 +1,130 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+)
+
+// TestLoopingSourceMatchesPositionPointer checks the source-based mixers match the
+// pointer-based ones for a first call.
+func TestLoopingSourceMatchesPositionPointer(t *testing.T) {
+	voice := encodeUlawTone(800, 440, 0.3)
+	music := encodeUlawTone(300, 200, 0.4)
+	pos := -1
+	want, err := MixUlaw8kHz(voice, music, &pos, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixUlaw8kHz failed: %v", err)
+	}
+	src := NewLoopingSourceUlaw(music)
+	got, err := MixUlaw8kHzSource(voice, src, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixUlaw8kHzSource failed: %v", err)
+	}
+	if !bytes.Equal(got, want) || src.Position() != pos {
+		t.Errorf("u-law source mix differs (position %d, want %d)", src.Position(), pos)
+	}
+
+	speech := corpus.MustReadFile(corpus.Speech24kS16LE)[:48000]
+	typing := corpus.MustReadFile(corpus.Typing24kS16LE)
+	pos = -1
+	want, err = MixResampleUlawWithRatio(speech, typing, &pos, 1.0/3.0, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixResampleUlawWithRatio failed: %v", err)
+	}
+	pcmSrc, err := NewLoopingSourceS16LE(typing)
+	if err != nil {
+		t.Fatalf("NewLoopingSourceS16LE failed: %v", err)
+	}
+	got, err = MixResampleUlawSource(speech, pcmSrc, 1.0/3.0, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixResampleUlawSource failed: %v", err)
+	}
+	if !bytes.Equal(got, want) || pcmSrc.Position() != pos {
+		t.Errorf("S16LE source mix differs (position %d, want %d)", pcmSrc.Position(), pos)
+	}
+
+	if _, err := NewLoopingSourceS16LE([]byte{1, 2, 3}); err == nil {
+		t.Error("expected error for odd S16LE length")
+	}
+}
+
+func TestLoopingSourcePlayback(t *testing.T) {
+	src := NewLoopingSourceUlaw(constUlaw(3, 1000))
+	level := float32(ulawToLinearGo(linearToUlawGo(1000)))
+	src.SetGain(0.5)
+	buf := make([]float32, 7)
+	src.read(buf, 1.0)
+	for i, v := range buf {
+		if v != level*0.5 {
+			t.Fatalf("looping sample %d is %g, want %g", i, v, level*0.5)
+		}
+	}
+	if src.Position() != 1 {
+		t.Errorf("position %d after 7 samples of 3, want 1", src.Position())
+	}
+
+	src.SetLoop(false)
+	src.read(buf, 1.0)
+	if buf[0] == 0 || buf[1] == 0 || buf[2] != 0 || !src.Done() {
+		t.Errorf("non-looping source should play to the end then stop, got %v", buf)
+	}
+	if err := src.Seek(4); err == nil {
+		t.Error("expected error seeking past the end")
+	}
+}
+
+func TestLoopingSourceSerialization(t *testing.T) {
+	music := encodeUlawTone(500, 200, 0.4)
+	src := NewLoopingSourceUlaw(music)
+	src.SetGain(0.8)
+	if _, err := MixUlaw8kHzSource(make([]byte, 320), src, 0.5); err != nil {
+		t.Fatalf("MixUlaw8kHzSource failed: %v", err)
+	}
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	restored := NewLoopingSourceUlaw(music)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if restored.Position() != 320 || restored.Gain() != 0.8 || !restored.Loop() {
+		t.Errorf("JSON restore gave position %d gain %g loop %v", restored.Position(), restored.Gain(), restored.Loop())
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		t.Fatalf("gob encode failed: %v", err)
+	}
+	fromGob := NewLoopingSourceUlaw(music)
+	if err := gob.NewDecoder(&buf).Decode(fromGob); err != nil {
+		t.Fatalf("gob decode failed: %v", err)
+	}
+
+	// Restored sources continue exactly where the original was
+	voice := encodeUlawTone(400, 440, 0.3)
+	want, _ := MixUlaw8kHzSource(voice, src, 0.5)
+	for name, s := range map[string]*LoopingSource{"json": restored, "gob": fromGob} {
+		got, _ := MixUlaw8kHzSource(voice, s, 0.5)
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: restored source does not continue the original", name)
+		}
+	}
+
+	if err := json.Unmarshal(data, NewLoopingSourceUlaw(music[:100])); err == nil {
+		t.Error("expected error restoring into a source with different audio")
+	}
+}
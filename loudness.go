@@ -0,0 +1,346 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// LoudnessMeasure selects how a Normalizer measures the level of a stream.
+type LoudnessMeasure int
+
+const (
+	LoudnessRMS           LoudnessMeasure = 0 // Plain RMS level, in dBov
+	LoudnessMomentaryLUFS LoudnessMeasure = 1 // EBU R128 momentary loudness (K-weighted, 400 ms), in LUFS
+)
+
+// --- Loudness Constants ---
+const (
+	loudnessWindowMs      = 400.0  // EBU R128 momentary window
+	loudnessHopMs         = 100.0  // Gain target is re-evaluated at this rate
+	loudnessSmoothingMs   = 200.0  // Time constant of the gain smoothing
+	loudnessSilenceGate   = -70.0  // Below this level the gain is held, silence is never boosted
+	loudnessLufsOffset    = -0.691 // ITU-R BS.1770 offset
+	loudnessDefaultMaxGDB = 24.0   // Default maximum gain/attenuation
+)
+
+// Normalizer measures the level of a stream (RMS or EBU R128 momentary loudness)
+// and applies a smoothly varying gain so the stream approaches a target level.
+// It is meant to be run on each source before mixing/resampling, so that e.g. TTS
+// and recorded music end up at comparable levels in MixResampleUlaw* outputs.
+//
+// A Normalizer keeps state across calls so it can be used on a chunked stream.
+// NOTE: A Normalizer is NOT goroutine-safe; use one instance per stream.
+type Normalizer struct {
+	measure    LoudnessMeasure
+	sampleRate float64
+	channels   int
+	target     float64 // Target level (dBov or LUFS depending on measure)
+	maxGainDB  float64 // Absolute limit for the applied gain
+
+	// K-weighting filter (only used for LoudnessMomentaryLUFS)
+	preFilter biquad
+	rlbFilter biquad
+	preState  []biquadState
+	rlbState  []biquadState
+
+	// Sliding window of per-frame power (sum over channels)
+	window    []float64
+	windowPos int
+	windowSum float64
+	filled    int
+
+	hopFrames  int
+	hopLeft    int
+	smoothCoef float64
+
+	level      float64 // Last measured level
+	targetGain float64 // Linear gain we are moving towards
+	gain       float64 // Current linear gain
+}
+
+// NewNormalizer creates a Normalizer for interleaved float32 audio.
+//
+// Args:
+//
+//	measure: LoudnessRMS or LoudnessMomentaryLUFS.
+//	sampleRate: Sample rate of the stream in Hz.
+//	channels: Number of interleaved channels (>= 1).
+//	target: Target level, in dBov for LoudnessRMS or LUFS for LoudnessMomentaryLUFS (e.g. -23).
+//	maxGainDB: Maximum gain (and attenuation) the normalizer will apply, in dB. Use 0 for the default (24 dB).
+//
+// Returns:
+//
+//	A new *Normalizer, or nil and an error if the parameters are invalid.
+func NewNormalizer(measure LoudnessMeasure, sampleRate float64, channels int, target, maxGainDB float64) (*Normalizer, error) {
+	if measure != LoudnessRMS && measure != LoudnessMomentaryLUFS {
+		return nil, fmt.Errorf("unknown loudness measure %d", measure)
+	}
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return nil, fmt.Errorf("sampleRate must be positive, got %f", sampleRate)
+	}
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if target > 0 || math.IsNaN(target) || math.IsInf(target, 0) {
+		return nil, fmt.Errorf("target level must be <= 0, got %f", target)
+	}
+	if maxGainDB < 0 || math.IsNaN(maxGainDB) {
+		return nil, fmt.Errorf("maxGainDB must be >= 0, got %f", maxGainDB)
+	}
+	if maxGainDB == 0 {
+		maxGainDB = loudnessDefaultMaxGDB
+	}
+
+	n := &Normalizer{
+		measure:    measure,
+		sampleRate: sampleRate,
+		channels:   channels,
+		target:     target,
+		maxGainDB:  maxGainDB,
+		window:     make([]float64, maxInt(1, int(math.Round(sampleRate*loudnessWindowMs/1000.0)))),
+		hopFrames:  maxInt(1, int(math.Round(sampleRate*loudnessHopMs/1000.0))),
+		smoothCoef: math.Exp(-1.0 / (sampleRate * loudnessSmoothingMs / 1000.0)),
+		preState:   make([]biquadState, channels),
+		rlbState:   make([]biquadState, channels),
+	}
+	n.preFilter, n.rlbFilter = kWeightingFilters(sampleRate)
+	n.Reset()
+	return n, nil
+}
+
+// Reset clears the measurement window, filter state and gain.
+func (n *Normalizer) Reset() {
+	if n == nil {
+		return
+	}
+	for i := range n.window {
+		n.window[i] = 0
+	}
+	for i := range n.preState {
+		n.preState[i] = biquadState{}
+		n.rlbState[i] = biquadState{}
+	}
+	n.windowPos = 0
+	n.windowSum = 0
+	n.filled = 0
+	n.hopLeft = n.hopFrames
+	n.level = math.Inf(-1)
+	n.targetGain = 1.0
+	n.gain = 1.0
+}
+
+// Level returns the last measured level (dBov or LUFS), or -Inf if nothing was measured yet.
+func (n *Normalizer) Level() float64 { return n.level }
+
+// GainDB returns the gain currently applied, in dB.
+func (n *Normalizer) GainDB() float64 { return 20.0 * math.Log10(n.gain) }
+
+// Process measures and normalizes a block of interleaved samples in place.
+// Trailing samples that do not form a complete frame are left untouched.
+func (n *Normalizer) Process(samples []float32) {
+	frames := len(samples) / n.channels
+	for fr := 0; fr < frames; fr++ {
+		frame := samples[fr*n.channels : (fr+1)*n.channels]
+
+		n.push(n.framePower(frame))
+		n.hopLeft--
+		if n.hopLeft <= 0 {
+			n.hopLeft = n.hopFrames
+			n.updateTarget()
+		}
+
+		n.gain = n.targetGain + (n.gain-n.targetGain)*n.smoothCoef
+		g := float32(n.gain)
+		for ch := range frame {
+			frame[ch] *= g
+		}
+	}
+}
+
+// framePower returns the (optionally K-weighted) power of one frame, summed over channels.
+func (n *Normalizer) framePower(frame []float32) float64 {
+	power := 0.0
+	for ch, v := range frame {
+		x := float64(v)
+		if n.measure == LoudnessMomentaryLUFS {
+			x = n.preFilter.process(&n.preState[ch], x)
+			x = n.rlbFilter.process(&n.rlbState[ch], x)
+		}
+		power += x * x
+	}
+	if n.measure == LoudnessRMS {
+		power /= float64(n.channels) // RMS is averaged over channels, BS.1770 sums them
+	}
+	return power
+}
+
+// push adds one frame power to the sliding window.
+func (n *Normalizer) push(power float64) {
+	n.windowSum += power - n.window[n.windowPos]
+	n.window[n.windowPos] = power
+	n.windowPos++
+	if n.windowPos >= len(n.window) {
+		n.windowPos = 0
+		n.windowSum = 0 // Re-sum periodically to avoid drift from floating point error
+		for _, p := range n.window {
+			n.windowSum += p
+		}
+	}
+	if n.filled < len(n.window) {
+		n.filled++
+	}
+}
+
+// updateTarget recomputes the measured level and the gain we should move towards.
+func (n *Normalizer) updateTarget() {
+	n.level = powerToLevel(n.measure, n.windowSum/float64(len(n.window)))
+	if n.filled < len(n.window) || n.level < loudnessSilenceGate {
+		return // Not enough data yet, or silence: hold the current gain
+	}
+	gainDB := n.target - n.level
+	if gainDB > n.maxGainDB {
+		gainDB = n.maxGainDB
+	} else if gainDB < -n.maxGainDB {
+		gainDB = -n.maxGainDB
+	}
+	n.targetGain = math.Pow(10.0, gainDB/20.0)
+}
+
+// MeasureLoudness returns the level of a whole interleaved block: the RMS level in dBov
+// for LoudnessRMS, or the maximum momentary loudness in LUFS for LoudnessMomentaryLUFS.
+// Returns -Inf for empty or silent input.
+func MeasureLoudness(samples []float32, sampleRate float64, channels int, measure LoudnessMeasure) (float64, error) {
+	n, err := NewNormalizer(measure, sampleRate, channels, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+	frames := len(samples) / channels
+	if frames == 0 {
+		return math.Inf(-1), nil
+	}
+
+	if measure == LoudnessRMS {
+		sum := 0.0
+		for _, v := range samples[:frames*channels] {
+			sum += float64(v) * float64(v)
+		}
+		return powerToLevel(measure, sum/float64(frames*channels)), nil
+	}
+
+	// Momentary loudness: maximum over 400 ms windows evaluated every 100 ms.
+	// Blocks shorter than one window are measured over their own length.
+	maxLevel := math.Inf(-1)
+	for fr := 0; fr < frames; fr++ {
+		n.push(n.framePower(samples[fr*channels : (fr+1)*channels]))
+		n.hopLeft--
+		if (n.hopLeft <= 0 && n.filled == len(n.window)) || (fr == frames-1 && n.filled < len(n.window)) {
+			n.hopLeft = n.hopFrames
+			maxLevel = math.Max(maxLevel, powerToLevel(measure, n.windowSum/float64(n.filled)))
+		} else if n.hopLeft <= 0 {
+			n.hopLeft = n.hopFrames
+		}
+	}
+	return maxLevel, nil
+}
+
+// NormalizeBlock applies a constant gain to a whole interleaved block so that its
+// level (as measured by MeasureLoudness) matches target, limited to +/- maxGainDB
+// (0 selects the default of 24 dB). Silent blocks are left untouched.
+// It returns the applied gain in dB.
+func NormalizeBlock(samples []float32, sampleRate float64, channels int, measure LoudnessMeasure, target, maxGainDB float64) (float64, error) {
+	if maxGainDB == 0 {
+		maxGainDB = loudnessDefaultMaxGDB
+	}
+	if maxGainDB < 0 || math.IsNaN(maxGainDB) {
+		return 0, fmt.Errorf("maxGainDB must be >= 0, got %f", maxGainDB)
+	}
+	level, err := MeasureLoudness(samples, sampleRate, channels, measure)
+	if err != nil {
+		return 0, err
+	}
+	if level < loudnessSilenceGate {
+		return 0, nil
+	}
+	gainDB := math.Max(-maxGainDB, math.Min(maxGainDB, target-level))
+	gain := float32(math.Pow(10.0, gainDB/20.0))
+	for i := range samples {
+		samples[i] *= gain
+	}
+	return gainDB, nil
+}
+
+// NormalizeS16LE runs a mono S16LE PCM byte stream through a Normalizer and returns
+// the normalized S16LE bytes, ready to be passed to the MixResampleUlaw* functions.
+func NormalizeS16LE(pcm []byte, n *Normalizer) ([]byte, error) {
+	if n == nil {
+		return nil, fmt.Errorf("normalizer must not be nil")
+	}
+	if len(pcm)%mixBytesPerInputFrame != 0 {
+		return nil, fmt.Errorf("input stream size (%d) not multiple of frame size (%d)", len(pcm), mixBytesPerInputFrame)
+	}
+
+	samples := make([]float32, len(pcm)/mixBytesPerInputFrame)
+	for i := range samples {
+		s16, err := bytesToS16LEGo(pcm, i*mixBytesPerInputFrame)
+		if err != nil {
+			return nil, fmt.Errorf("error reading input stream at index %d: %w", i*mixBytesPerInputFrame, err)
+		}
+		samples[i] = s16ToFloatGo(s16)
+	}
+	n.Process(samples)
+	return appendPCMFloatToS16LEBytes(make([]byte, 0, len(pcm)), samples), nil
+}
+
+// powerToLevel converts a mean power to dBov (RMS) or LUFS (momentary).
+func powerToLevel(measure LoudnessMeasure, power float64) float64 {
+	if power <= 0 {
+		return math.Inf(-1)
+	}
+	if measure == LoudnessMomentaryLUFS {
+		return loudnessLufsOffset + 10.0*math.Log10(power)
+	}
+	return 10.0 * math.Log10(power)
+}
+
+// kWeightingFilters returns the two ITU-R BS.1770 K-weighting stages (high-shelf
+// "pre-filter" and RLB high-pass) for the given sample rate.
+// Coefficients are derived for any rate, as in libebur128.
+func kWeightingFilters(sampleRate float64) (biquad, biquad) {
+	// Stage 1: high shelf
+	f0 := 1681.974450955533
+	g := 3.999843853973347
+	q := 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / sampleRate)
+	vh := math.Pow(10.0, g/20.0)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1.0 + k/q + k*k
+	pre := biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2.0 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2.0 * (k*k - 1.0) / a0,
+		a2: (1.0 - k/q + k*k) / a0,
+	}
+
+	// Stage 2: RLB high-pass
+	f0 = 38.13547087602444
+	q = 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / sampleRate)
+	a0 = 1.0 + k/q + k*k
+	rlb := biquad{
+		b0: 1.0,
+		b1: -2.0,
+		b2: 1.0,
+		a1: 2.0 * (k*k - 1.0) / a0,
+		a2: (1.0 - k/q + k*k) / a0,
+	}
+	return pre, rlb
+}
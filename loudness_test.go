@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// TestMeasureLoudnessReference checks the measurements against known reference values.
+func TestMeasureLoudnessReference(t *testing.T) {
+	// A full scale 1 kHz sine is -3.01 dBov RMS and -3.01 LUFS (BS.1770 reference).
+	for _, rate := range []float64{8000, 16000, 24000, 48000} {
+		sine := genSine(int(rate), 997, rate, 1.0)
+
+		rms, err := MeasureLoudness(sine, rate, 1, LoudnessRMS)
+		if err != nil {
+			t.Fatalf("MeasureLoudness RMS failed: %v", err)
+		}
+		if math.Abs(rms-(-3.01)) > 0.05 {
+			t.Errorf("rate %.0f: RMS %.3f dBov, want -3.01", rate, rms)
+		}
+
+		lufs, err := MeasureLoudness(sine, rate, 1, LoudnessMomentaryLUFS)
+		if err != nil {
+			t.Fatalf("MeasureLoudness LUFS failed: %v", err)
+		}
+		if math.Abs(lufs-(-3.01)) > 0.1 {
+			t.Errorf("rate %.0f: momentary loudness %.3f LUFS, want -3.01", rate, lufs)
+		}
+	}
+
+	level, err := MeasureLoudness(make([]float32, 1000), 8000, 1, LoudnessRMS)
+	if err != nil || !math.IsInf(level, -1) {
+		t.Errorf("silence: got %f (%v), want -Inf", level, err)
+	}
+}
+
+func TestNormalizerValidation(t *testing.T) {
+	if _, err := NewNormalizer(LoudnessMeasure(7), 8000, 1, -20, 0); err == nil {
+		t.Error("expected error for unknown measure")
+	}
+	if _, err := NewNormalizer(LoudnessRMS, 0, 1, -20, 0); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	if _, err := NewNormalizer(LoudnessRMS, 8000, 0, -20, 0); err == nil {
+		t.Error("expected error for zero channels")
+	}
+	if _, err := NewNormalizer(LoudnessRMS, 8000, 1, 6, 0); err == nil {
+		t.Error("expected error for positive target")
+	}
+	if _, err := NewNormalizer(LoudnessRMS, 8000, 1, -20, -1); err == nil {
+		t.Error("expected error for negative maxGainDB")
+	}
+}
+
+// TestNormalizerConverges checks that quiet and loud streams end up at the target level.
+func TestNormalizerConverges(t *testing.T) {
+	const rate = 16000.0
+	for _, measure := range []LoudnessMeasure{LoudnessRMS, LoudnessMomentaryLUFS} {
+		for _, amplitude := range []float64{0.02, 0.9} {
+			n, err := NewNormalizer(measure, rate, 1, -20, 0)
+			if err != nil {
+				t.Fatalf("NewNormalizer failed: %v", err)
+			}
+			sine := genSine(int(rate)*4, 440, rate, amplitude)
+
+			// Feed in small chunks, as a streaming caller would
+			for pos := 0; pos < len(sine); pos += 320 {
+				n.Process(sine[pos:minInt(pos+320, len(sine))])
+			}
+
+			tail := sine[len(sine)-int(rate):]
+			level, _ := MeasureLoudness(tail, rate, 1, measure)
+			if math.Abs(level-(-20)) > 0.5 {
+				t.Errorf("measure %d, amplitude %.2f: final level %.2f, want -20", measure, amplitude, level)
+			}
+		}
+	}
+}
+
+// TestNormalizerHoldsOnSilence checks silence is never boosted.
+func TestNormalizerHoldsOnSilence(t *testing.T) {
+	n, err := NewNormalizer(LoudnessRMS, 8000, 1, -20, 0)
+	if err != nil {
+		t.Fatalf("NewNormalizer failed: %v", err)
+	}
+	silence := make([]float32, 16000)
+	silence[100] = 1e-6
+	n.Process(silence)
+	if g := n.GainDB(); math.Abs(g) > 1e-9 {
+		t.Errorf("gain moved on silence: %f dB", g)
+	}
+}
+
+func TestNormalizeBlock(t *testing.T) {
+	sine := genSine(8000, 300, 8000, 0.05)
+	gain, err := NormalizeBlock(sine, 8000, 1, LoudnessRMS, -12, 0)
+	if err != nil {
+		t.Fatalf("NormalizeBlock failed: %v", err)
+	}
+	level, _ := MeasureLoudness(sine, 8000, 1, LoudnessRMS)
+	if math.Abs(level-(-12)) > 0.01 {
+		t.Errorf("normalized level %.3f, want -12 (gain %.2f dB)", level, gain)
+	}
+
+	// Gain is limited by maxGainDB
+	sine = genSine(8000, 300, 8000, 0.001)
+	gain, err = NormalizeBlock(sine, 8000, 1, LoudnessRMS, -12, 6)
+	if err != nil {
+		t.Fatalf("NormalizeBlock failed: %v", err)
+	}
+	if gain != 6 {
+		t.Errorf("gain %.2f dB, want limited to 6 dB", gain)
+	}
+}
+
+func TestNormalizeS16LE(t *testing.T) {
+	sine := genSine(24000, 440, 24000, 0.01)
+	pcm := make([]byte, 0, len(sine)*2)
+	for _, v := range sine {
+		pcm = binary.LittleEndian.AppendUint16(pcm, uint16(int16(v*32767.0)))
+	}
+	if _, err := NormalizeS16LE(pcm[:3], nil); err == nil {
+		t.Error("expected error for nil normalizer")
+	}
+
+	n, _ := NewNormalizer(LoudnessRMS, 24000, 1, -20, 0)
+	if _, err := NormalizeS16LE(pcm[:3], n); err == nil {
+		t.Error("expected error for odd byte count")
+	}
+	out, err := NormalizeS16LE(pcm, n)
+	if err != nil {
+		t.Fatalf("NormalizeS16LE failed: %v", err)
+	}
+	if len(out) != len(pcm) {
+		t.Fatalf("output length %d, want %d", len(out), len(pcm))
+	}
+	if n.GainDB() < 15 {
+		t.Errorf("expected the quiet stream to be boosted, gain %.2f dB", n.GainDB())
+	}
+}
@@ -0,0 +1,2076 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// Low latency filter for Options.LowLatency: a Kaiser windowed sinc spanning
+// 16 input samples on each side (~32 taps at ratios >= 1), against ~38 for
+// SincFastest and ~286 for SincBestQuality, traded for a narrower pass band.
+// h(t) = 0.8 * sinc(0.8 * t) * kaiser(t / 16, beta = 8.0), t in input samples,
+// normalised to unity DC gain.
+// Cutoff           : 0.80 (fraction of Nyquist)
+// Stop band atten. : 82.14 dB
+// -0.1dB band width : 0.3406
+// -3dB band width  : 0.3845
+// half length      : 2048
+// increment        : 128
+
+var lowLatencyCoeffs = coeffData{
+	Increment: 128,
+	Coeffs: []float32{
+		7.99996795055446519029e-01,
+		7.99944678583603629995e-01,
+		7.99788341611703312850e-01,
+		7.99527821467031052372e-01,
+		7.99163180349736324004e-01,
+		7.98694505314842317922e-01,
+		7.98121908247070632747e-01,
+		7.97445525828487045139e-01,
+		7.96665519498979013413e-01,
+		7.95782075409573907976e-01,
+		7.94795404368619173852e-01,
+		7.93705741780838081034e-01,
+		7.92513347579279381350e-01,
+		7.91218506150185296733e-01,
+		7.89821526250802596891e-01,
+		7.88322740920153863797e-01,
+		7.86722507382820124278e-01,
+		7.85021206945731631066e-01,
+		7.83219244888033738761e-01,
+		7.81317050344040420207e-01,
+		7.79315076179321608585e-01,
+		7.77213798859964777321e-01,
+		7.75013718315049282559e-01,
+		7.72715357792378099155e-01,
+		7.70319263707516799222e-01,
+		7.67826005486179963277e-01,
+		7.65236175400025198101e-01,
+		7.62550388395900502481e-01,
+		7.59769281918600825065e-01,
+		7.56893515727193211262e-01,
+		7.53923771704965495211e-01,
+		7.50860753663060043195e-01,
+		7.47705187137857718582e-01,
+		7.44457819182174462824e-01,
+		7.41119418150339326345e-01,
+		7.37690773477215677723e-01,
+		7.34172695451247636633e-01,
+		7.30566014981595124311e-01,
+		7.26871583359430695204e-01,
+		7.23090272013485635405e-01,
+		7.19222972259907833426e-01,
+		7.15270595046522239535e-01,
+		7.11234070691574404854e-01,
+		7.07114348617032262290e-01,
+		7.02912397076537742713e-01,
+		6.98629202878097044227e-01,
+		6.94265771101591488978e-01,
+		6.89823124811199894779e-01,
+		6.85302304762827940721e-01,
+		6.80704369106635120978e-01,
+		6.76030393084747549537e-01,
+		6.71281468724270857784e-01,
+		6.66458704525673240049e-01,
+		6.61563225146663880238e-01,
+		6.56596171081649138124e-01,
+		6.51558698336878405755e-01,
+		6.46451978101376445451e-01,
+		6.41277196413768790784e-01,
+		6.36035553825104016390e-01,
+		6.30728265057786008363e-01,
+		6.25356558660711381314e-01,
+		6.19921676660735054831e-01,
+		6.14424874210563909394e-01,
+		6.08867419233195761308e-01,
+		6.03250592063011348287e-01,
+		5.97575685083634011718e-01,
+		5.91844002362675758455e-01,
+		5.86056859283476283551e-01,
+		5.80215582173957300505e-01,
+		5.74321507932707198130e-01,
+		5.68375983652413485636e-01,
+		5.62380366240757934015e-01,
+		5.56336022038901756304e-01,
+		5.50244326437674291519e-01,
+		5.44106663491581987735e-01,
+		5.37924425530768468562e-01,
+		5.31699012771043144632e-01,
+		5.25431832922092167948e-01,
+		5.19124300794010062887e-01,
+		5.12777837902260613667e-01,
+		5.06393872071197903573e-01,
+		4.99973837036269019851e-01,
+		4.93519172045023601925e-01,
+		4.87031321457053634205e-01,
+		4.80511734342986107649e-01,
+		4.73961864082657557962e-01,
+		4.67383167962589773925e-01,
+		4.60777106772899236464e-01,
+		4.54145144403755363083e-01,
+		4.47488747441524059578e-01,
+		4.40809384764706990723e-01,
+		4.34108527139820288276e-01,
+		4.27387646817316779746e-01,
+		4.20648217127695511763e-01,
+		4.13891712077907258927e-01,
+		4.07119605948192964107e-01,
+		4.00333372889468408484e-01,
+		3.93534486521388615632e-01,
+		3.86724419531204954836e-01,
+		3.79904643273549280647e-01,
+		3.73076627371256186461e-01,
+		3.66241839317354656025e-01,
+		3.59401744078345408706e-01,
+		3.52557803698884786314e-01,
+		3.45711476908000303609e-01,
+		3.38864218726949772975e-01,
+		3.32017480078852345038e-01,
+		3.25172707400201321803e-01,
+		3.18331342254380755818e-01,
+		3.11494820947301520597e-01,
+		3.04664574145274702488e-01,
+		2.97842026495234002414e-01,
+		2.91028596247422444154e-01,
+		2.84225694880659462971e-01,
+		2.77434726730294844987e-01,
+		2.70657088618969532412e-01,
+		2.63894169490280938728e-01,
+		2.57147350045477229852e-01,
+		2.50418002383272997324e-01,
+		2.43707489642906338645e-01,
+		2.37017165650532546373e-01,
+		2.30348374569068148343e-01,
+		2.23702450551579112847e-01,
+		2.17080717398323519429e-01,
+		2.10484488217543508348e-01,
+		2.03915065090107205936e-01,
+		1.97373738738100490409e-01,
+		1.90861788197463244643e-01,
+		1.84380480494762272192e-01,
+		1.77931070328200935382e-01,
+		1.71514799752951335732e-01,
+		1.65132897870902128901e-01,
+		1.58786580524911541401e-01,
+		1.52477049997649632962e-01,
+		1.46205494715120204452e-01,
+		1.39973088954940760864e-01,
+		1.33780992559468264735e-01,
+		1.27630350653847535192e-01,
+		1.21522293369061756829e-01,
+		1.15457935570064729180e-01,
+		1.09438376589066049815e-01,
+		1.03464699964047432412e-01,
+		9.75379731825776336018e-02,
+		9.16592474309993882065e-02,
+		8.58295573490536201078e-02,
+		8.00499207900087217382e-02,
+		7.43213385863602110648e-02,
+		6.86447943211594691082e-02,
+		6.30212541050362839989e-02,
+		5.74516663589702131221e-02,
+		5.19369616028700839538e-02,
+		4.64780522500135365171e-02,
+		4.10758324074020492178e-02,
+		3.57311776820781398789e-02,
+		3.04449449934577932864e-02,
+		2.52179723917205431127e-02,
+		2.00510788823036294193e-02,
+		1.49450642565433205328e-02,
+		9.90070892850009910691e-03,
+		4.91877377801099024968e-03,
+		3.04802794334256672875e-17,
+		-4.85489103991499759000e-03,
+		-9.64519794349077974616e-03,
+		-1.43702394115707079991e-02,
+		-1.90293543694122040122e-02,
+		-2.36219020856068516456e-02,
+		-2.81472622845674556868e-02,
+		-3.26048352525607765262e-02,
+		-3.69940419372694062616e-02,
+		-4.13143240408628403815e-02,
+		-4.55651441065649878692e-02,
+		-4.97459855987058274795e-02,
+		-5.38563529762441789428e-02,
+		-5.78957717597574153534e-02,
+		-6.18637885918875626845e-02,
+		-6.57599712912435990786e-02,
+		-6.95839088997563665062e-02,
+		-7.33352117234853662087e-02,
+		-7.70135113668840853851e-02,
+		-8.06184607605217179449e-02,
+		-8.41497341822757677354e-02,
+		-8.76070272719981640863e-02,
+		-9.09900570396694474695e-02,
+		-9.42985618670527242502e-02,
+		-9.75323015028627116063e-02,
+		-1.00691057051468232930e-01,
+		-1.03774630955146246469e-01,
+		-1.06782846969909139823e-01,
+		-1.09715550134929909487e-01,
+		-1.12572606735589081373e-01,
+		-1.15353904260173195651e-01,
+		-1.18059351350252139667e-01,
+		-1.20688877744769024192e-01,
+		-1.23242434217874632929e-01,
+		-1.25719992510544276154e-01,
+		-1.28121545256011437619e-01,
+		-1.30447105899061693846e-01,
+		-1.32696708609224750530e-01,
+		-1.34870408187910711950e-01,
+		-1.36968279969535061680e-01,
+		-1.38990419716678303974e-01,
+		-1.40936943509331585878e-01,
+		-1.42807987628277954784e-01,
+		-1.44603708432660876815e-01,
+		-1.46324282231797941911e-01,
+		-1.47969905151292130396e-01,
+		-1.49540792993501120423e-01,
+		-1.51037181092423727913e-01,
+		-1.52459324163064846580e-01,
+		-1.53807496145343225447e-01,
+		-1.55081990042603812263e-01,
+		-1.56283117754805161992e-01,
+		-1.57411209906447885354e-01,
+		-1.58466615669311999826e-01,
+		-1.59449702580079205605e-01,
+		-1.60360856352906977484e-01,
+		-1.61200480687034242155e-01,
+		-1.61968997069489417662e-01,
+		-1.62666844572981417194e-01,
+		-1.63294479649051693659e-01,
+		-1.63852375916563486324e-01,
+		-1.64341023945616421242e-01,
+		-1.64760931036961821849e-01,
+		-1.65112620997007852885e-01,
+		-1.65396633908496876186e-01,
+		-1.65613525896941837789e-01,
+		-1.65763868892910809505e-01,
+		-1.65848250390245199881e-01,
+		-1.65867273200305281877e-01,
+		-1.65821555202330467305e-01,
+		-1.65711729090008808019e-01,
+		-1.65538442114347733591e-01,
+		-1.65302355822939117669e-01,
+		-1.65004145795716289369e-01,
+		-1.64644501377295554567e-01,
+		-1.64224125406001369987e-01,
+		-1.63743733939672397870e-01,
+		-1.63204055978345835554e-01,
+		-1.62605833183920522877e-01,
+		-1.61949819596897415241e-01,
+		-1.61236781350300367732e-01,
+		-1.60467496380875679352e-01,
+		-1.59642754137674452997e-01,
+		-1.58763355288120577846e-01,
+		-1.57830111421665558735e-01,
+		-1.56843844751135691462e-01,
+		-1.55805387811874335169e-01,
+		-1.54715583158787167717e-01,
+		-1.53575283061389705752e-01,
+		-1.52385349196968555852e-01,
+		-1.51146652341958093180e-01,
+		-1.49860072061641425023e-01,
+		-1.48526496398278945454e-01,
+		-1.47146821557775059341e-01,
+		-1.45721951594985688061e-01,
+		-1.44252798097777190645e-01,
+		-1.42740279869940422941e-01,
+		-1.41185322613070346476e-01,
+		-1.39588858607516602683e-01,
+		-1.37951826392511689434e-01,
+		-1.36275170445585153134e-01,
+		-1.34559840861371460274e-01,
+		-1.32806793029916603288e-01,
+		-1.31016987314591715208e-01,
+		-1.29191388729721023942e-01,
+		-1.27330966618028174064e-01,
+		-1.25436694328010606148e-01,
+		-1.23509548891345050103e-01,
+		-1.21550510700431005362e-01,
+		-1.19560563186178581141e-01,
+		-1.17540692496142837320e-01,
+		-1.15491887173112220411e-01,
+		-1.13415137834253346161e-01,
+		-1.11311436850916392616e-01,
+		-1.09181778029202786184e-01,
+		-1.07027156291400013521e-01,
+		-1.04848567358383937265e-01,
+		-1.02647007433089201833e-01,
+		-1.00423472885150286138e-01,
+		-9.81789599368107362976e-02,
+		-9.59144643502029409232e-02,
+		-9.36309811160925542506e-02,
+		-9.13295041441890415035e-02,
+		-8.90110259551176175030e-02,
+		-8.66765373741471278901e-02,
+		-8.43270272267722942328e-02,
+		-8.19634820362398069937e-02,
+		-7.95868857231171872302e-02,
+		-7.71982193069906813543e-02,
+		-7.47984606103872379235e-02,
+		-7.23885839650098006359e-02,
+		-6.99695599203749019068e-02,
+		-6.75423549549395291214e-02,
+		-6.51079311898064144737e-02,
+		-6.26672461050906542956e-02,
+		-6.02212522590354557006e-02,
+		-5.77708970099562318934e-02,
+		-5.53171222411001292651e-02,
+		-5.28608640884978067676e-02,
+		-5.04030526718876395464e-02,
+		-4.79446118287940384328e-02,
+		-4.54864588518318901933e-02,
+		-4.30295042293170684755e-02,
+		-4.05746513892539309443e-02,
+		-3.81227964467748051125e-02,
+		-3.56748279551028987799e-02,
+		-3.32316266601068097120e-02,
+		-3.07940652585173592348e-02,
+		-2.83630081598725304615e-02,
+		-2.59393112522565728872e-02,
+		-2.35238216718974060826e-02,
+		-2.11173775766843388468e-02,
+		-1.87208079236684636626e-02,
+		-1.63349322506038049929e-02,
+		-1.39605604615866390333e-02,
+		-1.15984926168522274603e-02,
+		-9.24951872677986369764e-03,
+		-6.91441855016185474153e-03,
+		-4.59396139678694154923e-03,
+		-2.28890593438737426427e-03,
+		-2.84483545275060654116e-17,
+		2.27201958421628224388e-03,
+		4.52642719065741126749e-03,
+		6.76250856140566628666e-03,
+		8.97956097877245819350e-03,
+		1.11768934312155461541e-02,
+		1.33538267745880387394e-02,
+		1.55096938886879875258e-02,
+		1.76438398290718299888e-02,
+		1.97556219740985909705e-02,
+		2.18444101671754660476e-02,
+		2.39095868541738983126e-02,
+		2.59505472159905561935e-02,
+		2.79666992962263674660e-02,
+		2.99574641239601854870e-02,
+		3.19222758315954036057e-02,
+		3.38605817677580384073e-02,
+		3.57718426052296700757e-02,
+		3.76555324438963695544e-02,
+		3.95111389087008518795e-02,
+		4.13381632425835893185e-02,
+		4.31361203943998322563e-02,
+		4.49045391018071879397e-02,
+		4.66429619691119748404e-02,
+		4.83509455400697937821e-02,
+		5.00280603656369088350e-02,
+		5.16738910666680942296e-02,
+		5.32880363915615856696e-02,
+		5.48701092688520242202e-02,
+		5.64197368547536340366e-02,
+		5.79365605756584009511e-02,
+		5.94202361655953095743e-02,
+		6.08704336986594374270e-02,
+		6.22868376164191703270e-02,
+		6.36691467503145563933e-02,
+		6.50170743390590943900e-02,
+		6.63303480410584178628e-02,
+		6.76087099418657827554e-02,
+		6.88519165566892216157e-02,
+		7.00597388279716876136e-02,
+		7.12319621180658030246e-02,
+		7.23683861970250003059e-02,
+		7.34688252255380480626e-02,
+		7.45331077330305818984e-02,
+		7.55610765909638243398e-02,
+		7.65525889813591098321e-02,
+		7.75075163605785238952e-02,
+		7.84257444183955182426e-02,
+		7.93071730323888363090e-02,
+		8.01517162176963310349e-02,
+		8.09593020721638273463e-02,
+		8.17298727169302879902e-02,
+		8.24633842324868776563e-02,
+		8.31598065902533073457e-02,
+		8.38191235797132866425e-02,
+		8.44413327311533817854e-02,
+		8.50264452340522280949e-02,
+		8.55744858511650052790e-02,
+		8.60854928283537740308e-02,
+		8.65595178002119408633e-02,
+		8.69966256915345320655e-02,
+		8.73968946146852915247e-02,
+		8.77604157629168074584e-02,
+		8.80872932996951962803e-02,
+		8.83776442440874221429e-02,
+		8.86315983522671518857e-02,
+		8.88492979951990141663e-02,
+		8.90308980325580229254e-02,
+		8.91765656829472119771e-02,
+		8.92864803904739323670e-02,
+		8.93608336877464853876e-02,
+		8.93998290553573160544e-02,
+		8.94036817779138154316e-02,
+		8.93726187966859680900e-02,
+		8.93068785589344049658e-02,
+		8.92067108639883477039e-02,
+		8.90723767061407795120e-02,
+		8.89041481144297041084e-02,
+		8.87023079893772270488e-02,
+		8.84671499367548491710e-02,
+		8.81989780984491489324e-02,
+		8.78981069804975895243e-02,
+		8.75648612783701402185e-02,
+		8.71995756995687792967e-02,
+		8.68025947836194161633e-02,
+		8.63742727195320469935e-02,
+		8.59149731608045558184e-02,
+		8.54250690380458505002e-02,
+		8.49049423692965349320e-02,
+		8.43549840681217105720e-02,
+		8.37755937495574254381e-02,
+		8.31671795339854885709e-02,
+		8.25301578490184456482e-02,
+		8.18649532294705023494e-02,
+		8.11719981154966907511e-02,
+		8.04517326489793682676e-02,
+		7.97046044682397092362e-02,
+		7.89310685011592738647e-02,
+		7.81315867567872607280e-02,
+		7.73066281155179169104e-02,
+		7.64566681179170010152e-02,
+		7.55821887522794277903e-02,
+		7.46836782409995847409e-02,
+		7.37616308258345065862e-02,
+		7.28165465521428412199e-02,
+		7.18489310521800983445e-02,
+		7.08592953275317544160e-02,
+		6.98481555307668700028e-02,
+		6.88160327463913090718e-02,
+		6.77634527711846040843e-02,
+		6.66909458939995286597e-02,
+		6.55990466751069090101e-02,
+		6.44882937251657073485e-02,
+		6.33592294838994790629e-02,
+		6.22123999985600903417e-02,
+		6.10483547022579939312e-02,
+		5.98676461922393668424e-02,
+		5.86708300081912256796e-02,
+		5.74584644106514580431e-02,
+		5.62311101596036574946e-02,
+		5.49893302933370797803e-02,
+		5.37336899076459942326e-02,
+		5.24647559354510170659e-02,
+		5.11830969269151669909e-02,
+		4.98892828301337673547e-02,
+		4.85838847724745642731e-02,
+		4.72674748426419530367e-02,
+		4.59406258735424941064e-02,
+		4.46039112260247019859e-02,
+		4.32579045735669437400e-02,
+		4.19031796879884149698e-02,
+		4.05403102262523878929e-02,
+		3.91698695184375250689e-02,
+		3.77924303569452599283e-02,
+		3.64085647870149853311e-02,
+		3.50188438986157521415e-02,
+		3.36238376197844079929e-02,
+		3.22241145114779831138e-02,
+		3.08202415640052226897e-02,
+		2.94127839951076261449e-02,
+		2.80023050497507884626e-02,
+		2.65893658016943638944e-02,
+		2.51745249569023804570e-02,
+		2.37583386588558174990e-02,
+		2.23413602958314506941e-02,
+		2.09241403102056952590e-02,
+		1.95072260098425112607e-02,
+		1.80911613816282269840e-02,
+		1.66764869072052838994e-02,
+		1.52637393809647789467e-02,
+		1.38534517303541323402e-02,
+		1.24461528385510205447e-02,
+		1.10423673695601506917e-02,
+		9.64261559578388251224e-03,
+		8.24741322811642016410e-03,
+		6.85727124861482996432e-03,
+		5.47269574579179428342e-03,
+		4.09418775258102093928e-03,
+		2.72224308702021487727e-03,
+		1.35735219569669651567e-03,
+		2.53241948681298670297e-17,
+		-1.34933425477486764604e-03,
+		-2.69017714743293194155e-03,
+		-4.02206122595354879229e-03,
+		-5.34452514959222416829e-03,
+		-6.65711382797526830118e-03,
+		-7.95937855714770776949e-03,
+		-9.25087715254057849079e-03,
+		-1.05311740788244668360e-02,
+		-1.17998405766139898704e-02,
+		-1.30564547859942960140e-02,
+		-1.43006018668391902959e-02,
+		-1.55318741158889696286e-02,
+		-1.67498710805653629186e-02,
+		-1.79541996694941342894e-02,
+		-1.91444742597103451098e-02,
+		-2.03203168005252760020e-02,
+		-2.14813569140303202432e-02,
+		-2.26272319922176945450e-02,
+		-2.37575872907000683332e-02,
+		-2.48720760190079201446e-02,
+		-2.59703594274510937701e-02,
+		-2.70521068905265997528e-02,
+		-2.81169959868592285457e-02,
+		-2.91647125756632971672e-02,
+		-3.01949508697127375068e-02,
+		-3.12074135048098152967e-02,
+		-3.22018116057435563326e-02,
+		-3.31778648487316904503e-02,
+		-3.41353015203378490350e-02,
+		-3.50738585728614174486e-02,
+		-3.59932816761952059648e-02,
+		-3.68933252661492738778e-02,
+		-3.77737525892405737182e-02,
+		-3.86343357439481588367e-02,
+		-3.94748557184361331673e-02,
+		-4.02951024247487354923e-02,
+		-4.10948747294785365902e-02,
+		-4.18739804809181229150e-02,
+		-4.26322365326988889089e-02,
+		-4.33694687639259932044e-02,
+		-4.40855120958202478798e-02,
+		-4.47802105048763499084e-02,
+		-4.54534170325496950094e-02,
+		-4.61049937914864635391e-02,
+		-4.67348119683093643495e-02,
+		-4.73427518229767793634e-02,
+		-4.79287026847311434241e-02,
+		-4.84925629446542882928e-02,
+		-4.90342400448513515698e-02,
+		-4.95536504642803757315e-02,
+		-5.00507197012525287261e-02,
+		-5.05253822526238807744e-02,
+		-5.09775815897025239010e-02,
+		-5.14072701308977766943e-02,
+		-5.18144092111360379804e-02,
+		-5.21989690480710866183e-02,
+		-5.25609287051174087213e-02,
+		-5.29002760513358205596e-02,
+		-5.32170077182014047978e-02,
+		-5.35111290532863381753e-02,
+		-5.37826540708885927899e-02,
+		-5.40316053996413026006e-02,
+		-5.42580142271368373597e-02,
+		-5.44619202416005906975e-02,
+		-5.46433715706514319677e-02,
+		-5.48024247171862363692e-02,
+		-5.49391444924256927562e-02,
+		-5.50536039461620094193e-02,
+		-5.51458842942472507453e-02,
+		-5.52160748433637854626e-02,
+		-5.52642729131181884017e-02,
+		-5.52905837555018389584e-02,
+		-5.52951204717598773786e-02,
+		-5.52780039267147804694e-02,
+		-5.52393626605875362467e-02,
+		-5.51793327983627901467e-02,
+		-5.50980579567445991063e-02,
+		-5.49956891487484861303e-02,
+		-5.48723846859793112918e-02,
+		-5.47283100786412970984e-02,
+		-5.45636379333305360229e-02,
+		-5.43785478486593712444e-02,
+		-5.41732263087617640918e-02,
+		-5.39478665747319952040e-02,
+		-5.37026685740461043328e-02,
+		-5.34378387880199631765e-02,
+		-5.31535901373541078141e-02,
+		-5.28501418658197039124e-02,
+		-5.25277194221384011175e-02,
+		-5.21865543401093950893e-02,
+		-5.18268841170380495376e-02,
+		-5.14489520905209510304e-02,
+		-5.10530073136415130097e-02,
+		-5.06393044286319315983e-02,
+		-5.02081035390565047494e-02,
+		-4.97596700805730401962e-02,
+		-4.92942746903269890524e-02,
+		-4.88121930750367305496e-02,
+		-4.83137058778240757739e-02,
+		-4.77990985438496371329e-02,
+		-4.72686611848071799868e-02,
+		-4.67226884423369223653e-02,
+		-4.61614793504129261592e-02,
+		-4.55853371967633452799e-02,
+		-4.49945693833803672668e-02,
+		-4.43894872861776701445e-02,
+		-4.37704061138538061404e-02,
+		-4.31376447660178630183e-02,
+		-4.24915256906362379108e-02,
+		-4.18323747408579152407e-02,
+		-4.11605210312756958357e-02,
+		-4.04762967936816708825e-02,
+		-3.97800372323735135227e-02,
+		-3.90720803790702286840e-02,
+		-3.83527669474936772076e-02,
+		-3.76224401876734948491e-02,
+		-3.68814457400330128478e-02,
+		-3.61301314893120173455e-02,
+		-3.53688474183834131170e-02,
+		-3.45979454620210860605e-02,
+		-3.38177793606730045517e-02,
+		-3.30287045142987761848e-02,
+		-3.22310778363237224187e-02,
+		-3.14252576077671888499e-02,
+		-3.06116033315997349007e-02,
+		-2.97904755873827364887e-02,
+		-2.89622358862461938556e-02,
+		-2.81272465262579797474e-02,
+		-2.72858704482371490496e-02,
+		-2.64384710920668071499e-02,
+		-2.55854122535561397112e-02,
+		-2.47270579419062962179e-02,
+		-2.38637722378309512228e-02,
+		-2.29959191523820827285e-02,
+		-2.21238624865325583801e-02,
+		-2.12479656915658121630e-02,
+		-2.03685917303217563223e-02,
+		-1.94861029393476846427e-02,
+		-1.86008608920039779366e-02,
+		-1.77132262625708351844e-02,
+		-1.68235586914047101359e-02,
+		-1.59322166511907427344e-02,
+		-1.50395573143361094975e-02,
+		-1.41459364215518797886e-02,
+		-1.32517081516659411544e-02,
+		-1.23572249927115639795e-02,
+		-1.14628376143367065321e-02,
+		-1.05688947415735669949e-02,
+		-9.67574303001255027479e-03,
+		-8.78372694242148324695e-03,
+		-7.89318862684922246953e-03,
+		-7.00446779625485838744e-03,
+		-6.11790160970070683033e-03,
+		-5.23382455514622823428e-03,
+		-4.35256833388228316778e-03,
+		-3.47446174663967139937e-03,
+		-2.59983058140966089011e-03,
+		-1.72899750301039019720e-03,
+		-8.62281944432857446782e-04,
+		-2.14545555095760402988e-17,
+		8.57535679628504930727e-04,
+		1.71001599057495783963e-03,
+		2.55713547695538868931e-03,
+		3.39859243015406924104e-03,
+		4.23408898613222665908e-03,
+		5.06333122074421795172e-03,
+		5.88602924303160707914e-03,
+		6.70189728647305974635e-03,
+		7.51065379816047602735e-03,
+		8.31202152587999856803e-03,
+		9.10572760307538746349e-03,
+		9.89150363166721446895e-03,
+		1.06690857627118745876e-02,
+		1.14382147748761237027e-02,
+		1.21986361507078842209e-02,
+		1.29501001506879213215e-02,
+		1.36923618850402235153e-02,
+		1.44251813832882800487e-02,
+		1.51483236615390353536e-02,
+		1.58615587874807867363e-02,
+		1.65646619430816627316e-02,
+		1.72574134849742787112e-02,
+		1.79395990025167720305e-02,
+		1.86110093735172288376e-02,
+		1.92714408176128740302e-02,
+		1.99206949472949187974e-02,
+		2.05585788165698959973e-02,
+		2.11849049672522549148e-02,
+		2.17994914728802113846e-02,
+		2.24021619802505962682e-02,
+		2.29927457485690316108e-02,
+		2.35710776862101574480e-02,
+		2.41369983850886100918e-02,
+		2.46903541526361236524e-02,
+		2.52309970413861286986e-02,
+		2.57587848761671213416e-02,
+		2.62735812789029465897e-02,
+		2.67752556910267058843e-02,
+		2.72636833935077718682e-02,
+		2.77387455244977147728e-02,
+		2.82003290946009552154e-02,
+		2.86483269997741506196e-02,
+		2.90826380318629645894e-02,
+		2.95031668867831754766e-02,
+		2.99098241703539650949e-02,
+		3.03025264017955098250e-02,
+		3.06811960148977609653e-02,
+		3.10457613568744100752e-02,
+		3.13961566849130582768e-02,
+		3.17323221604339858470e-02,
+		3.20542038410727980757e-02,
+		3.23617536703996108716e-02,
+		3.26549294653920296749e-02,
+		3.29336949016767466247e-02,
+		3.31980194965573599530e-02,
+		3.34478785898464359150e-02,
+		3.36832533225193617166e-02,
+		3.39041306132106534665e-02,
+		3.41105031325718011104e-02,
+		3.43023692755115156006e-02,
+		3.44797331313407007247e-02,
+		3.46426044518433201569e-02,
+		3.47909986172969115659e-02,
+		3.49249366004661665475e-02,
+		3.50444449285933767868e-02,
+		3.51495556434120379197e-02,
+		3.52403062592074878268e-02,
+		3.53167397189526124923e-02,
+		3.53789043485440504733e-02,
+		3.54268538091675744273e-02,
+		3.54606470478201554752e-02,
+		3.54803482460171182300e-02,
+		3.54860267667149661963e-02,
+		3.54777570994781535352e-02,
+		3.54556188039209380092e-02,
+		3.54196964514556511539e-02,
+		3.53700795653779653804e-02,
+		3.53068625593213128444e-02,
+		3.52301446741136864427e-02,
+		3.51400299130682700066e-02,
+		3.50366269757425158327e-02,
+		3.49200491901987958299e-02,
+		3.47904144438009252371e-02,
+		3.46478451125812464406e-02,
+		3.44924679892133906334e-02,
+		3.43244142096255505647e-02,
+		3.41438191782910335781e-02,
+		3.39508224922309212457e-02,
+		3.37455678637662778718e-02,
+		3.35282030420561844020e-02,
+		3.32988797334585126531e-02,
+		3.30577535207512984705e-02,
+		3.28049837812515732161e-02,
+		3.25407336038697481140e-02,
+		3.22651697051384994674e-02,
+		3.19784623442523271986e-02,
+		3.16807852371588857809e-02,
+		3.13723154697383849188e-02,
+		3.10532334101117357683e-02,
+		3.07237226201151174265e-02,
+		3.03839697659807964070e-02,
+		3.00341645282634073233e-02,
+		2.96744995110506011582e-02,
+		2.93051701504980083302e-02,
+		2.89263746227276692669e-02,
+		2.85383137511298033695e-02,
+		2.81411909131073466328e-02,
+		2.77352119463025216517e-02,
+		2.73205850543461088720e-02,
+		2.68975207121679334155e-02,
+		2.64662315709086638782e-02,
+		2.60269323624730819500e-02,
+		2.55798398037624628854e-02,
+		2.51251725006283177510e-02,
+		2.46631508515845303264e-02,
+		2.41939969513176068860e-02,
+		2.37179344940357389460e-02,
+		2.32351886766932053119e-02,
+		2.27459861021309141249e-02,
+		2.22505546821718525052e-02,
+		2.17491235407075850228e-02,
+		2.12419229168179721223e-02,
+		2.07291840679584872531e-02,
+		2.02111391732553852263e-02,
+		1.96880212369466012223e-02,
+		1.91600639920034322705e-02,
+		1.86275018039732502551e-02,
+		1.80905695750778680586e-02,
+		1.75495026486055943904e-02,
+		1.70045367136324419854e-02,
+		1.64559077101090536766e-02,
+		1.59038517343490747036e-02,
+		1.53486049449535633810e-02,
+		1.47904034692078693164e-02,
+		1.42294833099840048463e-02,
+		1.36660802531841929497e-02,
+		1.31004297757587821055e-02,
+		1.25327669543318139661e-02,
+		1.19633263744685219843e-02,
+		1.13923420406151654910e-02,
+		1.08200472867464887045e-02,
+		1.02466746877502099672e-02,
+		9.67245597157999868754e-03,
+		9.09762193221021545075e-03,
+		8.52240234341931218731e-03,
+		7.94702587343446009704e-03,
+		7.37172000046662154182e-03,
+		6.79671092916280493446e-03,
+		6.22222350800704510076e-03,
+		5.64848114769543260816e-03,
+		5.07570574051417148370e-03,
+		4.50411758074688046288e-03,
+		3.93393528613707188502e-03,
+		3.36537572043268734187e-03,
+		2.79865391703642369878e-03,
+		2.23398300378768819435e-03,
+		1.67157412889978006232e-03,
+		1.11163638807516146345e-03,
+		5.54376752823050296740e-04,
+		1.72413837176082753999e-17,
+		-5.51291357403423673902e-04,
+		-1.09929713820969146912e-03,
+		-1.64381955971455674323e-03,
+		-2.18466330303967440005e-03,
+		-2.72163557716053727126e-03,
+		-3.25454618158832649499e-03,
+		-3.78320756768960129307e-03,
+		-4.30743489862699200504e-03,
+		-4.82704610790135515280e-03,
+		-5.34186195648313905116e-03,
+		-5.85170608851465819805e-03,
+		-6.35640508556911069254e-03,
+		-6.85578851945400913337e-03,
+		-7.34968900354279405795e-03,
+		-7.83794224262419382543e-03,
+		-8.32038708125598484855e-03,
+		-8.79686555061128319655e-03,
+		-9.26722291380860201115e-03,
+		-9.73130770971249690893e-03,
+		-1.01889717951986896738e-02,
+		-1.06400703858713814587e-02,
+		-1.10844620952271939729e-02,
+		-1.15220089722576895380e-02,
+		-1.19525765374821919124e-02,
+		-1.23760338174069180195e-02,
+		-1.27922533774034660747e-02,
+		-1.32011113530012616502e-02,
+		-1.36024874795923796100e-02,
+		-1.39962651205418307787e-02,
+		-1.43823312937033272185e-02,
+		-1.47605766963362985966e-02,
+		-1.51308957284236895308e-02,
+		-1.54931865143892559533e-02,
+		-1.58473509232137863001e-02,
+		-1.61932945869517029569e-02,
+		-1.65309269176474107899e-02,
+		-1.68601611226544406685e-02,
+		-1.71809142183590342345e-02,
+		-1.74931070423108257772e-02,
+		-1.77966642637651731307e-02,
+		-1.80915143926399547836e-02,
+		-1.83775897868925230938e-02,
+		-1.86548266583219796155e-02,
+		-1.89231650768022924280e-02,
+		-1.91825489729538257999e-02,
+		-1.94329261392593294344e-02,
+		-1.96742482296325717217e-02,
+		-1.99064707574488387876e-02,
+		-2.01295530920441168021e-02,
+		-2.03434584536950936740e-02,
+		-2.05481539070876718933e-02,
+		-2.07436103532858823195e-02,
+		-2.09298025202124225730e-02,
+		-2.11067089516514747038e-02,
+		-2.12743119947872219577e-02,
+		-2.14325977862901036197e-02,
+		-2.15815562369640126517e-02,
+		-2.17211810149690077998e-02,
+		-2.18514695276326477436e-02,
+		-2.19724229018658893942e-02,
+		-2.20840459631978791588e-02,
+		-2.21863472134458811214e-02,
+		-2.22793388070365479259e-02,
+		-2.23630365259949066836e-02,
+		-2.24374597536185285684e-02,
+		-2.25026314468539725966e-02,
+		-2.25585781073941414598e-02,
+		-2.26053297515143442054e-02,
+		-2.26429198786660852705e-02,
+		-2.26713854388482620450e-02,
+		-2.26907667987744469940e-02,
+		-2.27011077068571265336e-02,
+		-2.27024552570290090447e-02,
+		-2.26948598514217086963e-02,
+		-2.26783751619237250452e-02,
+		-2.26530580906383130491e-02,
+		-2.26189687292635520366e-02,
+		-2.25761703174165648256e-02,
+		-2.25247291999242024718e-02,
+		-2.24647147831028987097e-02,
+		-2.23961994900507971318e-02,
+		-2.23192587149754033404e-02,
+		-2.22339707765801079808e-02,
+		-2.21404168705336482081e-02,
+		-2.20386810210463184034e-02,
+		-2.19288500315772960647e-02,
+		-2.18110134346977506403e-02,
+		-2.16852634411339173504e-02,
+		-2.15516948880159174562e-02,
+		-2.14104051863563474289e-02,
+		-2.12614942677849839969e-02,
+		-2.11050645305643214122e-02,
+		-2.09412207849117119884e-02,
+		-2.07700701976543319893e-02,
+		-2.05917222362419355397e-02,
+		-2.04062886121443888554e-02,
+		-2.02138832236595268610e-02,
+		-2.00146220981576132558e-02,
+		-1.98086233337891222395e-02,
+		-1.95960070406814013122e-02,
+		-1.93768952816516827620e-02,
+		-1.91514120124625687736e-02,
+		-1.89196830216456085560e-02,
+		-1.86818358699218228791e-02,
+		-1.84379998292430943851e-02,
+		-1.81883058214831992705e-02,
+		-1.79328863568040883236e-02,
+		-1.76718754717240141006e-02,
+		-1.74054086669147116406e-02,
+		-1.71336228447537021469e-02,
+		-1.68566562466582782476e-02,
+		-1.65746483902282464029e-02,
+		-1.62877400062226736221e-02,
+		-1.59960729753983385559e-02,
+		-1.56997902652352555597e-02,
+		-1.53990358665757071793e-02,
+		-1.50939547302030002002e-02,
+		-1.47846927033854630418e-02,
+		-1.44713964664121563786e-02,
+		-1.41542134691454140477e-02,
+		-1.38332918676160425420e-02,
+		-1.35087804606868339130e-02,
+		-1.31808286268086296417e-02,
+		-1.28495862608957057277e-02,
+		-1.25152037113432073462e-02,
+		-1.21778317172135715912e-02,
+		-1.18376213456148540043e-02,
+		-1.14947239292953994061e-02,
+		-1.11492910044800556252e-02,
+		-1.08014742489702334632e-02,
+		-1.04514254205323800506e-02,
+		-1.00992962955986486512e-02,
+		-9.74523860830099966646e-03,
+		-9.38940398986407541437e-03,
+		-9.03194390837719872367e-03,
+		-8.67300960896941024181e-03,
+		-8.31275205440869420503e-03,
+		-7.95132186614733865726e-03,
+		-7.58886926583521130574e-03,
+		-7.22554401732119870594e-03,
+		-6.86149536916476343595e-03,
+		-6.49687199767724418709e-03,
+		-6.13182195051346565218e-03,
+		-5.76649259083363181777e-03,
+		-5.40103054205420384654e-03,
+		-5.03558163320813687719e-03,
+		-4.67029084493232170966e-03,
+		-4.30530225610099240990e-03,
+		-3.94075899112355122061e-03,
+		-3.57680316792350879232e-03,
+		-3.21357584661711255178e-03,
+		-2.85121697890817040258e-03,
+		-2.48986535821454739448e-03,
+		-2.12965857054514410077e-03,
+		-1.77073294613992824470e-03,
+		-1.41322351189100974163e-03,
+		-1.05726394455869744313e-03,
+		-7.02986524796660617459e-04,
+		-3.50522092001563949012e-04,
+		-1.30794523894207166489e-17,
+		3.48451926413525213139e-04,
+		6.94707434074576767047e-04,
+		1.03864188317071676725e-03,
+		1.38013228807448446595e-03,
+		1.71905735727643713129e-03,
+		2.05529753240681371459e-03,
+		2.38873502633468260195e-03,
+		2.71925386033516772524e-03,
+		3.04673990031358856920e-03,
+		3.37108089207792610900e-03,
+		3.69216649565033434160e-03,
+		4.00988831860872672191e-03,
+		4.32413994845122235527e-03,
+		4.63481698397454327382e-03,
+		4.94181706565996817226e-03,
+		5.24503990505975539044e-03,
+		5.54438731317734214560e-03,
+		5.83976322783636044023e-03,
+		6.13107374003136681995e-03,
+		6.41822711925737598299e-03,
+		6.70113383781131746048e-03,
+		6.97970659406296420335e-03,
+		7.25386033469132326251e-03,
+		7.52351227588223675502e-03,
+		7.78858192348588860426e-03,
+		8.04899109213081005720e-03,
+		8.30466392329221557433e-03,
+		8.55552690231473414451e-03,
+		8.80150887438642046123e-03,
+		9.04254105946528108362e-03,
+		9.27855706615671876891e-03,
+		9.50949290454306157827e-03,
+		9.73528699796534502386e-03,
+		9.95588019375862748328e-03,
+		1.01712157729426308506e-02,
+		1.03812394588689849151e-02,
+		1.05858994248280609274e-02,
+		1.07851463006179426607e-02,
+		1.09789331780784961412e-02,
+		1.11672156155947794459e-02,
+		1.13499516415728873148e-02,
+		1.15271017568934156655e-02,
+		1.16986289363469435354e-02,
+		1.18644986290565943726e-02,
+		1.20246787578938956614e-02,
+		1.21791397178929935996e-02,
+		1.23278543736701114153e-02,
+		1.24707980558552006256e-02,
+		1.26079485565417960535e-02,
+		1.27392861237639928168e-02,
+		1.28647934550070175419e-02,
+		1.29844556897602356887e-02,
+		1.30982604011211246214e-02,
+		1.32061975864587091006e-02,
+		1.33082596571457174167e-02,
+		1.34044414273693495571e-02,
+		1.34947401020298358293e-02,
+		1.35791552637376505136e-02,
+		1.36576888589192597945e-02,
+		1.37303451830425773256e-02,
+		1.37971308649729139384e-02,
+		1.38580548504710840407e-02,
+		1.39131283848451491048e-02,
+		1.39623649947677331423e-02,
+		1.40057804692712856848e-02,
+		1.40433928399335914566e-02,
+		1.40752223602664764540e-02,
+		1.41012914843206393217e-02,
+		1.41216248445197711109e-02,
+		1.41362492287377388561e-02,
+		1.41451935566322337112e-02,
+		1.41484888552491604174e-02,
+		1.41461682339118089563e-02,
+		1.41382668584091909791e-02,
+		1.41248219244983121839e-02,
+		1.41058726307350321139e-02,
+		1.40814601506485809201e-02,
+		1.40516276042749847786e-02,
+		1.40164200290647730784e-02,
+		1.39758843501803509124e-02,
+		1.39300693501989755058e-02,
+		1.38790256382370583849e-02,
+		1.38228056185118915178e-02,
+		1.37614634583568401505e-02,
+		1.36950550557064492513e-02,
+		1.36236380060677994552e-02,
+		1.35472715689945160528e-02,
+		1.34660166340805041718e-02,
+		1.33799356864895482855e-02,
+		1.32890927720382152205e-02,
+		1.31935534618485093145e-02,
+		1.30933848165878073677e-02,
+		1.29886553503125948966e-02,
+		1.28794349939337273575e-02,
+		1.27657950583198263189e-02,
+		1.26478081970568673187e-02,
+		1.25255483688803560122e-02,
+		1.23990907997982395539e-02,
+		1.22685119449215014609e-02,
+		1.21338894500198513776e-02,
+		1.19953021128200946338e-02,
+		1.18528298440646415873e-02,
+		1.17065536283470807144e-02,
+		1.15565554847431271879e-02,
+		1.14029184272533504890e-02,
+		1.12457264250757603408e-02,
+		1.10850643627254524803e-02,
+		1.09210180000182278254e-02,
+		1.07536739319361047268e-02,
+		1.05831195483913325511e-02,
+		1.04094429939065279134e-02,
+		1.02327331272277742558e-02,
+		1.00530794808876850627e-02,
+		9.87057222073554028918e-03,
+		9.68530210545130547384e-03,
+		9.49736044606027188242e-03,
+		9.30683906546501960033e-03,
+		9.11383025801115742581e-03,
+		8.91842674910356403295e-03,
+		8.72072165488921823007e-03,
+		8.52080844202300104195e-03,
+		8.31878088753259907484e-03,
+		8.11473303879801932670e-03,
+		7.90875917366254226060e-03,
+		7.70095376068933353680e-03,
+		7.49141141958084393060e-03,
+		7.28022688177516145297e-03,
+		7.06749495123479894859e-03,
+		6.85331046544357131572e-03,
+		6.63776825662542105388e-03,
+		6.42096311320054704413e-03,
+		6.20298974149363201852e-03,
+		5.98394272770721297183e-03,
+		5.76391650017586427285e-03,
+		5.54300529191382312932e-03,
+		5.32130310347067331939e-03,
+		5.09890366610808373760e-03,
+		4.87590040531101984178e-03,
+		4.65238640464675501374e-03,
+		4.42845436998386479677e-03,
+		4.20419659408475827195e-03,
+		3.97970492158337095512e-03,
+		3.75507071436059032196e-03,
+		3.53038481732933194512e-03,
+		3.30573752464056709946e-03,
+		3.08121854632235079099e-03,
+		2.85691697536261330140e-03,
+		2.63292125524676760492e-03,
+		2.40931914796102932333e-03,
+		2.18619770247135015301e-03,
+		1.96364322368882977116e-03,
+		1.74174124193124781404e-03,
+		1.52057648288971502225e-03,
+		1.30023283811141004106e-03,
+		1.08079333600552942099e-03,
+		8.62340113382898190708e-04,
+		6.44954387537143535025e-04,
+		4.28716428875420955437e-04,
+		2.13705534107452372250e-04,
+		9.30065796717200855307e-18,
+		-2.12322902295505026469e-04,
+		-4.23186952335023649358e-04,
+		-6.32517004887025329797e-04,
+		-8.40239013843626335321e-04,
+		-1.04628005553181776914e-03,
+		-1.25056835141863515598e-03,
+		-1.45303329020435583081e-03,
+		-1.65360544929881716700e-03,
+		-1.85221661567498978480e-03,
+		-2.04879980609546687165e-03,
+		-2.24328928670715441879e-03,
+		-2.43562059199966575440e-03,
+		-2.62573054312398865631e-03,
+		-2.81355726556703489294e-03,
+		-2.99904020617914940025e-03,
+		-3.18212014955132453664e-03,
+		-3.36273923373913699239e-03,
+		-3.54084096533138813559e-03,
+		-3.71637023386026934743e-03,
+		-3.88927332555241093287e-03,
+		-4.05949793641764179741e-03,
+		-4.22699318467527253990e-03,
+		-4.39170962251643568625e-03,
+		-4.55359924720126485465e-03,
+		-4.71261551149106117098e-03,
+		-4.86871333341482200041e-03,
+		-5.02184910536981801016e-03,
+		-5.17198070255759333180e-03,
+		-5.31906749075466978027e-03,
+		-5.46307033341996307191e-03,
+		-5.60395159813921635295e-03,
+		-5.74167516240829331475e-03,
+		-5.87620641875695980116e-03,
+		-6.00751227921486521261e-03,
+		-6.13556117912236308881e-03,
+		-6.26032308028810942357e-03,
+		-6.38176947349659243885e-03,
+		-6.49987338036839799871e-03,
+		-6.61460935457630367501e-03,
+		-6.72595348242114102200e-03,
+		-6.83388338277060233827e-03,
+		-6.93837820636536515423e-03,
+		-7.03941863449658502411e-03,
+		-7.13698687705919491281e-03,
+		-7.23106666998590223022e-03,
+		-7.32164327206657507274e-03,
+		-7.40870346115814377985e-03,
+		-7.49223552979086469122e-03,
+		-7.57222928017566281750e-03,
+		-7.64867601861944027691e-03,
+		-7.72156854935343757440e-03,
+		-7.79090116778140967524e-03,
+		-7.85666965315402407444e-03,
+		-7.91887126067589153244e-03,
+		-7.97750471305222301488e-03,
+		-8.03257019148226336647e-03,
+		-8.08406932610641025538e-03,
+		-8.13200518591479515340e-03,
+		-8.17638226812466943627e-03,
+		-8.21720648703438971694e-03,
+		-8.25448516236208622299e-03,
+		-8.28822700707692802680e-03,
+		-8.31844211473149047764e-03,
+		-8.34514194630338670966e-03,
+		-8.36833931655496347790e-03,
+		-8.38804837991973493982e-03,
+		-8.40428461592436157335e-03,
+		-8.41706481415530408108e-03,
+		-8.42640705877926131373e-03,
+		-8.43233071262668686108e-03,
+		-8.43485640084777089920e-03,
+		-8.43400599415046990648e-03,
+		-8.42980259163028482206e-03,
+		-8.42227050320135464667e-03,
+		-8.41143523163902749606e-03,
+		-8.39732345424366866116e-03,
+		-8.37996300413583299072e-03,
+		-8.35938285119302779136e-03,
+		-8.33561308263831846066e-03,
+		-8.30868488329094059819e-03,
+		-8.27863051548964959392e-03,
+		-8.24548329869902695000e-03,
+		-8.20927758880940321162e-03,
+		-8.17004875714105564810e-03,
+		-8.12783316916329025281e-03,
+		-8.08266816293916508240e-03,
+		-8.03459202730675593751e-03,
+		-7.98364397980750803430e-03,
+		-7.92986414437288344992e-03,
+		-7.87329352877994513571e-03,
+		-7.81397400188690860467e-03,
+		-7.75194827065966811375e-03,
+		-7.68725985700012461738e-03,
+		-7.61995307438753568391e-03,
+		-7.55007300434350214768e-03,
+		-7.47766547273209791769e-03,
+		-7.40277702590573603164e-03,
+		-7.32545490670792002336e-03,
+		-7.24574703034400538493e-03,
+		-7.16370196013061972390e-03,
+		-7.07936888313506415549e-03,
+		-6.99279758571545555224e-03,
+		-6.90403842897237621234e-03,
+		-6.81314232412338384448e-03,
+		-6.72016070781055862432e-03,
+		-6.62514551735249226233e-03,
+		-6.52814916595121762927e-03,
+		-6.42922451786459570044e-03,
+		-6.32842486355531495812e-03,
+		-6.22580389482657939326e-03,
+		-6.12141567995537743546e-03,
+		-6.01531463883367336493e-03,
+		-5.90755551812782633142e-03,
+		-5.79819336646680578290e-03,
+		-5.68728350966921092358e-03,
+		-5.57488152601938978525e-03,
+		-5.46104322160281558524e-03,
+		-5.34582460571041053615e-03,
+		-5.22928186632209274221e-03,
+		-5.11147134567910711028e-03,
+		-4.99244951595490329965e-03,
+		-4.87227295503424740614e-03,
+		-4.75099832240980478326e-03,
+		-4.62868233520603682141e-03,
+		-4.50538174433895693277e-03,
+		-4.38115331082182882239e-03,
+		-4.25605378222502009344e-03,
+		-4.13013986929924512004e-03,
+		-4.00346822277113795208e-03,
+		-3.87609541031943465386e-03,
+		-3.74807789374053540454e-03,
+		-3.61947200631198813883e-03,
+		-3.49033393036149788835e-03,
+		-3.36071967505042340507e-03,
+		-3.23068505437893024532e-03,
+		-3.10028566542121068739e-03,
+		-2.96957686679807236763e-03,
+		-2.83861375739454272968e-03,
+		-2.70745115532996637880e-03,
+		-2.57614357718741714132e-03,
+		-2.44474521750998432038e-03,
+		-2.31330992857045484104e-03,
+		-2.18189120042124430968e-03,
+		-2.05054214123123615746e-03,
+		-1.91931545791562251785e-03,
+		-1.78826343706541619934e-03,
+		-1.65743792618236224397e-03,
+		-1.52689031522525837069e-03,
+		-1.39667151847353357419e-03,
+		-1.26683195671329382019e-03,
+		-1.13742153975163293352e-03,
+		-1.00848964926426344635e-03,
+		-8.80085121981092504719e-04,
+		-7.52256233215538002226e-04,
+		-6.25050680741105562331e-04,
+		-4.98515569020629815966e-04,
+		-3.72697393792140456610e-04,
+		-2.47642027015291816671e-04,
+		-1.23394702182757582060e-04,
+		-6.13497135199384854215e-18,
+		1.22498165562808029568e-04,
+		2.44056560843478601497e-04,
+		3.64632645681549881229e-04,
+		4.84184586230578754146e-04,
+		6.02671267385553521487e-04,
+		7.20052304823130275534e-04,
+		8.36288056652080297876e-04,
+		9.51339634671537703013e-04,
+		1.06516891523523765835e-03,
+		1.17773854971938048142e-03,
+		1.28901197459267615603e-03,
+		1.39895342108691195585e-03,
+		1.50752792446638022618e-03,
+		1.61470133289572537888e-03,
+		1.72044031590399930376e-03,
+		1.82471237244514051905e-03,
+		1.92748583855363825150e-03,
+		2.02872989459485734282e-03,
+		2.12841457211020938095e-03,
+		2.22651076025637620348e-03,
+		2.32299021183890019165e-03,
+		2.41782554894054988251e-03,
+		2.51099026814433426424e-03,
+		2.60245874535239589062e-03,
+		2.69220624020072817062e-03,
+		2.78020890007124512472e-03,
+		2.86644376370181032734e-03,
+		2.95088876439562148787e-03,
+		3.03352273283157957556e-03,
+		3.11432539947660222412e-03,
+		3.19327739660207931063e-03,
+		3.27036025990621107007e-03,
+		3.34555642974390188629e-03,
+		3.41884925196715098311e-03,
+		3.49022297837738373905e-03,
+		3.55966276679276893286e-03,
+		3.62715468073301124802e-03,
+		3.69268568872423369812e-03,
+		3.75624366322717048727e-03,
+		3.81781737919140856663e-03,
+		3.87739651223906536814e-03,
+		3.93497163648124075735e-03,
+		3.99053422197054828702e-03,
+		4.04407663179361283323e-03,
+		4.09559211880696633079e-03,
+		4.14507482202041820790e-03,
+		4.19251976263180885229e-03,
+		4.23792283971718714702e-03,
+		4.28128082558089807091e-03,
+		4.32259136076956762551e-03,
+		4.36185294875474777188e-03,
+		4.39906495028867788183e-03,
+		4.43422757743779875211e-03,
+		4.46734188729898656217e-03,
+		4.49840977540319660044e-03,
+		4.52743396881169404139e-03,
+		4.55441801890982614354e-03,
+		4.57936629390361896880e-03,
+		4.60228397102453289785e-03,
+		4.62317702844757417308e-03,
+		4.64205223692841853489e-03,
+		4.65891715116497143190e-03,
+		4.67378010088901826857e-03,
+		4.68665018169381070862e-03,
+		4.69753724560304907615e-03,
+		4.70645189138749024671e-03,
+		4.71340545463481974675e-03,
+		4.71840999757891076810e-03,
+		4.72147829869452514973e-03,
+		4.72262384206351398114e-03,
+		4.72186080651865788121e-03,
+		4.71920405457149257089e-03,
+		4.71466912113026326292e-03,
+		4.70827220201439945735e-03,
+		4.70003014227186096535e-03,
+		4.68996042430571899451e-03,
+		4.67808115581647750852e-03,
+		4.66441105756656634873e-03,
+		4.64896945097355036164e-03,
+		4.63177624553855367406e-03,
+		4.61285192611652402478e-03,
+		4.59221754003483802892e-03,
+		4.56989468406696162234e-03,
+		4.54590549126770125665e-03,
+		4.52027261767672019349e-03,
+		4.49301922889700205282e-03,
+		4.46416898655483968678e-03,
+		4.43374603464812867826e-03,
+		4.40177498578946981012e-03,
+		4.36828090735091444791e-03,
+		4.33328930751690401951e-03,
+		4.29682612125209008325e-03,
+		4.25891769619069458785e-03,
+		4.21959077845398319168e-03,
+		4.17887249840255461247e-03,
+		4.13679035632997117028e-03,
+		4.09337220810434415652e-03,
+		4.04864625076443648832e-03,
+		4.00264100807676010546e-03,
+		3.95538531606020541537e-03,
+		3.90690830848470799924e-03,
+		3.85723940235026870743e-03,
+		3.80640828335289567452e-03,
+		3.75444489134359182803e-03,
+		3.70137940578697593672e-03,
+		3.64724223122555668911e-03,
+		3.59206398275607480947e-03,
+		3.53587547152399081507e-03,
+		3.47870769024218430901e-03,
+		3.42059179874012759398e-03,
+		3.36155910954933278764e-03,
+		3.30164107353117346200e-03,
+		3.24086926555292465651e-03,
+		3.17927537021778835319e-03,
+		3.11689116765480030494e-03,
+		3.05374851937422657835e-03,
+		2.98987935419406641174e-03,
+		2.92531565424338077172e-03,
+		2.86008944104756187427e-03,
+		2.79423276170147382239e-03,
+		2.72777767513535497959e-03,
+		2.66075623847892434037e-03,
+		2.59320049352896545708e-03,
+		2.52514245332515581063e-03,
+		2.45661408883951363005e-03,
+		2.38764731578422310931e-03,
+		2.31827398154259159760e-03,
+		2.24852585222812262344e-03,
+		2.17843459987599559133e-03,
+		2.10803178977195552687e-03,
+		2.03734886792272720107e-03,
+		1.96641714867255975804e-03,
+		1.89526780247013088530e-03,
+		1.82393184378976959924e-03,
+		1.75244011921152765925e-03,
+		1.68082329566373472364e-03,
+		1.60911184883197853901e-03,
+		1.53733605173862254191e-03,
+		1.46552596349590032523e-03,
+		1.39371141823689472135e-03,
+		1.32192201422739476734e-03,
+		1.25018710316218781969e-03,
+		1.17853577964907802259e-03,
+		1.10699687088365106638e-03,
+		1.03559892651804998608e-03,
+		9.64370208726547965480e-04,
+		8.93338682470829663698e-04,
+		8.22532005967762909829e-04,
+		7.51977521362109002818e-04,
+		6.81702245606939801446e-04,
+		6.11732861553978472628e-04,
+		5.42095709256226808252e-04,
+		4.72816777485124384826e-04,
+		4.03921695464141419177e-04,
+		3.35435724821010099531e-04,
+		2.67383751760272357460e-04,
+		1.99790279457950334429e-04,
+		1.32679420680036671675e-04,
+		6.60748906261487359975e-05,
+		3.69373067236727603606e-18,
+		-6.55223516921600817979e-05,
+		-1.30469682613961043480e-04,
+		-1.94819934840905715232e-04,
+		-2.58551480371275674026e-04,
+		-3.21643126904764728967e-04,
+		-3.84074123388286007424e-04,
+		-4.45824165328240022959e-04,
+		-5.06873399868638875761e-04,
+		-5.67202430634833505707e-04,
+		-6.26792322342270211144e-04,
+		-6.85624605170240329459e-04,
+		-7.43681278900451396031e-04,
+		-8.00944816820271842175e-04,
+		-8.57398169391144007734e-04,
+		-9.13024767681748701011e-04,
+		-9.67808526566769802661e-04,
+		-1.02173384769137514001e-03,
+		-1.07478562220188460924e-03,
+		-1.12694923324350472493e-03,
+		-1.17821055822547307232e-03,
+		-1.22855597085456719371e-03,
+		-1.27797234293796713525e-03,
+		-1.32644704595620034626e-03,
+		-1.37396795240761590735e-03,
+		-1.42052343692513315110e-03,
+		-1.46610237716689574897e-03,
+		-1.51069415448193606656e-03,
+		-1.55428865435239130580e-03,
+		-1.59687626661394630953e-03,
+		-1.63844788545579973446e-03,
+		-1.67899490920213052651e-03,
+		-1.71850923987680072325e-03,
+		-1.75698328255294365478e-03,
+		-1.79440994448985834987e-03,
+		-1.83078263405871003236e-03,
+		-1.86609525945951085744e-03,
+		-1.90034222723144065499e-03,
+		-1.93351844055873354697e-03,
+		-1.96561929737458724113e-03,
+		-1.99664068826537052445e-03,
+		-2.02657899417768268616e-03,
+		-2.05543108393080884225e-03,
+		-2.08319431153712727664e-03,
+		-2.10986651333326669100e-03,
+		-2.13544600492460634120e-03,
+		-2.15993157794603686528e-03,
+		-2.18332249664181981055e-03,
+		-2.20561849426741118929e-03,
+		-2.22681976931635551911e-03,
+		-2.24692698157514256577e-03,
+		-2.26594124800922735313e-03,
+		-2.28386413848328259993e-03,
+		-2.30069767131892534764e-03,
+		-2.31644430869313612542e-03,
+		-2.33110695188063670250e-03,
+		-2.34468893634359396017e-03,
+		-2.35719402667199312909e-03,
+		-2.36862641137810039926e-03,
+		-2.37899069754847914543e-03,
+		-2.38829190535702748019e-03,
+		-2.39653546244257640435e-03,
+		-2.40372719815460864079e-03,
+		-2.40987333767063612033e-03,
+		-2.41498049598898876025e-03,
+		-2.41905567180045709408e-03,
+		-2.42210624124265501769e-03,
+		-2.42413995154067835047e-03,
+		-2.42516491453781055099e-03,
+		-2.42518960012005685076e-03,
+		-2.42422282953818138407e-03,
+		-2.42227376863109146107e-03,
+		-2.41935192095431208376e-03,
+		-2.41546712081739311725e-03,
+		-2.41062952623403168109e-03,
+		-2.40484961178873135612e-03,
+		-2.39813816142385623220e-03,
+		-2.39050626115088751464e-03,
+		-2.38196529168972423451e-03,
+		-2.37252692103990083308e-03,
+		-2.36220309698753107297e-03,
+		-2.35100603955182025501e-03,
+		-2.33894823337501634244e-03,
+		-2.32604242005960684322e-03,
+		-2.31230159045658868397e-03,
+		-2.29773897690864394722e-03,
+		-2.28236804545202832256e-03,
+		-2.26620248798096047410e-03,
+		-2.24925621437832654742e-03,
+		-2.23154334461642296639e-03,
+		-2.21307820083153482943e-03,
+		-2.19387529937608129552e-03,
+		-2.17394934285202248714e-03,
+		-2.15331521212924629008e-03,
+		-2.13198795835260789325e-03,
+		-2.10998279494125674793e-03,
+		-2.08731508958389993733e-03,
+		-2.06400035623358632866e-03,
+		-2.04005424710558460472e-03,
+		-2.01549254468188143372e-03,
+		-1.99033115372585162409e-03,
+		-1.96458609331052243968e-03,
+		-1.93827348886393036227e-03,
+		-1.91140956423492135337e-03,
+		-1.88401063378282242894e-03,
+		-1.85609309449428399111e-03,
+		-1.82767341813059279454e-03,
+		-1.79876814340874523375e-03,
+		-1.76939386821942773974e-03,
+		-1.73956724188514388202e-03,
+		-1.70930495746159094583e-03,
+		-1.67862374408535514406e-03,
+		-1.64754035937102216204e-03,
+		-1.61607158186056077548e-03,
+		-1.58423420352812524547e-03,
+		-1.55204502234299805512e-03,
+		-1.51952083489358992507e-03,
+		-1.48667842907533880210e-03,
+		-1.45353457684511402567e-03,
+		-1.42010602704496736656e-03,
+		-1.38640949829782261435e-03,
+		-1.35246167197761754490e-03,
+		-1.31827918525656779050e-03,
+		-1.28387862423184006232e-03,
+		-1.24927651713427063755e-03,
+		-1.21448932762130380400e-03,
+		-1.17953344815659513789e-03,
+		-1.14442519347846211400e-03,
+		-1.10918079415930404853e-03,
+		-1.07381639025829335281e-03,
+		-1.03834802506925800297e-03,
+		-1.00279163896580306679e-03,
+		-9.67163063345733765332e-04,
+		-9.31478014676414504264e-04,
+		-8.95752088643185441670e-04,
+		-8.60000754402402613902e-04,
+		-8.24239348940879927932e-04,
+		-7.88483071543387079028e-04,
+		-7.52746978369710760016e-04,
+		-7.17045977142888768703e-04,
+		-6.81394821950014983707e-04,
+		-6.45808108157000819102e-04,
+		-6.10300267438674106171e-04,
+		-5.74885562925381461696e-04,
+		-5.39578084467408954777e-04,
+		-5.04391744018275784041e-04,
+		-4.69340271137998015210e-04,
+		-4.34437208617365002057e-04,
+		-3.99695908224083275729e-04,
+		-3.65129526571790247354e-04,
+		-3.30751021112663759916e-04,
+		-2.96573146254420095397e-04,
+		-2.62608449602416769552e-04,
+		-2.28869268327400968589e-04,
+		-1.95367725659594725302e-04,
+		-1.62115727509526012578e-04,
+		-1.29124959216081827435e-04,
+		-9.64068824221919691607e-05,
+		-6.39727320783742162223e-05,
+		-3.18335135745159139228e-05,
+		-1.97572625219542272441e-18,
+		3.15172704676465261658e-05,
+		6.27079970455174314252e-05,
+		9.35621186995051908573e-05,
+		1.24069816369889638318e-04,
+		1.54221515068482669612e-04,
+		1.84007885847478245504e-04,
+		2.13419847640156562290e-04,
+		2.42448568973915596757e-04,
+		2.71085469555655436506e-04,
+		2.99322221730178575014e-04,
+		3.27150751811907227265e-04,
+		3.54563241290396747793e-04,
+		3.81552127910339880772e-04,
+		4.08110106626486822314e-04,
+		4.34230130434213798789e-04,
+		4.59905411076502788990e-04,
+		4.85129419627957541097e-04,
+		5.09895886956847604193e-04,
+		5.34198804065832738748e-04,
+		5.58032422312441174668e-04,
+		5.81391253510145930919e-04,
+		6.04270069911084909721e-04,
+		6.26663904071536994114e-04,
+		6.48568048601095561642e-04,
+		6.69978055796802509682e-04,
+		6.90889737163404731891e-04,
+		7.11299162820844136160e-04,
+		7.31202660800470572583e-04,
+		7.50596816231039864337e-04,
+		7.69478470415990863616e-04,
+		7.87844719803375650481e-04,
+		8.05692914849741640380e-04,
+		8.23020658779654678931e-04,
+		8.39825806242113642730e-04,
+		8.56106461865542263737e-04,
+		8.71860978712878011837e-04,
+		8.87087956638315925685e-04,
+		9.01786240547435393247e-04,
+		9.15954918562277421550e-04,
+		9.29593320093055944763e-04,
+		9.42701013818325606053e-04,
+		9.55277805575226244932e-04,
+		9.67323736161696890248e-04,
+		9.78839079052347578458e-04,
+		9.89824338029881038367e-04,
+		1.00028024473390272399e-03,
+		1.01020775612894846140e-03,
+		1.01960805189370458390e-03,
+		1.02848253173322737829e-03,
+		1.03683281261616761643e-03,
+		1.04466072593894327911e-03,
+		1.05196831461874850192e-03,
+		1.05875783011752594778e-03,
+		1.06503172939875485718e-03,
+		1.07079267181914338306e-03,
+		1.07604351595725396956e-03,
+		1.08078731638103588998e-03,
+		1.08502732035640299044e-03,
+		1.08876696449883227265e-03,
+		1.09200987137008319848e-03,
+		1.09475984602212220338e-03,
+		1.09702087249030936708e-03,
+		1.09879711023793975172e-03,
+		1.10009289055424514490e-03,
+		1.10091271290791293917e-03,
+		1.10126124125826843451e-03,
+		1.10114330032616298399e-03,
+		1.10056387182672294270e-03,
+		1.09952809066599945485e-03,
+		1.09804124110366623304e-03,
+		1.09610875288382054321e-03,
+		1.09373619733599855390e-03,
+		1.09092928344848931997e-03,
+		1.08769385391601323965e-03,
+		1.08403588116386269923e-03,
+		1.07996146335054667501e-03,
+		1.07547682035100925120e-03,
+		1.07058828972248789267e-03,
+		1.06530232265501637913e-03,
+		1.05962547990862978285e-03,
+		1.05356442773928051654e-03,
+		1.04712593381546103383e-03,
+		1.04031686312754611222e-03,
+		1.03314417389179847608e-03,
+		1.02561491345104028108e-03,
+		1.01773621417389757184e-03,
+		1.00951528935458098579e-03,
+		1.00095942911508162601e-03,
+		9.92075996311709380412e-04,
+		9.82872422447824420702e-04,
+		9.73356203594623998164e-04,
+		9.63534896321830065281e-04,
+		9.53416113640071210406e-04,
+		9.43007520956761283171e-04,
+		9.32316832047262993716e-04,
+		9.21351805043044429396e-04,
+		9.10120238438589341731e-04,
+		8.98629967118719165560e-04,
+		8.86888858408054012866e-04,
+		8.74904808144205551126e-04,
+		8.62685736776358101825e-04,
+		8.50239585490837916290e-04,
+		8.37574312365188295045e-04,
+		8.24697888552364819295e-04,
+		8.11618294496508404373e-04,
+		7.98343516181780990006e-04,
+		7.84881541415787821990e-04,
+		7.71240356148884223693e-04,
+		7.57427940830889185643e-04,
+		7.43452266806497600107e-04,
+		7.29321292750732732418e-04,
+		7.15042961145786158672e-04,
+		7.00625194800425865962e-04,
+		6.86075893413319744772e-04,
+		6.71402930181400525896e-04,
+		6.56614148454481264157e-04,
+		6.41717358437255661237e-04,
+		6.26720333939792041168e-04,
+		6.11630809177595422856e-04,
+		5.96456475622281234207e-04,
+		5.81204978903860085444e-04,
+		5.65883915765640933826e-04,
+		5.50500831072617507859e-04,
+		5.35063214874339576968e-04,
+		5.19578499523072677262e-04,
+		5.04054056848102054189e-04,
+		4.88497195386989636889e-04,
+		4.72915157674544774698e-04,
+		4.57315117590241873319e-04,
+		4.41704177764784783160e-04,
+		4.26089367046473394256e-04,
+		4.10477638028034885320e-04,
+		3.94875864634433159251e-04,
+		3.79290839772315172169e-04,
+		3.63729273041549629273e-04,
+		3.48197788509361659450e-04,
+		3.32702922547555533794e-04,
+		3.17251121733138491399e-04,
+		3.01848740812900892365e-04,
+		2.86502040732137066605e-04,
+		2.71217186727915060551e-04,
+		2.56000246487178800651e-04,
+		2.40857188369857989126e-04,
+		2.25793879697300557208e-04,
+		2.10816085106135418445e-04,
+		1.95929464967738750474e-04,
+		1.81139573873439764214e-04,
+		1.66451859185478726966e-04,
+		1.51871659653866100366e-04,
+		1.37404204099057900175e-04,
+		1.23054610160531330667e-04,
+		1.08827883111137961938e-04,
+		9.47289147371572549418e-05,
+		8.07624822840094920519e-05,
+		6.69332474674197430708e-05,
+		5.32457555498838701813e-05,
+		3.97044344822628081219e-05,
+		2.63135941101999912143e-05,
+		1.30774254451988988080e-05,
+		3.24328175098148313778e-18,
+		-1.29147308120408851748e-05,
+		-2.56629362138939521535e-05,
+		-3.82409065385408015992e-05,
+		-5.06450536397538736183e-05,
+		-6.28719112404284931383e-05,
+		-7.49181352189164301899e-05,
+		-8.67805038338150203309e-05,
+		-9.84559178877863453057e-05,
+		-1.09941400830845386087e-04,
+		-1.21234098803743044659e-04,
+		-1.32331280621992512098e-04,
+		-1.43230337701150540726e-04,
+		-1.53928783923975328989e-04,
+		-1.64424255450114521889e-04,
+		-1.74714510468987441498e-04,
+		-1.84797428896564883008e-04,
+		-1.94671012016727477305e-04,
+		-2.04333382068003438850e-04,
+		-2.13782781776352185082e-04,
+		-2.23017573834830397719e-04,
+		-2.32036240330906108230e-04,
+		-2.40837382122233416987e-04,
+		-2.49419718161716474740e-04,
+		-2.57782084772704121484e-04,
+		-2.65923434875177475828e-04,
+		-2.73842837163809247822e-04,
+		-2.81539475238764874600e-04,
+		-2.89012646690206271693e-04,
+		-2.96261762137344094540e-04,
+		-3.03286344223029796265e-04,
+		-3.10086026564813444558e-04,
+		-3.16660552663410228315e-04,
+		-3.23009774769607163961e-04,
+		-3.29133652710491186790e-04,
+		-3.35032252676092912567e-04,
+		-3.40705745967390030010e-04,
+		-3.46154407706686492643e-04,
+		-3.51378615511428781318e-04,
+		-3.56378848132428188884e-04,
+		-3.61155684057574196538e-04,
+		-3.65709800082064000800e-04,
+		-3.70041969846186911009e-04,
+		-3.74153062341760070993e-04,
+		-3.78044040388220506117e-04,
+		-3.81715959079497217047e-04,
+		-3.85169964202677320327e-04,
+		-3.88407290629587463419e-04,
+		-3.91429260682353953940e-04,
+		-3.94237282474000922793e-04,
+		-3.96832848225189642726e-04,
+		-3.99217532558180548171e-04,
+		-4.01392990769080691346e-04,
+		-4.03360957079499163336e-04,
+		-4.05123242868648603728e-04,
+		-4.06681734887007250226e-04,
+		-4.08038393452609358947e-04,
+		-4.09195250631047113387e-04,
+		-4.10154408400258629029e-04,
+		-4.10918036801174871644e-04,
+		-4.11488372075299499962e-04,
+		-4.11867714790283662944e-04,
+		-4.12058427954555830334e-04,
+		-4.12062935122074812475e-04,
+		-4.11883718488239322468e-04,
+		-4.11523316978012316213e-04,
+		-4.10984324327295252356e-04,
+		-4.10269387158582209997e-04,
+		-4.09381203051920169924e-04,
+		-4.08322518612191844717e-04,
+		-4.07096127533728335737e-04,
+		-4.05704868663253907703e-04,
+		-4.04151624062147444855e-04,
+		-4.02439317069011495264e-04,
+		-4.00570910363509994322e-04,
+		-3.98549404032440962586e-04,
+		-3.96377833638993180149e-04,
+		-3.94059268296125051866e-04,
+		-3.91596808744994770522e-04,
+		-3.88993585439355218231e-04,
+		-3.86252756636817884926e-04,
+		-3.83377506497878156513e-04,
+		-3.80371043193577520157e-04,
+		-3.77236597022670427926e-04,
+		-3.73977418539141821720e-04,
+		-3.70596776690920943001e-04,
+		-3.67097956970607452055e-04,
+		-3.63484259579022520557e-04,
+		-3.59758997602375877115e-04,
+		-3.55925495203836532877e-04,
+		-3.51987085830257563548e-04,
+		-3.47947110434816979287e-04,
+		-3.43808915716301694270e-04,
+		-3.39575852375746970943e-04,
+		-3.35251273391143117704e-04,
+		-3.30838532310885765318e-04,
+		-3.26340981566639000075e-04,
+		-3.21761970806269735450e-04,
+		-3.17104845247472022247e-04,
+		-3.12372944052718157573e-04,
+		-3.07569598726120652359e-04,
+		-3.02698131532800609675e-04,
+		-2.97761853941319468500e-04,
+		-2.92764065089718816121e-04,
+		-2.87708050275711888118e-04,
+		-2.82597079471526247893e-04,
+		-2.77434405863896649778e-04,
+		-2.72223264419688495714e-04,
+		-2.66966870477596028913e-04,
+		-2.61668418366374532554e-04,
+		-2.56331080050013976682e-04,
+		-2.50958003800263616465e-04,
+		-2.45552312896891186764e-04,
+		-2.40117104356044888062e-04,
+		-2.34655447687064918705e-04,
+		-2.29170383678078325119e-04,
+		-2.23664923210683050511e-04,
+		-2.18142046104028716020e-04,
+		-2.12604699988543961600e-04,
+		-2.07055799209598771510e-04,
+		-2.01498223761318109859e-04,
+		-1.95934818250776852586e-04,
+		-1.90368390892778599054e-04,
+		-1.84801712535399750284e-04,
+		-1.79237515716470211967e-04,
+		-1.73678493751135896951e-04,
+		-1.68127299850635760631e-04,
+		-1.62586546272413824113e-04,
+		-1.57058803501641130627e-04,
+		-1.51546599464255269361e-04,
+		-1.46052418771557988766e-04,
+		-1.40578701996421612892e-04,
+		-1.35127844981132612650e-04,
+		-1.29702198176881114021e-04,
+		-1.24304066014889729591e-04,
+		-1.18935706309160137790e-04,
+		-1.13599329690795952641e-04,
+		-1.08297099073855276896e-04,
+		-1.03031129152638219930e-04,
+		-9.78034859303550483268e-05,
+		-9.26161862790455967182e-05,
+		-8.74711975306427926089e-05,
+		-8.23704370990519120889e-05,
+		-7.73157721330771491829e-05,
+		-7.23090192000533328251e-05,
+		-6.73519440000056192732e-05,
+		-6.24462611101214443848e-05,
+		-5.75936337593598200670e-05,
+		-5.27956736329463112434e-05,
+		-4.80539407065523163404e-05,
+		-4.33699431098887459358e-05,
+		-3.87451370194632422933e-05,
+		-3.41809265802349307797e-05,
+		-2.96786638558597113194e-05,
+		-2.52396488072596438142e-05,
+		-2.08651292991857255376e-05,
+		-1.65563011344521269965e-05,
+		-1.23143081155401815948e-05,
+		-8.14024213317609715123e-06,
+		-4.03514328158145709933e-06,
+		-2.99368287518859167033e-19,
+		3.96425075986245158615e-06,
+		7.85672334086868909622e-06,
+		1.16765831623565049987e-05,
+		1.54230464773058275002e-05,
+		1.90953801051941453467e-05,
+		2.26929011409548444991e-05,
+		2.62149766404686926705e-05,
+		2.96610232830260972254e-05,
+		3.30305070112051150357e-05,
+		3.63229426486178432897e-05,
+		3.95378934959859771768e-05,
+		4.26749709060047415030e-05,
+		4.57338338374865837656e-05,
+		4.87141883892387128857e-05,
+		5.16157873141784580393e-05,
+		5.44384295141688565652e-05,
+		5.71819595160718080408e-05,
+		5.98462669295189640911e-05,
+		6.24312858869055921449e-05,
+		6.49369944661166357928e-05,
+		6.73634140964990868646e-05,
+		6.97106089485916198285e-05,
+		7.19786853081469759665e-05,
+		7.41677909349492862174e-05,
+		7.62781144069679662139e-05,
+		7.83098844503700642272e-05,
+		8.02633692559218196423e-05,
+		8.21388757823123444356e-05,
+		8.39367490469314998704e-05,
+		8.56573714046376998108e-05,
+		8.73011618150509925793e-05,
+		8.88685750989022060862e-05,
+		9.03601011839877112054e-05,
+		9.17762643412480384839e-05,
+		9.31176224115189002731e-05,
+		9.43847660234861619497e-05,
+		9.55783178033733785486e-05,
+		9.66989315769078603639e-05,
+		9.77472915640837601006e-05,
+		9.87241115672533051193e-05,
+		9.96301341530844675968e-05,
+		1.00466129828896206166e-04,
+		1.01232896213907945559e-04,
+		1.01931257205909982580e-04,
+		1.02562062143880995289e-04,
+		1.03126184967062935129e-04,
+		1.03624523371000939022e-04,
+		1.04057997961062091946e-04,
+		1.04427551403924603695e-04,
+		1.04734147577542475419e-04,
+		1.04978770720079252524e-04,
+		1.05162424578289769592e-04,
+		1.05286131555849989117e-04,
+		1.05350931862096790638e-04,
+		1.05357882661659746555e-04,
+		1.05308057225450861158e-04,
+		1.05202544083470294093e-04,
+		1.05042446179889843510e-04,
+		1.04828880030855947072e-04,
+		1.04562974885460857242e-04,
+		1.04245871890315454865e-04,
+		1.03878723258154075115e-04,
+		1.03462691440893818709e-04,
+		1.02998948307564058640e-04,
+		1.02488674327513965044e-04,
+		1.01933057759299799342e-04,
+		1.01333293845643550800e-04,
+		1.00690584014852076351e-04,
+		1.00006135089070346167e-04,
+		9.92811584997414488469e-05,
+		9.85168695106348427538e-05,
+		9.77144864487951243575e-05,
+		9.68752299437588681313e-05,
+		9.60003221753736620111e-05,
+		9.50909861305505215226e-05,
+		9.41484448692669607895e-05,
+		9.31739208001332552518e-05,
+		9.21686349658236837683e-05,
+		9.11338063386657828459e-05,
+		9.00706511266723244867e-05,
+		8.89803820902911746108e-05,
+		8.78642078701391765830e-05,
+		8.67233323259776256236e-05,
+		8.55589538871762069362e-05,
+		8.43722649149055076764e-05,
+		8.31644510762858258767e-05,
+		8.19366907307138059345e-05,
+		8.06901543285759483958e-05,
+		7.94260038225521057586e-05,
+		7.81453920916991740149e-05,
+		7.68494623784984675968e-05,
+		7.55393477390399790223e-05,
+		7.42161705065055301526e-05,
+		7.28810417681070350391e-05,
+		7.15350608556241410142e-05,
+		7.01793148496753231742e-05,
+		6.88148780978507611369e-05,
+		6.74428117468204056184e-05,
+		6.60641632885284062118e-05,
+		6.46799661205689984484e-05,
+		6.32912391208336220843e-05,
+		6.18989862365098132777e-05,
+		6.05041960874992809302e-05,
+		5.91078415843203074120e-05,
+		5.77108795605441775647e-05,
+		5.63142504198081163790e-05,
+		5.49188777974438885961e-05,
+		5.35256682367394345757e-05,
+		5.21355108798578082275e-05,
+		5.07492771734173675697e-05,
+		4.93678205887328655477e-05,
+		4.79919763567104886684e-05,
+		4.66225612173744478959e-05,
+		4.52603731840050369666e-05,
+		4.39061913218494927614e-05,
+		4.25607755413648714059e-05,
+		4.12248664059426941030e-05,
+		3.98991849540563075626e-05,
+		3.85844325357653173607e-05,
+		3.72812906635028060522e-05,
+		3.59904208770641021896e-05,
+		3.47124646227092121067e-05,
+		3.34480431462791407663e-05,
+		3.21977574002277103205e-05,
+		3.09621879644545540443e-05,
+		2.97418949808234420260e-05,
+		2.85374181012411943798e-05,
+		0.0, /* Need a final zero coefficient */
+	},
+}
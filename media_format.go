@@ -0,0 +1,205 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Encodings understood by ParseMediaFormat, with their RTP names (RFC 3551).
+const (
+	EncodingL16  = "L16"  // Signed 16-bit big-endian PCM
+	EncodingL24  = "L24"  // Signed 24-bit big-endian PCM
+	EncodingPCMU = "PCMU" // G.711 u-law
+)
+
+const defaultPtime = 20 // Packet duration in ms when the format gives none
+
+// MediaFormat is an RTP/SDP style audio payload format, as parsed from
+// "audio/L16;rate=24000;channels=1" or "audio/PCMU;rate=8000".
+type MediaFormat struct {
+	Encoding string // EncodingL16, EncodingL24 or EncodingPCMU
+	Rate     int    // Sample rate in Hz
+	Channels int
+	Ptime    int // Packet duration in ms
+}
+
+// ParseMediaFormat parses a media type such as "audio/L16;rate=24000;channels=1".
+// The encoding and parameter names are case-insensitive. The parameters are
+// rate (required for L16 and L24, 8000 by default for PCMU), channels (1 by
+// default) and ptime, the packet duration in ms (20 by default); others are
+// rejected so a misspelt parameter is not silently ignored.
+func ParseMediaFormat(s string) (MediaFormat, error) {
+	parts := strings.Split(s, ";")
+	typ := strings.TrimSpace(parts[0])
+	slash := strings.IndexByte(typ, '/')
+	if slash < 0 || !strings.EqualFold(typ[:slash], "audio") {
+		return MediaFormat{}, fmt.Errorf("media format %q is not an audio/ type", s)
+	}
+	f := MediaFormat{Encoding: strings.ToUpper(typ[slash+1:]), Channels: 1, Ptime: defaultPtime}
+	switch f.Encoding {
+	case EncodingL16, EncodingL24:
+	case EncodingPCMU:
+		f.Rate = 8000
+	default:
+		return MediaFormat{}, fmt.Errorf("media format %q: unsupported encoding %q", s, typ[slash+1:])
+	}
+
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			return MediaFormat{}, fmt.Errorf("media format %q: parameter %q is not key=value", s, param)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n <= 0 {
+			return MediaFormat{}, fmt.Errorf("media format %q: %s must be a positive integer, got %q", s, key, value)
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "rate":
+			f.Rate = n
+		case "channels":
+			f.Channels = n
+		case "ptime":
+			f.Ptime = n
+		default:
+			return MediaFormat{}, fmt.Errorf("media format %q: unknown parameter %q", s, key)
+		}
+	}
+	if f.Rate == 0 {
+		return MediaFormat{}, fmt.Errorf("media format %q: %s needs a rate", s, f.Encoding)
+	}
+	if f.Channels > maxChannels {
+		return MediaFormat{}, fmt.Errorf("media format %q: %d channels: %w", s, f.Channels, mapError(ErrBadChannelCount))
+	}
+	if f.Rate*f.Ptime%1000 != 0 {
+		return MediaFormat{}, fmt.Errorf("media format %q: ptime %dms is not a whole number of samples at %dHz", s, f.Ptime, f.Rate)
+	}
+	return f, nil
+}
+
+// String returns the format as a media type, e.g. "audio/PCMU;rate=8000;channels=1".
+func (f MediaFormat) String() string {
+	return fmt.Sprintf("audio/%s;rate=%d;channels=%d", f.Encoding, f.Rate, f.Channels)
+}
+
+// PacketFrames returns the number of frames in one packet of Ptime ms.
+func (f MediaFormat) PacketFrames() int {
+	return f.Rate * f.Ptime / 1000
+}
+
+// bytesPerSample returns the size of one encoded sample.
+func (f MediaFormat) bytesPerSample() int {
+	switch f.Encoding {
+	case EncodingL16:
+		return 2
+	case EncodingL24:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// decode converts a payload in format f to float32 samples in out, which must
+// hold len(payload)/bytesPerSample samples.
+func (f MediaFormat) decode(payload []byte, out []float32) error {
+	switch f.Encoding {
+	case EncodingL16:
+		_, err := DecodePCM(FormatS16BE, payload, out)
+		return err
+	case EncodingL24:
+		_, err := DecodePCM(FormatS24BE, payload, out)
+		return err
+	default:
+		UlawToFloatArray(payload, out)
+		return nil
+	}
+}
+
+// mediaEncoder is the Encoder of a Pipeline made by NewFromFormats, writing
+// packets in its format.
+type mediaEncoder struct {
+	format MediaFormat
+}
+
+func (e mediaEncoder) EncodeFrame(frame []float32) ([]byte, error) {
+	packet := make([]byte, len(frame)*e.format.bytesPerSample())
+	switch e.format.Encoding {
+	case EncodingL16:
+		_, err := EncodePCM(FormatS16BE, frame, packet)
+		return packet, err
+	case EncodingL24:
+		_, err := EncodePCM(FormatS24BE, frame, packet)
+		return packet, err
+	default:
+		FloatToUlawArray(frame, packet)
+		return packet, nil
+	}
+}
+
+// NewFromFormats creates a Pipeline converting payloads from the src media
+// format to the dst one (see ParseMediaFormat), e.g. from the
+// "audio/L16;rate=24000" output of a TTS engine to "audio/PCMU;rate=8000" for a
+// SIP leg: it decodes, resamples with SincBestQuality, up- or downmixes the
+// channels if they differ, and encodes packets of dst's ptime. Feed it source
+// payloads with ProcessPayload.
+//
+// Args:
+//
+//	src: Media format of the input, e.g. "audio/L16;rate=24000;channels=1".
+//	dst: Media format of the packets, e.g. "audio/PCMU;rate=8000;ptime=20".
+//
+// Returns:
+//
+//	A Pipeline whose Process and ProcessPayload return dst packets, or nil and
+//	an error for a malformed or unsupported format.
+func NewFromFormats(src, dst string) (*Pipeline, error) {
+	from, err := ParseMediaFormat(src)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+	to, err := ParseMediaFormat(dst)
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+	p, err := NewPipeline(mediaEncoder{format: to}, SincBestQuality, from.Channels, float64(from.Rate), float64(to.Rate), to.PacketFrames())
+	if err != nil {
+		return nil, err
+	}
+	p.source = from
+	if to.Channels != from.Channels {
+		p.stage.conv.Close()
+		if p.stage.conv, err = NewWithOptions(SincBestQuality, from.Channels, Options{OutputChannels: to.Channels}); err != nil {
+			return nil, err
+		}
+		p.stage.outChannels = to.Channels
+		p.stage.scratch = make([]float32, fanoutScratchFrames*to.Channels)
+		p.channels = to.Channels
+	}
+	return p, nil
+}
+
+// ProcessPayload is like Process but takes payload bytes in the source format
+// of a Pipeline made by NewFromFormats, e.g. the concatenated payloads of RTP
+// packets.
+func (p *Pipeline) ProcessPayload(payload []byte, endOfInput bool) ([][]byte, error) {
+	if p.source.Encoding == "" {
+		return nil, fmt.Errorf("pipeline has no source format; create it with NewFromFormats")
+	}
+	frameBytes := p.source.bytesPerSample() * p.inChannels
+	if len(payload)%frameBytes != 0 {
+		return nil, fmt.Errorf("payload size (%d) not multiple of frame size (%d) for %s", len(payload), frameBytes, p.source)
+	}
+	p.decodeBuf = growFloats(p.decodeBuf, len(payload)/p.source.bytesPerSample())
+	if err := p.source.decode(payload, p.decodeBuf); err != nil {
+		return nil, err
+	}
+	return p.Process(p.decodeBuf, endOfInput)
+}
@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseMediaFormat(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want MediaFormat
+	}{
+		{"audio/l16;rate=24000;channels=1", MediaFormat{EncodingL16, 24000, 1, 20}},
+		{"audio/PCMU;rate=8000", MediaFormat{EncodingPCMU, 8000, 1, 20}},
+		{"audio/pcmu", MediaFormat{EncodingPCMU, 8000, 1, 20}},
+		{"Audio/L24; Rate=48000; channels=2; ptime=10", MediaFormat{EncodingL24, 48000, 2, 10}},
+	} {
+		got, err := ParseMediaFormat(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMediaFormat(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{
+		"",
+		"video/L16;rate=8000",
+		"audio/opus;rate=48000",
+		"audio/L16",
+		"audio/L16;rate=0",
+		"audio/L16;rate=16000;channels",
+		"audio/L16;rate=16000;chanels=2",
+		"audio/PCMU;ptime=abc",
+		"audio/L16;rate=11025;ptime=20",
+		"audio/L16;rate=8000;channels=999",
+	} {
+		if f, err := ParseMediaFormat(in); err == nil {
+			t.Errorf("ParseMediaFormat(%q) = %+v, expected an error", in, f)
+		}
+	}
+	if s := (MediaFormat{EncodingPCMU, 8000, 1, 20}).String(); s != "audio/PCMU;rate=8000;channels=1" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// TestNewFromFormats converts TTS style L16 at 24kHz to 20ms PCMU packets.
+func TestNewFromFormats(t *testing.T) {
+	p, err := NewFromFormats("audio/l16;rate=24000;channels=1", "audio/PCMU;rate=8000")
+	if err != nil {
+		t.Fatalf("NewFromFormats failed: %v", err)
+	}
+	defer p.Close()
+	if p.OutputRate() != 8000 || p.FrameFrames() != 160 {
+		t.Errorf("output %gHz in %d frame packets, want 8000Hz and 160", p.OutputRate(), p.FrameFrames())
+	}
+
+	tone := genSine(24000, 1000, 24000, 0.5)
+	payload := make([]byte, 2*len(tone))
+	if _, err := EncodePCM(FormatS16BE, tone, payload); err != nil {
+		t.Fatal(err)
+	}
+	var out []byte
+	for pos := 0; pos < len(payload); pos += 960 { // 20ms RTP payloads
+		end := minInt(pos+960, len(payload))
+		packets, err := p.ProcessPayload(payload[pos:end], end == len(payload))
+		if err != nil {
+			t.Fatalf("ProcessPayload failed: %v", err)
+		}
+		for _, packet := range packets {
+			if len(packet) != 160 {
+				t.Fatalf("packet of %d bytes, want 160", len(packet))
+			}
+			out = append(out, packet...)
+		}
+	}
+	if len(out) != 8000 {
+		t.Errorf("got %d samples for 1s, want 8000", len(out))
+	}
+	decoded := make([]float32, len(out))
+	UlawToFloatArray(out, decoded)
+	if rms := rmsGo(decoded[1000:7000]); math.Abs(rms-0.5/math.Sqrt2) > 0.01 {
+		t.Errorf("tone RMS %g after conversion, want %g", rms, 0.5/math.Sqrt2)
+	}
+}
+
+func TestNewFromFormatsChannels(t *testing.T) {
+	p, err := NewFromFormats("audio/L16;rate=16000;channels=2", "audio/L16;rate=8000;ptime=10")
+	if err != nil {
+		t.Fatalf("NewFromFormats failed: %v", err)
+	}
+	defer p.Close()
+
+	// Opposite channels cancel in the mono downmix
+	stereo := make([]float32, 2*1600)
+	for i, v := range genSine(1600, 440, 16000, 0.5) {
+		stereo[2*i], stereo[2*i+1] = v, -v
+	}
+	payload := make([]byte, 2*len(stereo))
+	EncodePCM(FormatS16BE, stereo, payload)
+	packets, err := p.ProcessPayload(payload, true)
+	if err != nil {
+		t.Fatalf("ProcessPayload failed: %v", err)
+	}
+	if len(packets) != 10 {
+		t.Fatalf("got %d packets for 100ms, want 10", len(packets))
+	}
+	for _, packet := range packets {
+		mono := make([]float32, len(packet)/2)
+		if len(mono) != 80 {
+			t.Fatalf("packet of %d samples, want 80 mono samples", len(mono))
+		}
+		DecodePCM(FormatS16BE, packet, mono)
+		if peak := findPeakGo(mono); peak > 0.01 {
+			t.Fatalf("downmix of opposite channels peaks at %g, want silence", peak)
+		}
+	}
+}
+
+func TestNewFromFormatsErrors(t *testing.T) {
+	if _, err := NewFromFormats("audio/L16", "audio/PCMU"); err == nil {
+		t.Error("expected error for a source without rate")
+	}
+	if _, err := NewFromFormats("audio/PCMU", "audio/G729"); err == nil {
+		t.Error("expected error for an unsupported destination")
+	}
+
+	p, err := NewFromFormats("audio/L24;rate=48000", "audio/PCMU")
+	if err != nil {
+		t.Fatalf("NewFromFormats failed: %v", err)
+	}
+	defer p.Close()
+	if _, err := p.ProcessPayload(make([]byte, 10), false); err == nil {
+		t.Error("expected error for a payload of partial L24 samples")
+	}
+
+	plain, _ := NewPipeline(&recordingEncoder{}, SincFastest, 1, 8000, 16000, 320)
+	defer plain.Close()
+	if _, err := plain.ProcessPayload(make([]byte, 4), false); err == nil {
+		t.Error("expected error for ProcessPayload without a source format")
+	}
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "math"
+
+// BlockLevels holds the per-channel levels of the output of one Process call, on
+// a linear full scale of 1.0. Peak and RMS have one entry per output channel and
+// are reused by the next call: copy them to keep them.
+type BlockLevels struct {
+	Frames int64     // Output frames measured
+	Peak   []float32 // Largest absolute sample of each channel
+	RMS    []float32 // Root mean square of each channel
+}
+
+// Clipping reports whether any channel reached full scale, i.e. would clip when
+// converted to integer PCM.
+func (l BlockLevels) Clipping() bool {
+	for _, p := range l.Peak {
+		if p >= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// MeterFunc receives the levels of every Process call that generated output, see
+// Options.Meter. It is called synchronously on the processing goroutine.
+type MeterFunc func(levels BlockLevels)
+
+// meterBlock measures frames interleaved frames of out and passes the levels to
+// fn. buf holds the Peak and RMS slices between calls.
+func meterBlock(fn MeterFunc, buf *[]float32, out []float32, channels int, frames int64) {
+	if fn == nil || frames <= 0 {
+		return
+	}
+	*buf = growFloats(*buf, 2*channels)
+	levels := BlockLevels{Frames: frames, Peak: (*buf)[:channels], RMS: (*buf)[channels:]}
+	n := int(frames) * channels
+	for ch := 0; ch < channels; ch++ {
+		var peak float32
+		var sum float64
+		for i := ch; i < n; i += channels {
+			v := out[i]
+			if v < 0 {
+				v = -v
+			}
+			if v > peak || v != v { // A NaN peak flags the corrupt block
+				peak = v
+			}
+			sum += float64(out[i]) * float64(out[i])
+		}
+		levels.Peak[ch] = peak
+		levels.RMS[ch] = float32(math.Sqrt(sum / float64(frames)))
+	}
+	fn(levels)
+}
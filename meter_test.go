@@ -0,0 +1,199 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// levelsOf computes the expected BlockLevels of interleaved samples.
+func levelsOf(out []float32, channels int) ([]float32, []float32) {
+	peak := make([]float32, channels)
+	rms := make([]float32, channels)
+	frames := len(out) / channels
+	for ch := 0; ch < channels; ch++ {
+		var sum float64
+		for i := ch; i < len(out); i += channels {
+			peak[ch] = float32(math.Max(float64(peak[ch]), math.Abs(float64(out[i]))))
+			sum += float64(out[i]) * float64(out[i])
+		}
+		rms[ch] = float32(math.Sqrt(sum / float64(frames)))
+	}
+	return peak, rms
+}
+
+// meterRecorder returns a MeterFunc keeping a copy of every block it receives.
+func meterRecorder(blocks *[]BlockLevels) MeterFunc {
+	return func(l BlockLevels) {
+		*blocks = append(*blocks, BlockLevels{
+			Frames: l.Frames,
+			Peak:   append([]float32(nil), l.Peak...),
+			RMS:    append([]float32(nil), l.RMS...),
+		})
+	}
+}
+
+func checkLevels(t *testing.T, got BlockLevels, out []float32, channels int) {
+	t.Helper()
+	if got.Frames != int64(len(out)/channels) {
+		t.Fatalf("Frames = %d, want %d", got.Frames, len(out)/channels)
+	}
+	peak, rms := levelsOf(out, channels)
+	if len(got.Peak) != channels || len(got.RMS) != channels {
+		t.Fatalf("got %d peaks and %d RMS values, want %d", len(got.Peak), len(got.RMS), channels)
+	}
+	for ch := 0; ch < channels; ch++ {
+		if got.Peak[ch] != peak[ch] {
+			t.Errorf("channel %d: peak %g, want %g", ch, got.Peak[ch], peak[ch])
+		}
+		if math.Abs(float64(got.RMS[ch]-rms[ch])) > 1e-6 {
+			t.Errorf("channel %d: RMS %g, want %g", ch, got.RMS[ch], rms[ch])
+		}
+	}
+}
+
+func TestMeterProcess(t *testing.T) {
+	left := genSine(1024, 440, 8000, 0.8)
+	right := genSine(1024, 1000, 8000, 0.25)
+	in := make([]float32, 2*len(left))
+	for i := range left {
+		in[2*i], in[2*i+1] = left[i], right[i]
+	}
+	for _, ct := range []ConverterType{SincFastest, Linear} {
+		var blocks []BlockLevels
+		conv, err := NewWithOptions(ct, 2, Options{Meter: meterRecorder(&blocks)})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		out, err := processBlock(t, conv, in, 2)
+		if err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		if len(blocks) != 1 {
+			t.Fatalf("%v: meter called %d times, want 1", ct, len(blocks))
+		}
+		checkLevels(t, blocks[0], out, 2)
+		if blocks[0].Peak[0] < 0.7 || blocks[0].Peak[1] > 0.3 {
+			t.Errorf("%v: peaks %v do not follow the channel levels", ct, blocks[0].Peak)
+		}
+		if blocks[0].Clipping() {
+			t.Errorf("%v: Clipping() = true below full scale", ct)
+		}
+		conv.Close()
+	}
+}
+
+func TestMeterNoOutput(t *testing.T) {
+	calls := 0
+	conv, err := NewWithOptions(SincFastest, 1, Options{Meter: func(BlockLevels) { calls++ }})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	out := make([]float32, 64)
+	data := SrcData{DataOut: out, OutputFrames: 64, SrcRatio: 1.5}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if data.OutputFramesGen != 0 || calls != 0 {
+		t.Errorf("generated %d frames and called the meter %d times, want 0 and 0", data.OutputFramesGen, calls)
+	}
+}
+
+func TestMeterClipping(t *testing.T) {
+	var blocks []BlockLevels
+	conv, err := NewWithOptions(Linear, 1, Options{Meter: meterRecorder(&blocks)})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	out, err := processBlock(t, conv, genSine(512, 300, 8000, 1.3), 1)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("meter called %d times, want 1", len(blocks))
+	}
+	checkLevels(t, blocks[0], out, 1)
+	if !blocks[0].Clipping() {
+		t.Errorf("Clipping() = false with peak %g", blocks[0].Peak[0])
+	}
+}
+
+func TestMeterOutputChannels(t *testing.T) {
+	in := make([]float32, 2*512)
+	sine := genSine(512, 440, 8000, 0.5)
+	for i, v := range sine {
+		in[2*i], in[2*i+1] = v, v
+	}
+	var blocks []BlockLevels
+	conv, err := NewWithOptions(SincFastest, 2, Options{OutputChannels: 1, Meter: meterRecorder(&blocks)})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	out := make([]float32, 1024)
+	data := SrcData{DataIn: in, InputFrames: 512, DataOut: out, OutputFrames: 1024, SrcRatio: 1.5}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("meter called %d times, want 1 (the inner converter must not meter too)", len(blocks))
+	}
+	checkLevels(t, blocks[0], out[:data.OutputFramesGen], 1)
+}
+
+func TestMeterChannelGroups(t *testing.T) {
+	const channels = 2 * maxChannels
+	in := make([]float32, 256*channels)
+	for i := range in {
+		in[i] = 0.5 * float32(i%channels) / channels
+	}
+	var blocks []BlockLevels
+	conv, err := NewWithOptions(SincFastest, channels, Options{Meter: meterRecorder(&blocks)})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	out, err := processBlock(t, conv, in, channels)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("meter called %d times, want 1", len(blocks))
+	}
+	checkLevels(t, blocks[0], out, channels)
+}
+
+func TestMeterCallbackRead(t *testing.T) {
+	src := genSine(4096, 440, 8000, 0.5)
+	fed := false
+	cb := func(interface{}) ([]float32, int64, error) {
+		if fed {
+			return nil, 0, nil
+		}
+		fed = true
+		return src, int64(len(src)), nil
+	}
+	var frames int64
+	conv, err := CallbackNewWithOptions(cb, Linear, 1, nil, Options{Meter: func(l BlockLevels) { frames += l.Frames }})
+	if err != nil {
+		t.Fatalf("CallbackNewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	out := make([]float32, 1000)
+	n, err := CallbackRead(conv, 0.5, 1000, out)
+	if err != nil {
+		t.Fatalf("CallbackRead failed: %v", err)
+	}
+	if frames != n {
+		t.Errorf("metered %d frames, CallbackRead returned %d", frames, n)
+	}
+}
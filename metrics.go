@@ -0,0 +1,106 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ProcessMetrics describes one completed Process call.
+type ProcessMetrics struct {
+	Converter     Converter     // Instance that ran, e.g. to attribute cost to a call leg
+	ConverterType ConverterType // Algorithm used
+	Channels      int
+	Duration      time.Duration // Wall time spent in Process
+	InputFrames   int64         // Input frames consumed
+	OutputFrames  int64         // Output frames generated
+	Ratio         float64       // Requested conversion ratio
+	Err           error         // Error returned by Process, nil on success
+}
+
+// MetricsCollector receives a ProcessMetrics after every Process call, including
+// the Process calls made by CallbackRead. It is called synchronously on the
+// processing goroutine, possibly from many goroutines at once, so it must be
+// goroutine-safe and cheap (e.g. observe a Prometheus histogram and counters).
+type MetricsCollector interface {
+	ObserveProcess(m ProcessMetrics)
+}
+
+// MetricsCollectorFunc adapts a function to the MetricsCollector interface.
+type MetricsCollectorFunc func(m ProcessMetrics)
+
+// ObserveProcess calls f(m).
+func (f MetricsCollectorFunc) ObserveProcess(m ProcessMetrics) {
+	f(m)
+}
+
+// metricsHolder wraps the collector so atomic.Value always stores one concrete type.
+type metricsHolder struct {
+	collector MetricsCollector
+}
+
+var metricsCollector atomic.Value // metricsHolder
+
+// SetMetricsCollector installs a collector for all converters in the process, or
+// removes it when c is nil. Without a collector Process does no timing at all.
+// It is safe to call at any time, from any goroutine.
+func SetMetricsCollector(c MetricsCollector) {
+	metricsCollector.Store(metricsHolder{collector: c})
+}
+
+// currentMetricsCollector returns the installed collector, or nil.
+func currentMetricsCollector() MetricsCollector {
+	h, _ := metricsCollector.Load().(metricsHolder)
+	return h.collector
+}
+
+// observeProcess reports a Process call of conv to c.
+func observeProcess(c MetricsCollector, conv Converter, data *SrcData, d time.Duration, err error) {
+	m := ProcessMetrics{
+		Converter:     conv,
+		ConverterType: converterTypeOf(conv),
+		Channels:      conv.GetChannels(),
+		Duration:      d,
+		Err:           err,
+	}
+	if data != nil {
+		m.InputFrames = data.InputFramesUsed
+		m.OutputFrames = data.OutputFramesGen
+		m.Ratio = data.SrcRatio
+	}
+	c.ObserveProcess(m)
+}
+
+// converterTypeOf returns the converter type c was created with.
+func converterTypeOf(c Converter) ConverterType {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.converterType
+	case *channelGroups:
+		return conv.converterType
+	case *channelMapper:
+		return converterTypeOf(conv.inner)
+	}
+	return ConverterType(-1)
+}
+
+// reportAs makes the metrics report the Process calls of the states inside c,
+// which CallbackRead makes, as calls of outer, the wrapper the caller holds.
+// Of channel groups, which run in lockstep, only the first one reports.
+func reportAs(c, outer Converter) {
+	switch conv := c.(type) {
+	case *srcState:
+		conv.outer = outer
+	case *channelGroups:
+		for i, state := range conv.groups {
+			state.outer, state.quiet = outer, i > 0
+		}
+	}
+}
@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	conv, err := New(SincFastest, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+
+	var mu sync.Mutex
+	var got []ProcessMetrics
+	SetMetricsCollector(MetricsCollectorFunc(func(m ProcessMetrics) {
+		if m.Converter != conv {
+			return // Another test's converter
+		}
+		mu.Lock()
+		got = append(got, m)
+		mu.Unlock()
+	}))
+	t.Cleanup(func() { SetMetricsCollector(nil) })
+
+	in := make([]float32, 2000)
+	out := make([]float32, 1000)
+	data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 500, SrcRatio: 0.5}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	bad := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 500, SrcRatio: -1}
+	if err := conv.Process(&bad); err == nil {
+		t.Fatal("expected error for bad ratio")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 2 {
+		t.Fatalf("collector called %d times, want 2", len(got))
+	}
+	m := got[0]
+	if m.ConverterType != SincFastest || m.Channels != 2 || m.Ratio != 0.5 || m.Err != nil {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+	if m.InputFrames != data.InputFramesUsed || m.OutputFrames != data.OutputFramesGen {
+		t.Errorf("frames in %d out %d, want %d %d", m.InputFrames, m.OutputFrames, data.InputFramesUsed, data.OutputFramesGen)
+	}
+	if m.Duration <= 0 {
+		t.Errorf("Duration = %v, want > 0", m.Duration)
+	}
+	if got[1].Err == nil {
+		t.Error("failed call reported without error")
+	}
+}
+
+// TestMetricsWrappers checks the converters inside a channel mapping or channel
+// groups are reported as the converter the caller holds, once per call, in
+// process and in callback mode.
+func TestMetricsWrappers(t *testing.T) {
+	var mu sync.Mutex
+	counts := map[Converter]int64{}
+	SetMetricsCollector(MetricsCollectorFunc(func(m ProcessMetrics) {
+		mu.Lock()
+		counts[m.Converter]++
+		mu.Unlock()
+	}))
+	t.Cleanup(func() { SetMetricsCollector(nil) })
+
+	const grouped = maxChannels + 2
+	source := func(channels int) CallbackFunc {
+		chunk := make([]float32, 64*channels)
+		return func(interface{}) ([]float32, int64, error) { return chunk, 64, nil }
+	}
+	var convs []Converter
+	add := func(c Converter, err error) Converter {
+		if err != nil {
+			t.Fatalf("creating converter failed: %v", err)
+		}
+		convs = append(convs, c)
+		return c
+	}
+	for _, c := range []Converter{
+		add(NewWithOptions(SincFastest, 2, Options{OutputChannels: 1})),
+		add(New(SincFastest, grouped)),
+	} {
+		ch := c.GetChannels()
+		data := SrcData{DataIn: make([]float32, 100*ch), InputFrames: 100, DataOut: make([]float32, 200*ch), OutputFrames: 200, SrcRatio: 1.5}
+		if err := c.Process(&data); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+	}
+	mapped := add(CallbackNewWithOptions(source(2), SincFastest, 2, nil, Options{OutputChannels: 1}))
+	if _, err := CallbackRead(mapped, 1.5, 300, make([]float32, 300)); err != nil {
+		t.Fatalf("CallbackRead failed: %v", err)
+	}
+	groups := add(CallbackNew(source(grouped), SincFastest, grouped, nil))
+	if _, err := CallbackRead(groups, 1.5, 300, make([]float32, 300*grouped)); err != nil {
+		t.Fatalf("CallbackRead failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, c := range convs {
+		if calls := c.(StatsReporter).Stats().ProcessCalls; calls == 0 || counts[c] != calls {
+			t.Errorf("converter %d reported %d times, want once per Process call (%d)", i, counts[c], calls)
+		}
+		c.Close()
+	}
+	if len(counts) != len(convs) {
+		t.Errorf("%d converters reported, want the %d the test holds", len(counts), len(convs))
+	}
+}
+
+// TestMetricsCollectorConcurrent swaps the collector while converters run on
+// several goroutines (run with -race).
+func TestMetricsCollectorConcurrent(t *testing.T) {
+	var calls atomic.Int64
+	collector := MetricsCollectorFunc(func(m ProcessMetrics) { calls.Add(1) })
+	t.Cleanup(func() { SetMetricsCollector(nil) })
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conv, err := New(Linear, 1)
+			if err != nil {
+				t.Errorf("New failed: %v", err)
+				return
+			}
+			defer conv.Close()
+			in := make([]float32, 256)
+			out := make([]float32, 512)
+			for i := 0; i < 200; i++ {
+				data := SrcData{DataIn: in, InputFrames: 256, DataOut: out, OutputFrames: 512, SrcRatio: 2}
+				if err := conv.Process(&data); err != nil {
+					t.Errorf("Process failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		SetMetricsCollector(collector)
+		SetMetricsCollector(nil)
+	}
+	SetMetricsCollector(collector)
+	wg.Wait()
+
+	before := calls.Load()
+	conv, err := New(Linear, 1)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	data := SrcData{DataIn: make([]float32, 10), InputFrames: 10, DataOut: make([]float32, 20), OutputFrames: 20, SrcRatio: 2}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if calls.Load() != before+1 {
+		t.Errorf("installed collector not called")
+	}
+}
@@ -0,0 +1,509 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+// Package mix mixes a voice stream with a looped background track, resamples
+// the mix and encodes it for telephony (8kHz u-law by default). It replaces the
+// MixResampleUlaw* and MixUlaw8kHz* functions and the Mixer of the
+// libsamplerate package: the settings are gathered in a MixerConfig, the
+// background position is kept by the Mixer, which can crossfade to another
+// background, and conditions the old functions printed to stdout are returned as
+// Warnings. It uses libsamplerate only through its public API.
+package mix
+
+import (
+	"fmt"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// --- Defaults ---
+const (
+	DefaultOutputRate = 8000.0 // Telephony rate, used when MixerConfig.OutputRate is 0
+	DefaultMixFactor  = 0.6    // Mix factor of the libsamplerate *DefaultFactor functions
+)
+
+// Encoding is the sample layout of the voice and background bytes.
+type Encoding int
+
+const (
+	S16LE Encoding = iota // Signed 16-bit little-endian PCM
+	ULaw                  // G.711 u-law, one byte per sample
+	S16BE                 // Signed 16-bit big-endian PCM (AIFF, L16 RTP payloads)
+)
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case S16LE:
+		return "s16le"
+	case S16BE:
+		return "s16be"
+	case ULaw:
+		return "u-law"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int(e))
+	}
+}
+
+// bytesPerSample returns the size of one sample, or 0 for an unknown encoding.
+func (e Encoding) bytesPerSample() int {
+	switch e {
+	case S16LE, S16BE:
+		return 2
+	case ULaw:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Warning reports a condition a mix call handled but the caller may want to
+// know about. Warnings are returned, never printed.
+type Warning int
+
+const (
+	// WarningEmptyVoice: the voice block was empty, so the output is empty and
+	// the background did not advance.
+	WarningEmptyVoice Warning = iota + 1
+	// WarningNoBackground: the mixer has no background, so the voice was mixed
+	// with silence.
+	WarningNoBackground
+	// WarningClipped: part of the mix was beyond full scale and limited by
+	// cfg.Clip; lower MixFactor or the levels of the inputs. See Clipped.
+	WarningClipped
+)
+
+// String describes the warning.
+func (w Warning) String() string {
+	switch w {
+	case WarningEmptyVoice:
+		return "voice block is empty"
+	case WarningNoBackground:
+		return "no background, voice mixed with silence"
+	case WarningClipped:
+		return "mix beyond full scale, clipped"
+	default:
+		return fmt.Sprintf("Warning(%d)", int(w))
+	}
+}
+
+// MixerConfig holds the settings of a Mixer.
+type MixerConfig struct {
+	// Encoding of the voice blocks and of the background.
+	Encoding Encoding
+	// InputRate is the sample rate of voice and background in Hz, e.g. 24000.
+	InputRate float64
+	// OutputRate is the rate of the mixed output in Hz; 0 means DefaultOutputRate.
+	// When it equals InputRate the mix is not resampled.
+	OutputRate float64
+	// PadWithSilence plays the background once and then mixes the voice with
+	// silence, instead of looping the background. It is the same as a Loop of
+	// LoopOnce.
+	PadWithSilence bool
+	// Loop selects how the background repeats, e.g. LoopN(3); the zero value
+	// loops forever. See Position for how it counts.
+	Loop libsamplerate.LoopPolicy
+	// MixFactor scales both streams before they are added, from 0.0 to 1.0. Use
+	// DefaultMixFactor when unsure; 0.5 or less avoids clipping entirely.
+	MixFactor float32
+	// Envelope, when set, replaces MixFactor with per-frame gains of the voice
+	// and of the background, e.g. FadeBackground to lower hold music when an
+	// agent connects. Frames are counted in input samples from the creation of
+	// the Mixer (see Frame), so a fade runs across Mix calls.
+	Envelope libsamplerate.Envelope
+	// ConverterType used for resampling; the zero value is SincBestQuality.
+	ConverterType libsamplerate.ConverterType
+	// Gate, if set, gates both streams and adds comfort noise before resampling.
+	// Create it with NewNoiseGate(InputRate, ...).
+	Gate *libsamplerate.NoiseGate
+	// Ducker, if set, lowers the background while the voice contains speech,
+	// before the gate. Create it with NewDucker(InputRate, ...).
+	Ducker *libsamplerate.Ducker
+	// Mode selects how voice and background are combined; the zero value is
+	// MixModeAdd. MixModeDuck needs Sidechain.
+	Mode libsamplerate.MixMode
+	// Sidechain lowers the background in proportion to the voice level in
+	// MixModeDuck, after the Ducker. Create it with NewSidechain(InputRate, ...).
+	Sidechain *libsamplerate.Sidechain
+	// Effects, if set, run on the mixed samples at the output rate, before
+	// encoding (e.g. a Gain followed by TelephoneBandLimit(8000)).
+	Effects libsamplerate.Effect
+	// Clip selects how Mix limits a mix louder than full scale before u-law
+	// encoding; the zero value is HardClip. MixFloat32 output is never clipped.
+	Clip libsamplerate.ClipStrategy
+	// TelephonyBandpass limits the output to the 300-3400Hz PSTN voice band with
+	// NewTelephonyBandpass(OutputRate), before Effects. The filter state carries
+	// over from block to block. OutputRate must be above 6800Hz.
+	TelephonyBandpass bool
+	// Arena, if set, supplies the converter and the sample buffers of the Mixer,
+	// so a goroutine creating a Mixer per request reuses them (see
+	// libsamplerate.Arena). Close the Mixer before resetting the arena; the
+	// samples returned by MixFloat32 are then no longer valid.
+	Arena *libsamplerate.Arena
+}
+
+func (cfg *MixerConfig) validate() error {
+	if cfg.Encoding.bytesPerSample() == 0 {
+		return fmt.Errorf("unknown encoding %v", cfg.Encoding)
+	}
+	if cfg.InputRate <= 0 {
+		return fmt.Errorf("input rate must be positive, got %g", cfg.InputRate)
+	}
+	if cfg.OutputRate == 0 {
+		cfg.OutputRate = DefaultOutputRate
+	}
+	if !libsamplerate.IsValidRatio(cfg.OutputRate / cfg.InputRate) {
+		return fmt.Errorf("unsupported conversion from %g Hz to %g Hz", cfg.InputRate, cfg.OutputRate)
+	}
+	if cfg.MixFactor < 0.0 || cfg.MixFactor > 1.0 {
+		return fmt.Errorf("mix factor must be between 0.0 and 1.0, got %g", cfg.MixFactor)
+	}
+	if !cfg.Clip.IsValid() {
+		return fmt.Errorf("unknown clip strategy %v", cfg.Clip)
+	}
+	if err := cfg.Loop.Validate(); err != nil {
+		return err
+	}
+	if cfg.PadWithSilence {
+		if cfg.Loop.Mode != libsamplerate.LoopForever && cfg.Loop.Mode != libsamplerate.LoopOnce {
+			return fmt.Errorf("PadWithSilence conflicts with loop policy %v", cfg.Loop)
+		}
+		cfg.Loop = libsamplerate.LoopPolicy{Mode: libsamplerate.LoopOnce}
+	}
+	if !cfg.Mode.IsValid() {
+		return fmt.Errorf("unknown mix mode %v", cfg.Mode)
+	}
+	if cfg.Mode == libsamplerate.MixModeDuck && cfg.Sidechain == nil {
+		return fmt.Errorf("mix mode %v needs a Sidechain", cfg.Mode)
+	}
+	return nil
+}
+
+// Mixer mixes blocks of voice with a looped background. Each block is resampled
+// on its own (the converter is flushed at its end), so the output of a block is
+// about len(block)*OutputRate/InputRate samples long; the background continues
+// from one block to the next.
+//
+// NOTE: A Mixer is NOT goroutine-safe.
+type Mixer struct {
+	cfg        MixerConfig
+	ratio      float64
+	conv       libsamplerate.Converter // nil when the mix is not resampled
+	bandpass   libsamplerate.Effect    // nil unless cfg.TelephonyBandpass
+	background []float32
+	pos        int   // Next background sample
+	frame      int64 // Voice samples mixed, the frame passed to cfg.Envelope
+	clipped    int64 // Samples beyond full scale in the output of Mix
+
+	// --- Crossfade State ---
+	previous    []float32 // Background being faded out, nil when none
+	previousPos int
+	fadeFrames  int
+	fadePos     int
+	curve       libsamplerate.CrossfadeCurve
+
+	voice  []float32 // Decoded voice block, then the mix
+	outBuf []float32 // Converter output scratch
+}
+
+// NewMixer creates a mixer for cfg with the given background, in cfg.Encoding
+// at cfg.InputRate. A nil or empty background mixes the voice with silence.
+func NewMixer(cfg MixerConfig, background []byte) (*Mixer, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	m := &Mixer{cfg: cfg, ratio: cfg.OutputRate / cfg.InputRate}
+	var err error
+	if m.background, err = decode(cfg.Encoding, background, nil, cfg.Arena); err != nil {
+		return nil, fmt.Errorf("background: %w", err)
+	}
+	if cfg.TelephonyBandpass {
+		if m.bandpass, err = libsamplerate.NewTelephonyBandpass(cfg.OutputRate); err != nil {
+			return nil, fmt.Errorf("telephony band-pass: %w", err)
+		}
+	}
+	if cfg.OutputRate != cfg.InputRate {
+		if m.conv, err = cfg.Arena.Converter(cfg.ConverterType, 1); err != nil {
+			return nil, fmt.Errorf("failed to create resampler: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Config returns the configuration of the mixer, with defaults filled in.
+func (m *Mixer) Config() MixerConfig {
+	return m.cfg
+}
+
+// Position returns the index of the next background sample to be mixed. When
+// the background loops forever it wraps to 0 at the end; with any other Loop
+// policy it counts samples across the repeats and stays at
+// Loop.Length(background) once the background has played, e.g. the background
+// length with PadWithSilence.
+func (m *Mixer) Position() int {
+	return m.pos
+}
+
+// Frame returns the number of voice samples mixed since the mixer was created,
+// the frame cfg.Envelope sees for the first sample of the next block.
+func (m *Mixer) Frame() int64 {
+	return m.frame
+}
+
+// CrossfadeTo switches the background to newBackground (in cfg.Encoding at
+// cfg.InputRate, played from its start with cfg.Loop), fading the current
+// background out and the new one in over durationFrames input samples. A
+// duration of 0 switches immediately. Starting a crossfade while another one is
+// running drops the background that was being faded out.
+func (m *Mixer) CrossfadeTo(newBackground []byte, durationFrames int, curve libsamplerate.CrossfadeCurve) error {
+	if durationFrames < 0 {
+		return fmt.Errorf("durationFrames must not be negative, got %d", durationFrames)
+	}
+	if !curve.IsValid() {
+		return fmt.Errorf("unknown crossfade curve %d", curve)
+	}
+	samples, err := decode(m.cfg.Encoding, newBackground, nil, m.cfg.Arena)
+	if err != nil {
+		return fmt.Errorf("background: %w", err)
+	}
+	m.previous, m.previousPos = m.background, m.pos
+	m.background, m.pos = samples, 0
+	m.fadeFrames, m.fadePos, m.curve = durationFrames, 0, curve
+	if durationFrames == 0 {
+		m.previous = nil
+	}
+	return nil
+}
+
+// Crossfading reports whether a crossfade is in progress.
+func (m *Mixer) Crossfading() bool {
+	return m.previous != nil && m.fadePos < m.fadeFrames
+}
+
+// Seek moves the background to sample pos, e.g. to restore a saved Position.
+func (m *Mixer) Seek(pos int) error {
+	end := len(m.background) - 1 // Last valid position when looping
+	if m.cfg.Loop.Mode != libsamplerate.LoopForever {
+		end = m.cfg.Loop.Length(len(m.background))
+	}
+	if pos < 0 || (pos > 0 && pos > end) {
+		return fmt.Errorf("position %d out of range [0, %d]", pos, maxInt(end, 0))
+	}
+	m.pos = pos
+	return nil
+}
+
+// Mix mixes one voice block (in cfg.Encoding at cfg.InputRate) with the
+// background and returns the result u-law encoded at the output rate.
+func (m *Mixer) Mix(voice []byte) ([]byte, []Warning, error) {
+	mixed, warnings, err := m.MixFloat32(voice)
+	if err != nil {
+		return nil, warnings, err
+	}
+	out := make([]byte, len(mixed))
+	return out, m.encode(mixed, out, warnings), nil
+}
+
+// MixInPlace is Mix writing the u-law mix over voice instead of allocating the
+// output, for continuous hold-music mixing. It needs a u-law mixer that does not
+// resample (cfg.Encoding ULaw and OutputRate equal to InputRate), so the output
+// is as long as voice.
+func (m *Mixer) MixInPlace(voice []byte) ([]Warning, error) {
+	if m.cfg.Encoding != ULaw || m.conv != nil {
+		return nil, fmt.Errorf("mixing in place needs %v at the input rate, not %v from %g Hz to %g Hz",
+			ULaw, m.cfg.Encoding, m.cfg.InputRate, m.cfg.OutputRate)
+	}
+	mixed, warnings, err := m.MixFloat32(voice)
+	if err != nil {
+		return warnings, err
+	}
+	return m.encode(mixed, voice, warnings), nil
+}
+
+// encode counts the clipped samples of mixed, limits them with cfg.Clip and
+// u-law encodes them into out, returning warnings with WarningClipped added if
+// needed.
+func (m *Mixer) encode(mixed []float32, out []byte, warnings []Warning) []Warning {
+	if n := libsamplerate.CountClipped(mixed); n > 0 {
+		m.clipped += int64(n)
+		warnings = append(warnings, WarningClipped)
+	}
+	m.cfg.Clip.Apply(mixed)
+	libsamplerate.FloatToUlawArray(mixed, out)
+	return warnings
+}
+
+// Clipped returns the number of output samples of Mix that were beyond full
+// scale before cfg.Clip limited them, since the mixer was created.
+func (m *Mixer) Clipped() int64 {
+	return m.clipped
+}
+
+// MixFloat32 is Mix returning float32 samples at the output rate. They are not
+// clipped, so they may exceed [-1.0, 1.0] if the mix is hot. The returned slice
+// is reused by the next call; copy it to keep it.
+func (m *Mixer) MixFloat32(voice []byte) ([]float32, []Warning, error) {
+	var warnings []Warning
+	if len(voice) == 0 {
+		return []float32{}, append(warnings, WarningEmptyVoice), nil
+	}
+	if len(m.background) == 0 {
+		warnings = append(warnings, WarningNoBackground)
+	}
+	var err error
+	if m.voice, err = decode(m.cfg.Encoding, voice, m.voice, m.cfg.Arena); err != nil {
+		return nil, warnings, fmt.Errorf("voice: %w", err)
+	}
+
+	g1, g2 := m.cfg.MixFactor, m.cfg.MixFactor
+	for i, v := range m.voice {
+		b := m.nextBackground()
+		if m.cfg.Ducker != nil {
+			b = m.cfg.Ducker.Duck(v, b)
+		}
+		if m.cfg.Mode == libsamplerate.MixModeDuck {
+			b = m.cfg.Sidechain.Duck(v, b)
+		}
+		if m.cfg.Envelope != nil {
+			g1, g2 = m.cfg.Envelope(m.frame + int64(i))
+		}
+		if m.cfg.Gate != nil {
+			m.voice[i] = m.cfg.Gate.MixGains(v, b, g1, g2)
+		} else {
+			m.voice[i] = v*g1 + b*g2
+		}
+	}
+	m.frame += int64(len(m.voice))
+
+	out := m.voice
+	if m.conv != nil {
+		if out, err = m.resample(m.voice); err != nil {
+			return nil, warnings, err
+		}
+	}
+	if m.bandpass != nil {
+		m.bandpass.Apply(out, 1)
+	}
+	if m.cfg.Effects != nil {
+		m.cfg.Effects.Apply(out, 1)
+	}
+	return out, warnings, nil
+}
+
+// nextBackground returns the next background sample, applying the crossfade if
+// one is running.
+func (m *Mixer) nextBackground() float32 {
+	in := nextSample(m.cfg.Loop, m.background, &m.pos)
+	if !m.Crossfading() {
+		m.previous = nil
+		return in
+	}
+	out := nextSample(m.cfg.Loop, m.previous, &m.previousPos)
+	gainOut, gainIn := m.curve.Gains(float64(m.fadePos) / float64(m.fadeFrames))
+	m.fadePos++
+	return out*float32(gainOut) + in*float32(gainIn)
+}
+
+// nextSample returns the sample of background at *pos, 0 past its end, and
+// advances *pos as loop says.
+func nextSample(loop libsamplerate.LoopPolicy, background []float32, pos *int) float32 {
+	var b float32
+	if loop.Mode != libsamplerate.LoopForever {
+		if j := loop.Index(*pos, len(background)); j >= 0 {
+			b = background[j]
+		}
+		if *pos < loop.Length(len(background)) {
+			*pos++
+		}
+	} else if *pos < len(background) {
+		b = background[*pos]
+		if *pos++; *pos == len(background) {
+			*pos = 0
+		}
+	}
+	return b
+}
+
+// resample converts one block with a freshly reset converter and flushes it.
+func (m *Mixer) resample(in []float32) ([]float32, error) {
+	if err := m.conv.Reset(); err != nil {
+		return nil, err
+	}
+	scratch := int(float64(len(in))*m.ratio) + 20
+	if cap(m.outBuf) < scratch {
+		m.outBuf = m.cfg.Arena.Floats(scratch)
+	}
+	buf := m.outBuf[:scratch]
+	out := make([]float32, 0, scratch)
+
+	data := libsamplerate.SrcData{
+		DataIn:      in,
+		InputFrames: int64(len(in)),
+		SrcRatio:    m.ratio,
+		EndOfInput:  true,
+	}
+	for {
+		data.DataOut, data.OutputFrames = buf, int64(len(buf))
+		if err := m.conv.Process(&data); err != nil {
+			return nil, fmt.Errorf("resampling failed: %w", err)
+		}
+		out = append(out, buf[:data.OutputFramesGen]...)
+		data.DataIn = data.DataIn[data.InputFramesUsed:]
+		data.InputFrames -= data.InputFramesUsed
+		if data.OutputFramesGen == 0 && data.InputFramesUsed == 0 {
+			return out, nil // Drained
+		}
+		if len(data.DataIn) == 0 {
+			data.DataIn = nil
+		}
+	}
+}
+
+// Close releases the converter of the mixer, handing it back to cfg.Arena if
+// set.
+func (m *Mixer) Close() error {
+	if m.conv == nil {
+		return nil
+	}
+	conv := m.conv
+	m.conv = nil // The arena may hand it to another mixer
+	return m.cfg.Arena.Release(conv)
+}
+
+// decode converts bytes in the given encoding to float32 samples in [-1.0, 1.0),
+// reusing buf when it is large enough and taking a new one from arena otherwise.
+func decode(enc Encoding, in []byte, buf []float32, arena *libsamplerate.Arena) ([]float32, error) {
+	size := enc.bytesPerSample()
+	if len(in)%size != 0 {
+		return nil, fmt.Errorf("size %d is not a multiple of the %v sample size %d", len(in), enc, size)
+	}
+	n := len(in) / size
+	if cap(buf) < n {
+		buf = arena.Floats(n)
+	}
+	buf = buf[:n]
+	if enc == ULaw {
+		libsamplerate.UlawToFloatArray(in, buf)
+		return buf, nil
+	}
+	format := libsamplerate.FormatS16LE
+	if enc == S16BE {
+		format = libsamplerate.FormatS16BE
+	}
+	if _, err := libsamplerate.DecodePCM(format, in, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
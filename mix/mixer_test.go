@@ -0,0 +1,754 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package mix
+
+import (
+	"bytes"
+	"math"
+	"runtime"
+	"testing"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// floatTone returns frames samples of a sine at freq Hz.
+func floatTone(frames int, freq, rate, amp float64) []float32 {
+	samples := make([]float32, frames)
+	for i := range samples {
+		samples[i] = float32(amp * math.Sin(2*math.Pi*freq*float64(i)/rate))
+	}
+	return samples
+}
+
+// s16Tone returns frames S16LE samples of a sine at freq Hz.
+func s16Tone(frames int, freq, rate, amp float64) []byte {
+	out := make([]byte, 2*frames)
+	libsamplerate.EncodePCM(libsamplerate.FormatS16LE, floatTone(frames, freq, rate, amp), out)
+	return out
+}
+
+// TestMixerMatchesMixResampleUlaw checks a block mixed by a Mixer equals the
+// output of the libsamplerate function it replaces.
+func TestMixerMatchesMixResampleUlaw(t *testing.T) {
+	voice := s16Tone(4800, 440, 24000, 0.5)
+	background := s16Tone(2400, 1000, 24000, 0.3)
+
+	pos := -1 // The old functions start at *pos + 1
+	want, err := libsamplerate.MixResampleUlaw24to8(voice, background, &pos, DefaultMixFactor)
+	if err != nil {
+		t.Fatalf("MixResampleUlaw24to8 failed: %v", err)
+	}
+
+	m, err := NewMixer(MixerConfig{InputRate: 24000, MixFactor: DefaultMixFactor}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	got, warnings, err := m.Mix(voice)
+	if err != nil {
+		t.Fatalf("Mix failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings %v", warnings)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Mix output (%d bytes) differs from MixResampleUlaw24to8 (%d bytes)", len(got), len(want))
+	}
+	if m.Position() != 0 { // 4800 samples of a 2400 sample background
+		t.Errorf("position %d, want 0", m.Position())
+	}
+}
+
+// TestMixerBackgroundContinues checks the background carries on across blocks
+// without skipping samples, and wraps.
+func TestMixerBackgroundContinues(t *testing.T) {
+	background := make([]byte, 100)
+	for i := range background {
+		background[i] = byte(i)
+	}
+	m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 8000, MixFactor: 1}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	silence := bytes.Repeat([]byte{0xFF}, 30) // u-law zero
+	var got []float32
+	for i := 0; i < 5; i++ {
+		out, _, err := m.MixFloat32(silence)
+		if err != nil {
+			t.Fatalf("MixFloat32 failed: %v", err)
+		}
+		got = append(got, out...)
+	}
+	want := make([]float32, len(background))
+	libsamplerate.UlawToFloatArray(background, want)
+	for i, v := range got {
+		if v != want[i%len(want)] {
+			t.Fatalf("sample %d is %g, want background sample %d (%g)", i, v, i%len(want), want[i%len(want)])
+		}
+	}
+	if m.Position() != 50 {
+		t.Errorf("position %d, want 50", m.Position())
+	}
+	if err := m.Seek(100); err == nil {
+		t.Error("expected error seeking past the background")
+	}
+}
+
+func TestMixerWarnings(t *testing.T) {
+	m, err := NewMixer(MixerConfig{InputRate: 16000, MixFactor: DefaultMixFactor}, nil)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+
+	out, warnings, err := m.Mix(nil)
+	if err != nil || len(out) != 0 {
+		t.Fatalf("empty voice: got %d bytes, %v", len(out), err)
+	}
+	if len(warnings) != 1 || warnings[0] != WarningEmptyVoice {
+		t.Errorf("empty voice: warnings %v, want [%v]", warnings, WarningEmptyVoice)
+	}
+
+	out, warnings, err = m.Mix(s16Tone(1600, 440, 16000, 0.5))
+	if err != nil {
+		t.Fatalf("Mix failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != WarningNoBackground {
+		t.Errorf("no background: warnings %v, want [%v]", warnings, WarningNoBackground)
+	}
+	if len(out) < 790 || len(out) > 810 {
+		t.Errorf("got %d output samples for 100ms, want about 800", len(out))
+	}
+}
+
+func TestMixerClippedWarning(t *testing.T) {
+	background := s16Tone(8000, 300, 16000, 0.9)
+	m, err := NewMixer(MixerConfig{InputRate: 16000, MixFactor: 1}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+
+	_, warnings, err := m.Mix(s16Tone(1600, 300, 16000, 0.9))
+	if err != nil {
+		t.Fatalf("Mix failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != WarningClipped || m.Clipped() == 0 {
+		t.Errorf("hot mix: warnings %v, %d clipped; want [%v]", warnings, m.Clipped(), WarningClipped)
+	}
+	total := m.Clipped()
+	if _, warnings, _ = m.Mix(s16Tone(1600, 300, 16000, 0.01)); len(warnings) != 0 || m.Clipped() != total {
+		t.Errorf("quiet voice: warnings %v, clipped count %d -> %d", warnings, total, m.Clipped())
+	}
+}
+
+func TestMixerConfigErrors(t *testing.T) {
+	for _, cfg := range []MixerConfig{
+		{InputRate: 0},
+		{InputRate: 24000, MixFactor: 1.5},
+		{InputRate: 24000, Encoding: Encoding(7)},
+		{InputRate: 24000, OutputRate: 24000 * 1000},
+		{InputRate: 24000, Clip: libsamplerate.ClipStrategy(9)},
+		{InputRate: 24000, Mode: libsamplerate.MixModeDuck},
+		{InputRate: 24000, Mode: libsamplerate.MixMode(5)},
+	} {
+		if _, err := NewMixer(cfg, nil); err == nil {
+			t.Errorf("%+v: expected error", cfg)
+		}
+	}
+	if _, err := NewMixer(MixerConfig{InputRate: 24000}, []byte{1, 2, 3}); err == nil {
+		t.Error("expected error for a partial S16LE sample")
+	}
+}
+
+func TestMixerTelephonyBandpass(t *testing.T) {
+	m, err := NewMixer(MixerConfig{InputRate: 24000, MixFactor: 1, TelephonyBandpass: true}, nil)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	var out []float32
+	hum := s16Tone(24000, 100, 24000, 0.5)     // Hum below the voice band
+	for pos := 0; pos < len(hum); pos += 960 { // 20ms blocks
+		block, _, err := m.MixFloat32(hum[pos : pos+960])
+		if err != nil {
+			t.Fatalf("MixFloat32 failed: %v", err)
+		}
+		out = append(out, block...)
+	}
+	sum := 0.0
+	for _, v := range out[1000:] {
+		sum += float64(v) * float64(v)
+	}
+	if rms := math.Sqrt(sum / float64(len(out)-1000)); rms > 0.01 { // 0.35 unfiltered
+		t.Errorf("100 Hz hum RMS is %g with the band-pass, want below 0.01", rms)
+	}
+
+	if _, err := NewMixer(MixerConfig{InputRate: 24000, OutputRate: 6000, TelephonyBandpass: true}, nil); err == nil {
+		t.Error("expected error for a band-pass above the output Nyquist")
+	}
+}
+
+func TestMixerPadWithSilence(t *testing.T) {
+	background := bytes.Repeat([]byte{0x10}, 50)
+	m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 8000, MixFactor: 1, PadWithSilence: true}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	silence := bytes.Repeat([]byte{libsamplerate.UlawSilence}, 30)
+	var got []float32
+	for i := 0; i < 3; i++ {
+		out, _, err := m.MixFloat32(silence)
+		if err != nil {
+			t.Fatalf("MixFloat32 failed: %v", err)
+		}
+		got = append(got, out...)
+	}
+	for i, v := range got {
+		if (i < 50) != (v != 0) {
+			t.Fatalf("sample %d is %g; want the background for 50 samples, then silence", i, v)
+		}
+	}
+	if m.Position() != 50 {
+		t.Errorf("position %d, want 50 (the background length)", m.Position())
+	}
+	if err := m.Seek(50); err != nil {
+		t.Errorf("Seek to the end failed: %v", err)
+	}
+	if err := m.Seek(51); err == nil {
+		t.Error("expected error seeking past the end")
+	}
+}
+
+func TestMixerLoopPolicy(t *testing.T) {
+	background := bytes.Repeat([]byte{0x10}, 20)
+	silence := bytes.Repeat([]byte{libsamplerate.UlawSilence}, 30)
+	for _, tt := range []struct {
+		loop    libsamplerate.LoopPolicy
+		audible int // Samples of background out of 90
+		pos     int
+	}{
+		{libsamplerate.LoopN(3), 60, 60},
+		{libsamplerate.LoopPolicy{Mode: libsamplerate.HoldLast}, 90, 20},
+		{libsamplerate.LoopPolicy{}, 90, 10},
+	} {
+		m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 8000, MixFactor: 1, Loop: tt.loop}, background)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		audible := 0
+		for i := 0; i < 3; i++ {
+			out, _, err := m.MixFloat32(silence)
+			if err != nil {
+				t.Fatalf("MixFloat32 failed: %v", err)
+			}
+			for j, v := range out {
+				if v != 0 {
+					if audible != 30*i+j {
+						t.Fatalf("%v: background at sample %d after silence", tt.loop, 30*i+j)
+					}
+					audible++
+				}
+			}
+		}
+		if audible != tt.audible || m.Position() != tt.pos {
+			t.Errorf("%v: %d samples of background, position %d; want %d, %d", tt.loop, audible, m.Position(), tt.audible, tt.pos)
+		}
+		m.Close()
+	}
+
+	if _, err := NewMixer(MixerConfig{InputRate: 8000, PadWithSilence: true, Loop: libsamplerate.LoopN(2)}, nil); err == nil {
+		t.Error("expected error for PadWithSilence with LoopN(2)")
+	}
+	if _, err := NewMixer(MixerConfig{InputRate: 8000, Loop: libsamplerate.LoopN(0)}, nil); err == nil {
+		t.Error("expected error for LoopN(0)")
+	}
+}
+
+func TestMixerS16BE(t *testing.T) {
+	voice := s16Tone(2400, 440, 24000, 0.5)
+	background := s16Tone(1200, 1000, 24000, 0.3)
+	swap := func(le []byte) []byte {
+		be := append([]byte(nil), le...)
+		libsamplerate.SwapByteOrder(libsamplerate.FormatS16LE, be)
+		return be
+	}
+	mLE, _ := NewMixer(MixerConfig{InputRate: 24000, MixFactor: 0.5}, background)
+	defer mLE.Close()
+	mBE, err := NewMixer(MixerConfig{Encoding: S16BE, InputRate: 24000, MixFactor: 0.5}, swap(background))
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer mBE.Close()
+	want, _, _ := mLE.Mix(voice)
+	got, _, err := mBE.Mix(swap(voice))
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("S16BE mix differs from S16LE (err %v)", err)
+	}
+	if S16BE.String() != "s16be" {
+		t.Errorf("S16BE is %q", S16BE)
+	}
+}
+
+func TestMixerDucker(t *testing.T) {
+	d, err := libsamplerate.NewDucker(8000, -40, math.Inf(-1), 0, 0)
+	if err != nil {
+		t.Fatalf("NewDucker failed: %v", err)
+	}
+	background := s16Tone(800, 1000, 8000, 0.3)
+	m, err := NewMixer(MixerConfig{InputRate: 8000, MixFactor: 1, Ducker: d}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	silent, _, err := m.MixFloat32(make([]byte, 1600))
+	if err != nil {
+		t.Fatalf("MixFloat32 failed: %v", err)
+	}
+	speech, _, err := m.MixFloat32(s16Tone(800, 440, 8000, 0.3))
+	if err != nil {
+		t.Fatalf("MixFloat32 failed: %v", err)
+	}
+	peak := func(s []float32) float64 {
+		p := 0.0
+		for _, v := range s {
+			p = math.Max(p, math.Abs(float64(v)))
+		}
+		return p
+	}
+	if p := peak(silent); p < 0.25 {
+		t.Errorf("background peak %g without speech, want it unducked", p)
+	}
+	if !d.Speech() || d.Gain() != 0 {
+		t.Errorf("ducker speech=%v gain=%g after the tone, want muted", d.Speech(), d.Gain())
+	}
+	if p := peak(speech[100:]); p > 0.31 { // The voice alone, no background on top
+		t.Errorf("peak %g during speech, want only the voice", p)
+	}
+}
+
+func TestMixerDuckMode(t *testing.T) {
+	s, err := libsamplerate.NewSidechain(8000, -30, 4, 0, 0)
+	if err != nil {
+		t.Fatalf("NewSidechain failed: %v", err)
+	}
+	background := s16Tone(800, 1000, 8000, 0.3)
+	m, err := NewMixer(MixerConfig{InputRate: 8000, MixFactor: 1, Mode: libsamplerate.MixModeDuck, Sidechain: s}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	if _, _, err := m.MixFloat32(s16Tone(4000, 440, 8000, 0.5)); err != nil {
+		t.Fatalf("MixFloat32 failed: %v", err)
+	}
+	if want := (-9.03 + 30) * 0.75; math.Abs(s.ReductionDB()-want) > 0.1 {
+		t.Errorf("reduction %.2f dB under a -9 dBov voice, want %.2f", s.ReductionDB(), want)
+	}
+}
+
+// TestMixerCrossfade checks CrossfadeTo ramps from the old background to the new
+// one over the given samples, across blocks.
+func TestMixerCrossfade(t *testing.T) {
+	oldBg, newBg := bytes.Repeat([]byte{0x90}, 64), bytes.Repeat([]byte{0x20}, 48) // u-law, opposite signs
+	levels := make([]float32, 2)
+	libsamplerate.UlawToFloatArray([]byte{0x90, 0x20}, levels)
+	m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 8000, MixFactor: 1}, oldBg)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	silence := bytes.Repeat([]byte{0xFF}, 40)
+	if _, _, err := m.MixFloat32(silence); err != nil {
+		t.Fatalf("MixFloat32 failed: %v", err)
+	}
+	if err := m.CrossfadeTo(newBg, 100, libsamplerate.CrossfadeLinear); err != nil {
+		t.Fatalf("CrossfadeTo failed: %v", err)
+	}
+	var got []float32
+	for i := 0; i < 4; i++ {
+		out, _, err := m.MixFloat32(silence)
+		if err != nil {
+			t.Fatalf("MixFloat32 failed: %v", err)
+		}
+		got = append(got, out...)
+	}
+	for k, v := range got {
+		want := levels[1]
+		if k < 100 {
+			tt := float64(k) / 100
+			want = levels[0]*float32(1-tt) + levels[1]*float32(tt)
+		}
+		if math.Abs(float64(v-want)) > 1e-6 {
+			t.Fatalf("sample %d = %g, want %g", k, v, want)
+		}
+	}
+	if m.Crossfading() {
+		t.Error("still crossfading after the fade")
+	}
+	if m.Position() != 160%48 {
+		t.Errorf("position %d in the new background, want %d", m.Position(), 160%48)
+	}
+
+	if err := m.CrossfadeTo(newBg, -1, libsamplerate.CrossfadeLinear); err == nil {
+		t.Error("expected error for a negative duration")
+	}
+	if err := m.CrossfadeTo(newBg, 10, libsamplerate.CrossfadeCurve(7)); err == nil {
+		t.Error("expected error for an unknown curve")
+	}
+}
+
+// TestMixerEnvelope checks a constant Envelope is the plain MixFactor and that
+// a fade follows the frames of the mixer across blocks, with and without a gate.
+func TestMixerEnvelope(t *testing.T) {
+	voice := s16Tone(4800, 300, 24000, 0.4)
+	music := s16Tone(2400, 1000, 24000, 0.3)
+	mixAll := func(cfg MixerConfig, blocks [][]byte) [][]byte {
+		t.Helper()
+		m, err := NewMixer(cfg, music)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		defer m.Close()
+		var outs [][]byte
+		for _, block := range blocks {
+			out, _, err := m.Mix(block)
+			if err != nil {
+				t.Fatalf("Mix failed: %v", err)
+			}
+			outs = append(outs, out)
+		}
+		if want := int64(len(blocks) * len(blocks[0]) / 2); m.Frame() != want {
+			t.Errorf("frame %d after the blocks, want %d", m.Frame(), want)
+		}
+		return outs
+	}
+
+	constant := func(int64) (float32, float32) { return 0.5, 0.5 }
+	want := mixAll(MixerConfig{InputRate: 24000, MixFactor: 0.5}, [][]byte{voice})
+	got := mixAll(MixerConfig{InputRate: 24000, Envelope: constant}, [][]byte{voice})
+	if !bytes.Equal(got[0], want[0]) {
+		t.Error("constant envelope differs from the mix factor")
+	}
+
+	// Fade the music out over the second of four blocks; the voice is silent
+	silence := make([]byte, len(voice))
+	env := libsamplerate.FadeBackground(1, 1, 0, 4800, 4800)
+	blocks := [][]byte{silence, silence, silence, silence}
+	for _, gated := range []bool{false, true} {
+		cfg := MixerConfig{InputRate: 24000, Envelope: env}
+		if gated {
+			cfg.Gate, _ = libsamplerate.NewNoiseGate(24000, -90, -120)
+		}
+		var levels []float64
+		for _, out := range mixAll(cfg, blocks) {
+			decoded := make([]float32, len(out))
+			libsamplerate.UlawToFloatArray(out, decoded)
+			var sum float64
+			for _, v := range decoded[200:1400] {
+				sum += float64(v) * float64(v)
+			}
+			levels = append(levels, math.Sqrt(sum/1200))
+		}
+		full := 0.3 / math.Sqrt2
+		if math.Abs(levels[0]-full) > 0.01 || levels[1] >= levels[0] || levels[2] > 0.001 || levels[3] > 0.001 {
+			t.Errorf("gated=%v: music levels %v over the fade, want %g, then falling to silence", gated, levels, full)
+		}
+	}
+}
+
+// TestMixerMixInPlace checks MixInPlace writes the bytes Mix returns, block
+// after block, and keeps the background going as Mix does.
+func TestMixerMixInPlace(t *testing.T) {
+	voice := make([]byte, 800)
+	libsamplerate.FloatToUlawArray(floatTone(800, 300, 8000, 0.8), voice)
+	music := make([]byte, 333) // Shorter than a block: loops within it
+	libsamplerate.FloatToUlawArray(floatTone(333, 1000, 8000, 0.7), music)
+
+	for _, factor := range []float32{0.5, 1.0} { // 1.0 clips
+		cfg := MixerConfig{Encoding: ULaw, InputRate: 8000, OutputRate: 8000, MixFactor: factor}
+		ref, err := NewMixer(cfg, music)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		m, _ := NewMixer(cfg, music)
+		for call := 0; call < 3; call++ {
+			want, wantWarnings, err := ref.Mix(voice)
+			if err != nil {
+				t.Fatalf("Mix failed: %v", err)
+			}
+			buf := append([]byte(nil), voice...)
+			warnings, err := m.MixInPlace(buf)
+			if err != nil {
+				t.Fatalf("MixInPlace failed: %v", err)
+			}
+			if !bytes.Equal(buf, want) || len(warnings) != len(wantWarnings) {
+				t.Fatalf("factor %g, call %d: MixInPlace differs from Mix", factor, call)
+			}
+			if m.Position() != ref.Position() {
+				t.Fatalf("factor %g, call %d: position %d, want %d", factor, call, m.Position(), ref.Position())
+			}
+		}
+		if m.Clipped() != ref.Clipped() || (m.Clipped() > 0) != (factor == 1.0) {
+			t.Errorf("factor %g: %d samples clipped, Mix clipped %d", factor, m.Clipped(), ref.Clipped())
+		}
+		ref.Close()
+		m.Close()
+	}
+
+	m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 24000}, music)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	if _, err := m.MixInPlace(voice); err == nil {
+		t.Error("expected error mixing in place with resampling")
+	}
+}
+
+// TestMixerArena checks a Mixer per request with an arena gives the output of
+// plain Mixers and stops allocating its buffers and converter.
+func TestMixerArena(t *testing.T) {
+	voice := s16Tone(480, 300, 24000, 0.4)
+	music := s16Tone(960, 1000, 24000, 0.2)
+	request := func(a *libsamplerate.Arena) []byte {
+		m, err := NewMixer(MixerConfig{InputRate: 24000, MixFactor: 0.5, Arena: a}, music)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		var out []byte
+		for block := 0; block < 2; block++ {
+			mixed, _, err := m.Mix(voice)
+			if err != nil {
+				t.Fatalf("Mix failed: %v", err)
+			}
+			out = append(out, mixed...)
+		}
+		if err := m.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		a.Reset()
+		return out
+	}
+
+	a := libsamplerate.NewArena()
+	defer a.Close()
+	for i := 0; i < 3; i++ {
+		if !bytes.Equal(request(a), request(nil)) {
+			t.Fatalf("request %d: mix differs with the arena", i)
+		}
+	}
+
+	allocated := func(a *libsamplerate.Arena) uint64 {
+		request(a) // Warm up
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		for i := 0; i < 20; i++ {
+			request(a)
+		}
+		runtime.ReadMemStats(&after)
+		return after.TotalAlloc - before.TotalAlloc
+	}
+	plain, arena := allocated(nil), allocated(a)
+	if arena*4 > plain {
+		t.Errorf("allocated %d bytes with the arena, %d without; want a quarter at most", arena, plain)
+	}
+}
+
+// rmsDBov returns the RMS level of samples in dBov.
+func rmsDBov(samples []float32) float64 {
+	sum := 0.0
+	for _, v := range samples {
+		sum += float64(v) * float64(v)
+	}
+	return 20 * math.Log10(math.Sqrt(sum/float64(len(samples))))
+}
+
+// TestMixerGate checks low-level hiss is gated and replaced by comfort noise,
+// or by silence without it, and that a loud voice passes the gate unchanged.
+func TestMixerGate(t *testing.T) {
+	const frames = 8000
+	const comfortDBov = -60.0
+
+	// Both legs carry only encoder hiss at about -55 dBov RMS
+	hiss1, hiss2 := make([]float32, frames), make([]float32, frames)
+	rng := uint32(12345)
+	for i := 0; i < frames; i++ {
+		rng = rng*1664525 + 1013904223
+		hiss1[i] = float32(int32(rng>>16)%100-50) / 32768
+		rng = rng*1664525 + 1013904223
+		hiss2[i] = float32(int32(rng>>16)%100-50) / 32768
+	}
+	ulaw := func(samples []float32) []byte {
+		out := make([]byte, len(samples))
+		libsamplerate.FloatToUlawArray(samples, out)
+		return out
+	}
+	mix := func(gate *libsamplerate.NoiseGate, voice, background []byte, factor float32) []float32 {
+		t.Helper()
+		m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 8000, OutputRate: 8000, MixFactor: factor, Gate: gate}, background)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		out, _, err := m.MixFloat32(voice)
+		if err != nil {
+			t.Fatalf("MixFloat32 failed: %v", err)
+		}
+		if len(out) != len(voice) {
+			t.Fatalf("output length %d, want %d", len(out), len(voice))
+		}
+		return out
+	}
+
+	gate, err := libsamplerate.NewNoiseGate(8000, -40, comfortDBov)
+	if err != nil {
+		t.Fatalf("NewNoiseGate failed: %v", err)
+	}
+	// Skip the hold time and the ramp, then the output is comfort noise only
+	out := mix(gate, ulaw(hiss1), ulaw(hiss2), 1)
+	if level := rmsDBov(out[frames/2:]); math.Abs(level-comfortDBov) > 3 {
+		t.Errorf("gated output level %.2f dBov, want about %.2f dBov", level, comfortDBov)
+	}
+
+	gate, _ = libsamplerate.NewNoiseGate(8000, -40, math.Inf(-1))
+	out = mix(gate, ulaw(hiss1), ulaw(hiss2), 1)
+	for i, v := range out[frames/2:] {
+		if v != 0 {
+			t.Fatalf("expected silence at %d, got %g", frames/2+i, v)
+		}
+	}
+
+	tone := ulaw(floatTone(frames/2, 440, 8000, 0.5))
+	silence := bytes.Repeat([]byte{0xFF}, frames/2) // u-law zero
+	gate, _ = libsamplerate.NewNoiseGate(8000, -40, -70)
+	gated, ref := mix(gate, tone, silence, 0.5), mix(nil, tone, silence, 0.5)
+	if diff := rmsDBov(gated[frames/8:]) - rmsDBov(ref[frames/8:]); math.Abs(diff) > 0.5 {
+		t.Errorf("open gate changed the level by %.2f dB", diff)
+	}
+}
+
+// TestMixerEffects checks Effects run on the mix at the output rate, before
+// encoding.
+func TestMixerEffects(t *testing.T) {
+	voice := s16Tone(4800, 440, 24000, 0.5)
+	background := s16Tone(2400, 1000, 24000, 0.3)
+	mix := func(effects libsamplerate.Effect) []byte {
+		t.Helper()
+		m, err := NewMixer(MixerConfig{InputRate: 24000, MixFactor: DefaultMixFactor, Effects: effects}, background)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		defer m.Close()
+		out, _, err := m.Mix(voice)
+		if err != nil {
+			t.Fatalf("Mix failed: %v", err)
+		}
+		return out
+	}
+
+	// Unity gain must not change the u-law output
+	plain := mix(nil)
+	if !bytes.Equal(mix(libsamplerate.NewGain(0)), plain) {
+		t.Error("unity gain changed the mix")
+	}
+
+	// The same as filtering the float output offline
+	band, err := libsamplerate.TelephoneBandLimit(8000)
+	if err != nil {
+		t.Fatalf("TelephoneBandLimit failed: %v", err)
+	}
+	m, err := NewMixer(MixerConfig{InputRate: 24000, MixFactor: DefaultMixFactor}, background)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	defer m.Close()
+	floats, _, err := m.MixFloat32(voice)
+	if err != nil {
+		t.Fatalf("MixFloat32 failed: %v", err)
+	}
+	offline, _ := libsamplerate.TelephoneBandLimit(8000)
+	offline.Apply(floats, 1)
+	want := make([]byte, len(floats))
+	libsamplerate.FloatToUlawArray(floats, want)
+	if !bytes.Equal(mix(band), want) {
+		t.Error("band-limited mix differs from filtering the float output")
+	}
+
+	// A -6 dB trim lowers the level by about 6 dB
+	trimmed := mix(libsamplerate.NewGain(-6))
+	level := func(ulaw []byte) float64 {
+		decoded := make([]float32, len(ulaw))
+		libsamplerate.UlawToFloatArray(ulaw, decoded)
+		return rmsDBov(decoded)
+	}
+	if diff := level(trimmed) - level(plain); math.Abs(diff+6) > 0.5 {
+		t.Errorf("-6 dB trim changed the level by %.2f dB", diff)
+	}
+}
+
+// TestMixerClipStrategies checks the strategies reach the u-law output of a hot
+// mix, and that Clipped counts it whichever strategy limits it.
+func TestMixerClipStrategies(t *testing.T) {
+	loud := make([]byte, 800) // Mixed with itself at factor 1: peaks at 1.2
+	libsamplerate.FloatToUlawArray(floatTone(800, 400, 8000, 0.6), loud)
+	mix := func(clip libsamplerate.ClipStrategy, factor float32) ([]float32, int64) {
+		t.Helper()
+		m, err := NewMixer(MixerConfig{Encoding: ULaw, InputRate: 8000, OutputRate: 8000, MixFactor: factor, Clip: clip}, loud)
+		if err != nil {
+			t.Fatalf("%v: NewMixer failed: %v", clip, err)
+		}
+		out, _, err := m.Mix(loud)
+		if err != nil {
+			t.Fatalf("%v: Mix failed: %v", clip, err)
+		}
+		decoded := make([]float32, len(out))
+		libsamplerate.UlawToFloatArray(out, decoded)
+		return decoded, m.Clipped()
+	}
+	peak := func(samples []float32) float64 {
+		p := 0.0
+		for _, v := range samples {
+			p = math.Max(p, math.Abs(float64(v)))
+		}
+		return p
+	}
+	// flatTop counts the samples stuck at the peak, i.e. clipped flat
+	flatTop := func(samples []float32) int {
+		p, n := peak(samples), 0
+		for _, v := range samples {
+			if math.Abs(float64(v)) >= p {
+				n++
+			}
+		}
+		return n
+	}
+
+	hard, hardClipped := mix(libsamplerate.HardClip, 1)
+	soft, softClipped := mix(libsamplerate.SoftClip, 1)
+	norm, _ := mix(libsamplerate.Normalize, 1)
+	if peak(hard) < 0.97 || flatTop(hard) < 100 {
+		t.Errorf("HardClip: peak %g with %d samples at it, expected a flat topped mix", peak(hard), flatTop(hard))
+	}
+	if n := flatTop(soft); n >= flatTop(hard) {
+		t.Errorf("SoftClip: %d samples at the peak, want fewer than the %d of HardClip", n, flatTop(hard))
+	}
+	if p := peak(norm); p < 0.95 || p > 1.0 {
+		t.Errorf("Normalize peak %g, want just below full scale", p)
+	}
+	for i := range norm {
+		if d := math.Abs(float64(norm[i]) - float64(hard[i])/1.2); d > 0.05 && math.Abs(float64(hard[i])) < 0.9 {
+			t.Fatalf("Normalize sample %d is %g, expected the unclipped mix scaled down (~%g)", i, norm[i], hard[i]/1.2)
+		}
+	}
+	if hardClipped < 100 || softClipped != hardClipped {
+		t.Errorf("clipped %d with HardClip, %d with SoftClip; want the same count of 100 or more", hardClipped, softClipped)
+	}
+	if _, n := mix(libsamplerate.HardClip, 0.5); n != 0 {
+		t.Errorf("%d samples clipped in a mix at half scale", n)
+	}
+}
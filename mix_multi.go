@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// MixInput is one mono source of MixResampleMulti.
+type MixInput struct {
+	Data   []byte       // Samples in Format
+	Format SampleFormat // Layout of Data; set it explicitly, the zero value is FormatU8
+	Rate   float64      // Sample rate of Data in Hz
+	Gain   float32      // Linear gain applied before mixing; 1.0 keeps the level, 0 mutes
+	Loop   bool         // Repeat the source to cover the whole output
+
+	// ConverterType used to resample this source; the zero value is
+	// SincBestQuality, like the other mix functions.
+	ConverterType ConverterType
+}
+
+// MixResampleMulti resamples each input to outRate independently and mixes them,
+// e.g. a 44.1kHz music bed under 8kHz telephone audio. The output is as long as
+// the longest input that does not loop (at least one must not); looping inputs
+// are resampled once and repeated. Integer output formats are clipped by
+// EncodePCM; float formats are not.
+//
+// Args:
+//
+//	inputs: The sources to mix, each at its own rate and in its own format.
+//	outRate: The output sample rate in Hz.
+//	outFormat: The sample format of the returned bytes.
+//
+// Returns:
+//
+//	The mono mix in outFormat at outRate, or nil and an error.
+func MixResampleMulti(inputs []MixInput, outRate float64, outFormat SampleFormat) ([]byte, error) {
+	if !outFormat.IsValid() {
+		return nil, fmt.Errorf("unknown output sample format %d", outFormat)
+	}
+	if outRate <= 0 || math.IsNaN(outRate) || math.IsInf(outRate, 0) {
+		return nil, fmt.Errorf("output rate must be positive, got %f", outRate)
+	}
+	looped := 0
+	for i, in := range inputs {
+		if !in.Format.IsValid() {
+			return nil, fmt.Errorf("input %d: unknown sample format %d", i, in.Format)
+		}
+		if len(in.Data)%in.Format.BytesPerSample() != 0 {
+			return nil, fmt.Errorf("input %d: size (%d) not multiple of sample size (%d)", i, len(in.Data), in.Format.BytesPerSample())
+		}
+		if in.Rate <= 0 || math.IsNaN(in.Rate) || math.IsInf(in.Rate, 0) {
+			return nil, fmt.Errorf("input %d: rate must be positive, got %f", i, in.Rate)
+		}
+		if ratio := outRate / in.Rate; isBadSrcRatio(ratio) {
+			return nil, fmt.Errorf("input %d: %f Hz to %f Hz gives invalid ratio %f: %w", i, in.Rate, outRate, ratio, mapError(ErrBadSrcRatio))
+		}
+		if in.Loop {
+			looped++
+		}
+	}
+	if looped == len(inputs) {
+		return nil, fmt.Errorf("mix needs at least one input that does not loop")
+	}
+
+	// The decode and converter scratch buffers are shared by all inputs
+	m := &multiMixer{scratch: make([]float32, fanoutScratchFrames)}
+	var mix []float32
+	for _, loop := range []bool{false, true} { // The unlooped inputs set the length
+		for i, in := range inputs {
+			if in.Loop != loop {
+				continue
+			}
+			res, err := m.resample(in, outRate)
+			if err != nil {
+				return nil, fmt.Errorf("input %d: %w", i, err)
+			}
+			if !loop {
+				for len(mix) < len(res) {
+					mix = append(mix, 0)
+				}
+				for j, v := range res {
+					mix[j] += v * in.Gain
+				}
+			} else if len(res) > 0 {
+				for j := range mix {
+					mix[j] += res[j%len(res)] * in.Gain
+				}
+			}
+		}
+	}
+
+	out := make([]byte, len(mix)*outFormat.BytesPerSample())
+	if _, err := EncodePCM(outFormat, mix, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// multiMixer holds the buffers MixResampleMulti reuses from input to input.
+type multiMixer struct {
+	decodeBuf []float32
+	scratch   []float32
+	out       []float32
+}
+
+// resample decodes one input and converts it to outRate. The result is only
+// valid until the next call.
+func (m *multiMixer) resample(in MixInput, outRate float64) ([]float32, error) {
+	m.decodeBuf = growFloats(m.decodeBuf, len(in.Data)/in.Format.BytesPerSample())
+	if _, err := DecodePCM(in.Format, in.Data, m.decodeBuf); err != nil {
+		return nil, err
+	}
+	if in.Rate == outRate || len(m.decodeBuf) == 0 {
+		return m.decodeBuf, nil
+	}
+	conv, err := New(in.ConverterType, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	defer conv.Close()
+	stage := &streamStage{conv: conv, ratio: outRate / in.Rate, scratch: m.scratch, out: m.out}
+	if err := stage.process(m.decodeBuf, 1, true); err != nil {
+		return nil, err
+	}
+	m.out = stage.out
+	return m.out, nil
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMixResampleMulti mixes 8kHz speech with a looping 44.1kHz bed and checks
+// the result against resampling and mixing each source by hand.
+func TestMixResampleMulti(t *testing.T) {
+	voice := genSine(8000, 440, 8000, 0.5)
+	bed := genSine(11025, 1000, 44100, 0.5) // 250ms, looped
+	voiceBytes := make([]byte, 2*len(voice))
+	EncodePCM(FormatS16LE, voice, voiceBytes)
+	bedBytes := make([]byte, 4*len(bed))
+	EncodePCM(FormatF32LE, bed, bedBytes)
+
+	out, err := MixResampleMulti([]MixInput{
+		{Data: bedBytes, Format: FormatF32LE, Rate: 44100, Gain: 0.25, Loop: true, ConverterType: SincFastest},
+		{Data: voiceBytes, Format: FormatS16LE, Rate: 8000, Gain: 0.5},
+	}, 8000, FormatF32LE)
+	if err != nil {
+		t.Fatalf("MixResampleMulti failed: %v", err)
+	}
+	got := make([]float32, len(out)/4)
+	DecodePCM(FormatF32LE, out, got)
+	if len(got) != len(voice) {
+		t.Fatalf("got %d samples, want %d (the unlooped voice)", len(got), len(voice))
+	}
+
+	conv, _ := New(SincFastest, 1)
+	bedOut, err := processAll(conv, bed, 1, 8000.0/44100.0)
+	conv.Close()
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+	voiceIn := make([]float32, len(voice))
+	DecodePCM(FormatS16LE, voiceBytes, voiceIn)
+	for i := range got {
+		want := voiceIn[i]*0.5 + bedOut[i%len(bedOut)]*0.25
+		if math.Abs(float64(got[i]-want)) > 1e-6 {
+			t.Fatalf("sample %d is %g, want %g", i, got[i], want)
+		}
+	}
+}
+
+func TestMixResampleMultiLength(t *testing.T) {
+	short := make([]byte, 2*4410) // 100ms at 44.1kHz
+	long := make([]byte, 2*16000) // 1s at 16kHz
+	out, err := MixResampleMulti([]MixInput{
+		{Data: short, Format: FormatS16LE, Rate: 44100, Gain: 1},
+		{Data: long, Format: FormatS16LE, Rate: 16000, Gain: 1},
+	}, 8000, FormatS16LE)
+	if err != nil {
+		t.Fatalf("MixResampleMulti failed: %v", err)
+	}
+	if n := len(out) / 2; n < 7990 || n > 8010 {
+		t.Errorf("got %d samples, want about 8000 (the longest input)", n)
+	}
+}
+
+func TestMixResampleMultiErrors(t *testing.T) {
+	ok := MixInput{Data: make([]byte, 200), Format: FormatS16LE, Rate: 8000, Gain: 1}
+	tests := []struct {
+		name      string
+		inputs    []MixInput
+		outRate   float64
+		outFormat SampleFormat
+	}{
+		{"no inputs", nil, 8000, FormatS16LE},
+		{"only loops", []MixInput{{Data: ok.Data, Format: ok.Format, Rate: 8000, Loop: true}}, 8000, FormatS16LE},
+		{"bad out format", []MixInput{ok}, 8000, SampleFormat(99)},
+		{"bad out rate", []MixInput{ok}, math.NaN(), FormatS16LE},
+		{"bad in format", []MixInput{ok, {Data: ok.Data, Format: SampleFormat(99), Rate: 8000}}, 8000, FormatS16LE},
+		{"partial sample", []MixInput{{Data: make([]byte, 3), Format: FormatS16LE, Rate: 8000}}, 8000, FormatS16LE},
+		{"bad in rate", []MixInput{{Data: ok.Data, Format: ok.Format, Rate: 0}}, 8000, FormatS16LE},
+		{"bad ratio", []MixInput{{Data: ok.Data, Format: ok.Format, Rate: 8}}, 48000, FormatS16LE},
+	}
+	for _, tt := range tests {
+		if _, err := MixResampleMulti(tt.inputs, tt.outRate, tt.outFormat); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// CrossfadeCurve selects the gain curves of a crossfade.
+type CrossfadeCurve int
+
+const (
+	// CrossfadeLinear ramps the gains linearly; the gains always sum to 1, which
+	// suits correlated material (e.g. two takes of the same loop).
+	CrossfadeLinear CrossfadeCurve = iota
+	// CrossfadeEqualPower uses sine/cosine gains so the power stays constant, which
+	// avoids the level dip of a linear fade between unrelated sources.
+	CrossfadeEqualPower
+)
+
+// IsValid reports whether c is one of the defined curves.
+func (c CrossfadeCurve) IsValid() bool {
+	return c == CrossfadeLinear || c == CrossfadeEqualPower
+}
+
+// Gains returns the gain of the source fading out and of the source fading in
+// at t, from 0.0 at the start of the crossfade to 1.0 at its end.
+func (c CrossfadeCurve) Gains(t float64) (gainOut, gainIn float64) {
+	if c == CrossfadeEqualPower {
+		return math.Cos(t * math.Pi / 2.0), math.Sin(t * math.Pi / 2.0)
+	}
+	return 1.0 - t, t
+}
+
+// Mixer mixes 8kHz u-Law voice blocks with a looped background source, like
+// MixUlaw8kHz, but keeps the background position itself and can switch the
+// background with a crossfade instead of the pop of swapping buffers between calls.
+//
+// NOTE: A Mixer is NOT goroutine-safe.
+//
+// Deprecated: use mix.Mixer (package github.com/keereets/go-libsamplerate/mix),
+// which crossfades with CrossfadeTo, loops the background with a LoopPolicy and
+// takes any encoding and rate.
+type Mixer struct {
+	mixFactor float32
+
+	current *LoopingSource // Background source
+
+	// --- Crossfade State ---
+	previous   *LoopingSource // Source being faded out
+	fadeFrames int
+	fadePos    int
+	curve      CrossfadeCurve
+}
+
+// NewMixer creates a mixer with the given u-Law background (nil or empty for
+// silence). The background is decoded into a LoopingSource, see Source.
+//
+// Deprecated: use mix.NewMixer (package github.com/keereets/go-libsamplerate/mix).
+func NewMixer(background []byte, mixFactor float32) (*Mixer, error) {
+	if mixFactor < 0.0 || mixFactor > 1.0 {
+		return nil, fmt.Errorf("mixFactor must be between 0.0 and 1.0, got %f", mixFactor)
+	}
+	return &Mixer{mixFactor: mixFactor, current: NewLoopingSourceUlaw(background)}, nil
+}
+
+// Mix mixes one block of 8kHz u-Law voice with the background and returns the
+// u-Law result, as long as voice. The background continues where the previous
+// call stopped.
+func (m *Mixer) Mix(voice []byte) ([]byte, error) {
+	background := make([]float32, len(voice))
+	for i := range background {
+		background[i] = m.nextBackground()
+	}
+	return mixUlawBlock(voice, background, m.mixFactor), nil
+}
+
+// CrossfadeTo switches the background to newSource (played from its start), fading
+// the current background out and the new one in over durationFrames samples. A
+// duration of 0 switches immediately. Starting a crossfade while another one is
+// running drops the source that was being faded out.
+func (m *Mixer) CrossfadeTo(newSource []byte, durationFrames int, curve CrossfadeCurve) error {
+	return m.CrossfadeToSource(NewLoopingSourceUlaw(newSource), durationFrames, curve)
+}
+
+// CrossfadeToSource is CrossfadeTo with a u-Law LoopingSource, which is played
+// from its current position with its own loop flag and gain.
+func (m *Mixer) CrossfadeToSource(newSource *LoopingSource, durationFrames int, curve CrossfadeCurve) error {
+	if newSource == nil {
+		return fmt.Errorf("source must not be nil")
+	}
+	if durationFrames < 0 {
+		return fmt.Errorf("durationFrames must not be negative, got %d", durationFrames)
+	}
+	if !curve.IsValid() {
+		return fmt.Errorf("unknown crossfade curve %d", curve)
+	}
+	m.previous, m.current = m.current, newSource
+	m.fadeFrames, m.fadePos = durationFrames, 0
+	m.curve = curve
+	if durationFrames == 0 {
+		m.previous = nil
+	}
+	return nil
+}
+
+// Crossfading reports whether a crossfade is in progress.
+func (m *Mixer) Crossfading() bool {
+	return m.previous != nil && m.fadePos < m.fadeFrames
+}
+
+// Position returns the next sample read from the current background.
+func (m *Mixer) Position() int {
+	return m.current.Position()
+}
+
+// Source returns the current background source, e.g. to persist its position.
+func (m *Mixer) Source() *LoopingSource {
+	return m.current
+}
+
+// nextBackground returns the next background sample in the int16 range, applying
+// the crossfade if one is running.
+func (m *Mixer) nextBackground() float32 {
+	in := m.current.next()
+	if !m.Crossfading() {
+		m.previous = nil
+		return in
+	}
+	out := m.previous.next()
+	t := float64(m.fadePos) / float64(m.fadeFrames)
+	m.fadePos++
+
+	gainOut, gainIn := m.curve.Gains(t)
+	return out*float32(gainOut) + in*float32(gainIn)
+}
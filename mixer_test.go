@@ -0,0 +1,106 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// maxUlawStep returns the largest jump between consecutive decoded samples.
+func maxUlawStep(data []byte) float64 {
+	step := 0.0
+	for i := 1; i < len(data); i++ {
+		step = math.Max(step, math.Abs(float64(ulawToLinearGo(data[i]))-float64(ulawToLinearGo(data[i-1]))))
+	}
+	return step
+}
+
+// constUlaw returns n u-Law samples of a constant level.
+func constUlaw(n int, level int16) []byte {
+	return bytes.Repeat([]byte{linearToUlawGo(level)}, n)
+}
+
+func TestMixerMatchesMixUlaw8kHz(t *testing.T) {
+	voice := encodeUlawTone(800, 440, 0.3)
+	music := encodeUlawTone(300, 200, 0.4)
+
+	pos := -1
+	want, err := MixUlaw8kHz(voice, music, &pos, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("MixUlaw8kHz failed: %v", err)
+	}
+	m, err := NewMixer(music, mixFactorDefault)
+	if err != nil {
+		t.Fatalf("NewMixer failed: %v", err)
+	}
+	got, err := m.Mix(voice)
+	if err != nil {
+		t.Fatalf("Mix failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("Mixer output differs from MixUlaw8kHz")
+	}
+	if m.Position() != pos {
+		t.Errorf("position %d, want %d", m.Position(), pos)
+	}
+
+	if _, err := NewMixer(music, 1.5); err == nil {
+		t.Error("expected error for mixFactor > 1")
+	}
+}
+
+func TestMixerCrossfade(t *testing.T) {
+	silence := constUlaw(1600, 0)
+	high := constUlaw(100, 8000)
+	low := constUlaw(100, -8000)
+
+	for _, curve := range []CrossfadeCurve{CrossfadeLinear, CrossfadeEqualPower} {
+		m, err := NewMixer(high, 1.0)
+		if err != nil {
+			t.Fatalf("NewMixer failed: %v", err)
+		}
+		before, _ := m.Mix(silence[:400])
+		if err := m.CrossfadeTo(low, 800, curve); err != nil {
+			t.Fatalf("CrossfadeTo failed: %v", err)
+		}
+		var out []byte
+		for off := 0; off < len(silence); off += 160 { // 20 ms blocks
+			block, _ := m.Mix(silence[off : off+160])
+			out = append(out, block...)
+		}
+		all := append(before, out...)
+
+		if step := maxUlawStep(all); step > 600 {
+			t.Errorf("curve %d: step of %.0f during crossfade, want a smooth ramp", curve, step)
+		}
+		if m.Crossfading() {
+			t.Errorf("curve %d: crossfade should be over", curve)
+		}
+		if mid := ulawToLinearGo(out[400]); math.Abs(float64(mid)) > 300 {
+			t.Errorf("curve %d: midpoint level %d, want about 0", curve, mid)
+		}
+		if !bytes.Equal(out[800:], constUlaw(800, ulawToLinearGo(low[0]))) {
+			t.Errorf("curve %d: output after the crossfade is not the new source", curve)
+		}
+	}
+
+	// An immediate switch jumps
+	m, _ := NewMixer(high, 1.0)
+	before, _ := m.Mix(silence[:10])
+	m.CrossfadeTo(low, 0, CrossfadeLinear)
+	after, _ := m.Mix(silence[:10])
+	if step := maxUlawStep(append(before, after...)); step < 10000 {
+		t.Errorf("immediate switch step %.0f, want a jump", step)
+	}
+	if err := m.CrossfadeTo(low, -1, CrossfadeLinear); err == nil {
+		t.Error("expected error for negative duration")
+	}
+}
@@ -17,7 +17,7 @@ import (
 	"math"
 	"testing"
 	// Assumes types, constants, New, Simple, Process, Close, CallbackNew, CallbackRead etc. are defined
-	// Assumes helpers genWindowedSinesGo, calculateSnrGo, interleaveDataGo, deinterleaveDataGo etc. are in test_utils.go
+	// Assumes helpers genWindowedSinesGo, calculateSnrGo etc. are in test_utils.go
 )
 
 const (
@@ -118,7 +118,7 @@ func testMultiChannelSimple(t *testing.T, converter ConverterType, channels int,
 	}
 
 	// Interleave
-	err := interleaveDataGo(inputSerial, inputInterleaved, frames, channels)
+	_, err := Interleave(inputInterleaved, inputSerial)
 	if err != nil {
 		t.Fatalf("%s Interleaving failed: %v", logPrefix, err)
 	}
@@ -153,7 +153,7 @@ func testMultiChannelSimple(t *testing.T, converter ConverterType, channels int,
 	}
 
 	// De-interleave
-	err = deinterleaveDataGo(outputInterleaved[:actualOutputFrames*channels], outputSerial, actualOutputFrames, channels)
+	_, err = Deinterleave(outputSerial, outputInterleaved[:actualOutputFrames*channels])
 	if err != nil {
 		t.Fatalf("%s Deinterleaving failed: %v", logPrefix, err)
 	}
@@ -210,7 +210,7 @@ func testMultiChannelProcess(t *testing.T, converter ConverterType, channels int
 	}
 
 	// Interleave
-	err := interleaveDataGo(inputSerial, inputInterleaved, frames, channels)
+	_, err := Interleave(inputInterleaved, inputSerial)
 	if err != nil {
 		t.Fatalf("%s Interleaving failed: %v", logPrefix, err)
 	}
@@ -347,7 +347,7 @@ func testMultiChannelProcess(t *testing.T, converter ConverterType, channels int
 	}
 
 	// De-interleave
-	err = deinterleaveDataGo(outputInterleaved[:actualOutputFrames*channels], outputSerial, actualOutputFrames, channels)
+	_, err = Deinterleave(outputSerial, outputInterleaved[:actualOutputFrames*channels])
 	if err != nil {
 		t.Fatalf("%s Deinterleaving failed: %v", logPrefix, err)
 	}
@@ -445,7 +445,7 @@ func testMultiChannelCallback(t *testing.T, converter ConverterType, channels in
 	}
 
 	// Interleave
-	err := interleaveDataGo(inputSerial, inputInterleaved, frames, channels)
+	_, err := Interleave(inputInterleaved, inputSerial)
 	if err != nil {
 		t.Fatalf("%s Interleaving failed: %v", logPrefix, err)
 	}
@@ -513,7 +513,7 @@ func testMultiChannelCallback(t *testing.T, converter ConverterType, channels in
 	}
 
 	// De-interleave
-	err = deinterleaveDataGo(totalOutputInterleaved[:actualOutputFrames*channels], outputSerial, actualOutputFrames, channels)
+	_, err = Deinterleave(outputSerial, totalOutputInterleaved[:actualOutputFrames*channels])
 	if err != nil {
 		t.Fatalf("%s Deinterleaving failed: %v", logPrefix, err)
 	}
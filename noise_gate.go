@@ -0,0 +1,190 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// --- Noise Gate Constants ---
+const (
+	noiseGateDefaultHoldMs    = 100.0 // Keep the gate open this long after the level drops
+	noiseGateDefaultReleaseMs = 20.0  // Envelope follower release time
+	noiseGateDefaultRampMs    = 5.0   // Gain ramp used when opening/closing, avoids clicks
+	noiseGateLegs             = 2     // One gate state per mixer input stream
+	noiseGateSeed             = 0x9E3779B9
+)
+
+// gateLeg holds the per-stream state of the noise gate.
+type gateLeg struct {
+	envelope float64 // Peak envelope follower output (linear, 0.0 to 1.0)
+	holdLeft int     // Frames left before the gate starts closing
+	gain     float64 // Current gain applied to the stream (0.0 = closed, 1.0 = open)
+}
+
+// NoiseGate is a stateful noise gate with optional comfort-noise insertion,
+// used by the mixer of package mix (MixerConfig.Gate).
+//
+// Each mixer input stream ("leg") is gated independently: when its level stays
+// below ThresholdDBov for longer than the hold time, the stream is faded out so
+// the hiss of one encoder does not leak into the silent stretches of the mix.
+// When both legs are closed, low-level white noise at ComfortNoiseDBov is added
+// to the output so the far end does not hear a "dead" line.
+//
+// A NoiseGate keeps state across calls so it can be used on a chunked stream.
+// NOTE: A NoiseGate is NOT goroutine-safe; use one instance per stream.
+type NoiseGate struct {
+	thresholdDBov    float64
+	comfortNoiseDBov float64
+
+	threshold    float64 // Linear threshold
+	comfortPeak  float64 // Peak amplitude of the uniform comfort noise (0 disables it)
+	releaseCoef  float64 // Per-frame envelope decay
+	rampStep     float64 // Per-frame gain change while opening/closing
+	holdFrames   int     // Hold time in frames
+	legs         [noiseGateLegs]gateLeg
+	rngState     uint32 // xorshift32 state for the comfort noise generator
+	sampleRateHz float64
+}
+
+// NewNoiseGate creates a noise gate for streams at the given sample rate.
+//
+// Args:
+//
+//	sampleRate: Sample rate (Hz) of the streams the gate will see (the input rate of the
+//	            mixer, e.g. 8000 for 8kHz u-law).
+//	thresholdDBov: Level (dBov, <= 0) below which a stream is considered silent.
+//	comfortNoiseDBov: RMS level (dBov, < 0) of the comfort noise inserted when both streams
+//	                  are gated. Use math.Inf(-1) to disable comfort noise.
+//
+// Returns:
+//
+//	A new *NoiseGate, or nil and an error if the parameters are invalid.
+func NewNoiseGate(sampleRate, thresholdDBov, comfortNoiseDBov float64) (*NoiseGate, error) {
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return nil, fmt.Errorf("sampleRate must be positive, got %f", sampleRate)
+	}
+	if thresholdDBov > 0 || math.IsNaN(thresholdDBov) {
+		return nil, fmt.Errorf("thresholdDBov must be <= 0, got %f", thresholdDBov)
+	}
+	if comfortNoiseDBov >= 0 || math.IsNaN(comfortNoiseDBov) {
+		return nil, fmt.Errorf("comfortNoiseDBov must be < 0, got %f", comfortNoiseDBov)
+	}
+
+	g := &NoiseGate{
+		thresholdDBov:    thresholdDBov,
+		comfortNoiseDBov: comfortNoiseDBov,
+		sampleRateHz:     sampleRate,
+		threshold:        dbovToLinear(thresholdDBov),
+		holdFrames:       int(math.Round(sampleRate * noiseGateDefaultHoldMs / 1000.0)),
+		releaseCoef:      math.Exp(-1.0 / (sampleRate * noiseGateDefaultReleaseMs / 1000.0)),
+		rampStep:         1.0 / math.Max(1.0, sampleRate*noiseGateDefaultRampMs/1000.0),
+	}
+	if !math.IsInf(comfortNoiseDBov, -1) {
+		// Uniform noise in [-a, a] has an RMS of a/sqrt(3)
+		g.comfortPeak = dbovToLinear(comfortNoiseDBov) * math.Sqrt(3.0)
+	}
+	g.Reset()
+	return g, nil
+}
+
+// Reset clears the gate state (envelopes, hold counters and noise generator).
+func (g *NoiseGate) Reset() {
+	if g == nil {
+		return
+	}
+	for i := range g.legs {
+		g.legs[i] = gateLeg{}
+	}
+	g.rngState = noiseGateSeed
+}
+
+// ThresholdDBov returns the configured gate threshold in dBov.
+func (g *NoiseGate) ThresholdDBov() float64 { return g.thresholdDBov }
+
+// ComfortNoiseDBov returns the configured comfort noise level in dBov.
+func (g *NoiseGate) ComfortNoiseDBov() float64 { return g.comfortNoiseDBov }
+
+// SampleRate returns the sample rate the gate timings were computed for.
+func (g *NoiseGate) SampleRate() float64 { return g.sampleRateHz }
+
+// apply runs one sample of the given leg through the gate and returns the gated sample.
+func (g *NoiseGate) apply(leg int, x float32) float32 {
+	l := &g.legs[leg]
+
+	// Peak envelope follower with exponential release
+	absX := math.Abs(float64(x))
+	l.envelope *= g.releaseCoef
+	if absX > l.envelope {
+		l.envelope = absX
+	}
+
+	target := 0.0
+	if l.envelope >= g.threshold {
+		l.holdLeft = g.holdFrames
+		target = 1.0
+	} else if l.holdLeft > 0 {
+		l.holdLeft--
+		target = 1.0
+	}
+
+	// Ramp the gain towards the target to avoid clicks
+	if l.gain < target {
+		l.gain = math.Min(target, l.gain+g.rampStep)
+	} else if l.gain > target {
+		l.gain = math.Max(target, l.gain-g.rampStep)
+	}
+
+	return float32(float64(x) * l.gain)
+}
+
+// comfortNoise returns the next comfort noise sample, scaled by how closed
+// both legs are (full level only when every leg is fully gated).
+func (g *NoiseGate) comfortNoise() float32 {
+	if g.comfortPeak == 0 {
+		return 0
+	}
+	openness := 0.0
+	for i := range g.legs {
+		openness = math.Max(openness, g.legs[i].gain)
+	}
+	if openness >= 1.0 {
+		return 0
+	}
+
+	// xorshift32: cheap, deterministic and allocation free
+	x := g.rngState
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	g.rngState = x
+
+	uniform := float64(x)/float64(math.MaxUint32)*2.0 - 1.0 // [-1.0, 1.0]
+	return float32(uniform * g.comfortPeak * (1.0 - openness))
+}
+
+// Mix gates both samples (in [-1.0, 1.0)), mixes them with mixFactor and adds
+// comfort noise when needed. It is the per-sample step of the gated mixers.
+func (g *NoiseGate) Mix(sample1, sample2, mixFactor float32) float32 {
+	return g.MixGains(sample1, sample2, mixFactor, mixFactor)
+}
+
+// MixGains is Mix with a factor per sample, g1 for sample1 and g2 for sample2,
+// e.g. those of an Envelope.
+func (g *NoiseGate) MixGains(sample1, sample2, g1, g2 float32) float32 {
+	sample1 = g.apply(0, sample1)
+	sample2 = g.apply(1, sample2)
+	return sample1*g1 + sample2*g2 + g.comfortNoise()
+}
+
+// dbovToLinear converts a dBov level to a linear amplitude relative to full scale.
+func dbovToLinear(dbov float64) float64 {
+	return math.Pow(10.0, dbov/20.0)
+}
@@ -0,0 +1,48 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewNoiseGateValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		rate       float64
+		threshold  float64
+		comfort    float64
+		shouldFail bool
+	}{
+		{"Valid", 8000, -50, -70, false},
+		{"NoComfortNoise", 8000, -50, math.Inf(-1), false},
+		{"ZeroRate", 0, -50, -70, true},
+		{"PositiveThreshold", 8000, 3, -70, true},
+		{"ZeroComfort", 8000, -50, 0, true},
+		{"NaNThreshold", 8000, math.NaN(), -70, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewNoiseGate(tt.rate, tt.threshold, tt.comfort)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got gate %+v", g)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if g.ThresholdDBov() != tt.threshold || g.SampleRate() != tt.rate {
+				t.Errorf("gate parameters not stored: threshold=%f rate=%f", g.ThresholdDBov(), g.SampleRate())
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// NaNPolicy selects how Process handles NaN and Inf input samples. A single NaN
+// reaching the sinc filter history corrupts every output sample computed from it,
+// i.e. the whole following filter length, not just one sample.
+type NaNPolicy int
+
+const (
+	// NaNPass hands the input to the converter unchecked (the default, and the
+	// original behavior).
+	NaNPass NaNPolicy = iota
+	// NaNZero replaces NaN and Inf samples with silence before resampling. The
+	// caller's input slice is not modified.
+	NaNZero
+	// NaNError rejects a block containing NaN or Inf with ErrNonFiniteInput,
+	// before any of it is consumed.
+	NaNError
+)
+
+// Options holds optional converter settings for NewWithOptions and
+// CallbackNewWithOptions. The zero value gives the same converter as New.
+type Options struct {
+	// NaNPolicy is checked on every new input block.
+	NaNPolicy NaNPolicy
+
+	// ResetOnCorruption checks the output of every Process call: if it contains
+	// NaN or Inf (e.g. from input let through by NaNPass), those samples are
+	// replaced with silence and the filter history is cleared so the following
+	// blocks recover, instead of staying corrupted until the caller resets.
+	// Stats.CorruptionResets counts these recoveries.
+	ResetOnCorruption bool
+
+	// OutputChannels, when non-zero, makes the converter produce frames with this
+	// many channels from input with the channel count given to the constructor,
+	// e.g. 1 for the stereo to mono step of a telephony path. Downmixing is done
+	// before resampling and upmixing after it, in the same Process call, so the
+	// converter runs at the smaller channel count. GetChannels still reports the
+	// input channel count.
+	OutputChannels int
+
+	// ChannelMatrix overrides the default mix, as one row of input channel
+	// coefficients per output channel: out[j] = sum of ChannelMatrix[j][i]*in[i].
+	// The default averages inputs into outputs when downmixing ((L+R)/2 for
+	// stereo to mono) and duplicates them when upmixing.
+	ChannelMatrix [][]float32
+
+	// ChannelGains, when set, holds one linear gain per output channel, applied
+	// to the converted frames as they are written, before effects, fades and
+	// Meter: e.g. 0.5 trims a channel by 6dB and 0 mutes it, without another pass
+	// over the interleaved output. With OutputChannels or ChannelMatrix the gains
+	// are folded into the mix matrix, so they cost nothing. Its length must match
+	// the output channel count.
+	ChannelGains []float64
+
+	// LowLatency replaces the filter of the sinc converters with a short one
+	// (~32 taps, see low_latency_coeffs.go) for interactive voice, where the
+	// delay of SincBestQuality is too high: a streaming converter holds back about
+	// 16 input frames instead of about 143. In exchange the pass band is flat only
+	// up to ~0.34 of the lower sample rate (~2.7 kHz at 8 kHz), with ~82 dB stop
+	// band attenuation. All sinc types behave the same with it; Linear and
+	// ZeroOrderHold have no filter delay and ignore it.
+	LowLatency bool
+
+	// ForceFilter keeps the converter running at a ratio of exactly 1.0. By
+	// default such input is copied straight to the output (see passthrough.go),
+	// which is exact but does not band-limit it; set ForceFilter when the low-pass
+	// of the sinc converters is wanted anyway.
+	ForceFilter bool
+
+	// Deterministic guarantees the same output bits for the same input, calls and
+	// settings on every platform and in every release of the library, e.g. for
+	// cache keys computed over converted audio. The output always comes from the
+	// reference converter arithmetic, which is pinned: the filter coefficients are
+	// constant tables, sums accumulate in a fixed order, and a multiply is never
+	// fused with an add into an FMA instruction (as the Go compiler may do on
+	// arm64, or on amd64 with GOAMD64=v3); shortcuts such as the ratio 1.0
+	// passthrough are not taken. Golden vectors in deterministic_test.go fail on
+	// any drift, so a fix that changes the bits must leave this path as it is. A
+	// PreFilter or effects attached to the converter are outside the guarantee.
+	Deterministic bool
+
+	// Meter, when set, receives the per-channel peak and RMS of the output of
+	// every Process call that generated frames (and of every block read by
+	// CallbackRead), measured after effects and channel mixing, e.g. to drive VU
+	// meters and clipping warnings without scanning the output again.
+	Meter MeterFunc
+
+	// ErrorOnOutputFull makes Process return ErrOutputFull when DataOut filled up
+	// before all of the input was consumed, instead of returning nil and leaving
+	// the caller to notice InputFramesUsed < InputFrames, so a short output buffer
+	// shows up as an error rather than as truncated audio. The block is still
+	// processed: InputFramesUsed and OutputFramesGen are set and the output is
+	// valid, so the caller can go on with the rest of the input. CallbackRead is
+	// not affected. ProcessAppend avoids the case by growing its output.
+	ErrorOnOutputFull bool
+
+	// FadeFrames, when positive, fades the first FadeFrames output frames after
+	// creation and after every Reset in with a raised cosine, and Drain fades the
+	// last ones out, so that resampled segments spliced together do not click
+	// where the filter starts from an empty history. 5 ms (e.g. 40 frames at 8kHz)
+	// is usually enough. The Fade effect is the linear, attach-yourself variant.
+	FadeFrames int
+
+	// TrimLeadingTransient drops the output frames at the start of a stream (after
+	// creation and after every Reset) whose sinc filter reaches back before the
+	// first input frame: the filter's fade-in from its empty history, about
+	// coeffHalfLen/indexInc input frames (~143 for SincBestQuality, times the
+	// ratio when upsampling), so converted clips start cleanly. The output is
+	// shorter by as much, and starts that far into the input: Stats.TrimmedFrames
+	// counts the dropped frames, to shift output positions by. Counters, Meter and
+	// OnBlock see the trimmed output. Linear and ZeroOrderHold, and input copied
+	// through at ratio 1.0, have no transient and are left alone.
+	TrimLeadingTransient bool
+
+	// UpsampleRolloff, when non-zero, makes the sinc converters band-limit strictly
+	// when upsampling: the pass band ends at 1-UpsampleRolloff of the input Nyquist
+	// frequency and the stop band (~100 dB) starts at the Nyquist frequency itself,
+	// where by default the whole input band is passed. E.g. 0.15 for 8kHz input
+	// passes up to 3.4kHz and removes codec artifacts above the voice band. A
+	// smaller rolloff gives a steeper, longer filter; valid values are 0.02 to 0.5.
+	// The filter is chosen per Process call, so at ratios up to 1 the converter
+	// keeps its own. Linear and ZeroOrderHold ignore it.
+	UpsampleRolloff float64
+
+	// OnBlock, when set, is called after every successful Process call that
+	// consumed or generated frames, and in callback mode after every block
+	// CallbackRead converts, with the input frames that block consumed and the
+	// output frames it produced. Summing them maps each output chunk to the
+	// input it came from, e.g. for A/V sync bookkeeping, without differencing
+	// the cumulative counters of Stats. It runs synchronously on the processing
+	// goroutine, after effects, fades and Meter, once per call for the whole
+	// converter, also when OutputChannels or more than 128 channels make it
+	// several converters inside.
+	OnBlock func(inUsed, outGen int64)
+}
+
+// NewWithOptions is New with optional settings.
+func NewWithOptions(converterType ConverterType, channels int, opts Options) (Converter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if channels < 1 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	mix, err := newChannelMix(channels, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts, err = opts.withChannelGains(channels, mix); err != nil {
+		return nil, err
+	}
+	if mix == nil {
+		c, err := New(converterType, channels)
+		if err != nil {
+			return nil, err
+		}
+		applyOptions(c, opts)
+		return c, nil
+	}
+
+	inner, err := New(converterType, mix.innerChannels())
+	if err != nil {
+		return nil, err
+	}
+	applyOptions(inner, opts.innerOptions())
+	return newChannelMapper(inner.(converter), mix, opts), nil
+}
+
+// CallbackNewWithOptions is CallbackNew with optional settings.
+func CallbackNewWithOptions(cbFunc CallbackFunc, converterType ConverterType, channels int, userData interface{}, opts Options) (Converter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if channels < 1 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	mix, err := newChannelMix(channels, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts, err = opts.withChannelGains(channels, mix); err != nil {
+		return nil, err
+	}
+	if mix == nil {
+		c, err := CallbackNew(cbFunc, converterType, channels, userData)
+		if err != nil {
+			return nil, err
+		}
+		applyOptions(c, opts)
+		return c, nil
+	}
+
+	if cbFunc == nil {
+		return nil, mapError(ErrBadCallback)
+	}
+	feed := &mapperFeed{cbFunc: cbFunc, userData: userData, mix: mix, nanPolicy: opts.NaNPolicy}
+	inner, err := CallbackNew(mapperCallback, converterType, mix.innerChannels(), feed)
+	if err != nil {
+		return nil, err
+	}
+	applyOptions(inner, opts.innerOptions())
+	return newChannelMapper(inner.(converter), mix, opts), nil
+}
+
+func (opts Options) validate() error {
+	switch opts.NaNPolicy {
+	case NaNPass, NaNZero, NaNError:
+	default:
+		return fmt.Errorf("unknown NaN policy %d", opts.NaNPolicy)
+	}
+	if opts.FadeFrames < 0 {
+		return fmt.Errorf("fade length must not be negative, got %d frames", opts.FadeFrames)
+	}
+	if opts.UpsampleRolloff != 0 && !(opts.UpsampleRolloff >= minUpsampleRolloff && opts.UpsampleRolloff <= maxUpsampleRolloff) {
+		return fmt.Errorf("upsample rolloff must be between %g and %g, got %g", minUpsampleRolloff, maxUpsampleRolloff, opts.UpsampleRolloff)
+	}
+	for ch, g := range opts.ChannelGains {
+		if math.IsNaN(g) || math.IsInf(g, 0) {
+			return fmt.Errorf("gain of channel %d must be finite, got %g", ch, g)
+		}
+	}
+	return nil
+}
+
+// withChannelGains checks ChannelGains against the output channel count and
+// returns opts with a private copy of them, or with none when they are folded
+// into mix.
+func (opts Options) withChannelGains(channels int, mix *channelMix) (Options, error) {
+	if len(opts.ChannelGains) == 0 {
+		opts.ChannelGains = nil
+		return opts, nil
+	}
+	out := channels
+	if mix != nil {
+		out = mix.out
+	}
+	if len(opts.ChannelGains) != out {
+		return opts, fmt.Errorf("ChannelGains has %d gains, want one per output channel (%d)", len(opts.ChannelGains), out)
+	}
+	if mix != nil {
+		for j, row := range mix.matrix {
+			for i := range row {
+				row[i] *= float32(opts.ChannelGains[j])
+			}
+		}
+		opts.ChannelGains = nil
+		return opts, nil
+	}
+	opts.ChannelGains = append([]float64(nil), opts.ChannelGains...)
+	return opts, nil
+}
+
+// applyChannelGains applies Options.ChannelGains to frames interleaved output
+// frames.
+func applyChannelGains(out []float32, gains []float64, frames int64) {
+	if len(gains) == 0 || frames <= 0 {
+		return
+	}
+	channels := len(gains)
+	for fr := int64(0); fr < frames; fr++ {
+		frame := out[fr*int64(channels):][:channels]
+		for ch, g := range gains {
+			frame[ch] = float32(float64(frame[ch]) * g)
+		}
+	}
+}
+
+// innerOptions returns opts for a converter wrapped in a channelMapper or
+// channelGroups, without the settings the wrapper applies itself, once, on the
+// layout the caller sees: the NaN policy on the input and the postChain on the
+// output.
+func (opts Options) innerOptions() Options {
+	opts.NaNPolicy = NaNPass
+	opts.ChannelGains = nil
+	opts.FadeFrames = 0
+	opts.Meter, opts.OnBlock = nil, nil
+	opts.ErrorOnOutputFull = false
+	return opts
+}
+
+// applyOptions stores the options in a freshly created converter.
+func applyOptions(c Converter, opts Options) {
+	switch conv := c.(type) {
+	case *srcState:
+		conv.options = opts
+		conv.post = newPostChain(conv.channels, opts)
+		if opts.LowLatency {
+			conv.useLowLatencyFilter()
+		}
+		if opts.UpsampleRolloff != 0 {
+			conv.useStrictBandFilter(opts.UpsampleRolloff)
+		}
+	case *channelGroups:
+		conv.options = opts
+		conv.post = newPostChain(conv.channels, opts)
+		if conv.feeder != nil {
+			conv.feeder.nanPolicy = opts.NaNPolicy
+		}
+		inner := opts.innerOptions()
+		inner.ResetOnCorruption = false // Recovery is done here, for all groups at once
+		for _, state := range conv.groups {
+			applyOptions(state, inner)
+		}
+	}
+}
+
+// useLowLatencyFilter switches a freshly created sinc converter to
+// lowLatencyCoeffs. Its buffer, sized for the original longer filter, is kept.
+func (state *srcState) useLowLatencyFilter() {
+	filter, ok := state.privateData.(*sincFilter)
+	if !ok || filter == nil {
+		return
+	}
+	filter.coeffs = lowLatencyCoeffs.Coeffs
+	filter.coeffHalfLen = len(filter.coeffs) - 2
+	filter.indexInc = lowLatencyCoeffs.Increment
+}
+
+// sanitizeInput applies the NaN policy to the first n samples of in. It returns in
+// itself when it is clean, or a copy in *buf with the bad samples zeroed.
+func sanitizeInput(policy NaNPolicy, in []float32, n int, buf *[]float32) ([]float32, ErrorCode) {
+	n = minInt(n, len(in))
+	first := firstNonFinite(in[:n])
+	if policy == NaNPass || first < 0 {
+		return in, ErrNoError
+	}
+	if policy == NaNError {
+		return nil, ErrNonFiniteInput
+	}
+	*buf = growFloats(*buf, n)
+	clean := *buf
+	copy(clean, in[:n])
+	zeroNonFinite(clean[first:])
+	return clean, ErrNoError
+}
+
+// firstNonFinite returns the index of the first NaN or Inf sample, or -1.
+func firstNonFinite(samples []float32) int {
+	for i, v := range samples {
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return i
+		}
+	}
+	return -1
+}
+
+// zeroNonFinite replaces NaN and Inf samples with 0.
+func zeroNonFinite(samples []float32) {
+	for i, v := range samples {
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			samples[i] = 0
+		}
+	}
+}
+
+// recoverFromCorruption zeroes NaN/Inf output samples and, if there were any,
+// clears the filter history. It reports whether a recovery happened.
+func (state *srcState) recoverFromCorruption(out []float32) bool {
+	if firstNonFinite(out) < 0 {
+		return false
+	}
+	zeroNonFinite(out)
+	state.resetFilter()
+	state.stats.CorruptionResets++
+	return true
+}
+
+// resetFilter clears the filter history only; unlike Reset it keeps buffered
+// callback input, counters and attached hooks.
+func (state *srcState) resetFilter() {
+	state.vt.reset(state)
+	state.lastPosition = 0.0
+	state.lastRatio = 0.0
+	state.filtered = false
+}
@@ -0,0 +1,351 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNaNPolicyZero(t *testing.T) {
+	for _, ct := range []ConverterType{SincFastest, Linear, ZeroOrderHold} {
+		dirty := genSine(512, 440, 8000, 0.5)
+		dirty[100], dirty[300] = float32(math.NaN()), float32(math.Inf(1))
+		clean := append([]float32(nil), dirty...)
+		clean[100], clean[300] = 0, 0
+
+		conv, err := NewWithOptions(ct, 1, Options{NaNPolicy: NaNZero})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		ref, _ := New(ct, 1)
+		got, err := processBlock(t, conv, dirty, 1)
+		if err != nil {
+			t.Fatalf("%v: Process failed: %v", ct, err)
+		}
+		want, _ := processBlock(t, ref, clean, 1)
+		if len(got) != len(want) {
+			t.Fatalf("%v: got %d samples, want %d", ct, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%v: sample %d is %g, want %g", ct, i, got[i], want[i])
+			}
+		}
+		if !math.IsNaN(float64(dirty[100])) {
+			t.Errorf("%v: caller's input was modified", ct)
+		}
+		conv.Close()
+		ref.Close()
+	}
+}
+
+func TestNaNPolicyError(t *testing.T) {
+	conv, err := NewWithOptions(SincFastest, 2, Options{NaNPolicy: NaNError})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	in := make([]float32, 256)
+	in[77] = float32(math.NaN())
+	if _, err := processBlock(t, conv, in, 2); err == nil || mapGoErrorToCode(err) != ErrNonFiniteInput {
+		t.Fatalf("expected ErrNonFiniteInput, got %v", err)
+	}
+	in[77] = 0
+	if _, err := processBlock(t, conv, in, 2); err != nil {
+		t.Errorf("clean block after rejected one failed: %v", err)
+	}
+
+	if _, err := NewWithOptions(SincFastest, 1, Options{NaNPolicy: NaNPolicy(9)}); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestResetOnCorruption(t *testing.T) {
+	for _, channels := range []int{1, maxChannels + 2} {
+		block := func(nan bool) []float32 {
+			in := make([]float32, 512*channels)
+			for i := range in {
+				in[i] = 0.25
+			}
+			if nan {
+				in[500*channels] = float32(math.NaN()) // Near the end, still in the history next block
+			}
+			return in
+		}
+		// Without recovery the NaN stays in the filter history
+		plain, _ := New(SincFastest, channels)
+		processBlock(t, plain, block(true), channels)
+		after, _ := processBlock(t, plain, block(false), channels)
+		if firstNonFinite(after) < 0 {
+			t.Fatalf("%d channels: expected NaN to persist without recovery", channels)
+		}
+		plain.Close()
+
+		conv, err := NewWithOptions(SincFastest, channels, Options{ResetOnCorruption: true})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		for i, nan := range []bool{false, true, false, false} {
+			out, err := processBlock(t, conv, block(nan), channels)
+			if err != nil {
+				t.Fatalf("%d channels, block %d: Process failed: %v", channels, i, err)
+			}
+			if firstNonFinite(out) >= 0 {
+				t.Fatalf("%d channels, block %d: output contains NaN/Inf", channels, i)
+			}
+		}
+		if n := conv.(StatsReporter).Stats().CorruptionResets; n != 1 {
+			t.Errorf("%d channels: %d corruption resets, want 1", channels, n)
+		}
+		conv.Close()
+	}
+}
+
+func TestLowLatency(t *testing.T) {
+	// A streaming converter holds back the input its filter still needs (ratio
+	// 1.0 would otherwise be copied through)
+	heldBack := func(c Converter) int64 {
+		in := genSine(1000, 440, 8000, 0.5)
+		out := make([]float32, 2000)
+		data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 2000, SrcRatio: 1.0}
+		if err := c.Process(&data); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		return 1000 - data.OutputFramesGen
+	}
+	best, _ := NewWithOptions(SincBestQuality, 1, Options{ForceFilter: true})
+	defer best.Close()
+	low, err := NewWithOptions(SincBestQuality, 1, Options{LowLatency: true, ForceFilter: true})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer low.Close()
+	if d := heldBack(low); d > 20 {
+		t.Errorf("low latency converter holds back %d frames, want <= 20", d)
+	}
+	if d := heldBack(best); d < 100 {
+		t.Errorf("SincBestQuality holds back %d frames, expected far more than the low latency filter", d)
+	}
+
+	// Voice band tones pass unchanged, also through channel groups
+	const channels = 2 * maxChannels
+	in := make([]float32, 4000*channels)
+	tone := genSine(4000, 1000, 8000, 0.5)
+	for i, v := range tone {
+		in[i*channels] = v
+	}
+	conv, err := NewWithOptions(SincFastest, channels, Options{LowLatency: true})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	out, err := processAll(conv, in, channels, 2.0)
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+	want := genSine(8000, 1000, 16000, 0.5)
+	for i := 200; i < 7800; i++ {
+		if d := math.Abs(float64(out[i*channels] - want[i])); d > 1e-3 {
+			t.Fatalf("frame %d differs from the ideal tone by %g", i, d)
+		}
+	}
+}
+
+// blockRecorder returns an OnBlock hook appending each call to blocks.
+func blockRecorder(blocks *[][2]int64) func(inUsed, outGen int64) {
+	return func(inUsed, outGen int64) {
+		*blocks = append(*blocks, [2]int64{inUsed, outGen})
+	}
+}
+
+func TestOnBlockProcess(t *testing.T) {
+	for _, tc := range []struct {
+		channels int
+		opts     Options
+	}{
+		{1, Options{}},
+		{2, Options{OutputChannels: 1}},
+		{2 * maxChannels, Options{}},
+	} {
+		var blocks [][2]int64
+		tc.opts.OnBlock = blockRecorder(&blocks)
+		conv, err := NewWithOptions(SincMediumQuality, tc.channels, tc.opts)
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		outChannels := outputChannelsOf(conv)
+		in := make([]float32, 3000*tc.channels)
+		copy(in, genSine(len(in), 440, 16000, 0.5))
+		out := make([]float32, 700*outChannels)
+
+		// Every call that moved frames is reported once, with the call's counts
+		var want [][2]int64
+		for pos, end := 0, false; ; {
+			n := minInt(333, len(in)/tc.channels-pos)
+			data := SrcData{
+				DataIn: in[pos*tc.channels:], InputFrames: int64(n),
+				DataOut: out, OutputFrames: 700, SrcRatio: 1.37, EndOfInput: end,
+			}
+			if err := conv.Process(&data); err != nil {
+				t.Fatalf("Process failed: %v", err)
+			}
+			if data.InputFramesUsed > 0 || data.OutputFramesGen > 0 {
+				want = append(want, [2]int64{data.InputFramesUsed, data.OutputFramesGen})
+			} else if end {
+				break
+			}
+			pos += int(data.InputFramesUsed)
+			end = pos == len(in)/tc.channels
+		}
+		conv.Close()
+		if len(blocks) != len(want) {
+			t.Fatalf("%d channels: OnBlock called %d times, want %d", tc.channels, len(blocks), len(want))
+		}
+		for i := range want {
+			if blocks[i] != want[i] {
+				t.Errorf("%d channels: block %d reported %v, Process returned %v", tc.channels, i, blocks[i], want[i])
+			}
+		}
+	}
+}
+
+func TestOnBlockCallbackRead(t *testing.T) {
+	for _, tc := range []struct {
+		channels int
+		opts     Options
+	}{
+		{1, Options{}},
+		{2, Options{OutputChannels: 1}},
+		{2 * maxChannels, Options{}},
+	} {
+		frames := 4096
+		src := make([]float32, frames*tc.channels)
+		copy(src, genSine(len(src), 440, 8000, 0.5))
+		fed := false
+		cb := func(interface{}) ([]float32, int64, error) {
+			if fed {
+				return nil, 0, nil
+			}
+			fed = true
+			return src, int64(frames), nil
+		}
+		var blocks [][2]int64
+		tc.opts.OnBlock = blockRecorder(&blocks)
+		conv, err := CallbackNewWithOptions(cb, SincFastest, tc.channels, nil, tc.opts)
+		if err != nil {
+			t.Fatalf("CallbackNewWithOptions failed: %v", err)
+		}
+		out := make([]float32, 1000*outputChannelsOf(conv))
+		var read int64
+		for {
+			n, err := CallbackRead(conv, 0.5, 1000, out)
+			if err != nil {
+				t.Fatalf("%d channels: CallbackRead failed: %v", tc.channels, err)
+			}
+			if n == 0 {
+				break
+			}
+			read += n
+		}
+		conv.Close()
+		var inUsed, outGen int64
+		for _, b := range blocks {
+			inUsed += b[0]
+			outGen += b[1]
+		}
+		if inUsed != int64(frames) || outGen != read {
+			t.Errorf("%d channels: OnBlock reported %d frames in and %d out, want %d and %d", tc.channels, inUsed, outGen, frames, read)
+		}
+	}
+}
+
+func TestChannelGains(t *testing.T) {
+	for _, channels := range []int{2, 3, 130} { // 130 runs as channel groups
+		in := make([]float32, 2000*channels)
+		for i := range in {
+			in[i] = 0.5 * float32(math.Sin(float64(i)*0.01))
+		}
+		gains := make([]float64, channels)
+		for ch := range gains {
+			gains[ch] = float64(ch%3) * 0.5 // Mute, trim and unity
+		}
+		plain, _ := New(SincFastest, channels)
+		want, err := processBlock(t, plain, in, channels)
+		plain.Close()
+		if err != nil {
+			t.Fatalf("%d channels: Process failed: %v", channels, err)
+		}
+		conv, err := NewWithOptions(SincFastest, channels, Options{ChannelGains: gains})
+		if err != nil {
+			t.Fatalf("%d channels: NewWithOptions failed: %v", channels, err)
+		}
+		got, err := processBlock(t, conv, in, channels)
+		conv.Close()
+		if err != nil || len(got) != len(want) {
+			t.Fatalf("%d channels: got %d samples, want %d (err %v)", channels, len(got), len(want), err)
+		}
+		for i := range got {
+			if w := float32(float64(want[i]) * gains[i%channels]); got[i] != w {
+				t.Fatalf("%d channels: sample %d is %g, want %g", channels, i, got[i], w)
+			}
+		}
+	}
+}
+
+// TestChannelGainsMix checks the gains apply to the output channels of a mix.
+func TestChannelGainsMix(t *testing.T) {
+	mono := genSine(2000, 440, 8000, 0.5)
+	upmix := func(opts Options) []float32 {
+		conv, err := NewWithOptions(SincFastest, 1, opts)
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		defer conv.Close()
+		var out []float32
+		data := SrcData{DataIn: mono, InputFrames: int64(len(mono)), SrcRatio: 1.5}
+		if err := ProcessAppend(conv, &data, &out); err != nil {
+			t.Fatalf("ProcessAppend failed: %v", err)
+		}
+		return out
+	}
+	want := upmix(Options{OutputChannels: 2})
+	got := upmix(Options{OutputChannels: 2, ChannelGains: []float64{0, 2}})
+	if len(got) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(got), len(want))
+	}
+	for i := 0; i < len(got); i += 2 {
+		if got[i] != 0 || math.Abs(float64(got[i+1]-2*want[i+1])) > 1e-6 {
+			t.Fatalf("frame %d is [%g %g], want [0 %g]", i/2, got[i], got[i+1], 2*want[i+1])
+		}
+	}
+}
+
+func TestChannelGainsErrors(t *testing.T) {
+	for _, opts := range []Options{
+		{ChannelGains: []float64{1}},
+		{ChannelGains: []float64{1, math.NaN()}},
+		{OutputChannels: 1, ChannelGains: []float64{1, 1}},
+	} {
+		if conv, err := NewWithOptions(SincFastest, 2, opts); err == nil {
+			conv.Close()
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+	gains := []float64{1, 1}
+	conv, err := CallbackNewWithOptions(func(interface{}) ([]float32, int64, error) { return nil, 0, nil }, Linear, 2, nil, Options{ChannelGains: gains})
+	if err != nil {
+		t.Fatalf("CallbackNewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	gains[0] = 5
+	if g := optionsOf(conv).ChannelGains; g[0] != 1 {
+		t.Error("ChannelGains not copied by the constructor")
+	}
+}
@@ -0,0 +1,287 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// OutputEncoding selects the sample encoding an OutputWriter writes.
+type OutputEncoding int
+
+const (
+	OutputUlaw  OutputEncoding = 0 // G.711 u-law, one byte per sample (the mixer output)
+	OutputS16LE OutputEncoding = 1 // Signed 16-bit little-endian PCM
+	OutputS16BE OutputEncoding = 2 // Signed 16-bit big-endian PCM, raw only
+)
+
+// WAV format tags and header sizes
+const (
+	wavFormatPCM      = 1
+	wavFormatMulaw    = 7
+	wavPCMHeaderLen   = 44 // RIFF, fmt (16 bytes) and data chunk headers
+	wavUlawHeaderLen  = 58 // RIFF, fmt (18 bytes), fact and data chunk headers
+	wavUnknownDataLen = math.MaxUint32
+)
+
+// String returns the name of the encoding.
+func (e OutputEncoding) String() string {
+	switch e {
+	case OutputUlaw:
+		return "u-law"
+	case OutputS16LE:
+		return "s16le"
+	case OutputS16BE:
+		return "s16be"
+	default:
+		return fmt.Sprintf("OutputEncoding(%d)", int(e))
+	}
+}
+
+// OutputOptions selects the layout an OutputWriter writes audio in. The zero
+// value writes raw 8kHz u-law, as the MixResample* functions return it.
+type OutputOptions struct {
+	Encoding OutputEncoding
+	// WAV writes a WAV header before the samples. OutputS16BE cannot be stored
+	// in a WAV file.
+	WAV bool
+	// SampleRate is the rate recorded in the WAV header; 0 means 8000, the rate
+	// of the mixer output.
+	SampleRate int
+}
+
+// OutputWriter writes mono mixer results to w in the encoding and container of
+// its OutputOptions, e.g. straight into a WAV file, so consumers do not each
+// re-encode the bytes and build headers. Feed it u-law with WriteUlaw (the
+// MixResample* and MixUlaw8kHz* output) or S16LE PCM with WriteS16LE (e.g. the
+// Resample24kHzTo16kHz output), and Close it at the end.
+//
+// When w is an io.WriteSeeker (e.g. an *os.File), Close fills in the sizes of
+// the WAV header; otherwise they are left at 0xFFFFFFFF, as streaming WAV
+// writers do, and readers take the data to run to the end of the stream.
+//
+// NOTE: An OutputWriter is NOT goroutine-safe.
+type OutputWriter struct {
+	w         io.Writer
+	opts      OutputOptions
+	start     int64 // Offset of the header in w, if w is an io.WriteSeeker
+	header    bool  // The WAV header was written
+	closed    bool
+	dataBytes int64
+	buf       []byte
+}
+
+// NewOutputWriter creates an OutputWriter writing to w.
+func NewOutputWriter(w io.Writer, opts OutputOptions) (*OutputWriter, error) {
+	if w == nil {
+		return nil, fmt.Errorf("writer must not be nil")
+	}
+	switch opts.Encoding {
+	case OutputUlaw, OutputS16LE:
+	case OutputS16BE:
+		if opts.WAV {
+			return nil, fmt.Errorf("%s samples cannot be stored in a WAV file", opts.Encoding)
+		}
+	default:
+		return nil, fmt.Errorf("unknown output encoding %d", opts.Encoding)
+	}
+	if opts.SampleRate < 0 {
+		return nil, fmt.Errorf("sample rate must not be negative, got %d", opts.SampleRate)
+	}
+	if opts.SampleRate == 0 {
+		opts.SampleRate = int(mixOutputMuLawSampleRate)
+	}
+	o := &OutputWriter{w: w, opts: opts, start: -1}
+	if s, ok := w.(io.Seeker); ok {
+		if pos, err := s.Seek(0, io.SeekCurrent); err == nil {
+			o.start = pos
+		}
+	}
+	return o, nil
+}
+
+// WriteUlaw writes u-law samples, converted to the output encoding. It returns
+// the number of input bytes consumed.
+func (o *OutputWriter) WriteUlaw(ulaw []byte) (int, error) {
+	switch o.opts.Encoding {
+	case OutputUlaw:
+		return o.write(ulaw, len(ulaw))
+	default:
+		o.buf = growBytes(o.buf, 2*len(ulaw))
+		order := o.byteOrder()
+		for i, b := range ulaw {
+			order.PutUint16(o.buf[2*i:], uint16(ulawDecodeTable[b]))
+		}
+		return o.write(o.buf, len(ulaw))
+	}
+}
+
+// WriteS16LE writes S16LE PCM samples, converted to the output encoding. It
+// returns the number of input bytes consumed.
+func (o *OutputWriter) WriteS16LE(pcm []byte) (int, error) {
+	if len(pcm)%mixBytesPerInputFrame != 0 {
+		return 0, fmt.Errorf("input size (%d) not multiple of frame size (%d)", len(pcm), mixBytesPerInputFrame)
+	}
+	switch o.opts.Encoding {
+	case OutputS16LE:
+		return o.write(pcm, len(pcm))
+	case OutputS16BE:
+		o.buf = growBytes(o.buf, len(pcm))
+		for i := 0; i < len(pcm); i += 2 {
+			o.buf[i], o.buf[i+1] = pcm[i+1], pcm[i]
+		}
+		return o.write(o.buf, len(pcm))
+	default:
+		enc := ulawEncoder()
+		o.buf = growBytes(o.buf, len(pcm)/2)
+		for i := range o.buf {
+			o.buf[i] = enc[binary.LittleEndian.Uint16(pcm[2*i:])]
+		}
+		return o.write(o.buf, len(pcm))
+	}
+}
+
+// Close ends a WAV stream: it writes the header if nothing was written, the
+// pad byte after an odd number of data bytes, and fills in the header sizes
+// when w is an io.WriteSeeker. It does not close w.
+func (o *OutputWriter) Close() error {
+	if o.closed || !o.opts.WAV {
+		return nil
+	}
+	o.closed = true
+	if err := o.writeHeader(); err != nil {
+		return err
+	}
+	if o.dataBytes%2 != 0 {
+		if _, err := o.w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+	if o.start < 0 {
+		return nil
+	}
+	s := o.w.(io.WriteSeeker)
+	end, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Seek(o.start, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := s.Write(o.wavHeader(o.dataBytes)); err != nil {
+		return err
+	}
+	_, err = s.Seek(end, io.SeekStart)
+	return err
+}
+
+// write writes the header if needed, then out, and reports consumed on success.
+func (o *OutputWriter) write(out []byte, consumed int) (int, error) {
+	if o.closed {
+		return 0, fmt.Errorf("output writer is closed")
+	}
+	if err := o.writeHeader(); err != nil {
+		return 0, err
+	}
+	n, err := o.w.Write(out)
+	o.dataBytes += int64(n)
+	if err != nil {
+		return n * consumed / maxInt(len(out), 1), err
+	}
+	return consumed, nil
+}
+
+func (o *OutputWriter) writeHeader() error {
+	if !o.opts.WAV || o.header {
+		return nil
+	}
+	o.header = true
+	_, err := o.w.Write(o.wavHeader(wavUnknownDataLen))
+	return err
+}
+
+func (o *OutputWriter) byteOrder() binary.ByteOrder {
+	if o.opts.Encoding == OutputS16BE {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// wavHeader returns the WAV header for dataBytes bytes of samples, or for a
+// stream of unknown length with wavUnknownDataLen.
+func (o *OutputWriter) wavHeader(dataBytes int64) []byte {
+	bytesPerSample, tag, headerLen := 2, wavFormatPCM, wavPCMHeaderLen
+	if o.opts.Encoding == OutputUlaw {
+		bytesPerSample, tag, headerLen = 1, wavFormatMulaw, wavUlawHeaderLen
+	}
+	riffLen := uint32(wavUnknownDataLen)
+	if dataBytes < wavUnknownDataLen-int64(headerLen) {
+		riffLen = uint32(dataBytes+dataBytes%2) + uint32(headerLen) - 8 // Data is padded to even length
+	} else {
+		dataBytes = wavUnknownDataLen
+	}
+
+	h := make([]byte, 0, headerLen)
+	le := binary.LittleEndian
+	h = append(h, "RIFF"...)
+	h = le.AppendUint32(h, riffLen)
+	h = append(h, "WAVEfmt "...)
+	if tag == wavFormatPCM {
+		h = le.AppendUint32(h, 16)
+	} else {
+		h = le.AppendUint32(h, 18) // Non-PCM formats carry a cbSize field
+	}
+	h = le.AppendUint16(h, uint16(tag))
+	h = le.AppendUint16(h, 1) // Mono
+	h = le.AppendUint32(h, uint32(o.opts.SampleRate))
+	h = le.AppendUint32(h, uint32(o.opts.SampleRate*bytesPerSample))
+	h = le.AppendUint16(h, uint16(bytesPerSample))
+	h = le.AppendUint16(h, uint16(8*bytesPerSample))
+	if tag != wavFormatPCM {
+		h = le.AppendUint16(h, 0) // cbSize
+		h = append(h, "fact"...)
+		h = le.AppendUint32(h, 4)
+		h = le.AppendUint32(h, uint32(dataBytes)) // Samples, one byte each
+	}
+	h = append(h, "data"...)
+	return le.AppendUint32(h, uint32(dataBytes))
+}
+
+// EncodeUlawOutput converts a whole u-law mixer result to the layout of opts,
+// e.g. a complete WAV file, in memory.
+func EncodeUlawOutput(ulaw []byte, opts OutputOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	o, err := NewOutputWriter(&buf, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := o.WriteUlaw(ulaw); err != nil {
+		return nil, err
+	}
+	if err := o.Close(); err != nil {
+		return nil, err
+	}
+	out := buf.Bytes()
+	if opts.WAV {
+		copy(out, o.wavHeader(o.dataBytes)) // The sizes are known now
+	}
+	return out, nil
+}
+
+// growBytes returns buf resized to n bytes, reallocating it if it is too small.
+func growBytes(buf []byte, n int) []byte {
+	if cap(buf) < n {
+		return make([]byte, n)
+	}
+	return buf[:n]
+}
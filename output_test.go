@@ -0,0 +1,183 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// wavInfo holds the fields of a WAV header checked by the tests.
+type wavInfo struct {
+	riffLen, dataLen uint32
+	tag, channels    uint16
+	rate             uint32
+	bits             uint16
+	factSamples      uint32
+	data             []byte
+}
+
+// parseWAV walks the chunks of a WAV file.
+func parseWAV(t *testing.T, b []byte) wavInfo {
+	t.Helper()
+	le := binary.LittleEndian
+	if len(b) < 12 || string(b[:4]) != "RIFF" || string(b[8:12]) != "WAVE" {
+		t.Fatalf("not a WAV file: % x", b[:minInt(len(b), 12)])
+	}
+	info := wavInfo{riffLen: le.Uint32(b[4:])}
+	for pos := 12; pos+8 <= len(b); {
+		id, size := string(b[pos:pos+4]), le.Uint32(b[pos+4:])
+		body := b[pos+8:]
+		switch id {
+		case "fmt ":
+			info.tag, info.channels = le.Uint16(body), le.Uint16(body[2:])
+			info.rate, info.bits = le.Uint32(body[4:]), le.Uint16(body[14:])
+		case "fact":
+			info.factSamples = le.Uint32(body)
+		case "data":
+			info.dataLen = size
+			info.data = body[:minInt(int(size), len(body))]
+			return info
+		}
+		pos += 8 + int(size) + int(size%2)
+	}
+	t.Fatal("WAV file has no data chunk")
+	return info
+}
+
+func TestOutputWriterRaw(t *testing.T) {
+	ulaw := encodeUlawTone(160, 1000, 0.5)
+	pcm := make([]int16, len(ulaw))
+	UlawToShortArray(ulaw, pcm)
+
+	for _, tt := range []struct {
+		encoding OutputEncoding
+		order    binary.ByteOrder
+	}{
+		{OutputS16LE, binary.LittleEndian},
+		{OutputS16BE, binary.BigEndian},
+	} {
+		var buf bytes.Buffer
+		o, err := NewOutputWriter(&buf, OutputOptions{Encoding: tt.encoding})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n, err := o.WriteUlaw(ulaw); err != nil || n != len(ulaw) {
+			t.Fatalf("%s: WriteUlaw = %d, %v", tt.encoding, n, err)
+		}
+		if err := o.Close(); err != nil {
+			t.Fatal(err)
+		}
+		want := make([]byte, 2*len(pcm))
+		for i, v := range pcm {
+			tt.order.PutUint16(want[2*i:], uint16(v))
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("%s: u-law not decoded as UlawToShortArray", tt.encoding)
+		}
+	}
+
+	// S16LE in, u-law out, as FloatToUlawArray would encode it
+	s16 := s16leTone(160, 440, 8000, 0.5)
+	var buf bytes.Buffer
+	o, _ := NewOutputWriter(&buf, OutputOptions{})
+	if _, err := o.WriteS16LE(s16); err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range buf.Bytes() {
+		if want := linearToUlawGo(int16(binary.LittleEndian.Uint16(s16[2*i:]))); b != want {
+			t.Fatalf("sample %d encoded as %#x, want %#x", i, b, want)
+		}
+	}
+	if _, err := o.WriteS16LE(s16[:3]); err == nil {
+		t.Error("odd S16LE input: no error")
+	}
+}
+
+func TestEncodeUlawOutputWAV(t *testing.T) {
+	ulaw := encodeUlawTone(801, 1000, 0.5) // Odd, so the data chunk is padded
+	out, err := EncodeUlawOutput(ulaw, OutputOptions{WAV: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := parseWAV(t, out)
+	if info.tag != wavFormatMulaw || info.channels != 1 || info.rate != 8000 || info.bits != 8 {
+		t.Errorf("fmt tag=%d channels=%d rate=%d bits=%d, want u-law mono 8000Hz 8 bits", info.tag, info.channels, info.rate, info.bits)
+	}
+	if info.dataLen != 801 || info.factSamples != 801 || !bytes.Equal(info.data, ulaw) {
+		t.Errorf("data %d bytes, fact %d samples, want the 801 input bytes", info.dataLen, info.factSamples)
+	}
+	if len(out) != wavUlawHeaderLen+802 || int(info.riffLen) != len(out)-8 {
+		t.Errorf("file %d bytes with RIFF size %d, want %d and %d", len(out), info.riffLen, wavUlawHeaderLen+802, wavUlawHeaderLen+802-8)
+	}
+
+	out, err = EncodeUlawOutput(ulaw, OutputOptions{Encoding: OutputS16LE, WAV: true, SampleRate: 8000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info = parseWAV(t, out)
+	if info.tag != wavFormatPCM || info.bits != 16 || info.dataLen != 2*801 || len(out) != wavPCMHeaderLen+2*801 {
+		t.Errorf("PCM WAV: tag=%d bits=%d data=%d file=%d", info.tag, info.bits, info.dataLen, len(out))
+	}
+
+	if _, err := EncodeUlawOutput(ulaw, OutputOptions{Encoding: OutputS16BE, WAV: true}); err == nil {
+		t.Error("big-endian WAV: no error")
+	}
+	if _, err := EncodeUlawOutput(ulaw, OutputOptions{Encoding: 9}); err == nil {
+		t.Error("unknown encoding: no error")
+	}
+}
+
+// TestOutputWriterWAVFile checks that the header sizes are filled in on Close
+// for a file, and left unknown for a stream.
+func TestOutputWriterWAVFile(t *testing.T) {
+	ulaw := encodeUlawTone(800, 1000, 0.5)
+	f, err := os.Create(filepath.Join(t.TempDir(), "mix.wav"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	o, err := NewOutputWriter(f, OutputOptions{Encoding: OutputS16LE, WAV: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for start := 0; start < len(ulaw); start += 160 {
+		if _, err := o.WriteUlaw(ulaw[start : start+160]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := o.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info := parseWAV(t, b); info.dataLen != 1600 || int(info.riffLen) != len(b)-8 {
+		t.Errorf("file: data size %d, RIFF size %d; want 1600 and %d", info.dataLen, info.riffLen, len(b)-8)
+	}
+	if _, err := o.WriteUlaw(ulaw); err == nil {
+		t.Error("write after Close: no error")
+	}
+
+	var buf bytes.Buffer
+	o, _ = NewOutputWriter(&buf, OutputOptions{WAV: true})
+	o.WriteUlaw(ulaw)
+	o.Close()
+	if info := parseWAV(t, buf.Bytes()); info.dataLen != wavUnknownDataLen || info.riffLen != wavUnknownDataLen {
+		t.Errorf("stream: data size %#x, RIFF size %#x; want unknown", info.dataLen, info.riffLen)
+	}
+}
@@ -0,0 +1,220 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const parallelSegmentFrames = 1 << 16 // Minimum input frames per segment of ConvertParallel
+
+// ConvertParallel converts mono audio from inRate to outRate Hz with
+// SincBestQuality on all CPUs. See ConvertParallelWith.
+func ConvertParallel(in []float32, inRate, outRate int) ([]float32, error) {
+	return ConvertParallelWith(in, 1, inRate, outRate, SincBestQuality, 0)
+}
+
+// ConvertParallelWith is ResampleRateWith for long files, spread over several
+// goroutines. The input is cut into segments that start on an exact output
+// frame (a multiple of the reduced inRate/outRate denominator). Each segment is
+// converted with its own converter state, together with enough input on either
+// side to cover the filter, and the output produced for that overlap is
+// discarded, so the spliced result matches a single converter run over the
+// whole input up to float rounding, with the same length.
+//
+// There are several segments per worker and each worker takes the next
+// unconverted one as soon as it is free, so workers that get ahead pick up the
+// slack of slower ones. Input shorter than two segments is converted in one go.
+// A hook installed with SetProgressFunc is called after each segment, from the
+// worker that converted it (calls are serialized).
+//
+// Args:
+//
+//	in: Interleaved input samples, all of the stream (the converter is flushed).
+//	channels: Number of interleaved channels.
+//	inRate: Input sample rate in Hz.
+//	outRate: Output sample rate in Hz.
+//	converterType: Converter used when the rates differ.
+//	workers: Number of goroutines; 0 or less uses GOMAXPROCS.
+//
+// Returns:
+//
+//	The converted interleaved samples, or nil and an error.
+func ConvertParallelWith(in []float32, channels, inRate, outRate int, converterType ConverterType, workers int) ([]float32, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if len(in)%channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), channels)
+	}
+	ratio, err := RateRatio(inRate, outRate)
+	if err != nil {
+		return nil, err
+	}
+	if inRate == outRate {
+		return append([]float32{}, in...), nil
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	g := gcd(inRate, outRate)
+	num, den := int64(outRate/g), int64(inRate/g)
+
+	probe, err := New(converterType, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	reach := filterReachFrames(probe, ratio)
+	probe.Close()
+
+	// Segment length and margin in input frames, both multiples of den
+	margin := roundUpInt64(reach+2, den)
+	segIn := roundUpInt64(maxInt64(parallelSegmentFrames, 4*margin), den)
+	inFrames := int64(len(in) / channels)
+	segments := int(inFrames / segIn)
+	if workers == 1 || segments < 2 {
+		conv, err := New(converterType, channels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create resampler: %w", err)
+		}
+		defer conv.Close()
+		return processAll(conv, in, channels, ratio)
+	}
+	if workers > segments {
+		workers = segments
+	}
+
+	// The last segment runs to the end of the input and is flushed
+	outs := make([][]float32, segments)
+	progress := currentProgressFunc()
+	var (
+		next     int64 = -1
+		progMu   sync.Mutex
+		done     int64
+		firstErr error
+		errOnce  sync.Once
+		failed   atomic.Bool
+		wg       sync.WaitGroup
+	)
+	fail := func(err error) {
+		errOnce.Do(func() { firstErr = err })
+		failed.Store(true)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conv, err := New(converterType, channels)
+			if err != nil {
+				fail(fmt.Errorf("failed to create resampler: %w", err))
+				return
+			}
+			defer conv.Close()
+			for !failed.Load() {
+				k := int(atomic.AddInt64(&next, 1))
+				if k >= segments {
+					return
+				}
+				start := int64(k) * segIn
+				end := inFrames
+				if k < segments-1 {
+					end = start + segIn
+				}
+				out, err := convertSegment(conv, in, channels, ratio, start, end, margin, num, den)
+				if err != nil {
+					fail(fmt.Errorf("segment %d: %w", k, err))
+					return
+				}
+				outs[k] = out
+				if progress != nil {
+					progMu.Lock()
+					done += end - start
+					progress(done, inFrames)
+					progMu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var total int
+	for _, o := range outs {
+		total += len(o)
+	}
+	result := make([]float32, 0, total)
+	for _, o := range outs {
+		result = append(result, o...)
+	}
+	return result, nil
+}
+
+// convertSegment converts the input frames [start, end) with margin frames of
+// context before and after, and returns the output for [start, end) only. start
+// and margin are multiples of den, so start falls on output frame start*num/den;
+// a segment ending at the end of the input keeps the whole flushed tail.
+func convertSegment(conv Converter, in []float32, channels int, ratio float64, start, end, margin, num, den int64) ([]float32, error) {
+	if err := conv.Reset(); err != nil {
+		return nil, err
+	}
+	inFrames := int64(len(in) / channels)
+	from := maxInt64(start-margin, 0)
+	to := minInt64(end+margin, inFrames)
+	out, err := processAllWith(conv, in[from*int64(channels):to*int64(channels)], channels, ratio, nil)
+	if err != nil {
+		return nil, err
+	}
+	skip := (start - from) / den * num * int64(channels)
+	if skip > int64(len(out)) {
+		return nil, fmt.Errorf("converter produced %d samples, expected more than %d", len(out), skip)
+	}
+	if end == inFrames {
+		return out[skip:], nil
+	}
+	keep := (end - start) / den * num * int64(channels)
+	if skip+keep > int64(len(out)) {
+		return nil, fmt.Errorf("converter produced %d samples, expected %d", len(out), skip+keep)
+	}
+	return out[skip : skip+keep], nil
+}
+
+// filterReachFrames returns how many input frames on either side of its
+// position an output frame of conv depends on at ratio.
+func filterReachFrames(conv Converter, ratio float64) int64 {
+	switch c := conv.(type) {
+	case *srcState:
+		filter, ok := c.privateData.(*sincFilter)
+		if !ok || filter == nil || filter.indexInc <= 0 {
+			return 2 // Linear and ZOH look one frame back
+		}
+		count := float64(filter.coeffHalfLen+2) / float64(filter.indexInc)
+		if ratio < 1 {
+			count /= math.Max(ratio, 1/srcMaxRatio)
+		}
+		return int64(math.Ceil(count)) + 1
+	case *channelGroups:
+		if len(c.groups) > 0 {
+			return filterReachFrames(c.groups[0], ratio)
+		}
+	case *channelMapper:
+		return filterReachFrames(c.inner, ratio)
+	}
+	return 2
+}
+
+// roundUpInt64 rounds n up to a multiple of m.
+func roundUpInt64(n, m int64) int64 {
+	return (n + m - 1) / m * m
+}
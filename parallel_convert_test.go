@@ -0,0 +1,127 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+// The spliced output must match one converter run over the whole input.
+func TestConvertParallelMatchesSerial(t *testing.T) {
+	cases := []struct {
+		ct              ConverterType
+		inRate, outRate int
+		channels        int
+	}{
+		{SincBestQuality, 44100, 48000, 1},
+		{SincMediumQuality, 48000, 8000, 2},
+		{SincFastest, 8000, 44100, 1},
+		{Linear, 16000, 22050, 2},
+		{ZeroOrderHold, 48000, 32000, 1},
+	}
+	for _, tc := range cases {
+		mono := genSine(5*parallelSegmentFrames+1234, 997, float64(tc.inRate), 0.5)
+		in := make([]float32, 0, len(mono)*tc.channels)
+		for _, v := range mono {
+			for ch := 0; ch < tc.channels; ch++ {
+				in = append(in, v*float32(ch+1)/float32(tc.channels))
+			}
+		}
+		want, err := ResampleRateWith(in, tc.channels, tc.inRate, tc.outRate, tc.ct)
+		if err != nil {
+			t.Fatalf("ResampleRateWith failed: %v", err)
+		}
+		got, err := ConvertParallelWith(in, tc.channels, tc.inRate, tc.outRate, tc.ct, 4)
+		if err != nil {
+			t.Fatalf("%v %d to %d: ConvertParallelWith failed: %v", tc.ct, tc.inRate, tc.outRate, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%v %d to %d: got %d samples, want %d", tc.ct, tc.inRate, tc.outRate, len(got), len(want))
+		}
+		var maxErr float64
+		for i := range want {
+			maxErr = math.Max(maxErr, math.Abs(float64(got[i]-want[i])))
+		}
+		if maxErr > 1e-4 {
+			t.Errorf("%v %d to %d: differs from the serial conversion by %g", tc.ct, tc.inRate, tc.outRate, maxErr)
+		}
+	}
+}
+
+func TestConvertParallelShortAndEqualRates(t *testing.T) {
+	in := genSine(3000, 440, 16000, 0.5)
+	want, _ := ResampleRate(in, 16000, 8000)
+	got, err := ConvertParallel(in, 16000, 8000)
+	if err != nil {
+		t.Fatalf("ConvertParallel failed: %v", err)
+	}
+	checkSameSamples(t, "short input", got, want)
+
+	same, err := ConvertParallelWith(in, 1, 8000, 8000, SincFastest, 2)
+	if err != nil {
+		t.Fatalf("ConvertParallelWith failed: %v", err)
+	}
+	checkSameSamples(t, "equal rates", same, in)
+	if out, err := ConvertParallel(nil, 44100, 48000); err != nil || len(out) != 0 {
+		t.Errorf("empty input: got %d samples, err %v", len(out), err)
+	}
+}
+
+func TestConvertParallelErrors(t *testing.T) {
+	if _, err := ConvertParallelWith(make([]float32, 3), 2, 8000, 16000, SincFastest, 2); err == nil {
+		t.Error("input not a multiple of the channels accepted")
+	}
+	if _, err := ConvertParallelWith(nil, 0, 8000, 16000, SincFastest, 2); err == nil {
+		t.Error("0 channels accepted")
+	}
+	if _, err := ConvertParallelWith(nil, 1, 0, 16000, SincFastest, 2); err == nil {
+		t.Error("rate 0 accepted")
+	}
+	if _, err := ConvertParallelWith(make([]float32, 1<<18), 1, 8000, 16000, ConverterType(99), 2); err == nil {
+		t.Error("unknown converter accepted")
+	}
+}
+
+func TestConvertParallelProgress(t *testing.T) {
+	in := genSine(6*parallelSegmentFrames, 440, 48000, 0.5)
+	var (
+		mu    sync.Mutex
+		calls int
+		last  int64
+	)
+	SetProgressFunc(func(done, total int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done < last || total != int64(len(in)) {
+			t.Errorf("progress %d of %d after %d", done, total, last)
+		}
+		calls++
+		last = done
+	})
+	defer SetProgressFunc(nil)
+	if _, err := ConvertParallelWith(in, 1, 48000, 16000, SincFastest, 3); err != nil {
+		t.Fatalf("ConvertParallelWith failed: %v", err)
+	}
+	// One report per segment, segments being a little over parallelSegmentFrames
+	if calls != 5 || last != int64(len(in)) {
+		t.Errorf("%d progress reports ending at %d, want 5 ending at %d", calls, last, len(in))
+	}
+}
+
+func BenchmarkConvertParallel(b *testing.B) {
+	in := genSine(10*44100, 1000, 44100, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ConvertParallel(in, 44100, 48000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
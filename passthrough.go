@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// canPassThrough reports whether Process can copy the input to the output
+// instead of running the converter: the ratio is exactly 1.0 and has been since
+// the last reset. The sinc converters keep the frames copied last as the
+// history of their filter (see keepHistory), and Linear and ZeroOrderHold the
+// last frame, so when the ratio moves away from 1.0 the filter carries on the
+// stream instead of starting from silence or repeating a frame.
+//
+// Once the converter has filtered (the ratio moved away from 1.0), it keeps
+// filtering until Reset, even if the ratio comes back to 1.0. Options.ForceFilter
+// and Options.Deterministic disable the shortcut. Either way state.filtered
+// records that the filter may hold buffered input from now on.
+func (state *srcState) canPassThrough(data *SrcData) bool {
+	if !state.filtered && !state.options.ForceFilter && !state.options.Deterministic &&
+		data.SrcRatio == 1.0 && state.lastRatio == 1.0 {
+		return true
+	}
+	state.filtered = true
+	return false
+}
+
+// passThrough copies as many frames as fit from the input to the output.
+func passThrough(state *srcState, data *SrcData) ErrorCode {
+	frames := minInt64(data.InputFrames, data.OutputFrames)
+	if frames > 0 {
+		n := frames * int64(state.channels)
+		copy(data.DataOut[:n], data.DataIn[:n])
+		last := data.DataIn[n-int64(state.channels) : n]
+		switch filter := state.privateData.(type) {
+		case *sincFilter:
+			filter.keepHistory(data.DataIn[:n], state.channels)
+		case *linearFilter:
+			copy(filter.lastValue, last)
+			filter.dirty = true
+			state.lastPosition = 1.0 // The next output is the next input frame
+		case *zohFilter:
+			copy(filter.lastValue, last)
+			filter.dirty = true
+			state.lastPosition = 1.0
+		}
+	}
+	data.InputFramesUsed = frames
+	data.OutputFramesGen = frames
+	return ErrNoError
+}
+
+// keepHistory makes the samples in, just passed through, the end of the lookback
+// the filter needs at ratio 1.0, and leaves the read position past them with
+// nothing buffered: where a filter converting at 1.0 since the reset would be.
+// Ratios below 1.0 need a longer lookback; the filter finds silence before it,
+// as at the start of a stream.
+func (filter *sincFilter) keepHistory(in []float32, channels int) {
+	half := filter.halfChanLen(channels, 1.0)
+	lookback := filter.buffer[:half]
+	if filter.bCurrent == 0 {
+		for i := range lookback {
+			lookback[i] = 0.0 // Silence before the first frame
+		}
+	}
+	if n := int64(len(in)); n >= half {
+		copy(lookback, in[n-half:])
+	} else {
+		copy(lookback, lookback[n:])
+		copy(lookback[half-n:], in)
+	}
+	filter.bCurrent, filter.bEnd = half, half
+	filter.planarValid = 0
+}
@@ -0,0 +1,201 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// TestPassthroughIsExact checks ratio 1.0 output equals the input, sample for
+// sample and without delay, for every converter type and also through channel
+// groups.
+func TestPassthroughIsExact(t *testing.T) {
+	for _, channels := range []int{1, 2 * maxChannels} {
+		in := make([]float32, 1000*channels)
+		for i, v := range genSine(1000, 440, 8000, 0.5) {
+			for ch := 0; ch < channels; ch++ {
+				in[i*channels+ch] = v * float32(ch+1) / float32(channels)
+			}
+		}
+		for _, ct := range []ConverterType{SincBestQuality, SincFastest, Linear, ZeroOrderHold} {
+			conv, err := New(ct, channels)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			out := make([]float32, len(in))
+			data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 1000, SrcRatio: 1.0}
+			if err := conv.Process(&data); err != nil {
+				t.Fatalf("Process failed: %v", err)
+			}
+			if data.InputFramesUsed != 1000 || data.OutputFramesGen != 1000 {
+				t.Fatalf("%v, %d channels: used %d, generated %d frames, want 1000",
+					ct, channels, data.InputFramesUsed, data.OutputFramesGen)
+			}
+			for i := range in {
+				if out[i] != in[i] {
+					t.Fatalf("%v, %d channels: sample %d is %g, want %g", ct, channels, i, out[i], in[i])
+				}
+			}
+			conv.Close()
+		}
+	}
+}
+
+func TestPassthroughCallback(t *testing.T) {
+	in := genSine(1000, 440, 8000, 0.5)
+	conv, err := CallbackNew(eofSourceCallback, SincMediumQuality, 1, &eofSource{data: in, chunkLen: 128})
+	if err != nil {
+		t.Fatalf("CallbackNew failed: %v", err)
+	}
+	defer conv.Close()
+	out := make([]float32, 1200)
+	n, err := CallbackRead(conv, 1.0, 1200, out)
+	if err != nil {
+		t.Fatalf("CallbackRead failed: %v", err)
+	}
+	if n != 1000 {
+		t.Fatalf("read %d frames, want 1000", n)
+	}
+	for i := range in {
+		if out[i] != in[i] {
+			t.Fatalf("sample %d is %g, want %g", i, out[i], in[i])
+		}
+	}
+}
+
+// TestPassthroughStopsOnceFiltered checks a ratio change switches to the filter
+// for good, until Reset, and that ForceFilter never passes through.
+func TestPassthroughStopsOnceFiltered(t *testing.T) {
+	in := genSine(1000, 440, 8000, 0.5)
+	run := func(c Converter, ratio float64) int64 {
+		out := make([]float32, 3000)
+		data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 3000, SrcRatio: ratio}
+		if err := c.Process(&data); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		return data.OutputFramesGen
+	}
+
+	conv, _ := New(SincFastest, 1)
+	defer conv.Close()
+	if n := run(conv, 1.0); n != 1000 {
+		t.Fatalf("passthrough generated %d frames, want 1000", n)
+	}
+	run(conv, 1.5)
+	if n := run(conv, 1.0); n == 1000 {
+		t.Error("converter went back to passthrough with filter history pending")
+	}
+	conv.Reset()
+	if n := run(conv, 1.0); n != 1000 {
+		t.Errorf("passthrough after Reset generated %d frames, want 1000", n)
+	}
+
+	forced, err := NewWithOptions(SincFastest, 1, Options{ForceFilter: true})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer forced.Close()
+	if n := run(forced, 1.0); n == 1000 {
+		t.Error("ForceFilter converter passed the input through")
+	}
+}
+
+// TestPassthroughKeepsHistory checks a sinc converter leaving passthrough
+// filters with the frames it passed through as history: right after the ratio
+// moves from 1.0 to 1.001 its output is that of a converter filtering from the
+// start, without the transient of a filter starting from silence.
+func TestPassthroughKeepsHistory(t *testing.T) {
+	for _, ct := range []ConverterType{SincFastest, SincMediumQuality} {
+		for _, channels := range []int{1, 2} {
+			in := multichannelTones(2000, channels)
+			run := func(opts Options) []float32 {
+				conv, err := NewWithOptions(ct, channels, opts)
+				if err != nil {
+					t.Fatalf("NewWithOptions failed: %v", err)
+				}
+				defer conv.Close()
+				var got []float32
+				out := make([]float32, 400*channels)
+				for pos, block := 0, 0; pos < 2000; block++ {
+					ratio := 1.0
+					if block >= 10 {
+						ratio = 1.001
+					}
+					data := SrcData{DataIn: in[pos*channels : (pos+100)*channels], InputFrames: 100, DataOut: out, OutputFrames: 400, SrcRatio: ratio}
+					if err := conv.Process(&data); err != nil {
+						t.Fatalf("Process failed: %v", err)
+					}
+					got = append(got, out[:data.OutputFramesGen*int64(channels)]...)
+					pos += int(data.InputFramesUsed)
+				}
+				return got
+			}
+			got, want := run(Options{}), run(Options{ForceFilter: true})
+			for i := 1000 * channels; i < 1020*channels; i++ {
+				if d := math.Abs(float64(got[i] - want[i])); d > 1e-4 {
+					t.Fatalf("%v, %d channels: sample %d after the switch is %g off the filtered stream", ct, channels, i/channels-1000, d)
+				}
+			}
+		}
+	}
+
+	// Linear and ZeroOrderHold carry on from the last frame passed through:
+	// the first frame after the switch reads frame 1000 and the read position
+	// moves on by 1/ratio from there, with no frame repeated at the switch.
+	for _, ct := range []ConverterType{Linear, ZeroOrderHold} {
+		for _, channels := range []int{1, 2} {
+			for _, ratio := range []float64{2, 0.5} {
+				in := multichannelTones(2000, channels)
+				conv, _ := New(ct, channels)
+				got := stream(t, conv, in[:1000*channels], channels, channels, 1, 100, 400, false)
+				checkSameSamples(t, "passed through", got, in[:1000*channels])
+				if err := conv.SetRatio(ratio); err != nil {
+					t.Fatalf("SetRatio failed: %v", err)
+				}
+				got = stream(t, conv, in[1000*channels:], channels, channels, ratio, 100, 400, false)
+				conv.Close()
+				for k := 0; k < 20; k++ {
+					pos := 1000 + float64(k)/ratio
+					f := int(pos)
+					for c := 0; c < channels; c++ {
+						want := float64(in[f*channels+c])
+						if ct == Linear {
+							want += (pos - float64(f)) * float64(in[(f+1)*channels+c]-in[f*channels+c])
+						}
+						if d := math.Abs(float64(got[k*channels+c]) - want); d > 1e-6 {
+							t.Fatalf("%v, %d channels, ratio %g: frame %d after the switch is %g off input position %g", ct, channels, ratio, k, d, pos)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestResampleFormatPassthrough checks ratio 1.0 reduces ResampleFormat to a
+// format conversion.
+func TestResampleFormatPassthrough(t *testing.T) {
+	samples := genSine(800, 440, 8000, 0.5)
+	in := make([]byte, len(samples)*2)
+	EncodePCM(FormatS16LE, samples, in)
+
+	got, err := ResampleFormat(in, FormatS16LE, FormatS24LE, 1, 1.0, SincBestQuality)
+	if err != nil {
+		t.Fatalf("ResampleFormat failed: %v", err)
+	}
+	decoded := make([]float32, len(samples))
+	DecodePCM(FormatS16LE, in, decoded)
+	want := make([]byte, len(samples)*3)
+	EncodePCM(FormatS24LE, decoded, want)
+	if !bytes.Equal(got, want) {
+		t.Error("ratio 1.0 output differs from a plain s16le to s24le conversion")
+	}
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// UlawOptions holds the optional processing of ConvertPCMToUlaw. The zero value
+// only resamples and encodes.
+type UlawOptions struct {
+	// TelephonyBandpass limits the 8kHz output to the 300-3400Hz PSTN voice band
+	// before encoding (see NewTelephonyBandpass), which makes wideband speech such
+	// as TTS output more intelligible on the phone.
+	TelephonyBandpass bool
+	// Clip selects how samples beyond full scale are limited before encoding; the
+	// zero value is HardClip.
+	Clip ClipStrategy
+	// BigEndian reads the input as S16BE (AIFF, L16 RTP payloads) instead of S16LE.
+	BigEndian bool
+	// OnClip, if set, is called with the number of output samples beyond full
+	// scale before Clip limited them (see CountClipped) and the number of output
+	// samples.
+	OnClip func(clipped, samples int)
+}
+
+// ConvertPCMToUlaw converts mono 16-bit little-endian PCM at inputRate (e.g. 24kHz
+// TTS output) to 8kHz u-law, the reverse of ConvertUlawToPCM. Set
+// opts.BigEndian for big-endian input.
+//
+// Args:
+//
+//	inputPCM: Slice of bytes containing S16LE (or S16BE) PCM audio data (mono).
+//	inputRate: The sample rate of inputPCM in Hz.
+//	quality: The libsamplerate converter quality type (e.g., SincBestQuality).
+//	opts: Optional processing before encoding.
+//
+// Returns:
+//
+//	A slice of bytes containing u-law encoded audio data (8kHz),
+//	or nil and an error if conversion fails.
+func ConvertPCMToUlaw(inputPCM []byte, inputRate float64, quality ConverterType, opts UlawOptions) ([]byte, error) {
+	if len(inputPCM)%mixBytesPerInputFrame != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of frame size (%d)", len(inputPCM), mixBytesPerInputFrame)
+	}
+	if inputRate <= 0 || math.IsNaN(inputRate) || math.IsInf(inputRate, 0) {
+		return nil, fmt.Errorf("input rate must be positive, got %f", inputRate)
+	}
+	srcRatio := mixOutputMuLawSampleRate / inputRate
+	if isBadSrcRatio(srcRatio) {
+		return nil, mapError(ErrBadSrcRatio)
+	}
+	if !opts.Clip.IsValid() {
+		return nil, fmt.Errorf("unknown clip strategy %d", opts.Clip)
+	}
+	if len(inputPCM) == 0 {
+		return []byte{}, nil // Return empty slice for empty input
+	}
+
+	state, err := New(quality, channelsUlaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize libsamplerate: %w", err)
+	}
+	defer state.Close()
+
+	in := make([]float32, len(inputPCM)/mixBytesPerInputFrame)
+	format := FormatS16LE
+	if opts.BigEndian {
+		format = FormatS16BE
+	}
+	if _, err := DecodePCM(format, inputPCM, in); err != nil {
+		return nil, err
+	}
+	out, err := processAll(state, in, channelsUlaw, srcRatio)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TelephonyBandpass {
+		bandpass, err := NewTelephonyBandpass(mixOutputMuLawSampleRate)
+		if err != nil {
+			return nil, err
+		}
+		bandpass.Apply(out, channelsUlaw)
+	}
+	if opts.OnClip != nil && len(out) > 0 {
+		opts.OnClip(CountClipped(out), len(out))
+	}
+	opts.Clip.Apply(out)
+	return appendPCMFloatToUlawBytes(make([]byte, 0, len(out)), out), nil
+}
+
+// FloatToUlawArray encodes a slice of float32 to u-law bytes. The samples are
+// scaled, rounded and clipped to 16 bits as EncodePCM does for FormatS16LE, so
+// decoding with UlawToFloatArray and encoding again gives the same bytes. It
+// encodes min(len(in), len(out)) samples.
+func FloatToUlawArray(in []float32, out []byte) {
+	count := minInt(len(in), len(out))
+	for i := 0; i < count; i++ {
+		out[i] = floatToUlawGo(in[i])
+	}
+}
+
+// floatToUlawGo encodes one float32 sample to a u-law byte (see FloatToUlawArray).
+func floatToUlawGo(sample float32) byte {
+	return linearToUlawGo(int16(quantize(sample, fullScaleS16, RoundHalfAwayFromZero)))
+}
@@ -0,0 +1,133 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// ulawLevelDB returns the RMS level of the u-law samples in dBFS, skipping the
+// first skip samples (the filter and converter transients).
+func ulawLevelDB(ulaw []byte, skip int) float64 {
+	samples := make([]float32, len(ulaw))
+	UlawToFloatArray(ulaw, samples)
+	return 20 * math.Log10(rmsGo(samples[skip:]))
+}
+
+func TestNewTelephonyBandpass(t *testing.T) {
+	const rate = 8000.0
+	tests := []struct {
+		freq         float64
+		minDB, maxDB float64
+	}{
+		{100, -50, -30}, // Twice the attenuation of TelephoneBandLimit
+		{1000, -0.5, 0.5},
+		{3000, -3, 0.5},
+		{3900, -100, -35},
+	}
+	for _, tt := range tests {
+		bp, err := NewTelephonyBandpass(rate)
+		if err != nil {
+			t.Fatalf("NewTelephonyBandpass failed: %v", err)
+		}
+		in := genSine(int(rate), tt.freq, rate, 0.5)
+		out := append([]float32(nil), in...)
+		bp.Apply(out, 1)
+		db := 20 * math.Log10(rmsGo(out[2000:])/rmsGo(in[2000:]))
+		if db < tt.minDB || db > tt.maxDB {
+			t.Errorf("%g Hz: gain %.1f dB, want between %g and %g", tt.freq, db, tt.minDB, tt.maxDB)
+		}
+	}
+	if _, err := NewTelephonyBandpass(6000); err == nil {
+		t.Error("expected error for a rate with the band above Nyquist")
+	}
+}
+
+func TestConvertPCMToUlaw(t *testing.T) {
+	const rate = 24000.0
+	for _, tt := range []struct {
+		freq     float64
+		bandpass bool
+		minDB    float64
+		maxDB    float64
+	}{
+		{1000, false, -10, -8}, // Amplitude 0.5 is -9 dBFS
+		{1000, true, -10, -8},
+		{100, false, -10, -8},
+		{100, true, -60, -40},
+	} {
+		pcm := s16leTone(int(rate), tt.freq, rate, 0.5)
+		ulaw, err := ConvertPCMToUlaw(pcm, rate, SincMediumQuality, UlawOptions{TelephonyBandpass: tt.bandpass})
+		if err != nil {
+			t.Fatalf("ConvertPCMToUlaw failed: %v", err)
+		}
+		if len(ulaw) < 7990 || len(ulaw) > 8010 {
+			t.Fatalf("got %d u-law samples for 1s, want about 8000", len(ulaw))
+		}
+		if db := ulawLevelDB(ulaw, 1000); db < tt.minDB || db > tt.maxDB {
+			t.Errorf("%g Hz, band-pass %v: level %.1f dBFS, want between %g and %g", tt.freq, tt.bandpass, db, tt.minDB, tt.maxDB)
+		}
+	}
+
+	if out, err := ConvertPCMToUlaw(nil, rate, SincFastest, UlawOptions{}); err != nil || len(out) != 0 {
+		t.Errorf("empty input: got %d bytes, %v", len(out), err)
+	}
+	for _, bad := range []struct {
+		pcm  []byte
+		rate float64
+		opts UlawOptions
+	}{
+		{[]byte{1, 2, 3}, rate, UlawOptions{}},
+		{make([]byte, 4), 0, UlawOptions{}},
+		{make([]byte, 4), math.NaN(), UlawOptions{}},
+		{make([]byte, 4), 8000 * 1000, UlawOptions{}},
+		{make([]byte, 4), rate, UlawOptions{Clip: ClipStrategy(9)}},
+	} {
+		if _, err := ConvertPCMToUlaw(bad.pcm, bad.rate, SincFastest, bad.opts); err == nil {
+			t.Errorf("%d bytes at %g Hz, %+v: expected error", len(bad.pcm), bad.rate, bad.opts)
+		}
+	}
+}
+
+// TestFloatToUlawArrayRoundTrip checks every u-law code survives
+// UlawToFloatArray and FloatToUlawArray, and that FloatToUlawArray rounds and
+// clips as the 16-bit PCM encoder does.
+func TestFloatToUlawArrayRoundTrip(t *testing.T) {
+	codes := make([]byte, 256)
+	for i := range codes {
+		codes[i] = byte(i)
+	}
+	floats := make([]float32, len(codes))
+	UlawToFloatArray(codes, floats)
+	got := make([]byte, len(codes))
+	FloatToUlawArray(floats, got)
+	for i, code := range codes {
+		want := code
+		if code == 0x7F { // -0
+			want = UlawSilence
+		}
+		if got[i] != want {
+			t.Errorf("0x%02X -> %g -> 0x%02X, want 0x%02X", code, floats[i], got[i], want)
+		}
+	}
+
+	tone := genSine(2000, 441, 8000, 0.9)
+	tone = append(tone, 1.5, -1.5, 1, -1, float32(math.Inf(1)), float32(math.Inf(-1)), float32(math.NaN()))
+	pcm := make([]byte, 2*len(tone))
+	EncodePCM(FormatS16LE, tone, pcm)
+	encoded := make([]byte, len(tone))
+	FloatToUlawArray(tone, encoded)
+	for i, b := range encoded {
+		if want := linearToUlawGo(int16(binary.LittleEndian.Uint16(pcm[2*i:]))); b != want {
+			t.Fatalf("sample %d (%g) encoded as 0x%02X, want 0x%02X as through S16LE", i, tone[i], b, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Range of a 24-bit sample held in an int32.
+const (
+	minS24 = -8388608
+	maxS24 = 8388607
+)
+
+// DecodeS24LE unpacks 24-bit little-endian samples (3 bytes each, as in 24-bit
+// WAV files) into int32 values in [-8388608, 8388607]. It decodes
+// min(len(in)/3, len(out)) samples and returns that count. Use DecodePCM with
+// FormatS24LE to get float32 samples instead.
+func DecodeS24LE(in []byte, out []int32) (int, error) {
+	if len(in)%3 != 0 {
+		return 0, fmt.Errorf("input size (%d) not multiple of sample size (3) for %s", len(in), FormatS24LE)
+	}
+	count := minInt(len(in)/3, len(out))
+	for i := 0; i < count; i++ {
+		b := in[i*3:]
+		out[i] = signExtend24(uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16)
+	}
+	return count, nil
+}
+
+// EncodeS24LE packs int32 samples into 24-bit little-endian bytes (3 each),
+// clipping values outside [-8388608, 8388607]. It encodes
+// min(len(in), len(out)/3) samples and returns that count.
+func EncodeS24LE(in []int32, out []byte) int {
+	count := minInt(len(in), len(out)/3)
+	for i := 0; i < count; i++ {
+		v := in[i]
+		if v > maxS24 {
+			v = maxS24
+		} else if v < minS24 {
+			v = minS24
+		}
+		out[i*3], out[i*3+1], out[i*3+2] = byte(v), byte(v>>8), byte(v>>16)
+	}
+	return count
+}
+
+// ResampleS24LE resamples interleaved 24-bit packed little-endian PCM from
+// inputRate to outputRate, keeping the 24-bit format. It is ResampleFormat with
+// FormatS24LE in and out; use that to change the sample format as well.
+//
+// Not to be confused with the "24" of MixResampleUlaw24to8, which is the 24kHz
+// sample rate of 16-bit input.
+//
+// Args:
+//
+//	in: Interleaved S24LE bytes, 3 per sample.
+//	channels: Number of interleaved channels.
+//	inputRate, outputRate: Sample rates in Hz (e.g. 96000 and 48000).
+//	converterType: Converter to use (e.g. SincBestQuality).
+//
+// Returns:
+//
+//	The resampled S24LE bytes, or nil and an error.
+func ResampleS24LE(in []byte, channels int, inputRate, outputRate float64, converterType ConverterType) ([]byte, error) {
+	if inputRate <= 0 || math.IsNaN(inputRate) || math.IsInf(inputRate, 0) {
+		return nil, fmt.Errorf("input rate must be positive, got %f", inputRate)
+	}
+	if outputRate <= 0 || math.IsNaN(outputRate) || math.IsInf(outputRate, 0) {
+		return nil, fmt.Errorf("output rate must be positive, got %f", outputRate)
+	}
+	return ResampleFormat(in, FormatS24LE, FormatS24LE, channels, outputRate/inputRate, converterType)
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestS24LERoundTrip(t *testing.T) {
+	in := []int32{0, 1, -1, 8388607, -8388608, 0x123456, -0x123456, 9000000, -9000000}
+	want := []int32{0, 1, -1, 8388607, -8388608, 0x123456, -0x123456, 8388607, -8388608}
+	data := make([]byte, 3*len(in))
+	if n := EncodeS24LE(in, data); n != len(in) {
+		t.Fatalf("EncodeS24LE encoded %d samples, want %d", n, len(in))
+	}
+	if !bytes.Equal(data[15:18], []byte{0x56, 0x34, 0x12}) {
+		t.Errorf("0x123456 packed as % x", data[15:18])
+	}
+	got := make([]int32, len(in))
+	if n, err := DecodeS24LE(data, got); n != len(in) || err != nil {
+		t.Fatalf("DecodeS24LE gave %d, %v", n, err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample %d is %d, want %d", i, got[i], want[i])
+		}
+	}
+
+	// Same layout as FormatS24LE
+	floats := make([]float32, len(in))
+	DecodePCM(FormatS24LE, data, floats)
+	if floats[3] != float32(8388607.0/8388608.0) || floats[4] != -1 {
+		t.Errorf("DecodePCM(FormatS24LE) reads %g, %g", floats[3], floats[4])
+	}
+	if _, err := DecodeS24LE(data[:4], got); err == nil {
+		t.Error("expected error for a partial sample")
+	}
+}
+
+// TestResampleS24LE halves the rate of a 24-bit stereo sine and checks it keeps
+// more precision than 16 bits would.
+func TestResampleS24LE(t *testing.T) {
+	const frames = 9600
+	tone := genSine(frames, 1000, 96000, 0.5)
+	samples := make([]int32, 2*frames)
+	for i, v := range tone {
+		samples[2*i] = int32(math.Round(float64(v) * 8388608))
+		samples[2*i+1] = -samples[2*i]
+	}
+	in := make([]byte, 3*len(samples))
+	EncodeS24LE(samples, in)
+
+	out, err := ResampleS24LE(in, 2, 96000, 48000, SincBestQuality)
+	if err != nil {
+		t.Fatalf("ResampleS24LE failed: %v", err)
+	}
+	got := make([]int32, len(out)/3)
+	DecodeS24LE(out, got)
+	if n := len(got) / 2; n < frames/2-5 || n > frames/2+5 {
+		t.Fatalf("got %d frames, want about %d", n, frames/2)
+	}
+	fine := false // Some sample not a multiple of 256, i.e. not 16-bit
+	for i := 1000; i < len(got)-1000; i += 2 {
+		want := 0.5 * 8388608 * math.Sin(2*math.Pi*1000*float64(i/2)/48000)
+		if d := math.Abs(float64(got[i]) - want); d > 200 {
+			t.Fatalf("frame %d is %d, want %.0f", i/2, got[i], want)
+		}
+		if got[i+1] != -got[i] && math.Abs(float64(got[i+1]+got[i])) > 1 {
+			t.Fatalf("frame %d channels %d, %d are not opposite", i/2, got[i], got[i+1])
+		}
+		fine = fine || got[i]%256 != 0
+	}
+	if !fine {
+		t.Error("output has no 24-bit precision")
+	}
+
+	for _, bad := range [][2]float64{{0, 48000}, {96000, math.NaN()}, {96000, 96000 * 1000}} {
+		if _, err := ResampleS24LE(in, 2, bad[0], bad[1], Linear); err == nil {
+			t.Errorf("%g Hz to %g Hz: expected error", bad[0], bad[1])
+		}
+	}
+	if _, err := ResampleS24LE(in[:3], 2, 96000, 48000, Linear); err == nil {
+		t.Error("expected error for a partial frame")
+	}
+}
@@ -1,94 +1,132 @@
+// pcm24mix-testing mixes and converts the PCM/u-law streams used by telephony
+// integrations: 24kHz and 16kHz S16LE mixes down to 8kHz u-law, 24kHz -> 16kHz
+// resampling and 8kHz u-law mixing.
+//
+// By default it runs on the embedded corpus and writes to the temp directory;
+// any input can be overridden with a flag to run on real recordings.
 package main
 
 import (
-	libsamplerate "github.com/keereets/go-libsamplerate"
+	"flag"
 	"log"
 	"os"
+	"path/filepath"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+	"github.com/keereets/go-libsamplerate/internal/corpus"
 )
 
-func main() {
-	inputFile1 := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/mixing_input_file_24kHz.raw"
-	inputFile1_16 := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/output.PCM16.raw"
-	inputFile2 := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/typing.pcm24kHz.raw"
-	inputFile2_16 := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/last.input.twilio.PCM16.output.bin"
-	inputFile1_muLaw := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/input.mulaw.raw"
-	inputFile2_muLaw := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/last.input.twilio.original.8kHz.bin"
-	outputFile := "/tmp/mixed.golib-translated-24to8.8kHz.bin"
-	outputFile_16 := "/tmp/mixed.golib-translated-16to8.8kHz.bin"
-	outputFile1_converted := "/tmp/output_file_24kHz.converted.16kHz.raw"
-	outputFile1_muLawMixed := "/tmp/output_mu_law_mixed.8kHz.raw"
+// config holds the input files (empty means "use the embedded corpus") and output directory.
+type config struct {
+	input1_24k, input2_24k   string
+	input1_16k, input2_16k   string
+	input1_ulaw, input2_ulaw string
+	outDir                   string
+}
+
+// readInput reads a file from disk, or the named corpus file when path is empty.
+func readInput(path, corpusName string) ([]byte, error) {
+	if path == "" {
+		return corpus.ReadFile(corpusName)
+	}
+	return os.ReadFile(path)
+}
+
+// run performs all the conversions and returns the paths of the files written.
+func run(cfg config) ([]string, error) {
+	var written []string
+	write := func(name string, data []byte) error {
+		path := filepath.Join(cfg.outDir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+		written = append(written, path)
+		return nil
+	}
 
-	file1, err := os.ReadFile(inputFile1)
+	file1, err := readInput(cfg.input1_24k, corpus.Speech24kS16LE)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	file2, err := os.ReadFile(inputFile2)
+	file2, err := readInput(cfg.input2_24k, corpus.Typing24kS16LE)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	lastPos := 0
 	ulaw, err := libsamplerate.MixResampleUlaw24to8DefaultFactor(file1, file2, &lastPos)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
+	if err = write("mixed.golib-translated-24to8.8kHz.bin", ulaw); err != nil {
+		return nil, err
+	}
+	log.Println("finished mixing and converting 24kHz streams to uLaw ->", len(ulaw))
 
-	err = os.WriteFile(outputFile, ulaw, 0644)
-	if err != nil {
-		log.Fatal(err)
+	if resampled16kHz, err := libsamplerate.Resample24kHzTo16kHz(file1); err != nil {
+		return nil, err
+	} else if err = write("output_file_24kHz.converted.16kHz.raw", resampled16kHz); err != nil {
+		return nil, err
 	}
-	log.Println("finished mixing and converting 1st 24kHz file to uLaw", outputFile, inputFile1, inputFile2, "->", len(ulaw))
+	log.Println("finished converting 24kHz to 16kHz")
 
-	file1, err = os.ReadFile(inputFile1_16)
+	file1, err = readInput(cfg.input1_16k, corpus.Speech16kS16LE)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	file2, err = os.ReadFile(inputFile2_16)
+	file2, err = readInput(cfg.input2_16k, corpus.Typing16kS16LE)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	lastPos = 0
 	ulaw, err = libsamplerate.MixResampleUlaw16to8DefaultFactor(file1, file2, &lastPos)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	err = os.WriteFile(outputFile_16, ulaw, 0644)
-	if err != nil {
-		log.Fatal(err)
+	if err = write("mixed.golib-translated-16to8.8kHz.bin", ulaw); err != nil {
+		return nil, err
 	}
-	log.Println("finished mixing and converting 2nd 16kHz file to uLaw", outputFile_16, inputFile1_16, inputFile2_16, "->", len(ulaw))
+	log.Println("finished mixing and converting 16kHz streams to uLaw ->", len(ulaw))
 
-	file1, err = os.ReadFile(inputFile1)
+	file1, err = readInput(cfg.input1_ulaw, corpus.Speech8kUlaw)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	if resampled16kHz, err := libsamplerate.Resample24kHzTo16kHz(file1); err != nil {
-		log.Fatal(err)
-	} else {
-		if err = os.WriteFile(outputFile1_converted, resampled16kHz, 0644); err != nil {
-			log.Fatal(err)
-		}
-		log.Println("finished converting 24kHz to 16kHz", inputFile1, "to", outputFile1_converted)
+	file2, err = readInput(cfg.input2_ulaw, corpus.Typing8kUlaw)
+	if err != nil {
+		return nil, err
 	}
 
-	file1, err = os.ReadFile(inputFile1_muLaw)
+	lastPos = 1000
+	mixed8kHz, err := libsamplerate.MixUlaw8kHzDefaultFactor(file1, file2, &lastPos)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	file2, err = os.ReadFile(inputFile2_muLaw)
-	if err != nil {
-		log.Fatal(err)
+	if err = write("output_mu_law_mixed.8kHz.raw", mixed8kHz); err != nil {
+		return nil, err
 	}
+	log.Println("finished mixing 2 muLaw streams ->", len(mixed8kHz))
 
-	lastPos = 1000
-	if mixed8kHz, err := libsamplerate.MixUlaw8kHzDefaultFactor(file1, file2, &lastPos); err != nil {
+	return written, nil
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.input1_24k, "in1-24k", "", "first 24kHz S16LE input (default: embedded corpus)")
+	flag.StringVar(&cfg.input2_24k, "in2-24k", "", "second 24kHz S16LE input (default: embedded corpus)")
+	flag.StringVar(&cfg.input1_16k, "in1-16k", "", "first 16kHz S16LE input (default: embedded corpus)")
+	flag.StringVar(&cfg.input2_16k, "in2-16k", "", "second 16kHz S16LE input (default: embedded corpus)")
+	flag.StringVar(&cfg.input1_ulaw, "in1-ulaw", "", "first 8kHz u-law input (default: embedded corpus)")
+	flag.StringVar(&cfg.input2_ulaw, "in2-ulaw", "", "second 8kHz u-law input (default: embedded corpus)")
+	flag.StringVar(&cfg.outDir, "out", os.TempDir(), "output directory")
+	flag.Parse()
+
+	written, err := run(cfg)
+	if err != nil {
 		log.Fatal(err)
-	} else {
-		if err = os.WriteFile(outputFile1_muLawMixed, mixed8kHz, 0644); err != nil {
-			log.Fatal(err)
-		}
-		log.Println("finished mixing 2 muLaw []byte", inputFile1_muLaw, "&", inputFile2_muLaw, "to", outputFile1_muLawMixed)
+	}
+	for _, path := range written {
+		log.Println("wrote", path)
 	}
 }
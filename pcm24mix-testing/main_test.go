@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// TestRunOnCorpus runs the example end to end on the embedded corpus.
+func TestRunOnCorpus(t *testing.T) {
+	written, err := run(config{outDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if len(written) != 4 {
+		t.Fatalf("expected 4 output files, got %d", len(written))
+	}
+	for _, path := range written {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("missing output %s: %v", path, err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("output %s is empty", path)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Encoder encodes fixed-size frames of interleaved float32 samples, e.g. an Opus
+// encoder fed 20ms frames at 48kHz. It is the integration point of a Pipeline:
+// wrap the codec in a type with an EncodeFrame method. For gopkg.in/hraban/opus:
+//
+//	type opusEncoder struct{ enc *opus.Encoder }
+//
+//	func (e opusEncoder) EncodeFrame(frame []float32) ([]byte, error) {
+//		packet := make([]byte, 1500)
+//		n, err := e.enc.EncodeFloat32(frame, packet)
+//		return packet[:n], err
+//	}
+type Encoder interface {
+	// EncodeFrame encodes one frame of FrameFrames()*channels samples. The frame
+	// is only valid during the call. The returned packet is handed to the caller
+	// of Pipeline.Process as is, so it must not be reused by later calls.
+	EncodeFrame(frame []float32) ([]byte, error)
+}
+
+// Pipeline resamples a stream and feeds it to an Encoder in frames of exactly
+// frameFrames frames, e.g. 8kHz telephony audio to 16kHz and then 320 frame
+// (20ms) Opus packets. Output left over by one call is kept for the next; at the
+// end of the stream the last partial frame is padded with silence.
+//
+// NOTE: A Pipeline is NOT goroutine-safe.
+type Pipeline struct {
+	stage       *streamStage
+	encoder     Encoder
+	inChannels  int
+	channels    int         // Of the encoded frames
+	source      MediaFormat // Payload format of ProcessPayload, if set by NewFromFormats
+	outputRate  float64
+	frameFrames int
+	fifo        []float32 // Resampled interleaved frames not yet encoded
+	frame       []float32 // Padded last frame
+	decodeBuf   []float32
+	ended       bool
+}
+
+// NewPipeline creates a Pipeline resampling interleaved input from inputRate to
+// outputRate with a converter of the given type and encoding it with enc, in
+// frames of frameFrames frames (e.g. 960 for 20ms at 48kHz).
+func NewPipeline(enc Encoder, converterType ConverterType, channels int, inputRate, outputRate float64, frameFrames int) (*Pipeline, error) {
+	if enc == nil {
+		return nil, fmt.Errorf("pipeline needs an encoder")
+	}
+	if frameFrames <= 0 {
+		return nil, fmt.Errorf("frameFrames must be positive, got %d", frameFrames)
+	}
+	if inputRate <= 0 || math.IsNaN(inputRate) || math.IsInf(inputRate, 0) {
+		return nil, fmt.Errorf("input rate must be positive, got %f", inputRate)
+	}
+	ratio := outputRate / inputRate
+	if isBadSrcRatio(ratio) {
+		return nil, fmt.Errorf("%f Hz to %f Hz gives invalid ratio %f: %w", inputRate, outputRate, ratio, mapError(ErrBadSrcRatio))
+	}
+	conv, err := New(converterType, channels)
+	if err != nil {
+		return nil, err
+	}
+	return &Pipeline{
+		stage: &streamStage{
+			conv:    conv,
+			ratio:   ratio,
+			scratch: make([]float32, fanoutScratchFrames*channels),
+		},
+		encoder:     enc,
+		inChannels:  channels,
+		channels:    channels,
+		outputRate:  outputRate,
+		frameFrames: frameFrames,
+	}, nil
+}
+
+// FrameFrames returns the number of frames passed to each EncodeFrame call.
+func (p *Pipeline) FrameFrames() int {
+	return p.frameFrames
+}
+
+// OutputRate returns the sample rate the encoder is fed, in Hz.
+func (p *Pipeline) OutputRate() float64 {
+	return p.outputRate
+}
+
+// Process resamples the interleaved input, encodes every complete frame and
+// returns the packets in order; it may return none if less than a frame is
+// ready. If the encoder fails, the packets encoded so far are returned with the
+// error. Set endOfInput on the last call to flush the converter and encode the
+// final frame, padded with silence; call Reset before reusing the pipeline.
+func (p *Pipeline) Process(in []float32, endOfInput bool) ([][]byte, error) {
+	if p.ended {
+		return nil, fmt.Errorf("Process called after end of input; call Reset first")
+	}
+	if len(in)%p.inChannels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), p.inChannels)
+	}
+	if err := p.stage.process(in, p.inChannels, endOfInput); err != nil {
+		return nil, err
+	}
+	p.fifo = append(p.fifo, p.stage.out...)
+	p.ended = endOfInput
+
+	var packets [][]byte
+	frameLen := p.frameFrames * p.channels
+	n := 0
+	for ; len(p.fifo)-n >= frameLen; n += frameLen {
+		packet, err := p.encoder.EncodeFrame(p.fifo[n : n+frameLen])
+		if err != nil {
+			p.fifo = p.fifo[:copy(p.fifo, p.fifo[n:])] // Keep the failed frame
+			return packets, fmt.Errorf("encoder failed: %w", err)
+		}
+		packets = append(packets, packet)
+	}
+	p.fifo = p.fifo[:copy(p.fifo, p.fifo[n:])]
+
+	if endOfInput && len(p.fifo) > 0 {
+		p.frame = growFloats(p.frame, frameLen)
+		copy(p.frame, p.fifo)
+		for i := len(p.fifo); i < frameLen; i++ {
+			p.frame[i] = 0
+		}
+		p.fifo = p.fifo[:0]
+		packet, err := p.encoder.EncodeFrame(p.frame)
+		if err != nil {
+			return packets, fmt.Errorf("encoder failed: %w", err)
+		}
+		packets = append(packets, packet)
+	}
+	return packets, nil
+}
+
+// ProcessPCM is like Process but takes raw PCM in the given format.
+func (p *Pipeline) ProcessPCM(format SampleFormat, in []byte, endOfInput bool) ([][]byte, error) {
+	bps := format.BytesPerSample()
+	if bps == 0 {
+		return nil, fmt.Errorf("unknown sample format %d", format)
+	}
+	p.decodeBuf = growFloats(p.decodeBuf, len(in)/bps)
+	if _, err := DecodePCM(format, in, p.decodeBuf); err != nil {
+		return nil, err
+	}
+	return p.Process(p.decodeBuf, endOfInput)
+}
+
+// Buffered returns the number of resampled frames waiting for a full frame.
+func (p *Pipeline) Buffered() int {
+	return len(p.fifo) / p.channels
+}
+
+// Reset clears the queue and the converter state for a new stream.
+func (p *Pipeline) Reset() error {
+	p.fifo = p.fifo[:0]
+	p.ended = false
+	return p.stage.conv.Reset()
+}
+
+// Close releases the underlying converter. The encoder is not closed.
+func (p *Pipeline) Close() error {
+	return p.stage.conv.Close()
+}
@@ -0,0 +1,139 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"errors"
+	"testing"
+)
+
+// recordingEncoder keeps a copy of every frame it is given.
+type recordingEncoder struct {
+	frames [][]float32
+	failAt int // Fail the call with this index, if > 0
+}
+
+func (e *recordingEncoder) EncodeFrame(frame []float32) ([]byte, error) {
+	if e.failAt > 0 && len(e.frames) == e.failAt {
+		e.failAt = 0
+		return nil, errors.New("encoder full")
+	}
+	e.frames = append(e.frames, append([]float32(nil), frame...))
+	return []byte{byte(len(e.frames))}, nil
+}
+
+func TestPipelineFrames(t *testing.T) {
+	const channels, frameFrames = 2, 320
+	in := make([]float32, 8000*channels)
+	copy(in, genSine(len(in), 440, 8000, 0.5))
+
+	ref, _ := New(SincFastest, channels)
+	want, err := processAll(ref, in, channels, 2.0)
+	ref.Close()
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+
+	enc := &recordingEncoder{}
+	p, err := NewPipeline(enc, SincFastest, channels, 8000, 16000, frameFrames)
+	if err != nil {
+		t.Fatalf("NewPipeline failed: %v", err)
+	}
+	defer p.Close()
+	packets := 0
+	for pos := 0; pos < len(in); pos += 333 * channels { // Blocks unrelated to the frame size
+		end := minInt(pos+333*channels, len(in))
+		got, err := p.Process(in[pos:end], end == len(in))
+		if err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		packets += len(got)
+	}
+	if packets != len(enc.frames) {
+		t.Errorf("%d packets returned for %d frames encoded", packets, len(enc.frames))
+	}
+
+	wantFrames := IntDivCeil(len(want)/channels, frameFrames)
+	if len(enc.frames) != wantFrames {
+		t.Fatalf("%d frames encoded, want %d", len(enc.frames), wantFrames)
+	}
+	var got []float32
+	for i, f := range enc.frames {
+		if len(f) != frameFrames*channels {
+			t.Fatalf("frame %d has %d samples, want %d", i, len(f), frameFrames*channels)
+		}
+		got = append(got, f...)
+	}
+	for i := range got {
+		w := float32(0) // Padding of the last frame
+		if i < len(want) {
+			w = want[i]
+		}
+		if got[i] != w {
+			t.Fatalf("sample %d is %g, want %g", i, got[i], w)
+		}
+	}
+
+	if _, err := p.Process(in, false); err == nil {
+		t.Error("expected error for Process after end of input")
+	}
+	if err := p.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if _, err := p.Process(in[:100*channels], false); err != nil || p.Buffered() == 0 {
+		t.Errorf("after Reset: err %v, %d frames buffered", err, p.Buffered())
+	}
+}
+
+func TestPipelineEncoderError(t *testing.T) {
+	enc := &recordingEncoder{failAt: 2}
+	p, err := NewPipeline(enc, Linear, 1, 8000, 8000, 100)
+	if err != nil {
+		t.Fatalf("NewPipeline failed: %v", err)
+	}
+	defer p.Close()
+	in := genSine(1000, 440, 8000, 0.5)
+	packets, err := p.Process(in, false)
+	if err == nil || len(packets) != 2 {
+		t.Fatalf("got %d packets, err %v; want 2 packets and an error", len(packets), err)
+	}
+	// The failed frame is retried on the next call
+	packets, err = p.Process(nil, false)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if enc.frames[2][0] != in[200] {
+		t.Errorf("frame 2 starts with %g, want input sample 200 (%g)", enc.frames[2][0], in[200])
+	}
+	if len(packets) == 0 {
+		t.Error("no packets after the encoder recovered")
+	}
+}
+
+func TestNewPipelineErrors(t *testing.T) {
+	enc := &recordingEncoder{}
+	for _, tc := range []struct {
+		name        string
+		enc         Encoder
+		channels    int
+		in, out     float64
+		frameFrames int
+	}{
+		{"nil encoder", nil, 1, 8000, 16000, 320},
+		{"zero frame", enc, 1, 8000, 16000, 0},
+		{"bad input rate", enc, 1, 0, 16000, 320},
+		{"bad ratio", enc, 1, 8000, 8000 * 1000, 320},
+		{"bad channels", enc, 0, 8000, 16000, 320},
+	} {
+		if p, err := NewPipeline(tc.enc, SincFastest, tc.channels, tc.in, tc.out, tc.frameFrames); err == nil {
+			p.Close()
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "math"
+
+// --- Pitch Estimation Constants ---
+const (
+	pitchMinHz   = 40.0   // Lowest pitch searched, below the low E of a bass
+	pitchMaxHz   = 2000.0 // Highest pitch searched, above a soprano's range
+	yinThreshold = 0.15   // Normalized difference below which a lag counts as periodic
+	yinMinTau    = 2      // Shortest lag searched, in samples
+)
+
+// EstimatePitch returns the fundamental frequency of a mono frame of audio at
+// rate Hz with YIN (de Cheveigné and Kawahara, 2002): the first lag whose
+// cumulative mean normalized difference falls below a threshold, refined by
+// parabolic interpolation, which avoids the octave errors of picking the
+// autocorrelation peak on audio with strong harmonics.
+//
+// Pitches from 40Hz to 2kHz (or a quarter of rate) are found; the frame must
+// hold at least two periods of the lowest pitch searched, 2*rate/40 samples
+// for the full range, and a shorter frame limits the search to the pitches
+// that fit. Frames of about 50ms are a good compromise for voice and
+// instruments.
+//
+// To re-tune audio with a converter, see RetuneRatio.
+//
+// Returns:
+//
+//	The pitch in Hz, or 0 for silence, noise and frames too short to tell.
+func EstimatePitch(frame []float32, rate int) (hz float64) {
+	if rate <= 0 {
+		return 0
+	}
+	tauMin := maxInt(int(float64(rate)/minFloat64(pitchMaxHz, float64(rate)/4)), yinMinTau)
+	tauMax := minInt(int(math.Ceil(float64(rate)/pitchMinHz)), len(frame)/2)
+	if tauMax <= tauMin+1 {
+		return 0
+	}
+	window := len(frame) - tauMax
+
+	// Difference function, normalized by its cumulative mean so that it starts
+	// at 1 and dips towards 0 at the period and its multiples
+	diff := make([]float64, tauMax+1)
+	diff[0] = 1
+	var sum float64
+	for tau := 1; tau <= tauMax; tau++ {
+		var d float64
+		for j, v := range frame[:window] {
+			delta := float64(v) - float64(frame[j+tau])
+			d += delta * delta
+		}
+		sum += d
+		if sum == 0 {
+			diff[tau] = 1
+		} else {
+			diff[tau] = d * float64(tau) / sum
+		}
+	}
+
+	tau := 0
+	for t := tauMin; t < tauMax; t++ {
+		if diff[t] < yinThreshold {
+			for t+1 < tauMax && diff[t+1] < diff[t] {
+				t++
+			}
+			tau = t
+			break
+		}
+	}
+	if tau == 0 {
+		return 0
+	}
+
+	// The parabola through the minimum and its neighbours places the period
+	// between samples
+	period := float64(tau)
+	a, b, c := diff[tau-1], diff[tau], diff[tau+1]
+	if den := a - 2*b + c; den > 0 {
+		period += 0.5 * (a - c) / den
+	}
+	return float64(rate) / period
+}
+
+// RetuneRatio returns the conversion ratio that moves audio pitched at hz to
+// targetHz when the output is played at the input's sample rate, as with
+// varispeed: converting by ratio r makes the audio last r times as long and
+// divides its pitch by r. It is 0 when either pitch is not positive, e.g. when
+// EstimatePitch found none.
+func RetuneRatio(hz, targetHz float64) float64 {
+	if !(hz > 0) || !(targetHz > 0) {
+		return 0
+	}
+	return hz / targetHz
+}
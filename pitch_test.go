@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEstimatePitch(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rate  int
+		freq  float64
+		frame []float32
+	}{
+		{"sine 220Hz", 16000, 220, genSine(2048, 220, 16000, 0.5)},
+		{"sine 55Hz", 44100, 55, genSine(4096, 55, 44100, 0.5)},
+		{"sine 1760Hz", 48000, 1760, genSine(2048, 1760, 48000, 0.5)},
+		{"sine 311.1Hz", 8000, 311.1, genSine(400, 311.1, 8000, 0.5)},
+		{"harmonics 130.8Hz", 44100, 130.8, harmonicTone(2048, 130.8, 44100)},
+		{"harmonics 440Hz", 22050, 440, harmonicTone(1024, 440, 22050)},
+	} {
+		got := EstimatePitch(tt.frame, tt.rate)
+		if math.Abs(got-tt.freq) > tt.freq*0.002 {
+			t.Errorf("%s: got %.2fHz", tt.name, got)
+		}
+	}
+
+	noise := make([]float32, 2048)
+	rng := rand.New(rand.NewSource(1))
+	for i := range noise {
+		noise[i] = float32(rng.Float64() - 0.5)
+	}
+	for name, frame := range map[string][]float32{
+		"silence":   make([]float32, 2048),
+		"noise":     noise,
+		"too short": genSine(8, 220, 16000, 0.5),
+		"empty":     nil,
+	} {
+		if got := EstimatePitch(frame, 16000); got != 0 {
+			t.Errorf("%s: got %.2fHz, want 0", name, got)
+		}
+	}
+	if got := EstimatePitch(genSine(2048, 220, 16000, 0.5), 0); got != 0 {
+		t.Errorf("zero rate: got %.2fHz, want 0", got)
+	}
+}
+
+// TestRetuneRatio re-tunes a tone with the ratio and checks the new pitch.
+func TestRetuneRatio(t *testing.T) {
+	const rate = 16000
+	in := genSine(8000, 233, rate, 0.5)
+	ratio := RetuneRatio(EstimatePitch(in[:2048], rate), 220)
+	conv, err := New(SincMediumQuality, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conv.Close()
+	out, err := processAll(conv, in, 1, ratio)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EstimatePitch(out[2048:4096], rate); math.Abs(got-220) > 0.5 {
+		t.Errorf("re-tuned to %.2fHz, want 220Hz", got)
+	}
+	if RetuneRatio(0, 220) != 0 || RetuneRatio(220, math.NaN()) != 0 {
+		t.Error("expected 0 for a missing pitch")
+	}
+}
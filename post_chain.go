@@ -0,0 +1,68 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// postChain is the processing Options and SetEffects add to the output of a
+// converter: Options.ChannelGains, the effects, the Options.FadeFrames fade-in,
+// Options.Meter and Options.OnBlock, in that order. Only the outermost
+// converter runs it, once per call, on the frames the caller gets: a srcState
+// on its own, or the channelGroups or channelMapper wrapping the converters
+// that resample, which run with innerOptions and so with an empty chain.
+type postChain struct {
+	channels    int // Of the output frames
+	gains       []float64
+	effects     Effect
+	fadeFrames  int
+	fadePos     int64 // Output frames since the last reset
+	meter       MeterFunc
+	meterBuf    []float32 // Peak and RMS passed to meter
+	onBlock     func(inUsed, outGen int64)
+	errorOnFull bool // Options.ErrorOnOutputFull, checked by the converter after run
+}
+
+func newPostChain(channels int, opts Options) postChain {
+	return postChain{
+		channels:    channels,
+		gains:       opts.ChannelGains,
+		fadeFrames:  opts.FadeFrames,
+		meter:       opts.Meter,
+		onBlock:     opts.OnBlock,
+		errorOnFull: opts.ErrorOnOutputFull,
+	}
+}
+
+// run processes the outGen frames of out a call generated from inUsed input
+// frames.
+func (p *postChain) run(out []float32, inUsed, outGen int64) {
+	applyChannelGains(out, p.gains, outGen)
+	if p.effects != nil && outGen > 0 {
+		p.effects.Apply(out[:outGen*int64(p.channels)], p.channels)
+	}
+	fadeIn(out, p.channels, outGen, &p.fadePos, p.fadeFrames)
+	meterBlock(p.meter, &p.meterBuf, out, p.channels, outGen)
+	if p.onBlock != nil && (inUsed > 0 || outGen > 0) {
+		p.onBlock(inUsed, outGen)
+	}
+}
+
+// reset starts the chain over for a new stream.
+func (p *postChain) reset() {
+	p.fadePos = 0
+	if p.effects != nil {
+		p.effects.Reset()
+	}
+}
+
+// clone returns the chain for a clone of the converter, without the effects,
+// which hold per-stream state, and without the scratch buffer.
+func (p *postChain) clone() postChain {
+	c := *p
+	c.effects, c.meterBuf = nil, nil
+	return c
+}
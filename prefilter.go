@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+)
+
+// PreFilter processes one channel of input in place before it is resampled, e.g.
+// a DC blocker, an EQ or an anti-aliasing band-limit. block holds consecutive
+// samples of the given channel (0-based); successive calls for a channel carry on
+// where the previous one ended, so filters can keep per-channel state.
+type PreFilter func(channel int, block []float32)
+
+// SetPreFilter installs a pre-filter run inside Process on every new input frame,
+// before resampling; nil removes it. The caller's input slice is never modified.
+//
+// Each input frame is filtered exactly once: frames that Process does not consume
+// are kept filtered and reused when the caller passes them again at the start of
+// the next call (the usual contract: resubmit DataIn[InputFramesUsed:]). Reset
+// discards them.
+func SetPreFilter(c Converter, f PreFilter) error {
+	switch conv := c.(type) {
+	case *srcState:
+		if conv == nil {
+			return mapError(ErrBadState)
+		}
+		conv.preFilter = f
+		conv.preCarryFrames = 0
+		return nil
+	case *channelGroups:
+		first := 0
+		for i, state := range conv.groups {
+			var groupFilter PreFilter
+			if f != nil {
+				offset := first
+				groupFilter = func(channel int, block []float32) { f(offset+channel, block) }
+			}
+			state.preFilter = groupFilter
+			state.preCarryFrames = 0
+			first += conv.widths[i]
+		}
+		return nil
+	default:
+		return fmt.Errorf("pre-filter not supported by %T", c)
+	}
+}
+
+// preFilterInput returns the input of data with the pre-filter applied, reusing
+// the filtered frames left over from the previous call.
+func (state *srcState) preFilterInput(data *SrcData) []float32 {
+	channels := state.channels
+	frames := minInt(int(data.InputFrames), len(data.DataIn)/channels)
+	state.preBuf = growFloats(state.preBuf, frames*channels)
+	buf := state.preBuf
+
+	carry := minInt(int(state.preCarryFrames), frames)
+	copy(buf, state.preCarry[:carry*channels])
+	copy(buf[carry*channels:], data.DataIn[carry*channels:frames*channels])
+
+	fresh := frames - carry
+	if fresh == 0 {
+		return buf
+	}
+	state.preChannel = growFloats(state.preChannel, fresh)
+	block := state.preChannel
+	for ch := 0; ch < channels; ch++ {
+		for i := 0; i < fresh; i++ {
+			block[i] = buf[(carry+i)*channels+ch]
+		}
+		state.preFilter(ch, block)
+		for i := 0; i < fresh; i++ {
+			buf[(carry+i)*channels+ch] = block[i]
+		}
+	}
+	return buf
+}
+
+// preFilterKeepUnused saves the filtered frames Process did not consume.
+func (state *srcState) preFilterKeepUnused(data *SrcData) {
+	frames := int64(len(data.DataIn) / state.channels)
+	used := minInt64(data.InputFramesUsed, frames)
+	state.preCarry = append(state.preCarry[:0], data.DataIn[int(used)*state.channels:]...)
+	state.preCarryFrames = frames - used
+}
@@ -0,0 +1,143 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// dcBlocker is a stateful per-channel high-pass filter, y[n] = x[n] - x[n-1] + r*y[n-1].
+type dcBlocker struct {
+	prevX, prevY []float64
+	seen         []int // Samples filtered per channel
+}
+
+func newDCBlocker(channels int) *dcBlocker {
+	return &dcBlocker{prevX: make([]float64, channels), prevY: make([]float64, channels), seen: make([]int, channels)}
+}
+
+func (d *dcBlocker) filter(channel int, block []float32) {
+	for i, x := range block {
+		y := float64(x) - d.prevX[channel] + 0.995*d.prevY[channel]
+		d.prevX[channel], d.prevY[channel] = float64(x), y
+		block[i] = float32(y)
+	}
+	d.seen[channel] += len(block)
+}
+
+// TestPreFilterMatchesOfflinePass checks filtering inside Process gives the same
+// output as filtering the whole input first, even when Process leaves input
+// unconsumed and the caller resubmits it.
+func TestPreFilterMatchesOfflinePass(t *testing.T) {
+	const channels = 2
+	const frames = 3000
+	in := make([]float32, frames*channels)
+	mono := make([]float32, frames)
+	genWindowedSinesGo(2, []float64{0.02, 0.11}, 0.5, mono)
+	for i, v := range mono {
+		in[i*channels] = v + 0.3 // DC offset to remove
+		in[i*channels+1] = -v
+	}
+
+	for _, ct := range []ConverterType{SincFastest, Linear, ZeroOrderHold} {
+		for _, channelCount := range []int{channels, 130} {
+			t.Run(fmt.Sprintf("%s_%dch", GetName(ct), channelCount), func(t *testing.T) {
+				input := in
+				if channelCount != channels { // Wide layout, split into channel groups for sinc
+					input = make([]float32, frames*channelCount)
+					for fr := 0; fr < frames; fr++ {
+						for ch := 0; ch < channelCount; ch++ {
+							input[fr*channelCount+ch] = in[fr*channels+ch%channels]
+						}
+					}
+				}
+
+				// Reference: filter everything, then resample
+				offline := append([]float32(nil), input...)
+				ref := newDCBlocker(channelCount)
+				for ch := 0; ch < channelCount; ch++ {
+					block := make([]float32, frames)
+					for fr := range block {
+						block[fr] = offline[fr*channelCount+ch]
+					}
+					ref.filter(ch, block)
+					for fr := range block {
+						offline[fr*channelCount+ch] = block[fr]
+					}
+				}
+				refConv, err := New(ct, channelCount)
+				if err != nil {
+					t.Fatalf("New failed: %v", err)
+				}
+				want := drain(t, refConv, offline, channelCount, channelCount, 0.8, 50)
+				refConv.Close()
+
+				conv, err := New(ct, channelCount)
+				if err != nil {
+					t.Fatalf("New failed: %v", err)
+				}
+				defer conv.Close()
+				dc := newDCBlocker(channelCount)
+				if err := SetPreFilter(conv, dc.filter); err != nil {
+					t.Fatalf("SetPreFilter failed: %v", err)
+				}
+
+				original := append([]float32(nil), input...)
+				got := drain(t, conv, input, channelCount, channelCount, 0.8, 50)
+
+				for ch, n := range dc.seen {
+					if n != frames {
+						t.Fatalf("channel %d: %d samples filtered, want %d (each exactly once)", ch, n, frames)
+					}
+				}
+				for i := range input {
+					if input[i] != original[i] {
+						t.Fatalf("caller input modified at sample %d", i)
+					}
+				}
+				if len(got) != len(want) {
+					t.Fatalf("got %d samples, want %d", len(got), len(want))
+				}
+				for i := range want {
+					if got[i] != want[i] {
+						t.Fatalf("sample %d: got %g, want %g", i, got[i], want[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPreFilterRemove(t *testing.T) {
+	conv, err := New(Linear, 1)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	calls := 0
+	if err := SetPreFilter(conv, func(int, []float32) { calls++ }); err != nil {
+		t.Fatalf("SetPreFilter failed: %v", err)
+	}
+	in := make([]float32, 100)
+	out := make([]float32, 200)
+	data := SrcData{DataIn: in, InputFrames: 100, DataOut: out, OutputFrames: 200, SrcRatio: 1}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if err := SetPreFilter(conv, nil); err != nil {
+		t.Fatalf("SetPreFilter(nil) failed: %v", err)
+	}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("pre-filter called %d times, want 1", calls)
+	}
+}
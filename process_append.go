@@ -0,0 +1,242 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"sync"
+)
+
+const appendMarginFrames = 256 // Output frames reserved beyond the ratio estimate, for the filter tail
+
+// ProcessAppend is Process with an output that grows: it converts all of
+// data.DataIn and appends the result to *out, growing it as needed, so a short
+// output buffer cannot truncate the audio. data.DataOut and data.OutputFrames
+// are ignored. With EndOfInput set it also drains the converter.
+//
+// Args:
+//
+//	c: A converter created in process mode.
+//	data: Input block; InputFramesUsed and OutputFramesGen are set to the totals.
+//	out: Interleaved output, appended to. Its frames have the output channel
+//	     count, which differs from GetChannels with Options.OutputChannels.
+//
+// Returns:
+//
+//	The error of the failing Process call, if any; the output generated before it
+//	is kept in *out.
+func ProcessAppend(c Converter, data *SrcData, out *[]float32) error {
+	if c == nil || data == nil || out == nil {
+		return mapError(ErrBadData)
+	}
+	inChannels, outChannels := c.GetChannels(), outputChannelsOf(c)
+	inFrames := maxInt64(data.InputFrames, 0)
+	if int64(len(data.DataIn)) < inFrames*int64(inChannels) {
+		return mapError(ErrBadDataPtr)
+	}
+
+	data.InputFramesUsed, data.OutputFramesGen = 0, 0
+	for {
+		left := inFrames - data.InputFramesUsed
+		room := int64(math.Ceil(float64(left)*data.SrcRatio)) + appendMarginFrames
+		start := len(*out)
+		*out = extendFloats(*out, int(room)*outChannels)
+		block := SrcData{
+			DataIn:       data.DataIn[data.InputFramesUsed*int64(inChannels):],
+			InputFrames:  left,
+			DataOut:      (*out)[start:],
+			OutputFrames: room,
+			SrcRatio:     data.SrcRatio,
+			EndOfInput:   data.EndOfInput,
+		}
+		if left == 0 {
+			block.DataIn = nil
+		}
+		err := c.Process(&block)
+		if mapGoErrorToCode(err) == ErrOutputFull {
+			err = nil // Expected here, the loop grows the output
+		}
+		*out = (*out)[:start+int(block.OutputFramesGen)*outChannels]
+		data.InputFramesUsed += block.InputFramesUsed
+		data.OutputFramesGen += block.OutputFramesGen
+		if err != nil {
+			return err
+		}
+		if block.OutputFramesGen < block.OutputFrames {
+			return nil // Stopped for lack of input (or drained), not of room
+		}
+	}
+}
+
+// outputFull reports whether a Process call on c stopped because DataOut was
+// full while c could have produced more: input is left over, or a sinc converter
+// has buffered input it has not turned into output yet.
+func outputFull(c Converter, data *SrcData) bool {
+	if data.OutputFramesGen+trimmedLast(c) < data.OutputFrames {
+		return false // Frames dropped by Options.TrimLeadingTransient filled DataOut too
+	}
+	return data.InputFramesUsed < data.InputFrames || outputPending(c)
+}
+
+// outputPending reports whether c holds enough buffered input for more output.
+func outputPending(c Converter) bool {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.outputPending()
+	case *channelGroups:
+		return len(conv.groups) > 0 && conv.groups[0].outputPending() // Groups run in lockstep
+	case *channelMapper:
+		return outputPending(conv.inner)
+	}
+	return false
+}
+
+// outputPending reports whether the sinc buffer holds input past the filter
+// lookahead (or, after the end of input, before its real end), i.e. whether the
+// next Process call would generate output without new input. Linear and ZOH
+// consume input only as they produce output, so they never hold any.
+func (state *srcState) outputPending() bool {
+	filter, ok := state.privateData.(*sincFilter)
+	if !ok || filter == nil || filter.bLen <= 0 || filter.indexInc <= 0 || state.lastRatio <= 0 {
+		return false
+	}
+	if filter.bRealEnd >= 0 {
+		return float64(filter.bCurrent)+state.lastPosition+1/state.lastRatio < float64(filter.bRealEnd)
+	}
+	inHand := filter.bEnd - filter.bCurrent
+	if inHand < 0 {
+		inHand += filter.bLen
+	}
+	count := float64(filter.coeffHalfLen+2) / float64(filter.indexInc)
+	if state.lastRatio < 1 {
+		count /= math.Max(state.lastRatio, 1/srcMaxRatio)
+	}
+	return inHand > int64(state.channels)*int64(psfLrint(count)+1)
+}
+
+// outputChannelsOf returns the channel count of the frames c writes to DataOut.
+func outputChannelsOf(c Converter) int {
+	if m, ok := c.(*channelMapper); ok {
+		return m.mix.out
+	}
+	return c.GetChannels()
+}
+
+// extendFloats returns buf lengthened by n samples, reallocating with room to
+// spare when its capacity is too small.
+func extendFloats(buf []float32, n int) []float32 {
+	if cap(buf)-len(buf) < n {
+		grown := make([]float32, len(buf), 2*len(buf)+n)
+		copy(grown, buf)
+		buf = grown
+	}
+	return buf[:len(buf)+n]
+}
+
+// s16Scratch holds the float output of ProcessToS16LE between calls, so it does
+// not allocate a block's worth of samples each time.
+var s16Scratch = sync.Pool{New: func() interface{} { return new([]float32) }}
+
+// ProcessToS16LE is Process writing the output as S16LE bytes, rounded and
+// clipped as EncodePCM does, for servers that send raw 16-bit PCM: the float
+// frames go through a pooled buffer straight into out, so there is no float
+// output buffer to manage and copy from. data.DataOut is ignored; the output
+// is limited by len(out) and, when positive, data.OutputFrames.
+//
+// Args:
+//
+//	c: A converter created in process mode.
+//	data: Input block, as for Process; OutputFramesGen is set to the frames written.
+//	out: Interleaved S16LE output, 2 bytes per sample of the output channel count.
+//
+// Returns:
+//
+//	The number of bytes written to out, and the error of Process, if any.
+func ProcessToS16LE(c Converter, data *SrcData, out []byte) (bytesWritten int, err error) {
+	if c == nil || data == nil {
+		return 0, mapError(ErrBadData)
+	}
+	channels := outputChannelsOf(c)
+	frames := int64(len(out) / (2 * channels))
+	if data.OutputFrames > 0 {
+		frames = minInt64(frames, data.OutputFrames)
+	}
+	scratch := s16Scratch.Get().(*[]float32)
+	defer s16Scratch.Put(scratch)
+	*scratch = growFloats(*scratch, int(frames)*channels)
+
+	// Run on data itself, as a copy would escape to the heap
+	callerOut, callerFrames := data.DataOut, data.OutputFrames
+	data.DataOut, data.OutputFrames = *scratch, frames
+	err = c.Process(data)
+	data.DataOut, data.OutputFrames = callerOut, callerFrames
+	n, _ := EncodePCM(FormatS16LE, (*scratch)[:data.OutputFramesGen*int64(channels)], out)
+	return 2 * n, err
+}
+
+// ProcessFromS16LE is Process reading the input as S16LE bytes, for servers
+// that receive raw 16-bit PCM. A sinc converter decodes the samples straight
+// into its ring buffer as it loads them, so there is no float input buffer to
+// fill and no copy of it; other converters, and sinc ones with a pre-filter or
+// while they may still pass the input through, decode into an internal buffer
+// first. data.DataIn is ignored; the input is limited by len(in) and, when
+// positive, data.InputFrames.
+//
+// Args:
+//
+//	c: A converter created in process mode.
+//	data: Output block, as for Process; InputFramesUsed is set to the frames
+//	      read from in, which the next call must start after.
+//	in: Interleaved S16LE input, 2 bytes per sample of GetChannels channels.
+//
+// Returns:
+//
+//	The error of Process, if any.
+func ProcessFromS16LE(c Converter, data *SrcData, in []byte) error {
+	if c == nil || data == nil {
+		return mapError(ErrBadData)
+	}
+	channels := c.GetChannels()
+	frames := int64(len(in) / (2 * channels))
+	if data.InputFrames > 0 {
+		frames = minInt64(frames, data.InputFrames)
+	}
+	in = in[:2*frames*int64(channels)]
+
+	// Run on data itself, as a copy would escape to the heap
+	callerIn, callerFrames := data.DataIn, data.InputFrames
+	data.DataIn, data.InputFrames = nil, frames
+	var err error
+	if state, ok := c.(*srcState); ok && state != nil {
+		state.s16In = in
+		err = state.Process(data)
+		state.s16In = nil
+	} else {
+		scratch := s16Scratch.Get().(*[]float32)
+		defer s16Scratch.Put(scratch)
+		*scratch = growFloats(*scratch, len(in)/2)
+		DecodePCM(FormatS16LE, in, *scratch)
+		data.DataIn = *scratch
+		err = c.Process(data)
+	}
+	data.DataIn, data.InputFrames = callerIn, callerFrames
+	return err
+}
+
+// s16Filter returns the sinc filter that decodes the input of ProcessFromS16LE
+// itself, or nil when it must be decoded first: for Linear and ZeroOrderHold,
+// which read DataIn, with a pre-filter, which rewrites it, and until the
+// converter has stopped passing the input through.
+func (state *srcState) s16Filter() *sincFilter {
+	filter, ok := state.privateData.(*sincFilter)
+	if !ok || filter == nil || state.preFilter != nil || !state.filtered {
+		return nil
+	}
+	return filter
+}
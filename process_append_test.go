@@ -0,0 +1,350 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "testing"
+
+func TestProcessAppend(t *testing.T) {
+	in := genSine(3000, 440, 8000, 0.5)
+	for _, ct := range []ConverterType{SincBestQuality, SincFastest, Linear, ZeroOrderHold} {
+		for _, ratio := range []float64{0.5, 1, 3} {
+			want := resampleWhole(t, ct, in, 1, ratio)
+
+			conv, err := New(ct, 1)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			prefix := []float32{9, 9}
+			out := append(make([]float32, 0, 4), prefix...) // Far too small, must grow
+			data := SrcData{DataIn: in, InputFrames: int64(len(in)), SrcRatio: ratio, EndOfInput: true}
+			if err := ProcessAppend(conv, &data, &out); err != nil {
+				t.Fatalf("%v at %g: ProcessAppend failed: %v", ct, ratio, err)
+			}
+			conv.Close()
+
+			if data.InputFramesUsed != int64(len(in)) || data.OutputFramesGen != int64(len(want)) {
+				t.Errorf("%v at %g: used %d and generated %d frames, want %d and %d",
+					ct, ratio, data.InputFramesUsed, data.OutputFramesGen, len(in), len(want))
+			}
+			if out[0] != 9 || out[1] != 9 {
+				t.Fatalf("%v at %g: existing output overwritten", ct, ratio)
+			}
+			got := out[len(prefix):]
+			if len(got) != len(want) {
+				t.Fatalf("%v at %g: appended %d samples, want %d", ct, ratio, len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("%v at %g: sample %d = %g, want %g", ct, ratio, i, got[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestProcessAppendStreaming(t *testing.T) {
+	in := genSine(4000, 300, 8000, 0.5)
+	want := resampleWhole(t, SincMediumQuality, in, 1, 2.5)
+
+	conv, err := NewWithOptions(SincMediumQuality, 1, Options{ErrorOnOutputFull: true})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	var out []float32
+	for pos := 0; pos < len(in); pos += 500 {
+		block := in[pos : pos+500]
+		data := SrcData{DataIn: block, InputFrames: 500, SrcRatio: 2.5, EndOfInput: pos+500 == len(in)}
+		if err := ProcessAppend(conv, &data, &out); err != nil {
+			t.Fatalf("ProcessAppend failed: %v", err)
+		}
+		if data.InputFramesUsed != 500 {
+			t.Fatalf("block at %d: used %d of 500 frames", pos, data.InputFramesUsed)
+		}
+	}
+	if len(out) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Fatalf("sample %d = %g, want %g", i, out[i], want[i])
+		}
+	}
+}
+
+func TestProcessAppendOutputChannels(t *testing.T) {
+	conv, err := NewWithOptions(Linear, 1, Options{OutputChannels: 2})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	in := genSine(1000, 440, 8000, 0.5)
+	var out []float32
+	data := SrcData{DataIn: in, InputFrames: 1000, SrcRatio: 2, EndOfInput: true}
+	if err := ProcessAppend(conv, &data, &out); err != nil {
+		t.Fatalf("ProcessAppend failed: %v", err)
+	}
+	if int64(len(out)) != 2*data.OutputFramesGen || data.OutputFramesGen < 1990 {
+		t.Fatalf("got %d samples for %d frames", len(out), data.OutputFramesGen)
+	}
+	for i := 0; i < len(out); i += 2 {
+		if out[i] != out[i+1] {
+			t.Fatalf("frame %d: channels differ after upmixing, %g and %g", i/2, out[i], out[i+1])
+		}
+	}
+}
+
+func TestProcessAppendErrors(t *testing.T) {
+	conv, err := New(Linear, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	var out []float32
+	if err := ProcessAppend(conv, nil, &out); err == nil {
+		t.Error("nil data accepted")
+	}
+	if err := ProcessAppend(conv, &SrcData{DataIn: make([]float32, 3), InputFrames: 2, SrcRatio: 1}, &out); err == nil {
+		t.Error("short input accepted")
+	}
+	if err := ProcessAppend(conv, &SrcData{DataIn: make([]float32, 4), InputFrames: 2, SrcRatio: 0}, &out); err == nil {
+		t.Error("bad ratio accepted")
+	}
+}
+
+func TestErrorOnOutputFull(t *testing.T) {
+	cases := []struct {
+		name     string
+		ct       ConverterType
+		channels int
+		opts     Options
+	}{
+		{"linear", Linear, 1, Options{ErrorOnOutputFull: true}},
+		{"sinc", SincFastest, 2, Options{ErrorOnOutputFull: true}},
+		{"downmix", SincFastest, 2, Options{ErrorOnOutputFull: true, OutputChannels: 1}},
+		{"groups", SincFastest, 2 * maxChannels, Options{ErrorOnOutputFull: true}},
+	}
+	for _, tc := range cases {
+		outChannels := tc.channels
+		if tc.opts.OutputChannels > 0 {
+			outChannels = tc.opts.OutputChannels
+		}
+		in := make([]float32, 1000*tc.channels)
+		for i := range in {
+			in[i] = 0.25
+		}
+
+		conv, err := NewWithOptions(tc.ct, tc.channels, tc.opts)
+		if err != nil {
+			t.Fatalf("%s: NewWithOptions failed: %v", tc.name, err)
+		}
+		out := make([]float32, 100*outChannels)
+		data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 100, SrcRatio: 2}
+		err = conv.Process(&data)
+		if mapGoErrorToCode(err) != ErrOutputFull {
+			t.Errorf("%s: short output gave %v, want ErrOutputFull", tc.name, err)
+		}
+		if data.OutputFramesGen != 100 || data.InputFramesUsed == 0 {
+			t.Errorf("%s: used %d frames and generated %d, want a full output block", tc.name, data.InputFramesUsed, data.OutputFramesGen)
+		}
+		if mapGoErrorToCode(conv.LastError()) != ErrOutputFull {
+			t.Errorf("%s: LastError = %v, want ErrOutputFull", tc.name, conv.LastError())
+		}
+
+		// The rest goes through with enough room: the sinc converters buffered all
+		// of the input and only owe output, Linear left input over
+		rest := in[data.InputFramesUsed*int64(tc.channels):]
+		out = make([]float32, 4000*outChannels)
+		data = SrcData{DataIn: rest, InputFrames: int64(len(rest) / tc.channels), DataOut: out, OutputFrames: 4000, SrcRatio: 2}
+		if err := conv.Process(&data); err != nil {
+			t.Errorf("%s: Process with room failed: %v", tc.name, err)
+		}
+		if data.OutputFramesGen < 1500 {
+			t.Errorf("%s: generated %d frames of the rest, want the pending output", tc.name, data.OutputFramesGen)
+		}
+		conv.Close()
+	}
+
+	// Without the option a full output is not an error
+	conv, err := New(Linear, 1)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	data := SrcData{DataIn: make([]float32, 1000), InputFrames: 1000, DataOut: make([]float32, 100), OutputFrames: 100, SrcRatio: 2}
+	if err := conv.Process(&data); err != nil {
+		t.Errorf("Process without ErrorOnOutputFull failed: %v", err)
+	}
+}
+
+// TestProcessToS16LE streams a stereo sine and a mono-to-stereo upmix through
+// ProcessToS16LE and checks the bytes match Process followed by EncodePCM.
+func TestProcessToS16LE(t *testing.T) {
+	for _, tt := range []struct {
+		channels int
+		opts     Options
+	}{{2, Options{}}, {1, Options{OutputChannels: 2}}} {
+		in := make([]float32, 0, 4000*tt.channels)
+		for _, v := range genSine(4000, 440, 8000, 1.2) { // Clips
+			for c := 0; c < tt.channels; c++ {
+				in = append(in, v)
+			}
+		}
+		ref, err := NewWithOptions(SincFastest, tt.channels, tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conv, _ := NewWithOptions(SincFastest, tt.channels, tt.opts)
+		floats := make([]float32, 2*400)
+		want := make([]byte, 2*len(floats))
+		got := make([]byte, len(want)+3) // A partial sample is left alone
+		for pos := 0; pos < 4000; pos += 160 {
+			block := in[pos*tt.channels : (pos+160)*tt.channels]
+			data := SrcData{DataIn: block, InputFrames: 160, DataOut: floats, OutputFrames: 400, SrcRatio: 2, EndOfInput: pos+160 == 4000}
+			if err := ref.Process(&data); err != nil {
+				t.Fatal(err)
+			}
+			n, _ := EncodePCM(FormatS16LE, floats[:2*data.OutputFramesGen], want)
+
+			data = SrcData{DataIn: block, InputFrames: 160, SrcRatio: 2, EndOfInput: pos+160 == 4000}
+			written, err := ProcessToS16LE(conv, &data, got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if written != 2*n || data.InputFramesUsed != 160 || string(got[:written]) != string(want[:2*n]) {
+				t.Fatalf("%d channels, block at %d: %d bytes for %d frames differ from Process and EncodePCM (%d bytes)", tt.channels, pos, written, data.OutputFramesGen, 2*n)
+			}
+		}
+		ref.Close()
+		conv.Close()
+	}
+
+	conv, _ := New(Linear, 1)
+	defer conv.Close()
+	in := genSine(160, 440, 8000, 0.5)
+	data := SrcData{DataIn: in, InputFrames: 160, OutputFrames: 10, SrcRatio: 2}
+	if written, _ := ProcessToS16LE(conv, &data, make([]byte, 1000)); written != 20 {
+		t.Errorf("wrote %d bytes, want OutputFrames (10 frames) of them", written)
+	}
+	if _, err := ProcessToS16LE(conv, nil, make([]byte, 10)); err == nil {
+		t.Error("nil data accepted")
+	}
+	out, floats := make([]byte, 1000), make([]float32, 500)
+	allocs := testing.AllocsPerRun(20, func() {
+		data := SrcData{DataIn: in, InputFrames: 160, SrcRatio: 2}
+		ProcessToS16LE(conv, &data, out)
+	})
+	processAllocs := testing.AllocsPerRun(20, func() {
+		data := SrcData{DataIn: in, InputFrames: 160, DataOut: floats, OutputFrames: 500, SrcRatio: 2}
+		conv.Process(&data)
+	})
+	if allocs > processAllocs {
+		t.Errorf("%.0f allocations per call, want those of Process (%.0f)", allocs, processAllocs)
+	}
+}
+
+// TestProcessFromS16LE streams S16LE through ProcessFromS16LE in uneven blocks
+// with a short output, so input is left over, and checks the output matches
+// DecodePCM followed by Process, for the sinc ring buffer decoding the bytes and
+// the converters decoding them first.
+func TestProcessFromS16LE(t *testing.T) {
+	gain := func(channel int, block []float32) {
+		for i := range block {
+			block[i] *= 0.5
+		}
+	}
+	for _, tt := range []struct {
+		name          string
+		converterType ConverterType
+		channels      int
+		opts          Options
+		preFilter     PreFilter
+	}{
+		{"sinc mono", SincFastest, 1, Options{}, nil},
+		{"sinc stereo", SincMediumQuality, 2, Options{}, nil},
+		{"sinc trimmed", SincFastest, 2, Options{TrimLeadingTransient: true}, nil},
+		{"sinc pre-filtered", SincFastest, 1, Options{}, gain},
+		{"linear", Linear, 2, Options{}, nil},
+		{"downmix", SincFastest, 2, Options{OutputChannels: 1}, nil},
+	} {
+		in := make([]float32, 0, 3000*tt.channels)
+		for _, v := range genSine(3000, 440, 8000, 0.8) {
+			for c := 0; c < tt.channels; c++ {
+				in = append(in, v*float32(c+1)/float32(tt.channels))
+			}
+		}
+		pcm := make([]byte, 2*len(in))
+		EncodePCM(FormatS16LE, in, pcm)
+		decoded := make([]float32, len(in))
+		DecodePCM(FormatS16LE, pcm, decoded)
+
+		run := func(fromBytes bool) []float32 {
+			conv, err := NewWithOptions(tt.converterType, tt.channels, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conv.Close()
+			if tt.preFilter != nil {
+				if err := SetPreFilter(conv, tt.preFilter); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var got []float32
+			out := make([]float32, 2*150)
+			for pos, b := 0, 0; ; b++ {
+				n := minInt(40+37*(b%5), 3000-pos)
+				data := SrcData{InputFrames: int64(n), DataOut: out, OutputFrames: 150, SrcRatio: 1.5, EndOfInput: pos+n == 3000}
+				if fromBytes {
+					// The bytes run on past the block: InputFrames limits them
+					err = ProcessFromS16LE(conv, &data, pcm[2*pos*tt.channels:])
+				} else {
+					data.DataIn = decoded[pos*tt.channels : (pos+n)*tt.channels]
+					err = conv.Process(&data)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, out[:data.OutputFramesGen*int64(outputChannelsOf(conv))]...)
+				pos += int(data.InputFramesUsed)
+				if data.EndOfInput && data.InputFramesUsed == data.InputFrames && data.OutputFramesGen == 0 {
+					return got
+				}
+			}
+		}
+		checkSameSamples(t, tt.name, run(true), run(false))
+	}
+
+	conv, _ := New(SincFastest, 2)
+	defer conv.Close()
+	if err := ProcessFromS16LE(conv, nil, make([]byte, 8)); err == nil {
+		t.Error("nil data accepted")
+	}
+	pcm, out := make([]byte, 4*160), make([]float32, 2*400)
+	allocs := testing.AllocsPerRun(20, func() {
+		data := SrcData{DataOut: out, OutputFrames: 400, SrcRatio: 2}
+		ProcessFromS16LE(conv, &data, pcm)
+	})
+	floats := make([]float32, 2*160)
+	processAllocs := testing.AllocsPerRun(20, func() {
+		data := SrcData{DataIn: floats, InputFrames: 160, DataOut: out, OutputFrames: 400, SrcRatio: 2}
+		conv.Process(&data)
+	})
+	if allocs > processAllocs {
+		t.Errorf("%.0f allocations per call, want those of Process (%.0f)", allocs, processAllocs)
+	}
+	// Only the first call, which may pass the input through, decodes it first
+	conv.(*srcState).s16Buf = nil
+	data := SrcData{DataOut: out, OutputFrames: 400, SrcRatio: 2}
+	if err := ProcessFromS16LE(conv, &data, pcm); err != nil || data.InputFramesUsed != 160 {
+		t.Fatalf("used %d frames: %v", data.InputFramesUsed, err)
+	}
+	if conv.(*srcState).s16Buf != nil {
+		t.Error("the sinc converter decoded the input into a buffer")
+	}
+}
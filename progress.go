@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "sync/atomic"
+
+const progressChunkFrames = 1 << 16 // Input frames converted between two progress reports
+
+// ProgressFunc receives the progress of an offline conversion: inFramesDone of
+// the inFramesTotal input frames have been consumed.
+type ProgressFunc func(inFramesDone, inFramesTotal int64)
+
+// progressHolder wraps the function so atomic.Value always stores one concrete type.
+type progressHolder struct {
+	fn ProgressFunc
+}
+
+var progressFunc atomic.Value // progressHolder
+
+// SetProgressFunc installs a hook called periodically during the one-shot
+// conversions (Simple, ResampleFormat, ResampleRate, ConvertExact and their
+// variants), or removes it when fn is nil. While a hook is installed these feed
+// their input to the converter in blocks of 65536 frames and report after each
+// block, the last report having inFramesDone == inFramesTotal (unless Simple ran
+// out of output space first). The output is the same as without a hook.
+//
+// The hook is process-wide and called synchronously on the converting goroutine,
+// so it suits a CLI tool or UI running one conversion at a time. It is safe to
+// call at any time, from any goroutine.
+func SetProgressFunc(fn ProgressFunc) {
+	progressFunc.Store(progressHolder{fn: fn})
+}
+
+// currentProgressFunc returns the installed hook, or nil.
+func currentProgressFunc() ProgressFunc {
+	h, _ := progressFunc.Load().(progressHolder)
+	return h.fn
+}
+
+// processWithProgress is Process on a whole block with EndOfInput set, fed to the
+// converter in progressChunkFrames blocks with a report after each.
+func processWithProgress(c Converter, data *SrcData, channels int, progress ProgressFunc) error {
+	total := minInt64(data.InputFrames, int64(len(data.DataIn)/channels))
+	maxOut := minInt64(data.OutputFrames, int64(len(data.DataOut)/channels))
+	var used, gen int64
+	for {
+		block := SrcData{
+			DataIn:       data.DataIn[used*int64(channels):],
+			InputFrames:  minInt64(total-used, progressChunkFrames),
+			DataOut:      data.DataOut[gen*int64(channels):],
+			OutputFrames: maxOut - gen,
+			SrcRatio:     data.SrcRatio,
+			EndOfInput:   total-used <= progressChunkFrames,
+		}
+		err := c.Process(&block)
+		used += block.InputFramesUsed
+		gen += block.OutputFramesGen
+		data.InputFramesUsed, data.OutputFramesGen = used, gen
+		if err != nil {
+			return err
+		}
+		if block.InputFramesUsed > 0 {
+			progress(used, total)
+		}
+		if block.EndOfInput || (block.InputFramesUsed == 0 && block.OutputFramesGen == 0) {
+			return nil // Done, or out of output space
+		}
+	}
+}
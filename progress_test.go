@@ -0,0 +1,120 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"sync"
+	"testing"
+)
+
+// progressFrames is an input length no other test uses, so reports from
+// conversions running in parallel tests can be told apart.
+const progressFrames = 3*progressChunkFrames + 1234
+
+// recordProgress installs a hook collecting the reports for progressFrames
+// input frames and returns a function fetching them.
+func recordProgress(t *testing.T) func() []int64 {
+	t.Helper()
+	var mu sync.Mutex
+	var done []int64
+	SetProgressFunc(func(inFramesDone, inFramesTotal int64) {
+		if inFramesTotal != progressFrames {
+			return // Another test's conversion
+		}
+		mu.Lock()
+		done = append(done, inFramesDone)
+		mu.Unlock()
+	})
+	t.Cleanup(func() { SetProgressFunc(nil) })
+	return func() []int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int64(nil), done...)
+	}
+}
+
+// checkProgress checks the reports increase, one per block, up to the total.
+func checkProgress(t *testing.T, name string, done []int64) {
+	t.Helper()
+	if len(done) < 4 {
+		t.Fatalf("%s: %d progress reports, want at least 4: %v", name, len(done), done)
+	}
+	for i := 1; i < len(done); i++ {
+		if done[i] <= done[i-1] {
+			t.Fatalf("%s: progress went from %d to %d", name, done[i-1], done[i])
+		}
+	}
+	if last := done[len(done)-1]; last != progressFrames {
+		t.Errorf("%s: last report at %d frames, want %d", name, last, progressFrames)
+	}
+}
+
+func equalSamples(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestProgressSimple checks Simple reports progress and that feeding the input
+// in blocks does not change the output.
+func TestProgressSimple(t *testing.T) {
+	const channels = 2
+	in := deterministicInput(progressFrames, channels)
+	run := func() []float32 {
+		out := make([]float32, (progressFrames/2+100)*channels)
+		data := SrcData{DataIn: in, InputFrames: progressFrames, DataOut: out, OutputFrames: progressFrames/2 + 100, SrcRatio: 0.5}
+		if err := Simple(&data, SincFastest, channels); err != nil {
+			t.Fatalf("Simple failed: %v", err)
+		}
+		if data.InputFramesUsed != progressFrames {
+			t.Fatalf("Simple used %d input frames, want %d", data.InputFramesUsed, progressFrames)
+		}
+		return out[:data.OutputFramesGen*channels]
+	}
+	want := run()
+	reports := recordProgress(t)
+	got := run()
+	checkProgress(t, "Simple", reports())
+	if !equalSamples(got, want) {
+		t.Error("Simple output changed with a progress hook installed")
+	}
+}
+
+func TestProgressOneShotHelpers(t *testing.T) {
+	in := deterministicInput(progressFrames, 1)
+	helpers := []struct {
+		name string
+		run  func() ([]float32, error)
+	}{
+		{"ResampleRateWith", func() ([]float32, error) { return ResampleRateWith(in, 1, 48000, 16000, SincFastest) }},
+		{"ConvertExactWith", func() ([]float32, error) { return ConvertExactWith(in, 1, 44100, 48000, SincFastest) }},
+	}
+	for _, h := range helpers {
+		SetProgressFunc(nil)
+		want, err := h.run()
+		if err != nil {
+			t.Fatalf("%s failed: %v", h.name, err)
+		}
+		reports := recordProgress(t)
+		got, err := h.run()
+		if err != nil {
+			t.Fatalf("%s failed: %v", h.name, err)
+		}
+		checkProgress(t, h.name, reports())
+		if !equalSamples(got, want) {
+			t.Errorf("%s output changed with a progress hook installed", h.name)
+		}
+	}
+}
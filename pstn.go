@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+const pstnTargetDBFS = -18.0 // RMS level PreparePSTNAudio normalizes to
+
+// PreparePSTNAudio turns a whole mono S16LE clip, e.g. a TTS utterance or a
+// prompt, into 8kHz u-law ready for a phone call, with the chain voice bots
+// otherwise assemble from pieces: it removes the DC offset, resamples to 8kHz
+// with SincBestQuality, limits the audio to the 300-3400Hz PSTN band (see
+// NewTelephonyBandpass), scales the whole clip to an RMS level of -18dBFS (by at
+// most 24dB, silence is left alone) and encodes it, clipping at full scale.
+//
+// The band-pass runs after the resampler so that any input rate works; as the
+// resampler preserves the level of the voice band, the loudness is the same as
+// if it ran first.
+//
+// Args:
+//
+//	in: Slice of bytes containing S16LE PCM audio data (mono).
+//	inRate: The sample rate of in in Hz, e.g. 24000.
+//
+// Returns:
+//
+//	A slice of bytes containing u-law encoded audio data (8kHz),
+//	or nil and an error if conversion fails.
+func PreparePSTNAudio(in []byte, inRate int) ([]byte, error) {
+	if len(in)%mixBytesPerInputFrame != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of frame size (%d)", len(in), mixBytesPerInputFrame)
+	}
+	if inRate <= 0 {
+		return nil, fmt.Errorf("input rate must be positive, got %d", inRate)
+	}
+	srcRatio := mixOutputMuLawSampleRate / float64(inRate)
+	if isBadSrcRatio(srcRatio) {
+		return nil, mapError(ErrBadSrcRatio)
+	}
+	if len(in) == 0 {
+		return []byte{}, nil
+	}
+
+	samples := make([]float32, len(in)/mixBytesPerInputFrame)
+	if _, err := DecodePCM(FormatS16LE, in, samples); err != nil {
+		return nil, err
+	}
+	removeDC(samples)
+
+	conv, err := New(SincBestQuality, channelsUlaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize libsamplerate: %w", err)
+	}
+	defer conv.Close()
+	out, err := processAll(conv, samples, channelsUlaw, srcRatio)
+	if err != nil {
+		return nil, err
+	}
+
+	bandpass, err := NewTelephonyBandpass(mixOutputMuLawSampleRate)
+	if err != nil {
+		return nil, err
+	}
+	bandpass.Apply(out, channelsUlaw)
+	if _, err := NormalizeBlock(out, mixOutputMuLawSampleRate, channelsUlaw, LoudnessRMS, pstnTargetDBFS, 0); err != nil {
+		return nil, err
+	}
+	return appendPCMFloatToUlawBytes(make([]byte, 0, len(out)), out), nil
+}
+
+// removeDC subtracts the mean of a mono block from it.
+func removeDC(samples []float32) {
+	sum := 0.0
+	for _, v := range samples {
+		sum += float64(v)
+	}
+	mean := float32(sum / float64(len(samples)))
+	if mean == 0 || math.IsNaN(float64(mean)) {
+		return
+	}
+	for i := range samples {
+		samples[i] -= mean
+	}
+}
@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPreparePSTNAudio checks that a quiet tone with a DC offset and mains hum
+// comes out at 8kHz, -18dBFS and without either.
+func TestPreparePSTNAudio(t *testing.T) {
+	for _, rate := range []float64{8000, 16000, 24000, 44100} {
+		frames := int(rate) // One second
+		tone := genSine(frames, 1000, rate, 0.05)
+		hum := genSine(frames, 50, rate, 0.3)
+		in := make([]byte, 2*frames)
+		for i := range tone {
+			tone[i] += hum[i] + 0.2
+		}
+		EncodePCM(FormatS16LE, tone, in)
+
+		out, err := PreparePSTNAudio(in, int(rate))
+		if err != nil {
+			t.Fatalf("%.0fHz: %v", rate, err)
+		}
+		if len(out) < 7999 || len(out) > 8001 {
+			t.Errorf("%.0fHz: %d output samples, want 8000 within one", rate, len(out))
+		}
+		if level := ulawLevelDB(out, 0); math.Abs(level-pstnTargetDBFS) > 0.5 {
+			t.Errorf("%.0fHz: output level %.2fdBFS, want %.0f", rate, level, pstnTargetDBFS)
+		}
+		samples := make([]float32, len(out))
+		UlawToFloatArray(out, samples)
+		steady := samples[800:]
+		sum := 0.0
+		for _, v := range steady {
+			sum += float64(v)
+		}
+		if mean := sum / float64(len(steady)); math.Abs(mean) > 0.001 {
+			t.Errorf("%.0fHz: DC offset %.4f left", rate, mean)
+		}
+		// With the hum gone, the level is that of a sine of the tone's peak
+		if peak := findPeakGo(steady); math.Abs(20*math.Log10(peak)-pstnTargetDBFS-3.01) > 0.6 {
+			t.Errorf("%.0fHz: peak %.2fdBFS, want the tone's %.2f", rate, 20*math.Log10(peak), pstnTargetDBFS+3.01)
+		}
+	}
+}
+
+func TestPreparePSTNAudioErrors(t *testing.T) {
+	if out, err := PreparePSTNAudio(nil, 24000); err != nil || len(out) != 0 {
+		t.Errorf("empty input: %d bytes, %v", len(out), err)
+	}
+	if _, err := PreparePSTNAudio(make([]byte, 3), 24000); err == nil {
+		t.Error("odd input size: no error")
+	}
+	if _, err := PreparePSTNAudio(make([]byte, 4), 0); err == nil {
+		t.Error("zero input rate: no error")
+	}
+	silence, err := PreparePSTNAudio(make([]byte, 4800), 24000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range silence {
+		if b != 0xFF && b != 0x7F {
+			t.Fatalf("silence encoded as %#x at %d", b, i)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// RateRatio returns the conversion ratio (output frames per input frame) for
+// converting from inRate to outRate Hz, computed from the reduced fraction
+// outRate/inRate, so e.g. 24000 to 8000 gives exactly the float64 nearest to 1/3.
+// It fails if either rate is not positive or the ratio is outside the supported
+// [1/256, 256] range.
+func RateRatio(inRate, outRate int) (float64, error) {
+	if inRate <= 0 || outRate <= 0 {
+		return 0, fmt.Errorf("sample rates must be positive, got %d Hz to %d Hz", inRate, outRate)
+	}
+	g := gcd(inRate, outRate)
+	num, den := outRate/g, inRate/g
+	ratio := float64(num) / float64(den)
+	if !isValidRatio(ratio) {
+		return 0, fmt.Errorf("%d Hz to %d Hz gives ratio %d/%d: %w", inRate, outRate, num, den, mapError(ErrBadSrcRatio))
+	}
+	return ratio, nil
+}
+
+// ResampleRate converts mono audio from inRate to outRate Hz with SincBestQuality.
+// It takes rates instead of a ratio, which avoids mixing up 8/24 and 24/8.
+func ResampleRate(in []float32, inRate, outRate int) ([]float32, error) {
+	return ResampleRateWith(in, 1, inRate, outRate, SincBestQuality)
+}
+
+// ResampleRateWith is ResampleRate for interleaved audio with the given channel
+// count and converter. Equal rates return a copy of the input without resampling.
+//
+// Args:
+//
+//	in: Interleaved input samples, all of the stream (the converter is flushed).
+//	channels: Number of interleaved channels.
+//	inRate: Input sample rate in Hz.
+//	outRate: Output sample rate in Hz.
+//	converterType: Converter used when the rates differ.
+//
+// Returns:
+//
+//	The converted interleaved samples, or nil and an error.
+func ResampleRateWith(in []float32, channels, inRate, outRate int, converterType ConverterType) ([]float32, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if len(in)%channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), channels)
+	}
+	ratio, err := RateRatio(inRate, outRate)
+	if err != nil {
+		return nil, err
+	}
+	if inRate == outRate {
+		return append([]float32{}, in...), nil
+	}
+
+	state, err := New(converterType, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	defer state.Close()
+	return processAll(state, in, channels, ratio)
+}
+
+// gcd returns the greatest common divisor of two positive integers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ConvertExact converts mono audio from inRate to outRate Hz with SincBestQuality,
+// returning exactly round(len(in)*outRate/inRate) samples, so converted files keep
+// sample-accurate durations. A plain flush yields a few frames more or less
+// depending on the ratio and the filter.
+func ConvertExact(in []float32, inRate, outRate int) ([]float32, error) {
+	return ConvertExactWith(in, 1, inRate, outRate, SincBestQuality)
+}
+
+// ConvertExactWith is ConvertExact for interleaved audio with the given channel
+// count and converter. The output has exactly ExactOutputFrames frames.
+//
+// The converter is fed a few frames of silence after the input before it is
+// flushed, so the output always reaches the target length with the real filter
+// tail, and is then cut to it.
+func ConvertExactWith(in []float32, channels, inRate, outRate int, converterType ConverterType) ([]float32, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if len(in)%channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), channels)
+	}
+	ratio, err := RateRatio(inRate, outRate)
+	if err != nil {
+		return nil, err
+	}
+	target := ExactOutputFrames(int64(len(in)/channels), inRate, outRate) * int64(channels)
+	if inRate == outRate {
+		return append([]float32{}, in...), nil
+	}
+
+	conv, err := New(converterType, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	defer conv.Close()
+	stage := &streamStage{conv: conv, ratio: ratio, scratch: make([]float32, fanoutScratchFrames*channels)}
+
+	out := make([]float32, 0, target)
+	progress := currentProgressFunc()
+	block := len(in)
+	if progress != nil {
+		block = progressChunkFrames * channels // Report between blocks, see SetProgressFunc
+	}
+	for done := 0; done < len(in); done += block {
+		if err := stage.process(in[done:minInt(done+block, len(in))], channels, false); err != nil {
+			return nil, err
+		}
+		out = append(out, stage.out...)
+		if progress != nil {
+			progress(int64(minInt(done+block, len(in))/channels), int64(len(in)/channels))
+		}
+	}
+	padFrames := int(math.Ceil(2.0/ratio)) + 2 // At least two output frames of silence
+	if err := stage.process(make([]float32, padFrames*channels), channels, true); err != nil {
+		return nil, err
+	}
+	out = append(out, stage.out...)
+
+	if int64(len(out)) >= target {
+		return out[:target], nil
+	}
+	return append(out, make([]float32, target-int64(len(out)))...), nil // Not expected
+}
+
+// ExactOutputFrames returns round(inFrames*outRate/inRate), the output length
+// ConvertExact produces, computed with integers so it does not depend on the
+// float64 ratio. Halves round up.
+func ExactOutputFrames(inFrames int64, inRate, outRate int) int64 {
+	if inFrames <= 0 || inRate <= 0 || outRate <= 0 {
+		return 0
+	}
+	return (2*inFrames*int64(outRate) + int64(inRate)) / (2 * int64(inRate))
+}
+
+// rationalRatio is an exact ratio set with SetRatioRational. The fractional
+// input position is kept as an integer count of 1/num frames, advanced by den
+// for every output frame, and the process functions take the fraction of
+// their input index from it (see advanceInput), so the rounding of the float64
+// increment cannot accumulate.
+type rationalRatio struct {
+	num, den int64   // Reduced outRate/inRate; num 0 when no exact ratio is set
+	ratio    float64 // float64(num) / float64(den), the SrcRatio Process must get
+	phase    int64   // Fractional input position in 1/num frames, below num
+}
+
+// SetRatioRational sets the ratio of c to exactly outRate/inRate, e.g. (8000,
+// 24000) for 1/3. Like SetRatio, the next Process call jumps to the new ratio;
+// Process must then be called with SrcData.SrcRatio equal to RateRatio(inRate,
+// outRate). While it is, the input position is advanced with integer
+// arithmetic, so streams of any length do not drift by the fraction of a frame
+// the float64 ratio is off (about 1e-8 frames over a few hours at 48kHz). A
+// position already between two multiples of 1/num frames is rounded to the
+// nearest one.
+//
+// SetRatio, or a Process call with a different SrcRatio, returns to the float64
+// ratio; Reset keeps the exact ratio. It works on converters created with New,
+// NewWithOptions or CallbackNew.
+func SetRatioRational(c Converter, outRate, inRate int) error {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.setRatioRational(outRate, inRate)
+	case *channelGroups:
+		for _, state := range conv.groups {
+			if err := state.setRatioRational(outRate, inRate); err != nil {
+				return conv.fail(err)
+			}
+		}
+		return nil
+	case *channelMapper:
+		return SetRatioRational(conv.inner, outRate, inRate)
+	case nil:
+		return mapError(ErrBadState)
+	}
+	return fmt.Errorf("exact ratios not supported by %T", c)
+}
+
+func (state *srcState) setRatioRational(outRate, inRate int) error {
+	ratio, err := RateRatio(inRate, outRate)
+	if err != nil {
+		return err
+	}
+	if err := state.SetRatio(ratio); err != nil {
+		return err
+	}
+	g := gcd(inRate, outRate)
+	r := rationalRatio{num: int64(outRate / g), den: int64(inRate / g), ratio: ratio}
+	whole := math.Floor(state.lastPosition)
+	r.phase = int64(math.Round((state.lastPosition - whole) * float64(r.num)))
+	if r.phase == r.num {
+		r.phase, whole = 0, whole+1
+	}
+	state.lastPosition = whole + float64(r.phase)/float64(r.num)
+	state.rational = r
+	return nil
+}
+
+// checkRational drops the exact ratio before a Process call at another ratio.
+func (state *srcState) checkRational(data *SrcData) {
+	if state.rational.num != 0 && data.SrcRatio != state.rational.ratio {
+		state.rational = rationalRatio{}
+	}
+}
+
+// advanceInput returns inputIndex, the input position of a process function,
+// moved on by one output frame at srcRatio. At the exact ratio the whole frames
+// and the fraction come from the integer phase instead of 1.0 / srcRatio; a
+// step at another ratio, while the ratio ramps, drops the exact ratio.
+func (state *srcState) advanceInput(inputIndex, srcRatio float64) float64 {
+	r := &state.rational
+	if r.num == 0 {
+		return inputIndex + 1.0/srcRatio
+	}
+	if srcRatio != r.ratio {
+		*r = rationalRatio{}
+		return inputIndex + 1.0/srcRatio
+	}
+	r.phase += r.den
+	whole := r.phase / r.num
+	r.phase %= r.num
+	return math.Floor(inputIndex) + float64(whole) + float64(r.phase)/float64(r.num)
+}
@@ -0,0 +1,266 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRateRatio(t *testing.T) {
+	tests := []struct {
+		in, out int
+		want    float64
+	}{
+		{24000, 8000, 1.0 / 3.0},
+		{8000, 24000, 3.0},
+		{44100, 48000, 160.0 / 147.0},
+		{48000, 48000, 1.0},
+	}
+	for _, tt := range tests {
+		got, err := RateRatio(tt.in, tt.out)
+		if err != nil {
+			t.Fatalf("RateRatio(%d, %d) failed: %v", tt.in, tt.out, err)
+		}
+		if got != tt.want {
+			t.Errorf("RateRatio(%d, %d) = %v, want %v", tt.in, tt.out, got, tt.want)
+		}
+	}
+	for _, bad := range [][2]int{{0, 8000}, {8000, -1}, {8000, 8000 * 300}} {
+		if _, err := RateRatio(bad[0], bad[1]); err == nil {
+			t.Errorf("RateRatio(%d, %d): expected error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestResampleRate(t *testing.T) {
+	in := genSine(24000, 1000, 24000, 0.5)
+	out, err := ResampleRate(in, 24000, 8000)
+	if err != nil {
+		t.Fatalf("ResampleRate failed: %v", err)
+	}
+	if math.Abs(float64(len(out)-8000)) > 2 {
+		t.Errorf("got %d samples, want about 8000", len(out))
+	}
+	if f := zeroCrossingFreq(out[2000:6000], 1, 8000); math.Abs(f-1000) > 10 {
+		t.Errorf("tone at %.1f Hz, want 1000 Hz", f)
+	}
+
+	same, err := ResampleRateWith(in[:100], 2, 16000, 16000, Linear)
+	if err != nil || len(same) != 100 || same[51] != in[51] {
+		t.Errorf("equal rates should copy the input, got %d samples, err %v", len(same), err)
+	}
+	if _, err := ResampleRateWith(in[:99], 2, 16000, 8000, Linear); err == nil {
+		t.Error("expected error for partial frame")
+	}
+}
+
+func TestConvertExactLength(t *testing.T) {
+	rates := [][2]int{{44100, 48000}, {48000, 44100}, {24000, 8000}, {8000, 24000}, {16000, 11025}}
+	for _, ct := range []ConverterType{SincFastest, Linear, ZeroOrderHold} {
+		for _, r := range rates {
+			for _, frames := range []int{1, 999, 4410, 12347} {
+				in := genSine(frames, 440, float64(r[0]), 0.5)
+				out, err := ConvertExactWith(in, 1, r[0], r[1], ct)
+				if err != nil {
+					t.Fatalf("ConvertExactWith failed: %v", err)
+				}
+				want := int(math.Floor(float64(frames)*float64(r[1])/float64(r[0]) + 0.5))
+				if len(out) != want {
+					t.Errorf("%v %d->%d, %d frames: got %d, want %d", ct, r[0], r[1], frames, len(out), want)
+				}
+			}
+		}
+	}
+}
+
+func TestConvertExactMatchesFlush(t *testing.T) {
+	const channels = 2
+	in := make([]float32, 9000*channels)
+	copy(in, genSine(len(in), 300, 44100, 0.5))
+	got, err := ConvertExactWith(in, channels, 44100, 48000, SincMediumQuality)
+	if err != nil {
+		t.Fatalf("ConvertExactWith failed: %v", err)
+	}
+	if want := ExactOutputFrames(9000, 44100, 48000) * channels; int64(len(got)) != want {
+		t.Fatalf("got %d samples, want %d", len(got), want)
+	}
+
+	conv, _ := New(SincMediumQuality, channels)
+	defer conv.Close()
+	ratio, _ := RateRatio(44100, 48000)
+	flushed, err := processAll(conv, in, channels, ratio)
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+	n := minInt(len(got), len(flushed))
+	for i := 0; i < n; i++ {
+		if math.Abs(float64(got[i]-flushed[i])) > 1e-6 {
+			t.Fatalf("sample %d is %g, plain flush gives %g", i, got[i], flushed[i])
+		}
+	}
+}
+
+func TestExactOutputFrames(t *testing.T) {
+	cases := []struct {
+		frames          int64
+		inRate, outRate int
+		want            int64
+	}{
+		{44100, 44100, 48000, 48000},
+		{3, 2, 1, 2}, // 1.5 rounds up
+		{1, 3, 1, 0},
+		{2, 3, 1, 1},
+		{0, 8000, 16000, 0},
+		{100, 0, 16000, 0},
+	}
+	for _, c := range cases {
+		if got := ExactOutputFrames(c.frames, c.inRate, c.outRate); got != c.want {
+			t.Errorf("ExactOutputFrames(%d, %d, %d) = %d, want %d", c.frames, c.inRate, c.outRate, got, c.want)
+		}
+	}
+	if _, err := ConvertExactWith(make([]float32, 3), 2, 8000, 16000, Linear); err == nil {
+		t.Error("expected error for partial frame")
+	}
+	if _, err := ConvertExact(make([]float32, 10), 8000, 0); err == nil {
+		t.Error("expected error for zero rate")
+	}
+}
+
+// rationalPhaseError returns how far the position of c is from the exact
+// position of output frame outFrames at num/den, modulo a frame.
+func rationalPhaseError(c Converter, outFrames, num, den int64) float64 {
+	d := c.(*srcState).lastPosition - float64(outFrames*den%num)/float64(num)
+	return math.Abs(d - math.Round(d))
+}
+
+func TestSetRatioRational(t *testing.T) {
+	for _, ct := range []ConverterType{Linear, ZeroOrderHold, SincFastest} {
+		blocks := 5000 // 50 seconds
+		if ct == SincFastest {
+			blocks = 1000
+		}
+		ratio, _ := RateRatio(44100, 48000)
+		in := genSine(441, 1000, 44100, 0.5)
+		out := make([]float32, 1000)
+		var plainErr float64
+		for _, exact := range []bool{false, true} {
+			conv, err := New(ct, 1)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if exact {
+				if err := SetRatioRational(conv, 48000, 44100); err != nil {
+					t.Fatalf("SetRatioRational failed: %v", err)
+				}
+			}
+			var outFrames int64
+			for b := 0; b < blocks; b++ {
+				data := SrcData{DataIn: in, InputFrames: 441, DataOut: out, OutputFrames: 1000, SrcRatio: ratio}
+				if err := conv.Process(&data); err != nil {
+					t.Fatalf("Process failed: %v", err)
+				}
+				outFrames += data.OutputFramesGen
+			}
+			phaseErr := rationalPhaseError(conv, outFrames, 160, 147)
+			if !exact {
+				plainErr = phaseErr
+			} else if phaseErr > 1e-12 {
+				t.Errorf("%v: exact ratio is %g frames off after %d output frames (%g with the float ratio)", ct, phaseErr, outFrames, plainErr)
+			} else if phase := conv.(*srcState).rational.phase; phase != outFrames*147%160 {
+				t.Errorf("%v: phase %d after %d output frames, want %d", ct, phase, outFrames, outFrames*147%160)
+			}
+			conv.Close()
+		}
+	}
+}
+
+// TestSetRatioRationalTrim checks the exact position counts the frames
+// TrimLeadingTransient drops, so it stays where the untrimmed converter is.
+func TestSetRatioRationalTrim(t *testing.T) {
+	in := genSine(441, 1000, 44100, 0.5)
+	out := make([]float32, 1000)
+	ratio, _ := RateRatio(44100, 48000)
+	var convs [2]*srcState
+	for i, trim := range []bool{false, true} {
+		conv, err := NewWithOptions(SincFastest, 1, Options{TrimLeadingTransient: trim})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		defer conv.Close()
+		if err := SetRatioRational(conv, 48000, 44100); err != nil {
+			t.Fatalf("SetRatioRational failed: %v", err)
+		}
+		for b := 0; b < 50; b++ {
+			data := SrcData{DataIn: in, InputFrames: 441, DataOut: out, OutputFrames: 1000, SrcRatio: ratio}
+			if err := conv.Process(&data); err != nil {
+				t.Fatalf("Process failed: %v", err)
+			}
+		}
+		convs[i] = conv.(*srcState)
+	}
+	if convs[1].rational != convs[0].rational || convs[1].lastPosition != convs[0].lastPosition {
+		t.Errorf("trimmed converter at phase %d (position %g), untrimmed at %d (%g)",
+			convs[1].rational.phase, convs[1].lastPosition, convs[0].rational.phase, convs[0].lastPosition)
+	}
+}
+
+func TestSetRatioRationalEnds(t *testing.T) {
+	conv, err := New(Linear, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	state := conv.(*srcState)
+	if err := SetRatioRational(conv, 8000, 24000); err != nil {
+		t.Fatalf("SetRatioRational failed: %v", err)
+	}
+	if state.lastRatio != 1.0/3.0 || state.rational.num != 1 || state.rational.den != 3 {
+		t.Fatalf("ratio %g, exact %d/%d, want 1/3", state.lastRatio, state.rational.num, state.rational.den)
+	}
+	in := make([]float32, 2*300)
+	out := make([]float32, 2*200)
+	data := SrcData{DataIn: in, InputFrames: 300, DataOut: out, OutputFrames: 200, SrcRatio: 1.0 / 3.0}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	clone, _ := conv.Clone()
+	if clone.(*srcState).rational != state.rational {
+		t.Error("clone lost the exact ratio")
+	}
+	if err := conv.Reset(); err != nil || state.rational.num != 1 || state.rational.phase != 0 {
+		t.Errorf("Reset left exact %d/%d at phase %d, err %v", state.rational.num, state.rational.den, state.rational.phase, err)
+	}
+	data.SrcRatio = 0.5
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if state.rational.num != 0 {
+		t.Error("exact ratio kept after Process at another ratio")
+	}
+	SetRatioRational(conv, 8000, 24000)
+	conv.SetRatio(0.25)
+	if state.rational.num != 0 {
+		t.Error("exact ratio kept after SetRatio")
+	}
+
+	for _, bad := range [][2]int{{0, 8000}, {8000, -1}, {8000, 8000 * 300}} {
+		if err := SetRatioRational(conv, bad[0], bad[1]); err == nil {
+			t.Errorf("%d/%d accepted", bad[0], bad[1])
+		}
+	}
+	groups, _ := New(Linear, 2*maxChannels)
+	defer groups.Close()
+	if err := SetRatioRational(groups, 3, 2); err != nil {
+		t.Errorf("SetRatioRational on channel groups failed: %v", err)
+	}
+	if err := SetRatioRational(nil, 2, 1); err == nil {
+		t.Error("exact ratio accepted by a nil converter")
+	}
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "fmt"
+
+// RenderWithRatioCurve renders mono audio through a continuously varying ratio,
+// e.g. a Doppler shift or a turntable spinning down, with SincBestQuality.
+// See RenderWithRatioCurveWith.
+func RenderWithRatioCurve(in []float32, curve func(t float64) float64) ([]float32, error) {
+	return RenderWithRatioCurveWith(in, 1, curve, SincBestQuality)
+}
+
+// RenderWithRatioCurveWith renders the whole input through a ratio that varies
+// sample by sample. Process with a changing SrcRatio moves the ratio linearly
+// across each output block; here the curve is evaluated for every output frame
+// instead, at the input position the frame is interpolated at, and that ratio is
+// used for exactly that frame. t counts input frames from the start of the
+// input, fractional (divide by the input rate for seconds). The converter is
+// driven one output frame per call, so this is slower than Process and meant
+// for offline rendering.
+//
+// Args:
+//
+//	in: Interleaved input samples, all of the stream (the converter is flushed).
+//	channels: Number of interleaved channels.
+//	curve: Ratio at each input position; each value must be a valid ratio.
+//	converterType: Converter used for the rendering.
+//
+// Returns:
+//
+//	The rendered interleaved samples, or nil and an error.
+func RenderWithRatioCurveWith(in []float32, channels int, curve func(t float64) float64, converterType ConverterType) ([]float32, error) {
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	if len(in)%channels != 0 {
+		return nil, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), channels)
+	}
+	if curve == nil {
+		return nil, fmt.Errorf("ratio curve is nil")
+	}
+	// ForceFilter: a curve passing through 1.0 must not start as a passthrough
+	conv, err := NewWithOptions(converterType, channels, Options{ForceFilter: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	defer conv.Close()
+
+	inFrames := int64(len(in) / channels)
+	out := make([]float32, 0, len(in))
+	frame := make([]float32, channels)
+	var used int64
+	t := 0.0
+	for {
+		ratio := curve(t)
+		if !isValidRatio(ratio) {
+			return nil, fmt.Errorf("ratio curve gives %g at input frame %g: %w", ratio, t, mapError(ErrBadSrcRatio))
+		}
+		// SetRatio makes the ratio apply from this frame on, instead of being
+		// reached at the end of the block
+		if err := conv.SetRatio(ratio); err != nil {
+			return nil, err
+		}
+		data := SrcData{
+			DataIn:       in[used*int64(channels):],
+			InputFrames:  inFrames - used,
+			DataOut:      frame,
+			OutputFrames: 1,
+			SrcRatio:     ratio,
+			EndOfInput:   true, // DataIn always holds all the rest of the input
+		}
+		if err := conv.Process(&data); err != nil {
+			return nil, err
+		}
+		used += data.InputFramesUsed
+		if data.OutputFramesGen == 0 {
+			if data.InputFramesUsed == 0 {
+				return out, nil
+			}
+			continue
+		}
+		out = append(out, frame...)
+		t += 1 / ratio
+	}
+}
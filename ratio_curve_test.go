@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// Each output frame must be the input interpolated at its own position, which
+// advances by 1/curve(t) per frame.
+func TestRenderWithRatioCurveSampleAccurate(t *testing.T) {
+	const rate, freq = 44100.0, 440.0
+	in := genSine(20000, freq, rate, 0.5)
+	doppler := func(pos float64) float64 { return 1 + 0.3*math.Sin(2*math.Pi*pos/5000) }
+	out, err := RenderWithRatioCurve(in, doppler)
+	if err != nil {
+		t.Fatalf("RenderWithRatioCurve failed: %v", err)
+	}
+
+	var maxErr float64
+	pos := 0.0
+	for k, v := range out {
+		if pos > 200 && pos < float64(len(in)-200) {
+			want := 0.5 * math.Sin(2*math.Pi*freq*pos/rate)
+			maxErr = math.Max(maxErr, math.Abs(float64(v)-want))
+		}
+		pos += 1 / doppler(pos)
+		if k == len(out)-1 && math.Abs(pos-float64(len(in))) > 2 {
+			t.Errorf("output ends at input position %g, want %d", pos, len(in))
+		}
+	}
+	if maxErr > 1e-3 {
+		t.Errorf("output deviates from the input at its positions by %g", maxErr)
+	}
+}
+
+func TestRenderWithRatioCurveStep(t *testing.T) {
+	in := genSine(4000, 300, 8000, 0.5)
+	step := func(pos float64) float64 {
+		if pos < 2000 {
+			return 1
+		}
+		return 2
+	}
+	out, err := RenderWithRatioCurveWith(in, 1, step, Linear)
+	if err != nil {
+		t.Fatalf("RenderWithRatioCurveWith failed: %v", err)
+	}
+	// 2000 frames at ratio 1, then 2000 input frames at ratio 2
+	if len(out) < 5995 || len(out) > 6000 {
+		t.Errorf("got %d frames, want about 6000", len(out))
+	}
+	// Up to the step the output is that of ratio 1: the input, one frame late
+	for i := 1; i < 2000; i++ {
+		if out[i] != in[i-1] {
+			t.Fatalf("frame %d = %g, want the input %g", i, out[i], in[i-1])
+		}
+	}
+	// Then half an input frame per output frame: frame 2100 is at position 2050
+	if d := math.Abs(float64(out[2100] - in[2049])); d > 1e-6 {
+		t.Errorf("frame 2100 = %g, want the input %g", out[2100], in[2049])
+	}
+}
+
+func TestRenderWithRatioCurveConstant(t *testing.T) {
+	in := genSine(6000, 500, 16000, 0.5)
+	want := resampleWhole(t, SincFastest, in, 1, 1.7)
+	got, err := RenderWithRatioCurveWith(in, 1, func(float64) float64 { return 1.7 }, SincFastest)
+	if err != nil {
+		t.Fatalf("RenderWithRatioCurveWith failed: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d frames, want %d as with Process", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(float64(got[i]-want[i])) > 1e-5 {
+			t.Fatalf("frame %d = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRenderWithRatioCurveStereo(t *testing.T) {
+	mono := genSine(3000, 440, 8000, 0.5)
+	stereo := make([]float32, 0, 6000)
+	for _, v := range mono {
+		stereo = append(stereo, v, -v)
+	}
+	curve := func(pos float64) float64 { return 0.5 + pos/3000 }
+	out, err := RenderWithRatioCurveWith(stereo, 2, curve, SincMediumQuality)
+	if err != nil {
+		t.Fatalf("RenderWithRatioCurveWith failed: %v", err)
+	}
+	want, err := RenderWithRatioCurveWith(mono, 1, curve, SincMediumQuality)
+	if err != nil {
+		t.Fatalf("RenderWithRatioCurveWith failed: %v", err)
+	}
+	// The stereo filter may flush one frame more than the mono one
+	if d := len(out)/2 - len(want); d < 0 || d > 1 {
+		t.Fatalf("got %d frames, want %d", len(out)/2, len(want))
+	}
+	for i, v := range want {
+		if math.Abs(float64(out[2*i]-v)) > 1e-6 || math.Abs(float64(out[2*i+1]+v)) > 1e-6 {
+			t.Fatalf("frame %d = (%g, %g), want (%g, %g)", i, out[2*i], out[2*i+1], v, -v)
+		}
+	}
+}
+
+func TestRenderWithRatioCurveErrors(t *testing.T) {
+	in := make([]float32, 100)
+	if _, err := RenderWithRatioCurve(in, nil); err == nil {
+		t.Error("nil curve accepted")
+	}
+	if _, err := RenderWithRatioCurveWith(in, 3, func(float64) float64 { return 1 }, Linear); err == nil {
+		t.Error("input not a multiple of the channels accepted")
+	}
+	bad := func(pos float64) float64 {
+		if pos > 50 {
+			return 0
+		}
+		return 1
+	}
+	if _, err := RenderWithRatioCurve(in, bad); err == nil {
+		t.Error("curve giving ratio 0 accepted")
+	}
+	if out, err := RenderWithRatioCurve(nil, func(float64) float64 { return 2 }); err != nil || len(out) != 0 {
+		t.Errorf("empty input: got %d frames, err %v", len(out), err)
+	}
+}
@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestProcessDoesNotAllocate checks that, once a converter has seen a few
+// blocks, Process allocates nothing: blocks at a varying ratio, the drain after
+// EndOfInput and Reset, for every converter type, the planar and channel group
+// layouts, a channel mapper, output options and ProcessFromS16LE. Build with
+// -tags realtime to drop the debug prints too (see sinc_debug_realtime.go).
+func TestProcessDoesNotAllocate(t *testing.T) {
+	const block = 160
+	type testCase struct {
+		converterType ConverterType
+		channels      int
+		opts          Options
+		fromS16       bool
+	}
+	var cases []testCase
+	for _, ct := range []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, ZeroOrderHold, Linear} {
+		for _, channels := range []int{1, 2, 8} {
+			cases = append(cases, testCase{converterType: ct, channels: channels})
+		}
+	}
+	cases = append(cases,
+		testCase{converterType: SincFastest, channels: 2 * maxChannels},
+		testCase{converterType: SincFastest, channels: 2, opts: Options{OutputChannels: 1}},
+		testCase{converterType: SincFastest, channels: 2, opts: Options{TrimLeadingTransient: true, FadeFrames: 64, ChannelGains: []float64{1, 0.5}}},
+		testCase{converterType: SincMediumQuality, channels: 2, fromS16: true},
+	)
+	for _, tc := range cases {
+		name := fmt.Sprintf("%s/%dch/%+v/fromS16=%t", tc.converterType, tc.channels, tc.opts, tc.fromS16)
+		conv, err := NewWithOptions(tc.converterType, tc.channels, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		in := multichannelTones(block, tc.channels)
+		pcm := make([]byte, 2*len(in))
+		EncodePCM(FormatS16LE, in, pcm)
+		out := make([]float32, 4*block*tc.channels)
+
+		// One step is a block of the stream, or of the drain after every
+		// eighth; the next step after the drain resets
+		var data SrcData
+		step, draining := 0, false
+		run := func() {
+			step++
+			if draining {
+				data.DataIn, data.InputFrames = nil, 0
+			} else {
+				data = SrcData{
+					DataIn:       in,
+					InputFrames:  block,
+					DataOut:      out,
+					OutputFrames: 4 * block,
+					SrcRatio:     []float64{0.5, 0.55, 2.2, 2}[step%4],
+					EndOfInput:   step%8 == 0,
+				}
+			}
+			if tc.fromS16 {
+				data.DataIn = nil
+				err = ProcessFromS16LE(conv, &data, pcm[:2*data.InputFrames*int64(tc.channels)])
+			} else {
+				err = conv.Process(&data)
+			}
+			if err != nil {
+				panic(err)
+			}
+			switch {
+			case draining && data.OutputFramesGen == 0:
+				draining = false
+				conv.Reset()
+			case data.EndOfInput:
+				draining = true
+			}
+		}
+		for i := 0; i < 40; i++ {
+			run()
+		}
+		if allocs := testing.AllocsPerRun(200, run); allocs != 0 {
+			t.Errorf("%s: %.2f allocations per Process call", name, allocs)
+		}
+		conv.Close()
+	}
+}
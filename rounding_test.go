@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestRoundingModeRound(t *testing.T) {
+	tests := []struct {
+		x            float64
+		away, toEven int
+	}{
+		{0.5, 1, 0},
+		{1.5, 2, 2},
+		{2.5, 3, 2},
+		{-2.5, -3, -2},
+		{-3.5, -4, -4},
+		{2.4, 2, 2},
+		{-2.6, -3, -3},
+	}
+	for _, tt := range tests {
+		if got := Lrint(tt.x, RoundHalfAwayFromZero); got != tt.away {
+			t.Errorf("Lrint(%g, %v) = %d, want %d", tt.x, RoundHalfAwayFromZero, got, tt.away)
+		}
+		if got := Lrint(tt.x, RoundHalfEven); got != tt.toEven {
+			t.Errorf("Lrint(%g, %v) = %d, want %d", tt.x, RoundHalfEven, got, tt.toEven)
+		}
+	}
+	if RoundingMode(5).IsValid() {
+		t.Error("RoundingMode(5) reported valid")
+	}
+}
+
+func TestFloatToShortArrayWithRounding(t *testing.T) {
+	in := []float32{2.5 / 32768, -2.5 / 32768, 3.5 / 32768, 1.0, -1.0}
+	away := make([]int16, len(in))
+	even := make([]int16, len(in))
+	FloatToShortArrayWithRounding(in, away, RoundHalfAwayFromZero)
+	FloatToShortArrayWithRounding(in, even, RoundHalfEven)
+	wantAway := []int16{3, -3, 4, 32767, -32768}
+	wantEven := []int16{2, -2, 4, 32767, -32768}
+	for i := range in {
+		if away[i] != wantAway[i] {
+			t.Errorf("half away from zero: sample %d is %d, want %d", i, away[i], wantAway[i])
+		}
+		if even[i] != wantEven[i] {
+			t.Errorf("half even: sample %d is %d, want %d", i, even[i], wantEven[i])
+		}
+	}
+
+	// The default is unchanged
+	def := make([]int16, len(in))
+	FloatToShortArray(in, def)
+	for i := range def {
+		if def[i] != away[i] {
+			t.Errorf("FloatToShortArray sample %d is %d, want %d", i, def[i], away[i])
+		}
+	}
+}
+
+func TestFloatToIntArrayWithRounding(t *testing.T) {
+	in := []float32{2.5 / 2147483648, 1.0, -1.0}
+	out := make([]int32, len(in))
+	FloatToIntArrayWithRounding(in, out, RoundHalfEven)
+	if out[0] != 2 || out[1] != 2147483647 || out[2] != -2147483648 {
+		t.Errorf("half even: got %v", out)
+	}
+	FloatToIntArray(in, out)
+	if out[0] != 3 {
+		t.Errorf("FloatToIntArray rounded 2.5 to %d, want 3", out[0])
+	}
+}
+
+func TestEncodePCMWithRounding(t *testing.T) {
+	in := []float32{2.5 / 32768, -0.5 / 32768}
+	out := make([]byte, 4)
+	if _, err := EncodePCMWithRounding(FormatS16LE, in, out, RoundHalfEven); err != nil {
+		t.Fatalf("EncodePCMWithRounding failed: %v", err)
+	}
+	if a, b := int16(binary.LittleEndian.Uint16(out)), int16(binary.LittleEndian.Uint16(out[2:])); a != 2 || b != 0 {
+		t.Errorf("half even: got %d, %d, want 2, 0", a, b)
+	}
+	if _, err := EncodePCM(FormatS16LE, in, out); err != nil {
+		t.Fatalf("EncodePCM failed: %v", err)
+	}
+	if a, b := int16(binary.LittleEndian.Uint16(out)), int16(binary.LittleEndian.Uint16(out[2:])); a != 3 || b != -1 {
+		t.Errorf("default: got %d, %d, want 3, -1", a, b)
+	}
+}
+
+func TestIntDivCeil(t *testing.T) {
+	for _, tt := range []struct{ a, b, want int }{{0, 3, 0}, {1, 3, 1}, {3, 3, 1}, {7, 2, 4}} {
+		if got := IntDivCeil(tt.a, tt.b); got != tt.want {
+			t.Errorf("IntDivCeil(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
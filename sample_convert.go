@@ -0,0 +1,61 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// Unrolled int16 <-> float32 conversion kernels. They process four samples per
+// iteration on re-sliced windows, so the compiler drops the bounds checks, and
+// give the same results as the per-sample loops they replace.
+
+// floatToShort scales a sample by 32768, rounds half away from zero and clips it
+// to the int16 range. NaN gives 0.
+//
+// Scaling by a power of two is exact in float32, and adding 0.5 is exact in
+// float64 for any float32 below 2^24, so truncating the sum rounds exactly like
+// math.Round without its bit manipulation.
+func floatToShort(v float32) int16 {
+	s := v * 32768.0
+	switch {
+	case s >= 32767:
+		return 32767
+	case s >= 0:
+		return int16(float64(s) + 0.5)
+	case s > -32768:
+		return int16(float64(s) - 0.5)
+	case s <= -32768:
+		return -32768
+	}
+	return 0 // NaN
+}
+
+// floatToShortArrayFast is floatToShortArrayGo with RoundHalfAwayFromZero.
+func floatToShortArrayFast(in []float32, out []int16) {
+	count := minInt(len(in), len(out))
+	i := 0
+	for ; i+4 <= count; i += 4 {
+		src := in[i : i+4 : i+4]
+		dst := out[i : i+4 : i+4]
+		dst[0] = floatToShort(src[0])
+		dst[1] = floatToShort(src[1])
+		dst[2] = floatToShort(src[2])
+		dst[3] = floatToShort(src[3])
+	}
+	for ; i < count; i++ {
+		out[i] = floatToShort(in[i])
+	}
+}
+
+// shortToFloatArrayGo converts sample by sample. It is the reference for
+// ShortToFloatArray.
+func shortToFloatArrayGo(in []int16, out []float32) {
+	count := minInt(len(in), len(out))
+	scale := float32(1.0 / 32768.0) // 1.0 / 0x8000
+	for i := 0; i < count; i++ {
+		out[i] = float32(in[i]) * scale
+	}
+}
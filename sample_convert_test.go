@@ -0,0 +1,113 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// convertTestInput returns n samples mixing random values, exact halves of an
+// int16 step, values around the clip points and non-finite values.
+func convertTestInput(n int) []float32 {
+	rng := rand.New(rand.NewSource(1))
+	in := make([]float32, 0, n)
+	for _, v := range []float64{0, 0.5, -0.5, 1.5, -1.5, 2.5, -2.5, 32766.5, -32767.5, 32767, -32768, 32768, -32769, 1e10, -1e10} {
+		in = append(in, float32(v/32768))
+	}
+	in = append(in, float32(math.Inf(1)), float32(math.Inf(-1)), float32(math.NaN()),
+		math.Nextafter32(0.5/32768, 0), math.Nextafter32(-0.5/32768, 0), math.SmallestNonzeroFloat32)
+	for len(in) < n {
+		switch rng.Intn(3) {
+		case 0:
+			in = append(in, float32(rng.Intn(65536)-32768)/32768+0.5/32768) // Halves
+		case 1:
+			in = append(in, float32(rng.Float64()*2.2-1.1)) // Some beyond full scale
+		default:
+			in = append(in, float32(rng.NormFloat64()*1e-3))
+		}
+	}
+	return in[:n]
+}
+
+func TestFloatToShortArrayFast(t *testing.T) {
+	in := convertTestInput(10007) // Not a multiple of 4, to cover the tail loop
+	got := make([]int16, len(in))
+	want := make([]int16, len(in))
+	FloatToShortArray(in, got)
+	floatToShortArrayGo(in, want, RoundHalfAwayFromZero)
+	for i := range in {
+		if math.IsNaN(float64(in[i])) {
+			if got[i] != 0 {
+				t.Errorf("NaN converted to %d, want 0", got[i])
+			}
+			continue
+		}
+		if got[i] != want[i] {
+			t.Fatalf("sample %d (%g): got %d, want %d", i, in[i], got[i], want[i])
+		}
+	}
+}
+
+func TestShortToFloatArrayUnrolled(t *testing.T) {
+	in := make([]int16, 65536+3)
+	for i := range in {
+		in[i] = int16(i - 32768)
+	}
+	got := make([]float32, len(in))
+	want := make([]float32, len(in))
+	ShortToFloatArray(in, got)
+	shortToFloatArrayGo(in, want)
+	for i := range in {
+		if got[i] != want[i] {
+			t.Fatalf("sample %d: got %g, want %g", in[i], got[i], want[i])
+		}
+	}
+}
+
+// 20ms of 48kHz stereo, a typical real-time block
+const convertBenchSamples = 2 * 960
+
+func BenchmarkFloatToShortArray(b *testing.B) {
+	in := genSine(convertBenchSamples, 440, 48000, 0.9)
+	out := make([]int16, len(in))
+	b.Run("Unrolled", func(b *testing.B) {
+		b.SetBytes(int64(len(in) * 4))
+		for i := 0; i < b.N; i++ {
+			FloatToShortArray(in, out)
+		}
+	})
+	b.Run("PerSample", func(b *testing.B) {
+		b.SetBytes(int64(len(in) * 4))
+		for i := 0; i < b.N; i++ {
+			floatToShortArrayGo(in, out, RoundHalfAwayFromZero)
+		}
+	})
+}
+
+func BenchmarkShortToFloatArray(b *testing.B) {
+	in := make([]int16, convertBenchSamples)
+	for i := range in {
+		in[i] = int16(i * 31)
+	}
+	out := make([]float32, len(in))
+	b.Run("Unrolled", func(b *testing.B) {
+		b.SetBytes(int64(len(in) * 2))
+		for i := 0; i < b.N; i++ {
+			ShortToFloatArray(in, out)
+		}
+	})
+	b.Run("PerSample", func(b *testing.B) {
+		b.SetBytes(int64(len(in) * 2))
+		for i := 0; i < b.N; i++ {
+			shortToFloatArrayGo(in, out)
+		}
+	})
+}
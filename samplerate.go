@@ -9,8 +9,11 @@
 package libsamplerate
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"time"
 )
 
 // --- Public API ---
@@ -26,7 +29,14 @@ type Converter interface {
 	Process(data *SrcData) error
 	// Reset resets the internal converter state.
 	Reset() error
-	// SetRatio sets a new conversion ratio.
+	// SetRatio sets a new conversion ratio. It may also be called while the
+	// converter drains after EndOfInput: the rest of the drain then runs at the
+	// new ratio, so the input still buffered gives its frames times the new
+	// ratio of output (within one frame) before the drain ends.
+	//
+	// A converter that does not support variable ratios (see
+	// VariableRatioReporter) returns ErrNoVariableRatio for a ratio other than
+	// the one it runs at; after Reset any ratio may be set again.
 	SetRatio(newRatio float64) error
 	// GetChannels returns the number of channels the converter was configured for.
 	GetChannels() int
@@ -38,15 +48,90 @@ type Converter interface {
 	// Note: Idiomatic Go prefers functions return errors directly. This mirrors the C API.
 	LastError() error
 	// Clone creates a new converter instance with the same internal state.
+	// For callback-mode converters the buffered callback input is deep copied,
+	// the callback function is shared and the user data is cloned if it
+	// implements UserDataCloner (otherwise it is shared).
 	Clone() (Converter, error)
 }
 
-// Compile-time check to ensure srcState implements Converter
-var _ Converter = (*srcState)(nil)
+// The converters returned by this package also implement the interfaces
+// below. They are kept out of Converter so that implementations outside the
+// package still satisfy it; check for them with a type assertion, e.g.
+//
+//	if s, ok := conv.(libsamplerate.StatsReporter); ok {
+//		log.Println(s.Stats())
+//	}
+
+// VariableRatioReporter reports whether the ratio may change while the
+// converter runs, through SrcData.SrcRatio or SetRatio. Converters without
+// this support fail with ErrNoVariableRatio instead of producing wrong audio.
+type VariableRatioReporter interface {
+	SupportsVariableRatio() bool
+}
+
+// TailAdopter is implemented by converters that can take over a live stream.
+type TailAdopter interface {
+	// AdoptTail resets the converter and makes it carry on the stream of from,
+	// e.g. a new converter of another quality taking over a live stream, without
+	// the glitch of starting over: it copies only the input history its filter
+	// needs before from's read position (halfFilterChanLen samples for the sinc
+	// converters, one frame for Linear and ZeroOrderHold), the input from
+	// buffered past it, the position and the ratio. Feed it the input that
+	// would have gone to from next; from is left as it was.
+	//
+	// History from no longer holds counts as silence, as at the start of a
+	// stream. Both converters must be converters of this package in process
+	// mode with the same channel count and from must not be draining. Linear
+	// and ZeroOrderHold keep no input past the read position, so they fail with
+	// ErrBadState to take over from a sinc converter, which always buffers
+	// some; on error the converter is left as it was.
+	AdoptTail(from Converter) error
+}
+
+// StatsReporter is implemented by converters that keep Stats.
+type StatsReporter interface {
+	// Stats returns cumulative frame counts, flushes, underruns, the range of
+	// ratios used and the current internal buffer occupancy.
+	Stats() Stats
+}
+
+// Namer is implemented by converters that carry a label.
+type Namer interface {
+	// SetName labels the converter, e.g. with a call or stream ID, so it can be
+	// told apart in DumpState output. Clones keep the name.
+	SetName(name string)
+	// Name returns the label set with SetName, "" by default.
+	Name() string
+}
+
+// StateDumper is implemented by converters that can describe their state.
+type StateDumper interface {
+	// DumpState writes a snapshot of the internal state (ratio, position, filter
+	// buffer occupancy and counters) to w, one "key=value" group per line.
+	DumpState(w io.Writer) error
+}
+
+// converter is what every converter of this package implements; wrappers
+// hold their inner converters as one.
+type converter interface {
+	Converter
+	VariableRatioReporter
+	TailAdopter
+	StatsReporter
+	Namer
+	StateDumper
+}
+
+// Compile-time check to ensure srcState implements Converter and the optional
+// interfaces
+var _ converter = (*srcState)(nil)
 
 // New creates a new sample rate converter.
 // Each call returns a new independent instance. Instances are NOT goroutine-safe.
 func New(converterType ConverterType, channels int) (Converter, error) {
+	if needsChannelGroups(converterType, channels) {
+		return newChannelGroups(converterType, channels) // More channels than one sinc state supports
+	}
 	// Internal function psrcSetConverter handles the actual creation logic
 	state, errCode := psrcSetConverter(converterType, channels)
 	if errCode != ErrNoError {
@@ -69,7 +154,11 @@ func Simple(data *SrcData, converterType ConverterType, channels int) error {
 	// Mark as end of input for simple mode
 	data.EndOfInput = true
 
-	err = state.Process(data)
+	if progress := currentProgressFunc(); progress != nil {
+		err = processWithProgress(state, data, channels, progress)
+	} else {
+		err = state.Process(data)
+	}
 
 	// Ensure Close is called, although GC handles memory for pure Go objects.
 	// Using Close explicitly is good practice if resources could be held.
@@ -83,6 +172,9 @@ func CallbackNew(cbFunc CallbackFunc, converterType ConverterType, channels int,
 	if cbFunc == nil {
 		return nil, mapError(ErrBadCallback)
 	}
+	if needsChannelGroups(converterType, channels) {
+		return newCallbackChannelGroups(cbFunc, converterType, channels, userData)
+	}
 
 	state, errCode := psrcSetConverter(converterType, channels)
 	if errCode != ErrNoError {
@@ -106,7 +198,35 @@ func CallbackNew(cbFunc CallbackFunc, converterType ConverterType, channels int,
 
 // CallbackRead reads converted data when using callback mode.
 // *** VERSION WITH SAFE INPUT BUFFERING VIA COPY ***
+//
+// End of input: the callback signals the end of the stream either by returning
+// zero frames, or by returning io.EOF (any data returned alongside io.EOF is still
+// used). Once signalled, the callback is not called again until Reset, and
+// CallbackRead drains the converter, returning (0, nil) when nothing is left.
+// Any other callback error is returned wrapped, so errors.Is works on it.
+//
+// Short reads: fewer than framesToRead frames with a nil error only happen once
+// the stream has ended. An outData too small for framesToRead frames is not a
+// short read: nothing is read and the error matches ErrShortOutput with
+// errors.Is, giving the number of samples needed.
+//
+// Ratio changes: if ratio differs from the ratio the previous call ended with,
+// the ratio is ramped linearly across the framesToRead output frames of this
+// call, independently of how the callback chunks its input. This keeps
+// varispeed playback continuous when the ratio is changed on every read.
 func CallbackRead(c Converter, ratio float64, framesToRead int64, outData []float32) (framesRead int64, err error) {
+	if m, ok := c.(*channelMapper); ok {
+		return m.callbackRead(ratio, framesToRead, outData)
+	}
+	if g, ok := c.(*channelGroups); ok {
+		if framesToRead <= 0 {
+			return 0, nil
+		}
+		if isBadSrcRatio(ratio) {
+			return 0, mapError(ErrBadSrcRatio)
+		}
+		return g.callbackRead(ratio, framesToRead, outData)
+	}
 	state, ok := c.(*srcState)
 	if !ok || state == nil {
 		return 0, mapError(ErrBadState)
@@ -123,8 +243,8 @@ func CallbackRead(c Converter, ratio float64, framesToRead int64, outData []floa
 	if isBadSrcRatio(ratio) {
 		return 0, mapError(ErrBadSrcRatio)
 	}
-	if len(outData) < int(framesToRead)*state.channels {
-		return 0, fmt.Errorf("output buffer too small: need %d, got %d", int(framesToRead)*state.channels, len(outData))
+	if int64(len(outData)) < framesToRead*int64(state.channels) {
+		return 0, fmt.Errorf("%w (need %d samples, got %d)", mapError(ErrShortOutput), framesToRead*int64(state.channels), len(outData))
 	}
 
 	var srcData SrcData
@@ -133,7 +253,14 @@ func CallbackRead(c Converter, ratio float64, framesToRead int64, outData []floa
 
 	totalOutputFramesGen := int64(0)
 	currentOutPos := 0
-	eofSignalledByCallback := false // Track EOF locally
+	eofSignalledByCallback := state.callbackEOF // EOF persists across calls until Reset
+
+	// Ratio envelope: ramp from the ratio the previous call ended with to the
+	// requested one across the whole output of this call.
+	startRatio := state.lastRatio
+	if isBadSrcRatio(startRatio) {
+		startRatio = ratio
+	}
 
 	// --- Manage internal buffer for leftover input ---
 	// Ensure state.savedData is initialized (e.g., in New or Reset)
@@ -148,17 +275,23 @@ func CallbackRead(c Converter, ratio float64, framesToRead int64, outData []floa
 		if currentInputFrames == 0 && !eofSignalledByCallback {
 			// No saved data, and not EOF yet, call user callback
 			cbInputData, cbInputFrames, cbErr := state.callbackFunc(state.userCallbackData)
-			if cbErr != nil {
+			if cbErr != nil && !errors.Is(cbErr, io.EOF) {
 				state.errCode = ErrBadCallback
 				return totalOutputFramesGen, fmt.Errorf("callback error: %w", cbErr)
 			}
-			if cbInputFrames == 0 || len(cbInputData) == 0 {
-				eofSignalledByCallback = true // Mark EOF
-				currentInputData = nil        // No more data
-				currentInputFrames = 0
-			} else {
+			if cbInputFrames*int64(state.channels) > int64(len(cbInputData)) {
+				cbInputFrames = int64(len(cbInputData) / state.channels) // Never trust the count over the slice
+			}
+			if cbErr != nil || cbInputFrames <= 0 {
+				eofSignalledByCallback = true // io.EOF or an empty chunk ends the stream
+				state.callbackEOF = true
+			}
+			if cbInputFrames > 0 {
 				currentInputData = cbInputData // Use fresh data from callback
 				currentInputFrames = cbInputFrames
+			} else {
+				currentInputData = nil // No more data
+				currentInputFrames = 0
 			}
 		}
 		// Set EndOfInput for Process call
@@ -183,6 +316,9 @@ func CallbackRead(c Converter, ratio float64, framesToRead int64, outData []floa
 			break
 		} // Break if calculated output frames is 0
 
+		// Target ratio at the end of this chunk of output (see ratio envelope above)
+		srcData.SrcRatio = startRatio + (ratio-startRatio)*float64(totalOutputFramesGen+srcData.OutputFrames)/float64(framesToRead)
+
 		srcData.InputFramesUsed = 0
 		srcData.OutputFramesGen = 0
 
@@ -243,10 +379,47 @@ func CallbackRead(c Converter, ratio float64, framesToRead int64, outData []floa
 		// Stall check if needed...
 	}
 
+	if totalOutputFramesGen < framesToRead {
+		state.stats.Underruns++
+	}
 	state.errCode = ErrNoError
 	return totalOutputFramesGen, nil
 }
 
+// CallbackReadAppend is CallbackRead appending up to framesToRead output frames
+// to dst, growing it as needed, so accumulation loops need no scratch buffer:
+//
+//	for {
+//		out, n, err = CallbackReadAppend(c, ratio, 1024, out)
+//		if err != nil || n == 0 {
+//			break
+//		}
+//	}
+//
+// It returns dst extended by the frames read and their count. On error, dst is
+// returned with whatever frames were read before the error.
+func CallbackReadAppend(c Converter, ratio float64, framesToRead int64, dst []float32) ([]float32, int64, error) {
+	if c == nil {
+		return dst, 0, mapError(ErrBadState)
+	}
+	if framesToRead <= 0 {
+		return dst, 0, nil
+	}
+	channels := outputChannels(c)
+	start := len(dst)
+	dst = append(dst, make([]float32, int(framesToRead)*channels)...)
+	n, err := CallbackRead(c, ratio, framesToRead, dst[start:])
+	return dst[:start+int(n)*channels], n, err
+}
+
+// outputChannels returns the number of channels in an output frame of c.
+func outputChannels(c Converter) int {
+	if m, ok := c.(*channelMapper); ok {
+		return m.mix.out
+	}
+	return c.GetChannels()
+}
+
 // CallbackReadX reads converted data when using callback mode. Original version, with panic due to buffer corruption at state.savedData = srcData.DataIn
 func CallbackReadX(c Converter, ratio float64, framesToRead int64, outData []float32) (framesRead int64, err error) {
 	state, ok := c.(*srcState)
@@ -269,10 +442,10 @@ func CallbackReadX(c Converter, ratio float64, framesToRead int64, outData []flo
 		state.errCode = ErrBadSrcRatio
 		return 0, mapError(ErrBadSrcRatio)
 	}
-	if len(outData) < int(framesToRead)*state.channels {
+	if int64(len(outData)) < framesToRead*int64(state.channels) {
 		// Not enough space in output buffer
 		// This check wasn't explicit in C, but good practice in Go
-		return 0, fmt.Errorf("output buffer too small: need %d, got %d", int(framesToRead)*state.channels, len(outData))
+		return 0, fmt.Errorf("output buffer too small: need %d, got %d", framesToRead*int64(state.channels), len(outData))
 	}
 
 	var srcData SrcData
@@ -309,7 +482,7 @@ func CallbackReadX(c Converter, ratio float64, framesToRead int64, outData []flo
 		// Prepare output slice for this process call
 		// Ensure we don't try to write past the end of the user's buffer
 		remainingFrames := framesToRead - totalOutputFramesGen
-		if int(remainingFrames)*state.channels > len(srcData.DataOut[currentOutPos:]) {
+		if remainingFrames*int64(state.channels) > int64(len(srcData.DataOut[currentOutPos:])) {
 			remainingFrames = int64(len(srcData.DataOut[currentOutPos:]) / state.channels)
 		}
 		if remainingFrames <= 0 {
@@ -368,9 +541,53 @@ func CallbackReadX(c Converter, ratio float64, framesToRead int64, outData []flo
 
 // Process wraps the internal processing logic.
 func (state *srcState) Process(data *SrcData) error {
+	collector := currentMetricsCollector()
+	if collector == nil || state == nil || state.quiet {
+		return state.process(data)
+	}
+	start := time.Now()
+	err := state.process(data)
+	if state.outer != nil {
+		observeProcess(collector, state.outer, data, time.Since(start), err)
+	} else {
+		observeProcess(collector, state, data, time.Since(start), err)
+	}
+	return err
+}
+
+// process is Process without the metrics hook.
+func (state *srcState) process(data *SrcData) error {
 	if state == nil {
 		return mapError(ErrBadState)
 	}
+	err := state.processBlock(data)
+	// A block whose output Options.TrimLeadingTransient dropped whole generates
+	// no frames although DataOut filled up; go on, so that callers do not take
+	// it for the end of the stream
+	for err == nil && data.OutputFramesGen == 0 && state.trimLast > 0 && state.trimLast == data.OutputFrames {
+		used := data.InputFramesUsed
+		rest := *data
+		rest.DataIn = data.DataIn[minInt64(used*int64(state.channels), int64(len(data.DataIn))):]
+		rest.InputFrames -= used
+		if state.s16In != nil {
+			state.s16In = state.s16In[minInt64(2*used*int64(state.channels), int64(len(state.s16In))):]
+		}
+		err = state.processBlock(&rest)
+		data.InputFramesUsed, data.OutputFramesGen = used+rest.InputFramesUsed, rest.OutputFramesGen
+	}
+	if err != nil {
+		return err
+	}
+	state.post.run(data.DataOut, data.InputFramesUsed, data.OutputFramesGen)
+	if state.post.errorOnFull && state.mode == ModeProcess && outputFull(state, data) {
+		state.errCode = ErrOutputFull
+		return mapError(ErrOutputFull)
+	}
+	return nil
+}
+
+// processBlock runs the converter once over data.
+func (state *srcState) processBlock(data *SrcData) error {
 	if state.mode != ModeProcess && state.mode != ModeCallback { // Allow callback internals to call process
 		state.errCode = ErrBadMode
 		return mapError(ErrBadMode)
@@ -379,7 +596,7 @@ func (state *srcState) Process(data *SrcData) error {
 		state.errCode = ErrBadData
 		return mapError(ErrBadData)
 	}
-	if (data.InputFrames > 0 && len(data.DataIn) == 0) || (data.OutputFrames > 0 && len(data.DataOut) == 0) {
+	if (data.InputFrames > 0 && len(data.DataIn) == 0 && state.s16In == nil) || (data.OutputFrames > 0 && len(data.DataOut) == 0) {
 		state.errCode = ErrBadDataPtr
 		return mapError(ErrBadDataPtr)
 	}
@@ -409,15 +626,59 @@ func (state *srcState) Process(data *SrcData) error {
 	data.InputFramesUsed = 0
 	data.OutputFramesGen = 0
 
+	// A block without input and without EndOfInput (upstream has no data yet) is
+	// a no-op until the filter has run: it must neither seed the ratio below nor
+	// take the passthrough decision. Once the filter has run, the converter runs
+	// as usual and emits the output it still holds, without consuming anything.
+	if data.InputFrames == 0 && !data.EndOfInput && !state.filtered {
+		state.errCode = ErrNoError
+		state.recordProcess(data)
+		return nil
+	}
+
 	// Handle initial ratio state
 	if state.lastRatio < (1.0 / srcMaxRatio) { // Use near-zero check
 		state.lastRatio = data.SrcRatio
 	}
 
+	// The NaN policy and the optional pre-filter run on private copies of the input
+	// (S16LE input has no NaN, and the sinc filter may decode it itself)
+	callerIn := data.DataIn
+	if state.s16In != nil {
+		if filter := state.s16Filter(); filter != nil {
+			filter.s16In = state.s16In
+			defer func() { filter.s16In = nil }()
+		} else {
+			state.s16Buf = growFloats(state.s16Buf, int(data.InputFrames)*state.channels)
+			n, _ := DecodePCM(FormatS16LE, state.s16In, state.s16Buf)
+			data.DataIn = state.s16Buf[:n]
+		}
+	}
+	if state.options.NaNPolicy != NaNPass && data.InputFrames > 0 && state.s16In == nil {
+		in, errCode := sanitizeInput(state.options.NaNPolicy, data.DataIn, int(data.InputFrames)*state.channels, &state.nanBuf)
+		if errCode != ErrNoError {
+			state.errCode = errCode
+			return mapError(errCode)
+		}
+		data.DataIn = in
+	}
+	preFiltered := state.preFilter != nil && data.InputFrames > 0
+	if preFiltered {
+		data.DataIn = state.preFilterInput(data)
+	}
+
+	// The sinc process functions return state.errCode, so clear the error of an
+	// earlier rejected call (e.g. NaNError) before running them
+	state.errCode = ErrNoError
+	state.selectUpsampleFilter(data.SrcRatio)
+	state.checkRational(data)
+
 	// Choose constant or variable ratio processing function from VT
 	var errCode ErrorCode
 	if state.vt == nil {
 		errCode = ErrBadState // VT not initialized
+	} else if state.canPassThrough(data) {
+		errCode = passThrough(state, data)
 	} else if math.Abs(state.lastRatio-data.SrcRatio) < 1e-15 {
 		if state.vt.constProcess == nil {
 			errCode = ErrBadProcPtr
@@ -426,13 +687,27 @@ func (state *srcState) Process(data *SrcData) error {
 		}
 	} else {
 		if state.vt.variProcess == nil {
-			errCode = ErrBadProcPtr
+			errCode = ErrNoVariableRatio
 		} else {
 			errCode = state.vt.variProcess(state, data)
 		}
 	}
 
-	state.errCode = errCode  // Store internal code
+	if preFiltered {
+		state.preFilterKeepUnused(data)
+	}
+	data.DataIn = callerIn
+
+	state.errCode = errCode // Store internal code
+	if errCode == ErrNoError {
+		if state.options.TrimLeadingTransient {
+			state.trimLeadingTransient(data)
+		}
+		if state.options.ResetOnCorruption && data.OutputFramesGen > 0 {
+			state.recoverFromCorruption(data.DataOut[:data.OutputFramesGen*int64(state.channels)])
+		}
+		state.recordProcess(data)
+	}
 	return mapError(errCode) // Return Go error
 }
 
@@ -475,6 +750,14 @@ func (state *srcState) Reset() error {
 	state.lastRatio = 0.0
 	state.savedData = nil
 	state.savedFrames = 0
+	state.callbackEOF = false
+	state.flushing = false
+	state.filtered = false
+	state.post.reset()
+	state.trimLeft, state.trimKnown, state.trimLast = 0, false, 0
+	state.preCarryFrames = 0
+	state.clock = clockEstimator{}
+	state.rational.phase = 0
 	state.errCode = ErrNoError
 
 	return nil
@@ -510,12 +793,23 @@ func (state *srcState) SetRatio(newRatio float64) error {
 		state.errCode = ErrBadSrcRatio
 		return mapError(ErrBadSrcRatio)
 	}
-	state.lastRatio = newRatio // Update the target ratio
+	if !state.SupportsVariableRatio() && state.lastRatio >= 1.0/srcMaxRatio && newRatio != state.lastRatio {
+		state.errCode = ErrNoVariableRatio
+		return mapError(ErrNoVariableRatio)
+	}
+	state.lastRatio = newRatio       // Update the target ratio
+	state.rational = rationalRatio{} // Back to the float64 ratio, see SetRatioRational
 	// The process function will handle the change on the next call
 	state.errCode = ErrNoError
 	return nil
 }
 
+// SupportsVariableRatio reports whether the converter has a variable ratio
+// process function.
+func (state *srcState) SupportsVariableRatio() bool {
+	return state != nil && state.vt != nil && state.vt.variProcess != nil
+}
+
 // GetChannels returns the configured channel count.
 func (state *srcState) GetChannels() int {
 	if state == nil {
@@ -572,6 +866,22 @@ func (state *srcState) Clone() (Converter, error) {
 		return nil, err
 	}
 
+	// The VT copy functions shallow copy the common fields, so the buffered
+	// callback input would still alias the original's buffer. CallbackRead reuses
+	// that buffer in place, so each instance needs its own copy.
+	if len(state.savedData) > 0 {
+		newState.savedData = make([]float32, len(state.savedData))
+		copy(newState.savedData, state.savedData)
+	} else {
+		newState.savedData = nil
+	}
+	newState.preCarry = append([]float32(nil), state.preCarry...) // Scratch buffers are per instance
+	newState.preBuf, newState.preChannel, newState.nanBuf = nil, nil, nil
+	newState.post = state.post.clone() // Effects hold per-stream state; attach new ones to the clone
+	if cloner, ok := state.userCallbackData.(UserDataCloner); ok {
+		newState.userCallbackData = cloner.CloneUserData()
+	}
+
 	return newState, nil // Return the new state as the Converter interface
 }
 
@@ -638,6 +948,12 @@ func StrError(err error) string {
 		return "Internal error: Bad length in Sinc prepare_data."
 	case ErrBadInternalState:
 		return "Internal error: Inconsistent state detected."
+	case ErrNonFiniteInput:
+		return "Input contains NaN or Inf samples."
+	case ErrOutputFull:
+		return "Output buffer full before all input was consumed."
+	case ErrShortOutput:
+		return "Output buffer too small for the frames requested."
 	default:
 		// If it wasn't one of the known codes, return the original error message
 		return err.Error()
@@ -658,8 +974,17 @@ func mapError(code ErrorCode) error {
 	if msg == "" {
 		msg = "Unknown error"
 	}
-	// Optionally create custom error types here
-	return fmt.Errorf("libsamplerate error %d: %s", code, msg)
+	// The code is wrapped, so errors.Is(err, ErrShortOutput) and the like work
+	return fmt.Errorf("libsamplerate error %d: %w", code, code)
+}
+
+// Error returns the description of the code, making an ErrorCode usable as an
+// errors.Is target for the errors returned by this package.
+func (code ErrorCode) Error() string {
+	if msg := getErrorString(code); msg != "" {
+		return msg
+	}
+	return "Unknown error"
 }
 
 // getErrorString returns the base message for an ErrorCode.
@@ -712,6 +1037,12 @@ func getErrorString(code ErrorCode) string {
 		return "Internal error: Bad length in Sinc prepare_data."
 	case ErrBadInternalState:
 		return "Internal error: Inconsistent state detected."
+	case ErrNonFiniteInput:
+		return "Input contains NaN or Inf samples."
+	case ErrOutputFull:
+		return "Output buffer full before all input was consumed."
+	case ErrShortOutput:
+		return "Output buffer too small for the frames requested."
 	default:
 		return ""
 	}
@@ -768,6 +1099,10 @@ func psrcSetConverter(converterType ConverterType, channels int) (*srcState, Err
 		return nil, ErrBadConverter
 	}
 
+	if state != nil {
+		state.converterType = converterType
+		state.post = newPostChain(channels, Options{})
+	}
 	return state, errCode
 }
 
@@ -806,30 +1141,25 @@ func GetDescription(converterType ConverterType) string {
 // ShortToFloatArray converts a slice of int16 to float32.
 func ShortToFloatArray(in []int16, out []float32) {
 	count := minInt(len(in), len(out))
-	scale := float32(1.0 / 32768.0) // 1.0 / 0x8000
-	for i := 0; i < count; i++ {
+	const scale = float32(1.0 / 32768.0) // 1.0 / 0x8000
+	i := 0
+	for ; i+4 <= count; i += 4 {
+		src := in[i : i+4 : i+4]
+		dst := out[i : i+4 : i+4]
+		dst[0] = float32(src[0]) * scale
+		dst[1] = float32(src[1]) * scale
+		dst[2] = float32(src[2]) * scale
+		dst[3] = float32(src[3]) * scale
+	}
+	for ; i < count; i++ {
 		out[i] = float32(in[i]) * scale
 	}
 }
 
-// FloatToShortArray converts a slice of float32 to int16 with clipping.
+// FloatToShortArray converts a slice of float32 to int16 with clipping, rounding
+// half away from zero (see FloatToShortArrayWithRounding).
 func FloatToShortArray(in []float32, out []int16) {
-	count := minInt(len(in), len(out))
-	scale := float64(32768.0)
-	for i := 0; i < count; i++ {
-		scaledValue := float64(in[i]) * scale
-		// Use psfLrintf for rounding, consistent with C
-		rounded := psfLrintf(float32(scaledValue)) // Round first
-
-		// Clip
-		if rounded >= 32767 {
-			out[i] = 32767
-		} else if rounded <= -32768 {
-			out[i] = -32768
-		} else {
-			out[i] = int16(rounded)
-		}
-	}
+	FloatToShortArrayWithRounding(in, out, RoundHalfAwayFromZero)
 }
 
 // IntToFloatArray converts a slice of int32 to float32.
@@ -846,42 +1176,10 @@ func IntToFloatArray(in []int32, out []float32) {
 	}
 }
 
-// FloatToIntArray converts a slice of float32 to int32 with clipping.
-// (Ensure psfLrint is the updated version rounding half away from zero)
+// FloatToIntArray converts a slice of float32 to int32 with clipping, rounding
+// half away from zero (see FloatToIntArrayWithRounding).
 func FloatToIntArray(in []float32, out []int32) {
-	count := minInt(len(in), len(out))
-	scale := float64(2147483648.0)          // 2^31
-	maxInt32Float := float64(math.MaxInt32) // 2147483647.0
-	minInt32Float := float64(math.MinInt32) // -2147483648.0
-
-	for i := 0; i < count; i++ {
-		scaledValue := float64(in[i]) * scale
-
-		// Clip BEFORE rounding - this might be important if scaling pushes value
-		// slightly beyond representable range before rounding pulls it back.
-		// C might rely on float->int conversion behavior for this.
-		if scaledValue >= maxInt32Float+0.5 { // Check threshold slightly above max for rounding
-			out[i] = math.MaxInt32
-			continue
-		}
-		// For negative, check threshold slightly below min
-		if scaledValue <= minInt32Float-0.5 {
-			out[i] = math.MinInt32
-			continue
-		}
-
-		// Now round using psfLrint (rounds half away from zero)
-		rounded := psfLrint(scaledValue) // Returns int
-
-		// Final check after rounding (paranoia, maybe not needed if above clips work)
-		if rounded >= math.MaxInt32 {
-			out[i] = math.MaxInt32
-		} else if rounded <= math.MinInt32 {
-			out[i] = math.MinInt32
-		} else {
-			out[i] = int32(rounded)
-		}
-	}
+	FloatToIntArrayWithRounding(in, out, RoundHalfAwayFromZero)
 }
 
 func sincGetName(converterType ConverterType) string {
@@ -0,0 +1,165 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Scheduler shares a fixed CPU budget, a number of Process calls running at
+// once, between many streams converting in the same process, by weighted fair
+// queuing: when calls wait for a slot, the next one goes to the stream that has
+// used the least processing time for its weight. A heavy stream, say 96kHz to
+// 8kHz with SincBestQuality, then only gets the time left over by dozens of
+// cheap 8kHz to 16kHz telephony streams instead of delaying their blocks.
+//
+// The order is start-time fair queuing: each call is tagged with the virtual
+// time at which its stream may start, the later of the scheduler's virtual
+// time and the end of its stream's previous call, that end being the previous
+// start plus the measured duration over the weight. Calls start by increasing
+// tag. A running call is not preempted, so the wait a stream may see is about
+// one call of each other stream: keep the blocks of heavy streams short.
+//
+// A Scheduler is goroutine-safe; each of its streams is meant to be used from
+// one goroutine at a time, like the converter it runs.
+type Scheduler struct {
+	mu      sync.Mutex
+	free    int     // Slots not running a call
+	vtime   float64 // Start tag of the last call admitted, in seconds
+	seq     uint64  // Order of arrival, to break ties between equal tags
+	waiting schedQueue
+	clock   func() time.Time // time.Now, replaced in tests
+}
+
+// SchedulerStream is one stream's share of a Scheduler, e.g. one call leg.
+type SchedulerStream struct {
+	sched  *Scheduler
+	weight float64
+	finish float64       // Virtual time at which the stream's last call ended
+	busy   time.Duration // Processing time of all calls
+}
+
+// schedWaiter is a call waiting for a slot.
+type schedWaiter struct {
+	tag   float64
+	seq   uint64
+	ready chan struct{}
+}
+
+// schedQueue is a min-heap of waiting calls by tag, then arrival.
+type schedQueue []*schedWaiter
+
+func (q schedQueue) Len() int { return len(q) }
+func (q schedQueue) Less(i, j int) bool {
+	if q[i].tag != q[j].tag {
+		return q[i].tag < q[j].tag
+	}
+	return q[i].seq < q[j].seq
+}
+func (q schedQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *schedQueue) Push(x interface{}) { *q = append(*q, x.(*schedWaiter)) }
+func (q *schedQueue) Pop() interface{} {
+	old := *q
+	w := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return w
+}
+
+// NewScheduler creates a Scheduler that runs up to slots calls at once; 0 or
+// less uses GOMAXPROCS, the whole machine. To keep some CPU for other work,
+// give it fewer slots than cores.
+func NewScheduler(slots int) *Scheduler {
+	if slots <= 0 {
+		slots = runtime.GOMAXPROCS(0)
+	}
+	return &Scheduler{free: slots, clock: time.Now}
+}
+
+// NewStream adds a stream to the scheduler. Its weight sets its share when
+// streams compete for the slots: a stream of weight 2 gets twice the
+// processing time of a stream of weight 1. Give each stream the weight of what
+// it is worth to you, e.g. 1 each for equal tenants, rather than of its cost:
+// the cost is measured.
+func (s *Scheduler) NewStream(weight float64) (*SchedulerStream, error) {
+	if !(weight > 0) || math.IsInf(weight, 0) {
+		return nil, fmt.Errorf("stream weight must be positive, got %f", weight)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &SchedulerStream{sched: s, weight: weight, finish: s.vtime}, nil
+}
+
+// Process runs c.Process(data) when the stream's turn comes.
+func (st *SchedulerStream) Process(c Converter, data *SrcData) error {
+	if c == nil {
+		return mapError(ErrBadState)
+	}
+	return st.Run(func() error { return c.Process(data) })
+}
+
+// Run runs fn when the stream's turn comes and charges the stream with the time
+// it takes, e.g. for CallbackRead, ProcessTimed or a whole Pipeline block.
+func (st *SchedulerStream) Run(fn func() error) error {
+	s := st.sched
+	start := st.acquire()
+	began := s.clock()
+	defer func() {
+		elapsed := s.clock().Sub(began)
+		s.mu.Lock()
+		st.busy += elapsed
+		st.finish = start + elapsed.Seconds()/st.weight
+		s.release()
+		s.mu.Unlock()
+	}()
+	return fn()
+}
+
+// Busy returns the processing time the stream's calls have taken, e.g. to bill
+// or rank tenants.
+func (st *SchedulerStream) Busy() time.Duration {
+	st.sched.mu.Lock()
+	defer st.sched.mu.Unlock()
+	return st.busy
+}
+
+// acquire waits for a slot and returns the call's start tag.
+func (st *SchedulerStream) acquire() float64 {
+	s := st.sched
+	s.mu.Lock()
+	tag := maxFloat64(s.vtime, st.finish)
+	if s.free > 0 && len(s.waiting) == 0 {
+		s.free--
+		s.vtime = tag
+		s.mu.Unlock()
+		return tag
+	}
+	w := &schedWaiter{tag: tag, seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	heap.Push(&s.waiting, w)
+	s.mu.Unlock()
+	<-w.ready // release handed its slot over and advanced vtime
+	return tag
+}
+
+// release hands a finished call's slot to the waiting call with the lowest tag,
+// or frees it. s.mu must be held.
+func (s *Scheduler) release() {
+	if len(s.waiting) == 0 {
+		s.free++
+		return
+	}
+	w := heap.Pop(&s.waiting).(*schedWaiter)
+	s.vtime = maxFloat64(s.vtime, w.tag)
+	close(w.ready)
+}
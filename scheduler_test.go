@@ -0,0 +1,185 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSchedClock is a clock that only moves when a call advances it.
+type fakeSchedClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeSchedClock) read() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeSchedClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// queued returns the number of calls waiting for a slot.
+func (s *Scheduler) queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiting)
+}
+
+// runQueued holds the scheduler's only slot while the calls of streams, each
+// taking its cost, queue up in that order, then returns the order they ran in.
+func runQueued(t *testing.T, s *Scheduler, clock *fakeSchedClock, streams []*SchedulerStream, costs []time.Duration) []int {
+	hold, err := s.NewStream(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	held, unblock := make(chan struct{}), make(chan struct{})
+	go hold.Run(func() error {
+		close(held)
+		<-unblock
+		return nil
+	})
+	<-held
+
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	for i, st := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			st.Run(func() error {
+				clock.advance(costs[i])
+				mu.Lock()
+				order = append(order, i)
+				mu.Unlock()
+				return nil
+			})
+		}()
+		for s.queued() != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	close(unblock)
+	wg.Wait()
+	return order
+}
+
+// TestSchedulerFairQueuing checks that a stream which used much processing time
+// waits behind cheap streams that queued after it, and that weights scale the
+// share.
+func TestSchedulerFairQueuing(t *testing.T) {
+	clock := &fakeSchedClock{}
+	s := NewScheduler(1)
+	s.clock = clock.read
+
+	heavy, _ := s.NewStream(1)
+	for i := 0; i < 3; i++ {
+		heavy.Run(func() error {
+			clock.advance(50 * time.Millisecond)
+			return nil
+		})
+	}
+	if heavy.Busy() != 150*time.Millisecond {
+		t.Errorf("heavy stream busy for %v, want 150ms", heavy.Busy())
+	}
+	streams := []*SchedulerStream{heavy}
+	costs := []time.Duration{50 * time.Millisecond}
+	for i := 0; i < 5; i++ {
+		light, _ := s.NewStream(1)
+		streams = append(streams, light)
+		costs = append(costs, time.Millisecond)
+	}
+	order := runQueued(t, s, clock, streams, costs)
+	if want := []int{1, 2, 3, 4, 5, 0}; !reflect.DeepEqual(order, want) {
+		t.Errorf("calls ran in order %v, want %v", order, want)
+	}
+
+	// Both streams used 10ms; for weight 4 that is less of its share
+	low, _ := s.NewStream(1)
+	high, _ := s.NewStream(4)
+	for _, st := range []*SchedulerStream{low, high} {
+		st.Run(func() error {
+			clock.advance(10 * time.Millisecond)
+			return nil
+		})
+	}
+	order = runQueued(t, s, clock, []*SchedulerStream{low, high}, []time.Duration{time.Millisecond, time.Millisecond})
+	if want := []int{1, 0}; !reflect.DeepEqual(order, want) {
+		t.Errorf("calls ran in order %v, want %v", order, want)
+	}
+}
+
+// TestSchedulerProcess converts through a scheduler from many goroutines and
+// checks the output matches unscheduled conversion.
+func TestSchedulerProcess(t *testing.T) {
+	if _, err := NewScheduler(1).NewStream(0); err == nil {
+		t.Error("expected error for a zero weight")
+	}
+	s := NewScheduler(2)
+	in := genSine(4000, 440, 8000, 0.5)
+	conv, _ := New(SincFastest, 1)
+	want, err := processAll(conv, in, 1, 2)
+	conv.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	outs := make([][]float32, 8)
+	for g := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			st, err := s.NewStream(1)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conv, _ := New(SincFastest, 1)
+			defer conv.Close()
+			var got []float32
+			out := make([]float32, 400)
+			for pos := 0; ; {
+				n := minInt(160, len(in)-pos)
+				data := SrcData{DataIn: in[pos : pos+n], InputFrames: int64(n), DataOut: out, OutputFrames: 400, SrcRatio: 2, EndOfInput: pos+n == len(in)}
+				if err := st.Process(conv, &data); err != nil {
+					t.Error(err)
+					return
+				}
+				got = append(got, out[:data.OutputFramesGen]...)
+				pos += int(data.InputFramesUsed)
+				if data.EndOfInput && data.OutputFramesGen == 0 {
+					break
+				}
+			}
+			outs[g] = got
+			if st.Busy() <= 0 {
+				t.Error("stream has no processing time")
+			}
+		}()
+	}
+	wg.Wait()
+	for _, got := range outs {
+		checkSameSamples(t, "scheduled", got, want)
+	}
+	if s.free != 2 || s.queued() != 0 {
+		t.Errorf("%d slots free and %d calls queued after the streams ended", s.free, s.queued())
+	}
+}
@@ -0,0 +1,198 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const seekableChunkFrames = 1024 // Frames read from the source per callback
+
+// SeekableResampler resamples raw PCM read from an io.ReaderAt with random
+// access by time, e.g. for scrubbing in an editor.
+//
+// A seek restarts the converter a little before the target, so the filter is
+// primed with real audio (pre-roll), and drops the output up to the target. The
+// restart point is chosen on the common grid of the input and output rates, so
+// the audio returned for time t is the same as that at t in a conversion of the
+// whole source from its start. Reads continuing where the last one ended do not
+// seek.
+//
+// NOTE: A SeekableResampler is NOT goroutine-safe.
+type SeekableResampler struct {
+	src        io.ReaderAt
+	format     SampleFormat
+	channels   int
+	outRate    int
+	conv       Converter
+	ratio      float64
+	num, den   int64 // ratio = num/den in lowest terms
+	preroll    int64 // Input frames fed before the target
+	readBuf    []byte
+	decodeBuf  []float32
+	scratch    []float32
+	pos        int64 // Next input frame read from src
+	skip       int64 // Output frames still to drop after a seek
+	nextOut    int64 // Output frame following the last one returned
+	positioned bool
+}
+
+// NewSeekableResampler creates a SeekableResampler for interleaved PCM in the
+// given format, converted from inRate to outRate Hz.
+//
+// Args:
+//
+//	src: Raw PCM without header, starting at offset 0 (use io.NewSectionReader to skip one).
+//	format: Sample format of src.
+//	channels: Number of interleaved channels.
+//	inRate: Sample rate of src in Hz.
+//	outRate: Output sample rate in Hz.
+//	converterType: Converter used for the resampling.
+//
+// Returns:
+//
+//	The resampler, or nil and an error.
+func NewSeekableResampler(src io.ReaderAt, format SampleFormat, channels, inRate, outRate int, converterType ConverterType) (*SeekableResampler, error) {
+	if src == nil {
+		return nil, fmt.Errorf("source must not be nil")
+	}
+	if !format.IsValid() {
+		return nil, fmt.Errorf("unknown sample format %d", format)
+	}
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	ratio, err := RateRatio(inRate, outRate)
+	if err != nil {
+		return nil, err
+	}
+	g := gcd(inRate, outRate)
+	s := &SeekableResampler{
+		src:      src,
+		format:   format,
+		channels: channels,
+		outRate:  outRate,
+		ratio:    ratio,
+		num:      int64(outRate / g),
+		den:      int64(inRate / g),
+		preroll:  int64(math.Ceil(filterReach(converterType)/math.Min(ratio, 1.0))) + 2,
+		readBuf:  make([]byte, FramesToBytes(format, channels, seekableChunkFrames)),
+		scratch:  make([]float32, fanoutScratchFrames*channels),
+	}
+	s.conv, err = CallbackNew(seekableCallback, converterType, channels, s)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// filterReach returns how many input frames on each side of an output instant
+// the converter reads at ratios >= 1.
+func filterReach(converterType ConverterType) float64 {
+	var coeffs coeffData
+	switch converterType {
+	case SincBestQuality:
+		coeffs = highQualCoeffs
+	case SincMediumQuality:
+		coeffs = midQualCoeffs
+	case SincFastest:
+		coeffs = fastestCoeffs
+	default:
+		return 1 // Linear and ZOH only use the previous frame
+	}
+	return float64(len(coeffs.Coeffs)) / float64(coeffs.Increment)
+}
+
+// seekableCallback reads the next chunk of the source.
+func seekableCallback(userData interface{}) ([]float32, int64, error) {
+	s := userData.(*SeekableResampler)
+	frameBytes := int64(BytesPerFrame(s.format, s.channels))
+	n, err := s.src.ReadAt(s.readBuf, s.pos*frameBytes)
+	if err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	frames := int64(n) / frameBytes // A partial frame at the end is dropped
+	if frames == 0 {
+		return nil, 0, nil // End of data
+	}
+	s.decodeBuf = growFloats(s.decodeBuf, int(frames)*s.channels)
+	if _, err := DecodePCM(s.format, s.readBuf[:frames*frameBytes], s.decodeBuf); err != nil {
+		return nil, 0, err
+	}
+	s.pos += frames
+	return s.decodeBuf, frames, nil
+}
+
+// ReadAtTime writes up to len(dst)/channels interleaved output frames starting
+// at time t of the source into dst. It returns the number of frames written,
+// which is 0 once t is past the end of the source.
+func (s *SeekableResampler) ReadAtTime(dst []float32, t time.Duration) (int64, error) {
+	if t < 0 {
+		return 0, fmt.Errorf("negative time %v", t)
+	}
+	target := int64(math.Round(t.Seconds() * float64(s.outRate)))
+	if !s.positioned || target != s.nextOut {
+		if err := s.seek(target); err != nil {
+			return 0, err
+		}
+	}
+
+	frames := int64(len(dst) / s.channels)
+	var written int64
+	for written < frames {
+		out := dst[written*int64(s.channels):]
+		want := frames - written
+		if s.skip > 0 {
+			out = s.scratch
+			want = minInt64(s.skip, int64(len(s.scratch)/s.channels))
+		}
+		n, err := CallbackRead(s.conv, s.ratio, want, out)
+		if err != nil {
+			s.positioned = false // The converter state is unknown, seek on the next read
+			return written, err
+		}
+		if n == 0 {
+			break // End of data
+		}
+		if s.skip > 0 {
+			s.skip -= n
+		} else {
+			written += n
+		}
+	}
+	s.nextOut = target + written
+	return written, nil
+}
+
+// seek restarts the converter so that output frame target is produced after
+// s.skip dropped frames. Input frame m*den lies exactly on output frame m*num, so
+// the restart happens at such a frame at least s.preroll frames early.
+func (s *SeekableResampler) seek(target int64) error {
+	if err := s.conv.Reset(); err != nil {
+		return err
+	}
+	start := int64(math.Floor(float64(target)/s.ratio)) - s.preroll
+	m := int64(0)
+	if start > 0 {
+		m = start / s.den
+	}
+	s.pos = m * s.den
+	s.skip = target - m*s.num
+	s.nextOut = target
+	s.positioned = true
+	return nil
+}
+
+// Close releases the underlying converter.
+func (s *SeekableResampler) Close() error {
+	return s.conv.Close()
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSeekableResamplerMatchesFullConversion checks audio read at a time equals
+// the audio at that time in a conversion of the whole source.
+func TestSeekableResamplerMatchesFullConversion(t *testing.T) {
+	cases := []struct {
+		inRate, outRate int
+		ct              ConverterType
+	}{
+		{44100, 48000, SincFastest},
+		{48000, 8000, SincMediumQuality},
+		{8000, 16000, Linear},
+	}
+	const channels = 2
+	for _, c := range cases {
+		frames := c.inRate // One second
+		in := make([]float32, frames*channels)
+		for i, v := range genSine(frames, 440, float64(c.inRate), 0.5) {
+			in[i*channels], in[i*channels+1] = v, 0.5*v
+		}
+		pcm := make([]byte, len(in)*2)
+		EncodePCM(FormatS16LE, in, pcm)
+		DecodePCM(FormatS16LE, pcm, in) // What the resampler sees
+
+		conv, _ := New(c.ct, channels)
+		ratio, _ := RateRatio(c.inRate, c.outRate)
+		full, err := processAll(conv, in, channels, ratio)
+		conv.Close()
+		if err != nil {
+			t.Fatalf("processAll failed: %v", err)
+		}
+
+		s, err := NewSeekableResampler(bytes.NewReader(pcm), FormatS16LE, channels, c.inRate, c.outRate, c.ct)
+		if err != nil {
+			t.Fatalf("NewSeekableResampler failed: %v", err)
+		}
+		dst := make([]float32, 300*channels)
+		check := func(at time.Duration) {
+			n, err := s.ReadAtTime(dst, at)
+			if err != nil {
+				t.Fatalf("%v at %v: ReadAtTime failed: %v", c.ct, at, err)
+			}
+			k := int(math.Round(at.Seconds() * float64(c.outRate)))
+			want := full[minInt(k*channels, len(full)):]
+			if int(n) != minInt(300, len(want)/channels) {
+				t.Fatalf("%v at %v: got %d frames, want %d", c.ct, at, n, minInt(300, len(want)/channels))
+			}
+			for i := 0; i < int(n)*channels; i++ {
+				if math.Abs(float64(dst[i]-want[i])) > 1e-4 {
+					t.Fatalf("%v at %v: sample %d is %g, want %g", c.ct, at, i, dst[i], want[i])
+				}
+			}
+		}
+		for _, at := range []time.Duration{
+			500 * time.Millisecond, 0, 123457 * time.Microsecond,
+			123457*time.Microsecond + 300*time.Second/time.Duration(c.outRate), // Continues the last read
+			990 * time.Millisecond,
+		} {
+			check(at)
+		}
+		if n, err := s.ReadAtTime(dst, 2*time.Second); n != 0 || err != nil {
+			t.Errorf("%v: read past the end gave %d frames, %v", c.ct, n, err)
+		}
+		s.Close()
+	}
+}
+
+type failingReaderAt struct{}
+
+func (failingReaderAt) ReadAt([]byte, int64) (int, error) {
+	return 0, errors.New("disk on fire")
+}
+
+func TestSeekableResamplerErrors(t *testing.T) {
+	if _, err := NewSeekableResampler(bytes.NewReader(nil), SampleFormat(99), 1, 8000, 16000, Linear); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := NewSeekableResampler(bytes.NewReader(nil), FormatS16LE, 1, 8000, 0, Linear); err == nil {
+		t.Error("expected error for zero rate")
+	}
+	s, err := NewSeekableResampler(failingReaderAt{}, FormatS16LE, 1, 8000, 16000, Linear)
+	if err != nil {
+		t.Fatalf("NewSeekableResampler failed: %v", err)
+	}
+	defer s.Close()
+	if _, err := s.ReadAtTime(make([]float32, 64), time.Second); err == nil {
+		t.Error("expected read error to be returned")
+	}
+	if _, err := s.ReadAtTime(make([]float32, 64), -time.Second); err == nil {
+		t.Error("expected error for negative time")
+	}
+}
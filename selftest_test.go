@@ -0,0 +1,44 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunSelfTest(t *testing.T) {
+	if err := RunSelfTest(); err != nil {
+		t.Fatalf("RunSelfTest failed: %v", err)
+	}
+}
+
+// A damaged coefficient table must be caught.
+func TestRunSelfTestBrokenTable(t *testing.T) {
+	saved := fastestCoeffs
+	defer func() { fastestCoeffs = saved }()
+	broken := make([]float32, len(saved.Coeffs))
+	for i, c := range saved.Coeffs {
+		broken[i] = 0.8 * c
+	}
+	fastestCoeffs.Coeffs = broken
+
+	err := RunSelfTest()
+	if err == nil || !strings.Contains(err.Error(), GetName(SincFastest)) {
+		t.Errorf("scaled %s table not reported, got %v", GetName(SincFastest), err)
+	}
+}
+
+func BenchmarkRunSelfTest(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if err := RunSelfTest(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// --- Sidechain Constants ---
+const (
+	sidechainDetectMs = 10.0   // Time constant of the voice level follower
+	sidechainFloorDB  = -120.0 // Level reported for digital silence
+)
+
+// MixMode selects how the mixers combine the voice and background streams.
+type MixMode int
+
+const (
+	// MixModeAdd adds both streams scaled by the mix factor (the default, and
+	// the original behavior).
+	MixModeAdd MixMode = iota
+	// MixModeDuck lowers the background in proportion to the voice level above
+	// a threshold, like a compressor keyed by the voice (sidechain ducking),
+	// then adds both streams. It needs a Sidechain (mix.MixerConfig.Sidechain).
+	MixModeDuck
+)
+
+// String returns the name of the mode.
+func (m MixMode) String() string {
+	switch m {
+	case MixModeAdd:
+		return "add"
+	case MixModeDuck:
+		return "duck"
+	default:
+		return fmt.Sprintf("MixMode(%d)", int(m))
+	}
+}
+
+// IsValid reports whether m is one of the defined modes.
+func (m MixMode) IsValid() bool {
+	return m >= MixModeAdd && m <= MixModeDuck
+}
+
+// Sidechain is the level-dependent ducker of MixModeDuck. Unlike Ducker, which
+// switches between two gains, it reduces the background by (level - threshold) *
+// (1 - 1/ratio) dB, where level is the short-term RMS of the voice (about 10ms),
+// so a loud voice pushes the background down further than a quiet one. The
+// reduction grows with the attack time and shrinks with the release time.
+//
+// A Sidechain keeps state across calls so it can be used on a chunked stream.
+// NOTE: A Sidechain is NOT goroutine-safe; use one instance per stream.
+type Sidechain struct {
+	thresholdDBov float64
+	ratio         float64
+
+	slope       float64 // dB of reduction per dB above the threshold
+	detectCoef  float64 // Per-frame smoothing of the level follower
+	attackCoef  float64 // Per-frame smoothing while the reduction grows
+	releaseCoef float64 // Per-frame smoothing while the reduction shrinks
+
+	energy      float64 // Smoothed mean square of the voice
+	reductionDB float64 // Current reduction of the background, >= 0
+	gain        float64 // Current background gain, from reductionDB
+}
+
+// NewSidechain creates a sidechain ducker for streams at the given sample rate.
+//
+// Args:
+//
+//	sampleRate: Sample rate (Hz) of the streams (the input rate of the mixer).
+//	thresholdDBov: Voice RMS level (dBov, <= 0) above which the background is
+//	               reduced, e.g. -40.
+//	ratio: Compression ratio (>= 1). At 4, each 4 dB of voice above the threshold
+//	       lowers the background by 3 dB; math.Inf(1) lowers it dB for dB.
+//	attack: Time constant of the reduction growing (0 is immediate).
+//	release: Time constant of the reduction shrinking (0 is immediate).
+//
+// Returns:
+//
+//	A new *Sidechain, or nil and an error if the parameters are invalid.
+func NewSidechain(sampleRate, thresholdDBov, ratio float64, attack, release time.Duration) (*Sidechain, error) {
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return nil, fmt.Errorf("sampleRate must be positive, got %f", sampleRate)
+	}
+	if thresholdDBov > 0 || math.IsNaN(thresholdDBov) || math.IsInf(thresholdDBov, 0) {
+		return nil, fmt.Errorf("thresholdDBov must be <= 0, got %f", thresholdDBov)
+	}
+	if !(ratio >= 1) {
+		return nil, fmt.Errorf("ratio must be >= 1, got %f", ratio)
+	}
+	if attack < 0 || release < 0 {
+		return nil, fmt.Errorf("attack and release must not be negative, got %v and %v", attack, release)
+	}
+	s := &Sidechain{
+		thresholdDBov: thresholdDBov,
+		ratio:         ratio,
+		slope:         1.0 - 1.0/ratio, // 1 for an infinite ratio
+		detectCoef:    smoothingCoef(sampleRate, sidechainDetectMs/1000.0),
+		attackCoef:    smoothingCoef(sampleRate, attack.Seconds()),
+		releaseCoef:   smoothingCoef(sampleRate, release.Seconds()),
+	}
+	s.Reset()
+	return s, nil
+}
+
+// Reset clears the level follower and restores the full background gain.
+func (s *Sidechain) Reset() {
+	if s == nil {
+		return
+	}
+	s.energy = 0
+	s.reductionDB = 0
+	s.gain = 1.0
+}
+
+// ThresholdDBov returns the configured threshold in dBov.
+func (s *Sidechain) ThresholdDBov() float64 { return s.thresholdDBov }
+
+// Ratio returns the configured compression ratio.
+func (s *Sidechain) Ratio() float64 { return s.ratio }
+
+// Gain returns the background gain applied to the last sample (1.0 is full level).
+func (s *Sidechain) Gain() float64 { return s.gain }
+
+// ReductionDB returns the background reduction applied to the last sample in
+// dB, 0 or more.
+func (s *Sidechain) ReductionDB() float64 { return s.reductionDB }
+
+// Duck updates the level follower with one voice sample (in [-1.0, 1.0)) and
+// returns the background sample scaled by the current gain. The background may
+// be at any scale.
+func (s *Sidechain) Duck(voice, background float32) float32 {
+	v := float64(voice)
+	s.energy = s.detectCoef*s.energy + (1.0-s.detectCoef)*v*v
+
+	levelDB := sidechainFloorDB
+	if s.energy > 0 {
+		levelDB = maxFloat64(10.0*math.Log10(s.energy), sidechainFloorDB)
+	}
+	target := 0.0
+	if over := levelDB - s.thresholdDBov; over > 0 {
+		target = over * s.slope
+	}
+	coef := s.releaseCoef
+	if target > s.reductionDB {
+		coef = s.attackCoef
+	}
+	reduction := target + (s.reductionDB-target)*coef
+	if reduction != s.reductionDB {
+		s.reductionDB = reduction
+		s.gain = dbovToLinear(-reduction)
+	}
+	return float32(float64(background) * s.gain)
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSidechainRatio checks the steady-state reduction follows the voice level
+// above the threshold, divided by the ratio.
+func TestSidechainRatio(t *testing.T) {
+	const rate = 8000
+	for _, tt := range []struct {
+		amp, ratio, wantDB float64
+	}{
+		{0.5, 4, (-9.03 + 30) * 0.75}, // RMS of a 0.5 sine is -9.03 dBov
+		{0.05, 4, (-29.03 + 30) * 0.75},
+		{0.5, 2, (-9.03 + 30) * 0.5},
+		{0.5, math.Inf(1), -9.03 + 30},
+		{0.01, 4, 0}, // Below the threshold
+	} {
+		s, err := NewSidechain(rate, -30, tt.ratio, 5*time.Millisecond, 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("NewSidechain failed: %v", err)
+		}
+		for _, v := range genSine(rate/2, 440, rate, tt.amp) {
+			s.Duck(v, 1)
+		}
+		if math.Abs(s.ReductionDB()-tt.wantDB) > 0.1 {
+			t.Errorf("amp %g ratio %g: reduction %.2f dB, want %.2f", tt.amp, tt.ratio, s.ReductionDB(), tt.wantDB)
+		}
+		if got := s.Duck(0, 1); math.Abs(float64(got)-s.Gain()) > 1e-6 || math.Abs(s.Gain()-dbovToLinear(-s.ReductionDB())) > 1e-9 {
+			t.Errorf("Duck returned %g with gain %g and reduction %g dB", got, s.Gain(), s.ReductionDB())
+		}
+	}
+}
+
+func TestSidechainRelease(t *testing.T) {
+	const rate = 8000
+	s, _ := NewSidechain(rate, -30, 4, 0, 100*time.Millisecond)
+	for _, v := range genSine(rate/2, 440, rate, 0.5) {
+		s.Duck(v, 1)
+	}
+	peak := s.ReductionDB()
+	for i := 0; i < rate/20; i++ { // 50ms
+		s.Duck(0, 1)
+	}
+	if r := s.ReductionDB(); r <= 0 || r >= peak {
+		t.Errorf("reduction %g dB 50ms into the release, want between 0 and %g", r, peak)
+	}
+	for i := 0; i < rate; i++ {
+		s.Duck(0, 1)
+	}
+	if s.Gain() < 0.99 {
+		t.Errorf("gain %g after 1s of silence, want back near 1", s.Gain())
+	}
+	s.Reset()
+	if s.Gain() != 1 || s.ReductionDB() != 0 {
+		t.Errorf("Reset left gain %g, reduction %g", s.Gain(), s.ReductionDB())
+	}
+
+	for _, bad := range []struct {
+		rate, threshold, ratio float64
+	}{
+		{0, -30, 4},
+		{rate, 1, 4},
+		{rate, -30, 0.5},
+		{rate, -30, math.NaN()},
+	} {
+		if _, err := NewSidechain(bad.rate, bad.threshold, bad.ratio, 0, 0); err == nil {
+			t.Errorf("%+v: expected error", bad)
+		}
+	}
+}
+
+func TestMixModeString(t *testing.T) {
+	if MixModeDuck.String() != "duck" || MixMode(7).String() != "MixMode(7)" {
+		t.Errorf("unexpected names %q, %q", MixModeDuck, MixMode(7))
+	}
+	if MixMode(7).IsValid() {
+		t.Error("MixMode(7) reported valid")
+	}
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"time"
+)
+
+// UlawSilence is the u-law byte for a zero sample (the G.711 idle pattern).
+const UlawSilence byte = 0xFF
+
+// FramesForDuration returns the number of frames d lasts at rate Hz, rounded to
+// the nearest frame, e.g. 160 for 20ms at 8kHz. Negative durations give 0.
+//
+// Deprecated: use DurationToFrames.
+func FramesForDuration(d time.Duration, rate float64) int {
+	return int(DurationToFrames(rate, d))
+}
+
+// AppendSilence appends frames frames of silence in format with the given channel
+// count to dst and returns the extended slice. Silence is 128 for FormatU8 and
+// zero bytes for the other formats.
+func AppendSilence(dst []byte, format SampleFormat, channels, frames int) ([]byte, error) {
+	if !format.IsValid() {
+		return dst, fmt.Errorf("unknown sample format %d", format)
+	}
+	if channels <= 0 {
+		return dst, mapError(ErrBadChannelCount)
+	}
+	if frames < 0 {
+		return dst, fmt.Errorf("frames must not be negative, got %d", frames)
+	}
+	return appendBytes(dst, silenceByte(format), FramesToBytes(format, channels, frames)), nil
+}
+
+// InsertSilence returns a copy of the interleaved PCM stream with frames frames
+// of silence inserted before frame atFrame (0 prepends, the stream length in
+// frames appends).
+func InsertSilence(stream []byte, format SampleFormat, channels, atFrame, frames int) ([]byte, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("unknown sample format %d", format)
+	}
+	if channels <= 0 {
+		return nil, mapError(ErrBadChannelCount)
+	}
+	frameSize := BytesPerFrame(format, channels)
+	if len(stream)%frameSize != 0 {
+		return nil, fmt.Errorf("stream size (%d) not multiple of frame size (%d)", len(stream), frameSize)
+	}
+	return insertBytes(stream, silenceByte(format), frameSize, atFrame, frames)
+}
+
+// AppendUlawSilence appends frames u-law samples of silence to dst.
+func AppendUlawSilence(dst []byte, frames int) []byte {
+	if frames <= 0 {
+		return dst
+	}
+	return appendBytes(dst, UlawSilence, frames)
+}
+
+// InsertUlawSilence returns a copy of the mono u-law stream with frames samples
+// of silence inserted before sample atFrame.
+func InsertUlawSilence(stream []byte, atFrame, frames int) ([]byte, error) {
+	return insertBytes(stream, UlawSilence, 1, atFrame, frames)
+}
+
+// silenceByte returns the byte every sample byte of silence has in format.
+func silenceByte(format SampleFormat) byte {
+	if format == FormatU8 {
+		return 128
+	}
+	return 0
+}
+
+// appendBytes appends n copies of b to dst.
+func appendBytes(dst []byte, b byte, n int) []byte {
+	start := len(dst)
+	dst = append(dst, make([]byte, n)...)
+	if b != 0 {
+		for i := start; i < len(dst); i++ {
+			dst[i] = b
+		}
+	}
+	return dst
+}
+
+// insertBytes returns a copy of stream with frames frames of fill inserted before
+// frame atFrame.
+func insertBytes(stream []byte, fill byte, frameSize, atFrame, frames int) ([]byte, error) {
+	if atFrame < 0 || atFrame*frameSize > len(stream) {
+		return nil, fmt.Errorf("insert position %d out of range [0, %d]", atFrame, len(stream)/frameSize)
+	}
+	if frames < 0 {
+		return nil, fmt.Errorf("frames must not be negative, got %d", frames)
+	}
+	at := atFrame * frameSize
+	out := make([]byte, 0, len(stream)+frames*frameSize)
+	out = append(out, stream[:at]...)
+	out = appendBytes(out, fill, frames*frameSize)
+	return append(out, stream[at:]...), nil
+}
@@ -0,0 +1,110 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFramesForDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		rate float64
+		want int
+	}{
+		{20 * time.Millisecond, 8000, 160},
+		{time.Second, 44100, 44100},
+		{10 * time.Millisecond, 44100, 441},
+		{time.Millisecond / 3, 48000, 16},
+		{-time.Second, 8000, 0},
+		{time.Second, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := FramesForDuration(tt.d, tt.rate); got != tt.want {
+			t.Errorf("FramesForDuration(%v, %g) = %d, want %d", tt.d, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestAppendSilence(t *testing.T) {
+	out, err := AppendSilence([]byte{1, 2}, FormatS16LE, 2, FramesForDuration(time.Millisecond, 8000))
+	if err != nil {
+		t.Fatalf("AppendSilence failed: %v", err)
+	}
+	if want := append([]byte{1, 2}, make([]byte, 8*2*2)...); !bytes.Equal(out, want) {
+		t.Errorf("got %v, want %v", out, want)
+	}
+	out, _ = AppendSilence(nil, FormatU8, 1, 3)
+	if !bytes.Equal(out, []byte{128, 128, 128}) {
+		t.Errorf("U8 silence is %v, want 128s", out)
+	}
+	decoded := make([]float32, 3)
+	DecodePCM(FormatU8, out, decoded)
+	for _, v := range decoded {
+		if v != 0 {
+			t.Errorf("U8 silence decodes to %v", decoded)
+		}
+	}
+	for _, bad := range []struct {
+		format           SampleFormat
+		channels, frames int
+	}{{SampleFormat(99), 1, 1}, {FormatS16LE, 0, 1}, {FormatS16LE, 1, -1}} {
+		if _, err := AppendSilence(nil, bad.format, bad.channels, bad.frames); err == nil {
+			t.Errorf("%+v: expected error", bad)
+		}
+	}
+}
+
+func TestInsertSilence(t *testing.T) {
+	stream := []byte{1, 1, 2, 2, 3, 3, 4, 4} // Two S16LE stereo frames
+	out, err := InsertSilence(stream, FormatS16LE, 2, 1, 2)
+	if err != nil {
+		t.Fatalf("InsertSilence failed: %v", err)
+	}
+	want := []byte{1, 1, 2, 2, 0, 0, 0, 0, 0, 0, 0, 0, 3, 3, 4, 4}
+	if !bytes.Equal(out, want) {
+		t.Errorf("got %v, want %v", out, want)
+	}
+	if stream[4] != 3 {
+		t.Error("input stream was modified")
+	}
+	if out, _ := InsertSilence(stream, FormatS16LE, 2, 2, 1); !bytes.Equal(out[8:], make([]byte, 4)) {
+		t.Errorf("append at the end gave %v", out)
+	}
+	for _, at := range []int{-1, 3} {
+		if _, err := InsertSilence(stream, FormatS16LE, 2, at, 1); err == nil {
+			t.Errorf("position %d: expected error", at)
+		}
+	}
+	if _, err := InsertSilence(stream[:3], FormatS16LE, 2, 0, 1); err == nil {
+		t.Error("expected error for a partial frame")
+	}
+}
+
+func TestUlawSilence(t *testing.T) {
+	out := AppendUlawSilence([]byte{0x12}, 3)
+	if !bytes.Equal(out, []byte{0x12, UlawSilence, UlawSilence, UlawSilence}) {
+		t.Errorf("got %v", out)
+	}
+	decoded := make([]float32, 1)
+	UlawToFloatArray([]byte{UlawSilence}, decoded)
+	if decoded[0] != 0 || ulawToLinearGo(UlawSilence) != 0 {
+		t.Errorf("UlawSilence decodes to %g", decoded[0])
+	}
+
+	out, err := InsertUlawSilence([]byte{1, 2, 3}, 0, 2)
+	if err != nil || !bytes.Equal(out, []byte{UlawSilence, UlawSilence, 1, 2, 3}) {
+		t.Errorf("got %v, %v", out, err)
+	}
+	if _, err := InsertUlawSilence([]byte{1}, 2, 1); err == nil {
+		t.Error("expected error for a position past the end")
+	}
+}
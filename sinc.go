@@ -11,7 +11,6 @@ package libsamplerate
 import (
 	"fmt"
 	"math"
-	"os"
 )
 
 // --- Sinc Specific Types ---
@@ -37,16 +36,64 @@ type sincFilter struct {
 
 	coeffs []float32 // Coefficient table (slice referencing global data)
 
-	bCurrent int // Current read position in buffer (index)
-	bEnd     int // Current write position (end of valid data) in buffer (index)
-	bRealEnd int // Marker for real end of data when input ends (index, or -1)
-	bLen     int // Total allocated length of the buffer slice
+	// Tables for Options.UpsampleRolloff: the converter's own and the strict one
+	// used while upsampling; both nil without the option
+	baseCoeffs, strictCoeffs *coeffData
+
+	bCurrent int64 // Current read position in buffer (index)
+	bEnd     int64 // Current write position (end of valid data) in buffer (index)
+	bRealEnd int64 // Marker for real end of data when input ends (index, or -1)
+	bLen     int64 // Total allocated length of the buffer slice
 
 	// Pre-allocated temporary calculation buffers (avoids allocation during processing)
 	leftCalc  [maxChannels]float64 // Matched C array size
 	rightCalc [maxChannels]float64
 
 	buffer []float32 // Main internal processing buffer (ring buffer)
+
+	// Channel-major mirror of buffer for calcOutputPlanar, valid for the
+	// samples before planarValid; whatever rewrites them resets it to 0
+	planar       []float32
+	planarValid  int64
+	planarCoeffs []float64 // Scratch for the interpolated coefficients
+
+	halfLen halfLenCache // Last result of halfChanLen
+
+	// S16LE input of ProcessFromS16LE, decoded by prepareData straight into
+	// buffer in place of DataIn; nil otherwise
+	s16In []byte
+}
+
+// halfLenCache is a halfChanLen result with the minimum ratio and the
+// coefficient table it was computed for.
+type halfLenCache struct {
+	minRatio     float64
+	coeffHalfLen int
+	indexInc     int
+	samples      int64
+}
+
+// halfChanLen returns the samples of history the filter needs on each side of
+// the read position, for channels channels at ratios down to minRatio. It is
+// cached for the last minRatio and coefficient table, as a stream at a fixed
+// ratio asks for the same value on every Process call.
+func (filter *sincFilter) halfChanLen(channels int, minRatio float64) int64 {
+	minRatio = maxFloat64(minRatio, 1.0/srcMaxRatio)
+	if filter.halfLen.samples > 0 && filter.halfLen.minRatio == minRatio &&
+		filter.halfLen.coeffHalfLen == filter.coeffHalfLen && filter.halfLen.indexInc == filter.indexInc {
+		return filter.halfLen.samples
+	}
+	count := float64(filter.coeffHalfLen+2) / float64(filter.indexInc)
+	if minRatio < 1.0 {
+		count /= minRatio
+	}
+	filter.halfLen = halfLenCache{
+		minRatio:     minRatio,
+		coeffHalfLen: filter.coeffHalfLen,
+		indexInc:     filter.indexInc,
+		samples:      int64(channels) * int64(psfLrint(count)+1),
+	}
+	return filter.halfLen.samples
 }
 
 // Fixed-point math constants and types specific to Sinc
@@ -56,9 +103,6 @@ const (
 	invFpOne  = 1.0 / float64(fpOne)
 )
 
-// Check environment variable ONCE at package initialization
-var sincDebugEnabled = (os.Getenv("SINC_DEBUG") == "1")
-
 // incrementT is the fixed-point type used for filter calculations
 type incrementT = int32 // Match C typedef int32_t increment_t
 
@@ -97,7 +141,7 @@ func fpToDouble(x incrementT) float64 {
 
 // --- Integer Division Ceiling ---
 // Calculates ceil(a / b) for positive integers
-func intDivCeil(a, b int) int {
+func intDivCeil(a, b int64) int64 {
 	if a < 0 || b <= 0 {
 		// Match C assert behavior - should not happen in this context
 		panic(fmt.Sprintf("intDivCeil precondition violation: a=%d, b=%d", a, b))
@@ -158,13 +202,10 @@ func newSincFilterInternal(converterType ConverterType, channels int) (*sincFilt
 	}
 
 	// Calculate Buffer Length (bLen)
-	calcLen := 3 * psfLrint((float64(priv.coeffHalfLen)+2.0)/float64(priv.indexInc)*srcMaxRatio+1.0)
-	priv.bLen = maxInt(calcLen, 4096)
-	priv.bLen *= channels
-	priv.bLen += 1 // For C's <= check against samples_in_hand
+	priv.bLen = sincBufferLen(priv.coeffHalfLen, priv.indexInc, channels)
 
 	// Allocate Buffer
-	bufferSize := priv.bLen + channels // C allocates extra for sanity check area
+	bufferSize := priv.bLen + int64(channels) // C allocates extra for sanity check area
 	if bufferSize <= 0 {
 		return nil, fmt.Errorf("calculated negative or zero buffer size: %d", bufferSize)
 	}
@@ -176,6 +217,15 @@ func newSincFilterInternal(converterType ConverterType, channels int) (*sincFilt
 	return priv, nil
 }
 
+// sincBufferLen returns the bLen a filter table needs: room for its span at the
+// largest downsampling ratio.
+func sincBufferLen(coeffHalfLen, indexInc, channels int) int64 {
+	calcLen := 3 * psfLrint((float64(coeffHalfLen)+2.0)/float64(indexInc)*srcMaxRatio+1.0)
+	bLen := int64(maxInt(calcLen, 4096))
+	bLen *= int64(channels)
+	return bLen + 1 // For C's <= check against samples_in_hand
+}
+
 // newSincState creates the main srcState for a Sinc converter.
 func newSincState(converterType ConverterType, channels int) (*srcState, ErrorCode) {
 	// Basic validation
@@ -213,6 +263,9 @@ func newSincState(converterType ConverterType, channels int) (*srcState, ErrorCo
 		state.vt = &sincHexStateVT
 	default:
 		state.vt = &sincMultichanStateVT
+		if channels >= sincPlanarMinChannels {
+			state.vt = &sincPlanarStateVT
+		}
 	}
 
 	resetErr := state.Reset() // Calls sincReset via VT method
@@ -243,22 +296,19 @@ func sincReset(state *srcState) {
 	filter.bCurrent = 0
 	filter.bEnd = 0
 	filter.bRealEnd = -1
+	filter.planarValid = 0
 
 	// Don't reset state.lastRatio/lastPosition here, C src_reset handles common fields
 
-	// Zero out the main part of the buffer
-	if filter.bLen > 0 && len(filter.buffer) > 0 {
-		zeroLen := minInt(filter.bLen, len(filter.buffer)) // Ensure bounds
-		bufferToZero := filter.buffer[:zeroLen]
-		for i := range bufferToZero {
-			bufferToZero[i] = 0.0
-		}
-	}
+	// The buffer is not zeroed: the samples before bEnd are always written
+	// since the reset (prepareData zeroes the lookback of the first fill), and
+	// nothing after it is read. Zeroing it all made a Reset per utterance cost
+	// as much as converting hundreds of KB.
 
 	// Set the sanity check area after the main buffer data
 	sanityCheckValue := float32(170.0) // 0xAA
 	start := filter.bLen
-	count := state.channels
+	count := int64(state.channels)
 	end := start + count
 
 	for i := range filter.leftCalc {
@@ -267,8 +317,8 @@ func sincReset(state *srcState) {
 	}
 
 	if len(filter.buffer) > 0 && count > 0 && start >= 0 {
-		if end > len(filter.buffer) {
-			end = len(filter.buffer) // Clip to actual buffer size
+		if end > int64(len(filter.buffer)) {
+			end = int64(len(filter.buffer)) // Clip to actual buffer size
 		}
 		if end > start {
 			sanitySlice := filter.buffer[start:end]
@@ -300,12 +350,13 @@ func sincResetX(state *srcState) {
 	filter.bCurrent = 0
 	filter.bEnd = 0
 	filter.bRealEnd = -1
+	filter.planarValid = 0
 
 	// Don't reset state.lastRatio/lastPosition here, C src_reset handles common fields
 
 	// Zero out the main part of the buffer
 	if filter.bLen > 0 && len(filter.buffer) > 0 {
-		zeroLen := minInt(filter.bLen, len(filter.buffer)) // Ensure bounds
+		zeroLen := minInt64(filter.bLen, int64(len(filter.buffer))) // Ensure bounds
 		bufferToZero := filter.buffer[:zeroLen]
 		for i := range bufferToZero {
 			bufferToZero[i] = 0.0
@@ -315,12 +366,12 @@ func sincResetX(state *srcState) {
 	// Set the sanity check area after the main buffer data
 	sanityCheckValue := float32(170.0) // 0xAA
 	start := filter.bLen
-	count := state.channels
+	count := int64(state.channels)
 	end := start + count
 
 	if len(filter.buffer) > 0 && count > 0 && start >= 0 {
-		if end > len(filter.buffer) {
-			end = len(filter.buffer) // Clip to actual buffer size
+		if end > int64(len(filter.buffer)) {
+			end = int64(len(filter.buffer)) // Clip to actual buffer size
 		}
 		if end > start {
 			sanitySlice := filter.buffer[start:end]
@@ -385,6 +436,7 @@ func sincCopy(state *srcState) *srcState {
 	} else {
 		newFilter.buffer = nil // Ensure it's nil if original was
 	}
+	newFilter.planar, newFilter.planarCoeffs, newFilter.planarValid = nil, nil, 0 // Rebuilt on use
 
 	// 4. Coeffs slice can be shared (points to global data)
 	// newFilter.coeffs = origFilter.coeffs // Already copied by struct copy
@@ -442,9 +494,35 @@ var sincMultichanStateVT = srcStateVT{
 	close:        sincClose,
 }
 
+// samplesInHand returns the samples loaded after the read position.
+func (filter *sincFilter) samplesInHand() int64 {
+	if filter.bEnd >= filter.bCurrent {
+		return filter.bEnd - filter.bCurrent
+	}
+	return filter.bEnd + filter.bLen - filter.bCurrent
+}
+
+// refillBuffer loads input for the next output frames of a vari process loop
+// with prepareData, and returns the samples in hand afterwards.
+//
+// data.InputFramesUsed is the one count of the input a Process call consumed:
+// the loop sets it to 0, and each refill advances it by the whole frames
+// prepareData copied, however many refills the call takes. It never goes back
+// or past data.InputFrames, so callers can advance their input by it.
+func refillBuffer(filter *sincFilter, channels int, data *SrcData, halfFilterChanLen int64) (int64, ErrorCode) {
+	used := data.InputFramesUsed
+	if errCode := prepareData(filter, channels, data, halfFilterChanLen); errCode != ErrNoError {
+		return 0, errCode
+	}
+	if data.InputFramesUsed < used || data.InputFramesUsed > maxInt64(data.InputFrames, 0) {
+		return 0, ErrBadInternalState
+	}
+	return filter.samplesInHand(), ErrNoError
+}
+
 // prepareData manages the internal buffer, loading new data as needed.
 // Corresponds to prepare_data in src_sinc.c
-func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChanLen int) ErrorCode {
+func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChanLen int64) ErrorCode {
 	if sincDebugEnabled {
 		fmt.Printf("[SINC_DEBUG] prepareData: ENTRY - bCurrent=%d, bEnd=%d, bLen=%d, bRealEnd=%d, halfFCLen=%d, data.InFrames=%d, data.InUsed=%d, data.EOF=%t\n",
 			filter.bCurrent, filter.bEnd, filter.bLen, filter.bRealEnd, halfFilterChanLen, data.InputFrames, data.InputFramesUsed, data.EndOfInput)
@@ -461,12 +539,14 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: bRealEnd (%d) >= 0, returning early.\n", filter.bRealEnd)
 		}
-		return ErrNoError // Already marked end-of-input, don't load more.
+		// Already marked end-of-input, don't load more; only the padding may
+		// need to grow if the ratio dropped since
+		return extendEndPadding(filter, halfFilterChanLen)
 	}
 
 	// C: if (data->data_in == NULL) return SRC_ERR_NO_ERROR;
 	// In Go, check slice length. If InputFrames > 0, DataIn must be valid.
-	if data.InputFrames > 0 && len(data.DataIn) == 0 {
+	if data.InputFrames > 0 && len(data.DataIn) == 0 && filter.s16In == nil {
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: ERROR: data.InputFrames > 0 but len(data.DataIn) == 0\n")
 		}
@@ -483,9 +563,9 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 		fmt.Printf("[SINC_DEBUG] prepareData: Passed initial checks.\n")
 	}
 
-	currentDataOffset := int(inUsedSamples) // Start offset in data.DataIn slice
+	currentDataOffset := inUsedSamples // Start offset in data.DataIn slice
 
-	var requiredLen int // How many samples we need to load
+	var requiredLen int64 // How many samples we need to load
 
 	// Buffer management logic:
 	if filter.bCurrent == 0 { // C checks b_current == 0 for initial fill
@@ -501,9 +581,18 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 
 		filter.bCurrent = halfFilterChanLen
 		filter.bEnd = halfFilterChanLen
-		// Buffer from 0 to halfFilterChanLen-1 is implicitly zero (from make or reset)
+		// The lookback before the first sample is silence; sincReset leaves
+		// the buffer as it was, so zero it here
+		if halfFilterChanLen > int64(len(filter.buffer)) {
+			return ErrBadInternalState
+		}
+		lookback := filter.buffer[:halfFilterChanLen]
+		for i := range lookback {
+			lookback[i] = 0.0
+		}
+		filter.planarValid = 0
 
-	} else if filter.bEnd+halfFilterChanLen+channels < filter.bLen {
+	} else if filter.bEnd+halfFilterChanLen+int64(channels) < filter.bLen {
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: Enough space at buffer end case.\n")
 		}
@@ -534,7 +623,7 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 			}
 			return ErrBadInternalState
 		}
-		if srcStart+copyLen > len(filter.buffer) {
+		if srcStart+copyLen > int64(len(filter.buffer)) {
 			if sincDebugEnabled {
 				fmt.Printf("[SINC_DEBUG] prepareData: ERROR: Buffer wrap src bounds error: srcStart=%d, copyLen=%d, bufLen=%d\n", srcStart, copyLen, len(filter.buffer))
 			}
@@ -542,7 +631,7 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 		}
 
 		// Check destination bounds (copying to start of buffer)
-		if copyLen > len(filter.buffer) {
+		if copyLen > int64(len(filter.buffer)) {
 			if sincDebugEnabled {
 				fmt.Printf("[SINC_DEBUG] prepareData: ERROR: Buffer wrap dest bounds error: copyLen=%d, bufLen=%d\n", copyLen, len(filter.buffer))
 			}
@@ -551,6 +640,7 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 
 		// Perform the copy using Go's copy function (handles overlap)
 		copy(filter.buffer[0:copyLen], filter.buffer[srcStart:srcStart+copyLen])
+		filter.planarValid = 0
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: Wrapped %d samples from %d to 0.\n", copyLen, srcStart)
 		}
@@ -570,13 +660,13 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 		framesAvailable = 0
 	}
 
-	copyCount := minInt(int(framesAvailable), requiredLen) // Samples to copy
+	copyCount := minInt64(framesAvailable, requiredLen) // Samples to copy
 	if sincDebugEnabled {
 		fmt.Printf("[SINC_DEBUG] prepareData: requiredLen=%d, framesAvailable=%d, decided copyCount=%d (before mod channels).\n", requiredLen, framesAvailable, copyCount)
 	}
 
 	// C: len -= (len % channels) ; // Ensure whole frames
-	copyCount -= copyCount % channels
+	copyCount -= copyCount % int64(channels)
 	if sincDebugEnabled {
 		fmt.Printf("[SINC_DEBUG] prepareData: final copyCount=%d (after mod channels).\n", copyCount)
 	}
@@ -594,7 +684,7 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 		}
 		return ErrSincPrepareDataBadLen
 	}
-	if filter.bEnd+copyCount > len(filter.buffer) {
+	if filter.bEnd+copyCount > int64(len(filter.buffer)) {
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: ERROR: copy exceeds allocLen: bEnd=%d, copyCount=%d, allocLen=%d\n", filter.bEnd, copyCount, len(filter.buffer))
 		}
@@ -605,36 +695,39 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 	if copyCount > 0 {
 		// C: memcpy (filter->buffer + filter->b_end, data->data_in + filter->in_used, len * sizeof (filter->buffer [0])) ;
 		// Ensure source slice bounds are okay
-		if currentDataOffset+copyCount > len(data.DataIn) {
+		inLen := int64(len(data.DataIn))
+		if filter.s16In != nil {
+			inLen = int64(len(filter.s16In) / 2)
+		}
+		if currentDataOffset+copyCount > inLen {
 			if sincDebugEnabled {
 				fmt.Printf("[SINC_DEBUG] prepareData: ERROR: copy source bounds: offset=%d, copyCount=%d, len(DataIn)=%d\n", currentDataOffset, copyCount, len(data.DataIn))
 			}
 			return ErrBadData // Trying to read past end of provided input slice
 		}
 
-		copy(filter.buffer[filter.bEnd:filter.bEnd+copyCount], data.DataIn[currentDataOffset:currentDataOffset+copyCount])
+		if filter.s16In != nil {
+			DecodePCM(FormatS16LE, filter.s16In[2*currentDataOffset:2*(currentDataOffset+copyCount)], filter.buffer[filter.bEnd:filter.bEnd+copyCount])
+		} else {
+			copy(filter.buffer[filter.bEnd:filter.bEnd+copyCount], data.DataIn[currentDataOffset:currentDataOffset+copyCount])
+		}
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: Copied %d samples from input@%d to buffer@%d.\n", copyCount, currentDataOffset, filter.bEnd)
 		}
 
 		filter.bEnd += copyCount
-		inUsedSamples += int64(copyCount) // Update local count
+		inUsedSamples += copyCount // Update local count
 	} else {
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: No samples copied (copyCount=0).\n")
 		}
 	}
 
-	// Update SrcData with consumed frames (convert samples back to frames)
-	// Only update if more was consumed than initially passed in
-	if inUsedSamples > initialInUsedSamples {
-		data.InputFramesUsed = inUsedSamples / int64(channels)
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] prepareData: Updated data.InputFramesUsed to %d.\n", data.InputFramesUsed)
-		}
-	} else {
-		// Ensure it reflects at least what was passed in if no new data consumed
-		data.InputFramesUsed = initialInUsedSamples / int64(channels)
+	// Update SrcData with consumed frames; copyCount is whole frames, so this
+	// only ever grows by whole frames
+	data.InputFramesUsed = inUsedSamples / int64(channels)
+	if sincDebugEnabled && inUsedSamples > initialInUsedSamples {
+		fmt.Printf("[SINC_DEBUG] prepareData: Updated data.InputFramesUsed to %d.\n", data.InputFramesUsed)
 	}
 
 	// Handle End Of Input: Add zero padding if needed.
@@ -680,13 +773,13 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 				}
 				return ErrBadInternalState
 			}
-			if srcStart+copyLen > len(filter.buffer) {
+			if srcStart+copyLen > int64(len(filter.buffer)) {
 				if sincDebugEnabled {
 					fmt.Printf("[SINC_DEBUG] prepareData: ERROR: EOF pad-wrap src bounds: srcStart=%d, copyLen=%d, bufLen=%d\n", srcStart, copyLen, len(filter.buffer))
 				}
 				return ErrBadInternalState
 			}
-			if copyLen > len(filter.buffer) {
+			if copyLen > int64(len(filter.buffer)) {
 				if sincDebugEnabled {
 					fmt.Printf("[SINC_DEBUG] prepareData: ERROR: EOF pad-wrap dest bounds: copyLen=%d, bufLen=%d\n", copyLen, len(filter.buffer))
 				}
@@ -694,6 +787,7 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 			}
 
 			copy(filter.buffer[0:copyLen], filter.buffer[srcStart:srcStart+copyLen])
+			filter.planarValid = 0
 			if sincDebugEnabled {
 				fmt.Printf("[SINC_DEBUG] prepareData: EOF pad-wrap copied %d samples from %d to 0.\n", copyLen, srcStart)
 			}
@@ -718,8 +812,8 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 			paddingLen = filter.bLen - filter.bEnd
 		}
 		// Also ensure padding doesn't exceed allocated length
-		if filter.bEnd+paddingLen > len(filter.buffer) {
-			paddingLen = len(filter.buffer) - filter.bEnd
+		if filter.bEnd+paddingLen > int64(len(filter.buffer)) {
+			paddingLen = int64(len(filter.buffer)) - filter.bEnd
 		}
 		if sincDebugEnabled {
 			fmt.Printf("[SINC_DEBUG] prepareData: Calculated paddingLen = %d.\n", paddingLen)
@@ -743,8 +837,45 @@ func prepareData(filter *sincFilter, channels int, data *SrcData, halfFilterChan
 	return ErrNoError
 }
 
+// extendEndPadding lengthens the zero padding after the end of the input
+// (bRealEnd) to halfFilterChanLen + 5 samples, as prepareData pads it at the
+// end of input. When the ratio drops during the drain, the filter reaches
+// further than the padding added for the ratio in use then; without more of it
+// the drain would stop before the position reached bRealEnd and the rest of the
+// input would be lost. The buffer is shifted to its start first if the padding
+// does not fit after bEnd.
+func extendEndPadding(filter *sincFilter, halfFilterChanLen int64) ErrorCode {
+	want := filter.bRealEnd + halfFilterChanLen + 5
+	if filter.bEnd >= want {
+		return ErrNoError
+	}
+	if want > filter.bLen {
+		shift := maxInt64(filter.bCurrent-halfFilterChanLen, 0)
+		copy(filter.buffer, filter.buffer[shift:filter.bEnd])
+		filter.planarValid = 0
+		filter.bCurrent -= shift
+		filter.bEnd -= shift
+		filter.bRealEnd -= shift
+		want -= shift
+		if want > filter.bLen {
+			return ErrBadInternalState // Buffer too small for the filter, not expected
+		}
+	}
+	padding := filter.buffer[filter.bEnd:want]
+	for i := range padding {
+		padding[i] = 0.0
+	}
+	filter.bEnd = want
+	return ErrNoError
+}
+
 // calcOutputSingle calculates a single interpolated output sample.
 // Corresponds to calc_output_single in src_sinc.c
+//
+// The explicit float64() conversions of products here and in the other
+// calcOutput functions stop the compiler from fusing them with the following
+// add into an FMA, which rounds differently, so the output is the same on every
+// platform (see Options.Deterministic).
 func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT) float64 {
 	if sincDebugEnabled {
 		fmt.Printf("[SINC_DEBUG] calcOutputSingle: ENTRY - increment=%d, startFilterIndex=%d, bCurrent=%d, bEnd=%d, bRealEnd=%d\n", increment, startFilterIndex, filter.bCurrent, filter.bEnd, filter.bRealEnd)
@@ -759,15 +890,15 @@ func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT
 	if increment <= 0 {
 		panic(fmt.Sprintf("calcOutputSingle: invalid increment %d", increment))
 	}
-	coeffCount := int((maxFilterIndex - filterIndex) / increment)
+	coeffCount := int64((maxFilterIndex - filterIndex) / increment)
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
 	dataIndex := filter.bCurrent - coeffCount
 
 	if dataIndex < 0 {
 		steps := intDivCeil(-dataIndex, 1) // For single channel, step is 1
-		maxSteps := intDivCeil(int(filterIndex), int(increment))
+		maxSteps := intDivCeil(int64(filterIndex), int64(increment))
 		if filterIndex < 0 {
-			maxSteps = intDivCeil(int(-filterIndex+increment-1), int(increment))
+			maxSteps = intDivCeil(int64(-filterIndex+increment-1), int64(increment))
 		}
 		if steps > maxSteps {
 			panic(fmt.Sprintf("calcOutputSingle: buffer underflow assertion failed (steps=%d > maxSteps=%d, filterIndex=%d, increment=%d)", steps, maxSteps, filterIndex, increment))
@@ -786,7 +917,7 @@ func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputSingle: left coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Left Loop) ---
 		var sampleValue float64 = 0.0 // Default to 0.0 (for padded area)
@@ -803,7 +934,7 @@ func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT
 		}
 		// If filter.bRealEnd >= 0 AND dataIndex >= filter.bRealEnd, sampleValue remains 0.0
 
-		left += icoeff * sampleValue // Accumulate (adds 0.0 if reading padded area)
+		left += float64(icoeff * sampleValue) // Accumulate (adds 0.0 if reading padded area)
 		// --- END NEW ---
 
 		filterIndex -= increment
@@ -816,7 +947,7 @@ func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT
 	if filterIndex > maxFilterIndex {
 		coeffCount = -1
 	} else {
-		coeffCount = int((maxFilterIndex - filterIndex) / increment)
+		coeffCount = int64((maxFilterIndex - filterIndex) / increment)
 	}
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
 	dataIndex = filter.bCurrent + 1 + coeffCount
@@ -831,7 +962,7 @@ func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputSingle: right coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Right Loop) ---
 		var sampleValue float64 = 0.0 // Default to 0.0 (for padded area)
@@ -847,7 +978,7 @@ func calcOutputSingle(filter *sincFilter, increment, startFilterIndex incrementT
 		}
 		// If filter.bRealEnd >= 0 AND dataIndex >= filter.bRealEnd, sampleValue remains 0.0
 
-		right += icoeff * sampleValue // Accumulate (adds 0.0 if reading padded area)
+		right += float64(icoeff * sampleValue) // Accumulate (adds 0.0 if reading padded area)
 		// --- END NEW ---
 
 		filterIndex -= increment
@@ -881,21 +1012,21 @@ func calcOutputStereo(filter *sincFilter, channels int, increment, startFilterIn
 	if increment <= 0 {
 		panic(fmt.Sprintf("calcOutputStereo: invalid increment %d", increment))
 	}
-	coeffCount := int((maxFilterIndex - filterIndex) / increment)
+	coeffCount := int64((maxFilterIndex - filterIndex) / increment)
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex := filter.bCurrent - channels*coeffCount
+	dataIndex := filter.bCurrent - int64(channels)*coeffCount
 
 	if dataIndex < 0 {
-		steps := intDivCeil(-dataIndex, channels)
-		maxSteps := intDivCeil(int(filterIndex), int(increment))
+		steps := intDivCeil(-dataIndex, int64(channels))
+		maxSteps := intDivCeil(int64(filterIndex), int64(increment))
 		if filterIndex < 0 {
-			maxSteps = intDivCeil(int(-filterIndex+increment-1), int(increment))
+			maxSteps = intDivCeil(int64(-filterIndex+increment-1), int64(increment))
 		}
 		if steps > maxSteps {
 			panic(fmt.Sprintf("calcOutputStereo: buffer underflow assertion failed (steps=%d > maxSteps=%d, filterIndex=%d, increment=%d)", steps, maxSteps, filterIndex, increment))
 		}
 		filterIndex -= incrementT(steps) * increment
-		dataIndex += steps * channels
+		dataIndex += steps * int64(channels)
 	}
 
 	left[0], left[1] = 0.0, 0.0
@@ -905,7 +1036,7 @@ func calcOutputStereo(filter *sincFilter, channels int, increment, startFilterIn
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputStereo: left coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Left Loop - Stereo) ---
 		endDataIdx := dataIndex + 1 // Check up to the second channel
@@ -929,12 +1060,12 @@ func calcOutputStereo(filter *sincFilter, channels int, increment, startFilterIn
 			sampleValueCh1 = float64(filter.buffer[dataIndex+1])
 		}
 
-		left[0] += icoeff * sampleValueCh0
-		left[1] += icoeff * sampleValueCh1
+		left[0] += float64(icoeff * sampleValueCh0)
+		left[1] += float64(icoeff * sampleValueCh1)
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex += channels
+		dataIndex += int64(channels)
 	}
 
 	//---------------- Apply the right half of the filter -------------------
@@ -942,10 +1073,10 @@ func calcOutputStereo(filter *sincFilter, channels int, increment, startFilterIn
 	if filterIndex > maxFilterIndex {
 		coeffCount = -1
 	} else {
-		coeffCount = int((maxFilterIndex - filterIndex) / increment)
+		coeffCount = int64((maxFilterIndex - filterIndex) / increment)
 	}
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex = filter.bCurrent + channels*(1+coeffCount)
+	dataIndex = filter.bCurrent + int64(channels)*(1+coeffCount)
 
 	right[0], right[1] = 0.0, 0.0
 	for {
@@ -954,7 +1085,7 @@ func calcOutputStereo(filter *sincFilter, channels int, increment, startFilterIn
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputStereo: right coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Right Loop - Stereo) ---
 		endDataIdx := dataIndex + 1
@@ -978,12 +1109,12 @@ func calcOutputStereo(filter *sincFilter, channels int, increment, startFilterIn
 			sampleValueCh1 = float64(filter.buffer[dataIndex+1])
 		}
 
-		right[0] += icoeff * sampleValueCh0
-		right[1] += icoeff * sampleValueCh1
+		right[0] += float64(icoeff * sampleValueCh0)
+		right[1] += float64(icoeff * sampleValueCh1)
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex -= channels
+		dataIndex -= int64(channels)
 
 		if !(filterIndex > 0) {
 			break
@@ -1013,21 +1144,21 @@ func calcOutputQuad(filter *sincFilter, channels int, increment, startFilterInde
 	if increment <= 0 {
 		panic(fmt.Sprintf("calcOutputQuad: invalid increment %d", increment))
 	}
-	coeffCount := int((maxFilterIndex - filterIndex) / increment)
+	coeffCount := int64((maxFilterIndex - filterIndex) / increment)
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex := filter.bCurrent - channels*coeffCount
+	dataIndex := filter.bCurrent - int64(channels)*coeffCount
 
 	if dataIndex < 0 {
-		steps := intDivCeil(-dataIndex, channels)
-		maxSteps := intDivCeil(int(filterIndex), int(increment))
+		steps := intDivCeil(-dataIndex, int64(channels))
+		maxSteps := intDivCeil(int64(filterIndex), int64(increment))
 		if filterIndex < 0 {
-			maxSteps = intDivCeil(int(-filterIndex+increment-1), int(increment))
+			maxSteps = intDivCeil(int64(-filterIndex+increment-1), int64(increment))
 		}
 		if steps > maxSteps {
 			panic(fmt.Sprintf("calcOutputQuad: buffer underflow assertion failed (steps=%d > maxSteps=%d, filterIndex=%d, increment=%d)", steps, maxSteps, filterIndex, increment))
 		}
 		filterIndex -= incrementT(steps) * increment
-		dataIndex += steps * channels
+		dataIndex += steps * int64(channels)
 	}
 
 	for ch := 0; ch < 4; ch++ {
@@ -1039,7 +1170,7 @@ func calcOutputQuad(filter *sincFilter, channels int, increment, startFilterInde
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputQuad: left coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Left Loop - Quad) ---
 		endDataIdx := dataIndex + 3 // Check up to the last channel
@@ -1052,16 +1183,16 @@ func calcOutputQuad(filter *sincFilter, channels int, increment, startFilterInde
 
 		for ch := 0; ch < 4; ch++ {
 			sampleValue := 0.0
-			checkIdx := dataIndex + ch
+			checkIdx := dataIndex + int64(ch)
 			if checkIdx < filter.bEnd && (filter.bRealEnd < 0 || checkIdx < filter.bRealEnd) {
 				sampleValue = float64(filter.buffer[checkIdx])
 			}
-			left[ch] += icoeff * sampleValue
+			left[ch] += float64(icoeff * sampleValue)
 		}
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex += channels
+		dataIndex += int64(channels)
 	}
 
 	//---------------- Apply the right half of the filter -------------------
@@ -1069,10 +1200,10 @@ func calcOutputQuad(filter *sincFilter, channels int, increment, startFilterInde
 	if filterIndex > maxFilterIndex {
 		coeffCount = -1
 	} else {
-		coeffCount = int((maxFilterIndex - filterIndex) / increment)
+		coeffCount = int64((maxFilterIndex - filterIndex) / increment)
 	}
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex = filter.bCurrent + channels*(1+coeffCount)
+	dataIndex = filter.bCurrent + int64(channels)*(1+coeffCount)
 
 	for ch := 0; ch < 4; ch++ {
 		right[ch] = 0.0
@@ -1083,7 +1214,7 @@ func calcOutputQuad(filter *sincFilter, channels int, increment, startFilterInde
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputQuad: right coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Right Loop - Quad) ---
 		endDataIdx := dataIndex + 3
@@ -1096,16 +1227,16 @@ func calcOutputQuad(filter *sincFilter, channels int, increment, startFilterInde
 
 		for ch := 0; ch < 4; ch++ {
 			sampleValue := 0.0
-			checkIdx := dataIndex + ch
+			checkIdx := dataIndex + int64(ch)
 			if checkIdx < filter.bEnd && (filter.bRealEnd < 0 || checkIdx < filter.bRealEnd) {
 				sampleValue = float64(filter.buffer[checkIdx])
 			}
-			right[ch] += icoeff * sampleValue
+			right[ch] += float64(icoeff * sampleValue)
 		}
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex -= channels
+		dataIndex -= int64(channels)
 
 		if !(filterIndex > 0) {
 			break
@@ -1136,21 +1267,21 @@ func calcOutputHex(filter *sincFilter, channels int, increment, startFilterIndex
 	if increment <= 0 {
 		panic(fmt.Sprintf("calcOutputHex: invalid increment %d", increment))
 	}
-	coeffCount := int((maxFilterIndex - filterIndex) / increment)
+	coeffCount := int64((maxFilterIndex - filterIndex) / increment)
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex := filter.bCurrent - channels*coeffCount
+	dataIndex := filter.bCurrent - int64(channels)*coeffCount
 
 	if dataIndex < 0 {
-		steps := intDivCeil(-dataIndex, channels)
-		maxSteps := intDivCeil(int(filterIndex), int(increment))
+		steps := intDivCeil(-dataIndex, int64(channels))
+		maxSteps := intDivCeil(int64(filterIndex), int64(increment))
 		if filterIndex < 0 {
-			maxSteps = intDivCeil(int(-filterIndex+increment-1), int(increment))
+			maxSteps = intDivCeil(int64(-filterIndex+increment-1), int64(increment))
 		}
 		if steps > maxSteps {
 			panic(fmt.Sprintf("calcOutputHex: buffer underflow assertion failed (steps=%d > maxSteps=%d, filterIndex=%d, increment=%d)", steps, maxSteps, filterIndex, increment))
 		}
 		filterIndex -= incrementT(steps) * increment
-		dataIndex += steps * channels
+		dataIndex += steps * int64(channels)
 	}
 
 	for ch := 0; ch < 6; ch++ {
@@ -1162,7 +1293,7 @@ func calcOutputHex(filter *sincFilter, channels int, increment, startFilterIndex
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputHex: left coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Left Loop - Hex) ---
 		endDataIdx := dataIndex + 5
@@ -1175,16 +1306,16 @@ func calcOutputHex(filter *sincFilter, channels int, increment, startFilterIndex
 
 		for ch := 0; ch < 6; ch++ {
 			sampleValue := 0.0
-			checkIdx := dataIndex + ch
+			checkIdx := dataIndex + int64(ch)
 			if checkIdx < filter.bEnd && (filter.bRealEnd < 0 || checkIdx < filter.bRealEnd) {
 				sampleValue = float64(filter.buffer[checkIdx])
 			}
-			left[ch] += icoeff * sampleValue
+			left[ch] += float64(icoeff * sampleValue)
 		}
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex += channels
+		dataIndex += int64(channels)
 	}
 
 	//---------------- Apply the right half of the filter -------------------
@@ -1192,10 +1323,10 @@ func calcOutputHex(filter *sincFilter, channels int, increment, startFilterIndex
 	if filterIndex > maxFilterIndex {
 		coeffCount = -1
 	} else {
-		coeffCount = int((maxFilterIndex - filterIndex) / increment)
+		coeffCount = int64((maxFilterIndex - filterIndex) / increment)
 	}
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex = filter.bCurrent + channels*(1+coeffCount)
+	dataIndex = filter.bCurrent + int64(channels)*(1+coeffCount)
 
 	for ch := 0; ch < 6; ch++ {
 		right[ch] = 0.0
@@ -1206,7 +1337,7 @@ func calcOutputHex(filter *sincFilter, channels int, increment, startFilterIndex
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputHex: right coefficient index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Right Loop - Hex) ---
 		endDataIdx := dataIndex + 5
@@ -1219,16 +1350,16 @@ func calcOutputHex(filter *sincFilter, channels int, increment, startFilterIndex
 
 		for ch := 0; ch < 6; ch++ {
 			sampleValue := 0.0
-			checkIdx := dataIndex + ch
+			checkIdx := dataIndex + int64(ch)
 			if checkIdx < filter.bEnd && (filter.bRealEnd < 0 || checkIdx < filter.bRealEnd) {
 				sampleValue = float64(filter.buffer[checkIdx])
 			}
-			right[ch] += icoeff * sampleValue
+			right[ch] += float64(icoeff * sampleValue)
 		}
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex -= channels
+		dataIndex -= int64(channels)
 
 		if !(filterIndex > 0) {
 			break
@@ -1265,21 +1396,21 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 	if increment <= 0 {
 		panic(fmt.Sprintf("calcOutputMulti: invalid increment %d", increment))
 	}
-	coeffCount := int((maxFilterIndex - filterIndex) / increment)
+	coeffCount := int64((maxFilterIndex - filterIndex) / increment)
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex := filter.bCurrent - channels*coeffCount
+	dataIndex := filter.bCurrent - int64(channels)*coeffCount
 
 	if dataIndex < 0 {
-		steps := intDivCeil(-dataIndex, channels)
-		maxSteps := intDivCeil(int(filterIndex), int(increment))
+		steps := intDivCeil(-dataIndex, int64(channels))
+		maxSteps := intDivCeil(int64(filterIndex), int64(increment))
 		if filterIndex < 0 {
-			maxSteps = intDivCeil(int(-filterIndex+increment-1), int(increment))
+			maxSteps = intDivCeil(int64(-filterIndex+increment-1), int64(increment))
 		}
 		if steps > maxSteps {
 			panic(fmt.Sprintf("calcOutputMulti: buffer underflow assertion failed (steps=%d > maxSteps=%d, filterIndex=%d, increment=%d)", steps, maxSteps, filterIndex, increment))
 		}
 		filterIndex -= incrementT(steps) * increment
-		dataIndex += steps * channels
+		dataIndex += steps * int64(channels)
 	}
 
 	for filterIndex >= 0 {
@@ -1288,10 +1419,10 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputMulti: left coeff index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Left Loop - Multi) ---
-		endDataIdx := dataIndex + channels - 1
+		endDataIdx := dataIndex + int64(channels) - 1
 		if dataIndex < 0 || endDataIdx >= filter.bLen {
 			panic(fmt.Sprintf("calcOutputMulti: left buffer index out of allocated bounds (dataIndex=%d, channels=%d, bLen=%d)", dataIndex, channels, filter.bLen))
 		}
@@ -1301,17 +1432,17 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 
 		for ch := 0; ch < channels; ch++ {
 			sampleValue := 0.0
-			checkIdx := dataIndex + ch
+			checkIdx := dataIndex + int64(ch)
 			// Only read if index is within BOTH bEnd and bRealEnd (if set)
 			if checkIdx < filter.bEnd && (filter.bRealEnd < 0 || checkIdx < filter.bRealEnd) {
 				sampleValue = float64(filter.buffer[checkIdx])
 			}
-			left[ch] += icoeff * sampleValue
+			left[ch] += float64(icoeff * sampleValue)
 		}
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex += channels
+		dataIndex += int64(channels)
 	}
 
 	//---------------- Apply the right half of the filter -------------------
@@ -1319,10 +1450,10 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 	if filterIndex > maxFilterIndex {
 		coeffCount = -1
 	} else {
-		coeffCount = int((maxFilterIndex - filterIndex) / increment)
+		coeffCount = int64((maxFilterIndex - filterIndex) / increment)
 	}
 	filterIndex = filterIndex + incrementT(coeffCount)*increment
-	dataIndex = filter.bCurrent + channels*(1+coeffCount)
+	dataIndex = filter.bCurrent + int64(channels)*(1+coeffCount)
 
 	// Right accumulators already zeroed
 	for {
@@ -1331,10 +1462,10 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 		if indx < 0 || indx+1 >= len(filter.coeffs) {
 			panic(fmt.Sprintf("calcOutputMulti: right coeff index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
 		}
-		icoeff := float64(filter.coeffs[indx]) + fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])
+		icoeff := float64(filter.coeffs[indx]) + float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx]))
 
 		// --- NEW Checks and Read (Right Loop - Multi) ---
-		endDataIdx := dataIndex + channels - 1
+		endDataIdx := dataIndex + int64(channels) - 1
 		if dataIndex < 0 || endDataIdx >= filter.bLen {
 			panic(fmt.Sprintf("calcOutputMulti: right buffer index out of allocated bounds (dataIndex=%d, channels=%d, bLen=%d)", dataIndex, channels, filter.bLen))
 		}
@@ -1344,16 +1475,16 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 
 		for ch := 0; ch < channels; ch++ {
 			sampleValue := 0.0
-			checkIdx := dataIndex + ch
+			checkIdx := dataIndex + int64(ch)
 			if checkIdx < filter.bEnd && (filter.bRealEnd < 0 || checkIdx < filter.bRealEnd) {
 				sampleValue = float64(filter.buffer[checkIdx])
 			}
-			right[ch] += icoeff * sampleValue
+			right[ch] += float64(icoeff * sampleValue)
 		}
 		// --- END NEW ---
 
 		filterIndex -= increment
-		dataIndex -= channels
+		dataIndex -= int64(channels)
 		if !(filterIndex > 0) {
 			break
 		}
@@ -1367,197 +1498,165 @@ func calcOutputMulti(filter *sincFilter, channels int, increment, startFilterInd
 
 // --- TODO: Implement Processing & Helper Functions ---
 
+// sincKernel computes one interpolated output frame of channels samples around
+// the read position of filter into output, scaled by scale. The vari process
+// loop is shared by all channel counts; only the kernel is specialized, so a
+// new one (e.g. a SIMD one) only has to implement this.
+type sincKernel func(filter *sincFilter, channels int, increment, startFilterIndex incrementT, scale float64, output []float32)
+
+// calcOutputMono is calcOutputSingle as a sincKernel.
+func calcOutputMono(filter *sincFilter, channels int, increment, startFilterIndex incrementT, scale float64, output []float32) {
+	output[0] = float32(scale * calcOutputSingle(filter, increment, startFilterIndex))
+}
+
 // sincMonoVariProcess handles mono data with potentially varying sample rate ratio.
-// Corresponds to sinc_mono_vari_process in src_sinc.c (Corrected version)
+// Corresponds to sinc_mono_vari_process in src_sinc.c
 func sincMonoVariProcess(state *srcState, data *SrcData) ErrorCode {
+	return sincVariProcess(state, data, 1, calcOutputMono)
+}
+
+// sincStereoVariProcess handles stereo data with potentially varying sample rate ratio.
+// Corresponds to sinc_stereo_vari_process in src_sinc.c
+func sincStereoVariProcess(state *srcState, data *SrcData) ErrorCode {
+	return sincVariProcess(state, data, 2, calcOutputStereo)
+}
+
+// sincQuadVariProcess handles 4-channel data with potentially varying sample rate ratio.
+// Corresponds to sinc_quad_vari_process in src_sinc.c
+func sincQuadVariProcess(state *srcState, data *SrcData) ErrorCode {
+	return sincVariProcess(state, data, 4, calcOutputQuad)
+}
+
+// sincHexVariProcess handles 6-channel data with potentially varying sample rate ratio.
+// Corresponds to sinc_hex_vari_process in src_sinc.c
+func sincHexVariProcess(state *srcState, data *SrcData) ErrorCode {
+	return sincVariProcess(state, data, 6, calcOutputHex)
+}
+
+// sincMultichanVariProcess handles any number of channels with potentially varying sample rate ratio.
+// Corresponds to sinc_multichan_vari_process in src_sinc.c
+func sincMultichanVariProcess(state *srcState, data *SrcData) ErrorCode {
+	return sincVariProcess(state, data, 0, calcOutputMulti)
+}
+
+// sincVariProcess is the vari process loop of all the sinc converters: it
+// computes each output frame with kernel. wantChannels is the channel count the
+// kernel is specialized for, or 0 if it takes any.
+func sincVariProcess(state *srcState, data *SrcData, wantChannels int, kernel sincKernel) ErrorCode {
 	if sincDebugEnabled {
-		fmt.Printf("\n[SINC_DEBUG] sincMonoVariProcess: ENTRY - data.InFrames=%d, data.OutFrames=%d, data.SrcRatio=%.5f, data.EOF=%t\n",
-			data.InputFrames, data.OutputFrames, data.SrcRatio, data.EndOfInput)
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: State - lastRatio=%.5f, lastPos=%.5f\n", state.lastRatio, state.lastPosition)
+		fmt.Printf("\n[SINC_DEBUG] sincVariProcess(%d ch): ENTRY - data.InFrames=%d, data.OutFrames=%d, data.SrcRatio=%.5f, data.EOF=%t\n",
+			state.channels, data.InputFrames, data.OutputFrames, data.SrcRatio, data.EndOfInput)
+		fmt.Printf("[SINC_DEBUG] sincVariProcess: State - lastRatio=%.5f, lastPos=%.5f\n", state.lastRatio, state.lastPosition)
 	}
 
 	filter, ok := state.privateData.(*sincFilter)
 	if !ok || filter == nil {
 		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: Invalid private data.\n")
+			fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: Invalid private data.\n")
 		}
 		return ErrBadState
 	}
-	if state.channels != 1 {
+	if wantChannels != 0 && state.channels != wantChannels {
 		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: Incorrect channel count (%d).\n", state.channels)
+			fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: Incorrect channel count (%d, want %d).\n", state.channels, wantChannels)
 		}
 		return ErrBadInternalState
 	}
 	inputIndex := state.lastPosition
 	srcRatio := state.lastRatio
 	var increment, startFilterIndex incrementT
-	var halfFilterChanLen, samplesInHand int
+	var halfFilterChanLen, samplesInHand int64
 	outCountSamples := data.OutputFrames * int64(state.channels)
 	data.InputFramesUsed = 0    // Reset before processing
 	data.OutputFramesGen = 0    // Reset before processing
-	var inUsedSamples int64 = 0 // Tracks samples consumed *by prepareData* cumulatively in this call
 	var outGenSamples int64 = 0 // Tracks samples generated *in this call*
 
 	// Initialize srcRatio if needed (first call)
 	if isBadSrcRatio(srcRatio) {
 		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Initializing srcRatio from data.SrcRatio (%.5f)\n", data.SrcRatio)
+			fmt.Printf("[SINC_DEBUG] sincVariProcess: Initializing srcRatio from data.SrcRatio (%.5f)\n", data.SrcRatio)
 		}
 		if isBadSrcRatio(data.SrcRatio) {
 			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: Bad initial srcRatio from data.\n")
+				fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: Bad initial srcRatio from data.\n")
 			}
 			return ErrBadSrcRatio
 		}
 		srcRatio = data.SrcRatio
-		// state.lastRatio = srcRatio // Don't update state.lastRatio yet, use local srcRatio for consistency check below
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Effective srcRatio for start = %.5f\n", srcRatio)
 	}
 
 	// Calculate required lookback/lookahead based on filter length and minimum ratio
-	filterCoeffsLen := float64(filter.coeffHalfLen + 2)
 	if filter.indexInc <= 0 {
 		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: Bad filter.indexInc (%d).\n", filter.indexInc)
+			fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: Bad filter.indexInc (%d).\n", filter.indexInc)
 		}
 		return ErrBadInternalState
 	}
-	count := filterCoeffsLen / float64(filter.indexInc)
-	//minRatio := minFloat64(state.lastRatio, data.SrcRatio) // Use state.lastRatio here? C uses local src_ratio, which might be data->src_ratio initially. Let's use data->SrcRatio if state.lastRatio is invalid.
 	effectiveMinRatio := srcRatio        // Start with current effective ratio
 	if !isBadSrcRatio(state.lastRatio) { // If lastRatio was valid
 		effectiveMinRatio = minFloat64(state.lastRatio, srcRatio) // Consider variation
 	}
-	if effectiveMinRatio < (1.0 / srcMaxRatio) {
-		effectiveMinRatio = 1.0 / srcMaxRatio
-	}
-	if effectiveMinRatio < 1.0 && effectiveMinRatio > 1e-10 { // Avoid division by zero/very small
-		count /= effectiveMinRatio
-	} else if effectiveMinRatio <= 1e-10 {
-		// Handle extremely small ratio case - required lookback could be huge. Cap it?
-		// Let's just use a large multiplier for count to be safe, matching C's implicit large result.
-		count *= srcMaxRatio // Arbitrary large factor if ratio is near zero
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: WARNING: Very small minRatio (%.5f), using large lookback factor.\n", effectiveMinRatio)
-		}
+	if !isBadSrcRatio(data.SrcRatio) {
+		effectiveMinRatio = minFloat64(effectiveMinRatio, data.SrcRatio) // The ratio ramps towards data.SrcRatio
 	}
-
-	halfFilterChanLen = state.channels * (psfLrint(count) + 1)
+	halfFilterChanLen = filter.halfChanLen(state.channels, effectiveMinRatio)
 	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Calculated halfFilterChanLen = %d\n", halfFilterChanLen)
+		fmt.Printf("[SINC_DEBUG] sincVariProcess: srcRatio = %.5f, halfFilterChanLen = %d\n", srcRatio, halfFilterChanLen)
 	}
 
 	// Advance internal buffer pointer based on integer part of inputIndex
 	intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
 	if filter.bLen <= 0 {
 		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: Bad filter.bLen (%d).\n", filter.bLen)
+			fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: Bad filter.bLen (%d).\n", filter.bLen)
 		}
 		return ErrBadInternalState
 	}
 	// Wrap bCurrent using modulo
-	newBCurrent := (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
+	newBCurrent := (filter.bCurrent + int64(state.channels)*int64(intInputAdvance)) % filter.bLen
 	if newBCurrent < 0 { // Ensure positive result from modulo
 		newBCurrent += filter.bLen
 	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Advancing bCurrent by %d samples from %d to %d (modulo %d).\n", state.channels*intInputAdvance, filter.bCurrent, newBCurrent, filter.bLen)
-	}
 	filter.bCurrent = newBCurrent
 	inputIndex = fmodOne(inputIndex) // Keep only fractional part
 
 	// Main processing loop
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Starting main loop. Target output samples = %d\n", outCountSamples)
-	}
 	for outGenSamples < outCountSamples {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Loop Iteration %d. outGenSamples=%d\n", outGenSamples, outGenSamples)
-		}
-
 		// Calculate samples currently available in the buffer
-		if filter.bEnd >= filter.bCurrent {
-			samplesInHand = filter.bEnd - filter.bCurrent
-		} else {
-			samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-		}
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: samplesInHand=%d (bEnd=%d, bCurrent=%d, bLen=%d). Needed=%d\n", samplesInHand, filter.bEnd, filter.bCurrent, filter.bLen, halfFilterChanLen)
-		}
+		samplesInHand = filter.samplesInHand()
 
 		// Check if we need more data (including lookback/lookahead)
 		if samplesInHand <= halfFilterChanLen {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: samplesInHand <= halfFilterChanLen. Calling prepareData.\n")
-			}
-			// Need to update data.InputFramesUsed *before* calling prepareData if it was modified internally
-			data.InputFramesUsed = inUsedSamples / int64(state.channels)
-
-			errCode := prepareData(filter, state.channels, data, halfFilterChanLen)
+			var errCode ErrorCode
+			samplesInHand, errCode = refillBuffer(filter, state.channels, data, halfFilterChanLen)
 			if errCode != ErrNoError {
 				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: prepareData returned error: %d\n", errCode)
+					fmt.Printf("[SINC_DEBUG] sincVariProcess: prepareData returned error: %d\n", errCode)
 				}
 				state.errCode = errCode
 				return errCode // Propagate error
 			}
-			// Update local counter of used samples based on what prepareData consumed
-			inUsedSamples = data.InputFramesUsed * int64(state.channels)
-
-			// Recalculate samplesInHand after prepareData
-			if filter.bEnd >= filter.bCurrent {
-				samplesInHand = filter.bEnd - filter.bCurrent
-			} else {
-				samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-			}
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: After prepareData: samplesInHand=%d, inUsedSamples=%d (data.InputFramesUsed=%d)\n", samplesInHand, inUsedSamples, data.InputFramesUsed)
-			}
 
 			// If still not enough samples after trying to prepare, we must break (EOF or insufficient buffer)
 			if samplesInHand <= halfFilterChanLen {
 				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: samplesInHand *still* <= halfFilterChanLen (%d <= %d). Breaking loop.\n", samplesInHand, halfFilterChanLen)
+					fmt.Printf("[SINC_DEBUG] sincVariProcess: samplesInHand *still* <= halfFilterChanLen (%d <= %d). Breaking loop.\n", samplesInHand, halfFilterChanLen)
 				}
-				break // Exit the loop
+				break
 			}
 		}
 
-		// Check for End Of Input condition within the buffer
-		// If bRealEnd is set (by prepareData padding) and we need data beyond it, stop.
-		//if filter.bRealEnd >= 0 {
-		//	// Calculate the furthest index needed for the current output sample calculation
-		//	maxIndexNeeded := filter.bCurrent + halfFilterChanLen // Approximate index needed
-		//	// Note: This check is approximate. The actual max index depends on startFilterIndex/increment.
-		//	// C code just checks `b_current + half_filter_chan_len`.
-		//	// A more accurate check might involve the dataIndex calculation within calcOutput*.
-		//	// Let's stick to the C check for now.
-		//	fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: EOF Check: bRealEnd=%d, maxIndexNeeded (approx)=%d\n", filter.bRealEnd, maxIndexNeeded)
-		//	if maxIndexNeeded >= filter.bRealEnd {
-		//		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: maxIndexNeeded >= bRealEnd. Breaking loop due to EOF.\n")
-		//		break                                                                                                   // Cannot calculate more output, reached end of real data + padding
-		//	}
-		//}
+		// Check for End Of Input condition within the buffer.
+		// C checks: b_current + input_index (fractional) + terminate >= b_real_end,
+		// with terminate approx 1.0 / src_ratio for the current ratio.
 		if filter.bRealEnd >= 0 {
-			// Calculate approximate position corresponding to the *next* output sample's center
-			// C uses 'terminate' which is approx 1.0 / src_ratio
-			// C checks: b_current + input_index (fractional) + terminate
-			// Need to use the *current* effective srcRatio for terminate calculation
-			terminate := 1.0/srcRatio + 1e-20                                  // Use current loop's srcRatio
+			terminate := 1.0/srcRatio + 1e-20
 			checkPosition := float64(filter.bCurrent) + inputIndex + terminate // Approximate position needed for next sample
-
-			// C Check: if (checkPosition >= filter->b_real_end) break;
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] ... EOF Check: bRealEnd=%d, checkPosition(curr+idx+1/ratio)=%.2f\n", filter.bRealEnd, checkPosition)
-			}
 			if checkPosition >= float64(filter.bRealEnd) {
 				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] ... Breaking loop due to EOF check (C logic).\n")
+					fmt.Printf("[SINC_DEBUG] sincVariProcess: Breaking loop due to EOF check (bRealEnd=%d, checkPosition=%.2f).\n", filter.bRealEnd, checkPosition)
 				}
-				break // Break loop if EOF reached
+				break
 			}
 		}
 		// Vary ratio if needed (only if target output count > 0)
@@ -1572,7 +1671,6 @@ func sincMonoVariProcess(state *srcState, data *SrcData) ErrorCode {
 					srcRatio = srcMaxRatio
 				}
 			}
-			// fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Varied srcRatio to %.5f\n", srcRatio)
 		}
 
 		// Calculate fixed point increment based on potentially varying srcRatio
@@ -1582,76 +1680,55 @@ func sincMonoVariProcess(state *srcState, data *SrcData) ErrorCode {
 		if increment == 0 {
 			// This happens if srcRatio is extremely small, making floatIncrement effectively zero.
 			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: Calculated increment is zero (srcRatio=%.15f, floatInc=%.15f).\n", srcRatio, floatIncrement)
+				fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: Calculated increment is zero (srcRatio=%.15f, floatInc=%.15f).\n", srcRatio, floatIncrement)
 			}
-			// What should happen here? C returns error.
-			state.errCode = ErrBadSrcRatio // Or maybe ErrBadInternalState?
+			state.errCode = ErrBadSrcRatio
 			return state.errCode
 		}
 
-		// Calculate start index for filter coefficients based on fractional input position
-		// startFilterIndex = inputIndex * increment // This seems wrong, C uses inputIndex * floatIncrement
+		// Start index for filter coefficients from the fractional input position;
+		// C uses inputIndex * floatIncrement, as does the scale factor.
 		startFilterIndex = doubleToFP(inputIndex * floatIncrement)
-
-		// Calculate scaling factor for output sample
-		// scaleFactor = increment / filter.indexInc ; C uses float_increment
 		scaleFactor := floatIncrement / float64(filter.indexInc)
 
-		// fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Calculating output: increment=%d, startFilterIndex=%d, scaleFactor=%.5f\n", increment, startFilterIndex, scaleFactor)
-
-		// Calculate the output sample
-		// Ensure calcOutputSingle handles boundary conditions robustly
-		outputSample := scaleFactor * calcOutputSingle(filter, increment, startFilterIndex)
-
-		// Store the output sample
+		// Calculate and store the output frame
 		outPos := int(outGenSamples)
-		if outPos >= len(data.DataOut) {
+		if outPos+state.channels > len(data.DataOut) {
 			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: WARNING: Output buffer full (outPos=%d, len=%d). Breaking loop.\n", outPos, len(data.DataOut))
+				fmt.Printf("[SINC_DEBUG] sincVariProcess: WARNING: Output buffer full (outPos=%d, len=%d). Breaking loop.\n", outPos, len(data.DataOut))
 			}
-			// This indicates the provided output buffer was too small for the requested OutputFrames
-			state.errCode = ErrNoError // Not necessarily an error state, just can't write more
-			break                      // Exit the loop
+			break // The output buffer is smaller than data.OutputFrames, not an error
 		}
-		data.DataOut[outPos] = float32(outputSample)
-		outGenSamples++
-
-		// fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Generated sample %d = %.5f\n", outGenSamples, outputSample)
+		kernel(filter, state.channels, increment, startFilterIndex, scaleFactor, data.DataOut[outPos:outPos+state.channels])
+		outGenSamples += int64(state.channels)
 
-		// Update input index position for the next output sample
+		// Update input index position for the next output frame
 		if srcRatio <= 1e-10 { // Avoid division by zero/very small
 			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: ERROR: srcRatio is zero or very small (%.15f), cannot advance input index.\n", srcRatio)
+				fmt.Printf("[SINC_DEBUG] sincVariProcess: ERROR: srcRatio is zero or very small (%.15f), cannot advance input index.\n", srcRatio)
 			}
 			state.errCode = ErrBadSrcRatio
 			return state.errCode
 		}
-		inputIndex += 1.0 / srcRatio
+		inputIndex = state.advanceInput(inputIndex, srcRatio)
 
 		// Advance internal buffer pointer based on integer part of new inputIndex
 		intInputAdvance = psfLrint(inputIndex - fmodOne(inputIndex))
-		newBCurrent = (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
+		newBCurrent = (filter.bCurrent + int64(state.channels)*int64(intInputAdvance)) % filter.bLen
 		if newBCurrent < 0 {
 			newBCurrent += filter.bLen
 		}
-		// fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Advanced inputIndex to %.5f, intInputAdvance=%d, moving bCurrent from %d to %d.\n", inputIndex, intInputAdvance, filter.bCurrent, newBCurrent)
 		filter.bCurrent = newBCurrent
 		inputIndex = fmodOne(inputIndex) // Keep only fractional part
 	} // End main processing loop
 
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: Exited main loop.\n")
-	}
-
 	// Store final state
 	state.lastPosition = inputIndex
-	state.lastRatio = srcRatio // Store the potentially varied ratio used for the *last* sample
+	state.lastRatio = srcRatio // Store the potentially varied ratio used for the *last* frame
 	data.OutputFramesGen = outGenSamples / int64(state.channels)
-	// Ensure InputFramesUsed reflects *all* consumption triggered by prepareData calls
-	data.InputFramesUsed = inUsedSamples / int64(state.channels)
 
 	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMonoVariProcess: EXIT - data.OutGen=%d, data.InUsed=%d, state.lastPos=%.5f\n",
+		fmt.Printf("[SINC_DEBUG] sincVariProcess: EXIT - data.OutGen=%d, data.InUsed=%d, state.lastPos=%.5f\n",
 			data.OutputFramesGen, data.InputFramesUsed, state.lastPosition)
 	}
 
@@ -1663,975 +1740,4 @@ func sincMonoVariProcess(state *srcState, data *SrcData) ErrorCode {
 	return state.errCode
 }
 
-// sincStereoVariProcess handles stereo data with potentially varying sample rate ratio.
-// Corresponds to sinc_stereo_vari_process in src_sinc.c
-func sincStereoVariProcess(state *srcState, data *SrcData) ErrorCode {
-	if sincDebugEnabled {
-		fmt.Printf("\n[SINC_DEBUG] sincStereoVariProcess: ENTRY - data.InFrames=%d, data.OutFrames=%d, data.SrcRatio=%.5f, data.EOF=%t\n",
-			data.InputFrames, data.OutputFrames, data.SrcRatio, data.EndOfInput)
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: State - lastRatio=%.5f, lastPos=%.5f\n", state.lastRatio, state.lastPosition)
-	}
-
-	filter, ok := state.privateData.(*sincFilter)
-	if !ok || filter == nil {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: Invalid private data.\n")
-		}
-		return ErrBadState
-	}
-	if state.channels != 2 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: Incorrect channel count (%d).\n", state.channels)
-		}
-		return ErrBadInternalState
-	}
-	inputIndex := state.lastPosition
-	srcRatio := state.lastRatio
-	var increment, startFilterIndex incrementT
-	var halfFilterChanLen, samplesInHand int
-	outCountSamples := data.OutputFrames * int64(state.channels) // Total samples to generate
-	data.InputFramesUsed = 0                                     // Reset before processing
-	data.OutputFramesGen = 0                                     // Reset before processing
-	var inUsedSamples int64 = 0                                  // Tracks samples consumed by prepareData
-	var outGenSamples int64 = 0                                  // Tracks samples generated
-
-	// Initialize srcRatio if needed
-	if isBadSrcRatio(srcRatio) {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Initializing srcRatio from data.SrcRatio (%.5f)\n", data.SrcRatio)
-		}
-		if isBadSrcRatio(data.SrcRatio) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: Bad initial srcRatio from data.\n")
-			}
-			return ErrBadSrcRatio
-		}
-		srcRatio = data.SrcRatio
-		// state.lastRatio = srcRatio // Use local srcRatio for consistency check below
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Effective srcRatio for start = %.5f\n", srcRatio)
-	}
-
-	// Calculate required lookback/lookahead
-	filterCoeffsLen := float64(filter.coeffHalfLen + 2)
-	if filter.indexInc <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: Bad filter.indexInc (%d).\n", filter.indexInc)
-		}
-		return ErrBadInternalState
-	}
-	count := filterCoeffsLen / float64(filter.indexInc)
-	effectiveMinRatio := srcRatio
-	if !isBadSrcRatio(state.lastRatio) {
-		effectiveMinRatio = minFloat64(state.lastRatio, srcRatio)
-	}
-	if effectiveMinRatio < (1.0 / srcMaxRatio) {
-		effectiveMinRatio = 1.0 / srcMaxRatio
-	}
-	if effectiveMinRatio < 1.0 && effectiveMinRatio > 1e-10 {
-		count /= effectiveMinRatio
-	} else if effectiveMinRatio <= 1e-10 {
-		count *= srcMaxRatio
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: WARNING: Very small minRatio (%.5f), using large lookback factor.\n", effectiveMinRatio)
-		}
-	}
-	halfFilterChanLen = state.channels * (psfLrint(count) + 1)
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Calculated halfFilterChanLen = %d\n", halfFilterChanLen)
-	}
-
-	// Advance internal buffer pointer
-	intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
-	if filter.bLen <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: Bad filter.bLen (%d).\n", filter.bLen)
-		}
-		return ErrBadInternalState
-	}
-	newBCurrent := (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-	if newBCurrent < 0 {
-		newBCurrent += filter.bLen
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Advancing bCurrent by %d samples from %d to %d (modulo %d).\n", state.channels*intInputAdvance, filter.bCurrent, newBCurrent, filter.bLen)
-	}
-	filter.bCurrent = newBCurrent
-	inputIndex = fmodOne(inputIndex)
-
-	// Main processing loop
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Starting main loop. Target output samples = %d\n", outCountSamples)
-	}
-	for outGenSamples < outCountSamples {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Loop Iteration %d. outGenSamples=%d\n", outGenSamples/int64(state.channels), outGenSamples)
-		}
-
-		// Calculate samples available
-		if filter.bEnd >= filter.bCurrent {
-			samplesInHand = filter.bEnd - filter.bCurrent
-		} else {
-			samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-		}
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: samplesInHand=%d. Needed=%d\n", samplesInHand, halfFilterChanLen)
-		}
-
-		// Need more data?
-		if samplesInHand <= halfFilterChanLen {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: samplesInHand <= halfFilterChanLen. Calling prepareData.\n")
-			}
-			data.InputFramesUsed = inUsedSamples / int64(state.channels) // Update before call
-			errCode := prepareData(filter, state.channels, data, halfFilterChanLen)
-			if errCode != ErrNoError {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: prepareData returned error: %d\n", errCode)
-				}
-				state.errCode = errCode
-				return errCode
-			}
-			inUsedSamples = data.InputFramesUsed * int64(state.channels) // Update after call
-
-			// Recalculate samplesInHand
-			if filter.bEnd >= filter.bCurrent {
-				samplesInHand = filter.bEnd - filter.bCurrent
-			} else {
-				samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-			}
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: After prepareData: samplesInHand=%d, inUsedSamples=%d (data.InputFramesUsed=%d)\n", samplesInHand, inUsedSamples, data.InputFramesUsed)
-			}
-
-			// Break if still not enough
-			if samplesInHand <= halfFilterChanLen {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: samplesInHand *still* <= halfFilterChanLen (%d <= %d). Breaking loop.\n", samplesInHand, halfFilterChanLen)
-				}
-				break
-			}
-		}
-
-		// Check EOF condition
-		if filter.bRealEnd >= 0 {
-			terminate := 1.0/srcRatio + 1e-20                                  // Use current loop's srcRatio
-			checkPosition := float64(filter.bCurrent) + inputIndex + terminate // Approximate position needed for next sample
-
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] ... EOF Check: bRealEnd=%d, checkPosition(curr+idx+1/ratio)=%.2f\n", filter.bRealEnd, checkPosition)
-			}
-			if checkPosition >= float64(filter.bRealEnd) {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] ... Breaking loop due to EOF check (C logic).\n")
-				}
-				break // Break loop if EOF reached
-			}
-		}
-		// Vary ratio if needed
-		if outCountSamples > 0 && math.Abs(state.lastRatio-data.SrcRatio) > srcMinRatioDiff {
-			srcRatio = state.lastRatio + float64(outGenSamples)*(data.SrcRatio-state.lastRatio)/float64(outCountSamples)
-			if isBadSrcRatio(srcRatio) {
-				if srcRatio < 1.0/srcMaxRatio {
-					srcRatio = 1.0 / srcMaxRatio
-				}
-				if srcRatio > srcMaxRatio {
-					srcRatio = srcMaxRatio
-				}
-			}
-		}
-
-		// Calculate parameters for calcOutputStereo
-		floatIncrement := float64(filter.indexInc) * minFloat64(srcRatio, 1.0)
-		increment = doubleToFP(floatIncrement)
-		if increment == 0 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: Calculated increment is zero (srcRatio=%.15f, floatInc=%.15f).\n", srcRatio, floatIncrement)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		startFilterIndex = doubleToFP(inputIndex * floatIncrement)
-		scaleFactor := floatIncrement / float64(filter.indexInc)
-
-		// Get output slice
-		outPos := int(outGenSamples)
-		if outPos+state.channels > len(data.DataOut) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: WARNING: Output buffer full (outPos=%d, channels=%d, len=%d). Breaking loop.\n", outPos, state.channels, len(data.DataOut))
-			}
-			break
-		}
-		outputSlice := data.DataOut[outPos : outPos+state.channels]
-
-		// Calculate the output frame (stereo pair)
-		calcOutputStereo(filter, state.channels, increment, startFilterIndex, scaleFactor, outputSlice)
-		outGenSamples += int64(state.channels) // Increment by number of channels
-
-		// fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Generated frame %d = [%.5f, %.5f]\n", outGenSamples/int64(state.channels), outputSlice[0], outputSlice[1])
-
-		// Update input index
-		if srcRatio <= 1e-10 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: ERROR: srcRatio is zero or very small (%.15f), cannot advance input index.\n", srcRatio)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		inputIndex += 1.0 / srcRatio
-
-		// Advance buffer pointer
-		intInputAdvance = psfLrint(inputIndex - fmodOne(inputIndex))
-		newBCurrent = (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-		if newBCurrent < 0 {
-			newBCurrent += filter.bLen
-		}
-		// fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Advanced inputIndex to %.5f, intInputAdvance=%d, moving bCurrent from %d to %d.\n", inputIndex, intInputAdvance, filter.bCurrent, newBCurrent)
-		filter.bCurrent = newBCurrent
-		inputIndex = fmodOne(inputIndex)
-
-	} // End main processing loop
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: Exited main loop.\n")
-	}
-
-	// Store final state
-	state.lastPosition = inputIndex
-	state.lastRatio = srcRatio
-	data.OutputFramesGen = outGenSamples / int64(state.channels)
-	data.InputFramesUsed = inUsedSamples / int64(state.channels) // Ensure this reflects total consumed
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincStereoVariProcess: EXIT - data.OutGen=%d, data.InUsed=%d, state.lastPos=%.5f\n", data.OutputFramesGen, data.InputFramesUsed, state.lastPosition)
-	}
-
-	if state.errCode == ErrNoError {
-		return ErrNoError
-	}
-	return state.errCode
-}
-
-// sincQuadVariProcess handles quad audio data with potentially varying sample rate ratio.
-// Corresponds to sinc_quad_vari_process in src_sinc.c
-func sincQuadVariProcess(state *srcState, data *SrcData) ErrorCode {
-	if sincDebugEnabled {
-		fmt.Printf("\n[SINC_DEBUG] sincQuadVariProcess: ENTRY - data.InFrames=%d, data.OutFrames=%d, data.SrcRatio=%.5f, data.EOF=%t\n",
-			data.InputFrames, data.OutputFrames, data.SrcRatio, data.EndOfInput)
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: State - lastRatio=%.5f, lastPos=%.5f\n", state.lastRatio, state.lastPosition)
-	}
-
-	filter, ok := state.privateData.(*sincFilter)
-	if !ok || filter == nil {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: Invalid private data.\n")
-		}
-		return ErrBadState
-	}
-	if state.channels != 4 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: Incorrect channel count (%d).\n", state.channels)
-		}
-		return ErrBadInternalState
-	}
-	inputIndex := state.lastPosition
-	srcRatio := state.lastRatio
-	var increment, startFilterIndex incrementT
-	var halfFilterChanLen, samplesInHand int
-	outCountSamples := data.OutputFrames * int64(state.channels)
-	data.InputFramesUsed = 0
-	data.OutputFramesGen = 0
-	var inUsedSamples int64 = 0
-	var outGenSamples int64 = 0
-
-	// Init ratio
-	if isBadSrcRatio(srcRatio) {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Initializing srcRatio from data.SrcRatio (%.5f)\n", data.SrcRatio)
-		}
-		if isBadSrcRatio(data.SrcRatio) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: Bad initial srcRatio from data.\n")
-			}
-			return ErrBadSrcRatio
-		}
-		srcRatio = data.SrcRatio
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Effective srcRatio for start = %.5f\n", srcRatio)
-	}
-
-	// Calc lookback/ahead
-	filterCoeffsLen := float64(filter.coeffHalfLen + 2)
-	if filter.indexInc <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: Bad filter.indexInc (%d).\n", filter.indexInc)
-		}
-		return ErrBadInternalState
-	}
-	count := filterCoeffsLen / float64(filter.indexInc)
-	effectiveMinRatio := srcRatio
-	if !isBadSrcRatio(state.lastRatio) {
-		effectiveMinRatio = minFloat64(state.lastRatio, srcRatio)
-	}
-	if effectiveMinRatio < (1.0 / srcMaxRatio) {
-		effectiveMinRatio = 1.0 / srcMaxRatio
-	}
-	if effectiveMinRatio < 1.0 && effectiveMinRatio > 1e-10 {
-		count /= effectiveMinRatio
-	} else if effectiveMinRatio <= 1e-10 {
-		count *= srcMaxRatio
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: WARNING: Very small minRatio (%.5f), using large lookback factor.\n", effectiveMinRatio)
-		}
-	}
-	halfFilterChanLen = state.channels * (psfLrint(count) + 1)
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Calculated halfFilterChanLen = %d\n", halfFilterChanLen)
-	}
-
-	// Advance buffer ptr
-	intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
-	if filter.bLen <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: Bad filter.bLen (%d).\n", filter.bLen)
-		}
-		return ErrBadInternalState
-	}
-	newBCurrent := (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-	if newBCurrent < 0 {
-		newBCurrent += filter.bLen
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Advancing bCurrent by %d samples from %d to %d (modulo %d).\n", state.channels*intInputAdvance, filter.bCurrent, newBCurrent, filter.bLen)
-	}
-	filter.bCurrent = newBCurrent
-	inputIndex = fmodOne(inputIndex)
-
-	// Main loop
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Starting main loop. Target output samples = %d\n", outCountSamples)
-	}
-	for outGenSamples < outCountSamples {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Loop Iteration %d. outGenSamples=%d\n", outGenSamples/int64(state.channels), outGenSamples)
-		}
-
-		// Samples available
-		if filter.bEnd >= filter.bCurrent {
-			samplesInHand = filter.bEnd - filter.bCurrent
-		} else {
-			samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-		}
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: samplesInHand=%d. Needed=%d\n", samplesInHand, halfFilterChanLen)
-		}
-
-		// Need more?
-		if samplesInHand <= halfFilterChanLen {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: samplesInHand <= halfFilterChanLen. Calling prepareData.\n")
-			}
-			data.InputFramesUsed = inUsedSamples / int64(state.channels)
-			errCode := prepareData(filter, state.channels, data, halfFilterChanLen)
-			if errCode != ErrNoError {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: prepareData returned error: %d\n", errCode)
-				}
-				state.errCode = errCode
-				return errCode
-			}
-			inUsedSamples = data.InputFramesUsed * int64(state.channels)
-			if filter.bEnd >= filter.bCurrent {
-				samplesInHand = filter.bEnd - filter.bCurrent
-			} else {
-				samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-			}
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: After prepareData: samplesInHand=%d, inUsedSamples=%d (data.InputFramesUsed=%d)\n", samplesInHand, inUsedSamples, data.InputFramesUsed)
-			}
-			if samplesInHand <= halfFilterChanLen {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: samplesInHand *still* <= halfFilterChanLen (%d <= %d). Breaking loop.\n", samplesInHand, halfFilterChanLen)
-				}
-				break
-			}
-		}
-
-		// Check EOF
-		if filter.bRealEnd >= 0 {
-			terminate := 1.0/srcRatio + 1e-20                                  // Use current loop's srcRatio
-			checkPosition := float64(filter.bCurrent) + inputIndex + terminate // Approximate position needed for next sample
-
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] ... EOF Check: bRealEnd=%d, checkPosition(curr+idx+1/ratio)=%.2f\n", filter.bRealEnd, checkPosition)
-			}
-			if checkPosition >= float64(filter.bRealEnd) {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] ... Breaking loop due to EOF check (C logic).\n")
-				}
-				break // Break loop if EOF reached
-			}
-		}
-		// Vary ratio
-		if outCountSamples > 0 && math.Abs(state.lastRatio-data.SrcRatio) > srcMinRatioDiff {
-			srcRatio = state.lastRatio + float64(outGenSamples)*(data.SrcRatio-state.lastRatio)/float64(outCountSamples)
-			if isBadSrcRatio(srcRatio) {
-				if srcRatio < 1.0/srcMaxRatio {
-					srcRatio = 1.0 / srcMaxRatio
-				}
-				if srcRatio > srcMaxRatio {
-					srcRatio = srcMaxRatio
-				}
-			}
-		}
-
-		// Calc params
-		floatIncrement := float64(filter.indexInc) * minFloat64(srcRatio, 1.0)
-		increment = doubleToFP(floatIncrement)
-		if increment == 0 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: Calculated increment is zero (srcRatio=%.15f, floatInc=%.15f).\n", srcRatio, floatIncrement)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		startFilterIndex = doubleToFP(inputIndex * floatIncrement)
-		scaleFactor := floatIncrement / float64(filter.indexInc)
-
-		// Get output slice
-		outPos := int(outGenSamples)
-		if outPos+state.channels > len(data.DataOut) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: WARNING: Output buffer full (outPos=%d, channels=%d, len=%d). Breaking loop.\n", outPos, state.channels, len(data.DataOut))
-			}
-			break
-		}
-		outputSlice := data.DataOut[outPos : outPos+state.channels]
-
-		// Calc output frame
-		calcOutputQuad(filter, state.channels, increment, startFilterIndex, scaleFactor, outputSlice)
-		outGenSamples += int64(state.channels)
-
-		// Update input index
-		if srcRatio <= 1e-10 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: ERROR: srcRatio is zero or very small (%.15f), cannot advance input index.\n", srcRatio)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		inputIndex += 1.0 / srcRatio
-
-		// Advance buffer pointer
-		intInputAdvance = psfLrint(inputIndex - fmodOne(inputIndex))
-		newBCurrent = (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-		if newBCurrent < 0 {
-			newBCurrent += filter.bLen
-		}
-		filter.bCurrent = newBCurrent
-		inputIndex = fmodOne(inputIndex)
-
-	} // End main loop
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: Exited main loop.\n")
-	}
-
-	// Store final state
-	state.lastPosition = inputIndex
-	state.lastRatio = srcRatio
-	data.OutputFramesGen = outGenSamples / int64(state.channels)
-	data.InputFramesUsed = inUsedSamples / int64(state.channels)
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincQuadVariProcess: EXIT - data.OutGen=%d, data.InUsed=%d, state.lastPos=%.5f\n", data.OutputFramesGen, data.InputFramesUsed, state.lastPosition)
-	}
-
-	if state.errCode == ErrNoError {
-		return ErrNoError
-	}
-	return state.errCode
-}
-
-// sincHexVariProcess handles 6-channel audio data with potentially varying sample rate ratio.
-// Corresponds to sinc_hex_vari_process in src_sinc.c
-func sincHexVariProcess(state *srcState, data *SrcData) ErrorCode {
-	if sincDebugEnabled {
-		fmt.Printf("\n[SINC_DEBUG] sincHexVariProcess: ENTRY - data.InFrames=%d, data.OutFrames=%d, data.SrcRatio=%.5f, data.EOF=%t\n",
-			data.InputFrames, data.OutputFrames, data.SrcRatio, data.EndOfInput)
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: State - lastRatio=%.5f, lastPos=%.5f\n", state.lastRatio, state.lastPosition)
-	}
-
-	filter, ok := state.privateData.(*sincFilter)
-	if !ok || filter == nil {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: Invalid private data.\n")
-		}
-		return ErrBadState
-	}
-	if state.channels != 6 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: Incorrect channel count (%d).\n", state.channels)
-		}
-		return ErrBadInternalState
-	}
-	inputIndex := state.lastPosition
-	srcRatio := state.lastRatio
-	var increment, startFilterIndex incrementT
-	var halfFilterChanLen, samplesInHand int
-	outCountSamples := data.OutputFrames * int64(state.channels)
-	data.InputFramesUsed = 0
-	data.OutputFramesGen = 0
-	var inUsedSamples int64 = 0
-	var outGenSamples int64 = 0
-
-	// Init ratio
-	if isBadSrcRatio(srcRatio) {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Initializing srcRatio from data.SrcRatio (%.5f)\n", data.SrcRatio)
-		}
-		if isBadSrcRatio(data.SrcRatio) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: Bad initial srcRatio from data.\n")
-			}
-			return ErrBadSrcRatio
-		}
-		srcRatio = data.SrcRatio
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Effective srcRatio for start = %.5f\n", srcRatio)
-	}
-
-	// Calc lookback/ahead
-	filterCoeffsLen := float64(filter.coeffHalfLen + 2)
-	if filter.indexInc <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: Bad filter.indexInc (%d).\n", filter.indexInc)
-		}
-		return ErrBadInternalState
-	}
-	count := filterCoeffsLen / float64(filter.indexInc)
-	effectiveMinRatio := srcRatio
-	if !isBadSrcRatio(state.lastRatio) {
-		effectiveMinRatio = minFloat64(state.lastRatio, srcRatio)
-	}
-	if effectiveMinRatio < (1.0 / srcMaxRatio) {
-		effectiveMinRatio = 1.0 / srcMaxRatio
-	}
-	if effectiveMinRatio < 1.0 && effectiveMinRatio > 1e-10 {
-		count /= effectiveMinRatio
-	} else if effectiveMinRatio <= 1e-10 {
-		count *= srcMaxRatio
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: WARNING: Very small minRatio (%.5f), using large lookback factor.\n", effectiveMinRatio)
-		}
-	}
-	halfFilterChanLen = state.channels * (psfLrint(count) + 1)
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Calculated halfFilterChanLen = %d\n", halfFilterChanLen)
-	}
-
-	// Advance buffer ptr
-	intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
-	if filter.bLen <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: Bad filter.bLen (%d).\n", filter.bLen)
-		}
-		return ErrBadInternalState
-	}
-	newBCurrent := (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-	if newBCurrent < 0 {
-		newBCurrent += filter.bLen
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Advancing bCurrent by %d samples from %d to %d (modulo %d).\n", state.channels*intInputAdvance, filter.bCurrent, newBCurrent, filter.bLen)
-	}
-	filter.bCurrent = newBCurrent
-	inputIndex = fmodOne(inputIndex)
-
-	// Main loop
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Starting main loop. Target output samples = %d\n", outCountSamples)
-	}
-	for outGenSamples < outCountSamples {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Loop Iteration %d. outGenSamples=%d\n", outGenSamples/int64(state.channels), outGenSamples)
-		}
-
-		// Samples available
-		if filter.bEnd >= filter.bCurrent {
-			samplesInHand = filter.bEnd - filter.bCurrent
-		} else {
-			samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-		}
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincHexVariProcess: samplesInHand=%d. Needed=%d\n", samplesInHand, halfFilterChanLen)
-		}
-
-		// Need more?
-		if samplesInHand <= halfFilterChanLen {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincHexVariProcess: samplesInHand <= halfFilterChanLen. Calling prepareData.\n")
-			}
-			data.InputFramesUsed = inUsedSamples / int64(state.channels)
-			errCode := prepareData(filter, state.channels, data, halfFilterChanLen)
-			if errCode != ErrNoError {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincHexVariProcess: prepareData returned error: %d\n", errCode)
-				}
-				state.errCode = errCode
-				return errCode
-			}
-			inUsedSamples = data.InputFramesUsed * int64(state.channels)
-			if filter.bEnd >= filter.bCurrent {
-				samplesInHand = filter.bEnd - filter.bCurrent
-			} else {
-				samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-			}
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincHexVariProcess: After prepareData: samplesInHand=%d, inUsedSamples=%d (data.InputFramesUsed=%d)\n", samplesInHand, inUsedSamples, data.InputFramesUsed)
-			}
-			if samplesInHand <= halfFilterChanLen {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincHexVariProcess: samplesInHand *still* <= halfFilterChanLen (%d <= %d). Breaking loop.\n", samplesInHand, halfFilterChanLen)
-				}
-				break
-			}
-		}
-
-		// Check EOF
-		if filter.bRealEnd >= 0 {
-			terminate := 1.0/srcRatio + 1e-20                                  // Use current loop's srcRatio
-			checkPosition := float64(filter.bCurrent) + inputIndex + terminate // Approximate position needed for next sample
-
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] ... EOF Check: bRealEnd=%d, checkPosition(curr+idx+1/ratio)=%.2f\n", filter.bRealEnd, checkPosition)
-			}
-			if checkPosition >= float64(filter.bRealEnd) {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] ... Breaking loop due to EOF check (C logic).\n")
-				}
-				break // Break loop if EOF reached
-			}
-		}
-		// Vary ratio
-		if outCountSamples > 0 && math.Abs(state.lastRatio-data.SrcRatio) > srcMinRatioDiff {
-			srcRatio = state.lastRatio + float64(outGenSamples)*(data.SrcRatio-state.lastRatio)/float64(outCountSamples)
-			if isBadSrcRatio(srcRatio) {
-				if srcRatio < 1.0/srcMaxRatio {
-					srcRatio = 1.0 / srcMaxRatio
-				}
-				if srcRatio > srcMaxRatio {
-					srcRatio = srcMaxRatio
-				}
-			}
-		}
-
-		// Calc params
-		floatIncrement := float64(filter.indexInc) * minFloat64(srcRatio, 1.0)
-		increment = doubleToFP(floatIncrement)
-		if increment == 0 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: Calculated increment is zero (srcRatio=%.15f, floatInc=%.15f).\n", srcRatio, floatIncrement)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		startFilterIndex = doubleToFP(inputIndex * floatIncrement)
-		scaleFactor := floatIncrement / float64(filter.indexInc)
-
-		// Get output slice
-		outPos := int(outGenSamples)
-		if outPos+state.channels > len(data.DataOut) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincHexVariProcess: WARNING: Output buffer full (outPos=%d, channels=%d, len=%d). Breaking loop.\n", outPos, state.channels, len(data.DataOut))
-			}
-			break
-		}
-		outputSlice := data.DataOut[outPos : outPos+state.channels]
-
-		// Calc output frame
-		calcOutputHex(filter, state.channels, increment, startFilterIndex, scaleFactor, outputSlice)
-		outGenSamples += int64(state.channels)
-
-		// Update input index
-		if srcRatio <= 1e-10 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincHexVariProcess: ERROR: srcRatio is zero or very small (%.15f), cannot advance input index.\n", srcRatio)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		inputIndex += 1.0 / srcRatio
-
-		// Advance buffer pointer
-		intInputAdvance = psfLrint(inputIndex - fmodOne(inputIndex))
-		newBCurrent = (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-		if newBCurrent < 0 {
-			newBCurrent += filter.bLen
-		}
-		filter.bCurrent = newBCurrent
-		inputIndex = fmodOne(inputIndex)
-
-	} // End main loop
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: Exited main loop.\n")
-	}
-
-	// Store final state
-	state.lastPosition = inputIndex
-	state.lastRatio = srcRatio
-	data.OutputFramesGen = outGenSamples / int64(state.channels)
-	data.InputFramesUsed = inUsedSamples / int64(state.channels)
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincHexVariProcess: EXIT - data.OutGen=%d, data.InUsed=%d, state.lastPos=%.5f\n", data.OutputFramesGen, data.InputFramesUsed, state.lastPosition)
-	}
-
-	if state.errCode == ErrNoError {
-		return ErrNoError
-	}
-	return state.errCode
-}
-
-// sincMultichanVariProcess handles generic multi-channel audio data.
-// Corresponds to sinc_multichan_vari_process in src_sinc.c
-func sincMultichanVariProcess(state *srcState, data *SrcData) ErrorCode {
-	if sincDebugEnabled {
-		fmt.Printf("\n[SINC_DEBUG] sincMultichanVariProcess: ENTRY - data.InFrames=%d, data.OutFrames=%d, data.SrcRatio=%.5f, data.EOF=%t\n",
-			data.InputFrames, data.OutputFrames, data.SrcRatio, data.EndOfInput)
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: State - chans=%d, lastRatio=%.5f, lastPos=%.5f\n", state.channels, state.lastRatio, state.lastPosition)
-	}
-
-	filter, ok := state.privateData.(*sincFilter)
-	if !ok || filter == nil {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: ERROR: Invalid private data.\n")
-		}
-		return ErrBadState
-	}
-	// No specific channel check here
-	inputIndex := state.lastPosition
-	srcRatio := state.lastRatio
-	var increment, startFilterIndex incrementT
-	var halfFilterChanLen, samplesInHand int
-	outCountSamples := data.OutputFrames * int64(state.channels)
-	data.InputFramesUsed = 0
-	data.OutputFramesGen = 0
-	var inUsedSamples int64 = 0
-	var outGenSamples int64 = 0
-
-	// Init ratio
-	if isBadSrcRatio(srcRatio) {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Initializing srcRatio from data.SrcRatio (%.5f)\n", data.SrcRatio)
-		}
-		if isBadSrcRatio(data.SrcRatio) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: ERROR: Bad initial srcRatio from data.\n")
-			}
-			return ErrBadSrcRatio
-		}
-		srcRatio = data.SrcRatio
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Effective srcRatio for start = %.5f\n", srcRatio)
-	}
-
-	// Calc lookback/ahead
-	filterCoeffsLen := float64(filter.coeffHalfLen + 2)
-	if filter.indexInc <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: ERROR: Bad filter.indexInc (%d).\n", filter.indexInc)
-		}
-		return ErrBadInternalState
-	}
-	count := filterCoeffsLen / float64(filter.indexInc)
-	effectiveMinRatio := srcRatio
-	if !isBadSrcRatio(state.lastRatio) {
-		effectiveMinRatio = minFloat64(state.lastRatio, srcRatio)
-	}
-	if effectiveMinRatio < (1.0 / srcMaxRatio) {
-		effectiveMinRatio = 1.0 / srcMaxRatio
-	}
-	if effectiveMinRatio < 1.0 && effectiveMinRatio > 1e-10 {
-		count /= effectiveMinRatio
-	} else if effectiveMinRatio <= 1e-10 {
-		count *= srcMaxRatio
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: WARNING: Very small minRatio (%.5f), using large lookback factor.\n", effectiveMinRatio)
-		}
-	}
-	halfFilterChanLen = state.channels * (psfLrint(count) + 1)
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Calculated halfFilterChanLen = %d\n", halfFilterChanLen)
-	}
-
-	// Advance buffer ptr
-	intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
-	if filter.bLen <= 0 {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: ERROR: Bad filter.bLen (%d).\n", filter.bLen)
-		}
-		return ErrBadInternalState
-	}
-	newBCurrent := (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-	if newBCurrent < 0 {
-		newBCurrent += filter.bLen
-	}
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Advancing bCurrent by %d samples from %d to %d (modulo %d).\n", state.channels*intInputAdvance, filter.bCurrent, newBCurrent, filter.bLen)
-	}
-	filter.bCurrent = newBCurrent
-	inputIndex = fmodOne(inputIndex)
-
-	// Main loop
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Starting main loop. Target output samples = %d\n", outCountSamples)
-	}
-	for outGenSamples < outCountSamples {
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Loop Iteration %d. outGenSamples=%d\n", outGenSamples/int64(state.channels), outGenSamples)
-		}
-
-		// Samples available
-		if filter.bEnd >= filter.bCurrent {
-			samplesInHand = filter.bEnd - filter.bCurrent
-		} else {
-			samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-		}
-		if sincDebugEnabled {
-			fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: samplesInHand=%d. Needed=%d\n", samplesInHand, halfFilterChanLen)
-		}
-
-		// Need more?
-		if samplesInHand <= halfFilterChanLen {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: samplesInHand <= halfFilterChanLen. Calling prepareData.\n")
-			}
-			data.InputFramesUsed = inUsedSamples / int64(state.channels)
-			errCode := prepareData(filter, state.channels, data, halfFilterChanLen)
-			if errCode != ErrNoError {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: prepareData returned error: %d\n", errCode)
-				}
-				state.errCode = errCode
-				return errCode
-			}
-			inUsedSamples = data.InputFramesUsed * int64(state.channels)
-			if filter.bEnd >= filter.bCurrent {
-				samplesInHand = filter.bEnd - filter.bCurrent
-			} else {
-				samplesInHand = (filter.bEnd + filter.bLen) - filter.bCurrent
-			}
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: After prepareData: samplesInHand=%d, inUsedSamples=%d (data.InputFramesUsed=%d)\n", samplesInHand, inUsedSamples, data.InputFramesUsed)
-			}
-			if samplesInHand <= halfFilterChanLen {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: samplesInHand *still* <= halfFilterChanLen (%d <= %d). Breaking loop.\n", samplesInHand, halfFilterChanLen)
-				}
-				break
-			}
-		}
-
-		// Check EOF
-		if filter.bRealEnd >= 0 {
-			terminate := 1.0/srcRatio + 1e-20                                  // Use current loop's srcRatio
-			checkPosition := float64(filter.bCurrent) + inputIndex + terminate // Approximate position needed for next sample
-
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] ... EOF Check: bRealEnd=%d, checkPosition(curr+idx+1/ratio)=%.2f\n", filter.bRealEnd, checkPosition)
-			}
-			if checkPosition >= float64(filter.bRealEnd) {
-				if sincDebugEnabled {
-					fmt.Printf("[SINC_DEBUG] ... Breaking loop due to EOF check (C logic).\n")
-				}
-				break // Break loop if EOF reached
-			}
-		}
-
-		// Vary ratio
-		if outCountSamples > 0 && math.Abs(state.lastRatio-data.SrcRatio) > srcMinRatioDiff {
-			srcRatio = state.lastRatio + float64(outGenSamples)*(data.SrcRatio-state.lastRatio)/float64(outCountSamples)
-			if isBadSrcRatio(srcRatio) {
-				if srcRatio < 1.0/srcMaxRatio {
-					srcRatio = 1.0 / srcMaxRatio
-				}
-				if srcRatio > srcMaxRatio {
-					srcRatio = srcMaxRatio
-				}
-			}
-		}
-
-		// Calc params
-		floatIncrement := float64(filter.indexInc) * minFloat64(srcRatio, 1.0)
-		increment = doubleToFP(floatIncrement)
-		if increment == 0 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: ERROR: Calculated increment is zero (srcRatio=%.15f, floatInc=%.15f).\n", srcRatio, floatIncrement)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		startFilterIndex = doubleToFP(inputIndex * floatIncrement)
-		scaleFactor := floatIncrement / float64(filter.indexInc)
-
-		// Get output slice
-		outPos := int(outGenSamples)
-		if outPos+state.channels > len(data.DataOut) {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: WARNING: Output buffer full (outPos=%d, channels=%d, len=%d). Breaking loop.\n", outPos, state.channels, len(data.DataOut))
-			}
-			break
-		}
-		outputSlice := data.DataOut[outPos : outPos+state.channels]
-
-		calcOutputMulti(filter, state.channels, increment, startFilterIndex, scaleFactor, outputSlice)
-		outGenSamples += int64(state.channels)
-
-		// Update input index
-		if srcRatio <= 1e-10 {
-			if sincDebugEnabled {
-				fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: ERROR: srcRatio is zero or very small (%.15f), cannot advance input index.\n", srcRatio)
-			}
-			state.errCode = ErrBadSrcRatio
-			return state.errCode
-		}
-		inputIndex += 1.0 / srcRatio
-
-		// Advance buffer pointer
-		intInputAdvance = psfLrint(inputIndex - fmodOne(inputIndex))
-		newBCurrent = (filter.bCurrent + state.channels*intInputAdvance) % filter.bLen
-		if newBCurrent < 0 {
-			newBCurrent += filter.bLen
-		}
-		filter.bCurrent = newBCurrent
-		inputIndex = fmodOne(inputIndex)
-
-	} // End main loop
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: Exited main loop.\n")
-	}
-
-	// Store final state
-	state.lastPosition = inputIndex
-	state.lastRatio = srcRatio
-	data.OutputFramesGen = outGenSamples / int64(state.channels)
-	data.InputFramesUsed = inUsedSamples / int64(state.channels) // Ensure this reflects total consumed
-
-	if sincDebugEnabled {
-		fmt.Printf("[SINC_DEBUG] sincMultichanVariProcess: EXIT - data.OutGen=%d, data.InUsed=%d, state.lastPos=%.5f\n", data.OutputFramesGen, data.InputFramesUsed, state.lastPosition)
-	}
-
-	if state.errCode == ErrNoError {
-		return ErrNoError
-	}
-	return state.errCode
-}
-
 // --- End of Sinc Processing Functions ---
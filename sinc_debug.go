@@ -0,0 +1,16 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+//go:build !realtime
+
+package libsamplerate
+
+import "os"
+
+// Check environment variable ONCE at package initialization
+var sincDebugEnabled = (os.Getenv("SINC_DEBUG") == "1")
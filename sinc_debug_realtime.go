@@ -0,0 +1,21 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+//go:build realtime
+
+package libsamplerate
+
+// Building with -tags realtime, for hosts that run Process on an audio callback
+// thread (e.g. through cgo), makes sincDebugEnabled a false constant: the
+// compiler drops the SINC_DEBUG prints of the processing path with their fmt
+// calls, and the package no longer reads the environment. Process then makes no
+// system calls and takes no locks; TestProcessDoesNotAllocate checks that, once
+// its buffers have grown to the block size, it does not allocate either, so it
+// never waits for the garbage collector. A metrics collector, progress hook or
+// the Options callbacks run inside Process and must keep to the same rules.
+const sincDebugEnabled = false
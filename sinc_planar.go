@@ -0,0 +1,159 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "fmt"
+
+// sincPlanarMinChannels is the channel count from which sinc converters use the
+// planar kernel instead of calcOutputMulti: BenchmarkSincPlanar shows it 1.6 to
+// 1.75 times faster for 8 and 16 channels at 96kHz to 48kHz. The mirror it
+// keeps doubles the buffer memory, so fewer channels stay interleaved.
+const sincPlanarMinChannels = 8
+
+var sincPlanarStateVT = srcStateVT{
+	variProcess:  sincPlanarVariProcess,
+	constProcess: sincPlanarVariProcess,
+	reset:        sincReset,
+	copy:         sincCopy,
+	close:        sincClose,
+}
+
+// sincPlanarVariProcess handles many channels with potentially varying sample
+// rate ratio, with the planar kernel.
+func sincPlanarVariProcess(state *srcState, data *SrcData) ErrorCode {
+	return sincVariProcess(state, data, 0, calcOutputPlanar)
+}
+
+// syncPlanar deinterleaves the samples loaded into the buffer since the last
+// call into the planar mirror, channel-major: channel ch of buffer frame f is
+// at planar[ch*stride+f]. The samples calcOutputMulti reads as silence, those
+// of an incomplete last frame after bEnd and those after bRealEnd, are zero.
+func (filter *sincFilter) syncPlanar(channels int) {
+	stride := (int64(len(filter.buffer)) + int64(channels) - 1) / int64(channels)
+	if int64(len(filter.planar)) != stride*int64(channels) {
+		filter.planar = make([]float32, stride*int64(channels))
+		filter.planarValid = 0
+	}
+	if filter.planarValid >= filter.bEnd {
+		return
+	}
+	end := filter.bEnd
+	if filter.bRealEnd >= 0 && filter.bRealEnd < end {
+		end = maxInt64(filter.bRealEnd, filter.planarValid)
+	}
+	frame, ch := filter.planarValid/int64(channels), int(filter.planarValid%int64(channels))
+	for _, v := range filter.buffer[filter.planarValid:end] {
+		filter.planar[int64(ch)*stride+frame] = v
+		if ch++; ch == channels {
+			ch = 0
+			frame++
+		}
+	}
+	for idx := end; idx < filter.bEnd || ch != 0; idx++ {
+		filter.planar[int64(ch)*stride+frame] = 0
+		if ch++; ch == channels {
+			ch = 0
+			frame++
+		}
+	}
+	filter.planarValid = filter.bEnd
+}
+
+// calcOutputPlanar is calcOutputMulti working channel by channel on the planar
+// mirror of the buffer: it computes the interpolated coefficients of both
+// halves of the filter once, then takes each channel's dot product with them
+// over contiguous history, instead of striding across the interleaved frames
+// with a bounds check per sample. The result is the same to the bit.
+func calcOutputPlanar(filter *sincFilter, channels int, increment, startFilterIndex incrementT, scale float64, output []float32) {
+	if len(output) < channels {
+		panic(fmt.Sprintf("calcOutputPlanar: output slice too small (len=%d, need %d)", len(output), channels))
+	}
+	if increment <= 0 {
+		panic(fmt.Sprintf("calcOutputPlanar: invalid increment %d", increment))
+	}
+	filter.syncPlanar(channels)
+	stride := int64(len(filter.planar) / channels)
+	frameLen := int64(channels)
+	maxFilterIndex := intToFP(filter.coeffHalfLen)
+	coeffs := filter.planarCoeffs[:0]
+
+	//---------------- Left half of the filter, by increasing frame ----------
+	filterIndex := startFilterIndex
+	coeffCount := int64((maxFilterIndex - filterIndex) / increment)
+	filterIndex = filterIndex + incrementT(coeffCount)*increment
+	dataIndex := filter.bCurrent - frameLen*coeffCount
+	if dataIndex < 0 {
+		steps := intDivCeil(-dataIndex, frameLen)
+		filterIndex -= incrementT(steps) * increment
+		dataIndex += steps * frameLen
+	}
+	leftIndex := dataIndex
+	for filterIndex >= 0 {
+		indx := fpToInt(filterIndex)
+		if indx < 0 || indx+1 >= len(filter.coeffs) {
+			panic(fmt.Sprintf("calcOutputPlanar: left coeff index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
+		}
+		fraction := fpToDouble(filterIndex)
+		coeffs = append(coeffs, float64(filter.coeffs[indx])+float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])))
+		filterIndex -= increment
+	}
+	leftCount := int64(len(coeffs))
+	if leftCount > 0 {
+		if last := leftIndex + frameLen*(leftCount-1); leftIndex < 0 || last+frameLen-1 >= filter.bLen || last >= filter.bEnd {
+			panic(fmt.Sprintf("calcOutputPlanar: left buffer index out of bounds (first=%d, last=%d, bEnd=%d, bLen=%d)", leftIndex, last, filter.bEnd, filter.bLen))
+		}
+	}
+
+	//---------------- Right half of the filter, by decreasing frame ---------
+	filterIndex = increment - startFilterIndex
+	if filterIndex > maxFilterIndex {
+		coeffCount = -1
+	} else {
+		coeffCount = int64((maxFilterIndex - filterIndex) / increment)
+	}
+	filterIndex = filterIndex + incrementT(coeffCount)*increment
+	rightIndex := filter.bCurrent + frameLen*(1+coeffCount)
+	for {
+		indx := fpToInt(filterIndex)
+		if indx < 0 || indx+1 >= len(filter.coeffs) {
+			panic(fmt.Sprintf("calcOutputPlanar: right coeff index out of bounds (indx=%d, len=%d)", indx, len(filter.coeffs)))
+		}
+		fraction := fpToDouble(filterIndex)
+		coeffs = append(coeffs, float64(filter.coeffs[indx])+float64(fraction*float64(filter.coeffs[indx+1]-filter.coeffs[indx])))
+		filterIndex -= increment
+		if !(filterIndex > 0) {
+			break
+		}
+	}
+	filter.planarCoeffs = coeffs
+	rightCoeffs := coeffs[leftCount:]
+	rightLast := rightIndex - frameLen*int64(len(rightCoeffs)-1)
+	if rightLast < 0 || rightIndex+frameLen-1 >= filter.bLen || rightIndex >= filter.bEnd {
+		panic(fmt.Sprintf("calcOutputPlanar: right buffer index out of bounds (first=%d, last=%d, bEnd=%d, bLen=%d)", rightIndex, rightLast, filter.bEnd, filter.bLen))
+	}
+
+	//---------------- One dot product per channel ---------------------------
+	leftCoeffs := coeffs[:leftCount]
+	leftFrame, rightFrame := leftIndex/frameLen, rightLast/frameLen
+	for ch := 0; ch < channels; ch++ {
+		history := filter.planar[int64(ch)*stride : int64(ch+1)*stride]
+		var left, right float64
+		leftHistory := history[leftFrame : leftFrame+leftCount]
+		for i, c := range leftCoeffs {
+			left += float64(c * float64(leftHistory[i]))
+		}
+		// The right half runs backwards from the frame after the read position
+		rightHistory := history[rightFrame : rightFrame+int64(len(rightCoeffs))]
+		last := len(rightHistory) - 1
+		for i, c := range rightCoeffs {
+			right += float64(c * float64(rightHistory[last-i]))
+		}
+		output[ch] = float32(scale * (left + right))
+	}
+}
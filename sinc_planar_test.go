@@ -0,0 +1,152 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newSincWithVT creates a sinc converter running the process functions of vt.
+func newSincWithVT(t testing.TB, converterType ConverterType, channels int, vt *srcStateVT) *srcState {
+	conv, err := New(converterType, channels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := conv.(*srcState)
+	state.vt = vt
+	return state
+}
+
+// streamVaryingRatio converts in with blocks of 97 frames, moving the ratio
+// from ratio to 1.2*ratio over the stream, so that the buffer wraps and the
+// filter length changes.
+func streamVaryingRatio(t *testing.T, conv Converter, in []float32, channels int, ratio float64) []float32 {
+	const block = 97
+	frames := len(in) / channels
+	out := make([]float32, 0, int(float64(frames)*ratio*1.3+1000)*channels)
+	buf := make([]float32, 512*channels)
+	for pos := 0; ; {
+		n := minInt(block, frames-pos)
+		data := SrcData{
+			DataIn:       in[pos*channels : (pos+n)*channels],
+			InputFrames:  int64(n),
+			DataOut:      buf,
+			OutputFrames: int64(len(buf) / channels),
+			SrcRatio:     ratio * (1 + 0.2*float64(pos)/float64(frames)),
+			EndOfInput:   pos+n == frames,
+		}
+		if err := conv.Process(&data); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, buf[:data.OutputFramesGen*int64(channels)]...)
+		pos += int(data.InputFramesUsed)
+		if data.EndOfInput && data.OutputFramesGen == 0 {
+			return out
+		}
+	}
+}
+
+// TestSincPlanarMatchesMultichan checks that the planar kernel gives the
+// output of the interleaved one to the bit, streaming with a varying ratio,
+// after Reset and in a Clone.
+func TestSincPlanarMatchesMultichan(t *testing.T) {
+	conv, _ := New(SincFastest, sincPlanarMinChannels)
+	if conv.(*srcState).vt != &sincPlanarStateVT {
+		t.Errorf("%d channels do not use the planar kernel", sincPlanarMinChannels)
+	}
+	conv.Close()
+
+	// SincFastest's buffer is short enough for the stream to wrap it
+	for _, tt := range []struct {
+		converterType ConverterType
+		frames        int
+	}{{SincFastest, 40000}, {SincBestQuality, 6000}} {
+		converterType := tt.converterType
+		for _, channels := range []int{3, 8, 9, 16} {
+			in := multichannelTones(tt.frames, channels)
+			for _, ratio := range []float64{0.37, 0.5, 2.5} {
+				name := fmt.Sprintf("%s/%dch at ratio %.2f", converterType, channels, ratio)
+				multi := newSincWithVT(t, converterType, channels, &sincMultichanStateVT)
+				planar := newSincWithVT(t, converterType, channels, &sincPlanarStateVT)
+				want := streamVaryingRatio(t, multi, in, channels, ratio)
+				checkSameSamples(t, name, streamVaryingRatio(t, planar, in, channels, ratio), want)
+
+				if err := planar.Reset(); err != nil {
+					t.Fatal(err)
+				}
+				checkSameSamples(t, name+" after Reset", streamVaryingRatio(t, planar, in, channels, ratio), want)
+
+				if err := multi.Reset(); err != nil {
+					t.Fatal(err)
+				}
+				if err := planar.Reset(); err != nil {
+					t.Fatal(err)
+				}
+				half := len(in) / channels / 2 * channels
+				head := SrcData{DataIn: in[:half], InputFrames: int64(half / channels), DataOut: make([]float32, len(in)), OutputFrames: int64(len(in) / channels), SrcRatio: ratio}
+				headPlanar := head
+				headPlanar.DataOut = make([]float32, len(in))
+				if err := multi.Process(&head); err != nil {
+					t.Fatal(err)
+				}
+				if err := planar.Process(&headPlanar); err != nil {
+					t.Fatal(err)
+				}
+				clone, err := planar.Clone()
+				if err != nil {
+					t.Fatal(err)
+				}
+				planar.Close() // The clone must not share the planar mirror
+				rest := in[head.InputFramesUsed*int64(channels):]
+				checkSameSamples(t, name+" in a Clone", streamVaryingRatio(t, clone, rest, channels, ratio), streamVaryingRatio(t, multi, rest, channels, ratio))
+				clone.Close()
+				multi.Close()
+			}
+		}
+	}
+}
+
+// BenchmarkSincPlanar compares the interleaved and planar kernels converting
+// 8 and 16 channels from 96kHz to 48kHz in 10ms blocks.
+func BenchmarkSincPlanar(b *testing.B) {
+	for _, channels := range []int{8, 16} {
+		for _, kernel := range []struct {
+			name string
+			vt   *srcStateVT
+		}{
+			{"interleaved", &sincMultichanStateVT},
+			{"planar", &sincPlanarStateVT},
+		} {
+			for _, converterType := range []ConverterType{SincFastest, SincBestQuality} {
+				b.Run(fmt.Sprintf("%dch/%s/%s", channels, converterType, kernel.name), func(b *testing.B) {
+					const blockFrames = 960 // 10ms at 96kHz
+					conv := newSincWithVT(b, converterType, channels, kernel.vt)
+					defer conv.Close()
+					in := multichannelTones(blockFrames, channels)
+					out := make([]float32, len(in))
+					b.SetBytes(int64(len(in) * 4))
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						data := SrcData{
+							DataIn:       in,
+							InputFrames:  blockFrames,
+							DataOut:      out,
+							OutputFrames: blockFrames,
+							SrcRatio:     0.5,
+						}
+						if err := conv.Process(&data); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
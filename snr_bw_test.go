@@ -60,6 +60,7 @@ type converterTest struct {
 	testCount       int
 	doBandwidthTest bool
 	testData        []singleTest
+	options         Options // Passed to NewWithOptions
 }
 
 // --- Test Data (Mirrors C) ---
@@ -143,6 +144,23 @@ var snrTestData = []converterTest{
 			{1, [maxFreqs]float64{0.43111111111}, 1.33, 1, 143.0, 1.0, 0.01},
 		},
 	},
+	{
+		converter:       SincFastest,
+		testCount:       9,
+		doBandwidthTest: enableSincFastConverter,
+		testData: []singleTest{
+			{1, [maxFreqs]float64{0.01111111111}, 3.0, 1, 97.0, 1.0, 0.01},
+			{1, [maxFreqs]float64{0.01111111111}, 0.6, 1, 94.0, 1.0, 0.01},
+			{1, [maxFreqs]float64{0.01111111111}, 0.3, 1, 107.0, 1.0, 0.01},
+			{1, [maxFreqs]float64{0.01111111111}, 1.0, 1, 147.0, 1.0, 0.01},
+			{1, [maxFreqs]float64{0.01111111111}, 1.001, 1, 105.0, 1.0, 0.01},
+			{2, [maxFreqs]float64{0.011111, 0.324}, 1.9999, 2, 99.0, 1.0, 0.01},
+			{2, [maxFreqs]float64{0.012345, 0.457}, 0.456789, 1, 99.0, 0.5, 0.02},
+			{2, [maxFreqs]float64{0.011111, 0.45}, 0.6, 1, 92.0, 0.5, 0.02},
+			{1, [maxFreqs]float64{0.3111111111}, 1.33, 1, 93.0, 1.0, 0.01},
+		},
+		options: Options{LowLatency: true},
+	},
 }
 
 // --- Main Test Function ---
@@ -174,7 +192,11 @@ func TestSnrBwAPI(t *testing.T) {
 			continue
 		}
 
-		t.Run(GetName(convTest.converter), func(t *testing.T) {
+		name := GetName(convTest.converter)
+		if convTest.options.LowLatency {
+			name += " (LowLatency)"
+		}
+		t.Run(name, func(t *testing.T) {
 			var worstSnr float64 = 5000.0
 
 			t.Logf("Converter %d : %s", convTest.converter, GetName(convTest.converter))
@@ -194,7 +216,7 @@ func TestSnrBwAPI(t *testing.T) {
 						enableDetailedSnrLog = true
 						fmt.Printf("\n####### Enabling Detailed SNR Log for %s #######\n", t.Name())
 					}
-					snr, err := testSnrGo(t, &subTestData, i, convTest.converter, enableDetailedSnrLog)
+					snr, err := testSnrGo(t, &subTestData, i, convTest.converter, convTest.options, enableDetailedSnrLog)
 					if err != nil && snr == -1.0 { // Check if error requires failing the subtest run
 						t.Errorf("SNR test %d reported failure: %v", i, err)
 					} else if snr >= 0 && snr < worstSnr { // Only update if valid SNR calculated
@@ -210,7 +232,7 @@ func TestSnrBwAPI(t *testing.T) {
 			} else {
 				t.Run("Bandwidth_Test", func(t *testing.T) {
 					// t.Parallel() // IF testBandwidthGo is safe
-					freq3dB := testBandwidthGo(t, convTest.converter, verbose)
+					freq3dB := testBandwidthGo(t, convTest.converter, convTest.options, verbose)
 					if !t.Skipped() {
 						t.Logf("Measured -3dB rolloff point      : %5.2f %%.\n", freq3dB)
 						// TODO: Add assertion for expected bandwidth if known? C test doesn't seem to.
@@ -224,7 +246,7 @@ func TestSnrBwAPI(t *testing.T) {
 // --- Helper Functions ---
 
 // testSnrGo corresponds to snr_test() in C
-func testSnrGo(t *testing.T, testData *singleTest, testNum int, converter ConverterType, verbose bool) (snr float64, err error) {
+func testSnrGo(t *testing.T, testData *singleTest, testNum int, converter ConverterType, opts Options, verbose bool) (snr float64, err error) {
 	t.Helper() // Mark as test helper
 	snr = -1.0 // Default to error/unimplemented state
 
@@ -284,7 +306,7 @@ func testSnrGo(t *testing.T, testData *singleTest, testNum int, converter Conver
 
 	// --- Perform Sample Rate Conversion ---
 	var state Converter            // Use interface type
-	state, err = New(converter, 1) // Corrected arguments
+	state, err = NewWithOptions(converter, 1, opts)
 	if err != nil {
 		t.Errorf("%s libsamplerate.New() failed: %v (C Line ~163)", logPrefix, err)
 		return snr, err
@@ -408,7 +430,7 @@ func testSnrGo(t *testing.T, testData *singleTest, testNum int, converter Conver
 }
 
 // findAttenuationGo corresponds to find_attenuation() in C
-func findAttenuationGo(t *testing.T, freq float64, converter ConverterType, verbose bool) (float64, error) {
+func findAttenuationGo(t *testing.T, freq float64, converter ConverterType, opts Options, verbose bool) (float64, error) {
 	t.Helper()
 	inputData := make([]float32, bufferLenSnr)
 	outputCap := int(math.Ceil(bufferLenSnr*1.999)) + 100
@@ -422,9 +444,15 @@ func findAttenuationGo(t *testing.T, freq float64, converter ConverterType, verb
 		SrcRatio: 1.999, EndOfInput: true,
 	}
 
-	err := Simple(&srcData, converter, 1) // Use Simple API
+	state, err := NewWithOptions(converter, 1, opts) // Simple with options
+	if err != nil {
+		t.Errorf("findAttenuationGo: NewWithOptions() failed for freq %.5f: %v", freq, err)
+		return -1, err
+	}
+	defer state.Close()
+	err = state.Process(&srcData)
 	if err != nil {
-		t.Errorf("findAttenuationGo: Simple() failed for freq %.5f: %v (C Line ~253)", freq, err)
+		t.Errorf("findAttenuationGo: Process() failed for freq %.5f: %v (C Line ~253)", freq, err)
 		return -1, err
 	}
 
@@ -450,7 +478,7 @@ func findAttenuationGo(t *testing.T, freq float64, converter ConverterType, verb
 }
 
 // testBandwidthGo corresponds to bandwidth_test() in C
-func testBandwidthGo(t *testing.T, converter ConverterType, verbose bool) float64 {
+func testBandwidthGo(t *testing.T, converter ConverterType, opts Options, verbose bool) float64 {
 	t.Helper()
 	// Now attempts to run fully, relying on findAttenuationGo
 
@@ -458,13 +486,13 @@ func testBandwidthGo(t *testing.T, converter ConverterType, verbose bool) float6
 	var err error
 
 	f1 = 0.35
-	a1, err = findAttenuationGo(t, f1, converter, verbose)
+	a1, err = findAttenuationGo(t, f1, converter, opts, verbose)
 	if err != nil {
 		t.Fatalf("Bandwidth test: findAttenuationGo failed for f1=%.2f: %v", f1, err)
 	}
 
 	f2 = 0.495
-	a2, err = findAttenuationGo(t, f2, converter, verbose)
+	a2, err = findAttenuationGo(t, f2, converter, opts, verbose)
 	if err != nil {
 		t.Fatalf("Bandwidth test: findAttenuationGo failed for f2=%.2f: %v", f2, err)
 	}
@@ -480,7 +508,7 @@ func testBandwidthGo(t *testing.T, converter ConverterType, verbose bool) float6
 	for math.Abs(a2-a1) > 0.1 && iterations < maxIterations { // Use math.Abs for tolerance check
 		iterations++
 		freq = f1 + 0.5*(f2-f1)
-		atten, err = findAttenuationGo(t, freq, converter, verbose)
+		atten, err = findAttenuationGo(t, freq, converter, opts, verbose)
 		if err != nil {
 			t.Fatalf("Bandwidth test: findAttenuationGo failed during iteration for freq=%.5f: %v", freq, err)
 		}
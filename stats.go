@@ -0,0 +1,81 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+// Stats holds cumulative counters for a converter instance, for monitoring
+// long-running transcoding and debugging drift. Counters cover the lifetime of
+// the instance: they survive Reset, and a Clone starts with a copy of them.
+type Stats struct {
+	InputFrames  int64 // Input frames consumed by Process
+	OutputFrames int64 // Output frames generated by Process
+	ProcessCalls int64 // Successful Process calls
+
+	// Flushes counts end-of-stream drains: runs of Process calls with EndOfInput
+	// set count once, however many calls the drain takes.
+	Flushes int64
+
+	// Underruns counts CallbackRead calls that returned fewer frames than asked
+	// for because the callback ran out of input.
+	Underruns int64
+
+	// CorruptionResets counts NaN/Inf outputs recovered from with
+	// Options.ResetOnCorruption.
+	CorruptionResets int64
+
+	// TrimmedFrames counts output frames dropped with
+	// Options.TrimLeadingTransient; they are not in OutputFrames. After a reset,
+	// output frame n maps to input frame (n+trimmed)/ratio, where trimmed is the
+	// increase of TrimmedFrames since the reset.
+	TrimmedFrames int64
+
+	MinRatio  float64 // Lowest ratio requested (0 before the first Process call)
+	MaxRatio  float64 // Highest ratio requested
+	LastRatio float64 // Ratio of the most recent Process call
+
+	// BufferedFrames is the input currently held inside the converter: the
+	// sinc filter's history/lookahead buffer plus, in callback mode, callback
+	// input not yet handed to the filter.
+	BufferedFrames int64
+}
+
+// Stats returns the converter's cumulative counters and current buffer occupancy.
+func (state *srcState) Stats() Stats {
+	if state == nil {
+		return Stats{}
+	}
+	s := state.stats
+	if state.channels > 0 {
+		if filter, ok := state.privateData.(*sincFilter); ok {
+			if samplesInHand := filter.bEnd - filter.bCurrent; samplesInHand > 0 {
+				s.BufferedFrames = samplesInHand / int64(state.channels)
+			}
+		}
+	}
+	s.BufferedFrames += state.savedFrames
+	return s
+}
+
+// recordProcess updates the counters after a successful Process call.
+func (state *srcState) recordProcess(data *SrcData) {
+	s := &state.stats
+	s.ProcessCalls++
+	s.InputFrames += data.InputFramesUsed
+	s.OutputFrames += data.OutputFramesGen
+	if data.EndOfInput && !state.flushing {
+		s.Flushes++
+	}
+	state.flushing = data.EndOfInput
+	if s.ProcessCalls == 1 || data.SrcRatio < s.MinRatio {
+		s.MinRatio = data.SrcRatio
+	}
+	if s.ProcessCalls == 1 || data.SrcRatio > s.MaxRatio {
+		s.MaxRatio = data.SrcRatio
+	}
+	s.LastRatio = data.SrcRatio
+}
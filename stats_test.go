@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"testing"
+)
+
+func TestStatsProcess(t *testing.T) {
+	const channels = 2
+	conv, err := New(SincFastest, channels)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+
+	if s := conv.(StatsReporter).Stats(); s != (Stats{}) {
+		t.Errorf("fresh converter stats %+v, want zero", s)
+	}
+
+	in := make([]float32, 1000*channels)
+	genWindowedSinesGo(1, []float64{0.05}, 0.9, in)
+	out := make([]float32, 4000*channels)
+	var inTotal, outTotal int64
+	for i, ratio := range []float64{0.5, 0.75, 2.0} {
+		data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 4000, SrcRatio: ratio}
+		if err := conv.Process(&data); err != nil {
+			t.Fatalf("Process %d failed: %v", i, err)
+		}
+		inTotal += data.InputFramesUsed
+		outTotal += data.OutputFramesGen
+	}
+	s := conv.(StatsReporter).Stats()
+	if s.InputFrames != inTotal || s.OutputFrames != outTotal || s.ProcessCalls != 3 {
+		t.Errorf("counters %+v, want in %d out %d calls 3", s, inTotal, outTotal)
+	}
+	if s.MinRatio != 0.5 || s.MaxRatio != 2.0 || s.LastRatio != 2.0 {
+		t.Errorf("ratios min %g max %g last %g, want 0.5 2 2", s.MinRatio, s.MaxRatio, s.LastRatio)
+	}
+	if s.BufferedFrames <= 0 {
+		t.Errorf("BufferedFrames = %d, want the sinc lookahead", s.BufferedFrames)
+	}
+	if s.Flushes != 0 {
+		t.Errorf("Flushes = %d before end of input", s.Flushes)
+	}
+
+	// A drain spread over several calls counts as one flush
+	for i := 0; i < 3; i++ {
+		data := SrcData{DataOut: out, OutputFrames: 10, SrcRatio: 2.0, EndOfInput: true}
+		if err := conv.Process(&data); err != nil {
+			t.Fatalf("flush Process failed: %v", err)
+		}
+	}
+	if s := conv.(StatsReporter).Stats(); s.Flushes != 1 {
+		t.Errorf("Flushes = %d, want 1", s.Flushes)
+	}
+
+	// Counters survive Reset; a new stream's drain is another flush
+	if err := conv.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if s := conv.(StatsReporter).Stats(); s.ProcessCalls != 6 || s.BufferedFrames != 0 {
+		t.Errorf("after Reset: calls %d buffered %d, want 6 and 0", s.ProcessCalls, s.BufferedFrames)
+	}
+	data := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 4000, SrcRatio: 1.0, EndOfInput: true}
+	if err := conv.Process(&data); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if s := conv.(StatsReporter).Stats(); s.Flushes != 2 {
+		t.Errorf("Flushes = %d, want 2", s.Flushes)
+	}
+
+	// Failed calls are not counted
+	bad := SrcData{DataIn: in, InputFrames: 1000, DataOut: out, OutputFrames: 4000, SrcRatio: 1000}
+	_ = conv.Process(&bad)
+	if s := conv.(StatsReporter).Stats(); s.ProcessCalls != 7 {
+		t.Errorf("ProcessCalls = %d after a failed call, want 7", s.ProcessCalls)
+	}
+}
+
+func TestStatsCallbackUnderruns(t *testing.T) {
+	input := make([]float32, 3000)
+	genWindowedSinesGo(1, []float64{0.05}, 0.9, input)
+	c, err := CallbackNew(eofSourceCallback, Linear, 1, &eofSource{data: input, chunkLen: 700})
+	if err != nil {
+		t.Fatalf("CallbackNew failed: %v", err)
+	}
+	defer c.Close()
+
+	buf := make([]float32, 500)
+	if _, err := CallbackRead(c, 1.0, 500, buf); err != nil {
+		t.Fatalf("CallbackRead failed: %v", err)
+	}
+	s := c.(StatsReporter).Stats()
+	if s.Underruns != 0 || s.OutputFrames != 500 {
+		t.Errorf("after a full read: underruns %d, output %d", s.Underruns, s.OutputFrames)
+	}
+	if s.BufferedFrames != 200 { // 700-frame chunk, 500 used by the linear converter
+		t.Errorf("BufferedFrames = %d, want 200 saved callback frames", s.BufferedFrames)
+	}
+
+	readAllCallback(t, c, 1.0, 500)
+	s = c.(StatsReporter).Stats()
+	if s.Underruns != 1 {
+		t.Errorf("Underruns = %d, want 1 (the short final read)", s.Underruns)
+	}
+	if s.Flushes != 1 {
+		t.Errorf("Flushes = %d, want 1", s.Flushes)
+	}
+}
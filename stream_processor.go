@@ -0,0 +1,355 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"io"
+)
+
+// Frames read per Process round by Drain and StreamWriter
+const streamDrainFrames = 4096
+
+// StreamProcessor drives a converter in process mode for a stream written and
+// read in blocks of any size: Write queues input, Read converts as much of it as
+// fits in the output buffer. The input cursor (InputFramesUsed) is kept
+// internally, so the caller never re-slices DataIn.
+//
+//	sp, _ := NewStreamProcessor(conv, 16000.0/8000.0)
+//	sp.Write(block)
+//	n, _ := sp.Read(out) // n frames of out are valid
+//	...
+//	sp.CloseWrite()      // End of input, Read now drains the converter
+//	for { n, err := sp.Read(out); if err == io.EOF { break } ... }
+//
+// Close is the final flush too: it drains the converter before releasing it,
+// and the output still to come stays readable with Read until io.EOF.
+//
+// NOTE: A StreamProcessor is NOT goroutine-safe.
+type StreamProcessor struct {
+	conv        Converter
+	ratio       float64
+	channels    int       // Input channels
+	outChannels int       // Output channels (differ with Options.OutputChannels)
+	queue       []float32 // Written input
+	head        int       // Samples at the start of queue already consumed by the converter
+	closed      bool      // CloseWrite was called
+	drained     bool      // The converter has returned everything after CloseWrite
+	released    bool      // Close was called
+	tail        []float32 // Output drained by Close, not yet read
+}
+
+// NewStreamProcessor creates a StreamProcessor converting by ratio (output rate /
+// input rate) with c, a converter created with New or NewWithOptions. The
+// StreamProcessor takes ownership of c.
+func NewStreamProcessor(c Converter, ratio float64) (*StreamProcessor, error) {
+	if c == nil {
+		return nil, mapError(ErrBadState)
+	}
+	if isBadSrcRatio(ratio) {
+		return nil, mapError(ErrBadSrcRatio)
+	}
+	return &StreamProcessor{
+		conv:        c,
+		ratio:       ratio,
+		channels:    c.GetChannels(),
+		outChannels: outputChannels(c),
+	}, nil
+}
+
+// Write queues interleaved input frames. It returns the number of frames
+// queued, or an error if the input is not whole frames or CloseWrite was called.
+func (s *StreamProcessor) Write(in []float32) (int, error) {
+	if s.closed {
+		return 0, fmt.Errorf("Write called after CloseWrite; call Reset first")
+	}
+	if len(in)%s.channels != 0 {
+		return 0, fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), s.channels)
+	}
+	s.queue = append(s.queue, in...)
+	return len(in) / s.channels, nil
+}
+
+// CloseWrite marks the end of the input. Later Read calls flush the converter
+// and return io.EOF once all output has been read.
+func (s *StreamProcessor) CloseWrite() {
+	s.closed = true
+}
+
+// Read converts queued input into out, which holds len(out)/channels frames,
+// and returns the number of frames written. It returns 0 and a nil error when
+// more input is needed, and 0 and io.EOF when the stream has been drained after
+// CloseWrite.
+func (s *StreamProcessor) Read(out []float32) (int, error) {
+	if s.drained {
+		return s.readTail(out)
+	}
+	outFrames := int64(len(out) / s.outChannels)
+	data := SrcData{SrcRatio: s.ratio, EndOfInput: s.closed}
+	used, gen := int64(0), int64(0)
+	for gen < outFrames {
+		data.DataIn = s.queue[s.head+int(used)*s.channels:]
+		data.InputFrames = int64(len(data.DataIn) / s.channels)
+		if data.InputFrames == 0 {
+			data.DataIn = nil
+		}
+		data.DataOut = out[gen*int64(s.outChannels):]
+		data.OutputFrames = outFrames - gen
+		if err := s.conv.Process(&data); err != nil {
+			s.consume(used)
+			return int(gen), err
+		}
+		used += data.InputFramesUsed
+		gen += data.OutputFramesGen
+		if data.InputFramesUsed == 0 && data.OutputFramesGen == 0 {
+			if s.closed && gen == 0 {
+				s.drained = true
+			}
+			break // Needs more input, or drained
+		}
+	}
+	s.consume(used)
+	if s.drained {
+		return 0, io.EOF
+	}
+	return int(gen), nil
+}
+
+// Drain ends the input, as CloseWrite, and returns all the output still to
+// come: the rest of the queued input converted and the tail of the converter.
+// It is the final flush in one call, e.g. on Close of a wrapper; Read then
+// returns io.EOF. The returned slice belongs to the caller.
+func (s *StreamProcessor) Drain() ([]float32, error) {
+	s.CloseWrite()
+	var rest []float32
+	out := make([]float32, streamDrainFrames*s.outChannels)
+	for {
+		n, err := s.Read(out)
+		rest = append(rest, out[:n*s.outChannels]...)
+		if err == io.EOF {
+			return rest, nil
+		}
+		if err != nil {
+			return rest, err
+		}
+	}
+}
+
+// readTail returns the output Close drained, then io.EOF.
+func (s *StreamProcessor) readTail(out []float32) (int, error) {
+	if len(s.tail) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(out[:len(out)-len(out)%s.outChannels], s.tail) / s.outChannels
+	s.tail = s.tail[n*s.outChannels:]
+	return n, nil
+}
+
+// consume drops frames consumed by the converter from the front of the queue.
+// The rest of the queue is moved to its start only once it is shorter than the
+// consumed part, so the copies cost no more than the input itself.
+func (s *StreamProcessor) consume(frames int64) {
+	s.head += int(frames) * s.channels
+	if s.head == len(s.queue) {
+		s.queue, s.head = s.queue[:0], 0
+	} else if s.head > len(s.queue)/2 {
+		s.queue, s.head = s.queue[:copy(s.queue, s.queue[s.head:])], 0
+	}
+}
+
+// SetRatio changes the conversion ratio for the next Read.
+func (s *StreamProcessor) SetRatio(ratio float64) error {
+	if isBadSrcRatio(ratio) {
+		return mapError(ErrBadSrcRatio)
+	}
+	s.ratio = ratio
+	return nil
+}
+
+// Buffered returns the number of written input frames not yet consumed.
+func (s *StreamProcessor) Buffered() int {
+	return (len(s.queue) - s.head) / s.channels
+}
+
+// Reset discards queued input and resets the converter for a new stream.
+func (s *StreamProcessor) Reset() error {
+	if s.released {
+		return mapError(ErrBadState)
+	}
+	s.queue, s.head = s.queue[:0], 0
+	s.closed, s.drained = false, false
+	return s.conv.Reset()
+}
+
+// Close is the final flush: it ends the input, as CloseWrite, drains the
+// converter and releases it. The output not read yet, including the converter's
+// tail, is kept and returned by the following Read calls, then io.EOF; Drain
+// returns it all at once. Close may be called more than once.
+func (s *StreamProcessor) Close() error {
+	if s.released {
+		return nil
+	}
+	s.released = true
+	rest, err := s.Drain()
+	s.tail = rest
+	if cerr := s.conv.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// StreamWriter is an io.WriteCloser resampling a PCM byte stream: the bytes
+// written to it are decoded, converted by a StreamProcessor and written to the
+// underlying writer as they come out, and Close drains the converter and
+// writes the final frames. It thus composes with io.Copy and defer:
+//
+//	sw, _ := NewStreamWriter(dst, conv, 16000.0/8000.0, FormatS16LE, FormatS16LE)
+//	defer sw.Close()
+//	_, err := io.Copy(sw, src)
+//
+// Writes may split frames anywhere; the bytes of an incomplete frame are held
+// until the next Write.
+//
+// NOTE: A StreamWriter is NOT goroutine-safe.
+type StreamWriter struct {
+	w                   io.Writer
+	sp                  *StreamProcessor
+	inFormat, outFormat SampleFormat
+	inFrameBytes        int
+	partial             []byte // Bytes of an incomplete input frame
+	in, out             []float32
+	outBytes            []byte
+	closed              bool
+}
+
+// NewStreamWriter creates a StreamWriter writing to w the conversion by ratio
+// (output rate / input rate) with c, created with New or NewWithOptions, of the
+// inFormat bytes written to it, in outFormat. It takes ownership of c: Close
+// releases it, but does not close w.
+func NewStreamWriter(w io.Writer, c Converter, ratio float64, inFormat, outFormat SampleFormat) (*StreamWriter, error) {
+	if w == nil {
+		return nil, fmt.Errorf("writer must not be nil")
+	}
+	if !inFormat.IsValid() {
+		return nil, fmt.Errorf("unknown input sample format %d", inFormat)
+	}
+	if !outFormat.IsValid() {
+		return nil, fmt.Errorf("unknown output sample format %d", outFormat)
+	}
+	sp, err := NewStreamProcessor(c, ratio)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamWriter{
+		w:            w,
+		sp:           sp,
+		inFormat:     inFormat,
+		outFormat:    outFormat,
+		inFrameBytes: BytesPerFrame(inFormat, sp.channels),
+		out:          make([]float32, streamDrainFrames*sp.outChannels),
+	}, nil
+}
+
+// Write converts p and writes the output available so far to the underlying
+// writer. It returns len(p) unless that write fails; then, as io.Writer asks,
+// it returns the number of bytes of p already queued for conversion, which
+// must not be written again.
+func (sw *StreamWriter) Write(p []byte) (int, error) {
+	if sw.closed {
+		return 0, fmt.Errorf("stream writer is closed")
+	}
+	consumed := 0
+	if len(sw.partial) > 0 {
+		need := sw.inFrameBytes - len(sw.partial)
+		if len(p) < need {
+			sw.partial = append(sw.partial, p...)
+			return len(p), nil
+		}
+		sw.partial = append(sw.partial, p[:need]...)
+		queued, err := sw.convert(sw.partial)
+		if !queued {
+			sw.partial = sw.partial[:len(sw.partial)-need]
+			return 0, err
+		}
+		sw.partial, p, consumed = sw.partial[:0], p[need:], need
+		if err != nil {
+			return consumed, err
+		}
+	}
+	whole := len(p) - len(p)%sw.inFrameBytes
+	if queued, err := sw.convert(p[:whole]); err != nil {
+		if queued {
+			consumed += whole
+		}
+		return consumed, err
+	}
+	sw.partial = append(sw.partial, p[whole:]...)
+	return consumed + len(p), nil
+}
+
+// convert queues whole input frames and writes all the output they give. It
+// reports whether p was queued, even when writing the output then failed.
+func (sw *StreamWriter) convert(p []byte) (bool, error) {
+	if len(p) == 0 {
+		return true, nil
+	}
+	sw.in = growFloats(sw.in, len(p)/sw.inFormat.BytesPerSample())
+	if _, err := DecodePCM(sw.inFormat, p, sw.in); err != nil {
+		return false, err
+	}
+	if _, err := sw.sp.Write(sw.in); err != nil {
+		return false, err
+	}
+	for {
+		n, err := sw.sp.Read(sw.out)
+		if err != nil {
+			return true, err
+		}
+		if n == 0 {
+			return true, nil
+		}
+		if err := sw.writeOut(sw.out[:n*sw.sp.outChannels]); err != nil {
+			return true, err
+		}
+	}
+}
+
+// writeOut encodes samples and writes them to the underlying writer.
+func (sw *StreamWriter) writeOut(samples []float32) error {
+	sw.outBytes = growBytes(sw.outBytes, len(samples)*sw.outFormat.BytesPerSample())
+	if _, err := EncodePCM(sw.outFormat, samples, sw.outBytes); err != nil {
+		return err
+	}
+	_, err := sw.w.Write(sw.outBytes)
+	return err
+}
+
+// Close is the final flush: it drains the converter, writes the last frames to
+// the underlying writer and releases the converter. The bytes of an incomplete
+// last input frame are dropped and reported as an error, after the flush.
+// Close may be called more than once.
+func (sw *StreamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	rest, err := sw.sp.Drain()
+	if len(rest) > 0 {
+		if werr := sw.writeOut(rest); err == nil {
+			err = werr
+		}
+	}
+	if cerr := sw.sp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && len(sw.partial) > 0 {
+		err = fmt.Errorf("%d trailing bytes are not a whole frame (%d bytes)", len(sw.partial), sw.inFrameBytes)
+	}
+	return err
+}
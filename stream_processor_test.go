@@ -0,0 +1,307 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+// TestStreamProcessor writes and reads blocks of unrelated random sizes and
+// checks the stream equals a one-shot conversion.
+func TestStreamProcessor(t *testing.T) {
+	for _, ct := range []ConverterType{SincFastest, Linear} {
+		in := stereoTestSignal(5000)
+		ref, _ := New(ct, 2)
+		want, err := processAll(ref, in, 2, 0.7)
+		ref.Close()
+		if err != nil {
+			t.Fatalf("processAll failed: %v", err)
+		}
+
+		conv, _ := New(ct, 2)
+		sp, err := NewStreamProcessor(conv, 0.7)
+		if err != nil {
+			t.Fatalf("NewStreamProcessor failed: %v", err)
+		}
+		rng := rand.New(rand.NewSource(3))
+		var got []float32
+		out := make([]float32, 2*300)
+		read := func() error {
+			n, err := sp.Read(out[:2*(1+rng.Intn(300))])
+			got = append(got, out[:2*n]...)
+			return err
+		}
+		for pos := 0; pos < len(in); {
+			end := minInt(pos+2*(1+rng.Intn(400)), len(in))
+			if _, err := sp.Write(in[pos:end]); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			pos = end
+			if err := read(); err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+		}
+		sp.CloseWrite()
+		for {
+			err := read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+		}
+		if sp.Buffered() != 0 {
+			t.Errorf("%v: %d frames still buffered", ct, sp.Buffered())
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("%v: got %d samples, want %d", ct, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%v: sample %d is %g, want %g", ct, i, got[i], want[i])
+			}
+		}
+
+		if _, err := sp.Write(in[:2]); err == nil {
+			t.Errorf("%v: expected error writing after CloseWrite", ct)
+		}
+		if n, err := sp.Read(out); n != 0 || err != io.EOF {
+			t.Errorf("%v: Read after drain gave %d, %v; want 0, io.EOF", ct, n, err)
+		}
+		if err := sp.Reset(); err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		if _, err := sp.Write(in[:2*100]); err != nil {
+			t.Errorf("%v: Write after Reset failed: %v", ct, err)
+		}
+		sp.Close()
+	}
+}
+
+func TestStreamProcessorNeedsInput(t *testing.T) {
+	conv, _ := New(SincFastest, 1)
+	sp, _ := NewStreamProcessor(conv, 2.0)
+	defer sp.Close()
+	out := make([]float32, 100)
+	if n, err := sp.Read(out); n != 0 || err != nil {
+		t.Errorf("Read without input gave %d, %v; want 0, nil", n, err)
+	}
+	sp.Write(make([]float32, 1000))
+	n, err := sp.Read(out)
+	if n != 100 || err != nil {
+		t.Errorf("Read gave %d, %v; want a full buffer", n, err)
+	}
+	if sp.Buffered() == 1000 {
+		t.Error("no input consumed")
+	}
+	if _, err := sp.Write(make([]float32, 1)); err != nil {
+		t.Errorf("Write failed: %v", err)
+	}
+
+	stereo, _ := New(Linear, 2)
+	sp2, _ := NewStreamProcessor(stereo, 1.5)
+	defer sp2.Close()
+	if _, err := sp2.Write(make([]float32, 3)); err == nil {
+		t.Error("expected error for a partial frame")
+	}
+	if err := sp2.SetRatio(0); err == nil {
+		t.Error("expected error for a bad ratio")
+	}
+	if _, err := NewStreamProcessor(nil, 1.0); err == nil {
+		t.Error("expected error for a nil converter")
+	}
+}
+
+func TestStreamProcessorOutputChannels(t *testing.T) {
+	conv, err := NewWithOptions(SincFastest, 2, Options{OutputChannels: 1})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	sp, _ := NewStreamProcessor(conv, 0.5)
+	defer sp.Close()
+	sp.Write(stereoTestSignal(2000))
+	sp.CloseWrite()
+	out := make([]float32, 5000)
+	n, err := sp.Read(out)
+	if err != nil || n < 990 || n > 1010 {
+		t.Errorf("Read gave %d mono frames, %v; want about 1000", n, err)
+	}
+}
+
+func TestStreamProcessorDrain(t *testing.T) {
+	in := stereoTestSignal(5000)
+	ref, _ := New(SincFastest, 2)
+	want, err := processAll(ref, in, 2, 1.7)
+	ref.Close()
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+
+	conv, _ := New(SincFastest, 2)
+	sp, _ := NewStreamProcessor(conv, 1.7)
+	defer sp.Close()
+	sp.Write(in)
+	out := make([]float32, 2*1000)
+	n, err := sp.Read(out)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	rest, err := sp.Drain()
+	if err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+	checkSameSamples(t, "Read and Drain", append(out[:2*n], rest...), want)
+	if n, err := sp.Read(out); n != 0 || err != io.EOF {
+		t.Errorf("Read after Drain gave %d, %v; want 0, io.EOF", n, err)
+	}
+	if rest, err := sp.Drain(); len(rest) != 0 || err != nil {
+		t.Errorf("second Drain gave %d samples, %v; want none", len(rest), err)
+	}
+}
+
+// TestStreamWriter copies a stream through a StreamWriter in chunks that split
+// frames and checks the output equals ResampleFormat of the whole stream,
+// flushed by Close.
+// TestStreamProcessorClose checks Close drains the converter and keeps the output
+// for Read, and a backlog read by small blocks comes out whole.
+func TestStreamProcessorClose(t *testing.T) {
+	in := stereoTestSignal(20000)
+	ref, _ := New(SincFastest, 2)
+	want, err := processAll(ref, in, 2, 1.7)
+	ref.Close()
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+
+	conv, _ := New(SincFastest, 2)
+	sp, _ := NewStreamProcessor(conv, 1.7)
+	sp.Write(in)
+	var got []float32
+	out := make([]float32, 2*7)
+	for i := 0; i < 100; i++ {
+		n, err := sp.Read(out)
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		got = append(got, out[:2*n]...)
+	}
+	if err := sp.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if n := sp.Buffered(); n != 0 {
+		t.Errorf("Buffered after Close = %d, want 0", n)
+	}
+	for {
+		n, err := sp.Read(out)
+		got = append(got, out[:2*n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read after Close failed: %v", err)
+		}
+	}
+	checkSameSamples(t, "Read after Close", got, want)
+	if err := sp.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+	if err := sp.Reset(); err == nil {
+		t.Error("expected error resetting a closed StreamProcessor")
+	}
+}
+
+func TestStreamWriter(t *testing.T) {
+	pcm := make([]byte, 2*2*3000)
+	if _, err := EncodePCM(FormatS16LE, stereoTestSignal(3000), pcm); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ResampleFormat(pcm, FormatS16LE, FormatS24LE, 2, 0.7, SincFastest)
+	if err != nil {
+		t.Fatalf("ResampleFormat failed: %v", err)
+	}
+
+	var got bytes.Buffer
+	conv, _ := New(SincFastest, 2)
+	sw, err := NewStreamWriter(&got, conv, 0.7, FormatS16LE, FormatS24LE)
+	if err != nil {
+		t.Fatalf("NewStreamWriter failed: %v", err)
+	}
+	// HalfReader returns odd sized chunks, splitting frames
+	if _, err := io.Copy(sw, iotest.HalfReader(bytes.NewReader(pcm))); err != nil {
+		t.Fatalf("io.Copy failed: %v", err)
+	}
+	beforeClose := got.Len()
+	if err := sw.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if got.Len() <= beforeClose {
+		t.Error("Close wrote no final frames")
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Fatalf("got %d bytes, want %d, or they differ", got.Len(), len(want))
+	}
+	if err := sw.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+	if _, err := sw.Write(pcm[:4]); err == nil {
+		t.Error("expected error writing after Close")
+	}
+}
+
+func TestStreamWriterErrors(t *testing.T) {
+	conv, _ := New(Linear, 2)
+	if _, err := NewStreamWriter(nil, conv, 1, FormatS16LE, FormatS16LE); err == nil {
+		t.Error("expected error for a nil writer")
+	}
+	if _, err := NewStreamWriter(io.Discard, conv, 1, SampleFormat(99), FormatS16LE); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+	if _, err := NewStreamWriter(io.Discard, conv, 0, FormatS16LE, FormatS16LE); err == nil {
+		t.Error("expected error for a bad ratio")
+	}
+
+	sw, _ := NewStreamWriter(io.Discard, conv, 1, FormatS16LE, FormatS16LE)
+	if n, err := sw.Write(make([]byte, 4*100+3)); n != 403 || err != nil {
+		t.Errorf("Write gave %d, %v; want 403, nil", n, err)
+	}
+	if err := sw.Close(); err == nil {
+		t.Error("expected error closing with an incomplete frame")
+	}
+
+	conv2, _ := New(Linear, 1)
+	failing, _ := NewStreamWriter(failWriter{}, conv2, 1, FormatS16LE, FormatS16LE)
+	if n, err := failing.Write(make([]byte, 2*100+1)); n != 2*100 || err == nil {
+		t.Errorf("Write gave %d, %v; want the 200 queued bytes and the error of the underlying writer", n, err)
+	}
+	failing.Close()
+
+	// The frame completed by a failing Write was queued
+	conv3, _ := New(Linear, 1)
+	split, _ := NewStreamWriter(failWriter{}, conv3, 1, FormatS16LE, FormatS16LE)
+	if n, err := split.Write(make([]byte, 1)); n != 1 || err != nil {
+		t.Errorf("Write of half a frame gave %d, %v; want 1, nil", n, err)
+	}
+	if n, err := split.Write(make([]byte, 2*100+1)); n != 1 || err == nil {
+		t.Errorf("Write gave %d, %v; want the 1 byte completing the frame and an error", n, err)
+	}
+	split.Close()
+}
+
+// failWriter fails every write.
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
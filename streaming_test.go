@@ -246,3 +246,139 @@ func testStreaming(t *testing.T, converter ConverterType, srcRatio float64) {
 		t.Logf("%s ok", logPrefix)
 	}
 }
+
+// BenchmarkSmallBlockProcess streams 10ms blocks, where the fixed cost of each
+// Process call dominates.
+func BenchmarkSmallBlockProcess(b *testing.B) {
+	for _, tt := range []struct {
+		name          string
+		converterType ConverterType
+		channels      int
+	}{
+		{"SincFastest/mono", SincFastest, 1},
+		{"SincFastest/stereo", SincFastest, 2},
+		{"SincBest/mono", SincBestQuality, 1},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			const blockFrames = 80 // 10ms at 8kHz
+			conv, _ := New(tt.converterType, tt.channels)
+			defer conv.Close()
+			in := genSine(blockFrames*tt.channels, 440, 8000, 0.5)
+			out := make([]float32, 4*len(in))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				data := SrcData{
+					DataIn:       in,
+					InputFrames:  blockFrames,
+					DataOut:      out,
+					OutputFrames: int64(len(out) / tt.channels),
+					SrcRatio:     2,
+				}
+				if err := conv.Process(&data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestResetLeavesNoHistory checks that a sinc converter Reset after a stream
+// converts the next one exactly as a new converter, although Reset no longer
+// zeroes the buffer: the stale samples are poisoned with NaN to be sure none is
+// read.
+func TestResetLeavesNoHistory(t *testing.T) {
+	for _, converterType := range []ConverterType{SincFastest, SincMediumQuality, SincBestQuality} {
+		for _, channels := range []int{1, 2, 3, 4} {
+			name := fmt.Sprintf("%s/%dch", GetName(converterType), channels)
+			in := genSine(4000*channels, 440, 8000, 0.5)
+			for _, ratio := range []float64{0.5, 3.0} {
+				fresh, _ := New(converterType, channels)
+				want, err := processAll(fresh, in, channels, ratio)
+				fresh.Close()
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+
+				conv, _ := New(converterType, channels)
+				if _, err := processAll(conv, genSine(6000*channels, 1000, 8000, 0.9), channels, 1.0/ratio); err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if err := conv.Reset(); err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				filter := conv.(*srcState).privateData.(*sincFilter)
+				for i := range filter.buffer[:filter.bLen] {
+					filter.buffer[i] = float32(math.NaN())
+				}
+				got, err := processAll(conv, in, channels, ratio)
+				conv.Close()
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				checkSameSamples(t, fmt.Sprintf("%s at ratio %.1f after Reset", name, ratio), got, want)
+			}
+		}
+	}
+}
+
+// BenchmarkReset resets a converter per 10ms block, as a voice bot resetting
+// per utterance does at worst.
+func BenchmarkReset(b *testing.B) {
+	conv, _ := New(SincBestQuality, 2)
+	defer conv.Close()
+	in := genSine(160, 440, 8000, 0.5)
+	out := make([]float32, 4*len(in))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := conv.Reset(); err != nil {
+			b.Fatal(err)
+		}
+		data := SrcData{DataIn: in, InputFrames: 80, DataOut: out, OutputFrames: int64(len(out) / 2), SrcRatio: 2}
+		if err := conv.Process(&data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestSincKernelsMatchMono checks that the channel kernels the shared vari
+// process loop runs compute every channel as the mono kernel does.
+func TestSincKernelsMatchMono(t *testing.T) {
+	const frames = 3000
+	for _, converterType := range []ConverterType{SincFastest, SincBestQuality} {
+		for _, channels := range []int{2, 4, 5, 6} {
+			in := make([]float32, frames*channels)
+			for c := 0; c < channels; c++ {
+				tone := genSine(frames, 300+250*float64(c), 8000, 0.6)
+				for i, v := range tone {
+					in[i*channels+c] = v
+				}
+			}
+			for _, ratio := range []float64{0.37, 2.5} {
+				name := fmt.Sprintf("%s/%dch at ratio %.2f", GetName(converterType), channels, ratio)
+				conv, _ := New(converterType, channels)
+				got, err := processAll(conv, in, channels, ratio)
+				conv.Close()
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				for c := 0; c < channels; c++ {
+					mono, _ := New(converterType, 1)
+					want, err := processAll(mono, genSine(frames, 300+250*float64(c), 8000, 0.6), 1, ratio)
+					mono.Close()
+					if err != nil {
+						t.Fatalf("%s: %v", name, err)
+					}
+					n := minInt(len(got)/channels, len(want))
+					if len(want)-n > 1 || len(got)/channels-n > 1 {
+						t.Fatalf("%s: %d frames, mono %d", name, len(got)/channels, len(want))
+					}
+					for i := 0; i < n; i++ {
+						if got[i*channels+c] != want[i] {
+							t.Fatalf("%s: channel %d frame %d = %g, mono %g", name, c, i, got[i*channels+c], want[i])
+						}
+					}
+				}
+			}
+		}
+	}
+}
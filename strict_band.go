@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "math"
+
+const (
+	minUpsampleRolloff = 0.02
+	maxUpsampleRolloff = 0.5
+
+	strictBandAtten     = 100.0 // Stop band attenuation of the filter, dB
+	strictBandIncrement = 128   // Table entries per input sample, as in lowLatencyCoeffs
+)
+
+// strictBandCoeffs builds the Options.UpsampleRolloff filter: a Kaiser windowed
+// sinc whose pass band ends at 1-rolloff of the Nyquist frequency and whose stop
+// band starts at the Nyquist frequency itself.
+// h(t) = c * sinc(c * t) * kaiser(t / half), c = 1 - rolloff/2, t in input
+// samples, normalised to unity DC gain.
+func strictBandCoeffs(rolloff float64) *coeffData {
+	beta := 0.1102 * (strictBandAtten - 8.7)
+	// Kaiser's estimate of the taps for a transition of rolloff*pi rad/sample
+	half := int(math.Ceil((strictBandAtten - 8) / (2.285 * math.Pi * rolloff) / 2))
+	cutoff := 1 - rolloff/2
+
+	n := half * strictBandIncrement
+	coeffs := make([]float32, n+1) // The last entry stays 0
+	h := make([]float64, n)
+	for i := range h {
+		t := float64(i) / strictBandIncrement
+		x := t / float64(half)
+		h[i] = cutoff * sincPi(cutoff*t) * besselI0(beta*math.Sqrt(1-x*x)) / besselI0(beta)
+	}
+	gain := h[0]
+	for i := strictBandIncrement; i < n; i += strictBandIncrement {
+		gain += 2 * h[i]
+	}
+	for i, v := range h {
+		coeffs[i] = float32(v / gain)
+	}
+	return &coeffData{Increment: strictBandIncrement, Coeffs: coeffs}
+}
+
+// sincPi returns sin(pi*x)/(pi*x).
+func sincPi(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// besselI0 returns the modified Bessel function of the first kind of order 0.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	for k := 1; term > sum*1e-17; k++ {
+		term *= (x / (2 * float64(k))) * (x / (2 * float64(k)))
+		sum += term
+	}
+	return sum
+}
+
+// useStrictBandFilter gives a freshly created sinc converter the
+// Options.UpsampleRolloff filter next to its own, growing its buffer if the
+// new filter is the longer one.
+func (state *srcState) useStrictBandFilter(rolloff float64) {
+	filter, ok := state.privateData.(*sincFilter)
+	if !ok || filter == nil {
+		return
+	}
+	filter.baseCoeffs = &coeffData{Increment: filter.indexInc, Coeffs: filter.coeffs}
+	filter.strictCoeffs = strictBandCoeffs(rolloff)
+	if bLen := sincBufferLen(len(filter.strictCoeffs.Coeffs)-2, filter.strictCoeffs.Increment, state.channels); bLen > filter.bLen {
+		filter.bLen = bLen
+		filter.buffer = make([]float32, bLen+int64(state.channels))
+		sincReset(state)
+	}
+}
+
+// selectUpsampleFilter switches a converter with Options.UpsampleRolloff to the
+// strict filter for a Process call at a ratio above 1, and back to its own
+// filter otherwise.
+func (state *srcState) selectUpsampleFilter(ratio float64) {
+	filter, ok := state.privateData.(*sincFilter)
+	if !ok || filter == nil || filter.strictCoeffs == nil {
+		return
+	}
+	table := filter.baseCoeffs
+	if ratio > 1 {
+		table = filter.strictCoeffs
+	}
+	filter.coeffs = table.Coeffs
+	filter.coeffHalfLen = len(table.Coeffs) - 2
+	filter.indexInc = table.Increment
+}
@@ -0,0 +1,152 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// toneGainDB returns the gain in dB of a tone at freq Hz through an 8kHz to
+// 48kHz conversion with opts.
+func toneGainDB(t *testing.T, ct ConverterType, opts Options, freq float64) float64 {
+	t.Helper()
+	conv, err := NewWithOptions(ct, 1, opts)
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer conv.Close()
+	in := genSine(8000, freq, 8000, 0.5)
+	out, err := processAll(conv, in, 1, 6)
+	if err != nil {
+		t.Fatalf("processAll failed: %v", err)
+	}
+	mid := out[len(out)/4 : 3*len(out)/4]
+	return 20 * math.Log10(rmsGo(mid)/(0.5/math.Sqrt2))
+}
+
+func TestUpsampleRolloffResponse(t *testing.T) {
+	strict := Options{UpsampleRolloff: 0.15} // Pass band up to 3.4kHz at 8kHz
+	for _, ct := range []ConverterType{SincBestQuality, SincFastest} {
+		if g := toneGainDB(t, ct, strict, 3000); math.Abs(g) > 0.05 {
+			t.Errorf("%v: 3kHz gain %.3f dB, want flat", ct, g)
+		}
+		if g := toneGainDB(t, ct, strict, 3800); g > -12 {
+			t.Errorf("%v: 3.8kHz gain %.1f dB with rolloff 0.15, want attenuated", ct, g)
+		}
+		if g := toneGainDB(t, ct, strict, 3900); g > -30 {
+			t.Errorf("%v: 3.9kHz gain %.1f dB with rolloff 0.15, want attenuated", ct, g)
+		}
+		if g := toneGainDB(t, ct, strict, 3990); g > -60 {
+			t.Errorf("%v: 3.99kHz gain %.1f dB with rolloff 0.15, want stopped", ct, g)
+		}
+	}
+	// SincBestQuality on its own passes most of the transition band
+	if g := toneGainDB(t, SincBestQuality, Options{}, 3800); g < -1 {
+		t.Errorf("3.8kHz gain %.1f dB without the option, want passed", g)
+	}
+	// A gentler rolloff lets more of the transition band through
+	if soft, steep := toneGainDB(t, SincMediumQuality, Options{UpsampleRolloff: 0.4}, 3000),
+		toneGainDB(t, SincMediumQuality, Options{UpsampleRolloff: 0.1}, 3000); soft > -1 || steep < -0.05 {
+		t.Errorf("3kHz gain %.2f dB with rolloff 0.4 and %.2f dB with 0.1", soft, steep)
+	}
+}
+
+// Downsampling and the converters without a filter table are not affected.
+func TestUpsampleRolloffUnaffected(t *testing.T) {
+	in := genSine(4000, 700, 16000, 0.5)
+	cases := []struct {
+		ct    ConverterType
+		ratio float64
+	}{
+		{SincMediumQuality, 0.5},
+		{SincFastest, 1},
+		{Linear, 3},
+		{ZeroOrderHold, 3},
+	}
+	for _, tc := range cases {
+		want := resampleWhole(t, tc.ct, in, 1, tc.ratio)
+		conv, err := NewWithOptions(tc.ct, 1, Options{UpsampleRolloff: 0.1})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		got, err := processAll(conv, in, 1, tc.ratio)
+		conv.Close()
+		if err != nil {
+			t.Fatalf("processAll failed: %v", err)
+		}
+		checkSameSamples(t, fmt.Sprintf("%v at %g", tc.ct, tc.ratio), got, want)
+	}
+}
+
+func TestUpsampleRolloffGroupsAndMapper(t *testing.T) {
+	for _, tc := range []struct {
+		channels int
+		opts     Options
+	}{
+		{2*maxChannels + 2, Options{UpsampleRolloff: 0.15}},
+		{2, Options{UpsampleRolloff: 0.15, OutputChannels: 1}},
+	} {
+		conv, err := NewWithOptions(SincFastest, tc.channels, tc.opts)
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		mono := genSine(4000, 3990, 8000, 0.5)
+		in := make([]float32, 0, len(mono)*tc.channels)
+		for _, v := range mono {
+			for ch := 0; ch < tc.channels; ch++ {
+				in = append(in, v)
+			}
+		}
+		out, err := processAll(conv, in, tc.channels, 6)
+		conv.Close()
+		if err != nil {
+			t.Fatalf("%d channels: processAll failed: %v", tc.channels, err)
+		}
+		if rms := rmsGo(out[len(out)/4 : 3*len(out)/4]); rms > 1e-3 {
+			t.Errorf("%d channels: 3.99kHz passed at RMS %g", tc.channels, rms)
+		}
+	}
+}
+
+func TestUpsampleRolloffValidation(t *testing.T) {
+	for _, r := range []float64{-0.1, 0.01, 0.6, math.NaN()} {
+		if _, err := NewWithOptions(SincFastest, 1, Options{UpsampleRolloff: r}); err == nil {
+			t.Errorf("rolloff %g accepted", r)
+		}
+	}
+}
+
+func TestStrictBandCoeffs(t *testing.T) {
+	c := strictBandCoeffs(0.15)
+	var gain float64
+	for i := 0; i < len(c.Coeffs); i += c.Increment {
+		if i == 0 {
+			gain += float64(c.Coeffs[i])
+		} else {
+			gain += 2 * float64(c.Coeffs[i])
+		}
+	}
+	if math.Abs(gain-1) > 1e-6 {
+		t.Errorf("DC gain %g, want 1", gain)
+	}
+	if c.Coeffs[len(c.Coeffs)-1] != 0 {
+		t.Error("table does not end with 0")
+	}
+	if long := strictBandCoeffs(0.05); len(long.Coeffs) <= len(c.Coeffs) {
+		t.Errorf("rolloff 0.05 gives %d coefficients, 0.15 gives %d", len(long.Coeffs), len(c.Coeffs))
+	}
+	if v := besselI0(0); v != 1 {
+		t.Errorf("besselI0(0) = %g", v)
+	}
+	if v := besselI0(2); math.Abs(v-2.2795853023360673) > 1e-12 {
+		t.Errorf("besselI0(2) = %.16g", v)
+	}
+}
@@ -0,0 +1,197 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package telephony
+
+import "fmt"
+
+// Concealment selects how an Inbound fills frames reported lost with Conceal.
+type Concealment int
+
+const (
+	// ConcealSilence fills lost frames with silence.
+	ConcealSilence Concealment = iota
+	// ConcealRepeat repeats the last 20ms of audio, fading out.
+	ConcealRepeat
+	// ConcealWaveform repeats the last pitch period of the audio, fading out
+	// (waveform substitution, as in G.711 Appendix I). It is the least audible
+	// for voice.
+	ConcealWaveform
+)
+
+// IsValid reports whether c is a known concealment.
+func (c Concealment) IsValid() bool {
+	return c >= ConcealSilence && c <= ConcealWaveform
+}
+
+// Concealment parameters, in samples at SampleRate.
+const (
+	concealHistory = 2 * FrameBytes // Audio kept to build the fill from
+	concealHold    = 80             // Fill played at full level (10ms)
+	concealFade    = 400            // Then faded to silence over 50ms
+	concealJoin    = 32             // Crossfade into the first frame after a loss (4ms)
+	minPitch       = 40             // Pitch period search range (200Hz down to 66Hz)
+	maxPitch       = 120
+)
+
+// concealer synthesizes the fill for lost frames from the audio before them and
+// joins the fill to the audio after them.
+type concealer struct {
+	mode    Concealment
+	history []float32 // Last received samples, oldest first
+	cycle   []float32 // Period repeated during the current loss
+	pos     int       // Next sample of cycle
+	lost    int       // Samples filled since the loss started, 0 when none
+}
+
+// fill writes the fill for len(out) lost samples.
+func (c *concealer) fill(out []float32) {
+	if c.lost == 0 {
+		c.cycle = c.buildCycle(c.cycle[:0])
+		c.pos = 0
+	}
+	for i := range out {
+		out[i] = c.next()
+	}
+}
+
+// next returns the next fill sample.
+func (c *concealer) next() float32 {
+	var v float32
+	if len(c.cycle) > 0 {
+		v = c.cycle[c.pos] * concealGain(c.lost)
+		c.pos = (c.pos + 1) % len(c.cycle)
+	}
+	c.lost++
+	return v
+}
+
+// receive joins the received samples in pcm to a preceding fill and adds them
+// to the history.
+func (c *concealer) receive(pcm []float32) {
+	if len(pcm) == 0 {
+		return
+	}
+	if c.lost > 0 {
+		n := minInt(concealJoin, len(pcm))
+		for i := 0; i < n; i++ {
+			t := float32(i+1) / float32(n+1)
+			pcm[i] = t*pcm[i] + (1-t)*c.next()
+		}
+		c.lost = 0
+	}
+	if len(pcm) >= concealHistory {
+		c.history = append(c.history[:0], pcm[len(pcm)-concealHistory:]...)
+		return
+	}
+	if drop := len(c.history) + len(pcm) - concealHistory; drop > 0 {
+		c.history = c.history[:copy(c.history, c.history[drop:])]
+	}
+	c.history = append(c.history, pcm...)
+}
+
+func (c *concealer) reset() {
+	c.history = c.history[:0]
+	c.cycle = c.cycle[:0]
+	c.lost = 0
+}
+
+// buildCycle returns the period of history to repeat, with its end blended
+// into the samples before its start so the repeats join smoothly. It is empty
+// for ConcealSilence or when there is too little history.
+func (c *concealer) buildCycle(cycle []float32) []float32 {
+	var period int
+	switch c.mode {
+	case ConcealRepeat:
+		period = FrameBytes
+	case ConcealWaveform:
+		period = pitchPeriod(c.history)
+	}
+	h := c.history
+	if period == 0 || len(h) < 2*period {
+		return cycle
+	}
+	start := len(h) - period
+	cycle = append(cycle, h[start:]...)
+	join := period / 4
+	for j := period - join; j < period; j++ {
+		t := float32(j-(period-join)+1) / float32(join+1)
+		cycle[j] = (1-t)*cycle[j] + t*h[start-period+j]
+	}
+	return cycle
+}
+
+// pitchPeriod returns the lag in [minPitch, maxPitch] at which the last frame
+// of history best matches the audio before it, or 0 if history is too short.
+func pitchPeriod(history []float32) int {
+	const window = FrameBytes
+	if len(history) < window+maxPitch {
+		return 0
+	}
+	recent := history[len(history)-window:]
+	best, bestScore := 0, -1.0
+	for lag := minPitch; lag <= maxPitch; lag++ {
+		past := history[len(history)-window-lag : len(history)-lag]
+		var corr, energy float64
+		for i := range recent {
+			corr += float64(recent[i]) * float64(past[i])
+			energy += float64(past[i]) * float64(past[i])
+		}
+		if energy == 0 {
+			continue
+		}
+		if score := corr * corr / energy; corr > 0 && score > bestScore {
+			best, bestScore = lag, score
+		}
+	}
+	if best == 0 {
+		return maxPitch // Silence or noise, any period will do
+	}
+	return best
+}
+
+// concealGain returns the level of the fill n samples into a loss.
+func concealGain(n int) float32 {
+	if n < concealHold {
+		return 1
+	}
+	if n >= concealHold+concealFade {
+		return 0
+	}
+	return 1 - float32(n-concealHold)/concealFade
+}
+
+// SetConcealment selects how Conceal fills lost frames; the default is
+// ConcealSilence.
+func (d *Inbound) SetConcealment(c Concealment) error {
+	if !c.IsValid() {
+		return fmt.Errorf("unknown concealment %d", c)
+	}
+	d.plc.mode = c
+	return nil
+}
+
+// Conceal stands in for frames media frames that were lost (e.g. a gap in the
+// chunk numbers): it synthesizes 20ms of fill per frame as selected with
+// SetConcealment and converts it like Decode. The next decoded frame is
+// crossfaded with the continuing fill, so there is no hard discontinuity at
+// either end of the gap for downstream ASR.
+//
+// The returned slice is reused by the next call; copy it to keep it.
+func (d *Inbound) Conceal(frames int) ([]float32, error) {
+	if frames < 0 {
+		return nil, fmt.Errorf("negative number of lost frames %d", frames)
+	}
+	n := frames * FrameBytes
+	if cap(d.pcm) < n {
+		d.pcm = make([]float32, n)
+	}
+	d.pcm = d.pcm[:n]
+	d.plc.fill(d.pcm)
+	return d.resample(d.pcm, false)
+}
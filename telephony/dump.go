@@ -0,0 +1,221 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package telephony
+
+import (
+	"fmt"
+	"math"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// Recorded stream dumps (e.g. last.input.twilio.original.8kHz.bin) store each
+// media frame as a fixed size header followed by the frame's u-law audio.
+const (
+	DumpHeaderBytes = 33                           // Header before each media frame
+	DumpFrameBytes  = DumpHeaderBytes + FrameBytes // Header and audio of one frame
+)
+
+// HeaderMode selects what a DumpTranscoder does with the frame headers.
+type HeaderMode int
+
+const (
+	// StripHeaders drops the headers and outputs the converted audio only.
+	StripHeaders HeaderMode = iota
+	// KeepHeaders writes every header unchanged, followed by exactly 20ms of
+	// converted audio, so the output has the same framing as the dump.
+	KeepHeaders
+)
+
+// DumpTranscoder converts a recorded stream dump, fed in chunks of any size, to
+// S16LE PCM at the output rate. Chunks need not end on a frame boundary; the
+// partial frame is kept for the next Write.
+//
+// With KeepHeaders the audio is delayed against its header by the filter delay
+// (a few samples), like the output of Inbound against its input.
+//
+// NOTE: A DumpTranscoder is NOT goroutine-safe.
+type DumpTranscoder struct {
+	inbound       *Inbound
+	mode          HeaderMode
+	frameSamples  int      // Output samples per frame with KeepHeaders
+	partial       []byte   // Start of a frame not yet complete
+	headers       [][]byte // Headers waiting for their audio (KeepHeaders)
+	pcm           []float32
+	out           []byte
+	framesWritten int64
+}
+
+// NewDumpTranscoder creates a transcoder producing mono S16LE PCM at outRate.
+// With KeepHeaders, 20ms at outRate must be a whole number of samples.
+func NewDumpTranscoder(outRate float64, converterType libsamplerate.ConverterType, mode HeaderMode) (*DumpTranscoder, error) {
+	if mode != StripHeaders && mode != KeepHeaders {
+		return nil, fmt.Errorf("unknown header mode %d", mode)
+	}
+	frameSamples := outRate * FrameMs / 1000
+	if mode == KeepHeaders && (frameSamples < 1 || frameSamples != math.Trunc(frameSamples)) {
+		return nil, fmt.Errorf("%g Hz gives %g samples per %dms frame, want a whole number", outRate, frameSamples, FrameMs)
+	}
+	inbound, err := NewInbound(outRate, converterType)
+	if err != nil {
+		return nil, err
+	}
+	return &DumpTranscoder{inbound: inbound, mode: mode, frameSamples: int(frameSamples)}, nil
+}
+
+// Write converts the complete frames in chunk, together with a partial frame
+// left over from the previous call. It returns the output produced so far.
+//
+// The returned slice is reused by the next call; copy it to keep it.
+func (t *DumpTranscoder) Write(chunk []byte) ([]byte, error) {
+	t.out = t.out[:0]
+	for len(chunk) > 0 {
+		if len(t.partial) > 0 || len(chunk) < DumpFrameBytes {
+			n := minInt(DumpFrameBytes-len(t.partial), len(chunk))
+			t.partial = append(t.partial, chunk[:n]...)
+			chunk = chunk[n:]
+			if len(t.partial) < DumpFrameBytes {
+				break
+			}
+			if err := t.frame(t.partial); err != nil {
+				return nil, err
+			}
+			t.partial = t.partial[:0]
+			continue
+		}
+		if err := t.frame(chunk[:DumpFrameBytes]); err != nil {
+			return nil, err
+		}
+		chunk = chunk[DumpFrameBytes:]
+	}
+	return t.out, nil
+}
+
+// Flush returns the audio still held in the resampler and resets the
+// transcoder for a new dump. It fails if the dump ended inside a frame.
+func (t *DumpTranscoder) Flush() ([]byte, error) {
+	t.out = t.out[:0]
+	if len(t.partial) > 0 {
+		n := len(t.partial)
+		t.partial = t.partial[:0]
+		t.reset()
+		if _, err := t.inbound.Flush(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("dump ends with a partial frame of %d bytes (frames are %d bytes)", n, DumpFrameBytes)
+	}
+	tail, err := t.inbound.Flush()
+	if err != nil {
+		return nil, err
+	}
+	t.pcm = append(t.pcm, tail...)
+	if t.mode == KeepHeaders {
+		// Pad or cut the audio to exactly one frame per header
+		want := len(t.headers) * t.frameSamples
+		if len(t.pcm) < want {
+			t.pcm = append(t.pcm, make([]float32, want-len(t.pcm))...)
+		}
+		t.pcm = t.pcm[:want]
+	}
+	if err := t.emit(); err != nil {
+		return nil, err
+	}
+	t.reset()
+	return t.out, nil
+}
+
+// Frames returns the number of frames converted since the last Flush.
+func (t *DumpTranscoder) Frames() int64 {
+	return t.framesWritten
+}
+
+// Close releases the resampler.
+func (t *DumpTranscoder) Close() error {
+	return t.inbound.Close()
+}
+
+// frame converts one complete frame.
+func (t *DumpTranscoder) frame(frame []byte) error {
+	pcm, err := t.inbound.Decode(frame[DumpHeaderBytes:])
+	if err != nil {
+		return err
+	}
+	t.framesWritten++
+	t.pcm = append(t.pcm, pcm...)
+	if t.mode == KeepHeaders {
+		t.headers = append(t.headers, append([]byte(nil), frame[:DumpHeaderBytes]...))
+	}
+	return t.emit()
+}
+
+// emit moves the converted audio to the output: all of it, or with KeepHeaders
+// one frame's worth behind each waiting header.
+func (t *DumpTranscoder) emit() error {
+	if t.mode == StripHeaders {
+		var err error
+		t.out, err = appendS16LE(t.out, t.pcm)
+		t.pcm = t.pcm[:0]
+		return err
+	}
+	used := 0
+	for len(t.headers) > 0 && len(t.pcm)-used >= t.frameSamples {
+		t.out = append(t.out, t.headers[0]...)
+		var err error
+		if t.out, err = appendS16LE(t.out, t.pcm[used:used+t.frameSamples]); err != nil {
+			return err
+		}
+		t.headers = t.headers[1:]
+		used += t.frameSamples
+	}
+	t.pcm = t.pcm[:copy(t.pcm, t.pcm[used:])]
+	return nil
+}
+
+func (t *DumpTranscoder) reset() {
+	t.headers = t.headers[:0]
+	t.pcm = t.pcm[:0]
+	t.framesWritten = 0
+}
+
+// TranscodeDump converts a whole recorded stream dump to S16LE PCM at outRate.
+// See DumpTranscoder.
+func TranscodeDump(dump []byte, outRate float64, converterType libsamplerate.ConverterType, mode HeaderMode) ([]byte, error) {
+	t, err := NewDumpTranscoder(outRate, converterType, mode)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Close()
+	out, err := t.Write(dump)
+	if err != nil {
+		return nil, err
+	}
+	out = append([]byte(nil), out...)
+	tail, err := t.Flush()
+	if err != nil {
+		return nil, err
+	}
+	return append(out, tail...), nil
+}
+
+// appendS16LE appends pcm to out as S16LE samples.
+func appendS16LE(out []byte, pcm []float32) ([]byte, error) {
+	n := len(out)
+	out = append(out, make([]byte, 2*len(pcm))...)
+	if _, err := libsamplerate.EncodePCM(libsamplerate.FormatS16LE, pcm, out[n:]); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
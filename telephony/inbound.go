@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package telephony
+
+import (
+	"fmt"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// Inbound decodes caller audio: u-law 8kHz media frames to float32 PCM at the
+// requested rate (e.g. 16kHz for ASR). The resampler state is kept across
+// frames, so consecutive frames are joined without clicks.
+//
+// NOTE: An Inbound is NOT goroutine-safe.
+type Inbound struct {
+	outRate float64
+	fanout  *libsamplerate.Fanout
+	pcm     []float32
+	plc     concealer
+}
+
+// NewInbound creates an inbound decoder producing mono audio at outRate.
+func NewInbound(outRate float64, converterType libsamplerate.ConverterType) (*Inbound, error) {
+	f, err := libsamplerate.NewFanout(SampleRate, 1, []libsamplerate.FanoutOutput{
+		{Rate: outRate, ConverterType: converterType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Inbound{outRate: outRate, fanout: f}, nil
+}
+
+// OutputRate returns the rate of the decoded audio.
+func (d *Inbound) OutputRate() float64 {
+	return d.outRate
+}
+
+// Decode converts u-law bytes to float32 samples at the output rate. Because of
+// the filter delay the first calls return slightly fewer samples than the input
+// duration implies; Flush returns the remainder.
+//
+// The returned slice is reused by the next call; copy it to keep it.
+func (d *Inbound) Decode(ulaw []byte) ([]float32, error) {
+	return d.process(ulaw, false)
+}
+
+// DecodeMessage parses a websocket message and decodes its audio. It returns
+// nil, nil for events that carry no audio.
+func (d *Inbound) DecodeMessage(data []byte) ([]float32, error) {
+	m, err := ParseMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	ulaw, err := m.Audio()
+	if err != nil || ulaw == nil {
+		return nil, err
+	}
+	return d.Decode(ulaw)
+}
+
+// Flush returns the audio still held in the resampler, e.g. on a "stop" event,
+// and resets the decoder for a new stream.
+func (d *Inbound) Flush() ([]float32, error) {
+	out, err := d.process(nil, true)
+	if err != nil {
+		return nil, err
+	}
+	out = append([]float32(nil), out...) // Reset below clears the fanout buffers
+	if err := d.fanout.Reset(); err != nil {
+		return nil, err
+	}
+	d.plc.reset()
+	return out, nil
+}
+
+// Close releases the resampler.
+func (d *Inbound) Close() error {
+	return d.fanout.Close()
+}
+
+func (d *Inbound) process(ulaw []byte, endOfInput bool) ([]float32, error) {
+	if cap(d.pcm) < len(ulaw) {
+		d.pcm = make([]float32, len(ulaw))
+	}
+	d.pcm = d.pcm[:len(ulaw)]
+	libsamplerate.UlawToFloatArray(ulaw, d.pcm)
+	d.plc.receive(d.pcm)
+	return d.resample(d.pcm, endOfInput)
+}
+
+// resample converts pcm at SampleRate to the output rate.
+func (d *Inbound) resample(pcm []float32, endOfInput bool) ([]float32, error) {
+	res, err := d.fanout.Process(pcm, endOfInput)
+	if err != nil {
+		return nil, fmt.Errorf("inbound resampling failed: %w", err)
+	}
+	return res[0], nil
+}
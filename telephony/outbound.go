@@ -0,0 +1,178 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package telephony
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+const defaultOutboundBufferMs = 10000 // Default ring buffer length
+
+// Outbound encodes return audio (e.g. TTS output) for the media stream: PCM at
+// any rate is resampled to 8kHz, u-law encoded and queued in a ring buffer, from
+// which 20ms frames are taken one at a time, either directly with NextFrame or
+// in real time with Pace.
+//
+// Write and the frame readers may be called from different goroutines.
+type Outbound struct {
+	inRate float64
+
+	mu      sync.Mutex
+	fanout  *libsamplerate.Fanout
+	ring    *byteRing
+	ulaw    []byte
+	dropped int64
+
+	interval time.Duration // Pacing interval, one frame per tick
+}
+
+// NewOutbound creates an outbound encoder for mono PCM at inRate. bufferMs is
+// the ring buffer length in milliseconds (0 means 10s); when a writer gets more
+// than that ahead of playback, the oldest audio is dropped.
+func NewOutbound(inRate float64, converterType libsamplerate.ConverterType, bufferMs int) (*Outbound, error) {
+	if bufferMs < 0 {
+		return nil, fmt.Errorf("bufferMs must not be negative, got %d", bufferMs)
+	}
+	if bufferMs == 0 {
+		bufferMs = defaultOutboundBufferMs
+	}
+	frames := (bufferMs + FrameMs - 1) / FrameMs
+	f, err := libsamplerate.NewFanout(inRate, 1, []libsamplerate.FanoutOutput{
+		{Rate: SampleRate, ConverterType: converterType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Outbound{
+		inRate:   inRate,
+		fanout:   f,
+		ring:     newByteRing(frames * FrameBytes),
+		interval: FrameMs * time.Millisecond,
+	}, nil
+}
+
+// Write resamples and encodes mono PCM samples and queues them for sending.
+func (o *Outbound) Write(pcm []float32) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.writeLocked(pcm, false)
+}
+
+// Flush pushes out the audio held in the resampler and pads the queue with
+// silence to a whole frame, so the end of an utterance is not cut off.
+func (o *Outbound) Flush() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.writeLocked(nil, true); err != nil {
+		return err
+	}
+	if err := o.fanout.Reset(); err != nil {
+		return err
+	}
+	if partial := o.ring.len() % FrameBytes; partial != 0 {
+		pad := make([]byte, FrameBytes-partial)
+		for i := range pad {
+			pad[i] = UlawSilence
+		}
+		o.dropped += int64(o.ring.write(pad))
+	}
+	return nil
+}
+
+// Clear drops all queued audio and resets the resampler, e.g. on barge-in. Send
+// a ClearMessage as well so Twilio drops what it has already buffered.
+func (o *Outbound) Clear() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ring.discard(o.ring.len())
+	return o.fanout.Reset()
+}
+
+// Buffered returns the number of queued u-law bytes (8 per millisecond).
+func (o *Outbound) Buffered() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.ring.len()
+}
+
+// Dropped returns the number of u-law bytes dropped because the ring buffer was full.
+func (o *Outbound) Dropped() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.dropped
+}
+
+// NextFrame fills frame (FrameBytes long) with the next 20ms of audio. It
+// returns false, leaving frame untouched, if less than a full frame is queued.
+func (o *Outbound) NextFrame(frame []byte) bool {
+	if len(frame) != FrameBytes {
+		return false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.ring.len() < FrameBytes {
+		return false
+	}
+	o.ring.read(frame)
+	return true
+}
+
+// Pace sends one media message every 20ms while audio is queued, until ctx is
+// cancelled or send fails. Ticks with less than a frame queued send nothing.
+// Twilio plays frames as they arrive, so pacing them keeps the Twilio-side buffer
+// short and lets Clear take effect quickly.
+func (o *Outbound) Pace(ctx context.Context, streamSid string, send func(msg []byte) error) error {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	frame := make([]byte, FrameBytes)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if !o.NextFrame(frame) {
+			continue
+		}
+		msg, err := MediaMessage(streamSid, frame)
+		if err != nil {
+			return err
+		}
+		if err := send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// Close releases the resampler.
+func (o *Outbound) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.fanout.Close()
+}
+
+func (o *Outbound) writeLocked(pcm []float32, endOfInput bool) error {
+	res, err := o.fanout.Process(pcm, endOfInput)
+	if err != nil {
+		return fmt.Errorf("outbound resampling failed: %w", err)
+	}
+	out := res[0]
+	if cap(o.ulaw) < len(out) {
+		o.ulaw = make([]byte, len(out))
+	}
+	o.ulaw = o.ulaw[:len(out)]
+	libsamplerate.FloatToUlawArray(out, o.ulaw)
+	o.dropped += int64(o.ring.write(o.ulaw))
+	return nil
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package telephony
+
+// byteRing is a fixed-capacity FIFO of bytes. Writing past capacity drops the
+// oldest bytes, so a slow consumer loses stale audio rather than falling behind.
+type byteRing struct {
+	buf   []byte
+	start int // Index of the oldest byte
+	size  int // Number of bytes stored
+}
+
+func newByteRing(capacity int) *byteRing {
+	return &byteRing{buf: make([]byte, capacity)}
+}
+
+// write appends p and returns the number of old bytes dropped to make room.
+func (r *byteRing) write(p []byte) int {
+	dropped := 0
+	if len(p) > len(r.buf) { // Only the newest capacity bytes can survive
+		dropped += len(p) - len(r.buf)
+		p = p[len(p)-len(r.buf):]
+	}
+	if over := r.size + len(p) - len(r.buf); over > 0 {
+		r.discard(over)
+		dropped += over
+	}
+	end := (r.start + r.size) % len(r.buf)
+	n := copy(r.buf[end:], p)
+	copy(r.buf, p[n:])
+	r.size += len(p)
+	return dropped
+}
+
+// read moves up to len(p) of the oldest bytes into p.
+func (r *byteRing) read(p []byte) int {
+	count := len(p)
+	if count > r.size {
+		count = r.size
+	}
+	n := copy(p[:count], r.buf[r.start:])
+	copy(p[n:count], r.buf)
+	r.discard(count)
+	return count
+}
+
+func (r *byteRing) discard(n int) {
+	r.start = (r.start + n) % len(r.buf)
+	r.size -= n
+	if r.size == 0 {
+		r.start = 0
+	}
+}
+
+func (r *byteRing) len() int {
+	return r.size
+}
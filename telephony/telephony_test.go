@@ -0,0 +1,495 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package telephony
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+// tone returns frames samples of a sine at freq Hz.
+func tone(frames int, freq, rate float64) []float32 {
+	out := make([]float32, frames)
+	for i := range out {
+		out[i] = float32(0.5 * math.Sin(2*math.Pi*freq*float64(i)/rate))
+	}
+	return out
+}
+
+func TestParseMessage(t *testing.T) {
+	payload := []byte{0xFF, 0x7F, 0x00, 0x80}
+	msg := fmt.Sprintf(`{"event":"media","sequenceNumber":"3","media":{"track":"inbound","chunk":"1","timestamp":"5","payload":"%s"},"streamSid":"MZ123"}`,
+		base64.StdEncoding.EncodeToString(payload))
+
+	m, err := ParseMessage([]byte(msg))
+	if err != nil {
+		t.Fatalf("ParseMessage failed: %v", err)
+	}
+	if m.Event != EventMedia || m.StreamSid != "MZ123" || m.Media.Track != "inbound" {
+		t.Errorf("unexpected message %+v", m)
+	}
+	audio, err := m.Audio()
+	if err != nil || !bytes.Equal(audio, payload) {
+		t.Errorf("Audio() = %v, %v; want %v", audio, err, payload)
+	}
+
+	stop, err := ParseMessage([]byte(`{"event":"stop","streamSid":"MZ123"}`))
+	if err != nil {
+		t.Fatalf("ParseMessage failed: %v", err)
+	}
+	if audio, err := stop.Audio(); audio != nil || err != nil {
+		t.Errorf("stop event Audio() = %v, %v; want nil, nil", audio, err)
+	}
+
+	for _, bad := range []string{`not json`, `{}`} {
+		if _, err := ParseMessage([]byte(bad)); err == nil {
+			t.Errorf("ParseMessage(%q): expected error", bad)
+		}
+	}
+	bad := &Message{Event: EventMedia, Media: &Media{Payload: "!!"}}
+	if _, err := bad.Audio(); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+
+	out, err := MediaMessage("MZ123", payload)
+	if err != nil {
+		t.Fatalf("MediaMessage failed: %v", err)
+	}
+	back, err := ParseMessage(out)
+	if err != nil {
+		t.Fatalf("ParseMessage(MediaMessage) failed: %v", err)
+	}
+	if audio, _ := back.Audio(); !bytes.Equal(audio, payload) || back.StreamSid != "MZ123" {
+		t.Errorf("media message round trip: %s", out)
+	}
+}
+
+func TestInboundDecode(t *testing.T) {
+	const outRate = 16000.0
+	ulaw := make([]byte, 8000) // 1 second
+	libsamplerate.FloatToUlawArray(tone(len(ulaw), 440, SampleRate), ulaw)
+
+	d, err := NewInbound(outRate, libsamplerate.SincFastest)
+	if err != nil {
+		t.Fatalf("NewInbound failed: %v", err)
+	}
+	defer d.Close()
+
+	var pcm []float32
+	for pos := 0; pos < len(ulaw); pos += FrameBytes {
+		msg, err := MediaMessage("MZ1", ulaw[pos:pos+FrameBytes])
+		if err != nil {
+			t.Fatalf("MediaMessage failed: %v", err)
+		}
+		out, err := d.DecodeMessage(msg)
+		if err != nil {
+			t.Fatalf("DecodeMessage failed: %v", err)
+		}
+		pcm = append(pcm, out...)
+	}
+	if out, err := d.DecodeMessage([]byte(`{"event":"mark","mark":{"name":"x"}}`)); out != nil || err != nil {
+		t.Errorf("mark event decoded to %d samples, err %v", len(out), err)
+	}
+	tail, err := d.Flush()
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	pcm = append(pcm, tail...)
+
+	if math.Abs(float64(len(pcm))-outRate) > 2 {
+		t.Errorf("decoded %d samples, want about %.0f", len(pcm), outRate)
+	}
+	var energy float64
+	for _, v := range pcm[1000:15000] {
+		energy += float64(v) * float64(v)
+	}
+	if rms := math.Sqrt(energy / 14000); math.Abs(rms-0.5/math.Sqrt2) > 0.02 {
+		t.Errorf("decoded tone RMS %.3f, want about %.3f", rms, 0.5/math.Sqrt2)
+	}
+}
+
+func TestOutboundFrames(t *testing.T) {
+	const inRate = 24000.0
+	o, err := NewOutbound(inRate, libsamplerate.SincFastest, 0)
+	if err != nil {
+		t.Fatalf("NewOutbound failed: %v", err)
+	}
+	defer o.Close()
+
+	pcm := tone(int(inRate), 300, inRate)             // 1 second
+	for pos, n := 0, 1; pos < len(pcm); n = n*3 + 7 { // Irregular chunk sizes
+		end := pos + n
+		if end > len(pcm) {
+			end = len(pcm)
+		}
+		if err := o.Write(pcm[pos:end]); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		pos = end
+	}
+	if err := o.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if o.Buffered()%FrameBytes != 0 {
+		t.Errorf("Buffered() = %d after Flush, want whole frames", o.Buffered())
+	}
+
+	frames := 0
+	frame := make([]byte, FrameBytes)
+	for o.NextFrame(frame) {
+		frames++
+	}
+	if frames < 50 || frames > 51 {
+		t.Errorf("got %d frames, want 50 (1 second)", frames)
+	}
+	if o.Dropped() != 0 {
+		t.Errorf("Dropped() = %d, want 0", o.Dropped())
+	}
+	if o.NextFrame(make([]byte, 10)) {
+		t.Error("NextFrame accepted a short buffer")
+	}
+}
+
+func TestOutboundOverflowAndClear(t *testing.T) {
+	o, err := NewOutbound(8000, libsamplerate.Linear, 100) // 5 frames
+	if err != nil {
+		t.Fatalf("NewOutbound failed: %v", err)
+	}
+	defer o.Close()
+
+	if err := o.Write(tone(8000, 300, 8000)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if o.Buffered() != 5*FrameBytes {
+		t.Errorf("Buffered() = %d, want %d", o.Buffered(), 5*FrameBytes)
+	}
+	if o.Dropped() == 0 {
+		t.Error("expected dropped audio")
+	}
+	if err := o.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if o.Buffered() != 0 {
+		t.Errorf("Buffered() = %d after Clear", o.Buffered())
+	}
+	if _, err := NewOutbound(8000, libsamplerate.Linear, -1); err == nil {
+		t.Error("expected error for negative buffer")
+	}
+}
+
+func TestOutboundPace(t *testing.T) {
+	o, err := NewOutbound(8000, libsamplerate.Linear, 0)
+	if err != nil {
+		t.Fatalf("NewOutbound failed: %v", err)
+	}
+	defer o.Close()
+	o.interval = time.Millisecond
+
+	if err := o.Write(tone(3*FrameBytes, 300, 8000)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := o.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	want := o.Buffered() / FrameBytes
+
+	errDone := errors.New("done")
+	var sent [][]byte
+	send := func(msg []byte) error {
+		sent = append(sent, msg)
+		if len(sent) == want {
+			return errDone
+		}
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := o.Pace(ctx, "MZ9", send); !errors.Is(err, errDone) {
+		t.Fatalf("Pace returned %v, want send error", err)
+	}
+	for _, msg := range sent {
+		m, err := ParseMessage(msg)
+		if err != nil {
+			t.Fatalf("sent invalid message: %v", err)
+		}
+		if audio, _ := m.Audio(); len(audio) != FrameBytes || m.StreamSid != "MZ9" {
+			t.Errorf("sent frame of %d bytes for %q", len(audio), m.StreamSid)
+		}
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if err := o.Pace(ctx2, "MZ9", send); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pace on empty queue returned %v, want deadline exceeded", err)
+	}
+}
+
+func TestByteRing(t *testing.T) {
+	r := newByteRing(5)
+	if d := r.write([]byte{1, 2, 3}); d != 0 {
+		t.Errorf("dropped %d, want 0", d)
+	}
+	p := make([]byte, 2)
+	r.read(p)
+	if d := r.write([]byte{4, 5, 6, 7}); d != 0 { // Wraps around
+		t.Errorf("dropped %d, want 0", d)
+	}
+	if d := r.write([]byte{8, 9}); d != 2 {
+		t.Errorf("dropped %d, want 2", d)
+	}
+	all := make([]byte, 10)
+	n := r.read(all)
+	if !bytes.Equal(all[:n], []byte{5, 6, 7, 8, 9}) {
+		t.Errorf("ring contents %v, want [5 6 7 8 9]", all[:n])
+	}
+	if d := r.write([]byte{1, 2, 3, 4, 5, 6, 7}); d != 2 || r.len() != 5 {
+		t.Errorf("oversized write dropped %d, len %d", d, r.len())
+	}
+}
+
+// testDump returns a dump of frames media frames of a tone, each header holding
+// the frame number.
+func testDump(frames int) (dump, ulaw []byte) {
+	ulaw = make([]byte, frames*FrameBytes)
+	libsamplerate.FloatToUlawArray(tone(len(ulaw), 440, SampleRate), ulaw)
+	for i := 0; i < frames; i++ {
+		header := bytes.Repeat([]byte{byte(i)}, DumpHeaderBytes)
+		dump = append(dump, header...)
+		dump = append(dump, ulaw[i*FrameBytes:(i+1)*FrameBytes]...)
+	}
+	return dump, ulaw
+}
+
+func TestTranscodeDumpStrip(t *testing.T) {
+	dump, ulaw := testDump(50)
+	got, err := TranscodeDump(dump, 16000, libsamplerate.SincFastest, StripHeaders)
+	if err != nil {
+		t.Fatalf("TranscodeDump failed: %v", err)
+	}
+
+	// The same as decoding the bare audio
+	d, err := NewInbound(16000, libsamplerate.SincFastest)
+	if err != nil {
+		t.Fatalf("NewInbound failed: %v", err)
+	}
+	defer d.Close()
+	pcm, _ := d.Decode(ulaw)
+	pcm = append([]float32(nil), pcm...)
+	tail, _ := d.Flush()
+	pcm = append(pcm, tail...)
+	want := make([]byte, 2*len(pcm))
+	libsamplerate.EncodePCM(libsamplerate.FormatS16LE, pcm, want)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got %d bytes, want the %d bytes of the bare audio", len(got), len(want))
+	}
+
+	// Chunk boundaries anywhere give the same output
+	tr, err := NewDumpTranscoder(16000, libsamplerate.SincFastest, StripHeaders)
+	if err != nil {
+		t.Fatalf("NewDumpTranscoder failed: %v", err)
+	}
+	defer tr.Close()
+	var chunked []byte
+	for pos, n := 0, 1; pos < len(dump); pos, n = pos+n, n*3%250+1 {
+		out, err := tr.Write(dump[pos:minInt(pos+n, len(dump))])
+		if err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		chunked = append(chunked, out...)
+	}
+	if tr.Frames() != 50 {
+		t.Errorf("%d frames converted, want 50", tr.Frames())
+	}
+	out, err := tr.Flush()
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if chunked = append(chunked, out...); !bytes.Equal(chunked, want) {
+		t.Errorf("chunked writes gave %d bytes, want %d", len(chunked), len(want))
+	}
+}
+
+func TestTranscodeDumpKeepHeaders(t *testing.T) {
+	const frames, frameOut = 50, DumpHeaderBytes + 2*320 // 20ms at 16kHz
+	dump, _ := testDump(frames)
+	got, err := TranscodeDump(dump, 16000, libsamplerate.SincBestQuality, KeepHeaders)
+	if err != nil {
+		t.Fatalf("TranscodeDump failed: %v", err)
+	}
+	if len(got) != frames*frameOut {
+		t.Fatalf("got %d bytes, want %d frames of %d", len(got), frames, frameOut)
+	}
+	var energy float64
+	var n int
+	for i := 0; i < frames; i++ {
+		frame := got[i*frameOut : (i+1)*frameOut]
+		if !bytes.Equal(frame[:DumpHeaderBytes], bytes.Repeat([]byte{byte(i)}, DumpHeaderBytes)) {
+			t.Fatalf("frame %d has header %v", i, frame[:DumpHeaderBytes])
+		}
+		if i < 5 || i >= frames-5 {
+			continue
+		}
+		for p := DumpHeaderBytes; p < frameOut; p += 2 {
+			v := float64(int16(uint16(frame[p])|uint16(frame[p+1])<<8)) / 32768
+			energy += v * v
+			n++
+		}
+	}
+	if rms := math.Sqrt(energy / float64(n)); math.Abs(rms-0.5/math.Sqrt2) > 0.02 {
+		t.Errorf("audio RMS %.3f, want about %.3f", rms, 0.5/math.Sqrt2)
+	}
+}
+
+func TestTranscodeDumpErrors(t *testing.T) {
+	dump, _ := testDump(3)
+	if _, err := TranscodeDump(dump[:len(dump)-7], 16000, libsamplerate.SincFastest, StripHeaders); err == nil {
+		t.Error("dump ending inside a frame accepted")
+	}
+	if _, err := NewDumpTranscoder(11025, libsamplerate.SincFastest, KeepHeaders); err == nil {
+		t.Error("KeepHeaders at a rate without whole frames accepted")
+	}
+	if _, err := NewDumpTranscoder(11025, libsamplerate.SincFastest, StripHeaders); err != nil {
+		t.Errorf("StripHeaders at 11025 Hz failed: %v", err)
+	}
+	if _, err := NewDumpTranscoder(16000, libsamplerate.SincFastest, HeaderMode(7)); err == nil {
+		t.Error("unknown header mode accepted")
+	}
+}
+
+// decodeWithLoss decodes ulaw frame by frame, concealing the frames in lost.
+func decodeWithLoss(t *testing.T, ulaw []byte, mode Concealment, lost map[int]bool) []float32 {
+	t.Helper()
+	d, err := NewInbound(16000, libsamplerate.SincFastest)
+	if err != nil {
+		t.Fatalf("NewInbound failed: %v", err)
+	}
+	defer d.Close()
+	if err := d.SetConcealment(mode); err != nil {
+		t.Fatalf("SetConcealment failed: %v", err)
+	}
+	var pcm []float32
+	for frame := 0; frame*FrameBytes < len(ulaw); frame++ {
+		var out []float32
+		if lost[frame] {
+			out, err = d.Conceal(1)
+		} else {
+			out, err = d.Decode(ulaw[frame*FrameBytes : (frame+1)*FrameBytes])
+		}
+		if err != nil {
+			t.Fatalf("frame %d: %v", frame, err)
+		}
+		pcm = append(pcm, out...)
+	}
+	return pcm
+}
+
+// maxStep returns the largest difference between neighbouring samples.
+func maxStep(pcm []float32) float64 {
+	var step float64
+	for i := 1; i < len(pcm); i++ {
+		step = math.Max(step, math.Abs(float64(pcm[i]-pcm[i-1])))
+	}
+	return step
+}
+
+func TestInboundConceal(t *testing.T) {
+	ulaw := make([]byte, 10*FrameBytes)
+	libsamplerate.FloatToUlawArray(tone(len(ulaw), 200, SampleRate), ulaw)
+	ref := decodeWithLoss(t, ulaw, ConcealSilence, nil)
+	lost := map[int]bool{5: true}
+
+	// The first 10ms of a lost frame of a steady tone continue it closely; frame
+	// 5 is output samples 1600-1920 at 16kHz
+	for _, tc := range []struct {
+		mode    Concealment
+		maxDiff float64
+	}{
+		{ConcealSilence, 1},
+		{ConcealRepeat, 0.05},
+		{ConcealWaveform, 0.05},
+	} {
+		got := decodeWithLoss(t, ulaw, tc.mode, lost)
+		if len(got) != len(ref) {
+			t.Fatalf("mode %d: %d samples, want %d", tc.mode, len(got), len(ref))
+		}
+		var diff float64
+		for i := 1600; i < 1760; i++ {
+			diff = math.Max(diff, math.Abs(float64(got[i]-ref[i])))
+		}
+		if diff > tc.maxDiff || (tc.mode == ConcealSilence && diff < 0.3) {
+			t.Errorf("mode %d: concealed frame differs from the tone by up to %.3f", tc.mode, diff)
+		}
+	}
+
+	// A tone whose period is not a whole number of samples still joins smoothly
+	libsamplerate.FloatToUlawArray(tone(len(ulaw), 230, SampleRate), ulaw)
+	ref = decodeWithLoss(t, ulaw, ConcealSilence, nil)
+	got := decodeWithLoss(t, ulaw, ConcealWaveform, map[int]bool{4: true, 5: true})
+	refStep := maxStep(ref[200:])
+	if step := maxStep(got[200:]); step > 1.2*refStep {
+		t.Errorf("largest step %.3f with waveform substitution, %.3f in the tone", step, refStep)
+	}
+	silent := decodeWithLoss(t, ulaw, ConcealSilence, map[int]bool{4: true, 5: true})
+	if step := maxStep(silent[200:]); step < 2*refStep {
+		t.Errorf("largest step %.3f with silence, %.3f in the tone; expected a hard edge", step, refStep)
+	}
+}
+
+func TestInboundConcealLongLoss(t *testing.T) {
+	ulaw := make([]byte, 12*FrameBytes)
+	libsamplerate.FloatToUlawArray(tone(len(ulaw), 300, SampleRate), ulaw)
+	lost := map[int]bool{}
+	for frame := 3; frame < 9; frame++ {
+		lost[frame] = true
+	}
+	got := decodeWithLoss(t, ulaw, ConcealWaveform, lost)
+	// The fill fades out within 60ms: frames 7 and 8 (after the filter delay) are silent
+	for i := 2240; i < 2880; i++ {
+		if math.Abs(float64(got[i])) > 1e-3 {
+			t.Fatalf("sample %d = %g, want silence after 60ms of loss", i, got[i])
+		}
+	}
+	// The audio after the gap fades back in rather than starting hard
+	if step := maxStep(got[2880:]); step > maxStep(got[200:960])*1.2 {
+		t.Errorf("step of %.3f after the gap", step)
+	}
+}
+
+func TestInboundConcealErrors(t *testing.T) {
+	d, err := NewInbound(16000, libsamplerate.SincFastest)
+	if err != nil {
+		t.Fatalf("NewInbound failed: %v", err)
+	}
+	defer d.Close()
+	if err := d.SetConcealment(Concealment(7)); err == nil {
+		t.Error("unknown concealment accepted")
+	}
+	if _, err := d.Conceal(-1); err == nil {
+		t.Error("negative frame count accepted")
+	}
+	d.SetConcealment(ConcealWaveform)
+	// A loss before any audio is silence
+	out, err := d.Conceal(2)
+	if err != nil {
+		t.Fatalf("Conceal failed: %v", err)
+	}
+	for i, v := range out {
+		if v != 0 {
+			t.Fatalf("sample %d = %g before any audio, want 0", i, v)
+		}
+	}
+}
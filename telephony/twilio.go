@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+// Package telephony adapts libsamplerate to telephony media streams, in
+// particular Twilio Media Streams: 8kHz u-law audio carried as base64 in JSON
+// websocket messages, 20ms per frame.
+package telephony
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// --- Media Stream Constants ---
+const (
+	SampleRate  = 8000.0 // Media stream sample rate in Hz
+	FrameMs     = 20     // Duration of one media frame
+	FrameBytes  = 160    // u-law bytes (= samples) in one 20ms frame
+	UlawSilence = 0x7F   // u-law code for zero, as produced by the libsamplerate encoder
+)
+
+// Media stream event names.
+const (
+	EventConnected = "connected"
+	EventStart     = "start"
+	EventMedia     = "media"
+	EventMark      = "mark"
+	EventClear     = "clear"
+	EventStop      = "stop"
+)
+
+// Message is a Twilio Media Streams websocket message. Only the fields needed
+// to move audio are decoded; other events can be told apart by Event.
+type Message struct {
+	Event          string `json:"event"`
+	SequenceNumber string `json:"sequenceNumber,omitempty"`
+	StreamSid      string `json:"streamSid,omitempty"`
+	Media          *Media `json:"media,omitempty"`
+	Mark           *Mark  `json:"mark,omitempty"`
+}
+
+// Media is the payload of a "media" event. Payload is base64 u-law audio.
+type Media struct {
+	Track     string `json:"track,omitempty"`
+	Chunk     string `json:"chunk,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Payload   string `json:"payload"`
+}
+
+// Mark is the payload of a "mark" event.
+type Mark struct {
+	Name string `json:"name"`
+}
+
+// ParseMessage decodes a websocket text message.
+func ParseMessage(data []byte) (*Message, error) {
+	var m Message
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid media stream message: %w", err)
+	}
+	if m.Event == "" {
+		return nil, fmt.Errorf("media stream message has no event")
+	}
+	return &m, nil
+}
+
+// Audio returns the decoded u-law bytes of a "media" message, or nil for
+// other events.
+func (m *Message) Audio() ([]byte, error) {
+	if m.Event != EventMedia || m.Media == nil {
+		return nil, nil
+	}
+	ulaw, err := base64.StdEncoding.DecodeString(m.Media.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid media payload: %w", err)
+	}
+	return ulaw, nil
+}
+
+// MediaMessage builds an outbound "media" message carrying the given u-law audio.
+func MediaMessage(streamSid string, ulaw []byte) ([]byte, error) {
+	return json.Marshal(Message{
+		Event:     EventMedia,
+		StreamSid: streamSid,
+		Media:     &Media{Payload: base64.StdEncoding.EncodeToString(ulaw)},
+	})
+}
+
+// ClearMessage builds an outbound "clear" message, which makes Twilio drop the
+// audio it has buffered (e.g. when the caller barges in).
+func ClearMessage(streamSid string) ([]byte, error) {
+	return json.Marshal(Message{Event: EventClear, StreamSid: streamSid})
+}
+
+// MarkMessage builds an outbound "mark" message; Twilio echoes it back once the
+// audio sent before it has been played.
+func MarkMessage(streamSid, name string) ([]byte, error) {
+	return json.Marshal(Message{Event: EventMark, StreamSid: streamSid, Mark: &Mark{Name: name}})
+}
@@ -391,3 +391,103 @@ func testTerminationStream(t *testing.T, converter ConverterType, srcRatio float
 	}
 }
 
+// TestEndOfInputLengthParity checks that every converter type ends the stream
+// within one frame of round(frames*ratio), whether the input comes in one block
+// or many.
+func TestEndOfInputLengthParity(t *testing.T) {
+	converters := []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, ZeroOrderHold, Linear}
+	for _, frames := range []int{37, 1000, 4001} {
+		in := genSine(frames, 440, 16000, 0.5)
+		for _, ratio := range []float64{0.1, 1.0 / 3, 0.5, 0.9, 1, 1.37, 2, 6, 10.5} {
+			want := math.Round(float64(frames) * ratio)
+			for _, ct := range converters {
+				conv, err := NewWithOptions(ct, 1, Options{ForceFilter: true})
+				if err != nil {
+					t.Fatalf("NewWithOptions failed: %v", err)
+				}
+				whole, err := processAll(conv, in, 1, ratio)
+				if err != nil {
+					t.Fatalf("processAll failed: %v", err)
+				}
+				if err := conv.Reset(); err != nil {
+					t.Fatalf("Reset failed: %v", err)
+				}
+				blocks := stream(t, conv, in, 1, 1, ratio, 333, 1024, false)
+				flush := SrcData{SrcRatio: ratio, EndOfInput: true}
+				if err := ProcessAppend(conv, &flush, &blocks); err != nil {
+					t.Fatalf("ProcessAppend failed: %v", err)
+				}
+				conv.Close()
+				if math.Abs(float64(len(whole))-want) > 1 || len(blocks) != len(whole) {
+					t.Errorf("%v, %d frames at ratio %g: %d frames in one block and %d in blocks, want %g +/- 1",
+						ct, frames, ratio, len(whole), len(blocks), want)
+				}
+			}
+		}
+	}
+}
+
+// TestRatioChangeDuringDrain changes the ratio once the input has all been
+// consumed and the converter is draining: the rest of the input must come out at
+// the new ratio, including when the wider filter of a lower ratio reaches past
+// the padding added at the end of input.
+func TestRatioChangeDuringDrain(t *testing.T) {
+	const frames, changeAt = 3000, 2900 // Output frames at ratio 1 before the change
+	converters := []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, ZeroOrderHold, Linear}
+	for _, channels := range []int{1, 2, 2 * maxChannels} {
+		for _, ct := range converters {
+			for _, ratio := range []float64{1.0 / 256, 0.25, 0.5, 4, 256} {
+				for _, viaSetRatio := range []bool{true, false} {
+					conv, err := NewWithOptions(ct, channels, Options{ForceFilter: true})
+					if err != nil {
+						t.Fatalf("NewWithOptions failed: %v", err)
+					}
+					in := make([]float32, frames*channels)
+					copy(in, genSine(len(in), 440, 16000, 0.5))
+					out := make([]float32, 50*channels)
+					data := SrcData{DataIn: in, InputFrames: frames, DataOut: out, OutputFrames: 50, SrcRatio: 1, EndOfInput: true}
+					var gen, after int64
+					changed := false
+					for calls := 0; ; calls++ {
+						if calls > 100000 {
+							t.Fatalf("%v: drain does not end", ct)
+						}
+						if err := conv.Process(&data); err != nil {
+							t.Fatalf("%v: Process failed: %v", ct, err)
+						}
+						gen += data.OutputFramesGen
+						if changed {
+							after += data.OutputFramesGen
+						}
+						data.DataIn = data.DataIn[data.InputFramesUsed*int64(channels):]
+						data.InputFrames -= data.InputFramesUsed
+						if data.OutputFramesGen == 0 && data.InputFramesUsed == 0 {
+							break
+						}
+						if !changed && gen >= changeAt {
+							if viaSetRatio {
+								if err := conv.SetRatio(ratio); err != nil {
+									t.Fatalf("SetRatio failed: %v", err)
+								}
+							}
+							data.SrcRatio, changed = ratio, true
+						}
+					}
+					conv.Close()
+					if data.InputFrames != 0 {
+						t.Errorf("%v: %d input frames left", ct, data.InputFrames)
+					}
+					// With SrcRatio alone the ratio moves over one 50 frame block
+					want, tolerance := (frames-changeAt)*ratio, 1.5
+					if !viaSetRatio {
+						tolerance += 50 * math.Abs(1-ratio)
+					}
+					if d := float64(after) - want; math.Abs(d) > tolerance {
+						t.Errorf("%v, %d channels, ratio 1 to %g (SetRatio %t): %d frames after the change, want %g",
+							ct, channels, ratio, viaSetRatio, after, want)
+					}
+				}
+			}
+		}
+	}
+}
@@ -87,66 +87,3 @@ func minInt64(a, b int64) int64 {
 	}
 	return b
 }
-
-// interleaveDataGo converts separate channel buffers into a single interleaved buffer.
-// 'in' is a slice where each element is a slice representing one channel's data.
-// 'out' is the destination interleaved buffer.
-// Assumes len(in) == channels and len(in[0]) == frames (and all channels have same length).
-// Assumes len(out) >= frames * channels.
-func interleaveDataGo(in [][]float32, out []float32, frames int, channels int) error {
-	if len(in) != channels {
-		return fmt.Errorf("interleaveDataGo: input slice count (%d) != channels (%d)", len(in), channels)
-	}
-	if channels == 0 || frames == 0 {
-		return nil
-	} // Nothing to do
-	if len(in[0]) < frames {
-		return fmt.Errorf("interleaveDataGo: input channel 0 length (%d) < frames (%d)", len(in[0]), frames)
-	}
-	if len(out) < frames*channels {
-		return fmt.Errorf("interleaveDataGo: output buffer too small (%d < %d)", len(out), frames*channels)
-	}
-
-	outIdx := 0
-	for fr := 0; fr < frames; fr++ {
-		for ch := 0; ch < channels; ch++ {
-			if fr >= len(in[ch]) { // Check bounds for each channel frame
-				return fmt.Errorf("interleaveDataGo: input channel %d length (%d) < frames (%d) at frame %d", ch, len(in[ch]), frames, fr)
-			}
-			out[outIdx] = in[ch][fr]
-			outIdx++
-		}
-	}
-	return nil
-}
-
-// deinterleaveDataGo converts an interleaved buffer into separate channel buffers.
-// 'in' is the source interleaved buffer (length >= frames * channels).
-// 'out' is the destination slice-of-slices (must be pre-allocated with correct dimensions).
-// Assumes len(out) == channels and len(out[0]) >= frames.
-func deinterleaveDataGo(in []float32, out [][]float32, frames int, channels int) error {
-	if len(out) != channels {
-		return fmt.Errorf("deinterleaveDataGo: output slice count (%d) != channels (%d)", len(out), channels)
-	}
-	if channels == 0 || frames == 0 {
-		return nil
-	} // Nothing to do
-	if len(in) < frames*channels {
-		return fmt.Errorf("deinterleaveDataGo: input buffer too small (%d < %d)", len(in), frames*channels)
-	}
-	if len(out[0]) < frames {
-		return fmt.Errorf("deinterleaveDataGo: output channel 0 length (%d) < frames (%d)", len(out[0]), frames)
-	}
-
-	inIdx := 0
-	for fr := 0; fr < frames; fr++ {
-		for ch := 0; ch < channels; ch++ {
-			if fr >= len(out[ch]) { // Check bounds for each channel frame
-				return fmt.Errorf("deinterleaveDataGo: output channel %d length (%d) < frames (%d) at frame %d", ch, len(out[ch]), frames, fr)
-			}
-			out[ch][fr] = in[inIdx]
-			inIdx++
-		}
-	}
-	return nil
-}
@@ -0,0 +1,148 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"embed"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Expected outputs of the test vectors, little-endian float32, one file per
+// vector. Regenerate with "go run test_vectors_gen.go" after a change that is
+// meant to alter the output.
+//
+//go:embed vectors
+var testVectorFiles embed.FS
+
+const (
+	testVectorFrames    = 400  // Input frames of each vector
+	testVectorTolerance = 1e-5 // Rounding differences, e.g. of FMA on other CPUs
+)
+
+// Conversions covered for each converter type: down by 2, 44.1kHz to 48kHz and
+// stereo up by 3
+var testVectorCases = []struct {
+	channels int
+	ratio    float64
+	label    string
+}{
+	{1, 0.5, "mono_down2"},
+	{1, 160.0 / 147.0, "mono_44k1_48k"},
+	{2, 3.0, "stereo_up3"},
+}
+
+// TestVector is a canonical conversion: Input converted by a new converter of
+// ConverterType at Ratio, in process mode until drained, gives Expected.
+type TestVector struct {
+	Name          string // e.g. "sinc_fastest/mono_down2"
+	ConverterType ConverterType
+	Channels      int
+	Ratio         float64
+	Input         []float32 // Interleaved input, all of the stream
+	Expected      []float32 // Interleaved output
+	Tolerance     float64   // Largest difference allowed per sample
+}
+
+var (
+	testVectorsOnce sync.Once
+	testVectors     []TestVector
+)
+
+// TestVectors returns a small set of input and expected output pairs for every
+// converter type, so that code driving the library through another layer,
+// e.g. FFI bindings or a conversion service, can check at runtime that it
+// passes the audio, channel count, ratio and end of input through correctly:
+// convert each Input as described and compare the result with Check.
+//
+// The inputs mix a sawtooth, noise and an impulse, computed with integer
+// arithmetic so they are the same everywhere. The expected outputs are those
+// of this version of the library; feeding the input in one block or many
+// gives the same output. The returned slices are copies the caller may modify.
+func TestVectors() []TestVector {
+	testVectorsOnce.Do(func() {
+		for _, info := range ListConverters() {
+			if !info.Enabled {
+				continue
+			}
+			for _, c := range testVectorCases {
+				testVectors = append(testVectors, TestVector{
+					Name:          info.Type.String() + "/" + c.label,
+					ConverterType: info.Type,
+					Channels:      c.channels,
+					Ratio:         c.ratio,
+					Input:         testVectorInput(c.channels),
+					Expected:      readTestVector(info.Type.String() + "_" + c.label + ".f32"),
+					Tolerance:     testVectorTolerance,
+				})
+			}
+		}
+	})
+	vectors := make([]TestVector, len(testVectors))
+	for i, v := range testVectors {
+		v.Input = append([]float32(nil), v.Input...)
+		v.Expected = append([]float32(nil), v.Expected...)
+		vectors[i] = v
+	}
+	return vectors
+}
+
+// Check compares the output of a conversion with Expected.
+//
+// Returns:
+//
+//	nil, or an error giving the first sample off by more than Tolerance.
+func (v TestVector) Check(out []float32) error {
+	if len(out) != len(v.Expected) {
+		return fmt.Errorf("test vector %s: got %d samples, want %d", v.Name, len(out), len(v.Expected))
+	}
+	for i, want := range v.Expected {
+		if got := out[i]; !(math.Abs(float64(got)-float64(want)) <= v.Tolerance) {
+			return fmt.Errorf("test vector %s: sample %d (frame %d, channel %d) is %g, want %g", v.Name, i, i/v.Channels, i%v.Channels, got, want)
+		}
+	}
+	return nil
+}
+
+// testVectorInput returns the input of the vectors with the given channel
+// count: per channel a sawtooth of its own period plus noise from an LCG, and
+// an impulse in the middle.
+func testVectorInput(channels int) []float32 {
+	in := make([]float32, testVectorFrames*channels)
+	seed := uint32(12345)
+	for i := 0; i < testVectorFrames; i++ {
+		for ch := 0; ch < channels; ch++ {
+			seed = seed*1664525 + 1013904223
+			saw := (i*(37+11*ch))%400 - 200      // -200 to 199
+			noise := int32(seed>>16)%2048 - 1024 // -1024 to 1023
+			v := float32(saw)/800 + float32(noise)/8192
+			if i == testVectorFrames/2 {
+				v += 0.5
+			}
+			in[i*channels+ch] = v
+		}
+	}
+	return in
+}
+
+// readTestVector decodes an embedded expected output, nil if missing (only
+// while the files are being regenerated).
+func readTestVector(file string) []float32 {
+	data, err := testVectorFiles.ReadFile("vectors/" + file)
+	if err != nil {
+		return nil
+	}
+	out := make([]float32, len(data)/4)
+	for i := range out {
+		out[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+//go:build ignore
+
+// test_vectors_gen.go regenerates the expected outputs of TestVectors in
+// ./vectors with the library as it is.
+// Run with: go run test_vectors_gen.go
+package main
+
+import (
+	"encoding/binary"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	libsamplerate "github.com/keereets/go-libsamplerate"
+)
+
+func main() {
+	for _, v := range libsamplerate.TestVectors() {
+		conv, err := libsamplerate.New(v.ConverterType, v.Channels)
+		if err != nil {
+			log.Fatalf("%s: %v", v.Name, err)
+		}
+		out := make([]float32, len(v.Input)*4)
+		data := libsamplerate.SrcData{
+			DataIn:       v.Input,
+			InputFrames:  int64(len(v.Input) / v.Channels),
+			DataOut:      out,
+			OutputFrames: int64(len(out) / v.Channels),
+			SrcRatio:     v.Ratio,
+			EndOfInput:   true,
+		}
+		var got []float32
+		for {
+			if err := conv.Process(&data); err != nil {
+				log.Fatalf("%s: %v", v.Name, err)
+			}
+			got = append(got, out[:data.OutputFramesGen*int64(v.Channels)]...)
+			if data.OutputFramesGen == 0 {
+				break
+			}
+			data.DataIn = data.DataIn[data.InputFramesUsed*int64(v.Channels):]
+			data.InputFrames -= data.InputFramesUsed
+		}
+		conv.Close()
+
+		buf := make([]byte, 4*len(got))
+		for i, s := range got {
+			binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(s))
+		}
+		file := filepath.Join("vectors", strings.ReplaceAll(v.Name, "/", "_")+".f32")
+		if err := os.WriteFile(file, buf, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("%s: %d samples", file, len(got))
+	}
+}
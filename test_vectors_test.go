@@ -0,0 +1,54 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTestVectors converts every vector in one block and in blocks of 7 frames
+// and checks the output, so a change to a converter's output fails here until
+// the vectors are regenerated.
+func TestTestVectors(t *testing.T) {
+	vectors := TestVectors()
+	if len(vectors) != 3*len(ListConverters()) {
+		t.Fatalf("%d test vectors, want 3 per converter", len(vectors))
+	}
+	for _, v := range vectors {
+		if want := int(math.Ceil(float64(len(v.Input)/v.Channels)*v.Ratio)) * v.Channels; len(v.Expected) < want-v.Channels || len(v.Expected) > want+v.Channels {
+			t.Fatalf("%s: %d expected samples, want about %d", v.Name, len(v.Expected), want)
+		}
+		for _, block := range []int{len(v.Input) / v.Channels, 7} {
+			conv, err := New(v.ConverterType, v.Channels)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := stream(t, conv, v.Input, v.Channels, v.Channels, v.Ratio, block, 64, true)
+			conv.Close()
+			if err := v.Check(out); err != nil {
+				t.Errorf("blocks of %d frames: %v", block, err)
+			}
+		}
+	}
+
+	v := vectors[0]
+	out := append([]float32(nil), v.Expected...)
+	out[10] += 0.001
+	if v.Check(out) == nil {
+		t.Error("Check accepted a wrong sample")
+	}
+	if v.Check(out[:len(out)-1]) == nil {
+		t.Error("Check accepted a short output")
+	}
+	v.Input[0], v.Expected[0] = 9, 9
+	if again := TestVectors()[0]; again.Input[0] == 9 || again.Expected[0] == 9 {
+		t.Error("TestVectors returned shared slices")
+	}
+}
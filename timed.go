@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// timedMinSpan is how much input must have been timed before the measured rate is
+// trusted; shorter spans are dominated by network jitter.
+const timedMinSpan = time.Second
+
+// clockEstimator measures the real rate of a timestamped input stream.
+type clockEstimator struct {
+	started     bool
+	anchorTime  time.Duration // Timestamp of anchorFrame
+	anchorFrame int64
+	frame       int64 // Absolute index of the next input frame
+}
+
+// ProcessTimed is Process for timestamped input, e.g. RTP packets whose timestamps
+// are mapped to the local clock (arrival time or RTCP sender reports). The ratio
+// is derived from the timestamp progression instead of a constant, so the
+// resampler follows the sender's real clock rate and corrects its drift.
+//
+// inTimestamp is the local time of the first frame of data.DataIn, and
+// outClockRate the rate the output is played at. The input rate is measured
+// over the whole stream since the first call (or Reset), which averages out
+// jitter. Until timedMinSpan of input has been seen, data.SrcRatio is used as
+// given (the nominal ratio); after that it is overwritten with the measured
+// ratio. On return data.SrcRatio holds the ratio used.
+//
+// A timestamp going backwards (stream restart) starts a new measurement.
+func ProcessTimed(c Converter, data *SrcData, inTimestamp time.Duration, outClockRate float64) error {
+	var est *clockEstimator
+	switch conv := c.(type) {
+	case *srcState:
+		if conv == nil {
+			return mapError(ErrBadState)
+		}
+		est = &conv.clock
+	case *channelGroups:
+		est = &conv.clock
+	case *channelMapper:
+		est = &conv.clock
+	default:
+		return fmt.Errorf("timed processing not supported by %T", c)
+	}
+	if data == nil {
+		return mapError(ErrBadData)
+	}
+	if outClockRate <= 0 || math.IsNaN(outClockRate) || math.IsInf(outClockRate, 0) {
+		return fmt.Errorf("outClockRate must be positive, got %f", outClockRate)
+	}
+
+	if ratio, ok := est.ratio(inTimestamp, outClockRate); ok {
+		if isBadSrcRatio(ratio) {
+			return fmt.Errorf("timestamps give invalid ratio %f: %w", ratio, mapError(ErrBadSrcRatio))
+		}
+		data.SrcRatio = ratio
+	}
+	if err := c.Process(data); err != nil {
+		return err
+	}
+	est.frame += data.InputFramesUsed
+	return nil
+}
+
+// ratio returns outClockRate over the measured input rate, or false while there
+// is not enough history.
+func (e *clockEstimator) ratio(ts time.Duration, outClockRate float64) (float64, bool) {
+	if !e.started || ts < e.anchorTime {
+		e.started = true
+		e.anchorTime, e.anchorFrame = ts, e.frame
+		return 0, false
+	}
+	span := ts - e.anchorTime
+	if span < timedMinSpan || e.frame == e.anchorFrame {
+		return 0, false
+	}
+	inRate := float64(e.frame-e.anchorFrame) / span.Seconds()
+	return outClockRate / inRate, true
+}
@@ -0,0 +1,101 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestProcessTimedFollowsSenderClock simulates a sender whose nominal 8 kHz clock
+// really runs at 8008 Hz, with and without arrival jitter.
+func TestProcessTimedFollowsSenderClock(t *testing.T) {
+	const packetFrames = 160
+	const senderRate = 8008.0
+	const outRate = 8000.0
+	want := outRate / senderRate
+
+	for _, tt := range []struct {
+		name      string
+		jitter    time.Duration
+		tolerance float64
+	}{
+		{"NoJitter", 0, 1e-6},
+		{"Jitter5ms", 5 * time.Millisecond, 3e-4},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := New(Linear, 1)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			defer conv.Close()
+			rng := rand.New(rand.NewSource(1))
+			in := genSine(packetFrames, 400, 8000, 0.5)
+			out := make([]float32, 2*packetFrames)
+
+			var totalIn, totalOut int64
+			for pkt := 0; pkt < 1500; pkt++ { // 30 s
+				ts := time.Duration(float64(pkt*packetFrames) / senderRate * float64(time.Second))
+				if tt.jitter > 0 {
+					ts += time.Duration(rng.Int63n(int64(tt.jitter)))
+				}
+				data := SrcData{DataIn: in, InputFrames: packetFrames, DataOut: out, OutputFrames: int64(len(out)), SrcRatio: 1.0}
+				if err := ProcessTimed(conv, &data, ts, outRate); err != nil {
+					t.Fatalf("packet %d: ProcessTimed failed: %v", pkt, err)
+				}
+				if data.InputFramesUsed != packetFrames {
+					t.Fatalf("packet %d: used %d frames", pkt, data.InputFramesUsed)
+				}
+				if pkt < 40 && data.SrcRatio != 1.0 {
+					t.Fatalf("packet %d: ratio %f before a second of input, want nominal 1.0", pkt, data.SrcRatio)
+				}
+				if pkt == 1499 && math.Abs(data.SrcRatio-want) > tt.tolerance {
+					t.Errorf("final ratio %.6f, want %.6f", data.SrcRatio, want)
+				}
+				totalIn += data.InputFramesUsed
+				totalOut += data.OutputFramesGen
+			}
+			if got := float64(totalOut) / float64(totalIn); math.Abs(got-want) > 2e-3 {
+				t.Errorf("overall output/input %.5f, want about %.5f", got, want)
+			}
+		})
+	}
+}
+
+func TestProcessTimedReset(t *testing.T) {
+	conv, err := New(ZeroOrderHold, 1)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer conv.Close()
+	in := make([]float32, 800)
+	out := make([]float32, 2000)
+	run := func(ts time.Duration) float64 {
+		data := SrcData{DataIn: in, InputFrames: 800, DataOut: out, OutputFrames: 2000, SrcRatio: 1.0}
+		if err := ProcessTimed(conv, &data, ts, 8000); err != nil {
+			t.Fatalf("ProcessTimed failed: %v", err)
+		}
+		return data.SrcRatio
+	}
+	for i := 0; i < 20; i++ {
+		run(time.Duration(i) * 50 * time.Millisecond) // Input at 16 kHz
+	}
+	if r := run(time.Second); math.Abs(r-0.5) > 1e-9 {
+		t.Errorf("ratio %f, want 0.5", r)
+	}
+	conv.Reset()
+	if r := run(10 * time.Second); r != 1.0 {
+		t.Errorf("ratio %f after Reset, want nominal 1.0", r)
+	}
+	if err := ProcessTimed(conv, &SrcData{}, 0, 0); err == nil {
+		t.Error("expected error for zero outClockRate")
+	}
+}
@@ -0,0 +1,194 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+)
+
+// --- Time Stretch Constants ---
+const (
+	wsolaFrameMs = 30.0 // Analysis/synthesis frame length, long enough for voiced speech
+	wsolaMinRate = 0.1  // Lowest tempo/pitch factor accepted
+	wsolaMaxRate = 10.0 // Highest tempo/pitch factor accepted
+)
+
+// TimeStretch changes the tempo of interleaved audio without changing its pitch,
+// using WSOLA (Waveform Similarity Overlap-Add). tempo > 1 speeds up (e.g. 1.25
+// for speech at 1.25x, output is shorter), tempo < 1 slows down.
+//
+// Args:
+//
+//	in: Interleaved input samples.
+//	channels: Number of interleaved channels. Channels are stretched together so they stay aligned.
+//	sampleRate: Sample rate in Hz, used to size the WSOLA frames.
+//	tempo: Speed factor, in [0.1, 10].
+//
+// Returns:
+//
+//	The stretched interleaved audio (about len(in)/tempo samples), or nil and an error.
+func TimeStretch(in []float32, channels int, sampleRate, tempo float64) ([]float32, error) {
+	if err := checkStretchArgs(in, channels, sampleRate, tempo); err != nil {
+		return nil, err
+	}
+	return wsolaStretch(in, channels, sampleRate, tempo), nil
+}
+
+// PitchShift changes the pitch of interleaved audio by the given number of
+// semitones (positive is higher) without changing its duration. The audio is
+// time-stretched with WSOLA and then resampled with the given converter.
+func PitchShift(in []float32, channels int, sampleRate, semitones float64, converterType ConverterType) ([]float32, error) {
+	return ChangeTempoPitch(in, channels, sampleRate, 1.0, semitones, converterType)
+}
+
+// ChangeTempoPitch changes tempo and pitch independently: the output lasts
+// len(in)/tempo frames and is shifted by the given number of semitones.
+// Resampling (only done when semitones != 0) uses the given converter.
+func ChangeTempoPitch(in []float32, channels int, sampleRate, tempo, semitones float64, converterType ConverterType) ([]float32, error) {
+	if err := checkStretchArgs(in, channels, sampleRate, tempo); err != nil {
+		return nil, err
+	}
+	pitch := math.Pow(2.0, semitones/12.0)
+	if math.IsNaN(pitch) || pitch < wsolaMinRate || pitch > wsolaMaxRate {
+		return nil, fmt.Errorf("pitch shift of %f semitones out of range", semitones)
+	}
+	if semitones == 0 {
+		return wsolaStretch(in, channels, sampleRate, tempo), nil
+	}
+
+	// Resampling by 1/pitch shortens the audio by pitch while raising its pitch by
+	// pitch, so stretch it to pitch/tempo times its length first.
+	stretched := wsolaStretch(in, channels, sampleRate, tempo/pitch)
+
+	state, err := New(converterType, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	defer state.Close()
+	return processAll(state, stretched, channels, 1.0/pitch)
+}
+
+// checkStretchArgs validates the arguments shared by the time stretch functions.
+func checkStretchArgs(in []float32, channels int, sampleRate, tempo float64) error {
+	if channels <= 0 {
+		return mapError(ErrBadChannelCount)
+	}
+	if len(in)%channels != 0 {
+		return fmt.Errorf("input size (%d) not multiple of channel count (%d)", len(in), channels)
+	}
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return fmt.Errorf("sampleRate must be positive, got %f", sampleRate)
+	}
+	if math.IsNaN(tempo) || tempo < wsolaMinRate || tempo > wsolaMaxRate {
+		return fmt.Errorf("tempo must be in [%g, %g], got %f", wsolaMinRate, wsolaMaxRate, tempo)
+	}
+	return nil
+}
+
+// wsolaStretch is the WSOLA core. Frames of frameLen samples are taken from the
+// input every frameLen/2*tempo samples (the analysis hop) and overlap-added every
+// frameLen/2 samples (the synthesis hop). Each frame's position is adjusted within
+// +/- frameLen/4 to best match the natural continuation of the previous frame,
+// which keeps the waveform periodic across the joins and avoids phasiness.
+func wsolaStretch(in []float32, channels int, sampleRate, tempo float64) []float32 {
+	frames := len(in) / channels
+	outFrames := int(math.Round(float64(frames) / tempo))
+	if frames == 0 || outFrames == 0 {
+		return []float32{}
+	}
+
+	frameLen := 2 * maxInt(8, int(sampleRate*wsolaFrameMs/1000.0)/2) // Even length
+	hopOut := frameLen / 2
+	hopIn := float64(hopOut) * tempo
+	tolerance := frameLen / 4
+
+	// Similarity search runs on a mono downmix
+	mono := make([]float32, frames)
+	for fr := 0; fr < frames; fr++ {
+		sum := float32(0)
+		for ch := 0; ch < channels; ch++ {
+			sum += in[fr*channels+ch]
+		}
+		mono[fr] = sum / float32(channels)
+	}
+
+	window := make([]float32, frameLen)
+	for i := range window {
+		window[i] = float32(0.5 - 0.5*math.Cos(2.0*math.Pi*float64(i)/float64(frameLen))) // Periodic Hann
+	}
+
+	out := make([]float32, (outFrames+frameLen)*channels)
+	windowSum := make([]float32, outFrames+frameLen)
+
+	prevPos := 0
+	for k := 0; k*hopOut < outFrames; k++ {
+		pos := 0
+		if k > 0 {
+			nominal := int(math.Round(float64(k) * hopIn))
+			pos = bestOverlapPosition(mono, prevPos+hopOut, nominal, tolerance, frameLen)
+		}
+
+		outBase := k * hopOut
+		for i := 0; i < frameLen && pos+i < frames; i++ {
+			w := window[i]
+			inIdx := (pos + i) * channels
+			outIdx := (outBase + i) * channels
+			for ch := 0; ch < channels; ch++ {
+				out[outIdx+ch] += in[inIdx+ch] * w
+			}
+			windowSum[outBase+i] += w
+		}
+		prevPos = pos
+	}
+
+	// Normalize by the accumulated window, which is 1 except at the edges
+	for fr := 0; fr < outFrames; fr++ {
+		if ws := windowSum[fr]; ws > 1e-3 {
+			for ch := 0; ch < channels; ch++ {
+				out[fr*channels+ch] /= ws
+			}
+		}
+	}
+	return out[:outFrames*channels]
+}
+
+// bestOverlapPosition returns the frame start in [nominal-tolerance, nominal+tolerance]
+// whose samples are most similar (normalized cross-correlation) to the frame starting
+// at natural, the natural continuation of the previous frame.
+func bestOverlapPosition(mono []float32, natural, nominal, tolerance, frameLen int) int {
+	frames := len(mono)
+	lo := maxInt(0, nominal-tolerance)
+	hi := minInt(nominal+tolerance, frames-1)
+	if lo > hi {
+		return minInt(maxInt(0, nominal), maxInt(0, frames-1))
+	}
+	if natural >= frames {
+		return minInt(maxInt(lo, nominal), hi)
+	}
+	ref := mono[natural:minInt(natural+frameLen, frames)]
+
+	best := minInt(maxInt(lo, nominal), hi)
+	bestScore := math.Inf(-1)
+	for cand := lo; cand <= hi; cand++ {
+		n := minInt(len(ref), frames-cand)
+		var corr, energy float64
+		for i := 0; i < n; i++ {
+			c := float64(mono[cand+i])
+			corr += c * float64(ref[i])
+			energy += c * c
+		}
+		score := corr / math.Sqrt(energy+1e-12)
+		if score > bestScore {
+			bestScore = score
+			best = cand
+		}
+	}
+	return best
+}
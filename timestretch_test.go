@@ -0,0 +1,123 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// zeroCrossingFreq estimates the frequency of a pure tone from its zero crossings.
+func zeroCrossingFreq(samples []float32, channels int, sampleRate float64) float64 {
+	crossings := 0
+	frames := len(samples) / channels
+	for fr := 1; fr < frames; fr++ {
+		a, b := samples[(fr-1)*channels], samples[fr*channels]
+		if (a < 0) != (b < 0) {
+			crossings++
+		}
+	}
+	return float64(crossings) / 2.0 / (float64(frames) / sampleRate)
+}
+
+func TestTimeStretchKeepsPitch(t *testing.T) {
+	const rate = 16000.0
+	const freq = 440.0
+	in := genSine(int(rate)*2, freq, rate, 0.5)
+
+	for _, tempo := range []float64{0.5, 0.8, 1.25, 2.0} {
+		t.Run(fmt.Sprintf("Tempo_%.2f", tempo), func(t *testing.T) {
+			out, err := TimeStretch(in, 1, rate, tempo)
+			if err != nil {
+				t.Fatalf("TimeStretch failed: %v", err)
+			}
+			wantLen := int(math.Round(float64(len(in)) / tempo))
+			if len(out) != wantLen {
+				t.Errorf("output length %d, want %d", len(out), wantLen)
+			}
+			mid := out[len(out)/4 : 3*len(out)/4]
+			if f := zeroCrossingFreq(mid, 1, rate); math.Abs(f-freq) > freq*0.02 {
+				t.Errorf("pitch changed: %.1f Hz, want %.1f Hz", f, freq)
+			}
+			if peak := findPeakGo(mid); peak < 0.4 || peak > 0.6 {
+				t.Errorf("amplitude changed: peak %.3f, want about 0.5", peak)
+			}
+		})
+	}
+}
+
+func TestPitchShiftKeepsTempo(t *testing.T) {
+	const rate = 16000.0
+	const freq = 300.0
+	const channels = 2
+	mono := genSine(int(rate), freq, rate, 0.5)
+	in := make([]float32, len(mono)*channels)
+	for i, v := range mono {
+		in[i*channels] = v
+		in[i*channels+1] = v
+	}
+
+	for _, semitones := range []float64{-12, 7, 12} {
+		t.Run(fmt.Sprintf("Semitones_%+.0f", semitones), func(t *testing.T) {
+			out, err := PitchShift(in, channels, rate, semitones, SincFastest)
+			if err != nil {
+				t.Fatalf("PitchShift failed: %v", err)
+			}
+			frames := len(out) / channels
+			if math.Abs(float64(frames-len(mono))) > float64(len(mono))*0.01 {
+				t.Errorf("duration changed: %d frames, want about %d", frames, len(mono))
+			}
+			wantFreq := freq * math.Pow(2.0, semitones/12.0)
+			mid := out[len(out)/4/channels*channels : 3*len(out)/4/channels*channels]
+			if f := zeroCrossingFreq(mid, channels, rate); math.Abs(f-wantFreq) > wantFreq*0.03 {
+				t.Errorf("pitch %.1f Hz, want %.1f Hz", f, wantFreq)
+			}
+		})
+	}
+}
+
+func TestChangeTempoPitch(t *testing.T) {
+	const rate = 8000.0
+	in := genSine(8000, 200, rate, 0.5)
+	out, err := ChangeTempoPitch(in, 1, rate, 1.5, 12, Linear)
+	if err != nil {
+		t.Fatalf("ChangeTempoPitch failed: %v", err)
+	}
+	wantLen := float64(len(in)) / 1.5
+	if math.Abs(float64(len(out))-wantLen) > wantLen*0.01 {
+		t.Errorf("output length %d, want about %.0f", len(out), wantLen)
+	}
+	if f := zeroCrossingFreq(out[len(out)/4:3*len(out)/4], 1, rate); math.Abs(f-400) > 12 {
+		t.Errorf("pitch %.1f Hz, want 400 Hz", f)
+	}
+}
+
+func TestTimeStretchErrors(t *testing.T) {
+	in := make([]float32, 100)
+	if _, err := TimeStretch(in, 0, 8000, 1); err == nil {
+		t.Error("expected error for zero channels")
+	}
+	if _, err := TimeStretch(in[:99], 2, 8000, 1); err == nil {
+		t.Error("expected error for partial frame")
+	}
+	if _, err := TimeStretch(in, 1, 0, 1); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	if _, err := TimeStretch(in, 1, 8000, 0); err == nil {
+		t.Error("expected error for zero tempo")
+	}
+	if _, err := PitchShift(in, 1, 8000, 100, Linear); err == nil {
+		t.Error("expected error for huge pitch shift")
+	}
+	out, err := TimeStretch(nil, 1, 8000, 1.5)
+	if err != nil || len(out) != 0 {
+		t.Errorf("empty input: got %d samples, err %v", len(out), err)
+	}
+}
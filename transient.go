@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "math"
+
+// transientFrames returns the number of output frames at ratio whose sinc
+// filter reaches back before the first input frame, i.e. the fade-in from the
+// empty history. Linear and ZeroOrderHold have none.
+func (state *srcState) transientFrames(ratio float64) int64 {
+	filter, ok := state.privateData.(*sincFilter)
+	if !ok || filter == nil || filter.indexInc <= 0 {
+		return 0
+	}
+	// The filter spans coeffHalfLen/indexInc input frames on each side, stretched
+	// by 1/ratio when downsampling; in output frames that is the span times
+	// max(ratio, 1)
+	span := float64(filter.coeffHalfLen) / float64(filter.indexInc)
+	return int64(math.Ceil(span * maxFloat64(ratio, 1)))
+}
+
+// trimLeadingTransient applies Options.TrimLeadingTransient to the output of a
+// Process call: on the first call that ran the filter since the last reset it
+// works out the transient length at that ratio, then drops that many frames
+// from the start of the output, over as many calls as it takes.
+func (state *srcState) trimLeadingTransient(data *SrcData) {
+	state.trimLast = 0
+	if !state.trimKnown {
+		if data.InputFramesUsed == 0 && data.OutputFramesGen == 0 {
+			return // Nothing ran yet
+		}
+		state.trimKnown = true
+		if state.filtered { // Not copied through at ratio 1.0
+			state.trimLeft = state.transientFrames(data.SrcRatio)
+		}
+	}
+	if state.trimLeft <= 0 || data.OutputFramesGen <= 0 {
+		return
+	}
+	n := minInt64(state.trimLeft, data.OutputFramesGen)
+	ch := int64(state.channels)
+	copy(data.DataOut, data.DataOut[n*ch:data.OutputFramesGen*ch])
+	data.OutputFramesGen -= n
+	state.trimLeft -= n
+	state.trimLast = n
+	state.stats.TrimmedFrames += n
+}
+
+// trimmedLast returns the output frames c dropped with Options.TrimLeadingTransient
+// in its last Process call.
+func trimmedLast(c Converter) int64 {
+	switch conv := c.(type) {
+	case *srcState:
+		return conv.trimLast
+	case *channelGroups:
+		return conv.groups[0].trimLast // Groups run in lockstep
+	case *channelMapper:
+		return trimmedLast(conv.inner)
+	}
+	return 0
+}
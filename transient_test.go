@@ -0,0 +1,119 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestTrimLeadingTransient checks that the trimmed output is the plain output
+// without its first transientFrames frames, and that it starts at the level of
+// a DC input instead of fading in.
+func TestTrimLeadingTransient(t *testing.T) {
+	for _, converterType := range []ConverterType{SincBestQuality, SincFastest} {
+		for _, ratio := range []float64{0.5, 2.0} {
+			name := fmt.Sprintf("%s at %.1f", converterType, ratio)
+			in := make([]float32, 2000)
+			for i := range in {
+				in[i] = 0.5
+			}
+			plain, _ := New(converterType, 1)
+			want := drain(t, plain, in, 1, 1, ratio, 64)
+			trim := plain.(*srcState).transientFrames(ratio)
+			plain.Close()
+			dev := 0.0
+			for _, v := range want[:trim] {
+				dev = math.Max(dev, math.Abs(float64(v)-0.5))
+			}
+			if trim < 8 || dev < 0.03 {
+				t.Fatalf("%s: transient of %d frames off DC by up to %.3f, want a longer fade-in", name, trim, dev)
+			}
+
+			conv, err := NewWithOptions(converterType, 1, Options{TrimLeadingTransient: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for pass := 0; pass < 2; pass++ { // Again after Reset
+				got := drain(t, conv, in, 1, 1, ratio, 64)
+				checkSameSamples(t, fmt.Sprintf("%s, pass %d", name, pass), got, want[trim:])
+				if len(got) > 0 && math.Abs(float64(got[0])-0.5) > 0.01 {
+					t.Errorf("%s: trimmed output starts at %.4f, want 0.5", name, got[0])
+				}
+				if err := conv.Reset(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if s := conv.(StatsReporter).Stats(); s.TrimmedFrames != 2*trim || s.OutputFrames != 2*int64(len(want))-2*trim {
+				t.Errorf("%s: Stats trimmed %d, output %d; want %d and %d", name, s.TrimmedFrames, s.OutputFrames, 2*trim, 2*int64(len(want))-2*trim)
+			}
+			conv.Close()
+		}
+	}
+}
+
+// TestTrimLeadingTransientNoFilter checks the converters and ratio without a
+// transient.
+func TestTrimLeadingTransientNoFilter(t *testing.T) {
+	in := genSine(1000, 440, 8000, 0.5)
+	for _, tt := range []struct {
+		converterType ConverterType
+		ratio         float64
+	}{
+		{Linear, 2.0},
+		{ZeroOrderHold, 0.5},
+		{SincFastest, 1.0},
+	} {
+		plain, _ := New(tt.converterType, 1)
+		want := drain(t, plain, in, 1, 1, tt.ratio, 64)
+		plain.Close()
+		conv, _ := NewWithOptions(tt.converterType, 1, Options{TrimLeadingTransient: true})
+		got := drain(t, conv, in, 1, 1, tt.ratio, 64)
+		conv.Close()
+		checkSameSamples(t, fmt.Sprintf("%s at %.1f", tt.converterType, tt.ratio), got, want)
+	}
+}
+
+// TestTrimLeadingTransientChannels checks channel groups and channel mapping.
+func TestTrimLeadingTransientChannels(t *testing.T) {
+	const ratio = 0.5
+	trimmed := func(opts Options, channels, outChannels int) ([]float32, []float32, int64) {
+		in := make([]float32, 1000*channels)
+		for i := range in {
+			in[i] = float32(math.Sin(float64(i) * 0.01))
+		}
+		plain, err := NewWithOptions(SincFastest, channels, Options{OutputChannels: opts.OutputChannels})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := drain(t, plain, in, channels, outChannels, ratio, 64)
+		plain.Close()
+		conv, err := NewWithOptions(SincFastest, channels, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := drain(t, conv, in, channels, outChannels, ratio, 64)
+		trim := conv.(StatsReporter).Stats().TrimmedFrames
+		conv.Close()
+		return got, want, trim
+	}
+
+	got, want, trim := trimmed(Options{TrimLeadingTransient: true}, 256, 256)
+	if trim == 0 {
+		t.Fatal("channel groups: nothing trimmed")
+	}
+	checkSameSamples(t, "channel groups", got, want[trim*256:])
+
+	got, want, trim = trimmed(Options{TrimLeadingTransient: true, OutputChannels: 1}, 2, 1)
+	if trim == 0 {
+		t.Fatal("stereo to mono: nothing trimmed")
+	}
+	checkSameSamples(t, "stereo to mono", got, want[trim:])
+}
@@ -36,8 +36,8 @@ func ulawToLinearInt16Go(ulawByte byte) int16 {
 	// Calculate magnitude from exponent lookup and mantissa shift
 	linearVal := ulawExpLut[exponent] + (int16(mantissa) << (exponent + 3))
 
-	// Apply sign (match C++ where sign=0 is negative after inversion)
-	if sign == 0 {
+	// Apply sign: set after inversion (bytes 0x00-0x7F) is negative, as in G.711
+	if sign != 0 {
 		linearVal = -linearVal
 	}
 
@@ -46,6 +46,16 @@ func ulawToLinearInt16Go(ulawByte byte) int16 {
 	return linearVal
 }
 
+// UlawToFloatArray decodes a slice of u-law bytes to float32 in [-1.0, 1.0).
+// It is the inverse of FloatToUlawArray and matches ConvertUlawToPCM. It decodes
+// min(len(in), len(out)) samples.
+func UlawToFloatArray(in []byte, out []float32) {
+	count := minInt(len(in), len(out))
+	for i := 0; i < count; i++ {
+		out[i] = s16ToFloatGo(ulawDecodeTable[in[i]])
+	}
+}
+
 // ConvertUlawToPCM converts a slice of u-law encoded bytes (at 8kHz) to
 // a slice of 16-bit little-endian PCM bytes resampled to 16kHz.
 //
@@ -59,9 +69,56 @@ func ulawToLinearInt16Go(ulawByte byte) int16 {
 //	A slice of bytes containing 16-bit little-endian PCM audio data (16kHz),
 //	or nil and an error if conversion fails.
 func ConvertUlawToPCM(inputUlaw []byte, quality ConverterType) ([]byte, error) {
+	return ConvertUlawToPCMWithOptions(inputUlaw, quality, PCMOptions{})
+}
+
+// PCMOptions holds the optional settings of the conversions to 16-bit PCM,
+// ConvertUlawToPCMWithOptions and Resample24kHzTo16kHzWithOptions.
+type PCMOptions struct {
+	// BigEndian uses S16BE (AIFF, L16 RTP payloads) instead of S16LE for the PCM
+	// samples.
+	BigEndian bool
+	// Arena supplies the float buffers and the converter, so a goroutine
+	// converting many payloads reuses them (see Arena). The returned slice is not
+	// part of the arena.
+	Arena *Arena
+	// OnClip, if set, is called once per call with the number of output samples
+	// clamped to the int16 range, where the resampler's overshoot on near
+	// full-scale input exceeded it, and the number of output samples.
+	OnClip func(clipped, samples int)
+}
+
+// order returns the byte order of the PCM samples.
+func (opts PCMOptions) order() binary.ByteOrder {
+	if opts.BigEndian {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// reportClip passes the number of samples clamped to opts.OnClip.
+func (opts PCMOptions) reportClip(clipped, samples int) {
+	if opts.OnClip != nil && samples > 0 {
+		opts.OnClip(clipped, samples)
+	}
+}
+
+// ConvertUlawToPCMWithOptions is ConvertUlawToPCM with the byte order, arena and
+// clip reporting of opts.
+func ConvertUlawToPCMWithOptions(inputUlaw []byte, quality ConverterType, opts PCMOptions) ([]byte, error) {
+	out, clipped, err := convertUlawToPCM(inputUlaw, quality, opts.order(), opts.Arena)
+	if err != nil {
+		return nil, err
+	}
+	opts.reportClip(clipped, len(out)/bytesPerOutputFrame)
+	return out, nil
+}
 
+// convertUlawToPCM implements ConvertUlawToPCMWithOptions; it also returns the
+// number of samples clamped.
+func convertUlawToPCM(inputUlaw []byte, quality ConverterType, order binary.ByteOrder, arena *Arena) ([]byte, int, error) {
 	if len(inputUlaw) == 0 {
-		return []byte{}, nil // Return empty slice for empty input
+		return []byte{}, 0, nil // Return empty slice for empty input
 	}
 
 	// --- libsamplerate Setup ---
@@ -69,16 +126,16 @@ func ConvertUlawToPCM(inputUlaw []byte, quality ConverterType) ([]byte, error) {
 	var state Converter                                        // Use interface
 	var err error                                              // Declare err variable
 
-	state, err = New(quality, channelsUlaw)
+	state, err = arena.Converter(quality, channelsUlaw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize libsamplerate: %w", err)
+		return nil, 0, fmt.Errorf("failed to initialize libsamplerate: %w", err)
 	}
-	defer state.Close() // Ensure cleanup
+	defer arena.Release(state) // Ensure cleanup
 
 	// --- Prepare Input Data (u-Law -> float32) ---
 	totalInputFrames := len(inputUlaw)
-	inputFloatBuffer := make([]float32, totalInputFrames*channelsUlaw) // Size for mono
-	const scaleToFloat = 1.0 / 32768.0                                 // Consistent scaling factor
+	inputFloatBuffer := arena.Floats(totalInputFrames * channelsUlaw) // Size for mono
+	const scaleToFloat = 1.0 / 32768.0                                // Consistent scaling factor
 
 	for i := 0; i < totalInputFrames; i++ {
 		sampleS16 := ulawToLinearInt16Go(inputUlaw[i])
@@ -87,11 +144,12 @@ func ConvertUlawToPCM(inputUlaw []byte, quality ConverterType) ([]byte, error) {
 
 	// --- Prepare Output Buffers ---
 	estimatedMaxOutputFrames := int64(math.Ceil(float64(totalInputFrames)*srcRatio)) + 20 // Add headroom
-	outputFloatBuffer := make([]float32, estimatedMaxOutputFrames*int64(channelsUlaw))
+	outputFloatBuffer := arena.Floats(int(estimatedMaxOutputFrames) * channelsUlaw)
 	// Final byte slice - pre-allocate capacity
 	outputPcmBytes := make([]byte, 0, estimatedMaxOutputFrames*int64(channelsUlaw)*int64(bytesPerOutputFrame))
 	// Temporary buffer for byte conversion in the loop
 	byteBuf := make([]byte, bytesPerOutputFrame)
+	clipped, n := 0, 0
 
 	// --- Perform Resampling (Single Pass + Flush) ---
 	srcData := SrcData{
@@ -106,14 +164,15 @@ func ConvertUlawToPCM(inputUlaw []byte, quality ConverterType) ([]byte, error) {
 	// Process the main block of data
 	err = state.Process(&srcData)
 	if err != nil {
-		return nil, fmt.Errorf("libsamplerate src_process failed: %w", err)
+		return nil, 0, fmt.Errorf("libsamplerate src_process failed: %w", err)
 	}
 
 	framesGenerated := srcData.OutputFramesGen
 
 	// Convert generated float samples to S16 bytes and append
 	if framesGenerated > 0 {
-		outputPcmBytes = appendFloatToBytesPCM16LE(outputPcmBytes, outputFloatBuffer[:framesGenerated*int64(channelsUlaw)], byteBuf)
+		outputPcmBytes, n = appendFloatToBytesPCM16Counted(outputPcmBytes, outputFloatBuffer[:framesGenerated*int64(channelsUlaw)], byteBuf, order)
+		clipped += n
 	}
 
 	// --- Flush any remaining samples from libsamplerate ---
@@ -129,7 +188,7 @@ func ConvertUlawToPCM(inputUlaw []byte, quality ConverterType) ([]byte, error) {
 
 		err = state.Process(&srcData)
 		if err != nil {
-			return nil, fmt.Errorf("libsamplerate src_process (flush) failed: %w", err)
+			return nil, 0, fmt.Errorf("libsamplerate src_process (flush) failed: %w", err)
 		}
 
 		framesGenerated = srcData.OutputFramesGen
@@ -139,39 +198,56 @@ func ConvertUlawToPCM(inputUlaw []byte, quality ConverterType) ([]byte, error) {
 		}
 
 		// Convert and append flushed frames
-		outputPcmBytes = appendFloatToBytesPCM16LE(outputPcmBytes, outputFloatBuffer[:framesGenerated*int64(channelsUlaw)], byteBuf)
+		outputPcmBytes, n = appendFloatToBytesPCM16Counted(outputPcmBytes, outputFloatBuffer[:framesGenerated*int64(channelsUlaw)], byteBuf, order)
+		clipped += n
 
 	} // End flush loop
 
-	return outputPcmBytes, nil
+	return outputPcmBytes, clipped, nil
 }
 
 // appendFloatToBytesPCM16LE converts a slice of float32 to int16, then appends
 // the resulting bytes (Little Endian) to an existing byte slice.
 // Uses scaling by 32767 and clamping, matching the C++ code.
 func appendFloatToBytesPCM16LE(dest []byte, src []float32, byteBuf []byte) []byte {
+	return appendFloatToBytesPCM16(dest, src, byteBuf, binary.LittleEndian)
+}
+
+// appendFloatToBytesPCM16 is appendFloatToBytesPCM16LE writing the bytes in the
+// given order.
+func appendFloatToBytesPCM16(dest []byte, src []float32, byteBuf []byte, order binary.ByteOrder) []byte {
+	dest, _ = appendFloatToBytesPCM16Counted(dest, src, byteBuf, order)
+	return dest
+}
+
+// appendFloatToBytesPCM16Counted is appendFloatToBytesPCM16 also returning the
+// number of samples clamped.
+func appendFloatToBytesPCM16Counted(dest []byte, src []float32, byteBuf []byte, order binary.ByteOrder) ([]byte, int) {
 	if len(byteBuf) < bytesPerOutputFrame {
 		// Allocate if not provided or too small
 		byteBuf = make([]byte, bytesPerOutputFrame)
 	}
 
+	clipped := 0
 	for _, sampleF := range src {
 		// Clamp float32 sample to [-1.0, 1.0]
 		if sampleF > 1.0 {
 			sampleF = 1.0
+			clipped++
 		}
 		if sampleF < -1.0 {
 			sampleF = -1.0
+			clipped++
 		}
 
 		// Scale to int16 range using 32767 (matching C++) and cast
 		sampleS16 := int16(sampleF * 32767.0)
 
-		// Convert int16 to little-endian bytes
-		binary.LittleEndian.PutUint16(byteBuf, uint16(sampleS16))
+		// Convert int16 to bytes in the requested order
+		order.PutUint16(byteBuf, uint16(sampleS16))
 
 		// Append the 2 bytes to the destination slice
 		dest = append(dest, byteBuf...)
 	}
-	return dest
+	return dest, clipped
 }
@@ -1,28 +1,68 @@
+// ulaw-testing converts an 8kHz u-law stream (as received from a SIP/Twilio leg)
+// to 16kHz S16LE PCM.
+//
+// By default it runs on the embedded corpus and writes to the temp directory.
+// Recorded Twilio stream dumps, with a 33-byte header before each 160-byte
+// frame, are converted with -headers strip (audio only) or -headers keep.
 package main
 
 import (
-	libsamplerate "github.com/keereets/go-libsamplerate"
+	"flag"
+	"fmt"
 	"log"
 	"os"
-)
+	"path/filepath"
 
-func main() {
-	inputFile := "/home/antonio/go/src/syndeo-go-gen-ai/cpp/http-server/resources/last.input.twilio.original.8kHz.bin"
-	outputFile := "/tmp/last.input.twilio.golib-translated.16kHz.bin"
+	libsamplerate "github.com/keereets/go-libsamplerate"
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+	"github.com/keereets/go-libsamplerate/telephony"
+)
 
-	file, err := os.ReadFile(inputFile)
+// convert reads the input (the embedded corpus when inputFile is empty), converts
+// it and writes the result to outputFile. It returns the input and output sizes.
+// headers is "" for bare u-law, or "strip" or "keep" for a stream dump.
+func convert(inputFile, outputFile, headers string) (int, int, error) {
+	var file []byte
+	var err error
+	if inputFile == "" {
+		file, err = corpus.ReadFile(corpus.Speech8kUlaw)
+	} else {
+		file, err = os.ReadFile(inputFile)
+	}
 	if err != nil {
-		log.Fatal(err)
+		return 0, 0, err
 	}
 
-	pcm, err := libsamplerate.ConvertUlawToPCM(file, libsamplerate.SincBestQuality)
+	var pcm []byte
+	switch headers {
+	case "":
+		pcm, err = libsamplerate.ConvertUlawToPCM(file, libsamplerate.SincBestQuality)
+	case "strip":
+		pcm, err = telephony.TranscodeDump(file, 16000, libsamplerate.SincBestQuality, telephony.StripHeaders)
+	case "keep":
+		pcm, err = telephony.TranscodeDump(file, 16000, libsamplerate.SincBestQuality, telephony.KeepHeaders)
+	default:
+		err = fmt.Errorf("unknown -headers %q, want strip or keep", headers)
+	}
 	if err != nil {
-		log.Fatal(err)
+		return 0, 0, err
+	}
+
+	if err = os.WriteFile(outputFile, pcm, 0644); err != nil {
+		return 0, 0, err
 	}
+	return len(file), len(pcm), nil
+}
+
+func main() {
+	inputFile := flag.String("in", "", "8kHz u-law input (default: embedded corpus)")
+	outputFile := flag.String("out", filepath.Join(os.TempDir(), "last.input.twilio.golib-translated.16kHz.bin"), "16kHz S16LE output")
+	headers := flag.String("headers", "", "input is a Twilio stream dump: strip or keep the 33-byte frame headers")
+	flag.Parse()
 
-	err = os.WriteFile(outputFile, pcm, 0644)
+	inLen, outLen, err := convert(*inputFile, *outputFile, *headers)
 	if err != nil {
 		log.Fatal(err)
 	}
-	log.Println("finished converting to pcm", outputFile, inputFile, len(file), "->", len(pcm))
+	log.Println("finished converting to pcm", *outputFile, inLen, "->", outLen)
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keereets/go-libsamplerate/internal/corpus"
+	"github.com/keereets/go-libsamplerate/telephony"
+)
+
+// TestConvertCorpus runs the example end to end on the embedded corpus.
+func TestConvertCorpus(t *testing.T) {
+	inLen, outLen, err := convert("", filepath.Join(t.TempDir(), "out.raw"), "")
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	// 8kHz u-law (1 byte/frame) -> 16kHz S16LE (2 bytes/frame): about 4x the bytes
+	if outLen < inLen*4-40 || outLen > inLen*4+40 {
+		t.Errorf("unexpected output size %d for input %d", outLen, inLen)
+	}
+}
+
+// TestConvertDump converts a stream dump made of the corpus with 33-byte headers.
+func TestConvertDump(t *testing.T) {
+	ulaw, err := corpus.ReadFile(corpus.Speech8kUlaw)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	var dump []byte
+	for pos := 0; pos+telephony.FrameBytes <= len(ulaw); pos += telephony.FrameBytes {
+		dump = append(dump, make([]byte, telephony.DumpHeaderBytes)...)
+		dump = append(dump, ulaw[pos:pos+telephony.FrameBytes]...)
+	}
+	in := filepath.Join(t.TempDir(), "dump.bin")
+	if err := os.WriteFile(in, dump, 0644); err != nil {
+		t.Fatal(err)
+	}
+	frames := len(dump) / telephony.DumpFrameBytes
+	for headers, want := range map[string]int{"strip": frames * 640, "keep": frames * (33 + 640)} {
+		_, outLen, err := convert(in, filepath.Join(t.TempDir(), "out.raw"), headers)
+		if err != nil {
+			t.Fatalf("-headers %s: convert failed: %v", headers, err)
+		}
+		if outLen < want-40 || outLen > want+40 {
+			t.Errorf("-headers %s: got %d bytes, want about %d", headers, outLen, want)
+		}
+	}
+	if _, _, err := convert(in, filepath.Join(t.TempDir(), "out.raw"), "bogus"); err == nil {
+		t.Error("unknown -headers accepted")
+	}
+}
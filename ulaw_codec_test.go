@@ -0,0 +1,101 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"math"
+	"testing"
+)
+
+// TestUlawRoundTripAllInt16 checks, for every int16 value, that decoding the
+// encoded value lands within half a quantization step of the segment it was coded
+// in, keeps the sign, and never decreases as the input grows.
+func TestUlawRoundTripAllInt16(t *testing.T) {
+	prev := math.MinInt32
+	for x := math.MinInt16; x <= math.MaxInt16; x++ {
+		code := linearToUlawGo(int16(x))
+		y := int(ulawToLinearGo(code))
+		exponent := uint((^code >> 4) & 0x07)
+
+		if mag := math.Abs(float64(x)); mag <= 32635 { // Below the G.711 clip level
+			if d := math.Abs(float64(x - y)); d > float64(int(4)<<exponent) {
+				t.Fatalf("%d -> 0x%02X -> %d: error %g above half the step of segment %d", x, code, y, d, exponent)
+			}
+		} else if y != 32124 && y != -32124 {
+			t.Fatalf("%d -> 0x%02X -> %d: want the clipped level ±32124", x, code, y)
+		}
+		if (x < 0 && y > 0) || (x > 0 && y < 0) {
+			t.Fatalf("%d -> 0x%02X -> %d: sign flipped", x, code, y)
+		}
+		if y < prev {
+			t.Fatalf("%d -> %d decodes below %d, the decoded value of %d", x, y, prev, x-1)
+		}
+		prev = y
+	}
+}
+
+// TestUlawCodesRoundTrip checks every code survives decode and re-encode, with
+// both zero codes going to 0xFF, and that all decoders agree.
+func TestUlawCodesRoundTrip(t *testing.T) {
+	floats := make([]float32, 1)
+	for c := 0; c < 256; c++ {
+		code := byte(c)
+		v := ulawToLinearGo(code)
+		want := code
+		if code == 0x7F { // -0
+			want = UlawSilence
+		}
+		if got := linearToUlawGo(v); got != want {
+			t.Errorf("0x%02X -> %d -> 0x%02X, want 0x%02X", code, v, got, want)
+		}
+		if v2 := ulawToLinearInt16Go(code); v2 != v {
+			t.Errorf("0x%02X decodes to %d and %d", code, v, v2)
+		}
+		UlawToFloatArray([]byte{code}, floats)
+		if floats[0] != s16ToFloatGo(v) {
+			t.Errorf("UlawToFloatArray(0x%02X) = %g, want %g", code, floats[0], s16ToFloatGo(v))
+		}
+	}
+}
+
+// TestUlawReferenceVectors checks the codec against values of the G.711 u-law
+// tables (16-bit linear scale: the 14-bit table values times 4).
+func TestUlawReferenceVectors(t *testing.T) {
+	decode := []struct {
+		code byte
+		want int16
+	}{
+		{0xFF, 0}, {0x7F, 0}, // +0 and -0
+		{0xFE, 8}, {0x7E, -8}, // Segment 0, step 8
+		{0xF0, 120}, {0x70, -120}, // Top of segment 0
+		{0xEF, 132}, {0x6F, -132}, // Bottom of segment 1, step 16
+		{0xDF, 396}, {0xCF, 924}, {0xBF, 1980}, {0xAF, 4092}, {0x9F, 8316}, {0x8F, 16764},
+		{0x80, 32124}, {0x00, -32124}, // Full scale
+	}
+	for _, v := range decode {
+		if got := ulawToLinearGo(v.code); got != v.want {
+			t.Errorf("decode 0x%02X = %d, want %d", v.code, got, v.want)
+		}
+	}
+
+	encode := []struct {
+		pcm  int16
+		want byte
+	}{
+		{0, 0xFF}, {3, 0xFF}, {4, 0xFE}, {-4, 0x7E}, {-3, 0x7F},
+		{123, 0xF0}, {124, 0xEF}, {-124, 0x6F}, // Decision level between segments 0 and 1
+		{8316, 0x9F}, {16764, 0x8F},
+		{32124, 0x80}, {32767, 0x80}, {-32124, 0x00}, {-32768, 0x00},
+	}
+	for _, v := range encode {
+		if got := linearToUlawGo(v.pcm); got != v.want {
+			t.Errorf("encode %d = 0x%02X, want 0x%02X", v.pcm, got, v.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import "sync"
+
+// ulawDecodeTable holds ulawToLinearGo of every u-law byte.
+var ulawDecodeTable = func() (table [256]int16) {
+	for i := range table {
+		table[i] = ulawToLinearGo(byte(i))
+	}
+	return table
+}()
+
+// ulawEncodeTable holds linearToUlawGo of every int16, indexed by uint16(v). It
+// takes 64KB, so it is built on first use.
+var (
+	ulawEncodeTable     *[65536]byte
+	ulawEncodeTableOnce sync.Once
+)
+
+// ulawEncoder returns ulawEncodeTable, building it if needed.
+func ulawEncoder() *[65536]byte {
+	ulawEncodeTableOnce.Do(func() {
+		table := new([65536]byte)
+		for i := range table {
+			table[i] = linearToUlawGo(int16(uint16(i)))
+		}
+		ulawEncodeTable = table
+	})
+	return ulawEncodeTable
+}
+
+// UlawToShortArray decodes a slice of u-law bytes to 16-bit linear PCM, with a
+// lookup table. It decodes min(len(in), len(out)) samples.
+func UlawToShortArray(in []byte, out []int16) {
+	count := minInt(len(in), len(out))
+	out = out[:count]
+	for i, b := range in[:count] {
+		out[i] = ulawDecodeTable[b]
+	}
+}
+
+// mixUlawLoop is the plain 8kHz u-law mix, fused into one loop: it mixes stream1
+// with stream2, looped from the sample after lastPos, into dst. It returns the next
+// stream2 position, as MixUlaw8kHz stores it.
+func mixUlawLoop(dst, stream1, stream2 []byte, lastPos int, mixFactor float32) int {
+	enc := ulawEncoder()
+	dst = dst[:len(stream1)]
+	i2 := lastPos + 1
+	if i2 < 0 || i2 >= len(stream2) {
+		i2 = 0
+	}
+	for i, b := range stream1 {
+		var background float32
+		if len(stream2) > 0 {
+			background = float32(ulawDecodeTable[stream2[i2]])
+			if i2++; i2 >= len(stream2) {
+				i2 = 0
+			}
+		}
+		// The arithmetic of mixUlawBlock, so both give the same bytes
+		mixed := float32(ulawDecodeTable[b])*mixFactor + background*mixFactor
+		if mixed > 32767.0 {
+			mixed = 32767.0
+		} else if mixed < -32768.0 {
+			mixed = -32768.0
+		}
+		dst[i] = enc[uint16(int16(mixed))]
+	}
+	return i2
+}
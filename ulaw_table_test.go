@@ -0,0 +1,101 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUlawTables(t *testing.T) {
+	in := make([]byte, 256)
+	for i := range in {
+		in[i] = byte(i)
+	}
+	out := make([]int16, 256)
+	UlawToShortArray(in, out)
+	for i, v := range out {
+		if want := ulawToLinearInt16Go(byte(i)); v != want {
+			t.Fatalf("u-law %#02x decoded to %d, want %d", i, v, want)
+		}
+	}
+	enc := ulawEncoder()
+	for v := -32768; v <= 32767; v++ {
+		if got, want := enc[uint16(int16(v))], linearToUlawGo(int16(v)); got != want {
+			t.Fatalf("%d encoded to %#02x, want %#02x", v, got, want)
+		}
+	}
+}
+
+// plainMixUlaw mixes through mixUlawBlock, with stream 2 decoded sample by sample,
+// for comparison with the fused loop.
+func plainMixUlaw(stream1, stream2 []byte, pos *int, mixFactor float32) []byte {
+	background := make([]float32, len(stream1))
+	i2 := *pos + 1
+	if i2 < 0 || i2 >= len(stream2) {
+		i2 = 0
+	}
+	for i := range background {
+		if len(stream2) > 0 {
+			background[i] = float32(ulawToLinearGo(stream2[i2]))
+			i2 = (i2 + 1) % len(stream2)
+		}
+	}
+	*pos = i2
+	return mixUlawBlock(stream1, background, mixFactor)
+}
+
+// TestMixUlaw8kHzFused checks the fused loop of MixUlaw8kHz against the general
+// path.
+func TestMixUlaw8kHzFused(t *testing.T) {
+	voice := encodeUlawTone(800, 300, 0.8)
+	music := encodeUlawTone(333, 1000, 0.7) // Shorter than a block: loops within it
+
+	for _, tt := range []struct {
+		name   string
+		music  []byte
+		factor float32
+	}{
+		{"default", music, 0.5},
+		{"hot", music, 1.0}, // Clips
+		{"no background", nil, 0.5},
+	} {
+		posWant, posMix := -1, -1
+		for call := 0; call < 3; call++ {
+			want := plainMixUlaw(voice, tt.music, &posWant, tt.factor)
+			got, err := MixUlaw8kHz(voice, tt.music, &posMix, tt.factor)
+			if err != nil {
+				t.Fatalf("%s: MixUlaw8kHz failed: %v", tt.name, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%s, call %d: fused mix differs from the general path", tt.name, call)
+			}
+			if posMix != posWant {
+				t.Fatalf("%s, call %d: position %d, want %d", tt.name, call, posMix, posWant)
+			}
+		}
+	}
+}
+
+func BenchmarkMixUlaw8kHz(b *testing.B) {
+	voice := encodeUlawTone(160, 300, 0.5)
+	music := encodeUlawTone(8000, 1000, 0.5)
+	b.Run("general", func(b *testing.B) {
+		pos := 0
+		for i := 0; i < b.N; i++ {
+			plainMixUlaw(voice, music, &pos, 0.5)
+		}
+	})
+	b.Run("fused", func(b *testing.B) {
+		pos := 0
+		for i := 0; i < b.N; i++ {
+			MixUlaw8kHz(voice, music, &pos, 0.5)
+		}
+	})
+}
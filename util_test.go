@@ -285,3 +285,26 @@ func reverseDataGo(data []float32) {
 		right--
 	}
 }
+
+// TestUlawFloatRoundTrip checks every u-law code survives decode and re-encode.
+func TestUlawFloatRoundTrip(t *testing.T) {
+	codes := make([]byte, 256)
+	for k := range codes {
+		codes[k] = byte(k)
+	}
+	temp := make([]float32, len(codes))
+	output := make([]byte, len(codes))
+
+	UlawToFloatArray(codes, temp)
+	FloatToUlawArray(temp, output)
+
+	for k := range codes {
+		want := codes[k]
+		if want == 0x7F { // Both zero codes encode back to 0xFF, the G.711 +0
+			want = 0xFF
+		}
+		if output[k] != want {
+			t.Errorf("code 0x%02X -> %.8f -> 0x%02X", codes[k], temp[k], output[k])
+		}
+	}
+}
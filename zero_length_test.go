@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2025, Antonio Chirizzi <antonio.chirizzi@gmail.com>
+// All rights reserved.
+//
+// This code is released under 3-clause BSD license. Please see the
+// file LICENSE
+//
+
+package libsamplerate
+
+import (
+	"fmt"
+	"testing"
+)
+
+// idleBlock is a Process block without input, as fed when upstream has no data
+// yet. The variants check that DataIn is ignored when InputFrames is 0.
+func idleBlock(variant int, out []float32, frames int64, ratio float64) SrcData {
+	data := SrcData{DataOut: out, OutputFrames: frames, SrcRatio: ratio}
+	switch variant % 3 {
+	case 1:
+		data.DataIn = []float32{}
+	case 2:
+		data.DataIn = []float32{0.9, -0.9, 0.9, -0.9} // Stale data, must not be read
+	}
+	return data
+}
+
+// streamWithIdle feeds in to conv in blocks of block frames and drains it at the
+// end, with idle blocks before every block when idle is set. Each Process call
+// gets room for at most room output frames; output that did not fit is drained
+// with idle blocks when idle is set, or by feeding the rest of the block again.
+func streamWithIdle(t *testing.T, conv Converter, in []float32, inChannels, outChannels, block int, room int64, ratio float64, idle bool) []float32 {
+	t.Helper()
+	var result []float32
+	buf := make([]float32, int(room)*outChannels)
+	process := func(data *SrcData) {
+		t.Helper()
+		if err := conv.Process(data); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		result = append(result, buf[:data.OutputFramesGen*int64(outChannels)]...)
+	}
+	variant := 0
+	drainIdle := func() {
+		for {
+			data := idleBlock(variant, buf, room, ratio)
+			variant++
+			process(&data)
+			if data.InputFramesUsed != 0 {
+				t.Fatalf("idle block consumed %d frames", data.InputFramesUsed)
+			}
+			if data.OutputFramesGen == 0 {
+				return
+			}
+		}
+	}
+
+	frames := len(in) / inChannels
+	for pos := 0; pos < frames; {
+		if idle {
+			drainIdle()
+		}
+		end := minInt(pos+block, frames)
+		data := SrcData{DataIn: in[pos*inChannels : end*inChannels], InputFrames: int64(end - pos), DataOut: buf, OutputFrames: room, SrcRatio: ratio}
+		process(&data)
+		pos += int(data.InputFramesUsed)
+	}
+	for {
+		data := SrcData{DataOut: buf, OutputFrames: room, SrcRatio: ratio, EndOfInput: true}
+		process(&data)
+		if data.OutputFramesGen == 0 {
+			return result
+		}
+	}
+}
+
+func TestZeroLengthBlocks(t *testing.T) {
+	types := []ConverterType{SincBestQuality, SincMediumQuality, SincFastest, ZeroOrderHold, Linear}
+	for _, ct := range types {
+		for _, channels := range []int{1, 2, 3, 7} {
+			for _, ratio := range []float64{0.5, 1, 1.7} {
+				name := fmt.Sprintf("%v/%dch/%g", ct, channels, ratio)
+				in := make([]float32, 2000*channels)
+				sine := genSine(2000, 440, 8000, 0.5)
+				for i := range in {
+					in[i] = sine[i/channels] * float32(1+i%channels) / float32(channels)
+				}
+				run := func(room int64, idle bool) []float32 {
+					conv, err := New(ct, channels)
+					if err != nil {
+						t.Fatalf("%s: New failed: %v", name, err)
+					}
+					defer conv.Close()
+					return streamWithIdle(t, conv, in, channels, channels, 97, room, ratio, idle)
+				}
+				want := run(4096, false)
+				checkSameSamples(t, name+" with idle blocks", run(4096, true), want)
+				// With a small output the idle blocks drain what the converter holds
+				checkSameSamples(t, name+" draining with idle blocks", run(13, true), want)
+			}
+		}
+	}
+}
+
+func TestZeroLengthBlocksGroupsAndMapper(t *testing.T) {
+	cases := []struct {
+		name        string
+		channels    int
+		opts        Options
+		outChannels int
+	}{
+		{"groups", 2 * maxChannels, Options{}, 2 * maxChannels},
+		{"downmix", 2, Options{OutputChannels: 1}, 1},
+		{"upmix", 1, Options{OutputChannels: 2}, 2},
+	}
+	for _, tc := range cases {
+		in := make([]float32, 600*tc.channels)
+		sine := genSine(600, 300, 8000, 0.5)
+		for i := range in {
+			in[i] = sine[i/tc.channels]
+		}
+		run := func(room int64, idle bool) []float32 {
+			conv, err := NewWithOptions(SincFastest, tc.channels, tc.opts)
+			if err != nil {
+				t.Fatalf("%s: NewWithOptions failed: %v", tc.name, err)
+			}
+			defer conv.Close()
+			return streamWithIdle(t, conv, in, tc.channels, tc.outChannels, 64, room, 1.5, idle)
+		}
+		want := run(2048, false)
+		checkSameSamples(t, tc.name+" with idle blocks", run(2048, true), want)
+		checkSameSamples(t, tc.name+" draining with idle blocks", run(11, true), want)
+	}
+}
+
+// An idle block before the first input must not seed the ratio or give up the
+// ratio 1.0 passthrough, whatever ratio it carries.
+func TestZeroLengthFirstBlock(t *testing.T) {
+	in := genSine(1000, 440, 8000, 0.5)
+	for _, ct := range []ConverterType{SincFastest, Linear, ZeroOrderHold} {
+		for _, ratio := range []float64{1, 2} {
+			fresh, err := New(ct, 1)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			want := streamWithIdle(t, fresh, in, 1, 1, 100, 4096, ratio, false)
+			fresh.Close()
+
+			conv, err := New(ct, 1)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			out := make([]float32, 64)
+			idle := SrcData{DataOut: out, OutputFrames: 64, SrcRatio: 3 - ratio}
+			if err := conv.Process(&idle); err != nil || idle.OutputFramesGen != 0 {
+				t.Fatalf("%v: idle first block gave %d frames, %v", ct, idle.OutputFramesGen, err)
+			}
+			got := streamWithIdle(t, conv, in, 1, 1, 100, 4096, ratio, false)
+			checkSameSamples(t, fmt.Sprintf("%v at %g after an idle block at %g", ct, ratio, 3-ratio), got, want)
+			if ratio == 1 && ct != Linear && ct != ZeroOrderHold {
+				checkSameSamples(t, "passthrough", got, in)
+			}
+			if s := conv.(StatsReporter).Stats(); s.ProcessCalls == 0 || s.InputFrames != int64(len(in)) {
+				t.Errorf("%v: stats %+v after the stream", ct, s)
+			}
+			conv.Close()
+		}
+	}
+}
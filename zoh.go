@@ -232,7 +232,7 @@ func zohVariProcess(state *srcState, data *SrcData) ErrorCode {
 		outGenSamples += int64(channels)
 
 		// Figure out the next index.
-		inputIndex += 1.0 / srcRatio
+		inputIndex = state.advanceInput(inputIndex, srcRatio)
 	}
 
 	// --- Main Processing Loop ---
@@ -240,49 +240,19 @@ func zohVariProcess(state *srcState, data *SrcData) ErrorCode {
 	inUsedSamples += initialFramesSkipped * int64(channels)
 	inputIndex = fmodOne(inputIndex)
 
-	// C: while (priv->out_gen < priv->out_count && priv->in_used + state->channels * input_index <= priv->in_count) { ... }
-	// Loop while space in output AND the *previous* frame's data is available
+	// Loop while there is space in the output and the frame after the held one is
+	// in this block, as in linearVariProcess. The last frame of the block is held
+	// from the next block on (by the loop above), so the stream ends after the
+	// same number of frames as with Linear instead of holding the last input
+	// frame for one more input period (about ratio extra frames).
 	for outGenSamples < outCountSamples {
-		// Determine index for the *previous* input frame (the one to hold)
-		// This requires inUsedSamples >= channels
+		// The held frame is the one before inUsedSamples
 		y0BaseIndex := inUsedSamples - int64(channels)
-
-		// Check if we have data for the frame *before* the one `inUsedSamples` points to.
 		if y0BaseIndex < 0 {
-			// Should only happen if initialFramesSkipped was 0, handled by first loop?
 			break // Cannot access frame before the first one
 		}
-		// Also check if this required frame is within the current input block bounds
-		if y0BaseIndex+int64(channels) > inCountSamples {
-			// We need frame `y0`, but it's beyond the provided input.
-			// This differs slightly from linear which needed `y1`. ZOH only needs `y0`.
-			// Let's re-evaluate the C loop condition:
-			// `priv->in_used + state->channels * input_index <= priv->in_count`
-			// This seems to check if the *current* fractional position (`input_index`)
-			// projected onto the input buffer (`priv->in_used + ...`) is within bounds.
-			// This doesn't guarantee the *previous* frame needed (`y0BaseIndex`) is available.
-			// Let's stick to the explicit check: Do we have the frame at `y0BaseIndex`?
-			// If `y0BaseIndex` itself is valid (>=0), we can access it *if* it's
-			// within the current `inputData` slice length (relative to start).
-			// The effective length is `inCountSamples`.
-			// So the condition is just `y0BaseIndex >= 0`. If it is, we use that frame.
-			// The loop should terminate if `inUsedSamples` advances such that `y0BaseIndex`
-			// would require data not yet available.
-			// Let's adjust the loop condition to be simply `inUsedSamples >= int64(channels)` ?
-			// No, the C loop runs while the *next read position* is valid.
-			// Let's try the C condition more directly:
-			// `current_read_pos = y0BaseIndex + inputIndex * channels` ??? Doesn't make sense.
-			// Let's use: Loop while the *required previous frame* `y0BaseIndex` is available
-			// within the current block `inCountSamples`.
-			if y0BaseIndex+int64(channels) > inCountSamples {
-				break // Required previous frame is beyond available input
-			}
-			// And also the check from linear: Is the *current* frame available?
-			// This prevents reading past the end when calculating the *next* index advance.
-			if inUsedSamples+int64(channels) > inCountSamples {
-				break // Cannot calculate next step if current frame isn't fully available
-			}
-
+		if inUsedSamples+int64(channels) > inCountSamples {
+			break // Next frame not in this block yet
 		}
 
 		// Interpolate ratio if needed
@@ -316,7 +286,7 @@ func zohVariProcess(state *srcState, data *SrcData) ErrorCode {
 		outGenSamples += int64(channels)
 
 		// Figure out the next index.
-		inputIndex += 1.0 / srcRatio
+		inputIndex = state.advanceInput(inputIndex, srcRatio)
 		intInputAdvance := psfLrint(inputIndex - fmodOne(inputIndex))
 		inUsedSamples += int64(intInputAdvance) * int64(channels)
 		inputIndex = fmodOne(inputIndex)